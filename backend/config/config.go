@@ -36,9 +36,25 @@ type Config struct {
 		ReminderBeforeExpiredDuration     time.Duration `mapstructure:"REMINDER_BEFORE_EXPIRED_DURATION"`
 		ReminderBeforeBookingDateDuration time.Duration `mapstructure:"REMINDER_BEFORE_BOOKING_DATE_DURATION"`
 		CreateReviewDuration              time.Duration `mapstructure:"CREATE_REVIEW_DURATION"`
+		ResponseTimeWindow                time.Duration `mapstructure:"RESPONSE_TIME_WINDOW"`
+		CheckInBefore                     time.Duration `mapstructure:"CHECK_IN_BEFORE"`
+		NoShowAfter                       time.Duration `mapstructure:"NO_SHOW_AFTER"`
+		NoShowDisputeWindow               time.Duration `mapstructure:"NO_SHOW_DISPUTE_WINDOW"`
+		AutoCompleteAfter                 time.Duration `mapstructure:"AUTO_COMPLETE_AFTER"`
+		// GroupSessionCutoff is how long before a group session starts it
+		// must have its minimum attendees, it is cancelled otherwise
+		GroupSessionCutoff time.Duration `mapstructure:"GROUP_SESSION_CUTOFF"`
+		// GeofenceRadius is the distance in meters from an offline lesson
+		// check-ins must be within, 0 skips the check
+		GeofenceRadius int `mapstructure:"GEOFENCE_RADIUS"`
 	} `mapstructure:"BOOKING"`
+	CourseModeration struct {
+		SLADuration        time.Duration `mapstructure:"SLA_DURATION"`
+		SLAWarningDuration time.Duration `mapstructure:"SLA_WARNING_DURATION"`
+	} `mapstructure:"COURSE_MODERATION"`
 	Review struct {
 		MaxEditedDuration time.Duration `mapstructure:"MAX_EDITED_DURATION"`
+		BlockedWords      []string      `mapstructure:"BLOCKED_WORDS"`
 	} `mapstructure:"REVIEW"`
 	DB struct {
 		Read struct {
@@ -59,6 +75,9 @@ type Config struct {
 			EnableMigration bool   `mapstructure:"ENABLE_MIGRATION"`
 		}
 	} `mapstructure:"DB"`
+	ExchangeRate struct {
+		BaseURL string `mapstructure:"BASE_URL"`
+	} `mapstructure:"EXCHANGE_RATE"`
 	Frontend struct {
 		BaseURL             string `mapstructure:"BASE_URL"`
 		AdminBaseURL        string `mapstructure:"ADMIN_BASE_URL"`
@@ -71,6 +90,11 @@ type Config struct {
 		ListCourse          string `mapstructure:"LIST_COURSE"`
 		SubscriptionSuccess string `mapstructure:"SUBSCRIPTION_SUCCESS"`
 		SubscriptionFailure string `mapstructure:"SUBSCRIPTION_FAILURE"`
+		GuardianConfirm     string `mapstructure:"GUARDIAN_CONFIRM"`
+		GuardianStudent     string `mapstructure:"GUARDIAN_STUDENT"`
+		StudentTasks        string `mapstructure:"STUDENT_TASKS"`
+		MentorTasks         string `mapstructure:"MENTOR_TASKS"`
+		LearningPlans       string `mapstructure:"LEARNING_PLANS"`
 	} `mapstructure:"FRONTEND"`
 	GoogleMaps struct {
 		ApiKey string `mapstructure:"API_KEY"`
@@ -86,6 +110,14 @@ type Config struct {
 		Tiktok     string `mapstructure:"TIKTOK"`
 		Zoom       string `mapstructure:"ZOOM"`
 	} `mapstructure:"IMAGE"`
+	Invoice struct {
+		Prefix        string `mapstructure:"PREFIX"`
+		SellerName    string `mapstructure:"SELLER_NAME"`
+		SellerTaxID   string `mapstructure:"SELLER_TAX_ID"`
+		SellerAddress string `mapstructure:"SELLER_ADDRESS"`
+		ServiceCode   string `mapstructure:"SERVICE_CODE"`
+		UnitCode      string `mapstructure:"UNIT_CODE"`
+	} `mapstructure:"INVOICE"`
 	JWT struct {
 		Key              string        `mapstructure:"KEY"`
 		ExpiresIn        time.Duration `mapstructure:"EXPIRES_IN"`
@@ -109,6 +141,32 @@ type Config struct {
 		Host     string `mapstructure:"HOST"`
 		Port     string `mapstructure:"PORT"`
 	} `mapstructure:"MAIL"`
+	Meeting struct {
+		// Provider is one of jitsi (the default), zoom, google_meet or fake
+		Provider string `mapstructure:"PROVIDER"`
+		// RoomLifetime is how long after the start of a session its room
+		// stays open
+		RoomLifetime time.Duration `mapstructure:"ROOM_LIFETIME"`
+		Jitsi        struct {
+			BaseURL   string `mapstructure:"BASE_URL"`
+			AppID     string `mapstructure:"APP_ID"`
+			AppSecret string `mapstructure:"APP_SECRET"`
+		} `mapstructure:"JITSI"`
+		Zoom struct {
+			BaseURL      string `mapstructure:"BASE_URL"`
+			AuthURL      string `mapstructure:"AUTH_URL"`
+			AccountID    string `mapstructure:"ACCOUNT_ID"`
+			ClientID     string `mapstructure:"CLIENT_ID"`
+			ClientSecret string `mapstructure:"CLIENT_SECRET"`
+		} `mapstructure:"ZOOM"`
+		GoogleMeet struct {
+			BaseURL      string `mapstructure:"BASE_URL"`
+			TokenURL     string `mapstructure:"TOKEN_URL"`
+			ClientID     string `mapstructure:"CLIENT_ID"`
+			ClientSecret string `mapstructure:"CLIENT_SECRET"`
+			RefreshToken string `mapstructure:"REFRESH_TOKEN"`
+		} `mapstructure:"GOOGLE_MEET"`
+	} `mapstructure:"MEETING"`
 	Resend struct {
 		ApiKey string `mapstructure:"API_KEY"`
 		From   string `mapstructure:"FROM"`
@@ -127,13 +185,20 @@ type Config struct {
 		MaxUploadSize int64 `mapstructure:"MAX_UPLOAD_SIZE"`
 	} `mapstructure:"FILE"`
 	Subscription struct {
-		AmountMonthly float64 `mapstructure:"AMOUNT_MONTHLY"`
-		AmountYearly  float64 `mapstructure:"AMOUNT_YEARLY"`
+		AmountMonthly       float64       `mapstructure:"AMOUNT_MONTHLY"`
+		AmountYearly        float64       `mapstructure:"AMOUNT_YEARLY"`
+		TrialDuration       time.Duration `mapstructure:"TRIAL_DURATION"`
+		ReminderBeforeEnd   time.Duration `mapstructure:"REMINDER_BEFORE_END"`
+		GracePeriod         time.Duration `mapstructure:"GRACE_PERIOD"`
+		TotalRetry          int           `mapstructure:"TOTAL_RETRY"`
+		RetryIntervalInDays int           `mapstructure:"RETRY_INTERVAL_IN_DAYS"`
 	} `mapstructure:"SUBSCRIPTION"`
 	Xendit struct {
-		BaseURL    string `mapstructure:"BASE_URL"`
-		SecretKey  string `mapstructure:"SECRET_KEY"`
-		WebhookKey string `mapstructure:"WEBHOOK_KEY"`
+		BaseURL          string `mapstructure:"BASE_URL"`
+		SecretKey        string `mapstructure:"SECRET_KEY"`
+		WebhookKey       string `mapstructure:"WEBHOOK_KEY"`
+		FakeRefund       bool   `mapstructure:"FAKE_REFUND"`
+		FakeDisbursement bool   `mapstructure:"FAKE_DISBURSEMENT"`
 	} `mapstructure:"XENDIT"`
 }
 
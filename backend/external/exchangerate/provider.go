@@ -0,0 +1,81 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"resty.dev/v3"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// Provider fetches the latest exchange rates. Rates are the IDR value of one
+// unit of each currency.
+type Provider interface {
+	Rates(ctx context.Context, currencies []string) (map[string]decimal.Decimal, error)
+}
+
+// NewProvider returns a Frankfurter client, or ManualProvider when
+// EXCHANGE_RATE.BASE_URL is empty so rates can only be uploaded by admins.
+func NewProvider(config *config.Config) Provider {
+	if config.ExchangeRate.BaseURL == "" {
+		return ManualProvider{}
+	}
+
+	return &Client{
+		rc: resty.New().SetBaseURL(config.ExchangeRate.BaseURL),
+	}
+}
+
+// ManualProvider refuses to fetch rates.
+type ManualProvider struct{}
+
+func (ManualProvider) Rates(context.Context, []string) (map[string]decimal.Decimal, error) {
+	return nil, errors.New("exchange rate provider is not configured")
+}
+
+type Client struct {
+	rc *resty.Client
+}
+
+type latestResponse struct {
+	Base  string                     `json:"base"`
+	Date  string                     `json:"date"`
+	Rates map[string]decimal.Decimal `json:"rates"`
+}
+
+func (c *Client) Rates(ctx context.Context, currencies []string) (map[string]decimal.Decimal, error) {
+	result := latestResponse{}
+	resp, err := c.rc.R().
+		SetContext(ctx).
+		SetResult(&result).
+		SetQueryParam("from", "IDR").
+		SetQueryParam("to", strings.Join(currencies, ",")).
+		Get("/latest")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Rates] Error calling API")
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Msg("[Rates] Error calling API")
+		return nil, errors.New("error fetching exchange rates")
+	}
+
+	// The API quotes how much of each currency one rupiah buys, so invert it.
+	rates := make(map[string]decimal.Decimal, len(result.Rates))
+	for currency, rate := range result.Rates {
+		if !rate.IsPositive() {
+			continue
+		}
+		rates[currency] = decimal.NewFromInt(1).DivRound(rate, 6)
+	}
+
+	return rates, nil
+}
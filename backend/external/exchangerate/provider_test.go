@@ -0,0 +1,85 @@
+package exchangerate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/config"
+)
+
+func TestClientRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "rates are inverted to rupiah per unit",
+			status: http.StatusOK,
+			body:   `{"base":"IDR","date":"2026-03-05","rates":{"USD":0.0000625,"JPY":0.0093}}`,
+			want:   map[string]string{"USD": "16000", "JPY": "107.526882"},
+		},
+		{
+			name:   "non-positive rates are skipped",
+			status: http.StatusOK,
+			body:   `{"base":"IDR","date":"2026-03-05","rates":{"USD":0.0000625,"SGD":0}}`,
+			want:   map[string]string{"USD": "16000"},
+		},
+		{
+			name:    "error status",
+			status:  http.StatusBadGateway,
+			body:    `{}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/latest" || r.URL.Query().Get("from") != "IDR" || r.URL.Query().Get("to") != "USD,SGD,JPY" {
+					t.Errorf("request = %s, want the latest IDR rates of the currencies", r.URL)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{}
+			cfg.ExchangeRate.BaseURL = server.URL
+
+			got, err := NewProvider(cfg).Rates(context.Background(), []string{"USD", "SGD", "JPY"})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Rates() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Rates() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Rates() = %v, want %v", got, tt.want)
+			}
+			for currency, want := range tt.want {
+				if !got[currency].Equal(decimal.RequireFromString(want)) {
+					t.Errorf("rate of %s = %s, want %s", currency, got[currency], want)
+				}
+			}
+		})
+	}
+}
+
+func TestManualProviderRefusesRates(t *testing.T) {
+	if _, err := NewProvider(&config.Config{}).Rates(context.Background(), []string{"USD"}); err == nil {
+		t.Error("Rates() error = nil, want error without a provider")
+	}
+}
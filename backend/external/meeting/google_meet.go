@@ -0,0 +1,148 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// GoogleMeet creates meeting spaces with the Meet REST API on behalf of the
+// platform's Google account, authorised once through its refresh token.
+type GoogleMeet struct {
+	rc           *resty.Client
+	auth         *resty.Client
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	refreshToken string
+	token        accessToken
+}
+
+func NewGoogleMeet(config *config.Config) *GoogleMeet {
+	return &GoogleMeet{
+		rc:           resty.New().SetBaseURL(config.Meeting.GoogleMeet.BaseURL),
+		auth:         resty.New(),
+		tokenURL:     config.Meeting.GoogleMeet.TokenURL,
+		clientID:     config.Meeting.GoogleMeet.ClientID,
+		clientSecret: config.Meeting.GoogleMeet.ClientSecret,
+		refreshToken: config.Meeting.GoogleMeet.RefreshToken,
+	}
+}
+
+type googleTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type googleSpaceConfig struct {
+	AccessType string `json:"accessType"`
+}
+
+type googleSpaceRequest struct {
+	Config googleSpaceConfig `json:"config"`
+}
+
+type googleSpaceResponse struct {
+	Name       string `json:"name"`
+	MeetingURI string `json:"meetingUri"`
+}
+
+func (g *GoogleMeet) Name() string {
+	return ProviderGoogleMeet
+}
+
+func (g *GoogleMeet) CreateRoom(ctx context.Context, _ CreateRoomRequest) (Room, error) {
+	token, err := g.token.get(ctx, g.fetchToken)
+	if err != nil {
+		return Room{}, err
+	}
+
+	result := googleSpaceResponse{}
+	resp, err := g.rc.R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetResult(&result).
+		SetBody(googleSpaceRequest{
+			Config: googleSpaceConfig{AccessType: "OPEN"},
+		}).
+		Post("/v2/spaces")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GoogleMeet.CreateRoom] Error calling API")
+		return Room{}, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Msg("[GoogleMeet.CreateRoom] Error calling API")
+		return Room{}, errors.New("error creating google meet space")
+	}
+
+	return Room{
+		ID:      result.Name,
+		JoinURL: result.MeetingURI,
+	}, nil
+}
+
+// ExpireRoom ends the conference running in the space, Meet spaces cannot be
+// deleted. A space without a running conference answers with an error which
+// means there is nothing left to end.
+func (g *GoogleMeet) ExpireRoom(ctx context.Context, roomID string) error {
+	token, err := g.token.get(ctx, g.fetchToken)
+	if err != nil {
+		return err
+	}
+
+	resp, err := g.rc.R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetBody(map[string]any{}).
+		Post("/v2/" + roomID + ":endActiveConference")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GoogleMeet.ExpireRoom] Error calling API")
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK, http.StatusBadRequest, http.StatusNotFound:
+		return nil
+	default:
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Msg("[GoogleMeet.ExpireRoom] Error calling API")
+		return errors.New("error ending google meet conference")
+	}
+}
+
+func (g *GoogleMeet) fetchToken(ctx context.Context) (string, time.Duration, error) {
+	result := googleTokenResponse{}
+	resp, err := g.auth.R().
+		SetContext(ctx).
+		SetResult(&result).
+		SetFormData(map[string]string{
+			"client_id":     g.clientID,
+			"client_secret": g.clientSecret,
+			"refresh_token": g.refreshToken,
+			"grant_type":    "refresh_token",
+		}).
+		Post(g.tokenURL)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GoogleMeet.fetchToken] Error calling API")
+		return "", 0, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Msg("[GoogleMeet.fetchToken] Error calling API")
+		return "", 0, errors.New("error getting google access token")
+	}
+
+	return result.AccessToken, time.Duration(result.ExpiresIn) * time.Second, nil
+}
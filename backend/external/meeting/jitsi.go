@@ -0,0 +1,83 @@
+package meeting
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/lesprivate/backend/config"
+)
+
+// Jitsi generates room URLs on a self-hosted Jitsi Meet, rooms exist as soon
+// as someone joins them. With an app secret the URL carries a token which
+// only admits participants to that room until it expires, without one rooms
+// are only protected by their unguessable name.
+type Jitsi struct {
+	baseURL   string
+	appID     string
+	appSecret string
+}
+
+func NewJitsi(config *config.Config) *Jitsi {
+	return &Jitsi{
+		baseURL:   strings.TrimRight(config.Meeting.Jitsi.BaseURL, "/"),
+		appID:     config.Meeting.Jitsi.AppID,
+		appSecret: config.Meeting.Jitsi.AppSecret,
+	}
+}
+
+func (j *Jitsi) Name() string {
+	return ProviderJitsi
+}
+
+func (j *Jitsi) CreateRoom(_ context.Context, request CreateRoomRequest) (Room, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return Room{}, err
+	}
+
+	room := fmt.Sprintf("%s-%s", strings.ToLower(request.Reference), hex.EncodeToString(suffix))
+	joinURL := j.baseURL + "/" + room
+
+	if j.appSecret != "" {
+		token, err := j.token(room, request.ExpiresAt)
+		if err != nil {
+			return Room{}, err
+		}
+		joinURL += "?jwt=" + url.QueryEscape(token)
+	}
+
+	return Room{
+		ID:      room,
+		JoinURL: joinURL,
+	}, nil
+}
+
+// ExpireRoom has nothing to call, the token in the URL stops admitting
+// participants once it expires.
+func (j *Jitsi) ExpireRoom(context.Context, string) error {
+	return nil
+}
+
+func (j *Jitsi) token(room string, expiresAt time.Time) (string, error) {
+	domain := j.baseURL
+	if u, err := url.Parse(j.baseURL); err == nil && u.Host != "" {
+		domain = u.Host
+	}
+
+	claims := jwt.MapClaims{
+		"aud":  "jitsi",
+		"iss":  j.appID,
+		"sub":  domain,
+		"room": room,
+		"exp":  expiresAt.Unix(),
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.appSecret))
+}
@@ -0,0 +1,82 @@
+package meeting
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lesprivate/backend/config"
+)
+
+const (
+	ProviderJitsi      = "jitsi"
+	ProviderZoom       = "zoom"
+	ProviderGoogleMeet = "google_meet"
+	ProviderFake       = "fake"
+)
+
+// CreateRoomRequest describes the session a room is created for. Reference is
+// unique to the session and ends up in the room name where the provider lets
+// us choose it.
+type CreateRoomRequest struct {
+	Reference string
+	Topic     string
+	StartsAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Room is a video classroom participants join through JoinURL.
+type Room struct {
+	ID      string
+	JoinURL string
+}
+
+// Provider creates and expires video classrooms.
+type Provider interface {
+	Name() string
+	CreateRoom(ctx context.Context, request CreateRoomRequest) (Room, error)
+	// ExpireRoom closes the room so it can no longer be joined. Rooms which
+	// are gone already are not an error.
+	ExpireRoom(ctx context.Context, roomID string) error
+}
+
+// NewProvider returns the provider chosen by MEETING.PROVIDER, rooms on the
+// self-hosted Jitsi unless another one is set.
+func NewProvider(config *config.Config) Provider {
+	switch config.Meeting.Provider {
+	case ProviderZoom:
+		return NewZoom(config)
+	case ProviderGoogleMeet:
+		return NewGoogleMeet(config)
+	case ProviderFake:
+		return FakeProvider{}
+	default:
+		return NewJitsi(config)
+	}
+}
+
+// accessToken caches the OAuth token of a provider until shortly before it
+// expires.
+type accessToken struct {
+	mu        sync.Mutex
+	value     string
+	expiresAt time.Time
+}
+
+func (t *accessToken) get(ctx context.Context, fetch func(ctx context.Context) (string, time.Duration, error)) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.value != "" && time.Now().Before(t.expiresAt) {
+		return t.value, nil
+	}
+
+	value, expiresIn, err := fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	t.value = value
+	t.expiresAt = time.Now().Add(expiresIn - time.Minute)
+	return t.value, nil
+}
@@ -0,0 +1,230 @@
+package meeting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/lesprivate/backend/config"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     string
+	}{
+		{provider: "", want: ProviderJitsi},
+		{provider: ProviderJitsi, want: ProviderJitsi},
+		{provider: ProviderZoom, want: ProviderZoom},
+		{provider: ProviderGoogleMeet, want: ProviderGoogleMeet},
+		{provider: ProviderFake, want: ProviderFake},
+	}
+
+	for _, tt := range tests {
+		cfg := &config.Config{}
+		cfg.Meeting.Provider = tt.provider
+		if got := NewProvider(cfg).Name(); got != tt.want {
+			t.Errorf("NewProvider(%q).Name() = %s, want %s", tt.provider, got, tt.want)
+		}
+	}
+}
+
+func TestJitsiCreateRoom(t *testing.T) {
+	request := CreateRoomRequest{
+		Reference: "BK-ABC123",
+		StartsAt:  time.Now(),
+		ExpiresAt: time.Now().Add(2 * time.Hour),
+	}
+
+	cfg := &config.Config{}
+	cfg.Meeting.Jitsi.BaseURL = "https://meet.lesprivate.id/"
+
+	room, err := NewJitsi(cfg).CreateRoom(context.Background(), request)
+	if err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+	if !strings.HasPrefix(room.ID, "bk-abc123-") || len(room.ID) != len("bk-abc123-")+16 {
+		t.Errorf("CreateRoom().ID = %s, want the reference with a random suffix", room.ID)
+	}
+	if room.JoinURL != "https://meet.lesprivate.id/"+room.ID {
+		t.Errorf("CreateRoom().JoinURL = %s, want the room on the base URL without a token", room.JoinURL)
+	}
+
+	other, _ := NewJitsi(cfg).CreateRoom(context.Background(), request)
+	if other.ID == room.ID {
+		t.Errorf("CreateRoom().ID = %s twice, want unguessable room names", room.ID)
+	}
+
+	cfg.Meeting.Jitsi.AppID = "lesprivate"
+	cfg.Meeting.Jitsi.AppSecret = "secret"
+	room, err = NewJitsi(cfg).CreateRoom(context.Background(), request)
+	if err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+
+	joinURL, token, ok := strings.Cut(room.JoinURL, "?jwt=")
+	if !ok || joinURL != "https://meet.lesprivate.id/"+room.ID {
+		t.Fatalf("CreateRoom().JoinURL = %s, want the room with a token", room.JoinURL)
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) { return []byte("secret"), nil })
+	if err != nil {
+		t.Fatalf("token error = %v", err)
+	}
+	if claims["room"] != room.ID || claims["iss"] != "lesprivate" || claims["sub"] != "meet.lesprivate.id" {
+		t.Errorf("token claims = %v, want the room of the app on the domain", claims)
+	}
+	if exp, _ := claims.GetExpirationTime(); exp == nil || exp.Unix() != request.ExpiresAt.Unix() {
+		t.Errorf("token expiry = %v, want %v", exp, request.ExpiresAt)
+	}
+}
+
+func TestAccessTokenGet(t *testing.T) {
+	var token accessToken
+	calls := 0
+	fetch := func(context.Context) (string, time.Duration, error) {
+		calls++
+		return "token", time.Hour, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := token.get(context.Background(), fetch)
+		if err != nil || got != "token" {
+			t.Fatalf("get() = (%s, %v), want the token", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want the token cached", calls)
+	}
+
+	// Tokens about to expire are fetched again
+	token.expiresAt = time.Now().Add(-time.Second)
+	_, _ = token.get(context.Background(), fetch)
+	if calls != 2 {
+		t.Errorf("fetch called %d times, want the expired token fetched again", calls)
+	}
+
+	failing := accessToken{}
+	if _, err := failing.get(context.Background(), func(context.Context) (string, time.Duration, error) {
+		return "", 0, errors.New("unauthorized")
+	}); err == nil || failing.value != "" {
+		t.Errorf("get() error = %v, want the error and nothing cached", err)
+	}
+}
+
+func TestZoom(t *testing.T) {
+	deleteStatus := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/oauth/token":
+			if r.URL.Query().Get("grant_type") != "account_credentials" || r.URL.Query().Get("account_id") != "account" {
+				t.Errorf("token request = %s, want the account credentials grant", r.URL)
+			}
+			if user, pass, _ := r.BasicAuth(); user != "client" || pass != "secret" {
+				t.Errorf("token request auth = %s:%s, want the client credentials", user, pass)
+			}
+			_, _ = w.Write([]byte(`{"access_token":"zoom-token","expires_in":3600}`))
+		case r.URL.Path == "/users/me/meetings" && r.Method == http.MethodPost:
+			if r.Header.Get("Authorization") != "Bearer zoom-token" {
+				t.Errorf("Authorization = %s, want the access token", r.Header.Get("Authorization"))
+			}
+			body := zoomMeetingRequest{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Topic != "Kalkulus" || body.Duration != 120 || body.StartTime != "2026-03-10T03:00:00Z" {
+				t.Errorf("meeting request = %+v, want the 2 hour session in UTC", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":81234567890,"join_url":"https://zoom.us/j/81234567890"}`))
+		case r.URL.Path == "/meetings/81234567890" && r.Method == http.MethodDelete:
+			w.WriteHeader(deleteStatus)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Meeting.Zoom.BaseURL = server.URL
+	cfg.Meeting.Zoom.AuthURL = server.URL
+	cfg.Meeting.Zoom.AccountID = "account"
+	cfg.Meeting.Zoom.ClientID = "client"
+	cfg.Meeting.Zoom.ClientSecret = "secret"
+	zoom := NewZoom(cfg)
+
+	startsAt := time.Date(2026, 3, 10, 10, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	room, err := zoom.CreateRoom(context.Background(), CreateRoomRequest{Topic: "Kalkulus", StartsAt: startsAt, ExpiresAt: startsAt.Add(2 * time.Hour)})
+	if err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+	if room.ID != "81234567890" || room.JoinURL != "https://zoom.us/j/81234567890" {
+		t.Errorf("CreateRoom() = %+v, want the meeting created", room)
+	}
+
+	if err := zoom.ExpireRoom(context.Background(), room.ID); err != nil {
+		t.Errorf("ExpireRoom() error = %v, want nil for a meeting which is gone", err)
+	}
+	deleteStatus = http.StatusInternalServerError
+	if err := zoom.ExpireRoom(context.Background(), room.ID); err == nil {
+		t.Errorf("ExpireRoom() error = nil, want an error")
+	}
+}
+
+func TestGoogleMeet(t *testing.T) {
+	endStatus := http.StatusBadRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/token":
+			if r.FormValue("grant_type") != "refresh_token" || r.FormValue("refresh_token") != "refresh" {
+				t.Errorf("token request = %v, want the refresh token grant", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"access_token":"google-token","expires_in":3600}`))
+		case "/v2/spaces":
+			if r.Header.Get("Authorization") != "Bearer google-token" {
+				t.Errorf("Authorization = %s, want the access token", r.Header.Get("Authorization"))
+			}
+			_, _ = w.Write([]byte(`{"name":"spaces/abc","meetingUri":"https://meet.google.com/abc-defg-hij"}`))
+		case "/v2/spaces/abc:endActiveConference":
+			w.WriteHeader(endStatus)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Meeting.GoogleMeet.BaseURL = server.URL
+	cfg.Meeting.GoogleMeet.TokenURL = server.URL + "/token"
+	cfg.Meeting.GoogleMeet.RefreshToken = "refresh"
+	meet := NewGoogleMeet(cfg)
+
+	room, err := meet.CreateRoom(context.Background(), CreateRoomRequest{})
+	if err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+	if room.ID != "spaces/abc" || room.JoinURL != "https://meet.google.com/abc-defg-hij" {
+		t.Errorf("CreateRoom() = %+v, want the space created", room)
+	}
+
+	if err := meet.ExpireRoom(context.Background(), room.ID); err != nil {
+		t.Errorf("ExpireRoom() error = %v, want nil for a space without a conference", err)
+	}
+	endStatus = http.StatusForbidden
+	if err := meet.ExpireRoom(context.Background(), room.ID); err == nil {
+		t.Errorf("ExpireRoom() error = nil, want an error")
+	}
+}
@@ -0,0 +1,150 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// Zoom schedules meetings through a server-to-server OAuth app of the
+// platform's Zoom account.
+type Zoom struct {
+	rc        *resty.Client
+	auth      *resty.Client
+	accountID string
+	token     accessToken
+}
+
+func NewZoom(config *config.Config) *Zoom {
+	return &Zoom{
+		rc: resty.New().SetBaseURL(config.Meeting.Zoom.BaseURL),
+		auth: resty.New().
+			SetBasicAuth(config.Meeting.Zoom.ClientID, config.Meeting.Zoom.ClientSecret).
+			SetBaseURL(config.Meeting.Zoom.AuthURL),
+		accountID: config.Meeting.Zoom.AccountID,
+	}
+}
+
+type zoomTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type zoomMeetingSettings struct {
+	JoinBeforeHost bool `json:"join_before_host"`
+	WaitingRoom    bool `json:"waiting_room"`
+}
+
+type zoomMeetingRequest struct {
+	Topic     string              `json:"topic"`
+	Type      int                 `json:"type"`
+	StartTime string              `json:"start_time"`
+	Duration  int                 `json:"duration"`
+	Timezone  string              `json:"timezone"`
+	Settings  zoomMeetingSettings `json:"settings"`
+}
+
+type zoomMeetingResponse struct {
+	ID      int64  `json:"id"`
+	JoinURL string `json:"join_url"`
+}
+
+func (z *Zoom) Name() string {
+	return ProviderZoom
+}
+
+func (z *Zoom) CreateRoom(ctx context.Context, request CreateRoomRequest) (Room, error) {
+	token, err := z.token.get(ctx, z.fetchToken)
+	if err != nil {
+		return Room{}, err
+	}
+
+	result := zoomMeetingResponse{}
+	resp, err := z.rc.R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetResult(&result).
+		SetBody(zoomMeetingRequest{
+			Topic:     request.Topic,
+			Type:      2, // scheduled meeting
+			StartTime: request.StartsAt.UTC().Format("2006-01-02T15:04:05Z"),
+			Duration:  int(request.ExpiresAt.Sub(request.StartsAt).Minutes()),
+			Timezone:  "UTC",
+			Settings: zoomMeetingSettings{
+				JoinBeforeHost: true,
+			},
+		}).
+		Post("/users/me/meetings")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Zoom.CreateRoom] Error calling API")
+		return Room{}, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Msg("[Zoom.CreateRoom] Error calling API")
+		return Room{}, errors.New("error creating zoom meeting")
+	}
+
+	return Room{
+		ID:      strconv.FormatInt(result.ID, 10),
+		JoinURL: result.JoinURL,
+	}, nil
+}
+
+func (z *Zoom) ExpireRoom(ctx context.Context, roomID string) error {
+	token, err := z.token.get(ctx, z.fetchToken)
+	if err != nil {
+		return err
+	}
+
+	resp, err := z.rc.R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetPathParam("id", roomID).
+		Delete("/meetings/{id}")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Zoom.ExpireRoom] Error calling API")
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusNotFound {
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Msg("[Zoom.ExpireRoom] Error calling API")
+		return errors.New("error deleting zoom meeting")
+	}
+
+	return nil
+}
+
+func (z *Zoom) fetchToken(ctx context.Context) (string, time.Duration, error) {
+	result := zoomTokenResponse{}
+	resp, err := z.auth.R().
+		SetContext(ctx).
+		SetResult(&result).
+		SetQueryParam("grant_type", "account_credentials").
+		SetQueryParam("account_id", z.accountID).
+		Post("/oauth/token")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Zoom.fetchToken] Error calling API")
+		return "", 0, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Msg("[Zoom.fetchToken] Error calling API")
+		return "", 0, errors.New("error getting zoom access token")
+	}
+
+	return result.AccessToken, time.Duration(result.ExpiresIn) * time.Second, nil
+}
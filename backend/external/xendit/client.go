@@ -2,6 +2,7 @@ package xendit
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 
@@ -106,3 +107,58 @@ func (c *Client) CreatePaymentSession(ctx context.Context, request CreatePayment
 
 	return result, nil
 }
+
+func (c *Client) CreateRefund(ctx context.Context, request CreateRefundRequest) (CreateRefundResponse, error) {
+	result := CreateRefundResponse{}
+	resp, err := c.rc.R().
+		SetContext(ctx).
+		SetResult(&result).
+		SetHeader("Idempotency-key", request.ReferenceID).
+		SetBody(request).
+		Post("/refunds")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateRefund] Error calling API")
+		return CreateRefundResponse{}, err
+	}
+
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusCreated {
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Str("body", resp.String()).
+			Msg("[CreateRefund] Error calling API")
+
+		if rejectsRefund(resp.StatusCode()) {
+			rejected := &RefundRejectedError{StatusCode: resp.StatusCode()}
+			_ = json.Unmarshal(resp.Bytes(), rejected)
+			return CreateRefundResponse{}, rejected
+		}
+
+		return CreateRefundResponse{}, errors.New("error creating refund")
+	}
+
+	return result, nil
+}
+
+func (c *Client) CreateDisbursement(ctx context.Context, request CreateDisbursementRequest) (CreateDisbursementResponse, error) {
+	result := CreateDisbursementResponse{}
+	resp, err := c.rc.R().
+		SetContext(ctx).
+		SetResult(&result).
+		SetHeader("X-IDEMPOTENCY-KEY", request.ExternalID).
+		SetBody(request).
+		Post("/disbursements")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateDisbursement] Error calling API")
+		return CreateDisbursementResponse{}, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		logger.ErrorCtx(ctx).
+			Str("status", resp.Status()).
+			Str("body", resp.String()).
+			Msg("[CreateDisbursement] Error calling API")
+		return CreateDisbursementResponse{}, errors.New("error creating disbursement")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package xendit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/config"
+)
+
+// Disburser sends money out to bank accounts.
+type Disburser interface {
+	CreateDisbursement(ctx context.Context, request CreateDisbursementRequest) (CreateDisbursementResponse, error)
+}
+
+// NewDisburser returns the Xendit client, or FakeDisburser when
+// XENDIT.FAKE_DISBURSEMENT is set so payouts can be exercised locally without
+// moving money.
+func NewDisburser(config *config.Config, client *Client) Disburser {
+	if config.Xendit.FakeDisbursement {
+		return FakeDisburser{}
+	}
+
+	return client
+}
+
+// FakeDisburser completes every disbursement right away without calling
+// Xendit.
+type FakeDisburser struct{}
+
+func (FakeDisburser) CreateDisbursement(_ context.Context, request CreateDisbursementRequest) (CreateDisbursementResponse, error) {
+	return CreateDisbursementResponse{
+		ID:                "disb-fake-" + uuid.NewString(),
+		ExternalID:        request.ExternalID,
+		Amount:            request.Amount,
+		BankCode:          request.BankCode,
+		AccountHolderName: request.AccountHolderName,
+		Status:            DisbursementStatusCompleted,
+	}, nil
+}
@@ -17,6 +17,14 @@ const (
 	ItemTypeDigitalService = "DIGITAL_SERVICE"
 
 	FailedCycleActionResume = "RESUME"
+
+	RefundStatusSucceeded = "SUCCEEDED"
+	RefundStatusPending   = "PENDING"
+	RefundStatusFailed    = "FAILED"
+
+	DisbursementStatusPending   = "PENDING"
+	DisbursementStatusCompleted = "COMPLETED"
+	DisbursementStatusFailed    = "FAILED"
 )
 
 type SubscriptionSchedule struct {
@@ -48,7 +56,7 @@ type CreateSubscriptionRequest struct {
 	Currency                    string               `json:"currency"`
 	Amount                      int                  `json:"amount"`
 	Schedule                    SubscriptionSchedule `json:"schedule"`
-	ImmediateActionType         string               `json:"immediate_action_type"`
+	ImmediateActionType         string               `json:"immediate_action_type,omitempty"`
 	PaymentLinkForFailedAttempt bool                 `json:"payment_link_for_failed_attempt"`
 	FailedCycleAction           string               `json:"failed_cycle_action"`
 	Items                       []SubscriptionItem   `json:"items"`
@@ -84,3 +92,40 @@ type CreatePaymentSessionResponse struct {
 	PaymentLinkURL   string `json:"payment_link_url"`
 	PaymentSessionID string `json:"payment_session_id"`
 }
+
+type CreateRefundRequest struct {
+	PaymentRequestID string `json:"payment_request_id"`
+	ReferenceID      string `json:"reference_id"`
+	Currency         string `json:"currency"`
+	Amount           int    `json:"amount"`
+	Reason           string `json:"reason"`
+}
+
+type CreateRefundResponse struct {
+	ID               string `json:"id"`
+	PaymentRequestID string `json:"payment_request_id"`
+	ReferenceID      string `json:"reference_id"`
+	Amount           int    `json:"amount"`
+	Currency         string `json:"currency"`
+	Status           string `json:"status"`
+	FailureCode      string `json:"failure_code"`
+}
+
+type CreateDisbursementRequest struct {
+	ExternalID        string `json:"external_id"`
+	Amount            int    `json:"amount"`
+	BankCode          string `json:"bank_code"`
+	AccountHolderName string `json:"account_holder_name"`
+	AccountNumber     string `json:"account_number"`
+	Description       string `json:"description"`
+}
+
+type CreateDisbursementResponse struct {
+	ID                string `json:"id"`
+	ExternalID        string `json:"external_id"`
+	Amount            int    `json:"amount"`
+	BankCode          string `json:"bank_code"`
+	AccountHolderName string `json:"account_holder_name"`
+	Status            string `json:"status"`
+	FailureCode       string `json:"failure_code"`
+}
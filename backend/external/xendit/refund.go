@@ -0,0 +1,65 @@
+package xendit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/config"
+)
+
+// Refunder issues refunds of captured payments.
+type Refunder interface {
+	CreateRefund(ctx context.Context, request CreateRefundRequest) (CreateRefundResponse, error)
+}
+
+// RefundRejectedError is returned when Xendit turned the refund down, so no
+// refund was issued. Other errors leave it unknown whether it was.
+type RefundRejectedError struct {
+	StatusCode int    `json:"-"`
+	ErrorCode  string `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+func (e *RefundRejectedError) Error() string {
+	return fmt.Sprintf("refund rejected with status %d: %s %s", e.StatusCode, e.ErrorCode, e.Message)
+}
+
+// rejectsRefund reports whether answering with the status code means Xendit
+// did not issue the refund. Timeouts, conflicts and rate limits may be retried
+// by Xendit and are not taken as a rejection.
+func rejectsRefund(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return false
+	}
+
+	return statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError
+}
+
+// NewRefunder returns the Xendit client, or FakeRefunder when
+// XENDIT.FAKE_REFUND is set so refunds can be exercised locally and in tests
+// without a Xendit account.
+func NewRefunder(config *config.Config, client *Client) Refunder {
+	if config.Xendit.FakeRefund {
+		return FakeRefunder{}
+	}
+
+	return client
+}
+
+// FakeRefunder settles every refund right away without calling Xendit.
+type FakeRefunder struct{}
+
+func (FakeRefunder) CreateRefund(_ context.Context, request CreateRefundRequest) (CreateRefundResponse, error) {
+	return CreateRefundResponse{
+		ID:               "rfd-fake-" + uuid.NewString(),
+		PaymentRequestID: request.PaymentRequestID,
+		ReferenceID:      request.ReferenceID,
+		Amount:           request.Amount,
+		Currency:         request.Currency,
+		Status:           RefundStatusSucceeded,
+	}, nil
+}
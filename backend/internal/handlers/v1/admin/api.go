@@ -19,12 +19,25 @@ type Api struct {
 	tutorDocument      *services.TutorDocumentService
 	studentReview      *services.StudentReviewService
 	tutorReview        *services.TutorReviewService
+	review             *services.ReviewService
+	tutorLevel         *services.TutorLevelService
+	courseVersion      *services.CourseVersionService
+	courseModeration   *services.CourseModerationService
 	notification       *services.NotificationService
 	booking            *services.BookingService
+	bookingAttendance  *services.BookingAttendanceService
 	subscriptionPrice  *services.SubscriptionPriceService
+	entitlement        *services.EntitlementService
 	dashboard          *services.DashboardService
 	mentorBalanceAdmin *services.MentorBalanceAdminService
 	monthlyReport      *services.MonthlyReportService
+	paymentRefund      *services.PaymentRefundService
+	invoice            *services.InvoiceService
+	mentorBankAccount  *services.MentorBankAccountService
+	payout             *services.PayoutService
+	financeReport      *services.FinanceReportService
+	currency           *services.CurrencyService
+	conversation       *services.ConversationService
 	jwt                *jwt.JWT
 	userRepo           *repositories.UserRepository
 	roleRepo           *repositories.RoleRepository
@@ -37,12 +50,25 @@ func NewApi(
 	tutorDocument *services.TutorDocumentService,
 	studentReview *services.StudentReviewService,
 	tutorReview *services.TutorReviewService,
+	review *services.ReviewService,
+	tutorLevel *services.TutorLevelService,
+	courseVersion *services.CourseVersionService,
+	courseModeration *services.CourseModerationService,
 	notification *services.NotificationService,
 	booking *services.BookingService,
+	bookingAttendance *services.BookingAttendanceService,
 	subscriptionPrice *services.SubscriptionPriceService,
+	entitlement *services.EntitlementService,
 	dashboard *services.DashboardService,
 	mentorBalanceAdmin *services.MentorBalanceAdminService,
 	monthlyReport *services.MonthlyReportService,
+	paymentRefund *services.PaymentRefundService,
+	invoice *services.InvoiceService,
+	mentorBankAccount *services.MentorBankAccountService,
+	payout *services.PayoutService,
+	financeReport *services.FinanceReportService,
+	currency *services.CurrencyService,
+	conversation *services.ConversationService,
 	jwt *jwt.JWT,
 	userRepo *repositories.UserRepository,
 	roleRepo *repositories.RoleRepository,
@@ -54,12 +80,25 @@ func NewApi(
 		tutorDocument:      tutorDocument,
 		studentReview:      studentReview,
 		tutorReview:        tutorReview,
+		review:             review,
+		tutorLevel:         tutorLevel,
+		courseVersion:      courseVersion,
+		courseModeration:   courseModeration,
 		notification:       notification,
 		booking:            booking,
+		bookingAttendance:  bookingAttendance,
 		subscriptionPrice:  subscriptionPrice,
+		entitlement:        entitlement,
 		dashboard:          dashboard,
 		mentorBalanceAdmin: mentorBalanceAdmin,
 		monthlyReport:      monthlyReport,
+		paymentRefund:      paymentRefund,
+		invoice:            invoice,
+		mentorBankAccount:  mentorBankAccount,
+		payout:             payout,
+		financeReport:      financeReport,
+		currency:           currency,
+		conversation:       conversation,
 		jwt:                jwt,
 		userRepo:           userRepo,
 		roleRepo:           roleRepo,
@@ -78,6 +117,26 @@ func (a *Api) Router(r chi.Router) {
 		r.Delete("/{id}", a.DeleteCourse)
 		r.Post("/{id}/approve", a.ApproveCourse)
 		r.Post("/{id}/reject", a.RejectCourse)
+		r.Get("/{id}/draft", a.GetCourseDraftDiff)
+		r.Get("/{id}/versions", a.GetCourseVersions)
+		r.Get("/{id}/versions/diff", a.DiffCourseVersions)
+		r.Get("/{id}/versions/{version}", a.GetCourseVersion)
+		r.Post("/{id}/versions/{version}/rollback", a.RollbackCourseVersion)
+	})
+
+	r.Route("/course-moderation", func(r chi.Router) {
+		r.Get("/", a.GetCourseModerationQueue)
+		r.Post("/approve", a.BulkApproveCourses)
+		r.Post("/reject", a.BulkRejectCourses)
+		r.Post("/{courseId}/claim", a.ClaimCourseModeration)
+		r.Post("/{courseId}/release", a.ReleaseCourseModeration)
+		r.Put("/{courseId}/assignee", a.AssignCourseModeration)
+	})
+
+	r.Route("/course-rejection-reasons", func(r chi.Router) {
+		r.Get("/", a.GetCourseRejectionReasons)
+		r.Post("/", a.CreateCourseRejectionReason)
+		r.Put("/{id}", a.UpdateCourseRejectionReason)
 	})
 
 	r.Route("/notifications", func(r chi.Router) {
@@ -93,9 +152,11 @@ func (a *Api) Router(r chi.Router) {
 		r.Post("/{id}/change-role", a.ChangeRoleStudent)
 		r.Put("/{id}/status", a.UpdateStudentStatus)
 		r.Put("/{id}/premium", a.UpdateStudentPremium)
-		r.Get("/{id}/reports/monthly", a.GenerateMonthlyReport)
+		r.Post("/{id}/reports/monthly", a.CreateMonthlyReport)
 	})
 
+	r.Get("/reports/monthly/{id}", a.GetMonthlyReport)
+
 	r.Route("/tutors", func(r chi.Router) {
 		r.Get("/", a.GetTutors)
 		r.Get("/{id}", a.GetDetailTutor)
@@ -112,6 +173,13 @@ func (a *Api) Router(r chi.Router) {
 		})
 
 		r.Get("/{tutorId}/courses", a.GetTutorCourses)
+		r.Get("/{tutorId}/level-histories", a.GetTutorLevelHistories)
+		r.Get("/{tutorId}/reports/earnings", a.GenerateTutorEarningsStatement)
+	})
+
+	r.Route("/tutor-levels", func(r chi.Router) {
+		r.Get("/", a.GetTutorLevelCriteria)
+		r.Put("/{id}", a.UpdateTutorLevelCriteria)
 	})
 
 	r.Route("/student-reviews", func(r chi.Router) {
@@ -121,16 +189,28 @@ func (a *Api) Router(r chi.Router) {
 
 	r.Route("/tutor-reviews", func(r chi.Router) {
 		r.Put("/{id}", a.UpdateTutorReview)
-		r.Delete("/{id}", a.DeleteTutorReview)
+	})
+
+	r.Route("/review-moderation", func(r chi.Router) {
+		r.Get("/", a.ListReviewModeration)
+		r.Post("/{id}", a.ModerateReview)
+	})
+
+	r.Route("/conversation-reports", func(r chi.Router) {
+		r.Get("/", a.GetConversationReports)
+		r.Get("/{id}/messages", a.GetConversationReportMessages)
+		r.Post("/{id}/resolve", a.ResolveConversationReport)
 	})
 
 	r.Route("/bookings", func(r chi.Router) {
 		r.Get("/", a.GetBookings)
 		r.Post("/", a.CreateBooking)
+		r.Get("/no-show-disputes", a.GetNoShowDisputes)
 		r.Get("/{id}", a.GetBookingDetail)
 		r.Put("/{id}", a.UpdateBooking)
 		r.Post("/{id}/reminder-student", a.SendReminderToStudent)
 		r.Post("/{id}/reminder-tutor", a.SendReminderToTutor)
+		r.Post("/{id}/no-show/resolve", a.ResolveNoShowDispute)
 	})
 
 	r.Route("/subscription-prices", func(r chi.Router) {
@@ -138,6 +218,11 @@ func (a *Api) Router(r chi.Router) {
 		r.Put("/{id}", a.UpdateSubscriptionPrice)
 	})
 
+	r.Route("/plan-entitlements", func(r chi.Router) {
+		r.Get("/", a.GetPlanEntitlements)
+		r.Put("/{id}", a.UpdatePlanEntitlement)
+	})
+
 	r.Route("/dashboard", func(r chi.Router) {
 		r.Get("/statistic-user", a.GetDashboardStatisticUser)
 		r.Get("/statistic-subscription", a.GetDashboardStatisticSubscription)
@@ -155,8 +240,52 @@ func (a *Api) Router(r chi.Router) {
 		r.Post("/{id}/reject", a.RejectWithdrawal)
 	})
 
+	r.Route("/mentor-bank-accounts", func(r chi.Router) {
+		r.Get("/", a.GetMentorBankAccounts)
+		r.Post("/{id}/verify", a.VerifyMentorBankAccount)
+	})
+
+	r.Route("/payout-batches", func(r chi.Router) {
+		r.Get("/", a.GetPayoutBatches)
+		r.Post("/", a.CreatePayoutBatch)
+		r.Get("/{id}", a.GetPayoutBatch)
+		r.Post("/{id}/send", a.SendPayoutBatch)
+	})
+
+	r.Route("/payments/{id}/refunds", func(r chi.Router) {
+		r.Get("/", a.GetPaymentRefunds)
+		r.Post("/", a.CreatePaymentRefund)
+	})
+
+	r.Route("/refunds", func(r chi.Router) {
+		r.Get("/{id}/credit-note", a.GetRefundCreditNote)
+	})
+
+	r.Route("/invoices", func(r chi.Router) {
+		r.Get("/", a.GetInvoices)
+		r.Get("/export/e-faktur", a.ExportEFaktur)
+		r.Get("/{id}", a.GetInvoice)
+		r.Get("/{id}/download", a.DownloadInvoice)
+	})
+
+	r.Route("/tax-rates", func(r chi.Router) {
+		r.Get("/", a.GetTaxRates)
+		r.Post("/", a.CreateTaxRate)
+	})
+
+	r.Route("/exchange-rates", func(r chi.Router) {
+		r.Get("/", a.GetExchangeRates)
+		r.Post("/", a.UploadExchangeRates)
+		r.Post("/sync", a.SyncExchangeRates)
+	})
+
 	r.Route("/transactions", func(r chi.Router) {
 		r.Get("/", a.GetTransactions)
 		r.Get("/stats", a.GetTransactionStats)
 	})
+
+	r.Route("/finance/reports", func(r chi.Router) {
+		r.Get("/", a.GetFinanceReport)
+		r.Get("/export", a.ExportFinanceReport)
+	})
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model"
 	"github.com/lesprivate/backend/internal/model/dto"
 	"github.com/lesprivate/backend/internal/services"
 	"github.com/lesprivate/backend/shared/base"
@@ -20,7 +21,7 @@ import (
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param status query string false "Booking status (pending, accepted, declined, expired)"
+// @Param status query string false "Booking status (pending, accepted, declined, expired, completed, no_show)"
 // @Param tutorName query string false "Filter by tutor name (case-insensitive substring match)"
 // @Param studentName query string false "Filter by student name (case-insensitive substring match)"
 // @Param page query int false "Page number"
@@ -264,3 +265,81 @@ func (a *Api) UpdateBooking(w http.ResponseWriter, r *http.Request) {
 
 	response.Success(w, http.StatusOK, resp)
 }
+
+// GetNoShowDisputes
+// @Summary Get disputed no-shows
+// @Description get the no-show reports contested by the party reported absent, waiting to be settled, oldest first
+// @Tags admin-booking
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number"
+// @Param pageSize query int false "Page size"
+// @Success 200 {object} base.Base{data=[]dto.NoShowDisputeResponse,metadata=model.Metadata}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/bookings/no-show-disputes [get]
+func (a *Api) GetNoShowDisputes(w http.ResponseWriter, r *http.Request) {
+	var (
+		req model.Pagination
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Query Param format"), base.SetError(err.Error()))
+		return
+	}
+
+	req.SetDefault()
+	disputes, metadata, err := a.bookingAttendance.GetNoShowDisputes(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, disputes, base.SetMetadata(metadata))
+}
+
+// ResolveNoShowDispute
+// @Summary Resolve a disputed no-show
+// @Description settle a disputed no-show. A session found to have been held is completed, requesting the reviews and crediting the mentor, otherwise the no-show stands
+// @Tags admin-booking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID (UUID format)"
+// @Param request body dto.ResolveNoShowDisputeRequest true "Resolve dispute request"
+// @Success 200 {object} base.Base{data=dto.BookingAttendance}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/bookings/{id}/no-show/resolve [post]
+func (a *Api) ResolveNoShowDispute(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.ResolveNoShowDisputeRequest
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid booking ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ResolveNoShowDispute] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	attendance, err := a.bookingAttendance.ResolveNoShowDispute(ctx, id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewBookingAttendance(attendance))
+}
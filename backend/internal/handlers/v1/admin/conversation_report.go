@@ -0,0 +1,166 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetConversationReports
+// @Summary List conversation reports
+// @Description List the reports students and tutors filed on their conversations, the oldest first
+// @Tags admin-conversation
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Param status query string false "Report status (pending, resolved, dismissed)"
+// @Success 200 {object} base.Base{data=[]dto.ConversationReportResponse,metadata=model.Metadata}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/conversation-reports [get]
+func (a *Api) GetConversationReports(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		req dto.GetConversationReportsRequest
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversationReports] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversationReports] Invalid request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	req.Pagination.SetDefault()
+	reports, metadata, err := a.conversation.GetConversationReports(ctx, req)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversationReports] Failed to get reports")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, reports, base.SetMetadata(metadata))
+}
+
+// GetConversationReportMessages
+// @Summary List messages of a reported conversation
+// @Description List the messages of the conversation a report was filed on, the latest first. Admins can only read conversations through a report.
+// @Tags admin-conversation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report ID"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {object} base.Base{data=[]dto.ConversationMessageResponse,metadata=model.Metadata}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/conversation-reports/{id}/messages [get]
+func (a *Api) GetConversationReportMessages(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+		req   dto.GetConversationMessagesRequest
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("id", idStr).
+			Msg("[GetConversationReportMessages] Invalid report ID")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid report ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversationReportMessages] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	req.Pagination.SetDefault()
+	messages, metadata, err := a.conversation.GetReportedMessages(ctx, id, req)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("report_id", id.String()).
+			Msg("[GetConversationReportMessages] Failed to get messages")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, messages, base.SetMetadata(metadata))
+}
+
+// ResolveConversationReport
+// @Summary Resolve conversation report
+// @Description Close a pending conversation report as resolved or dismissed with an optional note
+// @Tags admin-conversation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report ID"
+// @Param request body dto.ResolveConversationReportRequest true "Resolution"
+// @Success 200 {object} base.Base{data=dto.ConversationReportResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/conversation-reports/{id}/resolve [post]
+func (a *Api) ResolveConversationReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+		req   dto.ResolveConversationReportRequest
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("id", idStr).
+			Msg("[ResolveConversationReport] Invalid report ID")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid report ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ResolveConversationReport] Failed to decode request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ResolveConversationReport] Invalid request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	report, err := a.conversation.ResolveConversationReport(ctx, id, req)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("report_id", id.String()).
+			Msg("[ResolveConversationReport] Failed to resolve report")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, report)
+}
@@ -67,13 +67,13 @@ func (a *Api) ApproveCourse(w http.ResponseWriter, r *http.Request) {
 
 // RejectCourse
 // @Summary Reject a course
-// @Description Rejects a course with optional review notes
+// @Description Rejects a course with review notes and/or canned rejection reasons that are sent to the tutor as a checklist
 // @Tags admin-course
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Course ID"
-// @Param request body dto.RejectCourseRequest true "Rejection request with notes"
+// @Param request body dto.RejectCourseRequest true "Rejection request with notes and reason codes"
 // @Success 200 {object} base.Base{data=string}
 // @Failure 400 {object} base.Base
 // @Failure 401 {object} base.Base
@@ -105,6 +105,11 @@ func (a *Api) RejectCourse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
 	req.ID = id
 	err = a.course.RejectCourse(ctx, req)
 	if err != nil {
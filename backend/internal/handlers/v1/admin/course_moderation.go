@@ -0,0 +1,360 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetCourseModerationQueue
+// @Summary Get course moderation queue
+// @Description List the courses waiting for review, oldest submission first, with their SLA status and reviewer
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Param assignee query string false "Filter by reviewer (me/unassigned)"
+// @Param sla query string false "Filter by SLA status (on_track/at_risk/breached)"
+// @Success 200 {object} base.Base{data=[]dto.CourseModerationQueueItem,metadata=model.Metadata}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-moderation [get]
+func (a *Api) GetCourseModerationQueue(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.CourseModerationQueueRequest
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetCourseModerationQueue] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	req.AdminID = middleware.GetUserID(ctx)
+	resp, metadata, err := a.courseModeration.GetQueue(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp, base.SetMetadata(metadata))
+}
+
+// ClaimCourseModeration
+// @Summary Claim a course for review
+// @Description Assign a course waiting for review to the current admin
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param courseId path string true "Course ID"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-moderation/{courseId}/claim [post]
+func (a *Api) ClaimCourseModeration(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "courseId")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", idStr).Msg("[ClaimCourseModeration] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	if err := a.courseModeration.Claim(ctx, id, middleware.GetUserID(ctx)); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// ReleaseCourseModeration
+// @Summary Release a claimed course
+// @Description Put a course claimed by the current admin back in the unassigned queue
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param courseId path string true "Course ID"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-moderation/{courseId}/release [post]
+func (a *Api) ReleaseCourseModeration(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "courseId")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", idStr).Msg("[ReleaseCourseModeration] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	if err := a.courseModeration.Release(ctx, id, middleware.GetUserID(ctx)); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// AssignCourseModeration
+// @Summary Assign a course reviewer
+// @Description Hand a course waiting for review to another admin. An empty adminId unassigns the course
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param courseId path string true "Course ID"
+// @Param request body dto.AssignCourseModerationRequest true "Reviewer to assign"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-moderation/{courseId}/assignee [put]
+func (a *Api) AssignCourseModeration(w http.ResponseWriter, r *http.Request) {
+	var (
+		req   dto.AssignCourseModerationRequest
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "courseId")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", idStr).Msg("[AssignCourseModeration] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AssignCourseModeration] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	req.CourseID = id
+	if err := a.courseModeration.Assign(ctx, req); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// BulkApproveCourses
+// @Summary Approve courses in bulk
+// @Description Approve several courses with the same notes. Each course is processed on its own and reported in the result
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.BulkApproveCoursesRequest true "Courses to approve"
+// @Success 200 {object} base.Base{data=[]dto.CourseModerationBulkResult}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-moderation/approve [post]
+func (a *Api) BulkApproveCourses(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.BulkApproveCoursesRequest
+		ctx = r.Context()
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[BulkApproveCourses] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	response.Success(w, http.StatusOK, a.courseModeration.BulkApprove(ctx, req))
+}
+
+// BulkRejectCourses
+// @Summary Reject courses in bulk
+// @Description Reject several courses with the same notes and rejection reasons. Each course is processed on its own and reported in the result
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.BulkRejectCoursesRequest true "Courses to reject"
+// @Success 200 {object} base.Base{data=[]dto.CourseModerationBulkResult}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-moderation/reject [post]
+func (a *Api) BulkRejectCourses(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.BulkRejectCoursesRequest
+		ctx = r.Context()
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[BulkRejectCourses] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	response.Success(w, http.StatusOK, a.courseModeration.BulkReject(ctx, req))
+}
+
+// GetCourseRejectionReasons
+// @Summary Get course rejection reasons
+// @Description List the canned reasons admins pick from when rejecting a course
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} base.Base{data=[]model.CourseRejectionReason}
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-rejection-reasons [get]
+func (a *Api) GetCourseRejectionReasons(w http.ResponseWriter, r *http.Request) {
+	resp, err := a.courseModeration.ListRejectionReasons(r.Context(), false)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// CreateCourseRejectionReason
+// @Summary Create a course rejection reason
+// @Description Add a canned reason admins can pick from when rejecting a course
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CourseRejectionReasonRequest true "Rejection reason"
+// @Success 201 {object} base.Base{data=model.CourseRejectionReason}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-rejection-reasons [post]
+func (a *Api) CreateCourseRejectionReason(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.CourseRejectionReasonRequest
+		ctx = r.Context()
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateCourseRejectionReason] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	req.AdminID = middleware.GetUserID(ctx)
+	resp, err := a.courseModeration.CreateRejectionReason(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, resp)
+}
+
+// UpdateCourseRejectionReason
+// @Summary Update a course rejection reason
+// @Description Update or deactivate a canned course rejection reason
+// @Tags admin-course-moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rejection reason ID"
+// @Param request body dto.CourseRejectionReasonRequest true "Rejection reason"
+// @Success 200 {object} base.Base{data=model.CourseRejectionReason}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/course-rejection-reasons/{id} [put]
+func (a *Api) UpdateCourseRejectionReason(w http.ResponseWriter, r *http.Request) {
+	var (
+		req   dto.CourseRejectionReasonRequest
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", idStr).Msg("[UpdateCourseRejectionReason] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateCourseRejectionReason] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	req.ID = id
+	req.AdminID = middleware.GetUserID(ctx)
+	resp, err := a.courseModeration.UpdateRejectionReason(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
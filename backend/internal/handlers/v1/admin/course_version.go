@@ -0,0 +1,234 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetCourseDraftDiff
+// @Summary Get course draft changes
+// @Description Compare the active draft of a course against the live course for approval
+// @Tags admin-course
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Success 200 {object} base.Base{data=dto.CourseDraftResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/courses/{id}/draft [get]
+func (a *Api) GetCourseDraftDiff(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", idStr).Msg("[GetCourseDraftDiff] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	resp, err := a.courseVersion.GetDraftDiff(ctx, id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// GetCourseVersions
+// @Summary Get course versions
+// @Description List the approved versions of a course, newest first
+// @Tags admin-course
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Success 200 {object} base.Base{data=[]dto.CourseVersionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/courses/{id}/versions [get]
+func (a *Api) GetCourseVersions(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", idStr).Msg("[GetCourseVersions] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	resp, err := a.courseVersion.ListVersions(ctx, id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// GetCourseVersion
+// @Summary Get course version
+// @Description Get a single version of a course with its snapshot
+// @Tags admin-course
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} base.Base{data=dto.CourseVersionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/courses/{id}/versions/{version} [get]
+func (a *Api) GetCourseVersion(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx        = r.Context()
+		idStr      = chi.URLParam(r, "id")
+		versionStr = chi.URLParam(r, "version")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", idStr).Msg("[GetCourseVersion] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("version", versionStr).Msg("[GetCourseVersion] Invalid version format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid version format"))
+		return
+	}
+
+	resp, err := a.courseVersion.GetVersion(ctx, id, version)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// DiffCourseVersions
+// @Summary Compare course versions
+// @Description List the fields that changed between two versions of a course
+// @Tags admin-course
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Param from query int true "Version to compare from"
+// @Param to query int true "Version to compare to"
+// @Success 200 {object} base.Base{data=dto.CourseVersionDiffResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/courses/{id}/versions/diff [get]
+func (a *Api) DiffCourseVersions(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+		query = r.URL.Query()
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", idStr).Msg("[DiffCourseVersions] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	req := dto.CourseVersionDiffRequest{CourseID: id}
+	req.From, _ = strconv.Atoi(query.Get("from"))
+	req.To, _ = strconv.Atoi(query.Get("to"))
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	resp, err := a.courseVersion.DiffVersions(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// RollbackCourseVersion
+// @Summary Roll back a course
+// @Description Restore a course to a previous version. The restore is recorded as a new version
+// @Tags admin-course
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Param version path int true "Version number to restore"
+// @Success 200 {object} base.Base{data=dto.AdminCourseDetail}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/courses/{id}/versions/{version}/rollback [post]
+func (a *Api) RollbackCourseVersion(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx        = r.Context()
+		idStr      = chi.URLParam(r, "id")
+		versionStr = chi.URLParam(r, "version")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", idStr).Msg("[RollbackCourseVersion] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("version", versionStr).Msg("[RollbackCourseVersion] Invalid version format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid version format"))
+		return
+	}
+
+	course, err := a.courseVersion.Rollback(ctx, dto.RollbackCourseVersionRequest{
+		CourseID: id,
+		Version:  version,
+		AdminID:  middleware.GetUserID(ctx),
+	})
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewAdminCourseDetail(course))
+}
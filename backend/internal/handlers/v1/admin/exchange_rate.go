@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetExchangeRates
+// @Summary List exchange rates
+// @Description List the daily exchange rate snapshots, newest first
+// @Tags admin-exchange-rate
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param currency query string false "Currency code"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date (YYYY-MM-DD)"
+// @Param page query int false "Page number"
+// @Param pageSize query int false "Page size"
+// @Success 200 {object} base.Base{data=[]model.ExchangeRate}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/exchange-rates [get]
+func (a *Api) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.GetExchangeRatesRequest
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetExchangeRates] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	rates, meta, err := a.currency.GetRates(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, rates, base.SetMetadata(meta))
+}
+
+// UploadExchangeRates
+// @Summary Upload exchange rates
+// @Description Record the IDR value of a unit of each currency for a day, replacing the rates already recorded that day
+// @Tags admin-exchange-rate
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UploadExchangeRatesRequest true "upload exchange rates request"
+// @Success 201 {object} base.Base{data=[]model.ExchangeRate}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/exchange-rates [post]
+func (a *Api) UploadExchangeRates(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.UploadExchangeRatesRequest
+		ctx = r.Context()
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UploadExchangeRates] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage(err.Error()), base.SetError(err.Error()))
+		return
+	}
+
+	req.AdminID = middleware.GetUserID(ctx)
+
+	rates, err := a.currency.UploadRates(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, rates)
+}
+
+// SyncExchangeRates
+// @Summary Sync exchange rates
+// @Description Record today's exchange rates from the provider
+// @Tags admin-exchange-rate
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} base.Base{data=[]model.ExchangeRate}
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/exchange-rates/sync [post]
+func (a *Api) SyncExchangeRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rates, err := a.currency.SyncRates(ctx)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, rates)
+}
@@ -0,0 +1,166 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetFinanceReport
+// @Summary Get finance report
+// @Description Sum the revenue, commission, payouts or liabilities figures per day, week or month. Tutor and category filters only apply to figures booked against mentors
+// @Tags admin-finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param report query string true "revenue, commission, payouts or liabilities"
+// @Param granularity query string false "day, week or month" default(month)
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Param tutorId query string false "Tutor ID"
+// @Param categoryId query string false "Course category ID"
+// @Success 200 {object} base.Base{data=dto.FinanceReportResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/finance/reports [get]
+func (a *Api) GetFinanceReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.GetFinanceReportRequest
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetFinanceReport] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage(err.Error()), base.SetError(err.Error()))
+		return
+	}
+
+	report, err := a.financeReport.Report(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, report)
+}
+
+// ExportFinanceReport
+// @Summary Export finance report
+// @Description Export a finance report as CSV or XLSX
+// @Tags admin-finance
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param report query string true "revenue, commission, payouts or liabilities"
+// @Param granularity query string false "day, week or month" default(month)
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Param tutorId query string false "Tutor ID"
+// @Param categoryId query string false "Course category ID"
+// @Param format query string false "csv or xlsx" default(csv)
+// @Success 200 {file} file "Returns the export file"
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/finance/reports/export [get]
+func (a *Api) ExportFinanceReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.GetFinanceReportRequest
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ExportFinanceReport] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage(err.Error()), base.SetError(err.Error()))
+		return
+	}
+
+	content, filename, err := a.financeReport.Export(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	contentType := "text/csv"
+	if req.Format == dto.FinanceExportFormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	_, _ = w.Write(content)
+}
+
+// GenerateTutorEarningsStatement
+// @Summary Generate earnings statement
+// @Description Generate a PDF monthly earnings statement for a tutor
+// @Tags admin-finance
+// @Accept json
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param tutorId path string true "Tutor ID"
+// @Param month query int true "Month (1-12)"
+// @Param year query int true "Year"
+// @Success 200 {file} file "Returns the PDF file"
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/tutors/{tutorId}/reports/earnings [get]
+func (a *Api) GenerateTutorEarningsStatement(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "tutorId")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error decoding ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	monthStr := r.URL.Query().Get("month")
+	yearStr := r.URL.Query().Get("year")
+
+	var month, year int
+	if _, err := fmt.Sscanf(monthStr, "%d", &month); err != nil || month < 1 || month > 12 {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid month specified"))
+		return
+	}
+	if _, err := fmt.Sscanf(yearStr, "%d", &year); err != nil || year < 2000 {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid year specified"))
+		return
+	}
+
+	pdfBytes, filename, err := a.financeReport.GenerateEarningsStatement(ctx, id, month, year)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	_, _ = w.Write(pdfBytes)
+}
@@ -0,0 +1,247 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetInvoices
+// @Summary List issued invoices
+// @Description List issued invoices, newest first
+// @Tags admin-invoice
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Search by invoice number, buyer name or email"
+// @Param issuedAtFrom query string false "Issued from date (YYYY-MM-DD)"
+// @Param issuedAtTo query string false "Issued until date (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {object} base.Base{data=[]model.Invoice,metadata=model.Metadata}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/invoices [get]
+func (a *Api) GetInvoices(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.GetAdminInvoicesRequest
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetInvoices] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	req.Pagination.SetDefault()
+
+	invoices, metadata, err := a.invoice.GetInvoices(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, invoices, base.SetMetadata(metadata))
+}
+
+// GetInvoice
+// @Summary Get issued invoice
+// @Description Get an issued invoice with its line items
+// @Tags admin-invoice
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} base.Base{data=model.Invoice}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/invoices/{id} [get]
+func (a *Api) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetInvoice] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	invoice, err := a.invoice.GetInvoice(ctx, id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, invoice)
+}
+
+// DownloadInvoice
+// @Summary Download issued invoice
+// @Description Download the PDF stored when the invoice was issued
+// @Tags admin-invoice
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {file} file "Returns the PDF file"
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/invoices/{id}/download [get]
+func (a *Api) DownloadInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DownloadInvoice] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	invoice, err := a.invoice.GetInvoice(ctx, id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	pdfBytes, filename, err := a.invoice.Download(ctx, *invoice)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	_, _ = w.Write(pdfBytes)
+}
+
+// ExportEFaktur
+// @Summary Export invoices for e-Faktur
+// @Description Export the invoices issued between two dates in the e-Faktur CSV or Coretax XML import layout
+// @Tags admin-invoice
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Param format query string false "csv or xml" default(csv)
+// @Success 200 {file} file "Returns the export file"
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/invoices/export/e-faktur [get]
+func (a *Api) ExportEFaktur(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.ExportEFakturRequest
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ExportEFaktur] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage(err.Error()), base.SetError(err.Error()))
+		return
+	}
+
+	content, filename, err := a.invoice.ExportEFaktur(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	contentType := "text/csv"
+	if req.Format == dto.EFakturFormatXML {
+		contentType = "application/xml"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	_, _ = w.Write(content)
+}
+
+// GetTaxRates
+// @Summary List tax rates
+// @Description List tax rates with the date each takes effect
+// @Tags admin-invoice
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} base.Base{data=[]model.TaxRate}
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/tax-rates [get]
+func (a *Api) GetTaxRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rates, err := a.invoice.GetTaxRates(ctx)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, rates)
+}
+
+// CreateTaxRate
+// @Summary Schedule tax rate
+// @Description Schedule a tax rate taking effect at a future date
+// @Tags admin-invoice
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateTaxRateRequest true "create tax rate request"
+// @Success 201 {object} base.Base{data=model.TaxRate}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/tax-rates [post]
+func (a *Api) CreateTaxRate(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.CreateTaxRateRequest
+		ctx = r.Context()
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateTaxRate] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage(err.Error()), base.SetError(err.Error()))
+		return
+	}
+
+	req.AdminID = middleware.GetUserID(ctx)
+
+	rate, err := a.invoice.CreateTaxRate(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, rate)
+}
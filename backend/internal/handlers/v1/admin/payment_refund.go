@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetPaymentRefunds
+// @Summary List payment refunds
+// @Description List the refunds issued for a payment
+// @Tags admin-payment-refund
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment ID"
+// @Success 200 {object} base.Base{data=[]model.PaymentRefund}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/payments/{id}/refunds [get]
+func (a *Api) GetPaymentRefunds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetPaymentRefunds] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	refunds, err := a.paymentRefund.ListByPayment(ctx, id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, refunds)
+}
+
+// CreatePaymentRefund
+// @Summary Refund a payment
+// @Description Refund a paid payment in full, or partially when an amount is given
+// @Tags admin-payment-refund
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment ID"
+// @Param request body dto.CreatePaymentRefundRequest true "Refund request"
+// @Success 201 {object} base.Base{data=model.PaymentRefund}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/payments/{id}/refunds [post]
+func (a *Api) CreatePaymentRefund(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.CreatePaymentRefundRequest
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreatePaymentRefund] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreatePaymentRefund] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage(err.Error()), base.SetError(err.Error()))
+		return
+	}
+
+	req.PaymentID = id
+	req.AdminID = middleware.GetUserID(ctx)
+
+	refund, err := a.paymentRefund.Create(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, refund)
+}
+
+// GetRefundCreditNote
+// @Summary Download refund credit note
+// @Description Download the PDF credit note of a succeeded refund
+// @Tags admin-payment-refund
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Refund ID"
+// @Success 200 {file} file "Returns the PDF file"
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/refunds/{id}/credit-note [get]
+func (a *Api) GetRefundCreditNote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetRefundCreditNote] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	pdfBytes, filename, err := a.paymentRefund.CreateCreditNote(ctx, id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	_, _ = w.Write(pdfBytes)
+}
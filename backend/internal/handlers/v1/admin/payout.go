@@ -0,0 +1,237 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetMentorBankAccounts
+// @Summary List mentor bank accounts
+// @Description List the bank accounts mentors registered for payouts
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Param verified query bool false "Filter by verification"
+// @Success 200 {object} base.Base{data=[]dto.AdminMentorBankAccountResponse,metadata=model.Metadata}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/mentor-bank-accounts [get]
+func (a *Api) GetMentorBankAccounts(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.AdminListMentorBankAccountsRequest
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMentorBankAccounts] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	accounts, meta, err := a.mentorBankAccount.AdminList(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	res := []dto.AdminMentorBankAccountResponse{}
+	for _, account := range accounts {
+		res = append(res, dto.ToAdminMentorBankAccountResponse(account))
+	}
+
+	response.Success(w, http.StatusOK, res, base.SetMetadata(meta))
+}
+
+// VerifyMentorBankAccount
+// @Summary Verify a mentor bank account
+// @Description Mark a mentor bank account as verified so payouts can be sent to it
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bank account ID"
+// @Success 200 {object} base.Base{data=dto.AdminMentorBankAccountResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/mentor-bank-accounts/{id}/verify [post]
+func (a *Api) VerifyMentorBankAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[VerifyMentorBankAccount] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	account, err := a.mentorBankAccount.Verify(ctx, id, middleware.GetUserID(ctx))
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.ToAdminMentorBankAccountResponse(*account))
+}
+
+// GetPayoutBatches
+// @Summary List payout batches
+// @Description List the batches of withdrawals sent to the disbursement provider
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Param status query string false "Filter by status (processing, completed)"
+// @Success 200 {object} base.Base{data=[]dto.AdminPayoutBatchResponse,metadata=model.Metadata}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/payout-batches [get]
+func (a *Api) GetPayoutBatches(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.AdminListPayoutBatchesRequest
+		ctx = r.Context()
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetPayoutBatches] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	batches, meta, err := a.payout.GetBatches(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	res := []dto.AdminPayoutBatchResponse{}
+	for _, batch := range batches {
+		res = append(res, dto.ToAdminPayoutBatchResponse(batch))
+	}
+
+	response.Success(w, http.StatusOK, res, base.SetMetadata(meta))
+}
+
+// GetPayoutBatch
+// @Summary Get a payout batch
+// @Description Get a payout batch with its withdrawals
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payout batch ID"
+// @Success 200 {object} base.Base{data=dto.AdminPayoutBatchResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/payout-batches/{id} [get]
+func (a *Api) GetPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetPayoutBatch] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	batch, err := a.payout.GetBatch(ctx, id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.ToAdminPayoutBatchResponse(*batch))
+}
+
+// CreatePayoutBatch
+// @Summary Create a payout batch
+// @Description Send approved withdrawals to the disbursement provider, every approved withdrawal when none is given
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreatePayoutBatchRequest true "Payout batch request"
+// @Success 201 {object} base.Base{data=dto.AdminPayoutBatchResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/payout-batches [post]
+func (a *Api) CreatePayoutBatch(w http.ResponseWriter, r *http.Request) {
+	var (
+		req dto.CreatePayoutBatchRequest
+		ctx = r.Context()
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreatePayoutBatch] Failed to decode JSON request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	batch, err := a.payout.CreateBatch(ctx, req, middleware.GetUserID(ctx))
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, dto.ToAdminPayoutBatchResponse(*batch))
+}
+
+// SendPayoutBatch
+// @Summary Send a payout batch again
+// @Description Retry the withdrawals of the batch the disbursement provider did not accept yet
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payout batch ID"
+// @Success 200 {object} base.Base{data=dto.AdminPayoutBatchResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/payout-batches/{id}/send [post]
+func (a *Api) SendPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SendPayoutBatch] Invalid ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	batch, err := a.payout.SendBatch(ctx, id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.ToAdminPayoutBatchResponse(*batch))
+}
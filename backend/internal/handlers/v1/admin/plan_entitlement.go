@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetPlanEntitlements
+// @Summary Get plan entitlements
+// @Description Get the limits and perks of the free plan and every subscription plan
+// @Tags admin-subscription-price
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} base.Base{data=[]model.PlanEntitlement}
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/plan-entitlements [get]
+func (a *Api) GetPlanEntitlements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := a.entitlement.ListPlans(ctx)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// UpdatePlanEntitlement
+// @Summary Update plan entitlement
+// @Description Update the limits and perks of a plan. A null limit means unlimited
+// @Tags admin-subscription-price
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Plan Entitlement ID"
+// @Param request body dto.UpdatePlanEntitlementRequest true "update plan entitlement request"
+// @Success 200 {object} base.Base{data=model.PlanEntitlement}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/plan-entitlements/{id} [put]
+func (a *Api) UpdatePlanEntitlement(w http.ResponseWriter, r *http.Request) {
+	var (
+		req   dto.UpdatePlanEntitlementRequest
+		idStr = chi.URLParam(r, "id")
+		ctx   = r.Context()
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error decoding ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error decoding body")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error validating request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	req.ID = id
+	req.AdminID = middleware.GetUserID(ctx)
+	resp, err := a.entitlement.UpdatePlan(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// ListReviewModeration
+// @Summary List review moderation queue
+// @Description List tutor reviews held by the profanity filter or reported by users. Pass status to browse published, pending or hidden reviews instead.
+// @Tags admin-review
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Param sort query string false "Sort by field"
+// @Param sortDirection query string false "Sort direction"
+// @Param status query string false "Moderation status (published, pending, hidden)"
+// @Param tutorId query string false "Tutor ID"
+// @Success 200 {object} base.Base{data=[]dto.ReviewModerationItem,metadata=model.Metadata}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/review-moderation [get]
+func (a *Api) ListReviewModeration(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		req dto.ListReviewModerationRequest
+	)
+
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListReviewModeration] Failed to decode query parameters")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid query parameters"), base.SetError(err.Error()))
+		return
+	}
+
+	req.Pagination.SetDefault()
+	req.Sort.SetDefault()
+
+	reviews, metadata, err := a.review.ListModerationQueue(ctx, req)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListReviewModeration] Failed to list moderation queue")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewReviewModerationItems(reviews), base.SetMetadata(metadata))
+}
+
+// ModerateReview
+// @Summary Moderate tutor review
+// @Description Approve (publish) or hide a tutor review. All pending reports on the review are closed and the course and tutor ratings are recalculated.
+// @Tags admin-review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review ID"
+// @Param request body dto.ModerateReviewRequest true "Moderation decision"
+// @Success 200 {object} base.Base
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/review-moderation/{id} [post]
+func (a *Api) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+		req   dto.ModerateReviewRequest
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("id", idStr).
+			Msg("[ModerateReview] Invalid review ID")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid review ID format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ModerateReview] Failed to decode request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ModerateReview] Invalid request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	req.ID = id
+	if err := a.review.Moderate(ctx, req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("review_id", id.String()).
+			Msg("[ModerateReview] Failed to moderate review")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
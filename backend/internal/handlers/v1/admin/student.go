@@ -2,7 +2,6 @@ package admin
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -372,27 +371,27 @@ func (a *Api) UpdateStudentPremium(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, http.StatusOK, "success")
 }
 
-// GenerateMonthlyReport
-// @Summary Generate monthly report
-// @Description Generate a PDF monthly report for a student
+// CreateMonthlyReport
+// @Summary Request monthly report
+// @Description Queue the monthly report of a student. Poll the report until it is completed for its download URL
 // @Tags admin-student
 // @Accept json
-// @Produce application/pdf
+// @Produce json
 // @Security BearerAuth
 // @Param id path string true "Student ID"
-// @Param month query int true "Month (1-12)"
-// @Param year query int true "Year"
-// @Success 200 {file} file "Returns the PDF file"
+// @Param request body dto.CreateMonthlyReportRequest true "month and year"
+// @Success 202 {object} base.Base{data=dto.MonthlyReportResponse}
 // @Failure 400 {object} base.Base
 // @Failure 401 {object} base.Base
 // @Failure 403 {object} base.Base
 // @Failure 404 {object} base.Base
 // @Failure 500 {object} base.Base
-// @Router /v1/admin/students/{id}/reports/monthly [get]
-func (a *Api) GenerateMonthlyReport(w http.ResponseWriter, r *http.Request) {
+// @Router /v1/admin/students/{id}/reports/monthly [post]
+func (a *Api) CreateMonthlyReport(w http.ResponseWriter, r *http.Request) {
 	var (
 		ctx   = r.Context()
 		idStr = chi.URLParam(r, "id")
+		req   dto.CreateMonthlyReportRequest
 	)
 
 	id, err := uuid.Parse(idStr)
@@ -402,26 +401,59 @@ func (a *Api) GenerateMonthlyReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	monthStr := r.URL.Query().Get("month")
-	yearStr := r.URL.Query().Get("year")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error decoding body")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"))
+		return
+	}
 
-	var month, year int
-	if _, err := fmt.Sscanf(monthStr, "%d", &month); err != nil || month < 1 || month > 12 {
-		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid month specified"))
+	if err := req.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error validating request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
 		return
 	}
-	if _, err := fmt.Sscanf(yearStr, "%d", &year); err != nil || year < 2000 {
-		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid year specified"))
+
+	report, err := a.monthlyReport.Request(ctx, id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusAccepted, report)
+}
+
+// GetMonthlyReport
+// @Summary Get monthly report
+// @Description Get the status of a monthly report with its download URL once completed
+// @Tags admin-student
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Monthly report ID"
+// @Success 200 {object} base.Base{data=dto.MonthlyReportResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/reports/monthly/{id} [get]
+func (a *Api) GetMonthlyReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error decoding ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
 		return
 	}
 
-	pdfBytes, filename, err := a.monthlyReport.GenerateMonthlyReport(ctx, id, month, year)
+	report, err := a.monthlyReport.GetMonthlyReport(ctx, id)
 	if err != nil {
 		response.Failure(w, base.CustomError(services.Error(err)))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/pdf")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
-	_, _ = w.Write(pdfBytes)
+	response.Success(w, http.StatusOK, report)
 }
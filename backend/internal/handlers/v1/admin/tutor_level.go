@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetTutorLevelCriteria
+// @Summary Get tutor level criteria
+// @Description Get the criteria of every tutor level
+// @Tags admin-tutor-level
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} base.Base{data=[]dto.AdminTutorLevelCriteriaResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/tutor-levels [get]
+func (a *Api) GetTutorLevelCriteria(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := a.tutorLevel.ListCriteria(ctx)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// UpdateTutorLevelCriteria
+// @Summary Update tutor level criteria
+// @Description Update the criteria a tutor has to meet to reach a level
+// @Tags admin-tutor-level
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tutor Level Criteria ID"
+// @Param request body dto.UpdateAdminTutorLevelCriteriaRequest true "update tutor level criteria request"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/tutor-levels/{id} [put]
+func (a *Api) UpdateTutorLevelCriteria(w http.ResponseWriter, r *http.Request) {
+	var (
+		req   dto.UpdateAdminTutorLevelCriteriaRequest
+		idStr = chi.URLParam(r, "id")
+		ctx   = r.Context()
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error decoding ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error decoding body")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error validating request")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	req.ID = id
+	err = a.tutorLevel.UpdateCriteria(ctx, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// GetTutorLevelHistories
+// @Summary Get tutor level histories
+// @Description Get the latest level changes of a tutor with their reasons
+// @Tags admin-tutor-level
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tutorId path string true "Tutor ID"
+// @Success 200 {object} base.Base{data=[]dto.TutorLevelHistoryEntry}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/admin/tutors/{tutorId}/level-histories [get]
+func (a *Api) GetTutorLevelHistories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tutorID, err := uuid.Parse(chi.URLParam(r, "tutorId"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error decoding ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid ID format"))
+		return
+	}
+
+	resp, err := a.tutorLevel.ListHistories(ctx, tutorID)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
@@ -75,43 +75,3 @@ func (a *Api) UpdateTutorReview(w http.ResponseWriter, r *http.Request) {
 
 	response.Success(w, http.StatusOK, nil)
 }
-
-// DeleteTutorReview
-// @Summary Delete tutor review
-// @Description delete tutor review by admin
-// @Tags admin-review
-// @Produce json
-// @Security BearerAuth
-// @Param id path string true "Review ID"
-// @Success 200 {object} base.Base
-// @Failure 400 {object} base.Base
-// @Failure 401 {object} base.Base
-// @Failure 403 {object} base.Base
-// @Failure 404 {object} base.Base
-// @Failure 500 {object} base.Base
-// @Router /v1/admin/tutor-reviews/{id} [delete]
-func (a *Api) DeleteTutorReview(w http.ResponseWriter, r *http.Request) {
-	var (
-		ctx   = r.Context()
-		idStr = chi.URLParam(r, "id")
-	)
-
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).
-			Str("id", idStr).
-			Msg("[DeleteTutorReview] Invalid review ID")
-		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid review ID format"), base.SetError(err.Error()))
-		return
-	}
-
-	if err := a.tutorReview.DeleteByAdmin(ctx, id); err != nil {
-		logger.ErrorCtx(ctx).Err(err).
-			Str("review_id", id.String()).
-			Msg("[DeleteTutorReview] Failed to delete tutor review")
-		response.Failure(w, base.CustomError(services.Error(err)))
-		return
-	}
-
-	response.Success(w, http.StatusOK, nil)
-}
@@ -24,7 +24,7 @@ import (
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Page size" default(10)
-// @Param status query string false "Filter by status (pending, approved, rejected)"
+// @Param status query string false "Filter by status (pending, approved, processing, completed, rejected, failed)"
 // @Success 200 {object} base.Base{data=[]dto.AdminWithdrawalResponse,metadata=model.Metadata}
 // @Failure 400 {object} base.Base
 // @Failure 401 {object} base.Base
@@ -72,7 +72,7 @@ func (a *Api) ListWithdrawals(w http.ResponseWriter, r *http.Request) {
 
 // ApproveWithdrawal
 // @Summary Approve a withdrawal request
-// @Description Approve a pending withdrawal request
+// @Description Approve a pending withdrawal request and hold its amount until it is paid out
 // @Tags admin-withdrawal
 // @Accept json
 // @Produce json
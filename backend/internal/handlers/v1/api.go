@@ -20,32 +20,50 @@ import (
 var decoder = shared.Decoder
 
 type Api struct {
-	config              *config.Config
-	course              *services.CourseService
-	courseCategory      *services.CourseCategoryService
-	subCourseCategory   *services.SubCourseCategoryService
-	location            *services.LocationService
-	lookup              *services.LookupService
-	user                *services.UserService
-	profile             services.ProfileService
-	file                *services.FileService
-	tutorDocument       *services.TutorDocumentService
-	studentBooking      *services.StudentBookingService
-	studentReview       *services.StudentReviewService
-	tutorBooking        *services.TutorBookingService
-	tutorReview         *services.TutorReviewService
-	courseView          *services.CourseViewService
-	booking             *services.BookingService
-	notification        *services.NotificationService
-	studentSubscription *services.StudentSubscriptionService
-	webhook             *services.WebhookService
-	jwt                 *jwt.JWT
-	admin               *admin.Api
-	mentor              *mentor.MentorHandler
-	mentorStudent       *services.MentorStudentService
-	courseRepo          *repositories.CourseRepository
-	userRepo            *repositories.UserRepository
-	roleRepo            *repositories.RoleRepository
+	config               *config.Config
+	course               *services.CourseService
+	courseCategory       *services.CourseCategoryService
+	subCourseCategory    *services.SubCourseCategoryService
+	location             *services.LocationService
+	lookup               *services.LookupService
+	currency             *services.CurrencyService
+	user                 *services.UserService
+	profile              services.ProfileService
+	file                 *services.FileService
+	tutorDocument        *services.TutorDocumentService
+	studentBooking       *services.StudentBookingService
+	studentReview        *services.StudentReviewService
+	tutorBooking         *services.TutorBookingService
+	tutorReview          *services.TutorReviewService
+	review               *services.ReviewService
+	tutorLevel           *services.TutorLevelService
+	bookingEvent         *services.BookingEventService
+	courseVersion        *services.CourseVersionService
+	courseView           *services.CourseViewService
+	booking              *services.BookingService
+	bookingAttendance    *services.BookingAttendanceService
+	meeting              *services.MeetingService
+	groupSession         *services.GroupSessionService
+	conversation         *services.ConversationService
+	notification         *services.NotificationService
+	studentSubscription  *services.StudentSubscriptionService
+	guardian             *services.GuardianService
+	guardianSubscription *services.GuardianSubscriptionService
+	lifecycle            *services.SubscriptionLifecycleService
+	entitlement          *services.EntitlementService
+	monthlyReport        *services.MonthlyReportService
+	sessionTask          *services.SessionTaskService
+	studentProgress      *services.StudentProgressService
+	learningPlan         *services.LearningPlanService
+	quiz                 *services.QuizService
+	webhook              *services.WebhookService
+	jwt                  *jwt.JWT
+	admin                *admin.Api
+	mentor               *mentor.MentorHandler
+	mentorStudent        *services.MentorStudentService
+	courseRepo           *repositories.CourseRepository
+	userRepo             *repositories.UserRepository
+	roleRepo             *repositories.RoleRepository
 }
 
 func NewApi(
@@ -55,6 +73,7 @@ func NewApi(
 	subCourseCategory *services.SubCourseCategoryService,
 	location *services.LocationService,
 	lookup *services.LookupService,
+	currency *services.CurrencyService,
 	user *services.UserService,
 	profile services.ProfileService,
 	file *services.FileService,
@@ -63,10 +82,27 @@ func NewApi(
 	studentReview *services.StudentReviewService,
 	tutorBooking *services.TutorBookingService,
 	tutorReview *services.TutorReviewService,
+	review *services.ReviewService,
+	tutorLevel *services.TutorLevelService,
+	bookingEvent *services.BookingEventService,
+	courseVersion *services.CourseVersionService,
 	courseView *services.CourseViewService,
 	booking *services.BookingService,
+	bookingAttendance *services.BookingAttendanceService,
+	meeting *services.MeetingService,
+	groupSession *services.GroupSessionService,
+	conversation *services.ConversationService,
 	notification *services.NotificationService,
 	studentSubscription *services.StudentSubscriptionService,
+	guardian *services.GuardianService,
+	guardianSubscription *services.GuardianSubscriptionService,
+	lifecycle *services.SubscriptionLifecycleService,
+	entitlement *services.EntitlementService,
+	monthlyReport *services.MonthlyReportService,
+	sessionTask *services.SessionTaskService,
+	studentProgress *services.StudentProgressService,
+	learningPlan *services.LearningPlanService,
+	quiz *services.QuizService,
 	webhook *services.WebhookService,
 	courseRepo *repositories.CourseRepository,
 	userRepo *repositories.UserRepository,
@@ -77,32 +113,50 @@ func NewApi(
 	jwt *jwt.JWT,
 ) *Api {
 	return &Api{
-		config:              config,
-		course:              course,
-		courseCategory:      courseCategory,
-		subCourseCategory:   subCourseCategory,
-		location:            location,
-		lookup:              lookup,
-		user:                user,
-		profile:             profile,
-		file:                file,
-		tutorDocument:       tutorDocument,
-		studentBooking:      studentBooking,
-		studentReview:       studentReview,
-		tutorBooking:        tutorBooking,
-		tutorReview:         tutorReview,
-		courseView:          courseView,
-		booking:             booking,
-		notification:        notification,
-		studentSubscription: studentSubscription,
-		webhook:             webhook,
-		jwt:                 jwt,
-		admin:               adminAPI,
-		mentor:              mentorHandler,
-		mentorStudent:       mentorStudent,
-		courseRepo:          courseRepo,
-		userRepo:            userRepo,
-		roleRepo:            roleRepo,
+		config:               config,
+		course:               course,
+		courseCategory:       courseCategory,
+		subCourseCategory:    subCourseCategory,
+		location:             location,
+		lookup:               lookup,
+		currency:             currency,
+		user:                 user,
+		profile:              profile,
+		file:                 file,
+		tutorDocument:        tutorDocument,
+		studentBooking:       studentBooking,
+		studentReview:        studentReview,
+		tutorBooking:         tutorBooking,
+		tutorReview:          tutorReview,
+		review:               review,
+		tutorLevel:           tutorLevel,
+		bookingEvent:         bookingEvent,
+		courseVersion:        courseVersion,
+		courseView:           courseView,
+		booking:              booking,
+		bookingAttendance:    bookingAttendance,
+		meeting:              meeting,
+		groupSession:         groupSession,
+		conversation:         conversation,
+		notification:         notification,
+		studentSubscription:  studentSubscription,
+		guardian:             guardian,
+		guardianSubscription: guardianSubscription,
+		lifecycle:            lifecycle,
+		entitlement:          entitlement,
+		monthlyReport:        monthlyReport,
+		sessionTask:          sessionTask,
+		studentProgress:      studentProgress,
+		learningPlan:         learningPlan,
+		quiz:                 quiz,
+		webhook:              webhook,
+		jwt:                  jwt,
+		admin:                adminAPI,
+		mentor:               mentorHandler,
+		mentorStudent:        mentorStudent,
+		courseRepo:           courseRepo,
+		userRepo:             userRepo,
+		roleRepo:             roleRepo,
 	}
 }
 
@@ -113,6 +167,7 @@ func (a *Api) Router(r chi.Router) {
 
 	r.Route("/webhook", func(r chi.Router) {
 		r.Post("/xendit", a.WebhookXendit)
+		r.Post("/xendit/disbursement", a.WebhookXenditDisbursement)
 	})
 
 	r.Route("/internal", func(r chi.Router) {
@@ -121,8 +176,18 @@ func (a *Api) Router(r chi.Router) {
 			r.Post("/reminder-expired", a.ReminderExpiredBooking)
 			r.Post("/reminder-course", a.ReminderCourseBooking)
 			r.Post("/review", a.CreateReviewBooking)
+			r.Post("/complete", a.CompleteUnfinishedSessions)
+			r.Post("/meeting-rooms", a.SyncMeetingRooms)
+			r.Post("/group-sessions", a.ProcessGroupSessions)
 		})
 		r.Delete("/notifications/retention", a.RetentionNotification)
+		r.Post("/tutors/level/recompute", a.RecomputeTutorLevel)
+		r.Post("/tutors/response-time/recalculate", a.RecalculateTutorResponseTime)
+		r.Post("/subscriptions/reminder", a.RemindSubscriptionRenewal)
+		r.Post("/subscriptions/period-end", a.ProcessSubscriptionPeriodEnd)
+		r.Post("/exchange-rates/sync", a.SyncExchangeRates)
+		r.Post("/reports/monthly", a.ScheduleMonthlyReports)
+		r.Post("/analytics/progress/refresh", a.RefreshProgressSummaries)
 	})
 
 	r.Route("/auth", func(r chi.Router) {
@@ -165,6 +230,13 @@ func (a *Api) Router(r chi.Router) {
 			r.Post("/{id}/submit", a.SubmitTutorCourse)
 			r.Put("/{id}/publish", a.PublishTutorCourse)
 			r.Delete("/{id}", a.DeleteTutorCourse)
+			r.Get("/{id}/draft", a.GetTutorCourseDraft)
+			r.Delete("/{id}/draft", a.DiscardTutorCourseDraft)
+			r.Get("/{id}/draft/conflicts", a.GetTutorCourseDraftConflicts)
+			r.Post("/{id}/draft/resolve", a.ResolveTutorCourseDraftConflict)
+			r.Get("/{id}/preview", a.PreviewTutorCourse)
+			r.Get("/{id}/pre-checks", a.GetTutorCoursePreChecks)
+			r.Get("/{id}/versions", a.ListTutorCourseVersions)
 		})
 
 		r.Route("/documents", func(r chi.Router) {
@@ -183,21 +255,60 @@ func (a *Api) Router(r chi.Router) {
 		r.Route("/reviews", func(r chi.Router) {
 			r.Get("/", a.ListTutorReview)
 			r.Put("/{id}", a.UpdateTutorReview)
+			r.Get("/received", a.ListReceivedTutorReview)
+			r.Put("/received/{id}/reply", a.ReplyTutorReview)
 		})
 	})
 
+	r.Route("/conversations", func(r chi.Router) {
+		r.Use(middleware.JWTAuth(a.jwt))
+		r.Get("/", a.GetConversations)
+		r.Post("/", a.StartConversation)
+		r.Get("/unread", a.GetUnreadMessages)
+		r.Get("/stream", a.StreamConversations)
+		r.Get("/{id}/messages", a.GetConversationMessages)
+		r.Post("/{id}/messages", a.SendConversationMessage)
+		r.Post("/{id}/read", a.ReadConversation)
+		r.Post("/{id}/report", a.ReportConversation)
+	})
+
+	r.Route("/reviews", func(r chi.Router) {
+		r.Use(middleware.JWTAuth(a.jwt))
+		r.Post("/{id}/helpful", a.VoteHelpfulReview)
+		r.Delete("/{id}/helpful", a.UnvoteHelpfulReview)
+		r.Post("/{id}/report", a.ReportReview)
+	})
+
 	r.Get("/students/subscriptions/prices", a.GetPricesStudentSubscription)
 	r.Route("/students", func(r chi.Router) {
 		r.Use(middleware.JWTAuth(a.jwt))
 		// TODO: add middleware to validate role student
 
 		r.Get("/tutors", a.GetStudentTutors)
+		r.Get("/entitlements", a.GetStudentEntitlements)
+		r.Get("/reports/monthly", a.GetStudentMonthlyReports)
+		r.Post("/reports/monthly", a.CreateStudentMonthlyReport)
+		r.Get("/progress", a.GetStudentProgress)
+		r.Get("/learning-plans", a.GetStudentLearningPlans)
+		r.Get("/learning-plans/{id}/notes", a.GetStudentLessonNotes)
+
+		r.Route("/tasks", func(r chi.Router) {
+			r.Get("/", a.GetStudentTasks)
+			r.Post("/{id}/submissions", a.SubmitStudentTask)
+			r.Post("/{id}/quiz", a.StartStudentQuiz)
+			r.Put("/{id}/quiz/answers", a.SaveStudentQuizAnswers)
+			r.Post("/{id}/quiz/submit", a.SubmitStudentQuiz)
+		})
 
 		r.Route("/booking", func(r chi.Router) {
 			r.Post("/", a.CreateStudentBooking)
 			r.Get("/", a.ListStudentBooking)
 			r.Get("/{id}", a.GetStudentBooking)
 			r.Post("/{id}/report", a.ReportStudentBooking)
+			r.Post("/{id}/check-in", a.CheckInStudentBooking)
+			r.Post("/{id}/check-out", a.CheckOutStudentBooking)
+			r.Post("/{id}/no-show", a.ReportNoShowStudentBooking)
+			r.Post("/{id}/no-show/dispute", a.DisputeNoShowStudentBooking)
 		})
 
 		r.Route("/reviews", func(r chi.Router) {
@@ -205,14 +316,64 @@ func (a *Api) Router(r chi.Router) {
 			r.Put("/{id}", a.UpdateStudentReview)
 		})
 
+		r.Route("/guardians", func(r chi.Router) {
+			r.Get("/", a.GetStudentGuardians)
+			r.Get("/invites", a.GetStudentGuardianInvites)
+			r.Post("/invites", a.CreateStudentGuardianInvite)
+			r.Post("/{id}/accept", a.AcceptStudentGuardian)
+			r.Delete("/{id}", a.DeleteStudentGuardian)
+		})
+
+		r.Post("/gift-codes/redeem", a.RedeemStudentGiftCode)
+
 		r.Route("/subscriptions", func(r chi.Router) {
 			r.Get("/", a.GetStudentSubscription)
 			r.Post("/", a.CreateStudentSubscription)
 			r.Post("/{id}/cancel", a.CancelStudentSubscription)
 			r.Post("/{id}/invoice", a.CreateInvoiceStudentSubscription)
+
+			r.Route("/recurring", func(r chi.Router) {
+				r.Get("/", a.GetRecurringStudentSubscription)
+				r.Post("/", a.CreateRecurringStudentSubscription)
+				r.Post("/{id}/cancel", a.CancelRecurringStudentSubscription)
+				r.Post("/{id}/change-plan", a.ChangePlanStudentSubscription)
+			})
 		})
 	})
 
+	r.Post("/guardians/links/confirm", a.ConfirmGuardianStudent)
+	r.Route("/guardians", func(r chi.Router) {
+		r.Use(middleware.JWTAuth(a.jwt))
+
+		r.Route("/students", func(r chi.Router) {
+			r.Get("/", a.GetGuardianStudents)
+			r.Post("/", a.CreateGuardianStudent)
+			r.Post("/invites/redeem", a.RedeemGuardianInvite)
+			r.Delete("/{id}", a.DeleteGuardianStudent)
+
+			r.Get("/{studentId}/bookings", a.GetGuardianStudentBookings)
+			r.Get("/{studentId}/sessions", a.GetGuardianStudentSessions)
+			r.Post("/{studentId}/reports/monthly", a.CreateGuardianStudentMonthlyReport)
+			r.Get("/{studentId}/learning-plans", a.GetGuardianStudentLearningPlans)
+			r.Get("/{studentId}/learning-plans/{planId}/notes", a.GetGuardianStudentLessonNotes)
+		})
+
+		r.Route("/subscriptions", func(r chi.Router) {
+			r.Get("/", a.GetGuardianSubscription)
+			r.Post("/", a.CreateGuardianSubscription)
+			r.Post("/gifts", a.CreateGuardianGiftSubscription)
+			r.Post("/{id}/cancel", a.CancelGuardianSubscription)
+			r.Post("/{id}/invoice", a.CreateInvoiceGuardianSubscription)
+		})
+
+		r.Get("/gift-codes", a.GetGuardianGiftCodes)
+	})
+
+	r.Route("/reports/monthly", func(r chi.Router) {
+		r.Use(middleware.JWTAuth(a.jwt))
+		r.Get("/{id}", a.GetMonthlyReport)
+	})
+
 	r.Get("/locations", a.GetLocations)
 
 	r.Route("/course-categories", func(r chi.Router) {
@@ -222,6 +383,7 @@ func (a *Api) Router(r chi.Router) {
 	})
 
 	r.Get("/lookups", a.GetLookups)
+	r.Get("/currencies", a.GetCurrencies)
 
 	// Admin routes for draft management with admin role validation
 	r.Route("/admin", a.admin.Router)
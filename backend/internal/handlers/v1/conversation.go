@@ -0,0 +1,416 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// conversationStreamKeepAlive is how often an idle stream sends a comment so
+// proxies do not close it
+const conversationStreamKeepAlive = 25 * time.Second
+
+// GetConversations list conversations
+// @Summary List conversations
+// @Description List the message threads of the current tutor or student, the latest message first, with the number of unread messages in each
+// @Tags conversation
+// @Produce json
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} base.Base{data=[]dto.ConversationResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/conversations [get]
+func (a *Api) GetConversations(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetConversationsRequest
+	)
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversations] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	conversations, metadata, err := a.conversation.GetConversations(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversations] Error getting conversations")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, conversations, base.SetMetadata(metadata))
+}
+
+// StartConversation start a conversation
+// @Summary Start conversation
+// @Description Open the thread with a tutor (students), with a student who booked the tutor (tutors) or about a booking, returning the existing one when there is
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param request body dto.StartConversationRequest true "start conversation request"
+// @Success 200 {object} base.Base{data=dto.ConversationResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/conversations [post]
+func (a *Api) StartConversation(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.StartConversationRequest
+	)
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	conversation, err := a.conversation.StartConversation(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Error starting conversation")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, conversation)
+}
+
+// GetUnreadMessages count unread messages
+// @Summary Count unread messages
+// @Description Count the messages the current user has not read across their conversations
+// @Tags conversation
+// @Produce json
+// @Success 200 {object} base.Base{data=dto.UnreadMessagesResponse}
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/conversations/unread [get]
+func (a *Api) GetUnreadMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	unread, err := a.conversation.GetUnreadMessages(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetUnreadMessages] Error counting unread messages")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, unread)
+}
+
+// StreamConversations stream conversation events
+// @Summary Stream conversation events
+// @Description Server-sent events of the conversations of the current user: "message" for a new message and "read" when the other party read the messages of the user
+// @Tags conversation
+// @Produce text/event-stream
+// @Success 200 {object} dto.ConversationEvent
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/conversations/stream [get]
+func (a *Api) StreamConversations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	events, err := a.conversation.Subscribe(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StreamConversations] Error subscribing to events")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StreamConversations] Streaming not supported")
+		return
+	}
+
+	keepAlive := time.NewTicker(conversationStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			payload, err := json.Marshal(event)
+			if err != nil {
+				logger.ErrorCtx(ctx).Err(err).Msg("[StreamConversations] Error encoding event")
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// GetConversationMessages list messages of a conversation
+// @Summary List messages of a conversation
+// @Description List the messages of a conversation of the current user, the latest first
+// @Tags conversation
+// @Produce json
+// @Param id path string true "ID of conversation"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} base.Base{data=[]dto.ConversationMessageResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/conversations/{id}/messages [get]
+func (a *Api) GetConversationMessages(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetConversationMessagesRequest
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversationMessages] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversationMessages] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	messages, metadata, err := a.conversation.GetMessages(ctx, id, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversationMessages] Error getting messages")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, messages, base.SetMetadata(metadata))
+}
+
+// SendConversationMessage send a message
+// @Summary Send message
+// @Description Send a message with optional files to a conversation. Email addresses and phone numbers are masked until the tutor and the student have a confirmed booking
+// @Tags conversation
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID of conversation"
+// @Param body formData string false "text of the message"
+// @Param files formData file false "files to attach"
+// @Success 200 {object} base.Base{data=dto.ConversationMessageResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/conversations/{id}/messages [post]
+func (a *Api) SendConversationMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SendConversationMessage] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	err = r.ParseMultipartForm(a.config.File.MaxUploadSize)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SendConversationMessage] Error parsing multipart form")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "failed to parse multipart form"
+		})
+		return
+	}
+
+	request := dto.SendMessageRequest{Body: r.FormValue("body")}
+	files := r.MultipartForm.File["files"]
+	if err := request.Validate(len(files)); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SendConversationMessage] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	message, err := a.conversation.SendMessage(ctx, id, request, files)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SendConversationMessage] Error sending message")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, message)
+}
+
+// ReadConversation mark a conversation read
+// @Summary Mark conversation read
+// @Description Mark the messages of the other party in a conversation read, sending them a read receipt
+// @Tags conversation
+// @Produce json
+// @Param id path string true "ID of conversation"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/conversations/{id}/read [post]
+func (a *Api) ReadConversation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReadConversation] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	err = a.conversation.MarkRead(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReadConversation] Error marking conversation read")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// ReportConversation report a conversation
+// @Summary Report conversation
+// @Description Report a conversation to the admins, who can then read its messages
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of conversation"
+// @Param request body dto.ReportConversationRequest true "report conversation request"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/conversations/{id}/report [post]
+func (a *Api) ReportConversation(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.ReportConversationRequest
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportConversation] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportConversation] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportConversation] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	err = a.conversation.ReportConversation(ctx, id, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportConversation] Error reporting conversation")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/lesprivate/backend/internal/services"
 	"github.com/lesprivate/backend/shared/base"
 	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
 	"github.com/lesprivate/backend/transport/http/response"
 )
 
@@ -29,6 +30,7 @@ import (
 // @Param radius query int false "radius"
 // @Param maxResponseTime query int false "max response time"
 // @Param levelEducationCourse query string false "level education course"
+// @Param currency query string false "currency to show prices in"
 // @Param page query int false "page"
 // @Param pageSize query int false "page size"
 // @Param sort query string false "sort"
@@ -65,7 +67,22 @@ func (a *Api) GetCourses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.Success(w, http.StatusOK, dto.NewCourses(result), base.SetMetadata(metadata))
+	response.Success(w, http.StatusOK, a.localizeCourses(ctx, dto.NewCourses(result), request.Currency), base.SetMetadata(metadata))
+}
+
+// localizeCourses adds the prices in the currency the viewer asked for or
+// chose in their profile.
+func (a *Api) localizeCourses(ctx context.Context, courses []dto.Course, requested string) []dto.Course {
+	currency, rates := a.currency.ViewerRates(ctx, middleware.GetUserID(ctx), requested)
+	if currency == "" {
+		return courses
+	}
+
+	for i := range courses {
+		courses[i].Localize(rates, currency)
+	}
+
+	return courses
 }
 
 // GetDetailCourse
@@ -73,6 +90,7 @@ func (a *Api) GetCourses(w http.ResponseWriter, r *http.Request) {
 // @Description GetDetailCourse
 // @Tags courses
 // @Param id path string true "id of course"
+// @Param currency query string false "currency to show prices in"
 // @Produce json
 // @Success 200 {object} base.Base{data=dto.CourseDetail}
 // @Failure 400 {object} base.Base
@@ -119,7 +137,17 @@ func (a *Api) GetDetailCourse(w http.ResponseWriter, r *http.Request) {
 		_ = a.courseView.RecordView(context.Background(), result.TutorID, result.ID, result.CourseCategoryID, userID, ipAddress, userAgent)
 	}()
 
-	response.Success(w, http.StatusOK, dto.NewCourseDetail(result))
+	resp := dto.NewCourseDetail(result)
+	if currency, rates := a.currency.ViewerRates(ctx, middleware.GetUserID(ctx), r.URL.Query().Get("currency")); currency != "" {
+		resp.Localize(rates, currency)
+	}
+
+	resp.BookingQuota, err = a.entitlement.GetCourseBookingQuota(ctx, middleware.GetUserID(ctx), result)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("Error getting course booking quota")
+	}
+
+	response.Success(w, http.StatusOK, resp)
 }
 
 // GetRelatedCourse
@@ -127,6 +155,7 @@ func (a *Api) GetDetailCourse(w http.ResponseWriter, r *http.Request) {
 // @Description GetRelatedCourse
 // @Tags courses
 // @Param id path string true "id of course"
+// @Param currency query string false "currency to show prices in"
 // @Param page query int false "page"
 // @Param pageSize query int false "page size"
 // @Param sort query string false "sort"
@@ -176,7 +205,7 @@ func (a *Api) GetRelatedCourse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.Success(w, http.StatusOK, dto.NewCourses(result), base.SetMetadata(metadata))
+	response.Success(w, http.StatusOK, a.localizeCourses(ctx, dto.NewCourses(result), request.Currency), base.SetMetadata(metadata))
 }
 
 // GetBookingCourse
@@ -231,12 +260,12 @@ func (a *Api) GetBookingCourse(w http.ResponseWriter, r *http.Request) {
 	}
 
 	request.ID = id
-	schedules, bookings, err := a.course.GetBookingCourse(ctx, request)
+	schedules, bookings, sessions, err := a.course.GetBookingCourse(ctx, request)
 	if err != nil {
 		response.Failure(w, base.CustomError(services.Error(err)))
 		return
 	}
 
-	resp := dto.NewBookingCourseResponse(ctx, schedules, bookings, request)
+	resp := dto.NewBookingCourseResponse(ctx, schedules, bookings, sessions, request)
 	response.Success(w, http.StatusOK, resp)
 }
@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetCurrencies get currencies
+// @Summary Get currencies
+// @Description get the currencies prices can be set and shown in
+// @Tags currencies
+// @Produce json
+// @Success 200 {object} base.Base{data=[]model.Currency}
+// @Failure 500 {object} base.Base
+// @Router /v1/currencies [get]
+func (a *Api) GetCurrencies(w http.ResponseWriter, r *http.Request) {
+	response.Success(w, http.StatusOK, a.currency.GetCurrencies())
+}
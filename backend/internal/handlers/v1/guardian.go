@@ -0,0 +1,371 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// CreateGuardianStudent request to link a student
+// @Summary Request to link a student
+// @Description Ask the student with the email to accept the guardian. The guardian can only pay for the student once accepted. The response does not tell whether the email belongs to a student
+// @Tags guardian
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateGuardianStudentRequest true "student email"
+// @Success 202 {object} base.Base{data=dto.CreateGuardianStudentResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students [post]
+func (a *Api) CreateGuardianStudent(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.CreateGuardianStudentRequest
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianStudent] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianStudent] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	res, err := a.guardian.RequestStudent(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianStudent] Error requesting student")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusAccepted, res)
+}
+
+// GetGuardianStudents list linked students
+// @Summary List linked students
+// @Description List the students linked to the guardian, pending requests included
+// @Tags guardian
+// @Produce json
+// @Success 200 {object} base.Base{data=[]dto.GuardianStudentResponse}
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students [get]
+func (a *Api) GetGuardianStudents(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	links, err := a.guardian.GetStudents(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudents] Error getting guardian students")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianStudentResponses(links))
+}
+
+// DeleteGuardianStudent remove a linked student
+// @Summary Remove a linked student
+// @Description Remove a student link of the guardian, or withdraw a pending request
+// @Tags guardian
+// @Produce json
+// @Param id path string true "guardian student id"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students/{id} [delete]
+func (a *Api) DeleteGuardianStudent(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteGuardianStudent] Error parsing guardian student id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	err = a.guardian.RemoveStudent(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteGuardianStudent] Error removing guardian student")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// GetStudentGuardians list guardians of the student
+// @Summary List guardians of the student
+// @Description List the guardians who asked to link to the student
+// @Tags student-guardian
+// @Produce json
+// @Success 200 {object} base.Base{data=[]dto.GuardianStudentResponse}
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/guardians [get]
+func (a *Api) GetStudentGuardians(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	links, err := a.guardian.GetGuardians(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentGuardians] Error getting student guardians")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianStudentResponses(links))
+}
+
+// AcceptStudentGuardian accept a guardian
+// @Summary Accept a guardian
+// @Description Accept a guardian request so the guardian can pay premium for the student
+// @Tags student-guardian
+// @Produce json
+// @Param id path string true "guardian student id"
+// @Success 200 {object} base.Base{data=dto.GuardianStudentResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/guardians/{id}/accept [post]
+func (a *Api) AcceptStudentGuardian(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AcceptStudentGuardian] Error parsing guardian student id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	link, err := a.guardian.AcceptGuardian(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AcceptStudentGuardian] Error accepting guardian")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianStudentResponse(*link))
+}
+
+// DeleteStudentGuardian decline or remove a guardian
+// @Summary Decline or remove a guardian
+// @Description Decline a guardian request or remove an accepted guardian
+// @Tags student-guardian
+// @Produce json
+// @Param id path string true "guardian student id"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/guardians/{id} [delete]
+func (a *Api) DeleteStudentGuardian(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteStudentGuardian] Error parsing guardian student id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	err = a.guardian.RemoveGuardian(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteStudentGuardian] Error removing guardian")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// ConfirmGuardianStudent confirm a guardian from the emailed link
+// @Summary Confirm a guardian from the emailed link
+// @Description Record the consent of the student through the token of the confirmation email. The token is the proof, so no session is needed
+// @Tags student-guardian
+// @Accept json
+// @Produce json
+// @Param request body dto.ConfirmGuardianStudentRequest true "confirmation token"
+// @Success 200 {object} base.Base{data=dto.GuardianStudentResponse}
+// @Failure 400 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/guardians/links/confirm [post]
+func (a *Api) ConfirmGuardianStudent(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.ConfirmGuardianStudentRequest
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ConfirmGuardianStudent] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ConfirmGuardianStudent] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	link, err := a.guardian.ConfirmGuardian(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ConfirmGuardianStudent] Error confirming guardian")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianStudentResponse(*link))
+}
+
+// CreateStudentGuardianInvite create a guardian invite code
+// @Summary Create a guardian invite code
+// @Description Create a code the student shares with a guardian. The guardian entering it is linked without another confirmation
+// @Tags student-guardian
+// @Produce json
+// @Success 201 {object} base.Base{data=dto.GuardianInviteResponse}
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/guardians/invites [post]
+func (a *Api) CreateStudentGuardianInvite(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	invite, err := a.guardian.CreateInvite(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentGuardianInvite] Error creating guardian invite")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, dto.NewGuardianInviteResponse(*invite))
+}
+
+// GetStudentGuardianInvites list guardian invite codes
+// @Summary List guardian invite codes
+// @Description List the invite codes of the student that were not used and have not expired
+// @Tags student-guardian
+// @Produce json
+// @Success 200 {object} base.Base{data=[]dto.GuardianInviteResponse}
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/guardians/invites [get]
+func (a *Api) GetStudentGuardianInvites(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	invites, err := a.guardian.GetInvites(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentGuardianInvites] Error getting guardian invites")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianInviteResponses(invites))
+}
+
+// RedeemGuardianInvite link a student with an invite code
+// @Summary Link a student with an invite code
+// @Description Link the guardian to the student who shared the invite code. The link is active right away
+// @Tags guardian
+// @Accept json
+// @Produce json
+// @Param request body dto.RedeemGuardianInviteRequest true "invite code"
+// @Success 200 {object} base.Base{data=dto.GuardianStudentResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students/invites/redeem [post]
+func (a *Api) RedeemGuardianInvite(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.RedeemGuardianInviteRequest
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RedeemGuardianInvite] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RedeemGuardianInvite] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	link, err := a.guardian.RedeemInvite(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RedeemGuardianInvite] Error redeeming guardian invite")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianStudentResponse(*link))
+}
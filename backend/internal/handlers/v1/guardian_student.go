@@ -0,0 +1,262 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetGuardianStudentBookings list upcoming bookings of a linked student
+// @Summary List upcoming bookings of a linked student
+// @Description List the pending and accepted bookings of a student who accepted the guardian, soonest first
+// @Tags guardian
+// @Produce json
+// @Param studentId path string true "student id"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} base.Base{data=[]dto.GuardianBookingResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students/{studentId}/bookings [get]
+func (a *Api) GetGuardianStudentBookings(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetGuardianStudentBookingsRequest
+	)
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentBookings] Error parsing student id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentBookings] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	bookings, metadata, err := a.guardian.GetStudentBookings(ctx, studentID, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentBookings] Error getting student bookings")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianBookingResponses(bookings), base.SetMetadata(metadata))
+}
+
+// GetGuardianStudentSessions list past sessions of a linked student
+// @Summary List past sessions of a linked student
+// @Description List the accepted sessions of a student who accepted the guardian with the tasks, their scores and the progress notes of the mentor, latest first
+// @Tags guardian
+// @Produce json
+// @Param studentId path string true "student id"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} base.Base{data=[]dto.GuardianSessionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students/{studentId}/sessions [get]
+func (a *Api) GetGuardianStudentSessions(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetGuardianStudentBookingsRequest
+	)
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentSessions] Error parsing student id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentSessions] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	bookings, metadata, err := a.guardian.GetStudentSessions(ctx, studentID, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentSessions] Error getting student sessions")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianSessionResponses(bookings), base.SetMetadata(metadata))
+}
+
+// CreateGuardianStudentMonthlyReport request monthly report of a linked student
+// @Summary Request monthly report of a linked student
+// @Description Queue the monthly report of a student who accepted the guardian. Poll the report until it is completed for its download URL. Requires a student plan that includes monthly reports
+// @Tags guardian
+// @Accept json
+// @Produce json
+// @Param studentId path string true "student id"
+// @Param request body dto.CreateMonthlyReportRequest true "month and year"
+// @Success 202 {object} base.Base{data=dto.MonthlyReportResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students/{studentId}/reports/monthly [post]
+func (a *Api) CreateGuardianStudentMonthlyReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.CreateMonthlyReportRequest
+	)
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianStudentMonthlyReport] Error parsing student id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianStudentMonthlyReport] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianStudentMonthlyReport] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	report, err := a.guardian.RequestStudentMonthlyReport(ctx, studentID, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianStudentMonthlyReport] Error requesting monthly report")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusAccepted, report)
+}
+
+// GetGuardianStudentLearningPlans list learning plans of a linked student
+// @Summary List learning plans of a linked student
+// @Description List the learning plans the tutors set per subject for a student who accepted the guardian, with their milestones
+// @Tags guardian
+// @Produce json
+// @Param studentId path string true "student id"
+// @Success 200 {object} base.Base{data=[]dto.LearningPlanResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students/{studentId}/learning-plans [get]
+func (a *Api) GetGuardianStudentLearningPlans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentLearningPlans] Error parsing student id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	plans, err := a.guardian.GetStudentLearningPlans(ctx, studentID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentLearningPlans] Error getting learning plans")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, plans)
+}
+
+// GetGuardianStudentLessonNotes list lesson notes of a learning plan of a linked student
+// @Summary List lesson notes of a learning plan of a linked student
+// @Description List the lesson notes of a learning plan of a student who accepted the guardian, the latest session first
+// @Tags guardian
+// @Produce json
+// @Param studentId path string true "student id"
+// @Param planId path string true "learning plan id"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} base.Base{data=[]dto.LessonNoteResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/students/{studentId}/learning-plans/{planId}/notes [get]
+func (a *Api) GetGuardianStudentLessonNotes(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetLessonNotesRequest
+	)
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentLessonNotes] Error parsing student id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	planID, err := uuid.Parse(chi.URLParam(r, "planId"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentLessonNotes] Error parsing learning plan id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentLessonNotes] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	notes, metadata, err := a.guardian.GetStudentLessonNotes(ctx, studentID, planID, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianStudentLessonNotes] Error getting lesson notes")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, notes, base.SetMetadata(metadata))
+}
@@ -0,0 +1,324 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// CreateGuardianSubscription buy premium for linked students
+// @Summary Buy premium for linked students
+// @Description Buy premium for students who accepted the guardian. The price is charged per student and the invoice is issued to the guardian
+// @Tags guardian-subscription
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateFamilySubscriptionRequest true "family subscription request"
+// @Success 201 {object} base.Base{data=dto.CreateStudentSubscriptionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/subscriptions [post]
+func (a *Api) CreateGuardianSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.CreateFamilySubscriptionRequest
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianSubscription] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianSubscription] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	resp, err := a.guardianSubscription.CreateFamily(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianSubscription] Error create family subscription")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, resp)
+}
+
+// CreateGuardianGiftSubscription buy premium gift codes
+// @Summary Buy premium gift codes
+// @Description Buy premium codes any student can redeem. The codes can be redeemed once the payment is paid
+// @Tags guardian-subscription
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateGiftSubscriptionRequest true "gift subscription request"
+// @Success 201 {object} base.Base{data=dto.CreateStudentSubscriptionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/subscriptions/gifts [post]
+func (a *Api) CreateGuardianGiftSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.CreateGiftSubscriptionRequest
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianGiftSubscription] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianGiftSubscription] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	resp, err := a.guardianSubscription.CreateGift(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGuardianGiftSubscription] Error create gift subscription")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, resp)
+}
+
+// GetGuardianSubscription list guardian payments
+// @Summary List guardian payments
+// @Description List the family and gift payments of the guardian
+// @Tags guardian-subscription
+// @Produce json
+// @Param page query int false "page"
+// @Param pageSize query int false "pageSize"
+// @Success 200 {object} base.Base{data=[]dto.GuardianPaymentResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/subscriptions [get]
+func (a *Api) GetGuardianSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetGuardianPaymentsRequest
+	)
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianSubscription] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	payments, err := a.guardianSubscription.GetPayments(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianSubscription] Error get guardian payments")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewGuardianPaymentResponses(payments))
+}
+
+// CancelGuardianSubscription cancel a pending guardian payment
+// @Summary Cancel a pending guardian payment
+// @Description Cancel a pending payment of the guardian and its gift codes
+// @Tags guardian-subscription
+// @Produce json
+// @Param id path string true "payment id"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/subscriptions/{id}/cancel [post]
+func (a *Api) CancelGuardianSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CancelGuardianSubscription] Error parsing payment id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	err = a.guardianSubscription.CancelPayment(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CancelGuardianSubscription] Error cancel guardian payment")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// CreateInvoiceGuardianSubscription download the invoice of a guardian payment
+// @Summary Download the invoice of a guardian payment
+// @Description Download the invoice issued to the guardian, or a proforma while the payment is not paid
+// @Tags guardian-subscription
+// @Produce application/pdf
+// @Param id path string true "payment id"
+// @Success 200 {file} file
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/subscriptions/{id}/invoice [post]
+func (a *Api) CreateInvoiceGuardianSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvoiceGuardianSubscription] Error parsing payment id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	buf, filename, err := a.guardianSubscription.CreateInvoice(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvoiceGuardianSubscription] Error create invoice")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.File(w, filename, buf)
+}
+
+// GetGuardianGiftCodes list gift codes
+// @Summary List gift codes
+// @Description List the gift codes bought by the guardian
+// @Tags guardian-subscription
+// @Produce json
+// @Param status query string false "pending, active, redeemed or canceled"
+// @Param page query int false "page"
+// @Param pageSize query int false "pageSize"
+// @Success 200 {object} base.Base{data=[]model.GiftCode}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/guardians/gift-codes [get]
+func (a *Api) GetGuardianGiftCodes(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetGiftCodesRequest
+	)
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianGiftCodes] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianGiftCodes] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	codes, metadata, err := a.guardianSubscription.GetGiftCodes(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGuardianGiftCodes] Error get gift codes")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, codes, base.SetMetadata(metadata))
+}
+
+// RedeemStudentGiftCode redeem a gift code
+// @Summary Redeem a gift code
+// @Description Redeem a premium gift code. The premium period is added after the premium the student already has
+// @Tags student-subscription
+// @Accept json
+// @Produce json
+// @Param request body dto.RedeemGiftCodeRequest true "gift code"
+// @Success 200 {object} base.Base{data=dto.RedeemGiftCodeResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/gift-codes/redeem [post]
+func (a *Api) RedeemStudentGiftCode(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.RedeemGiftCodeRequest
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RedeemStudentGiftCode] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RedeemStudentGiftCode] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	resp, err := a.guardianSubscription.RedeemGiftCode(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RedeemStudentGiftCode] Error redeem gift code")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
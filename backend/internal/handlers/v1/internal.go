@@ -3,6 +3,7 @@ package v1
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/lesprivate/backend/shared/logger"
 	"github.com/lesprivate/backend/transport/http/response"
@@ -121,3 +122,226 @@ func (a *Api) RetentionNotification(w http.ResponseWriter, r *http.Request) {
 
 	response.Success(w, http.StatusOK, "success")
 }
+
+// RecomputeTutorLevel recompute tutor level
+// @Summary recompute tutor level
+// @Description recompute the level of every tutor against the level criteria
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/tutors/level/recompute [post]
+func (a *Api) RecomputeTutorLevel(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		err := a.tutorLevel.RecomputeAll(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[RecomputeTutorLevel] Error recompute tutor level")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// RecalculateTutorResponseTime recalculate tutor response time
+// @Summary recalculate tutor response time
+// @Description recalculate the median response time of every tutor from booking events
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/tutors/response-time/recalculate [post]
+func (a *Api) RecalculateTutorResponseTime(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		err := a.bookingEvent.RecalculateAllResponseTimes(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[RecalculateTutorResponseTime] Error recalculate tutor response time")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// RemindSubscriptionRenewal remind subscription renewal
+// @Summary remind subscription renewal
+// @Description notify students whose subscription period ends soon
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/subscriptions/reminder [post]
+func (a *Api) RemindSubscriptionRenewal(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		err := a.lifecycle.RemindRenewal(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[RemindSubscriptionRenewal] Error remind subscription renewal")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// ProcessSubscriptionPeriodEnd process subscription period end
+// @Summary process subscription period end
+// @Description end subscriptions canceled at period end and expire subscriptions whose grace period ran out
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/subscriptions/period-end [post]
+func (a *Api) ProcessSubscriptionPeriodEnd(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		err := a.lifecycle.ProcessPeriodEnd(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ProcessSubscriptionPeriodEnd] Error process subscription period end")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// SyncExchangeRates sync exchange rates
+// @Summary sync exchange rates
+// @Description record today's exchange rates from the provider
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/exchange-rates/sync [post]
+func (a *Api) SyncExchangeRates(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		_, err := a.currency.SyncRates(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[SyncExchangeRates] Error sync exchange rates")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// ScheduleMonthlyReports schedule monthly reports
+// @Summary schedule monthly reports
+// @Description queue the reports of the previous month for the students whose plan includes monthly reports and email them to the students and their guardians once generated
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/reports/monthly [post]
+func (a *Api) ScheduleMonthlyReports(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		err := a.monthlyReport.ScheduleMonthlyReports(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ScheduleMonthlyReports] Error schedule monthly reports")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// RefreshProgressSummaries refresh student progress summaries
+// @Summary refresh student progress summaries
+// @Description recompute the monthly progress summaries of every student per tutor and subject for the last months, the current and previous one by default
+// @Tags internal
+// @Produce json
+// @Param months query int false "number of months up to the current one to recompute"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/analytics/progress/refresh [post]
+func (a *Api) RefreshProgressSummaries(w http.ResponseWriter, r *http.Request) {
+	months, _ := strconv.Atoi(r.URL.Query().Get("months"))
+
+	go func() {
+		ctx := context.Background()
+		err := a.studentProgress.RefreshProgressSummaries(ctx, months)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[RefreshProgressSummaries] Error refresh progress summaries")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// CompleteUnfinishedSessions complete unfinished sessions
+// @Summary complete unfinished sessions
+// @Description complete the sessions both the tutor and the student checked in to which the tutor did not check out of in time
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/booking/complete [post]
+func (a *Api) CompleteUnfinishedSessions(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		err := a.bookingAttendance.CompleteUnfinishedSessions(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[CompleteUnfinishedSessions] Error complete unfinished sessions")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// SyncMeetingRooms sync meeting rooms
+// @Summary sync meeting rooms
+// @Description create the rooms of accepted online sessions which have none yet and expire the rooms of sessions which are over
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/booking/meeting-rooms [post]
+func (a *Api) SyncMeetingRooms(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		err := a.meeting.SyncRooms(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[SyncMeetingRooms] Error sync meeting rooms")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// ProcessGroupSessions process group sessions
+// @Summary process group sessions
+// @Description confirm the open group sessions whose cutoff passed with enough students and cancel the others
+// @Tags internal
+// @Produce json
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/internal/booking/group-sessions [post]
+func (a *Api) ProcessGroupSessions(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		ctx := context.Background()
+		err := a.groupSession.ProcessCutoff(ctx)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ProcessGroupSessions] Error process group sessions")
+		}
+	}()
+
+	response.Success(w, http.StatusOK, "success")
+}
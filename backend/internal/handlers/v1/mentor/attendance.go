@@ -0,0 +1,117 @@
+package mentor
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// CheckInSession records the mentor turning up to a session, with their
+// location for offline lessons.
+func (h *MentorHandler) CheckInSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid session ID"))
+		return
+	}
+
+	var req dto.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	attendance, err := h.attendance.CheckIn(r.Context(), sessionID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, attendance)
+}
+
+// CheckOutSession records the mentor leaving a session, which completes it
+// when the student checked in as well.
+func (h *MentorHandler) CheckOutSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid session ID"))
+		return
+	}
+
+	attendance, err := h.attendance.CheckOut(r.Context(), sessionID)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, attendance)
+}
+
+// ReportStudentNoShow reports the student absent from a session the mentor
+// checked in to.
+func (h *MentorHandler) ReportStudentNoShow(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid session ID"))
+		return
+	}
+
+	var req dto.ReportNoShowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	attendance, err := h.attendance.ReportNoShow(r.Context(), sessionID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, attendance)
+}
+
+// DisputeNoShow contests the student reporting the mentor absent.
+func (h *MentorHandler) DisputeNoShow(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid session ID"))
+		return
+	}
+
+	var req dto.DisputeNoShowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	attendance, err := h.attendance.DisputeNoShow(r.Context(), sessionID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, attendance)
+}
@@ -40,14 +40,55 @@ type InviteCodeResponse struct {
 }
 
 type WithdrawalRequest struct {
-	Amount        float64 `json:"amount" validate:"required,gt=0"`
-	BankName      string  `json:"bank_name" validate:"required"`
-	AccountNumber string  `json:"account_number" validate:"required"`
-	AccountName   string  `json:"account_name" validate:"required"`
+	Amount float64 `json:"amount" validate:"required,gt=0"`
+	// Verified bank account to pay out to, the default one when empty
+	BankAccountID string `json:"bank_account_id"`
+}
+
+type BankAccountRequest struct {
+	BankCode      string `json:"bank_code" validate:"required"`
+	AccountNumber string `json:"account_number" validate:"required"`
+	AccountName   string `json:"account_name" validate:"required"`
+}
+
+type BankAccountResponse struct {
+	ID            string  `json:"id"`
+	BankCode      string  `json:"bank_code"`
+	BankName      string  `json:"bank_name"`
+	AccountNumber string  `json:"account_number"`
+	AccountName   string  `json:"account_name"`
+	IsDefault     bool    `json:"is_default"`
+	Verified      bool    `json:"verified"`
+	VerifiedAt    *string `json:"verified_at"`
+}
+
+func ToBankAccountResponse(a model.MentorBankAccount) BankAccountResponse {
+	res := BankAccountResponse{
+		ID:            a.ID.String(),
+		BankCode:      a.BankCode,
+		BankName:      a.BankName(),
+		AccountNumber: a.AccountNumber,
+		AccountName:   a.AccountName,
+		IsDefault:     a.IsDefault,
+		Verified:      a.Verified(),
+	}
+
+	if a.VerifiedAt != nil {
+		verifiedAt := a.VerifiedAt.Format("2006-01-02 15:04:05")
+		res.VerifiedAt = &verifiedAt
+	}
+
+	return res
 }
 
 type BalanceResponse struct {
-	Balance string `json:"balance"` // Decimal as string
+	Balance  string `json:"balance"` // Decimal as string
+	Currency string `json:"currency"`
+}
+
+type BalanceCurrencyRequest struct {
+	// Currency the balance is settled in, only changeable while it is empty
+	Currency string `json:"currency" validate:"required,len=3"`
 }
 
 type ChartDataPoint struct {
@@ -66,11 +107,12 @@ type FinanceStatsResponse struct {
 }
 
 type BookingStatsResponse struct {
-	Pending   int `json:"pending"`
-	Accepted  int `json:"accepted"`
-	Rejected  int `json:"rejected"`
-	Completed int `json:"completed"`
-	Total     int `json:"total"`
+	Pending      int      `json:"pending"`
+	Accepted     int      `json:"accepted"`
+	Rejected     int      `json:"rejected"`
+	Completed    int      `json:"completed"`
+	Total        int      `json:"total"`
+	ResponseTime null.Int `json:"responseTime"`
 }
 
 type TransactionResponse struct {
@@ -105,9 +147,11 @@ type WithdrawalResponse struct {
 	ID            string `json:"id"`
 	Amount        string `json:"amount"`
 	Status        string `json:"status"`
+	BankCode      string `json:"bank_code"`
 	BankName      string `json:"bank_name"`
 	AccountNumber string `json:"account_number"`
 	AccountName   string `json:"account_name"`
+	FailureCode   string `json:"failure_code,omitempty"`
 	CreatedAt     string `json:"created_at"`
 }
 
@@ -116,26 +160,31 @@ func ToWithdrawalResponse(w model.WithdrawalRequest) WithdrawalResponse {
 		ID:            w.ID.String(),
 		Amount:        w.Amount.String(),
 		Status:        string(w.Status),
+		BankCode:      w.BankCode,
 		BankName:      w.BankName,
 		AccountNumber: w.AccountNumber,
 		AccountName:   w.AccountName,
+		FailureCode:   w.FailureCode.String,
 		CreatedAt:     w.CreatedAt.Format("2006-01-02 15:04:05"),
 	}
 }
 
 type SessionResponse struct {
-	ID            string               `json:"id"`
-	StudentID     string               `json:"student_id"`
-	StudentName   string               `json:"student_name"`
-	CourseName    string               `json:"course_name"`
-	BookingDate   string               `json:"booking_date"`
-	BookingTime   string               `json:"booking_time"`
-	Status        string               `json:"status"`
-	ClassType     string               `json:"class_type"`
-	Code          string               `json:"code"`
-	Notes         string               `json:"notes"`
-	SessionTasks  []SessionTaskDTO     `json:"session_tasks,omitempty"`
-	ReportBooking *model.ReportBooking `json:"report_booking,omitempty"`
+	ID            string                   `json:"id"`
+	StudentID     string                   `json:"student_id"`
+	StudentName   string                   `json:"student_name"`
+	CourseName    string                   `json:"course_name"`
+	BookingDate   string                   `json:"booking_date"`
+	BookingTime   string                   `json:"booking_time"`
+	Status        string                   `json:"status"`
+	ClassType     string                   `json:"class_type"`
+	Code          string                   `json:"code"`
+	Notes         string                   `json:"notes"`
+	SessionTasks  []SessionTaskDTO         `json:"session_tasks,omitempty"`
+	ReportBooking *model.ReportBooking     `json:"report_booking,omitempty"`
+	Attendance    *model.BookingAttendance `json:"attendance,omitempty"`
+	LessonNote    *model.LessonNote        `json:"lesson_note,omitempty"`
+	JoinURL       string                   `json:"join_url,omitempty"`
 }
 
 func ToSessionResponse(b model.Booking) SessionResponse {
@@ -148,6 +197,9 @@ func ToSessionResponse(b model.Booking) SessionResponse {
 		ClassType:   string(b.ClassType),
 		Code:        b.Code,
 		Notes:       b.NotesStudent.String,
+		Attendance:  b.Attendance,
+		LessonNote:  b.LessonNote,
+		JoinURL:     b.JoinURL(),
 	}
 
 	if b.ReportBooking.ID != uuid.Nil {
@@ -160,14 +212,22 @@ func ToSessionResponse(b model.Booking) SessionResponse {
 			Title:         task.Title,
 			Description:   task.Description,
 			AttachmentURL: task.AttachmentURL,
+			DueAt:         task.DueAt,
+			Rubric:        task.GetRubric(),
 			CreatedAt:     task.CreatedAt,
 		}
 		if len(task.TaskSubmissions) > 0 {
 			sub := task.TaskSubmissions[0]
 			taskDTO.Submission = &TaskSubmissionDTO{
 				ID:            sub.ID,
+				Status:        sub.Status,
 				SubmissionURL: sub.SubmissionURL,
+				Files:         sub.Files,
+				SubmittedAt:   sub.SubmittedAt,
+				IsLate:        sub.IsLate,
 				Score:         sub.Score,
+				RubricScores:  sub.GetRubricScores(),
+				Feedback:      sub.Feedback,
 				CreatedAt:     sub.CreatedAt,
 			}
 		}
@@ -206,9 +266,9 @@ func ToStudentDetailResponse(ms model.MentorStudent, bookings []model.Booking) S
 	for _, b := range bookings {
 		sessions = append(sessions, ToSessionResponse(b))
 		switch b.Status {
-		case model.BookingStatusAccepted:
+		case model.BookingStatusCompleted:
 			completed++
-		case model.BookingStatusPending:
+		case model.BookingStatusPending, model.BookingStatusAccepted:
 			upcoming++
 		}
 	}
@@ -228,9 +288,13 @@ func ToStudentDetailResponse(ms model.MentorStudent, bookings []model.Booking) S
 }
 
 type CreateSessionTaskRequest struct {
-	Title         string  `json:"title" validate:"required"`
-	Description   *string `json:"description"`
-	AttachmentURL *string `json:"attachment_url"`
+	Title         string                  `json:"title" validate:"required"`
+	Description   *string                 `json:"description"`
+	AttachmentURL *string                 `json:"attachment_url"`
+	DueAt         *time.Time              `json:"due_at"`
+	Rubric        []model.RubricCriterion `json:"rubric"`
+	// QuizID attaches a quiz of the library, graded automatically
+	QuizID *uuid.UUID `json:"quiz_id"`
 }
 
 type GradeTaskRequest struct {
@@ -243,17 +307,25 @@ type UpdateSessionNotesRequest struct {
 }
 
 type SessionTaskDTO struct {
-	ID            uuid.UUID          `json:"id"`
-	Title         string             `json:"title"`
-	Description   null.String        `json:"description"`
-	AttachmentURL null.String        `json:"attachment_url"`
-	Submission    *TaskSubmissionDTO `json:"submission,omitempty"`
-	CreatedAt     time.Time          `json:"created_at"`
+	ID            uuid.UUID               `json:"id"`
+	Title         string                  `json:"title"`
+	Description   null.String             `json:"description"`
+	AttachmentURL null.String             `json:"attachment_url"`
+	DueAt         null.Time               `json:"due_at"`
+	Rubric        []model.RubricCriterion `json:"rubric"`
+	Submission    *TaskSubmissionDTO      `json:"submission,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
 }
 
 type TaskSubmissionDTO struct {
-	ID            uuid.UUID           `json:"id"`
-	SubmissionURL null.String         `json:"submission_url"`
-	Score         decimal.NullDecimal `json:"score"`
-	CreatedAt     time.Time           `json:"created_at"`
+	ID            uuid.UUID                  `json:"id"`
+	Status        string                     `json:"status"`
+	SubmissionURL null.String                `json:"submission_url"`
+	Files         []model.TaskSubmissionFile `json:"files"`
+	SubmittedAt   null.Time                  `json:"submitted_at"`
+	IsLate        bool                       `json:"is_late"`
+	Score         decimal.NullDecimal        `json:"score"`
+	RubricScores  []model.RubricScore        `json:"rubric_scores"`
+	Feedback      null.String                `json:"feedback"`
+	CreatedAt     time.Time                  `json:"created_at"`
 }
@@ -0,0 +1,307 @@
+package mentor
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+func (h *MentorHandler) groupRouter(r chi.Router) {
+	r.Get("/", h.ListGroups)
+	r.Post("/", h.CreateGroup)
+	r.Put("/{groupId}", h.UpdateGroup)
+	r.Delete("/{groupId}", h.DeleteGroup)
+
+	r.Get("/{groupId}/members", h.ListGroupMembers)
+	r.Post("/{groupId}/members", h.AddGroupMembers)
+	r.Delete("/{groupId}/members/{studentId}", h.RemoveGroupMember)
+
+	r.Get("/{groupId}/invite-codes", h.ListGroupInviteCodes)
+	r.Post("/{groupId}/invite-codes", h.CreateGroupInviteCode)
+	r.Delete("/{groupId}/invite-codes/{codeId}", h.RevokeGroupInviteCode)
+
+	r.Get("/{groupId}/announcements", h.ListGroupAnnouncements)
+	r.Post("/{groupId}/announcements", h.CreateGroupAnnouncement)
+}
+
+func groupID(r *http.Request) (uuid.UUID, error) {
+	return uuid.Parse(chi.URLParam(r, "groupId"))
+}
+
+func (h *MentorHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetMentorGroupsRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+	req.Pagination.SetDefault()
+
+	groups, meta, err := h.mentorGroup.GetGroups(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, groups, base.SetMetadata(meta))
+}
+
+func (h *MentorHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req dto.MentorGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	group, err := h.mentorGroup.CreateGroup(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, group)
+}
+
+func (h *MentorHandler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	var req dto.MentorGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	group, err := h.mentorGroup.UpdateGroup(r.Context(), id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, group)
+}
+
+// DeleteGroup removes the group, its invite codes and announcements. The
+// students stay in the mentor's student list.
+func (h *MentorHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	if err := h.mentorGroup.DeleteGroup(r.Context(), id); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+func (h *MentorHandler) ListGroupMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	var req dto.GetMentorGroupMembersRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+	req.Pagination.SetDefault()
+
+	members, meta, err := h.mentorGroup.GetMembers(r.Context(), id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, members, base.SetMetadata(meta))
+}
+
+// AddGroupMembers puts students the mentor already mentors in the group.
+func (h *MentorHandler) AddGroupMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	var req dto.AddMentorGroupMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := h.mentorGroup.AddMembers(r.Context(), id, req); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+func (h *MentorHandler) RemoveGroupMember(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid student ID"))
+		return
+	}
+
+	if err := h.mentorGroup.RemoveMember(r.Context(), id, studentID); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+func (h *MentorHandler) ListGroupInviteCodes(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	codes, err := h.mentorGroup.GetInviteCodes(r.Context(), id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, codes)
+}
+
+// CreateGroupInviteCode issues a code students join the group with, limited
+// by an expiry and a number of uses when given.
+func (h *MentorHandler) CreateGroupInviteCode(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	var req dto.CreateGroupInviteCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	code, err := h.mentorGroup.CreateInviteCode(r.Context(), id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, code)
+}
+
+func (h *MentorHandler) RevokeGroupInviteCode(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	codeID, err := uuid.Parse(chi.URLParam(r, "codeId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid invite code ID"))
+		return
+	}
+
+	if err := h.mentorGroup.RevokeInviteCode(r.Context(), id, codeID); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+func (h *MentorHandler) ListGroupAnnouncements(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	var pagination model.Pagination
+	if err := shared.Decoder.Decode(&pagination, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+	pagination.SetDefault()
+
+	announcements, meta, err := h.mentorGroup.GetAnnouncements(r.Context(), id, pagination)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, announcements, base.SetMetadata(meta))
+}
+
+// CreateGroupAnnouncement notifies every member of the group.
+func (h *MentorHandler) CreateGroupAnnouncement(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group ID"))
+		return
+	}
+
+	var req dto.MentorGroupAnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	announcement, err := h.mentorGroup.Announce(r.Context(), id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, announcement)
+}
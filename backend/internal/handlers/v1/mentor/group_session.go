@@ -0,0 +1,120 @@
+package mentor
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+func (h *MentorHandler) groupSessionRouter(r chi.Router) {
+	r.Get("/", h.ListGroupSessions)
+	r.Get("/{groupSessionId}", h.GetGroupSessionRoster)
+	r.Post("/{groupSessionId}/tasks", h.CreateGroupSessionTask)
+	r.Patch("/{groupSessionId}/notes", h.UpdateGroupSessionNotes)
+}
+
+func groupSessionID(r *http.Request) (uuid.UUID, error) {
+	return uuid.Parse(chi.URLParam(r, "groupSessionId"))
+}
+
+func (h *MentorHandler) ListGroupSessions(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetGroupSessionsRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+	req.Pagination.SetDefault()
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	sessions, meta, err := h.groupSession.GetSessions(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, sessions, base.SetMetadata(meta))
+}
+
+// GetGroupSessionRoster returns the session with the students holding a
+// seat.
+func (h *MentorHandler) GetGroupSessionRoster(w http.ResponseWriter, r *http.Request) {
+	id, err := groupSessionID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group session ID"))
+		return
+	}
+
+	roster, err := h.groupSession.GetRoster(r.Context(), id)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, roster)
+}
+
+// CreateGroupSessionTask gives every student of the session, or the given
+// ones, their own copy of the task.
+func (h *MentorHandler) CreateGroupSessionTask(w http.ResponseWriter, r *http.Request) {
+	id, err := groupSessionID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group session ID"))
+		return
+	}
+
+	var req dto.GroupSessionTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	tasks, err := h.groupSession.AssignTask(r.Context(), id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, tasks)
+}
+
+func (h *MentorHandler) UpdateGroupSessionNotes(w http.ResponseWriter, r *http.Request) {
+	id, err := groupSessionID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid group session ID"))
+		return
+	}
+
+	var req dto.GroupSessionNotesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := h.groupSession.UpdateNotes(r.Context(), id, req); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
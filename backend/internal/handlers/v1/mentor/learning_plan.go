@@ -0,0 +1,232 @@
+package mentor
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+func (h *MentorHandler) learningPlanRouter(r chi.Router) {
+	r.Put("/{planId}", h.UpdateLearningPlan)
+	r.Get("/{planId}/notes", h.ListLearningPlanNotes)
+
+	r.Post("/{planId}/milestones", h.AddLearningPlanMilestone)
+	r.Put("/{planId}/milestones/{milestoneId}", h.UpdateLearningPlanMilestone)
+	r.Delete("/{planId}/milestones/{milestoneId}", h.DeleteLearningPlanMilestone)
+}
+
+func learningPlanID(r *http.Request) (uuid.UUID, error) {
+	return uuid.Parse(chi.URLParam(r, "planId"))
+}
+
+func (h *MentorHandler) ListStudentLearningPlans(w http.ResponseWriter, r *http.Request) {
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid student ID"))
+		return
+	}
+
+	plans, err := h.learningPlan.GetMentorPlans(r.Context(), studentID)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, plans)
+}
+
+func (h *MentorHandler) CreateStudentLearningPlan(w http.ResponseWriter, r *http.Request) {
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid student ID"))
+		return
+	}
+
+	var req dto.LearningPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	plan, err := h.learningPlan.CreatePlan(r.Context(), studentID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, plan)
+}
+
+func (h *MentorHandler) UpdateLearningPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := learningPlanID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid learning plan ID"))
+		return
+	}
+
+	var req dto.UpdateLearningPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	plan, err := h.learningPlan.UpdatePlan(r.Context(), id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, plan)
+}
+
+func (h *MentorHandler) ListLearningPlanNotes(w http.ResponseWriter, r *http.Request) {
+	id, err := learningPlanID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid learning plan ID"))
+		return
+	}
+
+	var req dto.GetLessonNotesRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+	req.Pagination.SetDefault()
+
+	notes, meta, err := h.learningPlan.GetMentorNotes(r.Context(), id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, notes, base.SetMetadata(meta))
+}
+
+func (h *MentorHandler) AddLearningPlanMilestone(w http.ResponseWriter, r *http.Request) {
+	id, err := learningPlanID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid learning plan ID"))
+		return
+	}
+
+	var req dto.LearningPlanMilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	plan, err := h.learningPlan.AddMilestone(r.Context(), id, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, plan)
+}
+
+// UpdateLearningPlanMilestone changes a milestone, its status included, the
+// student being told once it is achieved.
+func (h *MentorHandler) UpdateLearningPlanMilestone(w http.ResponseWriter, r *http.Request) {
+	id, err := learningPlanID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid learning plan ID"))
+		return
+	}
+
+	milestoneID, err := uuid.Parse(chi.URLParam(r, "milestoneId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid milestone ID"))
+		return
+	}
+
+	var req dto.LearningPlanMilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	plan, err := h.learningPlan.UpdateMilestone(r.Context(), id, milestoneID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, plan)
+}
+
+func (h *MentorHandler) DeleteLearningPlanMilestone(w http.ResponseWriter, r *http.Request) {
+	id, err := learningPlanID(r)
+	if err != nil {
+		response.Failure(w, base.SetError("invalid learning plan ID"))
+		return
+	}
+
+	milestoneID, err := uuid.Parse(chi.URLParam(r, "milestoneId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid milestone ID"))
+		return
+	}
+
+	if err := h.learningPlan.DeleteMilestone(r.Context(), id, milestoneID); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+// SaveLessonNote writes the lesson note of a session, replacing the one
+// written before.
+func (h *MentorHandler) SaveLessonNote(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid session ID"))
+		return
+	}
+
+	var req dto.LessonNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	note, err := h.learningPlan.SaveLessonNote(r.Context(), sessionID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, note)
+}
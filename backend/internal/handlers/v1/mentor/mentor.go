@@ -2,7 +2,9 @@ package mentor
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -23,8 +25,18 @@ type MentorHandler struct {
 	config        *config.Config
 	mentorStudent *services.MentorStudentService
 	mentorBalance *services.MentorBalanceService
+	bankAccount   *services.MentorBankAccountService
+	financeReport *services.FinanceReportService
 	tutorBooking  *services.TutorBookingService
 	sessionTask   *services.SessionTaskService
+	monthlyReport *services.MonthlyReportService
+	taskLibrary   *services.TaskLibraryService
+	progress      *services.StudentProgressService
+	attendance    *services.BookingAttendanceService
+	mentorGroup   *services.MentorGroupService
+	groupSession  *services.GroupSessionService
+	learningPlan  *services.LearningPlanService
+	quiz          *services.QuizService
 	jwt           *jwt.JWT
 }
 
@@ -32,16 +44,36 @@ func NewMentorHandler(
 	config *config.Config,
 	mentorStudent *services.MentorStudentService,
 	mentorBalance *services.MentorBalanceService,
+	bankAccount *services.MentorBankAccountService,
+	financeReport *services.FinanceReportService,
 	tutorBooking *services.TutorBookingService,
 	sessionTask *services.SessionTaskService,
+	monthlyReport *services.MonthlyReportService,
+	taskLibrary *services.TaskLibraryService,
+	progress *services.StudentProgressService,
+	attendance *services.BookingAttendanceService,
+	mentorGroup *services.MentorGroupService,
+	groupSession *services.GroupSessionService,
+	learningPlan *services.LearningPlanService,
+	quiz *services.QuizService,
 	jwt *jwt.JWT,
 ) *MentorHandler {
 	return &MentorHandler{
 		config:        config,
 		mentorStudent: mentorStudent,
 		mentorBalance: mentorBalance,
+		bankAccount:   bankAccount,
+		financeReport: financeReport,
 		tutorBooking:  tutorBooking,
 		sessionTask:   sessionTask,
+		monthlyReport: monthlyReport,
+		taskLibrary:   taskLibrary,
+		progress:      progress,
+		attendance:    attendance,
+		mentorGroup:   mentorGroup,
+		groupSession:  groupSession,
+		learningPlan:  learningPlan,
+		quiz:          quiz,
 		jwt:           jwt,
 	}
 }
@@ -50,13 +82,26 @@ func (h *MentorHandler) Router(r chi.Router) {
 	r.Post("/join", h.JoinByCode)
 	r.Get("/students", h.ListStudents)
 	r.Get("/students/{studentId}", h.GetStudentDetail)
+	r.Delete("/students/{studentId}", h.RemoveStudent)
+	r.Post("/students/{studentId}/reports/monthly", h.CreateStudentMonthlyReport)
+	r.Get("/students/{studentId}/progress", h.GetStudentProgress)
+	r.Get("/students/{studentId}/learning-plans", h.ListStudentLearningPlans)
+	r.Post("/students/{studentId}/learning-plans", h.CreateStudentLearningPlan)
+	r.Get("/analytics/cohort", h.GetCohortProgress)
 	r.Get("/invite-code", h.GetInviteCode)
 
 	r.Get("/balance", h.GetBalance)
+	r.Put("/balance/currency", h.SetBalanceCurrency)
 	r.Get("/transactions", h.ListTransactions)
 	r.Post("/withdrawals", h.RequestWithdrawal)
 	r.Get("/withdrawals", h.ListWithdrawals)
+	r.Get("/banks", h.ListBanks)
+	r.Get("/bank-accounts", h.ListBankAccounts)
+	r.Post("/bank-accounts", h.CreateBankAccount)
+	r.Post("/bank-accounts/{accountId}/default", h.SetDefaultBankAccount)
+	r.Delete("/bank-accounts/{accountId}", h.DeleteBankAccount)
 	r.Get("/finance/stats", h.GetFinanceStats)
+	r.Get("/finance/statements", h.GetEarningsStatement)
 
 	r.Route("/bookings", func(r chi.Router) {
 		r.Get("/", h.ListSessions)
@@ -66,14 +111,28 @@ func (h *MentorHandler) Router(r chi.Router) {
 		r.Post("/{sessionId}/accept", h.ApproveBooking)
 		r.Post("/{sessionId}/reject", h.DeclineBooking)
 		r.Patch("/{sessionId}/notes", h.UpdateSessionNotes)
+		r.Put("/{sessionId}/lesson-note", h.SaveLessonNote)
+
+		// Attendance
+		r.Post("/{sessionId}/check-in", h.CheckInSession)
+		r.Post("/{sessionId}/check-out", h.CheckOutSession)
+		r.Post("/{sessionId}/no-show", h.ReportStudentNoShow)
+		r.Post("/{sessionId}/no-show/dispute", h.DisputeNoShow)
 
 		// Tasks
 		r.Post("/{sessionId}/tasks", h.CreateSessionTask)
 	})
 
 	r.Route("/tasks", func(r chi.Router) {
+		r.Get("/submissions", h.GetGradingQueue)
+		r.Post("/submissions/{submissionId}/grade", h.GradeTaskSubmission)
 		r.Post("/{taskId}/submissions", h.GradeSessionTask)
 	})
+
+	r.Route("/library", h.libraryRouter)
+	r.Route("/groups", h.groupRouter)
+	r.Route("/group-sessions", h.groupSessionRouter)
+	r.Route("/learning-plans", h.learningPlanRouter)
 }
 
 func (h *MentorHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
@@ -147,7 +206,7 @@ func (h *MentorHandler) JoinByCode(w http.ResponseWriter, r *http.Request) {
 	studentID := claims.UserID
 
 	if err := h.mentorStudent.JoinByCode(r.Context(), req.Code, studentID); err != nil {
-		response.Failure(w, base.SetError(err.Error()))
+		response.Failure(w, base.CustomError(services.Error(err)))
 		return
 	}
 
@@ -214,7 +273,29 @@ func (h *MentorHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.Success(w, http.StatusOK, BalanceResponse{Balance: balance.Balance.String()})
+	response.Success(w, http.StatusOK, BalanceResponse{Balance: balance.Balance.String(), Currency: balance.Currency})
+}
+
+func (h *MentorHandler) SetBalanceCurrency(w http.ResponseWriter, r *http.Request) {
+	var req BalanceCurrencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	claims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		response.Failure(w, base.SetError("invalid token claims"))
+		return
+	}
+
+	balance, err := h.mentorBalance.SetCurrency(r.Context(), claims.UserID, strings.ToUpper(req.Currency))
+	if err != nil {
+		response.Failure(w, base.SetError(err.Error()))
+		return
+	}
+
+	response.Success(w, http.StatusOK, BalanceResponse{Balance: balance.Balance.String(), Currency: balance.Currency})
 }
 
 func (h *MentorHandler) ListTransactions(w http.ResponseWriter, r *http.Request) {
@@ -261,11 +342,17 @@ func (h *MentorHandler) RequestWithdrawal(w http.ResponseWriter, r *http.Request
 	tutorID := claims.UserID
 
 	withdrawal := model.WithdrawalRequest{
-		TutorID:       tutorID,
-		Amount:        decimal.NewFromFloat(req.Amount),
-		BankName:      req.BankName,
-		AccountNumber: req.AccountNumber,
-		AccountName:   req.AccountName,
+		TutorID: tutorID,
+		Amount:  decimal.NewFromFloat(req.Amount),
+	}
+
+	if req.BankAccountID != "" {
+		accountID, err := uuid.Parse(req.BankAccountID)
+		if err != nil {
+			response.Failure(w, base.SetError("invalid bank account ID"))
+			return
+		}
+		withdrawal.BankAccountID = uuid.NullUUID{UUID: accountID, Valid: true}
 	}
 
 	if err := h.mentorBalance.RequestWithdrawal(r.Context(), tutorID, withdrawal); err != nil {
@@ -307,6 +394,99 @@ func (h *MentorHandler) ListWithdrawals(w http.ResponseWriter, r *http.Request)
 	response.Success(w, http.StatusOK, res, base.SetMetadata(meta))
 }
 
+func (h *MentorHandler) ListBanks(w http.ResponseWriter, r *http.Request) {
+	response.Success(w, http.StatusOK, h.bankAccount.ListBanks())
+}
+
+func (h *MentorHandler) ListBankAccounts(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		response.Failure(w, base.SetError("invalid token claims"))
+		return
+	}
+
+	accounts, err := h.bankAccount.List(r.Context(), claims.UserID)
+	if err != nil {
+		response.Failure(w, base.SetError(err.Error()))
+		return
+	}
+
+	res := []BankAccountResponse{}
+	for _, a := range accounts {
+		res = append(res, ToBankAccountResponse(a))
+	}
+
+	response.Success(w, http.StatusOK, res)
+}
+
+func (h *MentorHandler) CreateBankAccount(w http.ResponseWriter, r *http.Request) {
+	var req BankAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	claims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		response.Failure(w, base.SetError("invalid token claims"))
+		return
+	}
+
+	account, err := h.bankAccount.Create(r.Context(), claims.UserID, model.MentorBankAccount{
+		BankCode:      req.BankCode,
+		AccountNumber: req.AccountNumber,
+		AccountName:   req.AccountName,
+	})
+	if err != nil {
+		response.Failure(w, base.SetError(err.Error()))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, ToBankAccountResponse(*account))
+}
+
+func (h *MentorHandler) SetDefaultBankAccount(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		response.Failure(w, base.SetError("invalid token claims"))
+		return
+	}
+
+	accountID, err := uuid.Parse(chi.URLParam(r, "accountId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid bank account ID"))
+		return
+	}
+
+	if err := h.bankAccount.SetDefault(r.Context(), claims.UserID, accountID); err != nil {
+		response.Failure(w, base.SetError(err.Error()))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+func (h *MentorHandler) DeleteBankAccount(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		response.Failure(w, base.SetError("invalid token claims"))
+		return
+	}
+
+	accountID, err := uuid.Parse(chi.URLParam(r, "accountId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid bank account ID"))
+		return
+	}
+
+	if err := h.bankAccount.Delete(r.Context(), claims.UserID, accountID); err != nil {
+		response.Failure(w, base.SetError(err.Error()))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
 func (h *MentorHandler) GetStudentDetail(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetUserClaims(r.Context())
 	if !ok {
@@ -339,6 +519,29 @@ func (h *MentorHandler) GetStudentDetail(w http.ResponseWriter, r *http.Request)
 	response.Success(w, http.StatusOK, res)
 }
 
+// RemoveStudent stops mentoring the student and takes them out of the
+// mentor's groups
+func (h *MentorHandler) RemoveStudent(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		response.Failure(w, base.SetError("invalid token claims"))
+		return
+	}
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid student ID"))
+		return
+	}
+
+	if err := h.mentorStudent.RemoveStudent(r.Context(), claims.UserID, studentID); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
 func (h *MentorHandler) GetSessionDetail(w http.ResponseWriter, r *http.Request) {
 	sessionIDStr := chi.URLParam(r, "sessionId")
 	sessionID, err := uuid.Parse(sessionIDStr)
@@ -454,11 +657,12 @@ func (h *MentorHandler) GetBookingStats(w http.ResponseWriter, r *http.Request)
 	}
 
 	response.Success(w, http.StatusOK, BookingStatsResponse{
-		Pending:   stats.Pending,
-		Accepted:  stats.Accepted,
-		Rejected:  stats.Rejected,
-		Completed: stats.Completed,
-		Total:     stats.Total,
+		Pending:      stats.Pending,
+		Accepted:     stats.Accepted,
+		Rejected:     stats.Rejected,
+		Completed:    stats.Completed,
+		Total:        stats.Total,
+		ResponseTime: stats.ResponseTime,
 	})
 }
 
@@ -506,6 +710,71 @@ func (h *MentorHandler) GetFinanceStats(w http.ResponseWriter, r *http.Request)
 	response.Success(w, http.StatusOK, res)
 }
 
+// GetEarningsStatement downloads the mentor's monthly earnings statement as PDF.
+func (h *MentorHandler) GetEarningsStatement(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		response.Failure(w, base.SetError("invalid token claims"))
+		return
+	}
+
+	var month, year int
+	if _, err := fmt.Sscanf(r.URL.Query().Get("month"), "%d", &month); err != nil || month < 1 || month > 12 {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid month specified"))
+		return
+	}
+	if _, err := fmt.Sscanf(r.URL.Query().Get("year"), "%d", &year); err != nil || year < 2000 {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid year specified"))
+		return
+	}
+
+	pdfBytes, filename, err := h.financeReport.GenerateMentorEarningsStatement(r.Context(), claims.UserID, month, year)
+	if err != nil {
+		response.Failure(w, base.SetError(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	_, _ = w.Write(pdfBytes)
+}
+
+// CreateStudentMonthlyReport queues the monthly report of a student the
+// mentor had sessions with. Poll GET /v1/reports/monthly/{id} for the
+// download URL.
+func (h *MentorHandler) CreateStudentMonthlyReport(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		response.Failure(w, base.SetError("invalid token claims"))
+		return
+	}
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid student ID"))
+		return
+	}
+
+	var req dto.CreateMonthlyReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	report, err := h.monthlyReport.RequestTutorMonthlyReport(r.Context(), claims.UserID, studentID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusAccepted, report)
+}
+
 func (h *MentorHandler) CreateSessionTask(w http.ResponseWriter, r *http.Request) {
 	sessionIDStr := chi.URLParam(r, "sessionId")
 	sessionID, err := uuid.Parse(sessionIDStr)
@@ -520,7 +789,7 @@ func (h *MentorHandler) CreateSessionTask(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	task, err := h.sessionTask.AddTaskToBooking(r.Context(), sessionID, req.Title, null.StringFromPtr(req.Description), null.StringFromPtr(req.AttachmentURL))
+	task, err := h.sessionTask.AddTaskToBooking(r.Context(), sessionID, req.Title, null.StringFromPtr(req.Description), null.StringFromPtr(req.AttachmentURL), null.TimeFromPtr(req.DueAt), req.Rubric, req.QuizID)
 	if err != nil {
 		response.Failure(w, base.SetError(err.Error()))
 		return
@@ -556,3 +825,56 @@ func (h *MentorHandler) GradeSessionTask(w http.ResponseWriter, r *http.Request)
 
 	response.Success(w, http.StatusOK, submission)
 }
+
+// GetGradingQueue lists the submissions of the mentor's students waiting to
+// be graded, the oldest first. Pass status=graded for the graded ones.
+func (h *MentorHandler) GetGradingQueue(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetGradingQueueRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	req.Pagination.SetDefault()
+	submissions, meta, err := h.sessionTask.GetGradingQueue(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, submissions, base.SetMetadata(meta))
+}
+
+// GradeTaskSubmission scores a student's submission with written feedback,
+// per criterion for tasks with a rubric.
+func (h *MentorHandler) GradeTaskSubmission(w http.ResponseWriter, r *http.Request) {
+	submissionID, err := uuid.Parse(chi.URLParam(r, "submissionId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid submission ID"))
+		return
+	}
+
+	var req dto.GradeTaskSubmissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	submission, err := h.sessionTask.GradeSubmission(r.Context(), submissionID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewTaskSubmissionResponse(*submission))
+}
@@ -0,0 +1,222 @@
+package mentor
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// ListQuizQuestions lists the question bank of the mentor, filtered by sub
+// course category, level of education, type or prompt.
+func (h *MentorHandler) ListQuizQuestions(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetQuizQuestionsRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	req.Pagination.SetDefault()
+	questions, meta, err := h.quiz.GetQuestions(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, questions, base.SetMetadata(meta))
+}
+
+func (h *MentorHandler) CreateQuizQuestion(w http.ResponseWriter, r *http.Request) {
+	var req dto.QuizQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	question, err := h.quiz.CreateQuestion(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, question)
+}
+
+// UpdateQuizQuestion changes a question of the bank. Submitted attempts keep
+// their score.
+func (h *MentorHandler) UpdateQuizQuestion(w http.ResponseWriter, r *http.Request) {
+	questionID, err := uuid.Parse(chi.URLParam(r, "questionId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid question ID"))
+		return
+	}
+
+	var req dto.QuizQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	question, err := h.quiz.UpdateQuestion(r.Context(), questionID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, question)
+}
+
+// DeleteQuizQuestion deletes a question from the bank and the quizzes using
+// it.
+func (h *MentorHandler) DeleteQuizQuestion(w http.ResponseWriter, r *http.Request) {
+	questionID, err := uuid.Parse(chi.URLParam(r, "questionId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid question ID"))
+		return
+	}
+
+	if err := h.quiz.DeleteQuestion(r.Context(), questionID); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+func (h *MentorHandler) ListQuizzes(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetQuizzesRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	req.Pagination.SetDefault()
+	quizzes, meta, err := h.quiz.GetQuizzes(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, quizzes, base.SetMetadata(meta))
+}
+
+func (h *MentorHandler) GetQuiz(w http.ResponseWriter, r *http.Request) {
+	quizID, err := uuid.Parse(chi.URLParam(r, "quizId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid quiz ID"))
+		return
+	}
+
+	quiz, err := h.quiz.GetQuiz(r.Context(), quizID)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, quiz)
+}
+
+// CreateQuiz assembles questions of the bank into a quiz, optionally with a
+// time limit. Attach it to a session task with quiz_id.
+func (h *MentorHandler) CreateQuiz(w http.ResponseWriter, r *http.Request) {
+	var req dto.QuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	quiz, err := h.quiz.CreateQuiz(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, quiz)
+}
+
+func (h *MentorHandler) UpdateQuiz(w http.ResponseWriter, r *http.Request) {
+	quizID, err := uuid.Parse(chi.URLParam(r, "quizId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid quiz ID"))
+		return
+	}
+
+	var req dto.QuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	quiz, err := h.quiz.UpdateQuiz(r.Context(), quizID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, quiz)
+}
+
+func (h *MentorHandler) DeleteQuiz(w http.ResponseWriter, r *http.Request) {
+	quizID, err := uuid.Parse(chi.URLParam(r, "quizId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid quiz ID"))
+		return
+	}
+
+	if err := h.quiz.DeleteQuiz(r.Context(), quizID); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+// GetQuizAnalytics shows per question how the students answered the quiz,
+// across every task or for one task with taskId.
+func (h *MentorHandler) GetQuizAnalytics(w http.ResponseWriter, r *http.Request) {
+	quizID, err := uuid.Parse(chi.URLParam(r, "quizId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid quiz ID"))
+		return
+	}
+
+	var req dto.GetQuizAnalyticsRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	analytics, err := h.quiz.GetQuizAnalytics(r.Context(), quizID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, analytics)
+}
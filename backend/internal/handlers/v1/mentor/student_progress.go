@@ -0,0 +1,64 @@
+package mentor
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+func decodeProgressRequest(r *http.Request) (dto.GetStudentProgressRequest, error) {
+	var req dto.GetStudentProgressRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		return req, err
+	}
+
+	return req, req.Validate()
+}
+
+// GetStudentProgress returns the progress of one of the mentor's students
+// per subject in the sessions with the mentor.
+func (h *MentorHandler) GetStudentProgress(w http.ResponseWriter, r *http.Request) {
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid student ID"))
+		return
+	}
+
+	req, err := decodeProgressRequest(r)
+	if err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	progress, err := h.progress.GetMentorStudentProgress(r.Context(), studentID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, progress)
+}
+
+// GetCohortProgress compares the progress of the mentor's students with
+// the cohort of them all, flagging the ones declining.
+func (h *MentorHandler) GetCohortProgress(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeProgressRequest(r)
+	if err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	progress, err := h.progress.GetCohortProgress(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, progress)
+}
@@ -0,0 +1,238 @@
+package mentor
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+func (h *MentorHandler) libraryRouter(r chi.Router) {
+	r.Get("/rubrics", h.ListTaskRubrics)
+	r.Post("/rubrics", h.CreateTaskRubric)
+	r.Put("/rubrics/{rubricId}", h.UpdateTaskRubric)
+	r.Delete("/rubrics/{rubricId}", h.DeleteTaskRubric)
+
+	r.Get("/templates", h.ListTaskTemplates)
+	r.Post("/templates", h.CreateTaskTemplate)
+	r.Put("/templates/{templateId}", h.UpdateTaskTemplate)
+	r.Delete("/templates/{templateId}", h.DeleteTaskTemplate)
+	r.Post("/templates/{templateId}/assign", h.AssignTaskTemplate)
+
+	r.Get("/questions", h.ListQuizQuestions)
+	r.Post("/questions", h.CreateQuizQuestion)
+	r.Put("/questions/{questionId}", h.UpdateQuizQuestion)
+	r.Delete("/questions/{questionId}", h.DeleteQuizQuestion)
+
+	r.Get("/quizzes", h.ListQuizzes)
+	r.Post("/quizzes", h.CreateQuiz)
+	r.Get("/quizzes/{quizId}", h.GetQuiz)
+	r.Put("/quizzes/{quizId}", h.UpdateQuiz)
+	r.Delete("/quizzes/{quizId}", h.DeleteQuiz)
+	r.Get("/quizzes/{quizId}/analytics", h.GetQuizAnalytics)
+}
+
+func decodeLibraryRequest(r *http.Request) (dto.GetTaskLibraryRequest, error) {
+	var req dto.GetTaskLibraryRequest
+	if err := shared.Decoder.Decode(&req, r.URL.Query()); err != nil {
+		return req, err
+	}
+
+	req.Pagination.SetDefault()
+	return req, nil
+}
+
+// ListTaskRubrics lists the grading rubrics of the mentor's library,
+// filtered by course, sub course category or name.
+func (h *MentorHandler) ListTaskRubrics(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeLibraryRequest(r)
+	if err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	rubrics, meta, err := h.taskLibrary.GetRubrics(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, rubrics, base.SetMetadata(meta))
+}
+
+func (h *MentorHandler) CreateTaskRubric(w http.ResponseWriter, r *http.Request) {
+	var req dto.TaskRubricRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	rubric, err := h.taskLibrary.CreateRubric(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, rubric)
+}
+
+// UpdateTaskRubric changes a rubric. Tasks already assigned keep the
+// criteria they were given with.
+func (h *MentorHandler) UpdateTaskRubric(w http.ResponseWriter, r *http.Request) {
+	rubricID, err := uuid.Parse(chi.URLParam(r, "rubricId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid rubric ID"))
+		return
+	}
+
+	var req dto.TaskRubricRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	rubric, err := h.taskLibrary.UpdateRubric(r.Context(), rubricID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, rubric)
+}
+
+func (h *MentorHandler) DeleteTaskRubric(w http.ResponseWriter, r *http.Request) {
+	rubricID, err := uuid.Parse(chi.URLParam(r, "rubricId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid rubric ID"))
+		return
+	}
+
+	if err := h.taskLibrary.DeleteRubric(r.Context(), rubricID); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+// ListTaskTemplates lists the task templates of the mentor's library,
+// filtered by course, sub course category or title.
+func (h *MentorHandler) ListTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeLibraryRequest(r)
+	if err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid Request"), base.SetError(err.Error()))
+		return
+	}
+
+	templates, meta, err := h.taskLibrary.GetTemplates(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, templates, base.SetMetadata(meta))
+}
+
+func (h *MentorHandler) CreateTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.TaskTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	template, err := h.taskLibrary.CreateTemplate(r.Context(), req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, template)
+}
+
+func (h *MentorHandler) UpdateTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, err := uuid.Parse(chi.URLParam(r, "templateId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid template ID"))
+		return
+	}
+
+	var req dto.TaskTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Validation failed"), base.SetError(err.Error()))
+		return
+	}
+
+	template, err := h.taskLibrary.UpdateTemplate(r.Context(), templateID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, template)
+}
+
+func (h *MentorHandler) DeleteTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, err := uuid.Parse(chi.URLParam(r, "templateId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid template ID"))
+		return
+	}
+
+	if err := h.taskLibrary.DeleteTemplate(r.Context(), templateID); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+// AssignTaskTemplate gives the template as a task to one session, or to all
+// upcoming sessions of the mentor's students, or of a group's members, when
+// no booking is set.
+func (h *MentorHandler) AssignTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, err := uuid.Parse(chi.URLParam(r, "templateId"))
+	if err != nil {
+		response.Failure(w, base.SetError("invalid template ID"))
+		return
+	}
+
+	var req dto.AssignTaskTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid JSON format"), base.SetError(err.Error()))
+		return
+	}
+
+	res, err := h.taskLibrary.AssignTemplate(r.Context(), templateID, req)
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, res)
+}
@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetStudentMonthlyReports list monthly reports of the student
+// @Summary List monthly reports of the student
+// @Description List the monthly reports of the student, latest month first. Completed reports carry a download URL
+// @Tags student-entitlement
+// @Produce json
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} base.Base{data=[]dto.MonthlyReportResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/reports/monthly [get]
+func (a *Api) GetStudentMonthlyReports(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetMonthlyReportsRequest
+	)
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentMonthlyReports] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	reports, metadata, err := a.monthlyReport.GetStudentMonthlyReports(ctx, middleware.GetUserID(ctx), request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentMonthlyReports] Error getting monthly reports")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, reports, base.SetMetadata(metadata))
+}
+
+// CreateStudentMonthlyReport request monthly report of the student
+// @Summary Request monthly report of the student
+// @Description Queue the student's own monthly report. Poll the report until it is completed for its download URL. Requires a plan that includes monthly reports
+// @Tags student-entitlement
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateMonthlyReportRequest true "month and year"
+// @Success 202 {object} base.Base{data=dto.MonthlyReportResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/reports/monthly [post]
+func (a *Api) CreateStudentMonthlyReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.CreateMonthlyReportRequest
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentMonthlyReport] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentMonthlyReport] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	report, err := a.monthlyReport.RequestStudentMonthlyReport(ctx, middleware.GetUserID(ctx), request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentMonthlyReport] Error requesting monthly report")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusAccepted, report)
+}
+
+// GetMonthlyReport get a monthly report
+// @Summary Get a monthly report
+// @Description Get the status of a monthly report with its download URL once completed. Available to the student, their guardians and their tutors
+// @Tags monthly-report
+// @Produce json
+// @Param id path string true "monthly report id"
+// @Success 200 {object} base.Base{data=dto.MonthlyReportResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/reports/monthly/{id} [get]
+func (a *Api) GetMonthlyReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMonthlyReport] Error parsing monthly report id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	report, err := a.monthlyReport.GetUserMonthlyReport(ctx, middleware.GetUserID(ctx), id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMonthlyReport] Error getting monthly report")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, report)
+}
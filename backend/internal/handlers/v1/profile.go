@@ -125,7 +125,7 @@ func (a *Api) GetProfile(w http.ResponseWriter, r *http.Request) {
 
 // GetTutorLevel
 // @Summary Get tutor level information
-// @Description Returns the tutor's current level, points, and next level requirements
+// @Description Returns the tutor's current level, progress towards the next level and recent level changes
 // @Tags profile
 // @Accept json
 // @Produce json
@@ -137,7 +137,7 @@ func (a *Api) GetTutorLevel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userId := middleware.GetUserID(ctx)
 
-	info, err := a.profile.GetTutorLevel(ctx, userId)
+	info, err := a.tutorLevel.GetTutorLevel(ctx, userId)
 	if err != nil {
 		response.Failure(w, base.CustomError(services.Error(err)))
 		return
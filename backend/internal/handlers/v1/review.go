@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// VoteHelpfulReview mark review as helpful
+// @Summary Mark review as helpful
+// @Description Mark a published tutor review as helpful
+// @Tags review
+// @Produce json
+// @Param id path string true "ID of review"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/reviews/{id}/helpful [post]
+func (a *Api) VoteHelpfulReview(w http.ResponseWriter, r *http.Request) {
+	a.setHelpfulReview(w, r, true)
+}
+
+// UnvoteHelpfulReview remove helpful mark from review
+// @Summary Remove helpful mark from review
+// @Description Remove the helpful mark the user gave to a tutor review
+// @Tags review
+// @Produce json
+// @Param id path string true "ID of review"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/reviews/{id}/helpful [delete]
+func (a *Api) UnvoteHelpfulReview(w http.ResponseWriter, r *http.Request) {
+	a.setHelpfulReview(w, r, false)
+}
+
+func (a *Api) setHelpfulReview(w http.ResponseWriter, r *http.Request, helpful bool) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SetHelpfulReview] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	err = a.review.SetHelpful(ctx, id, helpful)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SetHelpfulReview] Error set helpful review")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// ReportReview report a review
+// @Summary Report review
+// @Description Report a published tutor review to the moderation queue
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of review"
+// @Param request body dto.ReportReviewRequest true "report review request"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/reviews/{id}/report [post]
+func (a *Api) ReportReview(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.ReportReviewRequest
+	)
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportReview] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportReview] Error validate request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportReview] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	request.ID = id
+	err = a.review.Report(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportReview] Error report review")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// CheckInStudentBooking check in to a session
+// @Summary Check in to a session
+// @Description Record the student turning up to the session of an accepted booking. Check-ins open shortly before the session starts, offline lessons may require the student's location to be near the lesson location
+// @Tags student-booking
+// @Accept json
+// @Produce json
+// @Param id path string true "id of booking"
+// @Param request body dto.CheckInRequest true "check in request"
+// @Success 200 {object} base.Base{data=dto.BookingAttendance}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 409 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/booking/{id}/check-in [post]
+func (a *Api) CheckInStudentBooking(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.CheckInRequest
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CheckInStudentBooking] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CheckInStudentBooking] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CheckInStudentBooking] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	attendance, err := a.bookingAttendance.CheckIn(ctx, id, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CheckInStudentBooking] Error check in")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewBookingAttendance(attendance))
+}
+
+// CheckOutStudentBooking check out of a session
+// @Summary Check out of a session
+// @Description Record the student leaving the session they checked in to
+// @Tags student-booking
+// @Produce json
+// @Param id path string true "id of booking"
+// @Success 200 {object} base.Base{data=dto.BookingAttendance}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 409 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/booking/{id}/check-out [post]
+func (a *Api) CheckOutStudentBooking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CheckOutStudentBooking] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	attendance, err := a.bookingAttendance.CheckOut(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CheckOutStudentBooking] Error check out")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewBookingAttendance(attendance))
+}
+
+// ReportNoShowStudentBooking report the tutor absent
+// @Summary Report the tutor absent
+// @Description Report the tutor did not turn up to the session. The student has to be checked in and the session started for a while. The tutor can dispute the report within the dispute window
+// @Tags student-booking
+// @Accept json
+// @Produce json
+// @Param id path string true "id of booking"
+// @Param request body dto.ReportNoShowRequest true "report no-show request"
+// @Success 200 {object} base.Base{data=dto.BookingAttendance}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 409 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/booking/{id}/no-show [post]
+func (a *Api) ReportNoShowStudentBooking(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.ReportNoShowRequest
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportNoShowStudentBooking] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportNoShowStudentBooking] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportNoShowStudentBooking] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	attendance, err := a.bookingAttendance.ReportNoShow(ctx, id, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportNoShowStudentBooking] Error report no-show")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewBookingAttendance(attendance))
+}
+
+// DisputeNoShowStudentBooking dispute a no-show report
+// @Summary Dispute a no-show report
+// @Description Contest the tutor reporting the student absent, within the dispute window. An admin settles the dispute
+// @Tags student-booking
+// @Accept json
+// @Produce json
+// @Param id path string true "id of booking"
+// @Param request body dto.DisputeNoShowRequest true "dispute no-show request"
+// @Success 200 {object} base.Base{data=dto.BookingAttendance}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 409 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/booking/{id}/no-show/dispute [post]
+func (a *Api) DisputeNoShowStudentBooking(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.DisputeNoShowRequest
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DisputeNoShowStudentBooking] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DisputeNoShowStudentBooking] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DisputeNoShowStudentBooking] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	attendance, err := a.bookingAttendance.DisputeNoShow(ctx, id, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DisputeNoShowStudentBooking] Error dispute no-show")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewBookingAttendance(attendance))
+}
@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetStudentEntitlements get entitlements student
+// @Summary Get entitlements student
+// @Description Get the limits and perks of the student's plan with today's usage
+// @Tags student-entitlement
+// @Accept json
+// @Produce json
+// @Success 200 {object} base.Base{data=dto.StudentEntitlementsResponse}
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/entitlements [get]
+func (a *Api) GetStudentEntitlements(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	resp, err := a.entitlement.GetStudentEntitlements(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
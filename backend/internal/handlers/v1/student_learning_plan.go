@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetStudentLearningPlans list learning plans of the student
+// @Summary List learning plans of the student
+// @Description List the learning plans the tutors set for the student per subject with their milestones, the latest first
+// @Tags student-learning-plan
+// @Produce json
+// @Success 200 {object} base.Base{data=[]dto.LearningPlanResponse}
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/learning-plans [get]
+func (a *Api) GetStudentLearningPlans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	plans, err := a.learningPlan.GetStudentPlans(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentLearningPlans] Error getting learning plans")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, plans)
+}
+
+// GetStudentLessonNotes list lesson notes of a learning plan of the student
+// @Summary List lesson notes of a learning plan of the student
+// @Description List the lesson notes the tutor wrote after the sessions of a learning plan with the milestones they worked on, the latest session first
+// @Tags student-learning-plan
+// @Produce json
+// @Param id path string true "learning plan id"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} base.Base{data=[]dto.LessonNoteResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/learning-plans/{id}/notes [get]
+func (a *Api) GetStudentLessonNotes(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetLessonNotesRequest
+	)
+
+	planID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentLessonNotes] Error parsing learning plan id")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentLessonNotes] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	notes, metadata, err := a.learningPlan.GetStudentNotes(ctx, planID, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentLessonNotes] Error getting lesson notes")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, notes, base.SetMetadata(metadata))
+}
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetStudentProgress get learning progress of the student
+// @Summary Get learning progress of the student
+// @Description Get the student's average task score, task completion and attendance per month over all subjects and per subject, with the trend of each and whether it is declining
+// @Tags student-progress
+// @Produce json
+// @Param months query int false "number of months up to the current one, 6 by default"
+// @Param courseCategoryId query string false "course category (subject) id"
+// @Success 200 {object} base.Base{data=dto.StudentProgressResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/progress [get]
+func (a *Api) GetStudentProgress(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetStudentProgressRequest
+	)
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentProgress] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentProgress] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	progress, err := a.studentProgress.GetStudentProgress(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentProgress] Error getting progress")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, progress)
+}
@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// StartStudentQuiz start the quiz of a task
+// @Summary Start the quiz of a task
+// @Description Start the student's attempt at the quiz of a task, the time limit running from now, or get the attempt already started. An attempt whose time ran out is graded with the answers saved. Correct answers are shown once the attempt is submitted
+// @Tags student-task
+// @Produce json
+// @Param id path string true "task id"
+// @Success 200 {object} base.Base{data=dto.QuizAttemptResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 409 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/tasks/{id}/quiz [post]
+func (a *Api) StartStudentQuiz(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StartStudentQuiz] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	attempt, err := a.quiz.StartQuiz(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StartStudentQuiz] Error starting quiz")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, attempt)
+}
+
+// SaveStudentQuizAnswers save answers of the quiz of a task
+// @Summary Save answers of the quiz of a task
+// @Description Save the student's answers while taking the quiz, until its time limit runs out. Questions left out keep the answer saved before
+// @Tags student-task
+// @Accept json
+// @Produce json
+// @Param id path string true "task id"
+// @Param request body dto.QuizAnswersRequest true "answers"
+// @Success 200 {object} base.Base{data=dto.QuizAttemptResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 409 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/tasks/{id}/quiz/answers [put]
+func (a *Api) SaveStudentQuizAnswers(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.QuizAnswersRequest
+	)
+
+	id, ok := a.decodeQuizAnswers(w, r, "[SaveStudentQuizAnswers]", &request)
+	if !ok {
+		return
+	}
+
+	attempt, err := a.quiz.SaveQuizAnswers(ctx, id, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SaveStudentQuizAnswers] Error saving answers")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, attempt)
+}
+
+// SubmitStudentQuiz submit the quiz of a task
+// @Summary Submit the quiz of a task
+// @Description Finish the student's attempt at the quiz and grade it, writing the score into the task submission. Answers sent after the time limit ran out are ignored
+// @Tags student-task
+// @Accept json
+// @Produce json
+// @Param id path string true "task id"
+// @Param request body dto.QuizAnswersRequest true "answers"
+// @Success 200 {object} base.Base{data=dto.QuizAttemptResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 409 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/tasks/{id}/quiz/submit [post]
+func (a *Api) SubmitStudentQuiz(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.QuizAnswersRequest
+	)
+
+	id, ok := a.decodeQuizAnswers(w, r, "[SubmitStudentQuiz]", &request)
+	if !ok {
+		return
+	}
+
+	attempt, err := a.quiz.SubmitQuiz(ctx, id, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitStudentQuiz] Error submitting quiz")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, attempt)
+}
+
+// decodeQuizAnswers reads the task id and the answers of the request,
+// writing the failure when they are invalid
+func (a *Api) decodeQuizAnswers(w http.ResponseWriter, r *http.Request, handler string, request *dto.QuizAnswersRequest) (uuid.UUID, bool) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg(handler + " Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return uuid.Nil, false
+	}
+
+	// an empty body submits the answers saved before
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		logger.ErrorCtx(ctx).Err(err).Msg(handler + " Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return uuid.Nil, false
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg(handler + " Error validate request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
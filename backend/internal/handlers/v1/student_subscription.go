@@ -225,3 +225,178 @@ func (a *Api) CreateInvoiceStudentSubscription(w http.ResponseWriter, r *http.Re
 
 	response.File(w, filename, buf)
 }
+
+// GetRecurringStudentSubscription get recurring subscription student
+// @Summary Get recurring subscription student
+// @Description Get the recurring subscriptions of the student with their lifecycle status
+// @Tags student-subscription
+// @Produce json
+// @Success 200 {object} base.Base{data=[]dto.StudentRecurringSubscriptionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/subscriptions/recurring [get]
+func (a *Api) GetRecurringStudentSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	subscriptions, err := a.studentSubscription.GetRecurring(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetRecurringStudentSubscription] Error get recurring subscription student")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewStudentRecurringSubscriptionResponses(subscriptions))
+}
+
+// CreateRecurringStudentSubscription create recurring subscription student
+// @Summary Create recurring subscription student
+// @Description Create a recurring subscription renewed automatically every period
+// @Tags student-subscription
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateStudentSubscriptionRequest true "create subscription student request"
+// @Success 201 {object} base.Base{data=dto.CreateStudentSubscriptionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/subscriptions/recurring [post]
+func (a *Api) CreateRecurringStudentSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.CreateStudentSubscriptionRequest
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateRecurringStudentSubscription] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateRecurringStudentSubscription] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	resp, err := a.studentSubscription.Create(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateRecurringStudentSubscription] Error create recurring subscription student")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusCreated, resp)
+}
+
+// CancelRecurringStudentSubscription cancel recurring subscription student
+// @Summary Cancel recurring subscription student
+// @Description Stop the renewal of a recurring subscription. Premium stays active until the end of the period
+// @Tags student-subscription
+// @Produce json
+// @Param id path string true "subscription id"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/subscriptions/recurring/{id}/cancel [post]
+func (a *Api) CancelRecurringStudentSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CancelRecurringStudentSubscription] Error parsing subscription id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = err.Error()
+		})
+		return
+	}
+
+	err = a.studentSubscription.Cancel(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CancelRecurringStudentSubscription] Error cancel recurring subscription student")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// ChangePlanStudentSubscription change plan subscription student
+// @Summary Change plan subscription student
+// @Description Move an active recurring subscription to another plan. The unused period is credited to the new plan
+// @Tags student-subscription
+// @Accept json
+// @Produce json
+// @Param id path string true "subscription id"
+// @Param request body dto.ChangeStudentSubscriptionPlanRequest true "change plan request"
+// @Success 200 {object} base.Base{data=dto.ChangeStudentSubscriptionPlanResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/subscriptions/recurring/{id}/change-plan [post]
+func (a *Api) ChangePlanStudentSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.ChangeStudentSubscriptionPlanRequest
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ChangePlanStudentSubscription] Error parsing subscription id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = err.Error()
+		})
+		return
+	}
+
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ChangePlanStudentSubscription] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err = request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ChangePlanStudentSubscription] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	request.ID = id
+	resp, err := a.studentSubscription.ChangePlan(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ChangePlanStudentSubscription] Error change plan subscription student")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
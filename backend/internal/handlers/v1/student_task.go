@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetStudentTasks list tasks of the student
+// @Summary List tasks of the student
+// @Description List the tasks given by tutors across the student's bookings with the student's submission, the ones due first
+// @Tags student-task
+// @Produce json
+// @Param status query string false "submission status: pending, submitted or graded"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} base.Base{data=[]dto.StudentTaskResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/tasks [get]
+func (a *Api) GetStudentTasks(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.GetStudentTasksRequest
+	)
+
+	if err := decoder.Decode(&request, r.URL.Query()); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentTasks] Error decoding query parameters")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Message = "invalid query parameters"
+			b.Error = err.Error()
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentTasks] Request validation failed")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Validation failed"
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	tasks, metadata, err := a.sessionTask.GetStudentTasks(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentTasks] Error getting tasks")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, tasks, base.SetMetadata(metadata))
+}
+
+// SubmitStudentTask submit work for a task
+// @Summary Submit work for a task
+// @Description Upload one or more files as the student's work for a task. Submitting again replaces the earlier files until the task is due or graded. Submissions after the due date are flagged late
+// @Tags student-task
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "task id"
+// @Param files formData file true "files of the submission, repeat the field for more files"
+// @Success 200 {object} base.Base{data=dto.TaskSubmissionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 403 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 409 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/students/tasks/{id}/submissions [post]
+func (a *Api) SubmitStudentTask(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitStudentTask] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	err = r.ParseMultipartForm(a.config.File.MaxUploadSize)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitStudentTask] Error parsing multipart form")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "failed to parse multipart form"
+		})
+		return
+	}
+
+	submission, err := a.sessionTask.SubmitTask(ctx, id, r.MultipartForm.File["files"])
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitStudentTask] Error submitting task")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewTaskSubmissionResponse(*submission))
+}
@@ -0,0 +1,283 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/services"
+	"github.com/lesprivate/backend/shared/base"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+	"github.com/lesprivate/backend/transport/http/response"
+)
+
+// GetTutorCourseDraft
+// @Summary Get Course Draft
+// @Description Get the active draft of a course with the changes it makes to the live course
+// @Tags tutor-course
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Success 200 {object} base.Base{data=dto.CourseDraftResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/courses/{id}/draft [get]
+func (a *Api) GetTutorCourseDraft(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", idStr).Msg("[GetTutorCourseDraft] Invalid course ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid course ID format"))
+		return
+	}
+
+	resp, err := a.course.GetTutorCourseDraft(ctx, dto.GetTutorCourseRequest{ID: id, UserID: middleware.GetUserID(ctx)})
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// DiscardTutorCourseDraft
+// @Summary Discard Course Draft
+// @Description Discard the unsubmitted edits of an approved course
+// @Tags tutor-course
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Success 200 {object} base.Base
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/courses/{id}/draft [delete]
+func (a *Api) DiscardTutorCourseDraft(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", idStr).Msg("[DiscardTutorCourseDraft] Invalid course ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid course ID format"))
+		return
+	}
+
+	err = a.course.DiscardTutorCourseDraft(ctx, dto.GetTutorCourseRequest{ID: id, UserID: middleware.GetUserID(ctx)})
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// GetTutorCourseDraftConflicts
+// @Summary Check Course Draft Conflicts
+// @Description Check whether the live course changed after the active draft was started
+// @Tags tutor-course
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Success 200 {object} base.Base{data=model.ConflictInfo}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/courses/{id}/draft/conflicts [get]
+func (a *Api) GetTutorCourseDraftConflicts(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", idStr).Msg("[GetTutorCourseDraftConflicts] Invalid course ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid course ID format"))
+		return
+	}
+
+	resp, err := a.course.CheckTutorCourseDraftConflicts(ctx, dto.GetTutorCourseRequest{ID: id, UserID: middleware.GetUserID(ctx)})
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// ResolveTutorCourseDraftConflict
+// @Summary Resolve Course Draft Conflict
+// @Description Keep the draft, refresh it from the live course or submit it anyway
+// @Tags tutor-course
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param request body dto.ResolveCourseDraftConflictRequest true "Conflict resolution"
+// @Success 200 {object} base.Base
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/courses/{id}/draft/resolve [post]
+func (a *Api) ResolveTutorCourseDraftConflict(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		idStr   = chi.URLParam(r, "id")
+		request dto.ResolveCourseDraftConflictRequest
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", idStr).Msg("[ResolveTutorCourseDraftConflict] Invalid course ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid course ID format"))
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ResolveTutorCourseDraftConflict] Error decoding request body")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid request body"))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ResolveTutorCourseDraftConflict] Error validate request body")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid request body"), base.SetError(err.Error()))
+		return
+	}
+
+	request.ID = id
+	request.UserID = middleware.GetUserID(ctx)
+	if err := a.course.ResolveTutorCourseDraftConflict(ctx, request); err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
+
+// PreviewTutorCourse
+// @Summary Preview Course
+// @Description Preview the course as it will look once the active draft is approved
+// @Tags tutor-course
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Success 200 {object} base.Base{data=dto.TutorCourseResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/courses/{id}/preview [get]
+func (a *Api) PreviewTutorCourse(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", idStr).Msg("[PreviewTutorCourse] Invalid course ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid course ID format"))
+		return
+	}
+
+	course, err := a.course.PreviewTutorCourse(ctx, dto.GetTutorCourseRequest{ID: id, UserID: middleware.GetUserID(ctx)})
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, dto.NewTutorCourseResponse(course))
+}
+
+// ListTutorCourseVersions
+// @Summary List Course Versions
+// @Description List the approved versions of a course, newest first
+// @Tags tutor-course
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Success 200 {object} base.Base{data=[]dto.CourseVersionResponse}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/courses/{id}/versions [get]
+func (a *Api) ListTutorCourseVersions(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", idStr).Msg("[ListTutorCourseVersions] Invalid course ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid course ID format"))
+		return
+	}
+
+	resp, err := a.courseVersion.ListVersionsForTutor(ctx, dto.GetTutorCourseRequest{ID: id, UserID: middleware.GetUserID(ctx)})
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// GetTutorCoursePreChecks
+// @Summary Get Course Pre-checks
+// @Description Run the automatic checks a course has to pass before it can be submitted for review
+// @Tags tutor-course
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Success 200 {object} base.Base{data=[]model.CoursePreCheck}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/courses/{id}/pre-checks [get]
+func (a *Api) GetTutorCoursePreChecks(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		idStr = chi.URLParam(r, "id")
+	)
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", idStr).Msg("[GetTutorCoursePreChecks] Invalid course ID format")
+		response.Failure(w, base.SetStatusCode(http.StatusBadRequest), base.SetMessage("Invalid course ID format"))
+		return
+	}
+
+	resp, err := a.course.GetTutorCoursePreChecks(ctx, dto.GetTutorCourseRequest{ID: id, UserID: middleware.GetUserID(ctx)})
+	if err != nil {
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
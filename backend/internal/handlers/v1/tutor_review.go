@@ -137,3 +137,135 @@ func (a *Api) UpdateTutorReview(w http.ResponseWriter, r *http.Request) {
 
 	response.Success(w, http.StatusOK, "success")
 }
+
+// ListReceivedTutorReview list reviews written about the tutor
+// @Summary List received tutor review
+// @Description List reviews students have written about the logged-in tutor
+// @Tags tutor-review
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Items per page" default(10)
+// @Param sort query string false "Sort by field"
+// @Param sortDirection query string false "Sort direction"
+// @Success 200 {object} base.Base{data=[]dto.Review}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/reviews/received [get]
+func (a *Api) ListReceivedTutorReview(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.ListReviewRequest
+	)
+
+	err := decoder.Decode(&request, r.URL.Query())
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListReceivedTutorReview] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	request.Pagination.SetDefault()
+	request.Sort.SetDefault()
+
+	reviews, metadata, err := a.tutorReview.ListReceived(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListReceivedTutorReview] Error list received tutor review")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	resp := make([]dto.Review, len(reviews))
+	for i, b := range reviews {
+		criteria := dto.NewReviewRating(b)
+		resp[i] = dto.Review{
+			ID:                 b.ID,
+			Name:               b.Student.User.Name,
+			Email:              b.Student.User.Email,
+			CourseTitle:        b.Course.Title,
+			PhotoProfile:       b.Student.PhotoProfile,
+			Review:             b.Review,
+			Rate:               b.Rate,
+			Criteria:           &criteria,
+			IsReviewed:         b.IsSubmitted,
+			RecommendByStudent: b.RecommendByStudent,
+			TutorReply:         b.TutorReply,
+			HelpfulCount:       b.HelpfulCount,
+			ModerationStatus:   string(b.ModerationStatus),
+			CreatedAt:          b.CreatedAt,
+			UpdatedAt:          b.UpdatedAt,
+		}
+	}
+
+	response.Success(w, http.StatusOK, resp, base.SetMetadata(metadata))
+}
+
+// ReplyTutorReview reply to a review
+// @Summary Reply tutor review
+// @Description Publicly reply to a review written about the logged-in tutor
+// @Tags tutor-review
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of review"
+// @Param request body dto.ReplyReviewRequest true "reply review request"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 401 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Security BearerAuth
+// @Router /v1/tutors/reviews/received/{id}/reply [put]
+func (a *Api) ReplyTutorReview(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.ReplyReviewRequest
+	)
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyTutorReview] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyTutorReview] Error validate request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyTutorReview] Error parse id")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	request.ID = id
+	err = a.tutorReview.Reply(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyTutorReview] Error reply tutor review")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
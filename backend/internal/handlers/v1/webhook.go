@@ -57,3 +57,48 @@ func (a *Api) WebhookXendit(w http.ResponseWriter, r *http.Request) {
 
 	response.Success(w, http.StatusOK, "success")
 }
+
+// WebhookXenditDisbursement webhook xendit disbursement
+// @Summary webhook xendit disbursement
+// @Description webhook xendit disbursement, settles mentor payouts
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param X-CALLBACK-TOKEN header string true "callback token"
+// @Param request body dto.WebhookXenditDisbursement true "disbursement callback"
+// @Success 200 {object} base.Base{data=string}
+// @Failure 400 {object} base.Base
+// @Failure 404 {object} base.Base
+// @Failure 500 {object} base.Base
+// @Router /v1/webhook/xendit/disbursement [post]
+func (a *Api) WebhookXenditDisbursement(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		request dto.WebhookXenditDisbursement
+	)
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[WebhookXenditDisbursement] Error decoding request body")
+		response.Failure(w, func(b *base.Base) {
+			b.StatusCode = http.StatusBadRequest
+			b.Error = err.Error()
+			b.Message = "Invalid request body"
+		})
+		return
+	}
+
+	logger.InfoCtx(ctx).
+		Str("X-CALLBACK-TOKEN", r.Header.Get("X-CALLBACK-TOKEN")).
+		Interface("request", request).
+		Msg("[WebhookXenditDisbursement] handle webhook xendit disbursement")
+
+	request.WebhookKey = r.Header.Get("X-CALLBACK-TOKEN")
+	err := a.webhook.HandleWebhookXenditDisbursement(ctx, request)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[WebhookXenditDisbursement] Error handle webhook xendit disbursement")
+		response.Failure(w, base.CustomError(services.Error(err)))
+		return
+	}
+
+	response.Success(w, http.StatusOK, "success")
+}
@@ -18,12 +18,35 @@ const (
 	BookingStatusAccepted BookingStatus = "accepted"
 	BookingStatusDeclined BookingStatus = "declined"
 	BookingStatusExpired  BookingStatus = "expired"
+	// An accepted booking ends completed once the session was held, or
+	// no_show when one of the parties did not turn up.
+	BookingStatusCompleted BookingStatus = "completed"
+	BookingStatusNoShow    BookingStatus = "no_show"
+	// Seats of a group session which did not get enough students end
+	// cancelled.
+	BookingStatusCancelled BookingStatus = "cancelled"
 )
 
+// BookedStatuses are the statuses of bookings counting against the booking
+// quotas of the student.
+var BookedStatuses = []BookingStatus{
+	BookingStatusPending,
+	BookingStatusAccepted,
+	BookingStatusCompleted,
+	BookingStatusNoShow,
+}
+
+// IsConfirmed reports whether the tutor accepted the booking, whatever
+// became of the session afterwards.
+func (s BookingStatus) IsConfirmed() bool {
+	return s == BookingStatusAccepted || s == BookingStatusCompleted || s == BookingStatusNoShow
+}
+
 type Booking struct {
 	ID                uuid.UUID       `gorm:"type:char(36);primary_key" json:"id"`
 	Code              string          `gorm:"type:varchar(50);not null" json:"code"`
 	CourseID          uuid.UUID       `gorm:"type:char(36);not null" json:"course_id"`
+	GroupSessionID    uuid.NullUUID   `gorm:"type:char(36)" json:"group_session_id"` // set on the seats of a group session
 	TutorID           uuid.UUID       `gorm:"type:char(36);not null" json:"tutor_id"`
 	StudentID         uuid.UUID       `gorm:"type:char(36);not null" json:"student_id"`
 	ClassType         ClassType       `gorm:"type:varchar(255);not null" json:"class_type"`
@@ -35,8 +58,10 @@ type Booking struct {
 	NotesTutor        null.String     `json:"notes_tutor"`   // notes for tutor
 	NotesStudent      null.String     `json:"notes_student"` // notes for student
 	IsFreeFirstCourse bool            `json:"is_free_first_course"`
+	IsPriority        bool            `json:"is_priority"`
 	Status            BookingStatus   `gorm:"type:varchar(255);not null" json:"status"`
 	IsReviewed        bool            `json:"is_reviewed"`
+	CompletedAt       null.Time       `json:"completed_at"`
 	ExpiredAt         time.Time       `json:"expired_at"`
 	CreatedAt         time.Time       `json:"created_at"`
 	UpdatedAt         time.Time       `json:"updated_at"`
@@ -50,15 +75,44 @@ type Booking struct {
 	Course        Course        `gorm:"foreignKey:CourseID" json:"course"`
 	ReportBooking ReportBooking `gorm:"foreignKey:BookingID" json:"report_booking"`
 	SessionTasks  []SessionTask `gorm:"foreignKey:BookingID" json:"session_tasks"`
+	LessonNote    *LessonNote   `gorm:"foreignKey:BookingID" json:"lesson_note,omitempty"`
+
+	Attendance *BookingAttendance `gorm:"foreignKey:BookingID" json:"attendance,omitempty"`
+	Meeting    *BookingMeeting    `gorm:"foreignKey:BookingID" json:"meeting,omitempty"`
 }
 
+// GetStatus returns the status of the booking, pending bookings the tutor
+// did not answer in time being expired. Seats of a group session are not
+// answered by the tutor, they stay pending until the session is confirmed
+// or cancelled.
 func (b *Booking) GetStatus() BookingStatus {
-	if b.Status == BookingStatusPending && time.Now().After(b.ExpiredAt) {
+	if b.Status == BookingStatusPending && !b.GroupSessionID.Valid && time.Now().After(b.ExpiredAt) {
 		return BookingStatusExpired
 	}
 	return b.Status
 }
 
+// StartsAt returns when the session starts, in the server's time zone like
+// the queries on booking_date and booking_time.
+func (b *Booking) StartsAt() time.Time {
+	start, err := time.Parse(time.TimeOnly, b.BookingTime)
+	if err != nil {
+		start, _ = time.Parse("15:04", b.BookingTime)
+	}
+
+	return time.Date(b.BookingDate.Year(), b.BookingDate.Month(), b.BookingDate.Day(),
+		start.Hour(), start.Minute(), start.Second(), 0, time.Local)
+}
+
+// JoinURL returns the link to the video classroom of the session while it
+// can be joined.
+func (b *Booking) JoinURL() string {
+	if b.Meeting == nil || !b.Meeting.IsOpen(time.Now()) {
+		return ""
+	}
+	return b.Meeting.JoinURL
+}
+
 func (b *Booking) GenerateCode() {
 	const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	const length = 5
@@ -83,6 +137,7 @@ type BookingFilter struct {
 	NotIDs                 []uuid.UUID
 	CourseCategoryID       uuid.UUID
 	StudentID              uuid.UUID
+	StudentIDs             []uuid.UUID
 	TutorID                uuid.UUID
 	CourseID               uuid.UUID
 	CourseIDs              []uuid.UUID
@@ -90,6 +145,8 @@ type BookingFilter struct {
 	TutorName              string
 	BookingDate            time.Time
 	BookingDateBetween     []string
+	BookingDateFrom        time.Time
+	BookingDateUntil       time.Time
 	BookingTime            time.Time
 	ExpiredAtBefore        time.Time
 	ExpiredAtBetween       []time.Time
@@ -101,7 +158,10 @@ type BookingFilter struct {
 	DateCreatedAt          time.Time
 	IsFreeFirstCourse      null.Bool
 	IsReviewed             null.Bool
+	IsGroupSession         null.Bool
+	GroupSessionID         uuid.UUID
 	DeletedAtIsNil         null.Bool
+	WithProgress           bool // preloads the report and the scored tasks
 	Pagination
 	Sort
 }
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+type AttendanceParty string
+
+const (
+	AttendancePartyTutor   AttendanceParty = "tutor"
+	AttendancePartyStudent AttendanceParty = "student"
+)
+
+// Other returns the party on the other side of the session.
+func (p AttendanceParty) Other() AttendanceParty {
+	if p == AttendancePartyTutor {
+		return AttendancePartyStudent
+	}
+	return AttendancePartyTutor
+}
+
+// CheckInColumns are the columns CheckIn sets for the party.
+func (p AttendanceParty) CheckInColumns() []string {
+	return []string{string(p) + "_check_in_at", string(p) + "_latitude", string(p) + "_longitude"}
+}
+
+// CheckOutColumn is the column CheckOut sets for the party.
+func (p AttendanceParty) CheckOutColumn() string {
+	return string(p) + "_check_out_at"
+}
+
+// BookingAttendance records who turned up to the session of a booking. A
+// no-show names the party who did not turn up, who may dispute it until
+// DisputeUntil, disputes being settled by an admin.
+type BookingAttendance struct {
+	ID                uuid.UUID           `gorm:"type:char(36);primaryKey" json:"id"`
+	BookingID         uuid.UUID           `gorm:"type:char(36);not null" json:"booking_id"`
+	TutorCheckInAt    null.Time           `json:"tutor_check_in_at"`
+	TutorCheckOutAt   null.Time           `json:"tutor_check_out_at"`
+	TutorLatitude     decimal.NullDecimal `gorm:"type:decimal(8,6)" json:"tutor_latitude"`
+	TutorLongitude    decimal.NullDecimal `gorm:"type:decimal(9,6)" json:"tutor_longitude"`
+	StudentCheckInAt  null.Time           `json:"student_check_in_at"`
+	StudentCheckOutAt null.Time           `json:"student_check_out_at"`
+	StudentLatitude   decimal.NullDecimal `gorm:"type:decimal(8,6)" json:"student_latitude"`
+	StudentLongitude  decimal.NullDecimal `gorm:"type:decimal(9,6)" json:"student_longitude"`
+	DurationMinutes   null.Int            `json:"duration_minutes"`
+	NoShowParty       null.String         `gorm:"type:enum('tutor','student')" json:"no_show_party"`
+	NoShowReason      null.String         `gorm:"type:text" json:"no_show_reason"`
+	NoShowReportedBy  uuid.NullUUID       `gorm:"type:char(36)" json:"no_show_reported_by"`
+	NoShowReportedAt  null.Time           `json:"no_show_reported_at"`
+	DisputeUntil      null.Time           `json:"dispute_until"`
+	DisputedAt        null.Time           `json:"disputed_at"`
+	DisputeReason     null.String         `gorm:"type:text" json:"dispute_reason"`
+	ResolvedAt        null.Time           `json:"resolved_at"`
+	ResolvedBy        uuid.NullUUID       `gorm:"type:char(36)" json:"resolved_by"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+
+	Booking *Booking `gorm:"foreignKey:BookingID" json:"-"`
+}
+
+func (BookingAttendance) TableName() string {
+	return "booking_attendances"
+}
+
+func (a *BookingAttendance) CheckedIn(party AttendanceParty) bool {
+	if party == AttendancePartyTutor {
+		return a.TutorCheckInAt.Valid
+	}
+	return a.StudentCheckInAt.Valid
+}
+
+func (a *BookingAttendance) CheckedOut(party AttendanceParty) bool {
+	if party == AttendancePartyTutor {
+		return a.TutorCheckOutAt.Valid
+	}
+	return a.StudentCheckOutAt.Valid
+}
+
+// CheckIn records the party turning up, with where they were when given.
+func (a *BookingAttendance) CheckIn(party AttendanceParty, at time.Time, latitude, longitude *float64) {
+	var lat, long decimal.NullDecimal
+	if latitude != nil && longitude != nil {
+		lat = decimal.NewNullDecimal(decimal.NewFromFloat(*latitude))
+		long = decimal.NewNullDecimal(decimal.NewFromFloat(*longitude))
+	}
+
+	if party == AttendancePartyTutor {
+		a.TutorCheckInAt = null.TimeFrom(at)
+		a.TutorLatitude, a.TutorLongitude = lat, long
+		return
+	}
+	a.StudentCheckInAt = null.TimeFrom(at)
+	a.StudentLatitude, a.StudentLongitude = lat, long
+}
+
+func (a *BookingAttendance) CheckOut(party AttendanceParty, at time.Time) {
+	if party == AttendancePartyTutor {
+		a.TutorCheckOutAt = null.TimeFrom(at)
+		return
+	}
+	a.StudentCheckOutAt = null.TimeFrom(at)
+}
+
+// Complete sets the actual duration, from when both parties were checked in
+// until the first of them checked out. Without a check-out the duration is
+// unknown.
+func (a *BookingAttendance) Complete() {
+	if !a.TutorCheckInAt.Valid || !a.StudentCheckInAt.Valid {
+		return
+	}
+
+	start := a.TutorCheckInAt.Time
+	if a.StudentCheckInAt.Time.After(start) {
+		start = a.StudentCheckInAt.Time
+	}
+
+	var end null.Time
+	for _, checkOut := range []null.Time{a.TutorCheckOutAt, a.StudentCheckOutAt} {
+		if checkOut.Valid && (!end.Valid || checkOut.Time.Before(end.Time)) {
+			end = checkOut
+		}
+	}
+
+	if !end.Valid || end.Time.Before(start) {
+		return
+	}
+
+	a.DurationMinutes = null.IntFrom(int64(end.Time.Sub(start).Minutes()))
+}
+
+// IsDisputed reports whether a no-show is disputed and waits for an admin.
+func (a *BookingAttendance) IsDisputed() bool {
+	return a.DisputedAt.Valid && !a.ResolvedAt.Valid
+}
+
+// CanDispute reports whether the party blamed for a no-show may still
+// dispute it.
+func (a *BookingAttendance) CanDispute(party AttendanceParty, now time.Time) bool {
+	return a.NoShowParty.String == string(party) &&
+		!a.DisputedAt.Valid &&
+		a.DisputeUntil.Valid && now.Before(a.DisputeUntil.Time)
+}
+
+// DistanceMeters returns the great-circle distance between two coordinates.
+func DistanceMeters(lat1, long1, lat2, long2 float64) float64 {
+	const earthRadius = 6371000
+
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLong := toRad(long2 - long1)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+func TestAttendanceParty_Other(t *testing.T) {
+	if got := AttendancePartyTutor.Other(); got != AttendancePartyStudent {
+		t.Errorf("Other() = %s, want %s", got, AttendancePartyStudent)
+	}
+	if got := AttendancePartyStudent.Other(); got != AttendancePartyTutor {
+		t.Errorf("Other() = %s, want %s", got, AttendancePartyTutor)
+	}
+}
+
+func TestBookingAttendance_CheckIn(t *testing.T) {
+	at := time.Date(2026, 3, 10, 9, 58, 0, 0, time.UTC)
+	latitude, longitude := -6.2, 106.816666
+
+	attendance := BookingAttendance{}
+	attendance.CheckIn(AttendancePartyTutor, at, &latitude, &longitude)
+	attendance.CheckIn(AttendancePartyStudent, at, &latitude, nil)
+
+	if !attendance.CheckedIn(AttendancePartyTutor) || !attendance.CheckedIn(AttendancePartyStudent) {
+		t.Fatalf("CheckedIn() = false, want both parties checked in")
+	}
+	if attendance.TutorLatitude.Decimal.InexactFloat64() != latitude || attendance.TutorLongitude.Decimal.InexactFloat64() != longitude {
+		t.Errorf("tutor location = (%v, %v), want (%v, %v)", attendance.TutorLatitude, attendance.TutorLongitude, latitude, longitude)
+	}
+	// A half given location is not kept
+	if attendance.StudentLatitude.Valid || attendance.StudentLongitude.Valid {
+		t.Errorf("student location = (%v, %v), want none", attendance.StudentLatitude, attendance.StudentLongitude)
+	}
+
+	attendance.CheckOut(AttendancePartyStudent, at.Add(time.Hour))
+	if attendance.CheckedOut(AttendancePartyTutor) || !attendance.CheckedOut(AttendancePartyStudent) {
+		t.Errorf("CheckedOut() = (%v, %v), want only the student checked out",
+			attendance.CheckedOut(AttendancePartyTutor), attendance.CheckedOut(AttendancePartyStudent))
+	}
+}
+
+func TestBookingAttendance_Complete(t *testing.T) {
+	start := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) null.Time { return null.TimeFrom(start.Add(time.Duration(minutes) * time.Minute)) }
+
+	tests := []struct {
+		name       string
+		attendance BookingAttendance
+		want       null.Int
+	}{
+		{
+			name:       "from the last check-in to the first check-out",
+			attendance: BookingAttendance{TutorCheckInAt: at(-5), StudentCheckInAt: at(3), TutorCheckOutAt: at(95), StudentCheckOutAt: at(90)},
+			want:       null.IntFrom(87),
+		},
+		{
+			name:       "one check-out",
+			attendance: BookingAttendance{TutorCheckInAt: at(0), StudentCheckInAt: at(0), TutorCheckOutAt: at(60)},
+			want:       null.IntFrom(60),
+		},
+		{
+			name:       "no check-out",
+			attendance: BookingAttendance{TutorCheckInAt: at(0), StudentCheckInAt: at(0)},
+		},
+		{
+			name:       "student never checked in",
+			attendance: BookingAttendance{TutorCheckInAt: at(0), TutorCheckOutAt: at(60)},
+		},
+		{
+			name:       "checked out before the other checked in",
+			attendance: BookingAttendance{TutorCheckInAt: at(0), TutorCheckOutAt: at(10), StudentCheckInAt: at(20)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attendance.Complete()
+			if tt.attendance.DurationMinutes != tt.want {
+				t.Errorf("DurationMinutes = %v, want %v", tt.attendance.DurationMinutes, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookingAttendance_CanDispute(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	noShow := func() BookingAttendance {
+		return BookingAttendance{
+			NoShowParty:  null.StringFrom(string(AttendancePartyTutor)),
+			DisputeUntil: null.TimeFrom(now.Add(time.Hour)),
+		}
+	}
+
+	attendance := noShow()
+	if !attendance.CanDispute(AttendancePartyTutor, now) {
+		t.Errorf("CanDispute() = false, want true for the party blamed")
+	}
+	if attendance.CanDispute(AttendancePartyStudent, now) {
+		t.Errorf("CanDispute() = true, want false for the party who reported")
+	}
+	if attendance.CanDispute(AttendancePartyTutor, now.Add(time.Hour)) {
+		t.Errorf("CanDispute() = true, want false once the window closed")
+	}
+	if attendance.IsDisputed() {
+		t.Errorf("IsDisputed() = true, want false before the dispute")
+	}
+
+	attendance.DisputedAt = null.TimeFrom(now)
+	if attendance.CanDispute(AttendancePartyTutor, now) {
+		t.Errorf("CanDispute() = true, want false once disputed")
+	}
+	if !attendance.IsDisputed() {
+		t.Errorf("IsDisputed() = false, want true until resolved")
+	}
+
+	attendance.ResolvedAt = null.TimeFrom(now)
+	if attendance.IsDisputed() {
+		t.Errorf("IsDisputed() = true, want false once resolved")
+	}
+
+	if attendance := (BookingAttendance{}); attendance.CanDispute(AttendancePartyTutor, now) {
+		t.Errorf("CanDispute() = true, want false without a no-show")
+	}
+}
+
+func TestDistanceMeters(t *testing.T) {
+	if got := DistanceMeters(-6.2, 106.8, -6.2, 106.8); got != 0 {
+		t.Errorf("DistanceMeters() = %v, want 0 for the same place", got)
+	}
+
+	// One thousandth of a degree of latitude is about 111 meters
+	if got := DistanceMeters(-6.2, 106.8, -6.201, 106.8); math.Abs(got-111.2) > 0.5 {
+		t.Errorf("DistanceMeters() = %v, want about 111.2", got)
+	}
+}
+
+func TestBooking_StartsAt(t *testing.T) {
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2026, 3, 10, 14, 30, 0, 0, time.Local)
+
+	for _, bookingTime := range []string{"14:30:00", "14:30"} {
+		booking := Booking{BookingDate: date, BookingTime: bookingTime}
+		if got := booking.StartsAt(); !got.Equal(want) {
+			t.Errorf("StartsAt() with %s = %v, want %v", bookingTime, got, want)
+		}
+	}
+}
+
+func TestBookingStatus_IsConfirmed(t *testing.T) {
+	tests := []struct {
+		status BookingStatus
+		want   bool
+	}{
+		{BookingStatusPending, false},
+		{BookingStatusDeclined, false},
+		{BookingStatusAccepted, true},
+		{BookingStatusCompleted, true},
+		{BookingStatusNoShow, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.IsConfirmed(); got != tt.want {
+			t.Errorf("%s.IsConfirmed() = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestAttendanceParty_Columns(t *testing.T) {
+	want := []string{"student_check_in_at", "student_latitude", "student_longitude"}
+	if got := AttendancePartyStudent.CheckInColumns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckInColumns() = %v, want %v", got, want)
+	}
+	if got := AttendancePartyTutor.CheckOutColumn(); got != "tutor_check_out_at" {
+		t.Errorf("CheckOutColumn() = %s, want tutor_check_out_at", got)
+	}
+}
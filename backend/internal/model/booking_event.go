@@ -0,0 +1,78 @@
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+)
+
+type BookingEventType string
+
+const (
+	BookingEventCreated  BookingEventType = "created"
+	BookingEventApproved BookingEventType = "approved"
+	BookingEventDeclined BookingEventType = "declined"
+	BookingEventExpired  BookingEventType = "expired"
+	// The session of the booking was held or one party did not turn up
+	BookingEventCompleted BookingEventType = "completed"
+	BookingEventNoShow    BookingEventType = "no_show"
+	// The group session of the seat did not get enough students
+	BookingEventCancelled BookingEventType = "cancelled"
+)
+
+// BookingEventTypeByStatus maps the status a booking moved to onto the
+// lifecycle event that records it.
+var BookingEventTypeByStatus = map[BookingStatus]BookingEventType{
+	BookingStatusAccepted:  BookingEventApproved,
+	BookingStatusDeclined:  BookingEventDeclined,
+	BookingStatusExpired:   BookingEventExpired,
+	BookingStatusCompleted: BookingEventCompleted,
+	BookingStatusNoShow:    BookingEventNoShow,
+	BookingStatusCancelled: BookingEventCancelled,
+}
+
+// BookingEvent is one step in the lifecycle of a booking. The events are
+// append-only and drive the tutor response time.
+type BookingEvent struct {
+	ID         uuid.UUID        `gorm:"type:char(36);primaryKey" json:"id"`
+	BookingID  uuid.UUID        `gorm:"type:char(36);not null;index" json:"booking_id"`
+	TutorID    uuid.UUID        `gorm:"type:char(36);not null" json:"tutor_id"`
+	Type       BookingEventType `gorm:"type:varchar(50);not null" json:"type"`
+	OccurredAt time.Time        `gorm:"not null" json:"occurred_at"`
+	ActorID    uuid.NullUUID    `gorm:"type:char(36)" json:"actor_id"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+func (BookingEvent) TableName() string {
+	return "booking_events"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (e *BookingEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// MedianMinutes returns the median of the given durations in minutes, or an
+// invalid value when there is nothing to measure.
+func MedianMinutes(minutes []int64) null.Int {
+	if len(minutes) == 0 {
+		return null.Int{}
+	}
+
+	sorted := make([]int64, len(minutes))
+	copy(sorted, minutes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return null.IntFrom(sorted[mid])
+	}
+
+	return null.IntFrom((sorted[mid-1] + sorted[mid]) / 2)
+}
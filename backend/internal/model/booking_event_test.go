@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/guregu/null/v6"
+)
+
+func TestMedianMinutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes []int64
+		want    null.Int
+	}{
+		{name: "nothing to measure", minutes: nil, want: null.Int{}},
+		{name: "single answer", minutes: []int64{42}, want: null.IntFrom(42)},
+		{name: "odd count", minutes: []int64{300, 5, 20}, want: null.IntFrom(20)},
+		{name: "even count averages the middle two", minutes: []int64{60, 10, 30, 1000}, want: null.IntFrom(45)},
+		{name: "even count rounds down", minutes: []int64{1, 2}, want: null.IntFrom(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MedianMinutes(tt.minutes); got != tt.want {
+				t.Errorf("MedianMinutes(%v) = %v, want %v", tt.minutes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMedianMinutesKeepsInput(t *testing.T) {
+	minutes := []int64{30, 10, 20}
+	MedianMinutes(minutes)
+
+	if minutes[0] != 30 || minutes[1] != 10 || minutes[2] != 20 {
+		t.Errorf("MedianMinutes() reordered its input to %v", minutes)
+	}
+}
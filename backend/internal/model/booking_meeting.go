@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// BookingMeeting is the video classroom of an online session, open until
+// ExpiresAt after which it is closed at the provider.
+type BookingMeeting struct {
+	ID        uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	BookingID uuid.UUID `gorm:"type:char(36);not null" json:"booking_id"`
+	Provider  string    `gorm:"type:varchar(20);not null" json:"provider"`
+	RoomID    string    `gorm:"type:varchar(255);not null" json:"room_id"`
+	JoinURL   string    `gorm:"type:text;not null" json:"join_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ExpiredAt null.Time `json:"expired_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Booking *Booking `gorm:"foreignKey:BookingID" json:"-"`
+}
+
+func (BookingMeeting) TableName() string {
+	return "booking_meetings"
+}
+
+// IsOpen reports whether the room can still be joined.
+func (m *BookingMeeting) IsOpen(now time.Time) bool {
+	return !m.ExpiredAt.Valid && now.Before(m.ExpiresAt)
+}
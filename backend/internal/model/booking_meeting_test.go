@@ -0,0 +1,47 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+func TestBookingMeeting_IsOpen(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		meeting BookingMeeting
+		want    bool
+	}{
+		{name: "open", meeting: BookingMeeting{ExpiresAt: now.Add(time.Minute)}, want: true},
+		{name: "past its lifetime", meeting: BookingMeeting{ExpiresAt: now}},
+		{name: "expired early", meeting: BookingMeeting{ExpiresAt: now.Add(time.Hour), ExpiredAt: null.TimeFrom(now)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.meeting.IsOpen(now); got != tt.want {
+				t.Errorf("IsOpen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBooking_JoinURL(t *testing.T) {
+	booking := Booking{}
+	if got := booking.JoinURL(); got != "" {
+		t.Errorf("JoinURL() = %s, want none without a meeting", got)
+	}
+
+	booking.Meeting = &BookingMeeting{JoinURL: "https://meet.lesprivate.id/room", ExpiresAt: time.Now().Add(time.Hour)}
+	if got := booking.JoinURL(); got != "https://meet.lesprivate.id/room" {
+		t.Errorf("JoinURL() = %s, want the room of the meeting", got)
+	}
+
+	booking.Meeting.ExpiresAt = time.Now().Add(-time.Hour)
+	if got := booking.JoinURL(); got != "" {
+		t.Errorf("JoinURL() = %s, want none once the room closed", got)
+	}
+}
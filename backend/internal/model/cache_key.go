@@ -11,6 +11,7 @@ const (
 	PasswordResetKey          = "password-reset:%s"
 	ReminderExpiredBookingKey = "reminder-expired-booking:%s"
 	ReminderCourseBookingKey  = "reminder-course-booking:%s"
+	ConversationEventsChannel = "conversation-events:%s"
 )
 
 func BuildCacheKey(key string, args ...any) string {
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// phonePattern finds Indonesian mobile numbers, local or international,
+	// with the separators people type in them
+	phonePattern = regexp.MustCompile(`(?:\+?62|0)[\s.\-]?8[\d\s.\-]{6,}\d`)
+
+	// linkPatterns find links, link shorteners and social media handles.
+	linkPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(https?://|www\.)\S+`),
+		regexp.MustCompile(`(?i)\b(wa\.me|t\.me|bit\.ly|linktr\.ee)/?`),
+		regexp.MustCompile(`(?i)\b(instagram|ig|tiktok|telegram|line)\s*[:=]\s*@?\w+`),
+	}
+)
+
+// minPhoneDigits is the length of the shortest Indonesian mobile number.
+const minPhoneDigits = 9
+
+// isPhoneNumber reports whether a phonePattern match has enough digits to be
+// a mobile number rather than, say, a price or a date.
+func isPhoneNumber(match string) bool {
+	digits := 0
+	for _, r := range match {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return digits >= minPhoneDigits
+}
+
+// ContainsContactDetails reports whether the text holds a phone number, email
+// address, link or social media handle.
+func ContainsContactDetails(text string) bool {
+	if emailPattern.MatchString(text) {
+		return true
+	}
+
+	for _, phone := range phonePattern.FindAllString(text, -1) {
+		if isPhoneNumber(phone) {
+			return true
+		}
+	}
+
+	for _, pattern := range linkPatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskContactDetails hides the email addresses and phone numbers in text the
+// same way contact details are hidden in unconfirmed bookings, reporting
+// whether anything was masked.
+func MaskContactDetails(text string) (string, bool) {
+	masked := false
+
+	text = emailPattern.ReplaceAllStringFunc(text, func(email string) string {
+		masked = true
+		at := strings.LastIndex(email, "@")
+		return strings.Repeat("*", at) + email[at:]
+	})
+
+	text = phonePattern.ReplaceAllStringFunc(text, func(phone string) string {
+		if !isPhoneNumber(phone) {
+			return phone
+		}
+
+		masked = true
+		kept := 0
+		return strings.Map(func(r rune) rune {
+			if !unicode.IsDigit(r) {
+				return r
+			}
+			if kept < 2 {
+				kept++
+				return r
+			}
+			return '*'
+		}, phone)
+	})
+
+	return text, masked
+}
@@ -0,0 +1,77 @@
+package model
+
+import "testing"
+
+func TestContainsContactDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{name: "plain description", text: "Belajar matematika SMA kelas 10 sampai 12", want: false},
+		{name: "local phone number", text: "Hubungi 0812-3456-7890", want: true},
+		{name: "international phone number", text: "WA +62 812 3456 7890", want: true},
+		{name: "phone number with dots", text: "0812.3456.7890", want: true},
+		{name: "short number", text: "Kode kelas 0812345", want: false},
+		{name: "class times", text: "Jadwal 08.00-10.00", want: false},
+		{name: "email address", text: "email saya tutor.budi@gmail.com", want: true},
+		{name: "link", text: "lihat www.example.com", want: true},
+		{name: "link shortener", text: "daftar di bit.ly/kelas", want: true},
+		{name: "social media handle", text: "follow IG: @budi_tutor", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContainsContactDetails(tt.text); got != tt.want {
+				t.Errorf("ContainsContactDetails(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskContactDetails(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		want       string
+		wantMasked bool
+	}{
+		{
+			name: "nothing to mask",
+			text: "Sampai jumpa jam 08.00 besok",
+			want: "Sampai jumpa jam 08.00 besok",
+		},
+		{
+			name:       "email address",
+			text:       "kirim ke budi@mail.com ya",
+			want:       "kirim ke ****@mail.com ya",
+			wantMasked: true,
+		},
+		{
+			name:       "phone number keeps separators and the first two digits",
+			text:       "WA 0812-3456-7890",
+			want:       "WA 08**-****-****",
+			wantMasked: true,
+		},
+		{
+			name:       "international phone number",
+			text:       "+62 812 3456 7890",
+			want:       "+62 *** **** ****",
+			wantMasked: true,
+		},
+		{
+			name: "short number",
+			text: "kode 0812345",
+			want: "kode 0812345",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, masked := MaskContactDetails(tt.text)
+			if got != tt.want || masked != tt.wantMasked {
+				t.Errorf("MaskContactDetails(%q) = (%q, %v), want (%q, %v)", tt.text, got, masked, tt.want, tt.wantMasked)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// Conversation is the message thread between a tutor and a student, about
+// one of their bookings when BookingID is set.
+type Conversation struct {
+	ID                 uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID            uuid.UUID     `gorm:"type:char(36);not null" json:"tutor_id"`
+	StudentID          uuid.UUID     `gorm:"type:char(36);not null" json:"student_id"`
+	BookingID          uuid.NullUUID `gorm:"type:char(36)" json:"booking_id"`
+	LastMessageAt      null.Time     `json:"last_message_at"`
+	LastMessagePreview null.String   `gorm:"type:varchar(255)" json:"last_message_preview"`
+	CreatedBy          uuid.UUID     `gorm:"type:char(36);not null" json:"created_by"`
+	CreatedAt          time.Time     `json:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+
+	Tutor   Tutor    `gorm:"foreignKey:TutorID" json:"tutor"`
+	Student Student  `gorm:"foreignKey:StudentID" json:"student"`
+	Booking *Booking `gorm:"foreignKey:BookingID" json:"booking,omitempty"`
+}
+
+func (Conversation) TableName() string {
+	return "conversations"
+}
+
+// Party returns which side of the conversation the user is on, false when
+// the user takes no part in it.
+func (c *Conversation) Party(userID uuid.UUID) (AttendanceParty, bool) {
+	switch userID {
+	case c.Tutor.UserID:
+		return AttendancePartyTutor, true
+	case c.Student.UserID:
+		return AttendancePartyStudent, true
+	}
+	return "", false
+}
+
+// Recipient returns the user on the other side of the conversation from the
+// sender.
+func (c *Conversation) Recipient(senderID uuid.UUID) uuid.UUID {
+	if senderID == c.Tutor.UserID {
+		return c.Student.UserID
+	}
+	return c.Tutor.UserID
+}
+
+type ConversationFilter struct {
+	UserID uuid.UUID
+	Pagination
+}
+
+type ConversationMessage struct {
+	ID             uuid.UUID   `gorm:"type:char(36);primaryKey" json:"id"`
+	ConversationID uuid.UUID   `gorm:"type:char(36);not null" json:"conversation_id"`
+	SenderID       uuid.UUID   `gorm:"type:char(36);not null" json:"sender_id"`
+	Body           null.String `gorm:"type:text" json:"body"`
+	IsMasked       bool        `json:"is_masked"`
+	ReadAt         null.Time   `json:"read_at"`
+	CreatedAt      time.Time   `json:"created_at"`
+
+	Attachments []ConversationMessageAttachment `gorm:"foreignKey:MessageID" json:"attachments"`
+}
+
+func (ConversationMessage) TableName() string {
+	return "conversation_messages"
+}
+
+// Preview returns the start of the message shown in the conversation list.
+func (m *ConversationMessage) Preview() string {
+	const maxPreview = 100
+
+	body := []rune(strings.TrimSpace(m.Body.String))
+	if len(body) == 0 && len(m.Attachments) > 0 {
+		return "📎 " + m.Attachments[0].Filename
+	}
+	if len(body) > maxPreview {
+		return string(body[:maxPreview-3]) + "..."
+	}
+	return string(body)
+}
+
+// ConversationMessageAttachment is a file sent with a message.
+type ConversationMessageAttachment struct {
+	ID        uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null" json:"message_id"`
+	URL       string    `gorm:"type:varchar(255);not null" json:"url"`
+	FileKey   string    `gorm:"type:varchar(255);not null" json:"-"`
+	Filename  string    `gorm:"type:varchar(255);not null" json:"filename"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ConversationMessageAttachment) TableName() string {
+	return "conversation_message_attachments"
+}
+
+type ConversationReportStatus string
+
+const (
+	ConversationReportStatusPending   ConversationReportStatus = "pending"
+	ConversationReportStatusResolved  ConversationReportStatus = "resolved"
+	ConversationReportStatusDismissed ConversationReportStatus = "dismissed"
+)
+
+// ConversationReport is filed by a participant against a conversation,
+// giving admins access to its messages.
+type ConversationReport struct {
+	ID             uuid.UUID                `gorm:"type:char(36);primaryKey" json:"id"`
+	ConversationID uuid.UUID                `gorm:"type:char(36);not null" json:"conversation_id"`
+	ReporterID     uuid.UUID                `gorm:"type:char(36);not null" json:"reporter_id"`
+	Reason         string                   `gorm:"type:varchar(500);not null" json:"reason"`
+	Status         ConversationReportStatus `gorm:"type:enum('pending','resolved','dismissed');not null;default:'pending'" json:"status"`
+	ResolutionNote null.String              `gorm:"type:text" json:"resolution_note"`
+	ResolvedAt     null.Time                `json:"resolved_at"`
+	ResolvedBy     uuid.NullUUID            `gorm:"type:char(36)" json:"resolved_by"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+
+	Conversation *Conversation `gorm:"foreignKey:ConversationID" json:"conversation,omitempty"`
+	Reporter     User          `gorm:"foreignKey:ReporterID" json:"reporter"`
+}
+
+func (ConversationReport) TableName() string {
+	return "conversation_reports"
+}
+
+type ConversationReportFilter struct {
+	Status ConversationReportStatus
+	Pagination
+}
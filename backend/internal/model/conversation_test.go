@@ -0,0 +1,31 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestConversationParty(t *testing.T) {
+	conversation := Conversation{
+		Tutor:   Tutor{UserID: uuid.New()},
+		Student: Student{UserID: uuid.New()},
+	}
+
+	if party, ok := conversation.Party(conversation.Tutor.UserID); !ok || party != AttendancePartyTutor {
+		t.Errorf("Party(tutor) = (%s, %v), want the tutor", party, ok)
+	}
+	if party, ok := conversation.Party(conversation.Student.UserID); !ok || party != AttendancePartyStudent {
+		t.Errorf("Party(student) = (%s, %v), want the student", party, ok)
+	}
+	if _, ok := conversation.Party(uuid.New()); ok {
+		t.Error("Party() of an outsider is ok, want false")
+	}
+
+	if got := conversation.Recipient(conversation.Tutor.UserID); got != conversation.Student.UserID {
+		t.Errorf("Recipient(tutor) = %s, want the student", got)
+	}
+	if got := conversation.Recipient(conversation.Student.UserID); got != conversation.Tutor.UserID {
+		t.Errorf("Recipient(student) = %s, want the tutor", got)
+	}
+}
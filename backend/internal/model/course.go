@@ -45,12 +45,24 @@ func (c *CourseSubCourseCategory) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// SessionType tells the schedules and prices of private sessions, one
+// student with the tutor, from those of group sessions sharing the slot
+// between several students.
+type SessionType string
+
+const (
+	PrivateSessionType SessionType = "private"
+	GroupSessionType   SessionType = "group"
+)
+
 type CoursePrice struct {
 	ID             uuid.UUID `gorm:"primaryKey"`
 	CourseID       uuid.UUID
 	ClassType      ClassType
+	SessionType    SessionType `gorm:"type:varchar(20);not null;default:'private'"` // group prices are per seat
 	DurationInHour int
 	Price          decimal.Decimal
+	Currency       string `gorm:"type:char(3);not null;default:'IDR'"`
 	CreatedAt      time.Time
 	CreatedBy      uuid.NullUUID
 }
@@ -64,13 +76,23 @@ type CourseSchedule struct {
 	StartTime string
 	Timezone  string
 	ClassType ClassType
-	Status    string `gorm:"-"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt null.Time
-	CreatedBy uuid.NullUUID
-	UpdatedBy uuid.NullUUID
-	DeletedBy uuid.NullUUID
+	// SessionType group lets up to Capacity students book the slot, the
+	// session only takes place once MinAttendees of them did
+	SessionType  SessionType `gorm:"type:varchar(20);not null;default:'private'"`
+	Capacity     null.Int
+	MinAttendees null.Int
+	Status       string `gorm:"-"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    null.Time
+	CreatedBy    uuid.NullUUID
+	UpdatedBy    uuid.NullUUID
+	DeletedBy    uuid.NullUUID
+}
+
+// IsGroup reports whether several students share the slot of the schedule.
+func (s CourseSchedule) IsGroup() bool {
+	return s.SessionType == GroupSessionType
 }
 
 type Course struct {
@@ -84,12 +106,15 @@ type Course struct {
 	Description       string
 	TutorDescription  null.String
 	Price             decimal.Decimal
+	Currency          string `gorm:"type:char(3);not null;default:'IDR'"`
 	IsFreeFirstCourse null.Bool
 	ClassType         ClassType
 	OnlineChannel     OnlineChannel
-	Status            CourseStatus `gorm:"type:varchar(255);not null;default:''"`
-	StatusNotes       null.String  `gorm:"type:text"`
-	IsPublished       null.Bool    `gorm:"not null;default:false"`
+	Status            CourseStatus    `gorm:"type:varchar(255);not null;default:''"`
+	StatusNotes       null.String     `gorm:"type:text"`
+	IsPublished       null.Bool       `gorm:"not null;default:false"`
+	Rating            decimal.Decimal `gorm:"type:decimal(3,2);not null;default:0"`
+	TotalRating       int             `gorm:"not null;default:0"`
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	DeletedAt         null.Time
@@ -106,10 +131,9 @@ type Course struct {
 	Draft                 *CourseDraft              `gorm:"foreignKey:CourseID"`
 	TutorReviews          []TutorReview             `gorm:"foreignKey:CourseID"`
 
-	TotalStudentEnrollment int             `gorm:"-"`
-	RelatedCourses         []Course        `gorm:"-"`
-	IsBooked               bool            `gorm:"-"`
-	Rating                 decimal.Decimal `gorm:"-"`
+	TotalStudentEnrollment int      `gorm:"-"`
+	RelatedCourses         []Course `gorm:"-"`
+	IsBooked               bool     `gorm:"-"`
 }
 
 func (c *Course) LevelEducationCourseSlice() []string {
@@ -128,6 +152,9 @@ func (c *Course) FillFromDraft(draft Course) {
 	c.Description = draft.Description
 	c.TutorDescription = draft.TutorDescription
 	c.Price = draft.Price
+	if draft.Currency != "" {
+		c.Currency = draft.Currency
+	}
 	c.IsFreeFirstCourse = draft.IsFreeFirstCourse
 	c.ClassType = draft.ClassType
 	c.OnlineChannel = draft.OnlineChannel
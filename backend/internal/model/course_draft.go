@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -39,16 +40,22 @@ type CourseDraft struct {
 	ReviewedAt  null.Time      `gorm:"type:timestamp" json:"reviewedAt,omitempty"`
 	ReviewedBy  uuid.NullUUID  `gorm:"type:char(36)" json:"reviewedBy,omitempty"`
 	ReviewNotes null.String    `gorm:"type:text" json:"reviewNotes,omitempty"`
+	AssignedTo  uuid.NullUUID  `gorm:"type:char(36)" json:"assignedTo,omitempty"`
+	AssignedAt  null.Time      `gorm:"type:timestamp" json:"assignedAt,omitempty"`
 	CreatedAt   time.Time      `gorm:"not null;default:CURRENT_TIMESTAMP" json:"createdAt"`
 	UpdatedAt   time.Time      `gorm:"not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP" json:"updatedAt"`
 	DeletedAt   null.Time      `gorm:"type:timestamp" json:"deletedAt,omitempty"`
 	UpdatedBy   uuid.NullUUID  `gorm:"type:char(36)" json:"updatedBy,omitempty"`
 	DeletedBy   uuid.NullUUID  `gorm:"type:char(36)" json:"deletedBy,omitempty"`
 
+	// RejectionChecklist holds the []CourseRejectionItem picked on rejection
+	RejectionChecklist datatypes.JSON `gorm:"type:json" json:"rejectionChecklist,omitempty"`
+
 	// Relationships
 	Course   Course `gorm:"foreignKey:CourseID" json:"course,omitempty"`
 	Creator  User   `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
 	Reviewer *User  `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+	Assignee *User  `gorm:"foreignKey:AssignedTo" json:"assignee,omitempty"`
 }
 
 // BeforeCreate hook to generate UUID if not provided
@@ -74,6 +81,16 @@ func (cd *CourseDraft) CanBeEdited() bool {
 	return cd.Status != DraftStatusApproved
 }
 
+// GetRejectionChecklist returns the checklist sent to the tutor on the last rejection
+func (cd *CourseDraft) GetRejectionChecklist() []CourseRejectionItem {
+	items := []CourseRejectionItem{}
+	if len(cd.RejectionChecklist) == 0 {
+		return items
+	}
+	_ = json.Unmarshal(cd.RejectionChecklist, &items)
+	return items
+}
+
 // CanBeSubmitted returns true if the draft can be submitted for approval
 func (cd *CourseDraft) CanBeSubmitted() bool {
 	return cd.Status == DraftStatusDraft
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+)
+
+// CourseRejectionReason is a canned reason admins pick from when rejecting a
+// course. Picked reasons are sent to the tutor as a checklist.
+type CourseRejectionReason struct {
+	ID          uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	Code        string        `gorm:"type:varchar(100);not null;uniqueIndex" json:"code"`
+	Title       string        `gorm:"type:varchar(255);not null" json:"title"`
+	Description null.String   `gorm:"type:text" json:"description"`
+	Sequence    int           `gorm:"not null;default:0" json:"sequence"`
+	IsActive    bool          `gorm:"not null;default:true" json:"isActive"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+	CreatedBy   uuid.NullUUID `gorm:"type:char(36)" json:"createdBy"`
+	UpdatedBy   uuid.NullUUID `gorm:"type:char(36)" json:"updatedBy"`
+}
+
+func (CourseRejectionReason) TableName() string {
+	return "course_rejection_reasons"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (r *CourseRejectionReason) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// CourseRejectionItem is one entry of the checklist a tutor has to work
+// through after a rejection.
+type CourseRejectionItem struct {
+	Code        string      `json:"code"`
+	Title       string      `json:"title"`
+	Description null.String `json:"description"`
+}
+
+type CourseModerationSLA string
+
+const (
+	CourseModerationSLAOnTrack  CourseModerationSLA = "on_track"
+	CourseModerationSLAAtRisk   CourseModerationSLA = "at_risk"
+	CourseModerationSLABreached CourseModerationSLA = "breached"
+)
+
+// CourseModerationSLAByAge tells how a submission waiting for the given
+// duration stands against the review SLA.
+func CourseModerationSLAByAge(age, warning, limit time.Duration) CourseModerationSLA {
+	switch {
+	case age >= limit:
+		return CourseModerationSLABreached
+	case age >= warning:
+		return CourseModerationSLAAtRisk
+	default:
+		return CourseModerationSLAOnTrack
+	}
+}
+
+type CourseModerationFilter struct {
+	AssignedTo      uuid.UUID
+	Unassigned      bool
+	SubmittedAfter  null.Time
+	SubmittedBefore null.Time
+	Pagination
+}
+
+const (
+	CoursePreCheckMissingPrice    = "missing_price"
+	CoursePreCheckMissingSchedule = "missing_schedule"
+	CoursePreCheckContactDetails  = "contact_details"
+	CoursePreCheckDuplicateTitle  = "duplicate_title"
+)
+
+// CoursePreCheck is the result of one automatic check a course has to pass
+// before it enters the moderation queue.
+type CoursePreCheck struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Passed  bool   `json:"passed"`
+}
+
+// RunCoursePreChecks checks the content a tutor submits for review.
+// duplicateTitle tells whether the tutor already has another course with the
+// same title.
+func RunCoursePreChecks(course Course, duplicateTitle bool) []CoursePreCheck {
+	hasPrice := len(course.CoursePrices) > 0
+	for _, price := range course.CoursePrices {
+		if !price.Price.IsPositive() {
+			hasPrice = false
+			break
+		}
+	}
+
+	texts := []string{course.Title, course.Description, course.TutorDescription.String}
+	hasContact := ContainsContactDetails(strings.Join(texts, "\n"))
+
+	return []CoursePreCheck{
+		{
+			Code:    CoursePreCheckMissingPrice,
+			Message: "Every class type and duration needs a price",
+			Passed:  hasPrice,
+		},
+		{
+			Code:    CoursePreCheckMissingSchedule,
+			Message: "At least one schedule is required",
+			Passed:  len(course.CourseSchedules) > 0,
+		},
+		{
+			Code:    CoursePreCheckContactDetails,
+			Message: "Title and descriptions must not contain contact details",
+			Passed:  !hasContact,
+		},
+		{
+			Code:    CoursePreCheckDuplicateTitle,
+			Message: "You already have another course with the same title",
+			Passed:  !duplicateTitle,
+		},
+	}
+}
+
+// FailedCoursePreChecks returns the codes of the checks that did not pass.
+func FailedCoursePreChecks(checks []CoursePreCheck) []string {
+	var failed []string
+	for _, check := range checks {
+		if !check.Passed {
+			failed = append(failed, check.Code)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+func TestCourseModerationSLAByAge(t *testing.T) {
+	const (
+		warning = 36 * time.Hour
+		limit   = 48 * time.Hour
+	)
+
+	tests := []struct {
+		age  time.Duration
+		want CourseModerationSLA
+	}{
+		{age: 0, want: CourseModerationSLAOnTrack},
+		{age: warning - time.Second, want: CourseModerationSLAOnTrack},
+		{age: warning, want: CourseModerationSLAAtRisk},
+		{age: limit - time.Second, want: CourseModerationSLAAtRisk},
+		{age: limit, want: CourseModerationSLABreached},
+		{age: 10 * limit, want: CourseModerationSLABreached},
+	}
+
+	for _, tt := range tests {
+		if got := CourseModerationSLAByAge(tt.age, warning, limit); got != tt.want {
+			t.Errorf("CourseModerationSLAByAge(%s) = %s, want %s", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestRunCoursePreChecks(t *testing.T) {
+	valid := Course{
+		Title:        "Bahasa Inggris Percakapan",
+		Description:  "Latihan speaking untuk pemula",
+		CoursePrices: []CoursePrice{{Price: decimal.NewFromInt(100000)}, {Price: decimal.NewFromInt(180000)}},
+		CourseSchedules: []CourseSchedule{
+			{Day: 2, StartTime: "18:00"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		course         func(c *Course)
+		duplicateTitle bool
+		want           []string
+	}{
+		{name: "passes every check"},
+		{name: "no prices", course: func(c *Course) { c.CoursePrices = nil }, want: []string{CoursePreCheckMissingPrice}},
+		{name: "free price", course: func(c *Course) {
+			c.CoursePrices = []CoursePrice{{Price: decimal.NewFromInt(100000)}, {Price: decimal.Zero}}
+		}, want: []string{CoursePreCheckMissingPrice}},
+		{name: "no schedules", course: func(c *Course) { c.CourseSchedules = nil }, want: []string{CoursePreCheckMissingSchedule}},
+		{name: "phone number in the tutor description", course: func(c *Course) { c.TutorDescription = null.StringFrom("WA 0812 3456 7890") }, want: []string{CoursePreCheckContactDetails}},
+		{name: "duplicate title", duplicateTitle: true, want: []string{CoursePreCheckDuplicateTitle}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			course := valid
+			if tt.course != nil {
+				tt.course(&course)
+			}
+
+			checks := RunCoursePreChecks(course, tt.duplicateTitle)
+			if len(checks) != 4 {
+				t.Fatalf("RunCoursePreChecks() returned %d checks, want 4", len(checks))
+			}
+			if got := FailedCoursePreChecks(checks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FailedCoursePreChecks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCourseDraftGetRejectionChecklist(t *testing.T) {
+	tests := []struct {
+		name      string
+		checklist string
+		want      []CourseRejectionItem
+	}{
+		{name: "never rejected", want: []CourseRejectionItem{}},
+		{
+			name:      "picked reasons",
+			checklist: `[{"code":"blurry_photo","title":"Foto tidak jelas","description":null}]`,
+			want:      []CourseRejectionItem{{Code: "blurry_photo", Title: "Foto tidak jelas"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draft := CourseDraft{}
+			if tt.checklist != "" {
+				draft.RejectionChecklist = []byte(tt.checklist)
+			}
+			if got := draft.GetRejectionChecklist(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRejectionChecklist() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
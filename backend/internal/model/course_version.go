@@ -0,0 +1,309 @@
+package model
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type CourseVersionSource string
+
+const (
+	CourseVersionSourceInitial     CourseVersionSource = "initial"
+	CourseVersionSourceApproval    CourseVersionSource = "approval"
+	CourseVersionSourceAdminUpdate CourseVersionSource = "admin_update"
+	CourseVersionSourceRollback    CourseVersionSource = "rollback"
+)
+
+// CourseVersion is an immutable snapshot of a course taken every time a
+// change goes live.
+type CourseVersion struct {
+	ID           uuid.UUID           `gorm:"type:char(36);primaryKey" json:"id"`
+	CourseID     uuid.UUID           `gorm:"type:char(36);not null" json:"courseId"`
+	Version      int                 `gorm:"not null" json:"version"`
+	Snapshot     datatypes.JSON      `gorm:"type:json;not null" json:"snapshot"`
+	Source       CourseVersionSource `gorm:"type:varchar(50);not null" json:"source"`
+	DraftID      uuid.NullUUID       `gorm:"type:char(36)" json:"draftId,omitempty"`
+	RestoredFrom null.Int            `json:"restoredFrom,omitempty"`
+	CreatedAt    time.Time           `json:"createdAt"`
+	CreatedBy    uuid.NullUUID       `gorm:"type:char(36)" json:"createdBy,omitempty"`
+}
+
+func (CourseVersion) TableName() string {
+	return "course_versions"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (v *CourseVersion) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+func (v *CourseVersion) GetSnapshot() (CourseSnapshot, error) {
+	var snapshot CourseSnapshot
+	err := json.Unmarshal(v.Snapshot, &snapshot)
+	if snapshot.Currency == "" {
+		// Snapshots taken before courses had a currency were priced in IDR.
+		snapshot.Currency = CurrencyIDR
+	}
+	return snapshot, err
+}
+
+// CourseVersionChange is the version recorded together with a change to a
+// course. Previous is the state being replaced and is stored as the first
+// version of courses that were created before versioning existed.
+type CourseVersionChange struct {
+	Previous CourseSnapshot
+	Version  CourseVersion
+}
+
+func NewCourseVersionChange(previous CourseSnapshot, course Course, source CourseVersionSource, userID uuid.UUID) (*CourseVersionChange, error) {
+	snapshot, err := json.Marshal(NewCourseSnapshot(course))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CourseVersionChange{
+		Previous: previous,
+		Version: CourseVersion{
+			CourseID:  course.ID,
+			Snapshot:  snapshot,
+			Source:    source,
+			CreatedAt: time.Now(),
+			CreatedBy: uuid.NullUUID{UUID: userID, Valid: true},
+		},
+	}, nil
+}
+
+// The session type of snapshot prices and schedules is only set for group
+// ones so snapshots taken before group sessions still compare equal.
+type CourseSnapshotPrice struct {
+	ClassType      ClassType       `json:"classType"`
+	SessionType    SessionType     `json:"sessionType,omitempty"`
+	DurationInHour int             `json:"durationInHour"`
+	Price          decimal.Decimal `json:"price"`
+}
+
+type CourseSnapshotSchedule struct {
+	ClassType    ClassType   `json:"classType"`
+	SessionType  SessionType `json:"sessionType,omitempty"`
+	Day          int         `json:"day"`
+	StartTime    string      `json:"startTime"`
+	Timezone     string      `json:"timezone"`
+	Capacity     null.Int    `json:"capacity,omitempty"`
+	MinAttendees null.Int    `json:"minAttendees,omitempty"`
+}
+
+// CourseSnapshot holds everything an approval can change on a course. Slices
+// are kept sorted so two snapshots of the same content compare equal.
+type CourseSnapshot struct {
+	Title                string                   `json:"title"`
+	Description          string                   `json:"description"`
+	TutorDescription     null.String              `json:"tutorDescription"`
+	Price                decimal.Decimal          `json:"price"`
+	Currency             string                   `json:"currency"`
+	IsFreeFirstCourse    null.Bool                `json:"isFreeFirstCourse"`
+	ClassType            ClassType                `json:"classType"`
+	OnlineChannel        OnlineChannel            `json:"onlineChannel"`
+	CourseCategoryID     uuid.UUID                `json:"courseCategoryId"`
+	SubCourseCategoryIDs []uuid.UUID              `json:"subCourseCategoryIds"`
+	LevelEducations      []string                 `json:"levelEducations"`
+	Prices               []CourseSnapshotPrice    `json:"prices"`
+	Schedules            []CourseSnapshotSchedule `json:"schedules"`
+}
+
+func NewCourseSnapshot(course Course) CourseSnapshot {
+	snapshot := CourseSnapshot{
+		Title:                course.Title,
+		Description:          course.Description,
+		TutorDescription:     course.TutorDescription,
+		Price:                course.Price,
+		Currency:             cmp.Or(course.Currency, CurrencyIDR),
+		IsFreeFirstCourse:    course.IsFreeFirstCourse,
+		ClassType:            course.ClassType,
+		OnlineChannel:        course.OnlineChannel,
+		CourseCategoryID:     course.CourseCategoryID,
+		SubCourseCategoryIDs: []uuid.UUID{},
+		LevelEducations:      course.LevelEducationCourseSlice(),
+		Prices:               []CourseSnapshotPrice{},
+		Schedules:            []CourseSnapshotSchedule{},
+	}
+
+	for _, subCategory := range course.SubCourseCategories {
+		snapshot.SubCourseCategoryIDs = append(snapshot.SubCourseCategoryIDs, subCategory.SubCourseCategoryID)
+	}
+
+	for _, price := range course.CoursePrices {
+		snapshotPrice := CourseSnapshotPrice{
+			ClassType:      price.ClassType,
+			DurationInHour: price.DurationInHour,
+			Price:          price.Price,
+		}
+		if price.SessionType == GroupSessionType {
+			snapshotPrice.SessionType = GroupSessionType
+		}
+		snapshot.Prices = append(snapshot.Prices, snapshotPrice)
+	}
+
+	for _, schedule := range course.CourseSchedules {
+		snapshotSchedule := CourseSnapshotSchedule{
+			ClassType: schedule.ClassType,
+			Day:       schedule.Day,
+			StartTime: schedule.StartTime,
+			Timezone:  schedule.Timezone,
+		}
+		if schedule.IsGroup() {
+			snapshotSchedule.SessionType = GroupSessionType
+			snapshotSchedule.Capacity = schedule.Capacity
+			snapshotSchedule.MinAttendees = schedule.MinAttendees
+		}
+		snapshot.Schedules = append(snapshot.Schedules, snapshotSchedule)
+	}
+
+	sort.Slice(snapshot.SubCourseCategoryIDs, func(i, j int) bool {
+		return snapshot.SubCourseCategoryIDs[i].String() < snapshot.SubCourseCategoryIDs[j].String()
+	})
+	sort.Strings(snapshot.LevelEducations)
+	sort.Slice(snapshot.Prices, func(i, j int) bool {
+		a, b := snapshot.Prices[i], snapshot.Prices[j]
+		if a.ClassType != b.ClassType {
+			return a.ClassType < b.ClassType
+		}
+		if a.SessionType != b.SessionType {
+			return a.SessionType < b.SessionType
+		}
+		return a.DurationInHour < b.DurationInHour
+	})
+	sort.Slice(snapshot.Schedules, func(i, j int) bool {
+		a, b := snapshot.Schedules[i], snapshot.Schedules[j]
+		if a.ClassType != b.ClassType {
+			return a.ClassType < b.ClassType
+		}
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.StartTime != b.StartTime {
+			return a.StartTime < b.StartTime
+		}
+		return a.SessionType < b.SessionType
+	})
+
+	return snapshot
+}
+
+// Apply overwrites the course with the snapshot. Related rows are rebuilt
+// with new IDs because the repository replaces them wholesale.
+func (s CourseSnapshot) Apply(course *Course, userID uuid.UUID) {
+	now := time.Now()
+	createdBy := uuid.NullUUID{UUID: userID, Valid: true}
+
+	course.Title = s.Title
+	course.Description = s.Description
+	course.TutorDescription = s.TutorDescription
+	course.Price = s.Price
+	course.Currency = s.Currency
+	course.IsFreeFirstCourse = s.IsFreeFirstCourse
+	course.ClassType = s.ClassType
+	course.OnlineChannel = s.OnlineChannel
+	course.CourseCategoryID = s.CourseCategoryID
+	// Cleared so saving the course does not put back the old category.
+	course.CourseCategory = CourseCategory{}
+	course.UpdatedAt = now
+	course.UpdatedBy = createdBy
+
+	course.SubCourseCategories = make([]CourseSubCourseCategory, 0, len(s.SubCourseCategoryIDs))
+	for _, id := range s.SubCourseCategoryIDs {
+		course.SubCourseCategories = append(course.SubCourseCategories, CourseSubCourseCategory{
+			CourseID:            course.ID,
+			SubCourseCategoryID: id,
+		})
+	}
+
+	course.LevelEducationCourses = make([]LevelEducationCourse, 0, len(s.LevelEducations))
+	for _, level := range s.LevelEducations {
+		course.LevelEducationCourses = append(course.LevelEducationCourses, LevelEducationCourse{
+			CourseID:         course.ID,
+			LevelOfEducation: level,
+		})
+	}
+
+	course.CoursePrices = make([]CoursePrice, 0, len(s.Prices))
+	for _, price := range s.Prices {
+		course.CoursePrices = append(course.CoursePrices, CoursePrice{
+			ID:             uuid.New(),
+			CourseID:       course.ID,
+			ClassType:      price.ClassType,
+			SessionType:    cmp.Or(price.SessionType, PrivateSessionType),
+			DurationInHour: price.DurationInHour,
+			Price:          price.Price,
+			Currency:       s.Currency,
+			CreatedAt:      now,
+			CreatedBy:      createdBy,
+		})
+	}
+
+	course.CourseSchedules = make([]CourseSchedule, 0, len(s.Schedules))
+	for _, schedule := range s.Schedules {
+		course.CourseSchedules = append(course.CourseSchedules, CourseSchedule{
+			ID:           uuid.New(),
+			CourseID:     course.ID,
+			Day:          schedule.Day,
+			StartTime:    schedule.StartTime,
+			Timezone:     schedule.Timezone,
+			ClassType:    schedule.ClassType,
+			SessionType:  cmp.Or(schedule.SessionType, PrivateSessionType),
+			Capacity:     schedule.Capacity,
+			MinAttendees: schedule.MinAttendees,
+			CreatedAt:    now,
+			CreatedBy:    createdBy,
+		})
+	}
+}
+
+type CourseFieldDiff struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// DiffCourseSnapshots lists the fields that differ between two snapshots.
+func DiffCourseSnapshots(from, to CourseSnapshot) []CourseFieldDiff {
+	fields := []CourseFieldDiff{
+		{Field: "title", From: from.Title, To: to.Title},
+		{Field: "description", From: from.Description, To: to.Description},
+		{Field: "tutorDescription", From: from.TutorDescription, To: to.TutorDescription},
+		{Field: "price", From: from.Price, To: to.Price},
+		{Field: "currency", From: from.Currency, To: to.Currency},
+		{Field: "isFreeFirstCourse", From: from.IsFreeFirstCourse, To: to.IsFreeFirstCourse},
+		{Field: "classType", From: from.ClassType, To: to.ClassType},
+		{Field: "onlineChannel", From: from.OnlineChannel, To: to.OnlineChannel},
+		{Field: "courseCategoryId", From: from.CourseCategoryID, To: to.CourseCategoryID},
+		{Field: "subCourseCategoryIds", From: from.SubCourseCategoryIDs, To: to.SubCourseCategoryIDs},
+		{Field: "levelEducations", From: from.LevelEducations, To: to.LevelEducations},
+		{Field: "prices", From: from.Prices, To: to.Prices},
+		{Field: "schedules", From: from.Schedules, To: to.Schedules},
+	}
+
+	diffs := []CourseFieldDiff{}
+	for _, field := range fields {
+		// Values are compared on their JSON form so decimals with a different
+		// scale are treated as the same.
+		fromJSON, _ := json.Marshal(field.From)
+		toJSON, _ := json.Marshal(field.To)
+		if !bytes.Equal(fromJSON, toJSON) {
+			diffs = append(diffs, field)
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+func newVersionedCourse() Course {
+	courseID := uuid.New()
+	return Course{
+		ID:               courseID,
+		Title:            "Matematika SMA",
+		Description:      "Persiapan UTBK",
+		Price:            decimal.NewFromInt(150000),
+		ClassType:        AllClassType,
+		CourseCategoryID: uuid.New(),
+		SubCourseCategories: []CourseSubCourseCategory{
+			{CourseID: courseID, SubCourseCategoryID: uuid.New()},
+			{CourseID: courseID, SubCourseCategoryID: uuid.New()},
+		},
+		LevelEducationCourses: []LevelEducationCourse{
+			{CourseID: courseID, LevelOfEducation: "SMA"},
+			{CourseID: courseID, LevelOfEducation: "SMP"},
+		},
+		CoursePrices: []CoursePrice{
+			{ClassType: OnlineClassType, SessionType: PrivateSessionType, DurationInHour: 2, Price: decimal.NewFromInt(250000)},
+			{ClassType: OfflineClassType, SessionType: PrivateSessionType, DurationInHour: 1, Price: decimal.NewFromInt(175000)},
+			{ClassType: OnlineClassType, SessionType: GroupSessionType, DurationInHour: 1, Price: decimal.NewFromInt(60000)},
+			{ClassType: OnlineClassType, SessionType: PrivateSessionType, DurationInHour: 1, Price: decimal.NewFromInt(150000)},
+		},
+		CourseSchedules: []CourseSchedule{
+			{ClassType: OnlineClassType, SessionType: PrivateSessionType, Day: 3, StartTime: "19:00", Timezone: "Asia/Jakarta"},
+			{ClassType: OnlineClassType, SessionType: GroupSessionType, Day: 1, StartTime: "16:00", Timezone: "Asia/Jakarta", Capacity: null.IntFrom(8), MinAttendees: null.IntFrom(3)},
+			{ClassType: OfflineClassType, SessionType: PrivateSessionType, Day: 6, StartTime: "09:00", Timezone: "Asia/Jakarta"},
+		},
+	}
+}
+
+func TestNewCourseSnapshotIgnoresOrder(t *testing.T) {
+	course := newVersionedCourse()
+
+	reversed := course
+	reversed.SubCourseCategories = []CourseSubCourseCategory{course.SubCourseCategories[1], course.SubCourseCategories[0]}
+	reversed.LevelEducationCourses = []LevelEducationCourse{course.LevelEducationCourses[1], course.LevelEducationCourses[0]}
+	reversed.CoursePrices = []CoursePrice{course.CoursePrices[3], course.CoursePrices[2], course.CoursePrices[1], course.CoursePrices[0]}
+	reversed.CourseSchedules = []CourseSchedule{course.CourseSchedules[2], course.CourseSchedules[1], course.CourseSchedules[0]}
+
+	if diffs := DiffCourseSnapshots(NewCourseSnapshot(course), NewCourseSnapshot(reversed)); len(diffs) != 0 {
+		t.Errorf("snapshots of the same content differ in %v", diffs)
+	}
+}
+
+func TestNewCourseSnapshot(t *testing.T) {
+	snapshot := NewCourseSnapshot(newVersionedCourse())
+
+	if snapshot.Currency != CurrencyIDR {
+		t.Errorf("Currency = %q, want %q for courses without one", snapshot.Currency, CurrencyIDR)
+	}
+
+	// Only group prices and schedules carry their session type, so snapshots
+	// taken before group sessions existed still compare equal.
+	for _, price := range snapshot.Prices {
+		if price.SessionType == PrivateSessionType {
+			t.Errorf("price %+v keeps the private session type", price)
+		}
+	}
+	for _, schedule := range snapshot.Schedules {
+		if schedule.SessionType == GroupSessionType && !schedule.Capacity.Valid {
+			t.Errorf("group schedule %+v lost its capacity", schedule)
+		}
+		if schedule.SessionType == PrivateSessionType {
+			t.Errorf("schedule %+v keeps the private session type", schedule)
+		}
+	}
+
+	wantLevels := []string{"SMA", "SMP"}
+	if !reflect.DeepEqual(snapshot.LevelEducations, wantLevels) {
+		t.Errorf("LevelEducations = %v, want %v", snapshot.LevelEducations, wantLevels)
+	}
+}
+
+func TestCourseSnapshotApply(t *testing.T) {
+	course := newVersionedCourse()
+	snapshot := NewCourseSnapshot(course)
+
+	restored := Course{ID: course.ID, Title: "Judul baru", Price: decimal.NewFromInt(1)}
+	userID := uuid.New()
+	snapshot.Apply(&restored, userID)
+
+	if diffs := DiffCourseSnapshots(snapshot, NewCourseSnapshot(restored)); len(diffs) != 0 {
+		t.Errorf("applied course differs from the snapshot in %v", diffs)
+	}
+
+	for _, price := range restored.CoursePrices {
+		if price.ID == uuid.Nil || price.CourseID != course.ID || price.Currency != CurrencyIDR {
+			t.Errorf("price %+v is not rebuilt for the course", price)
+		}
+		if price.SessionType == "" {
+			t.Errorf("price %+v has no session type", price)
+		}
+	}
+	for _, schedule := range restored.CourseSchedules {
+		if schedule.ID == uuid.Nil || schedule.CourseID != course.ID || schedule.SessionType == "" {
+			t.Errorf("schedule %+v is not rebuilt for the course", schedule)
+		}
+	}
+	if !restored.UpdatedBy.Valid || restored.UpdatedBy.UUID != userID {
+		t.Errorf("UpdatedBy = %v, want %s", restored.UpdatedBy, userID)
+	}
+}
+
+func TestDiffCourseSnapshots(t *testing.T) {
+	from := NewCourseSnapshot(newVersionedCourse())
+
+	to := from
+	to.Title = "Matematika SMA Intensif"
+	to.Price = decimal.RequireFromString("175000")
+	to.Prices = append([]CourseSnapshotPrice{}, from.Prices...)
+	to.Prices[0].Price = to.Prices[0].Price.Add(decimal.NewFromInt(10000))
+
+	var fields []string
+	for _, diff := range DiffCourseSnapshots(from, to) {
+		fields = append(fields, diff.Field)
+	}
+
+	want := []string{"title", "price", "prices"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("DiffCourseSnapshots() fields = %v, want %v", fields, want)
+	}
+}
+
+func TestDiffCourseSnapshotsIgnoresDecimalScale(t *testing.T) {
+	from := NewCourseSnapshot(newVersionedCourse())
+
+	to := from
+	to.Price = decimal.RequireFromString("150000.00")
+
+	if diffs := DiffCourseSnapshots(from, to); len(diffs) != 0 {
+		t.Errorf("DiffCourseSnapshots() = %v, want no difference", diffs)
+	}
+}
+
+func TestCourseVersionGetSnapshotDefaultsCurrency(t *testing.T) {
+	data, err := json.Marshal(map[string]any{"title": "Fisika", "price": "100000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	version := CourseVersion{Snapshot: data}
+	snapshot, err := version.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot() error = %v", err)
+	}
+	if snapshot.Title != "Fisika" || snapshot.Currency != CurrencyIDR {
+		t.Errorf("GetSnapshot() = %+v, want the title and IDR", snapshot)
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"slices"
+
+	"github.com/leekchan/accounting"
+	"github.com/shopspring/decimal"
+)
+
+// CurrencyIDR is the currency of the platform. Students pay in it and amounts
+// without a currency of their own are in it.
+const CurrencyIDR = "IDR"
+
+// Currency is an ISO 4217 currency prices can be set and shown in.
+type Currency struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Symbol    string `json:"symbol"`
+	Precision int    `json:"precision"`
+	Thousand  string `json:"-"`
+	Decimal   string `json:"-"`
+}
+
+// Currencies lists the currencies tutors can price courses in and mentors can
+// be settled in.
+var Currencies = []Currency{
+	{Code: CurrencyIDR, Name: "Indonesian Rupiah", Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","},
+	{Code: "USD", Name: "US Dollar", Symbol: "US$", Precision: 2, Thousand: ",", Decimal: "."},
+	{Code: "SGD", Name: "Singapore Dollar", Symbol: "S$", Precision: 2, Thousand: ",", Decimal: "."},
+	{Code: "MYR", Name: "Malaysian Ringgit", Symbol: "RM", Precision: 2, Thousand: ",", Decimal: "."},
+	{Code: "AUD", Name: "Australian Dollar", Symbol: "A$", Precision: 2, Thousand: ",", Decimal: "."},
+	{Code: "EUR", Name: "Euro", Symbol: "€", Precision: 2, Thousand: ".", Decimal: ","},
+	{Code: "GBP", Name: "British Pound", Symbol: "£", Precision: 2, Thousand: ",", Decimal: "."},
+	{Code: "JPY", Name: "Japanese Yen", Symbol: "¥", Precision: 0, Thousand: ",", Decimal: "."},
+}
+
+// CurrencyByCode looks a currency up in Currencies.
+func CurrencyByCode(code string) (Currency, bool) {
+	i := slices.IndexFunc(Currencies, func(currency Currency) bool {
+		return currency.Code == code
+	})
+	if i < 0 {
+		return Currency{}, false
+	}
+
+	return Currencies[i], true
+}
+
+// MustCurrency looks a currency up in Currencies, falling back to IDR for
+// amounts recorded before they had a currency.
+func MustCurrency(code string) Currency {
+	if currency, ok := CurrencyByCode(code); ok {
+		return currency
+	}
+
+	currency, _ := CurrencyByCode(CurrencyIDR)
+	return currency
+}
+
+// Round rounds an amount to the minor unit of the currency.
+func (c Currency) Round(amount decimal.Decimal) decimal.Decimal {
+	return amount.Round(int32(c.Precision))
+}
+
+// Format formats an amount with the symbol and separators of the currency,
+// e.g. Rp1.500.000,00 or US$92.50.
+func (c Currency) Format(amount decimal.Decimal) string {
+	ac := accounting.Accounting{
+		Symbol:    c.Symbol,
+		Precision: c.Precision,
+		Thousand:  c.Thousand,
+		Decimal:   c.Decimal,
+	}
+
+	return ac.FormatMoney(amount)
+}
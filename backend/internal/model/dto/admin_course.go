@@ -1,8 +1,10 @@
 package dto
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +21,14 @@ type ApproveCourseRequest struct {
 type RejectCourseRequest struct {
 	ID          uuid.UUID `json:"-"`
 	ReviewNotes string    `json:"reviewNotes"`
+	ReasonCodes []string  `json:"reasonCodes"`
+}
+
+func (r *RejectCourseRequest) Validate() error {
+	if strings.TrimSpace(r.ReviewNotes) == "" && len(r.ReasonCodes) == 0 {
+		return errors.New("reviewNotes or reasonCodes is required")
+	}
+	return nil
 }
 
 type AdminCreateCourseRequest struct {
@@ -62,10 +72,14 @@ func (r *AdminCreateCourseRequest) Validate() error {
 		return err
 	}
 
-	return nil
+	return validateGroupSessions(r.CoursePrices, r.CourseSchedulesOffline, r.CourseSchedulesOnline)
 }
 
 func (r *AdminCreateCourseRequest) validateCoursePrices() error {
+	if err := r.CoursePrices.validateCurrency(); err != nil {
+		return err
+	}
+
 	offlineDurations := make(map[int]bool)
 	for i, price := range r.CoursePrices.Offline {
 		if price.DurationInHour <= 0 {
@@ -244,11 +258,15 @@ func (r *AdminUpdateCourseRequest) Validate() error {
 		return err
 	}
 
-	return nil
+	return validateGroupSessions(r.CoursePrices, r.CourseSchedulesOffline, r.CourseSchedulesOnline)
 }
 
 // validateCoursePrices validates the course prices structure
 func (r *AdminUpdateCourseRequest) validateCoursePrices() error {
+	if err := r.CoursePrices.validateCurrency(); err != nil {
+		return err
+	}
+
 	// Validate offline prices
 	offlineDurations := make(map[int]bool)
 	for i, price := range r.CoursePrices.Offline {
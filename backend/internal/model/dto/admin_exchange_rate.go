@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type GetExchangeRatesRequest struct {
+	Currency string `form:"currency"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+	model.Pagination
+}
+
+func (r *GetExchangeRatesRequest) Filter() (model.ExchangeRateFilter, error) {
+	filter := model.ExchangeRateFilter{
+		Currency:   strings.ToUpper(r.Currency),
+		Pagination: r.Pagination,
+	}
+
+	var err error
+	if r.From != "" {
+		if filter.From, err = time.Parse(time.DateOnly, r.From); err != nil {
+			return filter, errors.New("from must be a date formatted as YYYY-MM-DD")
+		}
+	}
+
+	if r.To != "" {
+		if filter.To, err = time.Parse(time.DateOnly, r.To); err != nil {
+			return filter, errors.New("to must be a date formatted as YYYY-MM-DD")
+		}
+	}
+
+	return filter, nil
+}
+
+type ExchangeRateRequest struct {
+	Currency string          `json:"currency"`
+	Rate     decimal.Decimal `json:"rate"`
+}
+
+// UploadExchangeRatesRequest records the IDR value of a unit of each currency
+// on RateDate, today unless set. Rates already recorded that day are replaced.
+type UploadExchangeRatesRequest struct {
+	AdminID  uuid.UUID             `json:"-"`
+	RateDate string                `json:"rateDate"`
+	Rates    []ExchangeRateRequest `json:"rates"`
+}
+
+func (r *UploadExchangeRatesRequest) Validate() error {
+	if r.RateDate == "" {
+		r.RateDate = time.Now().Format(time.DateOnly)
+	}
+
+	if _, err := time.Parse(time.DateOnly, r.RateDate); err != nil {
+		return errors.New("rateDate must be a date formatted as YYYY-MM-DD")
+	}
+
+	if len(r.Rates) == 0 {
+		return errors.New("rates is required")
+	}
+
+	seen := make(map[string]bool)
+	for i := range r.Rates {
+		rate := &r.Rates[i]
+		rate.Currency = strings.ToUpper(rate.Currency)
+
+		if _, ok := model.CurrencyByCode(rate.Currency); !ok || rate.Currency == model.CurrencyIDR {
+			return fmt.Errorf("rates[%d]: unsupported currency %s", i, rate.Currency)
+		}
+
+		if !rate.Rate.IsPositive() {
+			return fmt.Errorf("rates[%d]: rate must be greater than 0", i)
+		}
+
+		if seen[rate.Currency] {
+			return fmt.Errorf("rates[%d]: duplicate currency %s", i, rate.Currency)
+		}
+		seen[rate.Currency] = true
+	}
+
+	return nil
+}
+
+// ExchangeRates returns the rates of a validated request.
+func (r UploadExchangeRatesRequest) ExchangeRates() []model.ExchangeRate {
+	rateDate, _ := time.Parse(time.DateOnly, r.RateDate)
+
+	rates := make([]model.ExchangeRate, 0, len(r.Rates))
+	for _, rate := range r.Rates {
+		rates = append(rates, model.ExchangeRate{
+			Currency:  rate.Currency,
+			Rate:      rate.Rate,
+			RateDate:  rateDate,
+			Source:    model.ExchangeRateSourceManual,
+			CreatedBy: uuid.NullUUID{UUID: r.AdminID, Valid: true},
+		})
+	}
+
+	return rates
+}
@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+const (
+	FinanceReportRevenue     = "revenue"
+	FinanceReportCommission  = "commission"
+	FinanceReportPayouts     = "payouts"
+	FinanceReportLiabilities = "liabilities"
+)
+
+var FinanceReports = []string{
+	FinanceReportRevenue,
+	FinanceReportCommission,
+	FinanceReportPayouts,
+	FinanceReportLiabilities,
+}
+
+const (
+	FinanceExportFormatCSV  = "csv"
+	FinanceExportFormatXLSX = "xlsx"
+)
+
+// maxFinanceReportDays keeps daily reports to a year of rows.
+const maxFinanceReportDays = 366
+
+// GetFinanceReportRequest reports from From until To, both dates included.
+type GetFinanceReportRequest struct {
+	Report      string    `form:"report"`
+	Granularity string    `form:"granularity"`
+	From        string    `form:"from"`
+	To          string    `form:"to"`
+	TutorID     uuid.UUID `form:"tutorId"`
+	CategoryID  uuid.UUID `form:"categoryId"`
+	Format      string    `form:"format"`
+}
+
+func (r *GetFinanceReportRequest) Validate() error {
+	r.Report = strings.ToLower(r.Report)
+	if !slices.Contains(FinanceReports, r.Report) {
+		return errors.New("report must be revenue, commission, payouts or liabilities")
+	}
+
+	r.Granularity = strings.ToLower(r.Granularity)
+	if r.Granularity == "" {
+		r.Granularity = model.FinanceGranularityMonth
+	}
+
+	if !slices.Contains(model.FinanceGranularities, r.Granularity) {
+		return errors.New("granularity must be day, week or month")
+	}
+
+	from, err := time.Parse(time.DateOnly, r.From)
+	if err != nil {
+		return errors.New("from must be a date formatted as YYYY-MM-DD")
+	}
+
+	to, err := time.Parse(time.DateOnly, r.To)
+	if err != nil {
+		return errors.New("to must be a date formatted as YYYY-MM-DD")
+	}
+
+	if to.Before(from) {
+		return errors.New("to must not be before from")
+	}
+
+	if r.Granularity == model.FinanceGranularityDay && to.Sub(from).Hours()/24 >= maxFinanceReportDays {
+		return errors.New("daily reports cover at most 366 days")
+	}
+
+	r.Format = strings.ToLower(r.Format)
+	if r.Format == "" {
+		r.Format = FinanceExportFormatCSV
+	}
+
+	if !slices.Contains([]string{FinanceExportFormatCSV, FinanceExportFormatXLSX}, r.Format) {
+		return errors.New("format must be csv or xlsx")
+	}
+
+	return nil
+}
+
+// Filter returns the report filter of a validated request. Dates are local
+// and To is moved to the end of its day.
+func (r GetFinanceReportRequest) Filter() model.FinanceReportFilter {
+	from, _ := time.ParseInLocation(time.DateOnly, r.From, time.Local)
+	to, _ := time.ParseInLocation(time.DateOnly, r.To, time.Local)
+
+	return model.FinanceReportFilter{
+		From:        from,
+		To:          to.AddDate(0, 0, 1),
+		Granularity: r.Granularity,
+		TutorID:     r.TutorID,
+		CategoryID:  r.CategoryID,
+	}
+}
+
+type FinanceReportColumn struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+type FinanceReportRow struct {
+	Period string                     `json:"period"`
+	Values map[string]decimal.Decimal `json:"values"`
+}
+
+type FinanceReportResponse struct {
+	Report      string                     `json:"report"`
+	Granularity string                     `json:"granularity"`
+	From        string                     `json:"from"`
+	To          string                     `json:"to"`
+	Columns     []FinanceReportColumn      `json:"columns"`
+	Rows        []FinanceReportRow         `json:"rows"`
+	Totals      map[string]decimal.Decimal `json:"totals"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestGetFinanceReportRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request GetFinanceReportRequest
+		wantErr bool
+	}{
+		{name: "defaults", request: GetFinanceReportRequest{Report: "Revenue", From: "2026-01-01", To: "2026-03-31"}},
+		{name: "unknown report", request: GetFinanceReportRequest{Report: "profit", From: "2026-01-01", To: "2026-03-31"}, wantErr: true},
+		{name: "unknown granularity", request: GetFinanceReportRequest{Report: "revenue", Granularity: "year", From: "2026-01-01", To: "2026-03-31"}, wantErr: true},
+		{name: "bad date", request: GetFinanceReportRequest{Report: "revenue", From: "01/01/2026", To: "2026-03-31"}, wantErr: true},
+		{name: "to before from", request: GetFinanceReportRequest{Report: "revenue", From: "2026-03-31", To: "2026-01-01"}, wantErr: true},
+		{name: "a year of days", request: GetFinanceReportRequest{Report: "payouts", Granularity: "day", From: "2026-01-01", To: "2026-12-31"}},
+		{name: "too many days", request: GetFinanceReportRequest{Report: "payouts", Granularity: "day", From: "2026-01-01", To: "2027-01-02"}, wantErr: true},
+		{name: "unknown format", request: GetFinanceReportRequest{Report: "revenue", From: "2026-01-01", To: "2026-01-31", Format: "pdf"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetFinanceReportRequestDefaults(t *testing.T) {
+	request := GetFinanceReportRequest{Report: "REVENUE", From: "2026-01-01", To: "2026-01-31"}
+	if err := request.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if request.Report != FinanceReportRevenue || request.Granularity != model.FinanceGranularityMonth || request.Format != FinanceExportFormatCSV {
+		t.Errorf("Validate() = %+v, want revenue by month as CSV", request)
+	}
+
+	filter := request.Filter()
+	wantTo := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	if !filter.To.Equal(wantTo) {
+		t.Errorf("Filter().To = %s, want the end of the last day %s", filter.To, wantTo)
+	}
+}
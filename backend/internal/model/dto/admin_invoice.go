@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+const (
+	EFakturFormatCSV = "csv"
+	EFakturFormatXML = "xml"
+)
+
+type GetAdminInvoicesRequest struct {
+	Query        string `form:"q"`
+	IssuedAtFrom string `form:"issuedAtFrom"`
+	IssuedAtTo   string `form:"issuedAtTo"`
+	model.Pagination
+}
+
+// ExportEFakturRequest exports the invoices issued from From until To, both
+// dates included.
+type ExportEFakturRequest struct {
+	From   string `form:"from"`
+	To     string `form:"to"`
+	Format string `form:"format"`
+}
+
+func (r *ExportEFakturRequest) Validate() error {
+	from, err := time.Parse(time.DateOnly, r.From)
+	if err != nil {
+		return errors.New("from must be a date formatted as YYYY-MM-DD")
+	}
+
+	to, err := time.Parse(time.DateOnly, r.To)
+	if err != nil {
+		return errors.New("to must be a date formatted as YYYY-MM-DD")
+	}
+
+	if to.Before(from) {
+		return errors.New("to must not be before from")
+	}
+
+	r.Format = strings.ToLower(r.Format)
+	if r.Format == "" {
+		r.Format = EFakturFormatCSV
+	}
+
+	if !slices.Contains([]string{EFakturFormatCSV, EFakturFormatXML}, r.Format) {
+		return errors.New("format must be csv or xml")
+	}
+
+	return nil
+}
+
+type CreateTaxRateRequest struct {
+	AdminID         uuid.UUID       `json:"-"`
+	Name            string          `json:"name"`
+	Rate            decimal.Decimal `json:"rate"`
+	BaseNumerator   int             `json:"baseNumerator"`
+	BaseDenominator int             `json:"baseDenominator"`
+	EffectiveFrom   time.Time       `json:"effectiveFrom"`
+}
+
+func (r *CreateTaxRateRequest) Validate() error {
+	if r.Name == "" {
+		r.Name = model.TaxNameVAT
+	}
+	if !r.Rate.IsPositive() || r.Rate.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return errors.New("rate must be between 0 and 1")
+	}
+	if r.BaseNumerator == 0 && r.BaseDenominator == 0 {
+		r.BaseNumerator, r.BaseDenominator = 1, 1
+	}
+	if r.BaseNumerator <= 0 || r.BaseDenominator <= 0 {
+		return errors.New("base numerator and denominator must be greater than 0")
+	}
+	if r.EffectiveFrom.IsZero() {
+		return errors.New("effectiveFrom is required")
+	}
+	return nil
+}
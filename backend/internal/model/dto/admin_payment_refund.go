@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+// CreatePaymentRefundRequest refunds the remaining refundable amount of the
+// payment when Amount is empty.
+type CreatePaymentRefundRequest struct {
+	PaymentID uuid.UUID           `json:"-"`
+	AdminID   uuid.UUID           `json:"-"`
+	Amount    decimal.NullDecimal `json:"amount"`
+	Reason    string              `json:"reason"`
+	Note      null.String         `json:"note"`
+}
+
+func (r *CreatePaymentRefundRequest) Validate() error {
+	r.Reason = strings.ToUpper(strings.TrimSpace(r.Reason))
+	if !model.IsValidRefundReason(r.Reason) {
+		return errors.New("reason must be one of " + strings.Join(model.RefundReasons, ", "))
+	}
+	if r.Amount.Valid {
+		if !r.Amount.Decimal.IsPositive() {
+			return errors.New("amount must be greater than 0")
+		}
+		if !r.Amount.Decimal.IsInteger() {
+			return errors.New("amount must be a whole number")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type AdminListMentorBankAccountsRequest struct {
+	model.Pagination
+	Verified null.Bool `form:"verified"`
+}
+
+type AdminMentorBankAccountResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Tutor         AdminTutor `json:"tutor"`
+	BankCode      string     `json:"bank_code"`
+	BankName      string     `json:"bank_name"`
+	AccountNumber string     `json:"account_number"`
+	AccountName   string     `json:"account_name"`
+	IsDefault     bool       `json:"is_default"`
+	VerifiedAt    *time.Time `json:"verified_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func ToAdminMentorBankAccountResponse(account model.MentorBankAccount) AdminMentorBankAccountResponse {
+	return AdminMentorBankAccountResponse{
+		ID: account.ID,
+		Tutor: AdminTutor{
+			ID:          account.Tutor.ID,
+			UserID:      account.Tutor.UserID,
+			Name:        account.Tutor.User.Name,
+			PhoneNumber: account.Tutor.User.PhoneNumber,
+			Email:       account.Tutor.User.Email,
+			Status:      account.Tutor.StatusLabel(),
+			CreatedAt:   account.Tutor.CreatedAt,
+			UpdatedAt:   account.Tutor.UpdatedAt,
+		},
+		BankCode:      account.BankCode,
+		BankName:      account.BankName(),
+		AccountNumber: account.AccountNumber,
+		AccountName:   account.AccountName,
+		IsDefault:     account.IsDefault,
+		VerifiedAt:    account.VerifiedAt,
+		CreatedAt:     account.CreatedAt,
+		UpdatedAt:     account.UpdatedAt,
+	}
+}
+
+type AdminListPayoutBatchesRequest struct {
+	model.Pagination
+	Status string `form:"status"`
+}
+
+type CreatePayoutBatchRequest struct {
+	WithdrawalIDs []uuid.UUID `json:"withdrawal_ids"`
+}
+
+type AdminPayoutBatchResponse struct {
+	ID          uuid.UUID                 `json:"id"`
+	Status      string                    `json:"status"`
+	TotalCount  int                       `json:"total_count"`
+	TotalAmount decimal.Decimal           `json:"total_amount"`
+	SentAt      *time.Time                `json:"sent_at"`
+	CompletedAt *time.Time                `json:"completed_at"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	CreatedBy   uuid.UUID                 `json:"created_by"`
+	Withdrawals []AdminWithdrawalResponse `json:"withdrawals,omitempty"`
+}
+
+func ToAdminPayoutBatchResponse(batch model.PayoutBatch) AdminPayoutBatchResponse {
+	res := AdminPayoutBatchResponse{
+		ID:          batch.ID,
+		Status:      string(batch.Status),
+		TotalCount:  batch.TotalCount,
+		TotalAmount: batch.TotalAmount,
+		SentAt:      batch.SentAt,
+		CompletedAt: batch.CompletedAt,
+		CreatedAt:   batch.CreatedAt,
+		CreatedBy:   batch.CreatedBy,
+	}
+
+	for _, w := range batch.Withdrawals {
+		res.Withdrawals = append(res.Withdrawals, ToAdminWithdrawalResponse(w, w.Tutor))
+	}
+
+	return res
+}
@@ -51,7 +51,6 @@ type AdminDetailTutor struct {
 	TutorToStudentReview []AdminReview       `json:"tutorToStudentReview"`
 	PhotoProfile         null.String         `json:"photoProfile"`
 	Rating               float64             `json:"rating"`
-	LevelPoint           uint                `json:"levelPoint"`
 	Level                string              `json:"level"`
 	Status               string              `json:"status"`
 }
@@ -101,7 +100,6 @@ type UpdateAdminTutorRequest struct {
 	Latitude         decimal.NullDecimal `json:"latitude"`
 	Longitude        decimal.NullDecimal `json:"longitude"`
 	PhotoProfile     null.String         `json:"photoProfile"`
-	LevelPoint       uint                `json:"levelPoint"`
 }
 
 func (r *UpdateAdminTutorRequest) Validate() error {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"github.com/lesprivate/backend/internal/model"
 	"github.com/shopspring/decimal"
 )
@@ -27,6 +28,11 @@ type AdminWithdrawalResponse struct {
 	ProcessedAt   *time.Time      `json:"processed_at,omitempty"`
 	CreatedAt     time.Time       `json:"created_at"`
 	UpdatedAt     time.Time       `json:"updated_at"`
+
+	BankCode       string        `json:"bank_code"`
+	PayoutBatchID  uuid.NullUUID `json:"payout_batch_id"`
+	DisbursementID null.String   `json:"disbursement_id"`
+	FailureCode    null.String   `json:"failure_code"`
 }
 
 type ApproveWithdrawalRequest struct {
@@ -67,5 +73,10 @@ func ToAdminWithdrawalResponse(w model.WithdrawalRequest, tutor model.Tutor) Adm
 		ProcessedAt:   w.ProcessedAt,
 		CreatedAt:     w.CreatedAt,
 		UpdatedAt:     w.UpdatedAt,
+
+		BankCode:       w.BankCode,
+		PayoutBatchID:  w.PayoutBatchID,
+		DisbursementID: w.DisbursementID,
+		FailureCode:    w.FailureCode,
 	}
 }
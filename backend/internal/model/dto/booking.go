@@ -46,7 +46,7 @@ func NewBookingTutor(tutor *model.Tutor, status model.BookingStatus) BookingTuto
 	if tutor.DateOfBirth.Valid {
 		b.DateOfBirth = null.StringFrom(tutor.DateOfBirth.Time.Format(time.DateOnly))
 	}
-	if status != model.BookingStatusAccepted {
+	if !status.IsConfirmed() {
 		parts := strings.Split(tutor.User.Email, "@")
 		if len(parts) == 2 {
 			local := parts[0]
@@ -63,7 +63,7 @@ func NewBookingTutor(tutor *model.Tutor, status model.BookingStatus) BookingTuto
 
 	for _, v := range tutor.SocialMediaLink {
 		maskedLink := v.Link
-		if status != model.BookingStatusAccepted {
+		if !status.IsConfirmed() {
 			lastSlash := strings.LastIndex(v.Link, "/")
 			if lastSlash != -1 && lastSlash < len(v.Link)-1 {
 				maskedLink = v.Link[:lastSlash+1] + "***********"
@@ -99,7 +99,7 @@ func NewBookingStudent(student *model.Student, status model.BookingStatus) Booki
 		b.DateOfBirth = null.StringFrom(student.DateOfBirth.Time.Format(time.DateOnly))
 	}
 
-	if status != model.BookingStatusAccepted {
+	if !status.IsConfirmed() {
 		parts := strings.Split(student.User.Email, "@")
 		if len(parts) == 2 {
 			local := parts[0]
@@ -115,7 +115,7 @@ func NewBookingStudent(student *model.Student, status model.BookingStatus) Booki
 
 	for _, v := range student.SocialMediaLink {
 		maskedLink := v.Link
-		if status != model.BookingStatusAccepted {
+		if !status.IsConfirmed() {
 			lastSlash := strings.LastIndex(v.Link, "/")
 			if lastSlash != -1 && lastSlash > len(v.Link)-1 {
 				maskedLink = v.Link[:lastSlash+1] + "***********"
@@ -134,8 +134,11 @@ type BookingCourse struct {
 
 type TaskSubmissionDTO struct {
 	ID            uuid.UUID       `json:"id"`
+	Status        string          `json:"status"`
 	SubmissionURL null.String     `json:"submissionUrl"`
+	IsLate        bool            `json:"isLate"`
 	Score         decimal.Decimal `json:"score"`
+	Feedback      null.String     `json:"feedback"`
 	CreatedAt     time.Time       `json:"createdAt"`
 }
 
@@ -144,6 +147,7 @@ type SessionTaskDTO struct {
 	Title         string             `json:"title"`
 	Description   null.String        `json:"description"`
 	AttachmentURL null.String        `json:"attachmentUrl"`
+	DueAt         null.Time          `json:"dueAt"`
 	Submission    *TaskSubmissionDTO `json:"submission,omitempty"`
 	CreatedAt     time.Time          `json:"createdAt"`
 }
@@ -162,11 +166,34 @@ type BookingDetail struct {
 	Latitude     decimal.Decimal     `json:"latitude"`
 	Longitude    decimal.Decimal     `json:"longitude"`
 	Status       model.BookingStatus `json:"status"`
+	IsPriority   bool                `json:"isPriority"`
 	ExpiredAt    time.Time           `json:"expiredAt"`
 	CreatedAt    time.Time           `json:"createdAt"`
 	// Mentor grading feature
-	SessionTasks  []SessionTaskDTO `json:"sessionTasks"`
-	ReportBooking *ReportBooking   `json:"reportBooking,omitempty"`
+	SessionTasks  []SessionTaskDTO   `json:"sessionTasks"`
+	ReportBooking *ReportBooking     `json:"reportBooking,omitempty"`
+	Attendance    *BookingAttendance `json:"attendance,omitempty"`
+	Meeting       *BookingMeeting    `json:"meeting,omitempty"`
+}
+
+// BookingMeeting is the video classroom of an online session, JoinURL being
+// empty once the room is closed.
+type BookingMeeting struct {
+	Provider  string    `json:"provider"`
+	JoinURL   string    `json:"joinUrl"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func NewBookingMeeting(booking *model.Booking) *BookingMeeting {
+	if booking.Meeting == nil || !booking.Status.IsConfirmed() {
+		return nil
+	}
+
+	return &BookingMeeting{
+		Provider:  booking.Meeting.Provider,
+		JoinURL:   booking.JoinURL(),
+		ExpiresAt: booking.Meeting.ExpiresAt,
+	}
 }
 
 func NewBookingDetail(booking *model.Booking) BookingDetail {
@@ -177,8 +204,11 @@ func NewBookingDetail(booking *model.Booking) BookingDetail {
 			sub := st.TaskSubmissions[0] // Since it's a 1-to-1 relationship logically (one submission per task)
 			subDTO = &TaskSubmissionDTO{
 				ID:            sub.ID,
+				Status:        sub.Status,
 				SubmissionURL: sub.SubmissionURL,
+				IsLate:        sub.IsLate,
 				Score:         sub.Score.Decimal,
+				Feedback:      sub.Feedback,
 				CreatedAt:     sub.CreatedAt,
 			}
 		}
@@ -188,6 +218,7 @@ func NewBookingDetail(booking *model.Booking) BookingDetail {
 			Title:         st.Title,
 			Description:   st.Description,
 			AttachmentURL: st.AttachmentURL,
+			DueAt:         st.DueAt,
 			Submission:    subDTO,
 			CreatedAt:     st.CreatedAt,
 		})
@@ -207,10 +238,13 @@ func NewBookingDetail(booking *model.Booking) BookingDetail {
 		Latitude:      booking.Latitude,
 		Longitude:     booking.Longitude,
 		Status:        booking.GetStatus(),
+		IsPriority:    booking.IsPriority,
 		ExpiredAt:     booking.ExpiredAt,
 		CreatedAt:     booking.CreatedAt,
 		SessionTasks:  sessionTasks,
 		ReportBooking: NewReportBooking(booking.ReportBooking),
+		Attendance:    NewBookingAttendance(booking.Attendance),
+		Meeting:       NewBookingMeeting(booking),
 	}
 }
 
@@ -0,0 +1,132 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+// CheckInRequest carries where the tutor or the student is, required for
+// offline lessons when check-ins are geofenced.
+type CheckInRequest struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+func (r *CheckInRequest) Validate() error {
+	if (r.Latitude == nil) != (r.Longitude == nil) {
+		return errors.New("latitude and longitude must be given together")
+	}
+
+	if r.Latitude != nil && (*r.Latitude < -90 || *r.Latitude > 90) {
+		return errors.New("latitude must be between -90 and 90")
+	}
+
+	if r.Longitude != nil && (*r.Longitude < -180 || *r.Longitude > 180) {
+		return errors.New("longitude must be between -180 and 180")
+	}
+
+	return nil
+}
+
+type ReportNoShowRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r *ReportNoShowRequest) Validate() error {
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
+
+type DisputeNoShowRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r *DisputeNoShowRequest) Validate() error {
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
+
+// ResolveNoShowDisputeRequest settles a dispute, SessionHeld overturning the
+// no-show and completing the session.
+type ResolveNoShowDisputeRequest struct {
+	SessionHeld bool `json:"sessionHeld"`
+}
+
+type BookingAttendance struct {
+	TutorCheckInAt    null.Time   `json:"tutorCheckInAt"`
+	TutorCheckOutAt   null.Time   `json:"tutorCheckOutAt"`
+	StudentCheckInAt  null.Time   `json:"studentCheckInAt"`
+	StudentCheckOutAt null.Time   `json:"studentCheckOutAt"`
+	DurationMinutes   null.Int    `json:"durationMinutes"`
+	NoShowParty       null.String `json:"noShowParty"`
+	NoShowReason      null.String `json:"noShowReason"`
+	NoShowReportedAt  null.Time   `json:"noShowReportedAt"`
+	DisputeUntil      null.Time   `json:"disputeUntil"`
+	DisputedAt        null.Time   `json:"disputedAt"`
+	DisputeReason     null.String `json:"disputeReason"`
+	ResolvedAt        null.Time   `json:"resolvedAt"`
+}
+
+func NewBookingAttendance(attendance *model.BookingAttendance) *BookingAttendance {
+	if attendance == nil {
+		return nil
+	}
+
+	return &BookingAttendance{
+		TutorCheckInAt:    attendance.TutorCheckInAt,
+		TutorCheckOutAt:   attendance.TutorCheckOutAt,
+		StudentCheckInAt:  attendance.StudentCheckInAt,
+		StudentCheckOutAt: attendance.StudentCheckOutAt,
+		DurationMinutes:   attendance.DurationMinutes,
+		NoShowParty:       attendance.NoShowParty,
+		NoShowReason:      attendance.NoShowReason,
+		NoShowReportedAt:  attendance.NoShowReportedAt,
+		DisputeUntil:      attendance.DisputeUntil,
+		DisputedAt:        attendance.DisputedAt,
+		DisputeReason:     attendance.DisputeReason,
+		ResolvedAt:        attendance.ResolvedAt,
+	}
+}
+
+type NoShowDisputeResponse struct {
+	BookingID   uuid.UUID `json:"bookingId"`
+	BookingCode string    `json:"bookingCode"`
+	CourseTitle string    `json:"courseTitle"`
+	TutorName   string    `json:"tutorName"`
+	StudentName string    `json:"studentName"`
+	StartsAt    time.Time `json:"startsAt"`
+	*BookingAttendance
+}
+
+func NewNoShowDisputeResponses(attendances []model.BookingAttendance) []NoShowDisputeResponse {
+	res := make([]NoShowDisputeResponse, 0, len(attendances))
+	for i := range attendances {
+		dispute := NoShowDisputeResponse{
+			BookingID:         attendances[i].BookingID,
+			BookingAttendance: NewBookingAttendance(&attendances[i]),
+		}
+
+		if booking := attendances[i].Booking; booking != nil {
+			dispute.BookingCode = booking.Code
+			dispute.CourseTitle = booking.Course.Title
+			dispute.TutorName = booking.Tutor.User.Name
+			dispute.StudentName = booking.Student.User.Name
+			dispute.StartsAt = booking.StartsAt()
+		}
+
+		res = append(res, dispute)
+	}
+
+	return res
+}
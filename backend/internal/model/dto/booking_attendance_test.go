@@ -0,0 +1,40 @@
+package dto
+
+import "testing"
+
+func TestCheckInRequest_Validate(t *testing.T) {
+	coordinate := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		req     CheckInRequest
+		wantErr bool
+	}{
+		{name: "no location"},
+		{name: "location", req: CheckInRequest{Latitude: coordinate(-6.2), Longitude: coordinate(106.8)}},
+		{name: "latitude only", req: CheckInRequest{Latitude: coordinate(-6.2)}, wantErr: true},
+		{name: "longitude only", req: CheckInRequest{Longitude: coordinate(106.8)}, wantErr: true},
+		{name: "latitude out of range", req: CheckInRequest{Latitude: coordinate(-91), Longitude: coordinate(106.8)}, wantErr: true},
+		{name: "longitude out of range", req: CheckInRequest{Latitude: coordinate(-6.2), Longitude: coordinate(180.5)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReportNoShowRequest_Validate(t *testing.T) {
+	if err := (&ReportNoShowRequest{}).Validate(); err == nil {
+		t.Errorf("ReportNoShowRequest.Validate() error = nil, want an error without reason")
+	}
+	if err := (&ReportNoShowRequest{Reason: "Tutor tidak datang"}).Validate(); err != nil {
+		t.Errorf("ReportNoShowRequest.Validate() error = %v, want nil", err)
+	}
+	if err := (&DisputeNoShowRequest{}).Validate(); err == nil {
+		t.Errorf("DisputeNoShowRequest.Validate() error = nil, want an error without reason")
+	}
+}
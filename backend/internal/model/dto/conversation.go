@@ -0,0 +1,264 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+const maxMessageLength = 2000
+
+// StartConversationRequest opens the thread with a tutor, for students, or
+// with a student, for tutors. Giving a booking opens the thread about it
+// with the other party of the booking.
+type StartConversationRequest struct {
+	TutorID   uuid.UUID `json:"tutorId"`
+	StudentID uuid.UUID `json:"studentId"`
+	BookingID uuid.UUID `json:"bookingId"`
+}
+
+func (r *StartConversationRequest) Validate() error {
+	if r.BookingID == uuid.Nil && r.TutorID == uuid.Nil && r.StudentID == uuid.Nil {
+		return errors.New("one of tutorId, studentId or bookingId is required")
+	}
+
+	if r.TutorID != uuid.Nil && r.StudentID != uuid.Nil {
+		return errors.New("only one of tutorId or studentId can be given")
+	}
+
+	return nil
+}
+
+type GetConversationsRequest struct {
+	model.Pagination
+}
+
+type GetConversationMessagesRequest struct {
+	model.Pagination
+}
+
+type SendMessageRequest struct {
+	Body string `form:"body"`
+}
+
+func (r *SendMessageRequest) Validate(attachments int) error {
+	r.Body = strings.TrimSpace(r.Body)
+	if r.Body == "" && attachments == 0 {
+		return errors.New("body or files is required")
+	}
+
+	if len([]rune(r.Body)) > maxMessageLength {
+		return fmt.Errorf("body must be at most %d characters", maxMessageLength)
+	}
+
+	return nil
+}
+
+type ReportConversationRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r *ReportConversationRequest) Validate() error {
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	if len(r.Reason) > 500 {
+		return errors.New("reason must be at most 500 characters")
+	}
+
+	return nil
+}
+
+type ConversationParticipant struct {
+	ID           uuid.UUID   `json:"id"`
+	UserID       uuid.UUID   `json:"userId"`
+	Name         string      `json:"name"`
+	PhotoProfile null.String `json:"photoProfile"`
+}
+
+type ConversationResponse struct {
+	ID                 uuid.UUID               `json:"id"`
+	Tutor              ConversationParticipant `json:"tutor"`
+	Student            ConversationParticipant `json:"student"`
+	BookingID          uuid.NullUUID           `json:"bookingId"`
+	BookingCode        null.String             `json:"bookingCode"`
+	LastMessageAt      null.Time               `json:"lastMessageAt"`
+	LastMessagePreview null.String             `json:"lastMessagePreview"`
+	UnreadCount        int64                   `json:"unreadCount"`
+	CreatedAt          time.Time               `json:"createdAt"`
+}
+
+func NewConversationResponse(conversation model.Conversation, unread int64) ConversationResponse {
+	res := ConversationResponse{
+		ID: conversation.ID,
+		Tutor: ConversationParticipant{
+			ID:           conversation.TutorID,
+			UserID:       conversation.Tutor.UserID,
+			Name:         conversation.Tutor.User.Name,
+			PhotoProfile: conversation.Tutor.PhotoProfile,
+		},
+		Student: ConversationParticipant{
+			ID:           conversation.StudentID,
+			UserID:       conversation.Student.UserID,
+			Name:         conversation.Student.User.Name,
+			PhotoProfile: conversation.Student.PhotoProfile,
+		},
+		BookingID:          conversation.BookingID,
+		LastMessageAt:      conversation.LastMessageAt,
+		LastMessagePreview: conversation.LastMessagePreview,
+		UnreadCount:        unread,
+		CreatedAt:          conversation.CreatedAt,
+	}
+
+	if conversation.Booking != nil {
+		res.BookingCode = null.StringFrom(conversation.Booking.Code)
+	}
+
+	return res
+}
+
+type UnreadMessagesResponse struct {
+	Total int64 `json:"total"`
+}
+
+type MessageAttachment struct {
+	ID       uuid.UUID `json:"id"`
+	URL      string    `json:"url"`
+	Filename string    `json:"filename"`
+	Size     int64     `json:"size"`
+}
+
+type ConversationMessageResponse struct {
+	ID             uuid.UUID           `json:"id"`
+	ConversationID uuid.UUID           `json:"conversationId"`
+	SenderID       uuid.UUID           `json:"senderId"`
+	Body           null.String         `json:"body"`
+	IsMasked       bool                `json:"isMasked"`
+	ReadAt         null.Time           `json:"readAt"`
+	Attachments    []MessageAttachment `json:"attachments"`
+	CreatedAt      time.Time           `json:"createdAt"`
+}
+
+func NewConversationMessageResponse(message model.ConversationMessage) ConversationMessageResponse {
+	res := ConversationMessageResponse{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		SenderID:       message.SenderID,
+		Body:           message.Body,
+		IsMasked:       message.IsMasked,
+		ReadAt:         message.ReadAt,
+		Attachments:    make([]MessageAttachment, 0, len(message.Attachments)),
+		CreatedAt:      message.CreatedAt,
+	}
+
+	for _, attachment := range message.Attachments {
+		res.Attachments = append(res.Attachments, MessageAttachment{
+			ID:       attachment.ID,
+			URL:      attachment.URL,
+			Filename: attachment.Filename,
+			Size:     attachment.Size,
+		})
+	}
+
+	return res
+}
+
+func NewConversationMessageResponses(messages []model.ConversationMessage) []ConversationMessageResponse {
+	res := make([]ConversationMessageResponse, 0, len(messages))
+	for _, message := range messages {
+		res = append(res, NewConversationMessageResponse(message))
+	}
+
+	return res
+}
+
+const (
+	ConversationEventMessage = "message"
+	ConversationEventRead    = "read"
+)
+
+// ConversationEvent is pushed over the message stream of a user, a new
+// message or the other party reading the messages of the user.
+type ConversationEvent struct {
+	Type           string                       `json:"type"`
+	ConversationID uuid.UUID                    `json:"conversationId"`
+	Message        *ConversationMessageResponse `json:"message,omitempty"`
+	ReadBy         uuid.NullUUID                `json:"readBy"`
+	ReadAt         null.Time                    `json:"readAt"`
+}
+
+type GetConversationReportsRequest struct {
+	Status string `form:"status"`
+	model.Pagination
+}
+
+func (r *GetConversationReportsRequest) Validate() error {
+	switch model.ConversationReportStatus(r.Status) {
+	case "", model.ConversationReportStatusPending, model.ConversationReportStatusResolved, model.ConversationReportStatusDismissed:
+		return nil
+	}
+
+	return fmt.Errorf("invalid status %s", r.Status)
+}
+
+type ResolveConversationReportRequest struct {
+	Status string      `json:"status"`
+	Note   null.String `json:"note"`
+}
+
+func (r *ResolveConversationReportRequest) Validate() error {
+	switch model.ConversationReportStatus(r.Status) {
+	case model.ConversationReportStatusResolved, model.ConversationReportStatusDismissed:
+		return nil
+	}
+
+	return errors.New("status must be resolved or dismissed")
+}
+
+type ConversationReportResponse struct {
+	ID             uuid.UUID                      `json:"id"`
+	Conversation   ConversationResponse           `json:"conversation"`
+	ReporterID     uuid.UUID                      `json:"reporterId"`
+	ReporterName   string                         `json:"reporterName"`
+	Reason         string                         `json:"reason"`
+	Status         model.ConversationReportStatus `json:"status"`
+	ResolutionNote null.String                    `json:"resolutionNote"`
+	ResolvedAt     null.Time                      `json:"resolvedAt"`
+	CreatedAt      time.Time                      `json:"createdAt"`
+}
+
+func NewConversationReportResponse(report model.ConversationReport) ConversationReportResponse {
+	res := ConversationReportResponse{
+		ID:             report.ID,
+		ReporterID:     report.ReporterID,
+		ReporterName:   report.Reporter.Name,
+		Reason:         report.Reason,
+		Status:         report.Status,
+		ResolutionNote: report.ResolutionNote,
+		ResolvedAt:     report.ResolvedAt,
+		CreatedAt:      report.CreatedAt,
+	}
+
+	if report.Conversation != nil {
+		res.Conversation = NewConversationResponse(*report.Conversation, 0)
+	}
+
+	return res
+}
+
+func NewConversationReportResponses(reports []model.ConversationReport) []ConversationReportResponse {
+	res := make([]ConversationReportResponse, 0, len(reports))
+	for _, report := range reports {
+		res = append(res, NewConversationReportResponse(report))
+	}
+
+	return res
+}
@@ -9,6 +9,7 @@ import (
 	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,6 +34,7 @@ type GetCoursesRequest struct {
 	Radius               int             `form:"radius"`
 	MaxResponseTime      int             `form:"maxResponseTime"`
 	LevelEducationCourse []string        `form:"levelEducationCourse"`
+	Currency             string          `form:"currency"`
 	model.Pagination
 	model.Sort
 }
@@ -82,6 +84,7 @@ type Tutor struct {
 	ResponseTime     null.Int          `json:"responseTime"`
 	Rating           decimal.Decimal   `json:"rating"`
 	TotalRating      int               `json:"totalRating"`
+	RatingCriteria   *RatingCriteria   `json:"ratingCriteria,omitempty"`
 	Location         Location          `json:"location"`
 	Ratings          []TutorRating     `json:"ratings"`
 }
@@ -93,6 +96,8 @@ type Course struct {
 	IsFreeFirstCourse bool            `json:"isFreeFirstCourse"`
 	Description       string          `json:"description"`
 	Price             decimal.Decimal `json:"price"`
+	Currency          string          `json:"currency"`
+	DisplayPrice      *Money          `json:"displayPrice,omitempty"`
 	IsBooked          bool            `json:"isBooked"`
 }
 
@@ -130,8 +135,8 @@ func NewCourse(course model.Course) Course {
 			Latitude:         course.Tutor.Latitude.Decimal,
 			Longitude:        course.Tutor.Longitude.Decimal,
 			Rating:           course.Rating,
-			TotalRating:      len(course.TutorReviews),
-			Level:            course.Tutor.LevelByPoint(),
+			TotalRating:      course.TotalRating,
+			Level:            course.Tutor.LevelName(),
 			LevelOfEducation: course.Tutor.LevelOfEducation.String,
 			ResponseTime:     course.Tutor.ResponseTime,
 			Location: Location{
@@ -145,13 +150,22 @@ func NewCourse(course model.Course) Course {
 		IsFreeFirstCourse: course.IsFreeFirstCourse.Bool,
 		Description:       course.Description,
 		Price:             course.Price,
+		Currency:          cmp.Or(course.Currency, model.CurrencyIDR),
 		IsBooked:          course.IsBooked,
 	}
 }
 
+// Localize adds the price in the viewer's currency.
+func (c *Course) Localize(rates model.ExchangeRates, currency string) {
+	c.DisplayPrice = NewMoney(rates, c.Price, c.Currency, currency)
+}
+
 type CoursePrice struct {
-	DurationInHour int             `json:"durationInHour"`
-	Price          decimal.Decimal `json:"price"`
+	SessionType    model.SessionType `json:"sessionType"`
+	DurationInHour int               `json:"durationInHour"`
+	Price          decimal.Decimal   `json:"price"`
+	Currency       string            `json:"currency"`
+	DisplayPrice   *Money            `json:"displayPrice,omitempty"`
 }
 
 type CourseDetail struct {
@@ -168,7 +182,10 @@ type CourseDetail struct {
 	CourseSchedulesOnline  map[int][]CourseSchedule          `json:"courseSchedulesOnline"`
 	CourseSchedulesOffline map[int][]CourseSchedule          `json:"courseSchedulesOffline"`
 	Price                  decimal.Decimal                   `json:"price"`
+	Currency               string                            `json:"currency"`
+	DisplayPrice           *Money                            `json:"displayPrice,omitempty"`
 	IsBooked               bool                              `json:"isBooked"`
+	BookingQuota           *CourseBookingQuota               `json:"bookingQuota,omitempty"`
 }
 
 // CourseDetailWithDraft extends CourseDetail with draft-related information
@@ -191,8 +208,10 @@ func NewCoursePrices(coursePrices []model.CoursePrice) map[model.ClassType][]Cou
 		}
 
 		val = append(val, CoursePrice{
+			SessionType:    cmp.Or(price.SessionType, model.PrivateSessionType),
 			DurationInHour: price.DurationInHour,
 			Price:          price.Price,
+			Currency:       cmp.Or(price.Currency, model.CurrencyIDR),
 		})
 		resp[price.ClassType] = val
 	}
@@ -201,6 +220,7 @@ func NewCoursePrices(coursePrices []model.CoursePrice) map[model.ClassType][]Cou
 }
 
 func NewCourseDetail(course model.Course) CourseDetail {
+	ratingCriteria := NewRatingCriteria(course.Tutor)
 	return CourseDetail{
 		ID: course.ID,
 		CourseCategory: CourseCategory{
@@ -224,8 +244,8 @@ func NewCourseDetail(course model.Course) CourseDetail {
 			Latitude:         course.Tutor.Latitude.Decimal,
 			Longitude:        course.Tutor.Longitude.Decimal,
 			Rating:           course.Rating,
-			TotalRating:      len(course.TutorReviews),
-			Level:            course.Tutor.LevelByPoint(),
+			TotalRating:      course.TotalRating,
+			Level:            course.Tutor.LevelName(),
 			LevelOfEducation: course.Tutor.LevelOfEducation.String,
 			ResponseTime:     course.Tutor.ResponseTime,
 			Location: Location{
@@ -235,16 +255,28 @@ func NewCourseDetail(course model.Course) CourseDetail {
 				ShortName: course.Tutor.Location.ShortName(),
 				Type:      course.Tutor.Location.Type,
 			},
-			Ratings: NewRatings(course.TutorReviews),
+			RatingCriteria: &ratingCriteria,
+			Ratings:        NewRatings(course.TutorReviews),
 		},
 		IsFreeFirstCourse: course.IsFreeFirstCourse.Bool,
 		Description:       course.Description,
 		CoursePrices:      NewCoursePrices(course.CoursePrices),
 		Price:             course.Price,
+		Currency:          cmp.Or(course.Currency, model.CurrencyIDR),
 		IsBooked:          course.IsBooked,
 	}
 }
 
+// Localize adds the prices in the viewer's currency.
+func (c *CourseDetail) Localize(rates model.ExchangeRates, currency string) {
+	c.DisplayPrice = NewMoney(rates, c.Price, c.Currency, currency)
+	for _, prices := range c.CoursePrices {
+		for i := range prices {
+			prices[i].DisplayPrice = NewMoney(rates, prices[i].Price, prices[i].Currency, currency)
+		}
+	}
+}
+
 type OnlineChannel struct {
 	Channel  string `json:"channel"`
 	ImageURL string `json:"imageURL"`
@@ -263,9 +295,12 @@ func NewOnlineChannel(channels model.OnlineChannel) []OnlineChannel {
 }
 
 type CourseSchedule struct {
-	StartTime string          `json:"startTime"`
-	Timezone  string          `json:"timezone"`
-	ClassType model.ClassType `json:"classType"`
+	StartTime    string            `json:"startTime"`
+	Timezone     string            `json:"timezone"`
+	ClassType    model.ClassType   `json:"classType"`
+	SessionType  model.SessionType `json:"sessionType"`
+	Capacity     null.Int          `json:"capacity"`
+	MinAttendees null.Int          `json:"minAttendees"`
 }
 
 func NewCourseSchedules(schedules []model.CourseSchedule, classType model.ClassType) map[int][]CourseSchedule {
@@ -281,9 +316,12 @@ func NewCourseSchedules(schedules []model.CourseSchedule, classType model.ClassT
 		}
 
 		val = append(val, CourseSchedule{
-			StartTime: schedule.StartTime,
-			Timezone:  schedule.Timezone,
-			ClassType: schedule.ClassType,
+			StartTime:    schedule.StartTime,
+			Timezone:     schedule.Timezone,
+			ClassType:    schedule.ClassType,
+			SessionType:  cmp.Or(schedule.SessionType, model.PrivateSessionType),
+			Capacity:     schedule.Capacity,
+			MinAttendees: schedule.MinAttendees,
 		})
 
 		resp[schedule.Day] = val
@@ -293,22 +331,30 @@ func NewCourseSchedules(schedules []model.CourseSchedule, classType model.ClassT
 }
 
 type TutorRating struct {
-	StudentName  string `json:"studentName"`
-	StudentEmail string `json:"studentEmail"`
-	StudentPhoto string `json:"studentPhoto"`
-	Rating       int    `json:"rating"`
-	Review       string `json:"review"`
+	ID           uuid.UUID    `json:"id"`
+	StudentName  string       `json:"studentName"`
+	StudentEmail string       `json:"studentEmail"`
+	StudentPhoto string       `json:"studentPhoto"`
+	Rating       int          `json:"rating"`
+	Criteria     ReviewRating `json:"criteria"`
+	Review       string       `json:"review"`
+	TutorReply   null.String  `json:"tutorReply"`
+	HelpfulCount int          `json:"helpfulCount"`
 }
 
 func NewRatings(ratings []model.TutorReview) []TutorRating {
 	resp := make([]TutorRating, len(ratings))
 	for i, rating := range ratings {
 		resp[i] = TutorRating{
+			ID:           rating.ID,
 			StudentName:  rating.Student.User.Name,
 			StudentEmail: rating.Student.User.Email,
 			StudentPhoto: rating.Student.PhotoProfile.String,
 			Rating:       int(rating.Rate.ValueOrZero()),
+			Criteria:     NewReviewRating(rating),
 			Review:       rating.Review.String,
+			TutorReply:   rating.TutorReply,
+			HelpfulCount: rating.HelpfulCount,
 		}
 	}
 
@@ -368,16 +414,126 @@ type CoursePriceRequest struct {
 	Price          decimal.Decimal `json:"price" validate:"required,min=0"`
 }
 
-// CoursePricesRequest represents the course prices structure in the request
+// CoursePricesRequest represents the course prices structure in the request.
+// Every price of a course is in the same currency, IDR unless set. Group
+// prices are per seat and needed by the group schedules of the class type.
 type CoursePricesRequest struct {
-	Offline []CoursePriceRequest `json:"offline"`
-	Online  []CoursePriceRequest `json:"online"`
+	Currency     string               `json:"currency"`
+	Offline      []CoursePriceRequest `json:"offline"`
+	Online       []CoursePriceRequest `json:"online"`
+	GroupOffline []CoursePriceRequest `json:"groupOffline"`
+	GroupOnline  []CoursePriceRequest `json:"groupOnline"`
 }
 
-// CourseScheduleRequest represents a single course schedule entry
+// validateCurrency defaults the currency to IDR and checks it is supported.
+func (r *CoursePricesRequest) validateCurrency() error {
+	r.Currency = strings.ToUpper(r.Currency)
+	if r.Currency == "" {
+		r.Currency = model.CurrencyIDR
+	}
+
+	if _, ok := model.CurrencyByCode(r.Currency); !ok {
+		return fmt.Errorf("unsupported currency: %s", r.Currency)
+	}
+
+	return nil
+}
+
+// CourseScheduleRequest represents a single course schedule entry. Group
+// schedules take up to capacity students and need minAttendees of them for
+// a session to take place.
 type CourseScheduleRequest struct {
-	StartTime string `json:"startTime" validate:"required"`
-	Timezone  string `json:"timezone" validate:"required"`
+	StartTime    string            `json:"startTime" validate:"required"`
+	Timezone     string            `json:"timezone" validate:"required"`
+	SessionType  model.SessionType `json:"sessionType"`
+	Capacity     null.Int          `json:"capacity"`
+	MinAttendees null.Int          `json:"minAttendees"`
+}
+
+// Group schedules take from minGroupCapacity to maxGroupCapacity students
+const (
+	minGroupCapacity = 3
+	maxGroupCapacity = 10
+)
+
+// validateGroupSessions defaults the session type of the schedules to
+// private, checks the capacity of the group schedules and that the class
+// types with group schedules have per-seat prices.
+func validateGroupSessions(prices CoursePricesRequest, offline, online map[string][]CourseScheduleRequest) error {
+	classTypes := []struct {
+		name      string
+		schedules map[string][]CourseScheduleRequest
+		prices    []CoursePriceRequest
+	}{
+		{name: "offline", schedules: offline, prices: prices.GroupOffline},
+		{name: "online", schedules: online, prices: prices.GroupOnline},
+	}
+
+	for _, classType := range classTypes {
+		durations := make(map[int]bool)
+		for i, price := range classType.prices {
+			if price.DurationInHour <= 0 {
+				return fmt.Errorf("group %s price[%d]: durationInHour must be greater than 0", classType.name, i)
+			}
+			if price.Price.LessThan(decimal.Zero) {
+				return fmt.Errorf("group %s price[%d]: price must be greater than or equal to 0", classType.name, i)
+			}
+			if durations[price.DurationInHour] {
+				return fmt.Errorf("group %s price[%d]: duplicate durationInHour %d found", classType.name, i, price.DurationInHour)
+			}
+			durations[price.DurationInHour] = true
+		}
+
+		hasGroup := false
+		for dayStr, schedules := range classType.schedules {
+			for i := range schedules {
+				schedule := &schedules[i]
+				switch schedule.SessionType {
+				case "", model.PrivateSessionType:
+					schedule.SessionType = model.PrivateSessionType
+					schedule.Capacity = null.Int{}
+					schedule.MinAttendees = null.Int{}
+					continue
+				case model.GroupSessionType:
+				default:
+					return fmt.Errorf("day %s schedule[%d]: invalid sessionType '%s', must be one of: private, group", dayStr, i, schedule.SessionType)
+				}
+
+				hasGroup = true
+				if !schedule.Capacity.Valid || schedule.Capacity.Int64 < minGroupCapacity || schedule.Capacity.Int64 > maxGroupCapacity {
+					return fmt.Errorf("day %s schedule[%d]: capacity must be between %d and %d", dayStr, i, minGroupCapacity, maxGroupCapacity)
+				}
+				if !schedule.MinAttendees.Valid || schedule.MinAttendees.Int64 < 1 || schedule.MinAttendees.Int64 > schedule.Capacity.Int64 {
+					return fmt.Errorf("day %s schedule[%d]: minAttendees must be between 1 and the capacity", dayStr, i)
+				}
+			}
+		}
+
+		if hasGroup && len(classType.prices) == 0 {
+			return fmt.Errorf("group %s schedules need group %s prices", classType.name, classType.name)
+		}
+	}
+
+	// A group session takes the whole slot, for both class types
+	slots := make(map[string]int)
+	for _, schedulesByDay := range []map[string][]CourseScheduleRequest{offline, online} {
+		for dayStr, schedules := range schedulesByDay {
+			for _, schedule := range schedules {
+				slots[dayStr+" "+schedule.StartTime]++
+			}
+		}
+	}
+	for _, schedulesByDay := range []map[string][]CourseScheduleRequest{offline, online} {
+		for dayStr, schedules := range schedulesByDay {
+			for i, schedule := range schedules {
+				if schedule.SessionType == model.GroupSessionType && slots[dayStr+" "+schedule.StartTime] > 1 {
+					return fmt.Errorf("day %s schedule[%d]: group schedule at %s cannot share its time with another schedule", dayStr, i, schedule.StartTime)
+				}
+			}
+		}
+	}
+
+	return nil
 }
 
 // TutorCourseRequest represents the request payload for creating a new course
@@ -428,11 +584,15 @@ func (r *TutorCourseRequest) Validate() error {
 		return err
 	}
 
-	return nil
+	return validateGroupSessions(r.CoursePrices, r.CourseSchedulesOffline, r.CourseSchedulesOnline)
 }
 
 // validateCoursePrices validates the course prices structure
 func (r *TutorCourseRequest) validateCoursePrices() error {
+	if err := r.CoursePrices.validateCurrency(); err != nil {
+		return err
+	}
+
 	// Validate offline prices
 	offlineDurations := make(map[int]bool)
 	for i, price := range r.CoursePrices.Offline {
@@ -587,6 +747,7 @@ func (r *TutorCourseRequest) CreateCourse(subCategories []model.SubCourseCategor
 
 	r.ID = course.ID
 	course.CoursePrices, course.Price = r.prepareCoursesPrices()
+	course.Currency = r.CoursePrices.Currency
 	course.CourseSchedules = r.prepareCourseSchedules()
 	course.SubCourseCategories = r.prepareCourseSubCourseCategories(subCategories)
 	course.LevelEducationCourses = r.prepareLevelEducationCourses()
@@ -606,6 +767,7 @@ func (r *TutorCourseRequest) UpdateCourse(course *model.Course, subCategories []
 	course.UpdatedAt = time.Now()
 	course.UpdatedBy = uuid.NullUUID{UUID: r.UserID, Valid: true}
 	course.CoursePrices, course.Price = r.prepareCoursesPrices()
+	course.Currency = r.CoursePrices.Currency
 	course.CourseSchedules = r.prepareCourseSchedules()
 	course.SubCourseCategories = r.prepareCourseSubCourseCategories(subCategories)
 	course.LevelEducationCourses = r.prepareLevelEducationCourses()
@@ -623,8 +785,10 @@ func (r *TutorCourseRequest) prepareCoursesPrices() ([]model.CoursePrice, decima
 			ID:             uuid.New(),
 			CourseID:       r.ID,
 			ClassType:      model.OfflineClassType,
+			SessionType:    model.PrivateSessionType,
 			DurationInHour: offlinePrice.DurationInHour,
 			Price:          offlinePrice.Price,
+			Currency:       r.CoursePrices.Currency,
 			CreatedAt:      time.Now(),
 			CreatedBy:      uuid.NullUUID{UUID: r.UserID, Valid: true},
 		})
@@ -636,13 +800,39 @@ func (r *TutorCourseRequest) prepareCoursesPrices() ([]model.CoursePrice, decima
 			ID:             uuid.New(),
 			CourseID:       r.ID,
 			ClassType:      model.OnlineClassType,
+			SessionType:    model.PrivateSessionType,
 			DurationInHour: onlinePrice.DurationInHour,
 			Price:          onlinePrice.Price,
+			Currency:       r.CoursePrices.Currency,
 			CreatedAt:      time.Now(),
 			CreatedBy:      uuid.NullUUID{UUID: r.UserID, Valid: true},
 		})
 	}
 
+	groupPrices := []struct {
+		classType model.ClassType
+		prices    []CoursePriceRequest
+	}{
+		{classType: model.OfflineClassType, prices: r.CoursePrices.GroupOffline},
+		{classType: model.OnlineClassType, prices: r.CoursePrices.GroupOnline},
+	}
+	for _, group := range groupPrices {
+		for _, groupPrice := range group.prices {
+			prices = append(prices, groupPrice.Price)
+			coursePrices = append(coursePrices, model.CoursePrice{
+				ID:             uuid.New(),
+				CourseID:       r.ID,
+				ClassType:      group.classType,
+				SessionType:    model.GroupSessionType,
+				DurationInHour: groupPrice.DurationInHour,
+				Price:          groupPrice.Price,
+				Currency:       r.CoursePrices.Currency,
+				CreatedAt:      time.Now(),
+				CreatedBy:      uuid.NullUUID{UUID: r.UserID, Valid: true},
+			})
+		}
+	}
+
 	price := slices.MinFunc(prices, func(a, b decimal.Decimal) int {
 		return cmp.Compare(a.IntPart(), b.IntPart())
 	})
@@ -658,14 +848,17 @@ func (r *TutorCourseRequest) prepareCourseSchedules() []model.CourseSchedule {
 
 		for _, schedule := range schedules {
 			courseSchedules = append(courseSchedules, model.CourseSchedule{
-				ID:        uuid.New(),
-				CourseID:  r.ID,
-				Day:       day,
-				StartTime: schedule.StartTime,
-				Timezone:  schedule.Timezone,
-				ClassType: model.OfflineClassType,
-				CreatedAt: time.Now(),
-				CreatedBy: uuid.NullUUID{UUID: r.UserID, Valid: true},
+				ID:           uuid.New(),
+				CourseID:     r.ID,
+				Day:          day,
+				StartTime:    schedule.StartTime,
+				Timezone:     schedule.Timezone,
+				ClassType:    model.OfflineClassType,
+				SessionType:  cmp.Or(schedule.SessionType, model.PrivateSessionType),
+				Capacity:     schedule.Capacity,
+				MinAttendees: schedule.MinAttendees,
+				CreatedAt:    time.Now(),
+				CreatedBy:    uuid.NullUUID{UUID: r.UserID, Valid: true},
 			})
 		}
 	}
@@ -675,14 +868,17 @@ func (r *TutorCourseRequest) prepareCourseSchedules() []model.CourseSchedule {
 
 		for _, schedule := range schedules {
 			courseSchedules = append(courseSchedules, model.CourseSchedule{
-				ID:        uuid.New(),
-				CourseID:  r.ID,
-				Day:       day,
-				StartTime: schedule.StartTime,
-				Timezone:  schedule.Timezone,
-				ClassType: model.OnlineClassType,
-				CreatedAt: time.Now(),
-				CreatedBy: uuid.NullUUID{UUID: r.UserID, Valid: true},
+				ID:           uuid.New(),
+				CourseID:     r.ID,
+				Day:          day,
+				StartTime:    schedule.StartTime,
+				Timezone:     schedule.Timezone,
+				ClassType:    model.OnlineClassType,
+				SessionType:  cmp.Or(schedule.SessionType, model.PrivateSessionType),
+				Capacity:     schedule.Capacity,
+				MinAttendees: schedule.MinAttendees,
+				CreatedAt:    time.Now(),
+				CreatedBy:    uuid.NullUUID{UUID: r.UserID, Valid: true},
 			})
 		}
 	}
@@ -780,6 +976,7 @@ type TutorCourseResponse struct {
 	Price                  decimal.Decimal                   `json:"price"`
 	Status                 model.CourseStatus                `json:"status"`
 	StatusNotes            null.String                       `json:"statusNotes"`
+	RejectionChecklist     []model.CourseRejectionItem       `json:"rejectionChecklist"`
 	IsPublished            bool                              `json:"isPublished"`
 	Draft                  any                               `json:"draft" swaggertype:"object"`
 	CreatedAt              time.Time                         `json:"createdAt"`
@@ -814,6 +1011,7 @@ func NewTutorCourseResponse(course model.Course) TutorCourseResponse {
 		ClassType:              course.ClassType,
 		Status:                 course.Status,
 		StatusNotes:            course.StatusNotes,
+		RejectionChecklist:     []model.CourseRejectionItem{},
 		IsPublished:            course.IsPublished.Bool,
 		CoursePrices:           NewCoursePrices(course.CoursePrices),
 		Price:                  course.Price,
@@ -824,6 +1022,10 @@ func NewTutorCourseResponse(course model.Course) TutorCourseResponse {
 	}
 
 	if course.Draft != nil {
+		if course.Draft.Status == model.DraftStatusRejected {
+			resp.RejectionChecklist = course.Draft.GetRejectionChecklist()
+		}
+
 		var c model.Course
 		err := json.Unmarshal(course.Draft.DraftData, &c)
 		if err != nil {
@@ -893,18 +1095,37 @@ func (r *GetBookingCourseRequest) Validate() error {
 	return nil
 }
 
+// BookingCourseResponse is a slot students can book. Group slots also show
+// how many of their seats are taken.
 type BookingCourseResponse struct {
-	Status    bool            `json:"status"`
-	ClassType model.ClassType `json:"classType"`
+	Status      bool              `json:"status"`
+	ClassType   model.ClassType   `json:"classType"`
+	SessionType model.SessionType `json:"sessionType"`
+	Capacity    int               `json:"capacity,omitempty"`
+	SeatsTaken  int64             `json:"seatsTaken,omitempty"`
 }
 
-func NewBookingCourseResponse(ctx context.Context, schedules []model.CourseSchedule, bookings []model.Booking, request GetBookingCourseRequest) map[string]BookingCourseResponse {
+func NewBookingCourseResponse(ctx context.Context, schedules []model.CourseSchedule, bookings []model.Booking, sessions []model.GroupSession, request GetBookingCourseRequest) map[string]BookingCourseResponse {
+	// Seats of group sessions do not take the slot, the session does once
+	// it has a seat taken.
 	bookedSchedule := make(map[string]struct{})
 	for _, booking := range bookings {
+		if booking.GroupSessionID.Valid {
+			continue
+		}
 		key := fmt.Sprintf("%s %s", booking.BookingDate.Format(time.DateOnly), booking.BookingTime)
 		bookedSchedule[key] = struct{}{}
 	}
 
+	groupSessions := make(map[string]model.GroupSession)
+	for _, session := range sessions {
+		key := fmt.Sprintf("%s %s", session.SessionDate.Format(time.DateOnly), session.SessionTime)
+		groupSessions[key] = session
+		if session.Status != model.GroupSessionStatusCancelled && session.SeatsTaken > 0 {
+			bookedSchedule[key] = struct{}{}
+		}
+	}
+
 	resp := make(map[string]BookingCourseResponse)
 	startDate, err := time.Parse(time.DateOnly, request.StartDate)
 	if err != nil {
@@ -919,7 +1140,16 @@ func NewBookingCourseResponse(ctx context.Context, schedules []model.CourseSched
 	}
 
 	availableSchedules := make(map[int]map[string]model.ClassType)
+	groupSchedules := make(map[int]map[string]model.CourseSchedule)
 	for _, schedule := range schedules {
+		if schedule.IsGroup() {
+			if _, ok := groupSchedules[schedule.Day]; !ok {
+				groupSchedules[schedule.Day] = make(map[string]model.CourseSchedule)
+			}
+			groupSchedules[schedule.Day][schedule.StartTime] = schedule
+			continue
+		}
+
 		if _, ok := availableSchedules[schedule.Day]; !ok {
 			availableSchedules[schedule.Day] = make(map[string]model.ClassType)
 		}
@@ -931,11 +1161,9 @@ func NewBookingCourseResponse(ctx context.Context, schedules []model.CourseSched
 		availableSchedules[schedule.Day][schedule.StartTime] = c
 	}
 
+	now := time.Now()
 	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
 		dayOfWeek := int(d.Weekday())
-		if _, ok := availableSchedules[dayOfWeek]; !ok {
-			continue
-		}
 
 		for startTime, classType := range availableSchedules[dayOfWeek] {
 			key := fmt.Sprintf("%s %s", d.Format(time.DateOnly), startTime)
@@ -944,10 +1172,31 @@ func NewBookingCourseResponse(ctx context.Context, schedules []model.CourseSched
 			}
 
 			resp[key] = BookingCourseResponse{
-				Status:    true,
-				ClassType: classType,
+				Status:      true,
+				ClassType:   classType,
+				SessionType: model.PrivateSessionType,
 			}
 		}
+
+		for startTime, schedule := range groupSchedules[dayOfWeek] {
+			key := fmt.Sprintf("%s %s", d.Format(time.DateOnly), startTime)
+			slot := BookingCourseResponse{
+				Status:      true,
+				ClassType:   schedule.ClassType,
+				SessionType: model.GroupSessionType,
+				Capacity:    int(schedule.Capacity.Int64),
+			}
+
+			if session, ok := groupSessions[key]; ok {
+				slot.Capacity = session.Capacity
+				slot.SeatsTaken = session.SeatsTaken
+				slot.Status = session.IsBookable(now) && session.SeatsTaken < int64(session.Capacity)
+			} else if _, ok := bookedSchedule[key]; ok {
+				continue
+			}
+
+			resp[key] = slot
+		}
 	}
 
 	return resp
@@ -0,0 +1,175 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+const (
+	CourseModerationAssigneeMe         = "me"
+	CourseModerationAssigneeUnassigned = "unassigned"
+
+	maxBulkCourseModeration = 100
+)
+
+type CourseModerationQueueRequest struct {
+	AdminID  uuid.UUID                 `form:"-"`
+	Assignee string                    `form:"assignee"`
+	SLA      model.CourseModerationSLA `form:"sla"`
+	model.Pagination
+}
+
+func (r CourseModerationQueueRequest) Validate() error {
+	switch r.Assignee {
+	case "", CourseModerationAssigneeMe, CourseModerationAssigneeUnassigned:
+	default:
+		return errors.New("assignee must be one of me or unassigned")
+	}
+
+	switch r.SLA {
+	case "", model.CourseModerationSLAOnTrack, model.CourseModerationSLAAtRisk, model.CourseModerationSLABreached:
+	default:
+		return errors.New("sla must be one of on_track, at_risk or breached")
+	}
+
+	return nil
+}
+
+type CourseModerationQueueItem struct {
+	CourseID     uuid.UUID                 `json:"courseId"`
+	DraftID      uuid.UUID                 `json:"draftId"`
+	Title        string                    `json:"title"`
+	TutorName    string                    `json:"tutorName"`
+	DraftType    model.DraftType           `json:"draftType"`
+	SubmittedAt  time.Time                 `json:"submittedAt"`
+	AgeHours     int                       `json:"ageHours"`
+	SLA          model.CourseModerationSLA `json:"sla"`
+	DueAt        time.Time                 `json:"dueAt"`
+	AssignedTo   uuid.NullUUID             `json:"assignedTo"`
+	AssigneeName null.String               `json:"assigneeName"`
+	AssignedAt   null.Time                 `json:"assignedAt"`
+}
+
+// NewCourseModerationQueueItem builds a queue entry for a pending draft as of
+// now against the given SLA thresholds.
+func NewCourseModerationQueueItem(draft model.CourseDraft, now time.Time, warning, limit time.Duration) CourseModerationQueueItem {
+	submittedAt := draft.SubmittedAt.ValueOrZero()
+	if submittedAt.IsZero() {
+		submittedAt = draft.UpdatedAt
+	}
+	age := now.Sub(submittedAt)
+
+	item := CourseModerationQueueItem{
+		CourseID:    draft.CourseID,
+		DraftID:     draft.ID,
+		Title:       draft.Course.Title,
+		TutorName:   draft.Course.Tutor.User.Name,
+		DraftType:   draft.DraftType,
+		SubmittedAt: submittedAt,
+		AgeHours:    int(age.Hours()),
+		SLA:         model.CourseModerationSLAByAge(age, warning, limit),
+		DueAt:       submittedAt.Add(limit),
+		AssignedTo:  draft.AssignedTo,
+		AssignedAt:  draft.AssignedAt,
+	}
+
+	// the title under review is the one in the draft, not the live one
+	var content struct {
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal(draft.DraftData, &content); err == nil && content.Title != "" {
+		item.Title = content.Title
+	}
+
+	if draft.Assignee != nil {
+		item.AssigneeName = null.StringFrom(draft.Assignee.Name)
+	}
+
+	return item
+}
+
+type AssignCourseModerationRequest struct {
+	CourseID uuid.UUID     `json:"-"`
+	AdminID  uuid.NullUUID `json:"adminId"`
+}
+
+type BulkApproveCoursesRequest struct {
+	CourseIDs   []uuid.UUID `json:"courseIds"`
+	ReviewNotes null.String `json:"reviewNotes"`
+}
+
+func (r BulkApproveCoursesRequest) Validate() error {
+	return validateBulkCourseIDs(r.CourseIDs)
+}
+
+type BulkRejectCoursesRequest struct {
+	CourseIDs   []uuid.UUID `json:"courseIds"`
+	ReviewNotes string      `json:"reviewNotes"`
+	ReasonCodes []string    `json:"reasonCodes"`
+}
+
+func (r BulkRejectCoursesRequest) Validate() error {
+	if err := validateBulkCourseIDs(r.CourseIDs); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(r.ReviewNotes) == "" && len(r.ReasonCodes) == 0 {
+		return errors.New("reviewNotes or reasonCodes is required")
+	}
+
+	return nil
+}
+
+func validateBulkCourseIDs(ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return errors.New("courseIds is required")
+	}
+
+	if len(ids) > maxBulkCourseModeration {
+		return errors.New("courseIds must not contain more than 100 courses")
+	}
+
+	return nil
+}
+
+type CourseModerationBulkResult struct {
+	CourseID uuid.UUID `json:"courseId"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type CourseRejectionReasonRequest struct {
+	ID          uuid.UUID   `json:"-"`
+	AdminID     uuid.UUID   `json:"-"`
+	Code        string      `json:"code"`
+	Title       string      `json:"title"`
+	Description null.String `json:"description"`
+	Sequence    int         `json:"sequence"`
+	IsActive    null.Bool   `json:"isActive"`
+}
+
+func (r *CourseRejectionReasonRequest) Validate() error {
+	r.Code = strings.ToLower(strings.TrimSpace(r.Code))
+	r.Title = strings.TrimSpace(r.Title)
+
+	if r.Code == "" {
+		return errors.New("code is required")
+	}
+
+	if strings.ContainsAny(r.Code, " ,") {
+		return errors.New("code must not contain spaces or commas")
+	}
+
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+
+	return nil
+}
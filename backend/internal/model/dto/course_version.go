@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type CourseVersionResponse struct {
+	ID           uuid.UUID                 `json:"id"`
+	Version      int                       `json:"version"`
+	Source       model.CourseVersionSource `json:"source"`
+	DraftID      uuid.NullUUID             `json:"draftId"`
+	RestoredFrom null.Int                  `json:"restoredFrom"`
+	CreatedAt    time.Time                 `json:"createdAt"`
+	CreatedBy    uuid.NullUUID             `json:"createdBy"`
+	Snapshot     *model.CourseSnapshot     `json:"snapshot,omitempty"`
+}
+
+func NewCourseVersionResponse(version model.CourseVersion) CourseVersionResponse {
+	return CourseVersionResponse{
+		ID:           version.ID,
+		Version:      version.Version,
+		Source:       version.Source,
+		DraftID:      version.DraftID,
+		RestoredFrom: version.RestoredFrom,
+		CreatedAt:    version.CreatedAt,
+		CreatedBy:    version.CreatedBy,
+	}
+}
+
+func NewCourseVersionResponses(versions []model.CourseVersion) []CourseVersionResponse {
+	resp := make([]CourseVersionResponse, 0, len(versions))
+	for _, version := range versions {
+		resp = append(resp, NewCourseVersionResponse(version))
+	}
+	return resp
+}
+
+type CourseVersionDiffRequest struct {
+	CourseID uuid.UUID
+	From     int
+	To       int
+}
+
+func (r CourseVersionDiffRequest) Validate() error {
+	if r.From <= 0 || r.To <= 0 {
+		return errors.New("from and to must be positive version numbers")
+	}
+	if r.From == r.To {
+		return errors.New("from and to must be different versions")
+	}
+	return nil
+}
+
+type CourseVersionDiffResponse struct {
+	From    int                     `json:"from"`
+	To      int                     `json:"to"`
+	Changes []model.CourseFieldDiff `json:"changes"`
+}
+
+type RollbackCourseVersionRequest struct {
+	CourseID uuid.UUID
+	Version  int
+	AdminID  uuid.UUID
+}
+
+// CourseDraftResponse is a draft together with the changes it makes to the
+// live course.
+type CourseDraftResponse struct {
+	ID                 uuid.UUID                   `json:"id"`
+	CourseID           uuid.UUID                   `json:"courseId"`
+	DraftType          model.DraftType             `json:"draftType"`
+	Status             model.DraftStatus           `json:"status"`
+	SubmittedAt        null.Time                   `json:"submittedAt"`
+	ReviewedAt         null.Time                   `json:"reviewedAt"`
+	ReviewNotes        null.String                 `json:"reviewNotes"`
+	CreatedAt          time.Time                   `json:"createdAt"`
+	UpdatedAt          time.Time                   `json:"updatedAt"`
+	Changes            []model.CourseFieldDiff     `json:"changes"`
+	Conflict           *model.ConflictInfo         `json:"conflict,omitempty"`
+	RejectionChecklist []model.CourseRejectionItem `json:"rejectionChecklist"`
+}
+
+func NewCourseDraftResponse(draft model.CourseDraft, changes []model.CourseFieldDiff) CourseDraftResponse {
+	return CourseDraftResponse{
+		ID:                 draft.ID,
+		CourseID:           draft.CourseID,
+		DraftType:          draft.DraftType,
+		Status:             draft.Status,
+		SubmittedAt:        draft.SubmittedAt,
+		ReviewedAt:         draft.ReviewedAt,
+		ReviewNotes:        draft.ReviewNotes,
+		CreatedAt:          draft.CreatedAt,
+		UpdatedAt:          draft.UpdatedAt,
+		Changes:            changes,
+		RejectionChecklist: draft.GetRejectionChecklist(),
+	}
+}
+
+type ResolveCourseDraftConflictRequest struct {
+	ID         uuid.UUID                `json:"-"`
+	UserID     uuid.UUID                `json:"-"`
+	Resolution model.ConflictResolution `json:"resolution"`
+}
+
+func (r ResolveCourseDraftConflictRequest) Validate() error {
+	switch r.Resolution {
+	case model.ConflictResolutionKeepDraft, model.ConflictResolutionUpdateDraft, model.ConflictResolutionForceSubmit:
+		return nil
+	default:
+		return errors.New("resolution must be one of keep_draft, update_draft or force_submit")
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+// Money is an amount converted to the currency the viewer asked for.
+type Money struct {
+	Amount   decimal.Decimal `json:"amount"`
+	Currency string          `json:"currency"`
+	Rate     decimal.Decimal `json:"rate"`
+}
+
+// NewMoney converts an amount to the given currency. It is nil when no
+// currency is asked for, the amount is already in it or no rate is known.
+func NewMoney(rates model.ExchangeRates, amount decimal.Decimal, from, to string) *Money {
+	if to == "" || to == from {
+		return nil
+	}
+
+	converted, rate, ok := rates.Convert(amount, from, to)
+	if !ok {
+		return nil
+	}
+
+	return &Money{
+		Amount:   converted,
+		Currency: to,
+		Rate:     rate,
+	}
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type StudentEntitlementsResponse struct {
+	Plan               string                     `json:"plan"`
+	IsPremium          bool                       `json:"isPremium"`
+	PremiumUntil       null.Time                  `json:"premiumUntil"`
+	BookingPerDay      model.EntitlementQuota     `json:"bookingPerDay"`
+	BookingPerCategory []CategoryEntitlementQuota `json:"bookingPerCategory"`
+	Limits             model.PlanEntitlement      `json:"limits"`
+}
+
+// CategoryEntitlementQuota is the usage of the per category quotas for one
+// course category the student booked today.
+type CategoryEntitlementQuota struct {
+	CourseCategoryID uuid.UUID              `json:"courseCategoryId"`
+	Booking          model.EntitlementQuota `json:"booking"`
+	FreeFirstCourse  model.EntitlementQuota `json:"freeFirstCourse"`
+}
+
+// CourseBookingQuota tells a student how many bookings are left before
+// booking the course.
+type CourseBookingQuota struct {
+	BookingPerDay      model.EntitlementQuota  `json:"bookingPerDay"`
+	BookingPerCategory model.EntitlementQuota  `json:"bookingPerCategory"`
+	FreeFirstCourse    *model.EntitlementQuota `json:"freeFirstCourse,omitempty"`
+}
+
+type UpdatePlanEntitlementRequest struct {
+	ID                            uuid.UUID `json:"-"`
+	AdminID                       uuid.UUID `json:"-"`
+	Name                          string    `json:"name"`
+	MaxBookingPerDay              null.Int  `json:"maxBookingPerDay"`
+	MaxBookingPerCategory         null.Int  `json:"maxBookingPerCategory"`
+	MaxFreeFirstCoursePerCategory null.Int  `json:"maxFreeFirstCoursePerCategory"`
+	MonthlyReport                 bool      `json:"monthlyReport"`
+	PriorityResponse              bool      `json:"priorityResponse"`
+}
+
+func (r UpdatePlanEntitlementRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	for _, limit := range []null.Int{r.MaxBookingPerDay, r.MaxBookingPerCategory, r.MaxFreeFirstCoursePerCategory} {
+		if limit.Valid && limit.Int64 < 0 {
+			return errors.New("limits must not be negative")
+		}
+	}
+
+	return nil
+}
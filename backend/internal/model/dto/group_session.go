@@ -0,0 +1,193 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type GetGroupSessionsRequest struct {
+	Status   model.GroupSessionStatus `form:"status"`
+	DateFrom string                   `form:"dateFrom"`
+	DateTo   string                   `form:"dateTo"`
+	model.Pagination
+}
+
+func (r *GetGroupSessionsRequest) Validate() error {
+	switch r.Status {
+	case "", model.GroupSessionStatusOpen, model.GroupSessionStatusConfirmed, model.GroupSessionStatusCancelled:
+	default:
+		return errors.New("status must be one of open, confirmed, cancelled")
+	}
+
+	if r.DateFrom != "" {
+		if _, err := time.Parse(time.DateOnly, r.DateFrom); err != nil {
+			return errors.New("invalid dateFrom format")
+		}
+	}
+
+	if r.DateTo != "" {
+		if _, err := time.Parse(time.DateOnly, r.DateTo); err != nil {
+			return errors.New("invalid dateTo format")
+		}
+	}
+
+	return nil
+}
+
+func (r *GetGroupSessionsRequest) Filter(tutorID uuid.UUID) model.GroupSessionFilter {
+	filter := model.GroupSessionFilter{
+		TutorID:    tutorID,
+		Status:     r.Status,
+		Pagination: r.Pagination,
+	}
+	filter.DateFrom, _ = time.Parse(time.DateOnly, r.DateFrom)
+	filter.DateTo, _ = time.Parse(time.DateOnly, r.DateTo)
+
+	return filter
+}
+
+// GroupSessionTaskRequest gives a task to every student of a group session,
+// only to the given ones when studentIds is set
+type GroupSessionTaskRequest struct {
+	Title         string                  `json:"title"`
+	Description   *string                 `json:"description"`
+	AttachmentURL *string                 `json:"attachmentUrl"`
+	DueAt         *time.Time              `json:"dueAt"`
+	Rubric        []model.RubricCriterion `json:"rubric"`
+	StudentIDs    []uuid.UUID             `json:"studentIds"`
+}
+
+func (r *GroupSessionTaskRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+
+	if r.DueAt != nil && !r.DueAt.After(time.Now()) {
+		return errors.New("dueAt must be in the future")
+	}
+
+	if len(r.Rubric) > 0 {
+		if err := model.ValidateRubric(r.Rubric); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GroupSessionNotesRequest sets the session notes of every student of a
+// group session, only of the given ones when studentIds is set
+type GroupSessionNotesRequest struct {
+	Notes      string      `json:"notes"`
+	StudentIDs []uuid.UUID `json:"studentIds"`
+}
+
+func (r *GroupSessionNotesRequest) Validate() error {
+	r.Notes = strings.TrimSpace(r.Notes)
+	if r.Notes == "" {
+		return errors.New("notes is required")
+	}
+
+	return nil
+}
+
+type GroupSessionResponse struct {
+	ID           uuid.UUID                `json:"id"`
+	CourseID     uuid.UUID                `json:"courseId"`
+	CourseTitle  string                   `json:"courseTitle"`
+	ClassType    model.ClassType          `json:"classType"`
+	SessionDate  string                   `json:"sessionDate"`
+	SessionTime  string                   `json:"sessionTime"`
+	Timezone     string                   `json:"timezone"`
+	Capacity     int                      `json:"capacity"`
+	MinAttendees int                      `json:"minAttendees"`
+	SeatsTaken   int64                    `json:"seatsTaken"`
+	Status       model.GroupSessionStatus `json:"status"`
+	CutoffAt     time.Time                `json:"cutoffAt"`
+	ConfirmedAt  null.Time                `json:"confirmedAt"`
+	CancelledAt  null.Time                `json:"cancelledAt"`
+}
+
+func NewGroupSessionResponse(session model.GroupSession) GroupSessionResponse {
+	return GroupSessionResponse{
+		ID:           session.ID,
+		CourseID:     session.CourseID,
+		CourseTitle:  session.Course.Title,
+		ClassType:    session.ClassType,
+		SessionDate:  session.SessionDate.Format(time.DateOnly),
+		SessionTime:  session.SessionTime,
+		Timezone:     session.Timezone,
+		Capacity:     session.Capacity,
+		MinAttendees: session.MinAttendees,
+		SeatsTaken:   session.SeatsTaken,
+		Status:       session.Status,
+		CutoffAt:     session.CutoffAt,
+		ConfirmedAt:  session.ConfirmedAt,
+		CancelledAt:  session.CancelledAt,
+	}
+}
+
+func NewGroupSessionResponses(sessions []model.GroupSession) []GroupSessionResponse {
+	res := make([]GroupSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, NewGroupSessionResponse(session))
+	}
+
+	return res
+}
+
+// GroupSessionSeatResponse is a student on the roster of a group session
+type GroupSessionSeatResponse struct {
+	BookingID    uuid.UUID           `json:"bookingId"`
+	StudentID    uuid.UUID           `json:"studentId"`
+	Name         string              `json:"name"`
+	Email        string              `json:"email"`
+	PhotoProfile null.String         `json:"photoProfile"`
+	Status       model.BookingStatus `json:"status"`
+	Notes        null.String         `json:"notes"`
+	TaskCount    int                 `json:"taskCount"`
+	CheckedInAt  null.Time           `json:"checkedInAt"`
+	JoinURL      string              `json:"joinUrl,omitempty"`
+	BookedAt     time.Time           `json:"bookedAt"`
+}
+
+type GroupSessionRosterResponse struct {
+	GroupSessionResponse
+	Seats []GroupSessionSeatResponse `json:"seats"`
+}
+
+func NewGroupSessionRosterResponse(session model.GroupSession, seats []model.Booking) GroupSessionRosterResponse {
+	res := GroupSessionRosterResponse{
+		GroupSessionResponse: NewGroupSessionResponse(session),
+		Seats:                make([]GroupSessionSeatResponse, 0, len(seats)),
+	}
+
+	for _, seat := range seats {
+		item := GroupSessionSeatResponse{
+			BookingID:    seat.ID,
+			StudentID:    seat.StudentID,
+			Name:         seat.Student.User.Name,
+			Email:        seat.Student.User.Email,
+			PhotoProfile: seat.Student.PhotoProfile,
+			Status:       seat.GetStatus(),
+			Notes:        seat.NotesStudent,
+			TaskCount:    len(seat.SessionTasks),
+			JoinURL:      seat.JoinURL(),
+			BookedAt:     seat.CreatedAt,
+		}
+		if seat.Attendance != nil {
+			item.CheckedInAt = seat.Attendance.StudentCheckInAt
+		}
+
+		res.Seats = append(res.Seats, item)
+	}
+
+	return res
+}
@@ -0,0 +1,217 @@
+package dto
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestValidateGroupSessions(t *testing.T) {
+	groupPrices := []CoursePriceRequest{{DurationInHour: 2, Price: decimal.NewFromInt(75000)}}
+	group := func(capacity, minAttendees int64) CourseScheduleRequest {
+		return CourseScheduleRequest{
+			StartTime:    "09:00",
+			Timezone:     "Asia/Jakarta",
+			SessionType:  model.GroupSessionType,
+			Capacity:     null.IntFrom(capacity),
+			MinAttendees: null.IntFrom(minAttendees),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		prices  CoursePricesRequest
+		offline map[string][]CourseScheduleRequest
+		online  map[string][]CourseScheduleRequest
+		wantErr bool
+	}{
+		{
+			name:   "group schedule with per-seat prices",
+			prices: CoursePricesRequest{GroupOnline: groupPrices},
+			online: map[string][]CourseScheduleRequest{"6": {group(10, 3)}},
+		},
+		{
+			name:    "group schedule without per-seat prices",
+			prices:  CoursePricesRequest{GroupOnline: groupPrices},
+			offline: map[string][]CourseScheduleRequest{"6": {group(5, 3)}},
+			wantErr: true,
+		},
+		{
+			name:    "capacity under the minimum",
+			prices:  CoursePricesRequest{GroupOnline: groupPrices},
+			online:  map[string][]CourseScheduleRequest{"6": {group(2, 1)}},
+			wantErr: true,
+		},
+		{
+			name:    "capacity over the maximum",
+			prices:  CoursePricesRequest{GroupOnline: groupPrices},
+			online:  map[string][]CourseScheduleRequest{"6": {group(11, 3)}},
+			wantErr: true,
+		},
+		{
+			name:    "minimum attendees over the capacity",
+			prices:  CoursePricesRequest{GroupOnline: groupPrices},
+			online:  map[string][]CourseScheduleRequest{"6": {group(5, 6)}},
+			wantErr: true,
+		},
+		{
+			name:    "group schedule sharing its slot",
+			prices:  CoursePricesRequest{GroupOnline: groupPrices},
+			online:  map[string][]CourseScheduleRequest{"6": {group(5, 3)}},
+			offline: map[string][]CourseScheduleRequest{"6": {{StartTime: "09:00", Timezone: "Asia/Jakarta"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid session type",
+			online:  map[string][]CourseScheduleRequest{"6": {{StartTime: "09:00", SessionType: "class"}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate per-seat duration",
+			prices:  CoursePricesRequest{GroupOffline: append(groupPrices, groupPrices...)},
+			wantErr: true,
+		},
+		{
+			name:    "negative per-seat price",
+			prices:  CoursePricesRequest{GroupOffline: []CoursePriceRequest{{DurationInHour: 1, Price: decimal.NewFromInt(-1)}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateGroupSessions(tt.prices, tt.offline, tt.online); (err != nil) != tt.wantErr {
+				t.Errorf("validateGroupSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGroupSessionsDefaultsPrivate(t *testing.T) {
+	offline := map[string][]CourseScheduleRequest{
+		"1": {{StartTime: "09:00", Capacity: null.IntFrom(5), MinAttendees: null.IntFrom(3)}},
+	}
+
+	if err := validateGroupSessions(CoursePricesRequest{}, offline, nil); err != nil {
+		t.Fatalf("validateGroupSessions() error = %v", err)
+	}
+
+	schedule := offline["1"][0]
+	if schedule.SessionType != model.PrivateSessionType || schedule.Capacity.Valid || schedule.MinAttendees.Valid {
+		t.Errorf("schedule = %+v, want a private schedule without capacity", schedule)
+	}
+}
+
+func TestNewBookingCourseResponseGroupSlots(t *testing.T) {
+	// Three consecutive Saturdays a month ahead so their sessions are bookable
+	start := time.Now().AddDate(0, 1, 0)
+	for start.Weekday() != time.Saturday {
+		start = start.AddDate(0, 0, 1)
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	dates := []time.Time{start, start.AddDate(0, 0, 7), start.AddDate(0, 0, 14)}
+	key := func(date time.Time) string { return date.Format(time.DateOnly) + " 09:00:00" }
+
+	schedules := []model.CourseSchedule{{
+		Day:          int(time.Saturday),
+		StartTime:    "09:00:00",
+		ClassType:    model.OnlineClassType,
+		SessionType:  model.GroupSessionType,
+		Capacity:     null.IntFrom(5),
+		MinAttendees: null.IntFrom(3),
+	}}
+	session := func(date time.Time, seatsTaken int64) model.GroupSession {
+		return model.GroupSession{
+			SessionDate: date,
+			SessionTime: "09:00:00",
+			Capacity:    4,
+			Status:      model.GroupSessionStatusOpen,
+			CutoffAt:    date.Add(-24 * time.Hour),
+			SeatsTaken:  seatsTaken,
+		}
+	}
+	sessions := []model.GroupSession{session(dates[1], 2), session(dates[2], 4)}
+	// A seat does not take the slot like a private booking does
+	bookings := []model.Booking{{BookingDate: dates[0], BookingTime: "09:00:00", GroupSessionID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}}
+
+	resp := NewBookingCourseResponse(context.Background(), schedules, bookings, sessions, GetBookingCourseRequest{
+		StartDate: dates[0].Format(time.DateOnly),
+		EndDate:   dates[2].Format(time.DateOnly),
+	})
+
+	if len(resp) != 3 {
+		t.Fatalf("NewBookingCourseResponse() = %v, want the 3 Saturdays", resp)
+	}
+
+	tests := []struct {
+		name   string
+		slot   BookingCourseResponse
+		status bool
+		seats  int64
+		total  int
+	}{
+		{name: "no session yet", slot: resp[key(dates[0])], status: true, total: 5},
+		{name: "seats left", slot: resp[key(dates[1])], status: true, seats: 2, total: 4},
+		{name: "full", slot: resp[key(dates[2])], status: false, seats: 4, total: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.slot.SessionType != model.GroupSessionType || tt.slot.Status != tt.status ||
+				tt.slot.SeatsTaken != tt.seats || tt.slot.Capacity != tt.total {
+				t.Errorf("slot = %+v, want a group slot with %d of %d seats taken, bookable %v", tt.slot, tt.seats, tt.total, tt.status)
+			}
+		})
+	}
+}
+
+func TestGetGroupSessionsRequest_Validate(t *testing.T) {
+	req := GetGroupSessionsRequest{Status: model.GroupSessionStatusConfirmed, DateFrom: "2026-03-01", DateTo: "2026-03-31"}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+
+	filter := req.Filter(uuid.Nil)
+	if !filter.DateFrom.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !filter.DateTo.Equal(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Filter() = %+v, want the dates parsed", filter)
+	}
+
+	for _, req := range []GetGroupSessionsRequest{
+		{Status: "full"},
+		{DateFrom: "01-03-2026"},
+		{DateTo: "2026-3-31"},
+	} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate() with %+v error = nil, want an error", req)
+		}
+	}
+}
+
+func TestGroupSessionTaskRequest_Validate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		req     GroupSessionTaskRequest
+		wantErr bool
+	}{
+		{name: "valid", req: GroupSessionTaskRequest{Title: "Latihan soal"}},
+		{name: "no title", req: GroupSessionTaskRequest{Title: "  "}, wantErr: true},
+		{name: "due in the past", req: GroupSessionTaskRequest{Title: "Latihan soal", DueAt: &past}, wantErr: true},
+		{name: "invalid rubric", req: GroupSessionTaskRequest{Title: "Latihan soal", Rubric: []model.RubricCriterion{{Name: "Isi"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
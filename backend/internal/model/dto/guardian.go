@@ -0,0 +1,374 @@
+package dto
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+// MaxFamilySeats is the most students one family payment covers, and the most
+// codes one gift payment buys.
+const MaxFamilySeats = 10
+
+type CreateGuardianStudentRequest struct {
+	Email string `json:"email"`
+}
+
+func (r *CreateGuardianStudentRequest) Validate() error {
+	r.Email = strings.TrimSpace(r.Email)
+	if r.Email == "" {
+		return errors.New("email is required")
+	}
+
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	if !emailRegex.MatchString(r.Email) {
+		return errors.New("invalid email format")
+	}
+
+	return nil
+}
+
+// CreateGuardianStudentResponse does not tell whether the email belongs to a
+// student.
+type CreateGuardianStudentResponse struct {
+	Message string `json:"message"`
+}
+
+// GuardianStudentResponse is a guardian link as seen by either side.
+type GuardianStudentResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Status        string    `json:"status"`
+	AcceptedAt    null.Time `json:"acceptedAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+	GuardianID    uuid.UUID `json:"guardianId"`
+	GuardianName  string    `json:"guardianName"`
+	GuardianEmail string    `json:"guardianEmail"`
+	StudentID     uuid.UUID `json:"studentId"`
+	StudentName   string    `json:"studentName"`
+	StudentEmail  string    `json:"studentEmail"`
+	IsPremium     bool      `json:"isPremium"`
+	PremiumUntil  null.Time `json:"premiumUntil"`
+}
+
+func NewGuardianStudentResponse(link model.GuardianStudent) GuardianStudentResponse {
+	return GuardianStudentResponse{
+		ID:            link.ID,
+		Status:        link.Status,
+		AcceptedAt:    link.AcceptedAt,
+		CreatedAt:     link.CreatedAt,
+		GuardianID:    link.GuardianID,
+		GuardianName:  link.Guardian.User.Name,
+		GuardianEmail: link.Guardian.User.Email,
+		StudentID:     link.StudentID,
+		StudentName:   link.Student.User.Name,
+		StudentEmail:  link.Student.User.Email,
+		IsPremium:     link.Student.IsPremium(),
+		PremiumUntil:  link.Student.PremiumUntil,
+	}
+}
+
+func NewGuardianStudentResponses(links []model.GuardianStudent) []GuardianStudentResponse {
+	resp := make([]GuardianStudentResponse, 0, len(links))
+	for _, link := range links {
+		resp = append(resp, NewGuardianStudentResponse(link))
+	}
+
+	return resp
+}
+
+// CreateFamilySubscriptionRequest buys premium for linked students, one seat
+// per student.
+type CreateFamilySubscriptionRequest struct {
+	SubscriptionID uuid.UUID   `json:"subscriptionId"`
+	IntervalCount  int         `json:"intervalCount"`
+	StudentIDs     []uuid.UUID `json:"studentIds"`
+}
+
+func (r *CreateFamilySubscriptionRequest) Validate() error {
+	if len(r.StudentIDs) == 0 {
+		return errors.New("studentIds is required")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(r.StudentIDs))
+	for _, id := range r.StudentIDs {
+		if seen[id] {
+			return errors.New("studentIds must be unique")
+		}
+		seen[id] = true
+	}
+
+	if len(r.StudentIDs) > MaxFamilySeats {
+		return errors.New("studentIds can not have more than 10 students")
+	}
+
+	return (&CreateStudentSubscriptionRequest{
+		SubscriptionID: r.SubscriptionID,
+		IntervalCount:  r.IntervalCount,
+	}).Validate()
+}
+
+// CreateGiftSubscriptionRequest buys premium codes any student can redeem.
+type CreateGiftSubscriptionRequest struct {
+	SubscriptionID uuid.UUID `json:"subscriptionId"`
+	IntervalCount  int       `json:"intervalCount"`
+	Quantity       int       `json:"quantity"`
+}
+
+func (r *CreateGiftSubscriptionRequest) Validate() error {
+	if r.Quantity <= 0 || r.Quantity > MaxFamilySeats {
+		return errors.New("quantity must be between 1 and 10")
+	}
+
+	return (&CreateStudentSubscriptionRequest{
+		SubscriptionID: r.SubscriptionID,
+		IntervalCount:  r.IntervalCount,
+	}).Validate()
+}
+
+type GetGuardianPaymentsRequest struct {
+	model.Pagination
+}
+
+type GuardianPaymentResponse struct {
+	ID            uuid.UUID                  `json:"id"`
+	Name          string                     `json:"name"`
+	Type          string                     `json:"type"`
+	Price         decimal.Decimal            `json:"price"`
+	Interval      model.SubscriptionInterval `json:"interval"`
+	IntervalCount int                        `json:"intervalCount"`
+	Seats         int                        `json:"seats"`
+	URL           string                     `json:"url"`
+	StartAt       time.Time                  `json:"startAt"`
+	EndAt         time.Time                  `json:"endAt"`
+	Status        model.SubscriptionStatus   `json:"status"`
+	Students      []string                   `json:"students"`
+}
+
+func NewGuardianPaymentResponses(payments []model.Payment) []GuardianPaymentResponse {
+	resp := make([]GuardianPaymentResponse, 0, len(payments))
+	for _, payment := range payments {
+		students := make([]string, 0, len(payment.Beneficiaries))
+		for _, beneficiary := range payment.Beneficiaries {
+			students = append(students, beneficiary.Student.User.Name)
+		}
+
+		resp = append(resp, GuardianPaymentResponse{
+			ID:            payment.ID,
+			Name:          payment.Name(),
+			Type:          payment.Type,
+			Price:         payment.Amount,
+			Interval:      payment.Interval,
+			IntervalCount: payment.IntervalCount,
+			Seats:         payment.Seats,
+			URL:           payment.URL,
+			StartAt:       payment.StartDate,
+			EndAt:         payment.EndDate,
+			Status:        payment.StatusLabel(),
+			Students:      students,
+		})
+	}
+
+	return resp
+}
+
+type GetGiftCodesRequest struct {
+	Status string `form:"status"`
+	model.Pagination
+}
+
+func (r *GetGiftCodesRequest) Validate() error {
+	switch r.Status {
+	case "", model.GiftCodeStatusPending, model.GiftCodeStatusActive, model.GiftCodeStatusRedeemed, model.GiftCodeStatusCanceled:
+		return nil
+	default:
+		return errors.New("invalid status")
+	}
+}
+
+type RedeemGiftCodeRequest struct {
+	Code string `json:"code"`
+}
+
+func (r *RedeemGiftCodeRequest) Validate() error {
+	r.Code = strings.ToUpper(strings.TrimSpace(r.Code))
+	if r.Code == "" {
+		return errors.New("code is required")
+	}
+
+	return nil
+}
+
+type RedeemGiftCodeResponse struct {
+	PremiumUntil time.Time `json:"premiumUntil"`
+}
+
+type ConfirmGuardianStudentRequest struct {
+	Token string `json:"token"`
+}
+
+func (r *ConfirmGuardianStudentRequest) Validate() error {
+	r.Token = strings.TrimSpace(r.Token)
+	if r.Token == "" {
+		return errors.New("token is required")
+	}
+
+	return nil
+}
+
+type GuardianInviteResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func NewGuardianInviteResponse(invite model.GuardianInvite) GuardianInviteResponse {
+	return GuardianInviteResponse{
+		ID:        invite.ID,
+		Code:      invite.Code,
+		ExpiresAt: invite.ExpiresAt,
+	}
+}
+
+func NewGuardianInviteResponses(invites []model.GuardianInvite) []GuardianInviteResponse {
+	resp := make([]GuardianInviteResponse, 0, len(invites))
+	for _, invite := range invites {
+		resp = append(resp, NewGuardianInviteResponse(invite))
+	}
+
+	return resp
+}
+
+type RedeemGuardianInviteRequest struct {
+	Code string `json:"code"`
+}
+
+func (r *RedeemGuardianInviteRequest) Validate() error {
+	r.Code = strings.ToUpper(strings.TrimSpace(r.Code))
+	if r.Code == "" {
+		return errors.New("code is required")
+	}
+
+	return nil
+}
+
+type GetGuardianStudentBookingsRequest struct {
+	model.Pagination
+}
+
+// GuardianBookingResponse is an upcoming booking of a linked student.
+type GuardianBookingResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	Code        string              `json:"code"`
+	CourseTitle string              `json:"courseTitle"`
+	TutorName   string              `json:"tutorName"`
+	ClassType   model.ClassType     `json:"classType"`
+	BookingDate time.Time           `json:"bookingDate"`
+	BookingTime string              `json:"bookingTime"`
+	Timezone    string              `json:"timezone"`
+	Status      model.BookingStatus `json:"status"`
+}
+
+func NewGuardianBookingResponses(bookings []model.Booking) []GuardianBookingResponse {
+	resp := make([]GuardianBookingResponse, 0, len(bookings))
+	for _, booking := range bookings {
+		resp = append(resp, GuardianBookingResponse{
+			ID:          booking.ID,
+			Code:        booking.Code,
+			CourseTitle: booking.Course.Title,
+			TutorName:   booking.Tutor.User.Name,
+			ClassType:   booking.ClassType,
+			BookingDate: booking.BookingDate,
+			BookingTime: booking.BookingTime,
+			Timezone:    booking.Timezone,
+			Status:      booking.GetStatus(),
+		})
+	}
+
+	return resp
+}
+
+// GuardianSessionResponse is a past session of a linked student with the
+// tasks, their scores, the progress notes and the lesson note of the mentor.
+type GuardianSessionResponse struct {
+	ID            uuid.UUID                     `json:"id"`
+	Code          string                        `json:"code"`
+	CourseTitle   string                        `json:"courseTitle"`
+	TutorName     string                        `json:"tutorName"`
+	BookingDate   time.Time                     `json:"bookingDate"`
+	BookingTime   string                        `json:"bookingTime"`
+	Topic         null.String                   `json:"topic"`
+	ProgressNotes null.String                   `json:"progressNotes"`
+	LessonNote    *LessonNoteResponse           `json:"lessonNote"`
+	Tasks         []GuardianSessionTaskResponse `json:"tasks"`
+}
+
+type GuardianSessionTaskResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	Title       string              `json:"title"`
+	Description null.String         `json:"description"`
+	Submitted   bool                `json:"submitted"`
+	Score       decimal.NullDecimal `json:"score"`
+}
+
+func NewGuardianSessionResponses(bookings []model.Booking) []GuardianSessionResponse {
+	resp := make([]GuardianSessionResponse, 0, len(bookings))
+	for _, booking := range bookings {
+		session := GuardianSessionResponse{
+			ID:          booking.ID,
+			Code:        booking.Code,
+			CourseTitle: booking.Course.Title,
+			TutorName:   booking.Tutor.User.Name,
+			BookingDate: booking.BookingDate,
+			BookingTime: booking.BookingTime,
+			Tasks:       make([]GuardianSessionTaskResponse, 0, len(booking.SessionTasks)),
+		}
+
+		if booking.ReportBooking.ID != uuid.Nil && !booking.ReportBooking.DeletedAt.Valid {
+			session.Topic = null.StringFrom(booking.ReportBooking.Topic)
+			session.ProgressNotes = booking.ReportBooking.ProgressNotes
+			if !session.ProgressNotes.Valid {
+				session.ProgressNotes = null.StringFrom(booking.ReportBooking.Body)
+			}
+		}
+
+		if booking.LessonNote != nil {
+			note := NewLessonNoteResponse(*booking.LessonNote)
+			session.LessonNote = &note
+		}
+
+		for _, task := range booking.SessionTasks {
+			if task.DeletedAt.Valid {
+				continue
+			}
+
+			item := GuardianSessionTaskResponse{
+				ID:          task.ID,
+				Title:       task.Title,
+				Description: task.Description,
+			}
+
+			for _, submission := range task.TaskSubmissions {
+				if submission.DeletedAt.Valid {
+					continue
+				}
+
+				item.Submitted = true
+				item.Score = submission.Score
+				break
+			}
+
+			session.Tasks = append(session.Tasks, item)
+		}
+
+		resp = append(resp, session)
+	}
+
+	return resp
+}
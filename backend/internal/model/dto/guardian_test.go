@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestCreateFamilySubscriptionRequestValidate(t *testing.T) {
+	var (
+		monthly = uuid.MustParse(model.SubscriptionMonthlyID)
+		student = uuid.New()
+		tooMany = make([]uuid.UUID, MaxFamilySeats+1)
+	)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name    string
+		request CreateFamilySubscriptionRequest
+		wantErr bool
+	}{
+		{name: "valid", request: CreateFamilySubscriptionRequest{SubscriptionID: monthly, IntervalCount: 1, StudentIDs: []uuid.UUID{student, uuid.New()}}},
+		{name: "no students", request: CreateFamilySubscriptionRequest{SubscriptionID: monthly, IntervalCount: 1}, wantErr: true},
+		{name: "same student twice", request: CreateFamilySubscriptionRequest{SubscriptionID: monthly, IntervalCount: 1, StudentIDs: []uuid.UUID{student, student}}, wantErr: true},
+		{name: "more students than seats", request: CreateFamilySubscriptionRequest{SubscriptionID: monthly, IntervalCount: 1, StudentIDs: tooMany}, wantErr: true},
+		{name: "unknown plan", request: CreateFamilySubscriptionRequest{SubscriptionID: uuid.New(), IntervalCount: 1, StudentIDs: []uuid.UUID{student}}, wantErr: true},
+		{name: "no interval", request: CreateFamilySubscriptionRequest{SubscriptionID: monthly, StudentIDs: []uuid.UUID{student}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateGiftSubscriptionRequestValidate(t *testing.T) {
+	yearly := uuid.MustParse(model.SubscriptionYearlyID)
+
+	tests := []struct {
+		name     string
+		quantity int
+		wantErr  bool
+	}{
+		{name: "one code", quantity: 1},
+		{name: "most codes", quantity: MaxFamilySeats},
+		{name: "no codes", quantity: 0, wantErr: true},
+		{name: "too many codes", quantity: MaxFamilySeats + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := CreateGiftSubscriptionRequest{SubscriptionID: yearly, IntervalCount: 1, Quantity: tt.quantity}
+			err := request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedeemGiftCodeRequestValidate(t *testing.T) {
+	request := RedeemGiftCodeRequest{Code: "  gift-ab23-cd45-ef67 "}
+	if err := request.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if request.Code != "GIFT-AB23-CD45-EF67" {
+		t.Errorf("Code = %q, want it trimmed and upper case", request.Code)
+	}
+
+	if err := (&RedeemGiftCodeRequest{Code: "   "}).Validate(); err == nil {
+		t.Error("Validate() error = nil, want an error without a code")
+	}
+}
+
+func TestCreateGuardianStudentRequestValidate(t *testing.T) {
+	tests := []struct {
+		email   string
+		want    string
+		wantErr bool
+	}{
+		{email: " sari@example.com ", want: "sari@example.com"},
+		{email: "", wantErr: true},
+		{email: "sari@example", wantErr: true},
+		{email: "sari example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		request := CreateGuardianStudentRequest{Email: tt.email}
+		err := request.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.email, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && request.Email != tt.want {
+			t.Errorf("Validate(%q) email = %q, want %q", tt.email, request.Email, tt.want)
+		}
+	}
+}
+
+func TestRedeemGuardianInviteRequestValidate(t *testing.T) {
+	request := RedeemGuardianInviteRequest{Code: " ab23cd45 "}
+	if err := request.Validate(); err != nil || request.Code != "AB23CD45" {
+		t.Errorf("Validate() = (%q, %v), want AB23CD45", request.Code, err)
+	}
+
+	if err := (&RedeemGuardianInviteRequest{}).Validate(); err == nil {
+		t.Error("Validate() error = nil, want an error without a code")
+	}
+}
@@ -1,15 +1,36 @@
 package dto
 
 type InvoiceData struct {
-	InvoiceNumber      string
-	InvoiceDate        string
-	CustomerEmail      string
-	Status             string
-	SubscriptionType   string
-	SubscriptionPeriod string
-	StartDate          string
-	EndDate            string
-	SubtotalPrice      string
-	VATAmount          string
-	TotalPrice         string
+	InvoiceNumber   string
+	InvoiceDate     string
+	CustomerName    string
+	CustomerEmail   string
+	CustomerTaxID   string
+	CustomerAddress string
+	SellerName      string
+	SellerTaxID     string
+	Status          string
+	Items           []InvoiceItemData
+	VATLabel        string
+	VATAmount       string
+	TotalPrice      string
+}
+
+type InvoiceItemData struct {
+	Description string
+	StartDate   string
+	EndDate     string
+	Price       string
+}
+
+type CreditNoteData struct {
+	CreditNoteNumber string
+	CreditNoteDate   string
+	InvoiceNumber    string
+	CustomerEmail    string
+	Description      string
+	Reason           string
+	SubtotalAmount   string
+	VATAmount        string
+	TotalAmount      string
 }
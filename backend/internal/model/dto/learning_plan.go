@@ -0,0 +1,283 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+// LearningPlanRequest creates the plan of a student in a subject, optionally
+// with its first milestones
+type LearningPlanRequest struct {
+	CourseCategoryID uuid.UUID                      `json:"courseCategoryId"`
+	Title            string                         `json:"title"`
+	Description      *string                        `json:"description"`
+	Milestones       []LearningPlanMilestoneRequest `json:"milestones"`
+}
+
+func (r *LearningPlanRequest) Validate() error {
+	if r.CourseCategoryID == uuid.Nil {
+		return errors.New("courseCategoryId is required")
+	}
+
+	r.Title = strings.TrimSpace(r.Title)
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+
+	for i := range r.Milestones {
+		if err := r.Milestones[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type UpdateLearningPlanRequest struct {
+	Title       string                   `json:"title"`
+	Description *string                  `json:"description"`
+	Status      model.LearningPlanStatus `json:"status"`
+}
+
+func (r *UpdateLearningPlanRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+
+	switch r.Status {
+	case "":
+		r.Status = model.LearningPlanStatusActive
+	case model.LearningPlanStatusActive, model.LearningPlanStatusArchived:
+	default:
+		return errors.New("status must be one of active, archived")
+	}
+
+	return nil
+}
+
+// LearningPlanMilestoneRequest is a goal of a plan. Milestones are ordered
+// by position, appended to the plan when it is not set.
+type LearningPlanMilestoneRequest struct {
+	Title       string                `json:"title"`
+	Description *string               `json:"description"`
+	TargetDate  string                `json:"targetDate"`
+	Status      model.MilestoneStatus `json:"status"`
+	Position    *int                  `json:"position"`
+}
+
+func (r *LearningPlanMilestoneRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	if r.Title == "" {
+		return errors.New("milestone title is required")
+	}
+
+	if r.TargetDate != "" {
+		if _, err := time.Parse(time.DateOnly, r.TargetDate); err != nil {
+			return errors.New("invalid targetDate format")
+		}
+	}
+
+	switch r.Status {
+	case "":
+		r.Status = model.MilestoneStatusNotStarted
+	case model.MilestoneStatusNotStarted, model.MilestoneStatusInProgress, model.MilestoneStatusAchieved:
+	default:
+		return errors.New("status must be one of not_started, in_progress, achieved")
+	}
+
+	if r.Position != nil && *r.Position < 0 {
+		return errors.New("position must not be negative")
+	}
+
+	return nil
+}
+
+// GetTargetDate returns the parsed target date, null when not set
+func (r *LearningPlanMilestoneRequest) GetTargetDate() null.Time {
+	targetDate, err := time.Parse(time.DateOnly, r.TargetDate)
+	if err != nil {
+		return null.Time{}
+	}
+	return null.TimeFrom(targetDate)
+}
+
+// LessonNoteRequest writes the lesson note of a session. The milestones the
+// session worked on must belong to the plan.
+type LessonNoteRequest struct {
+	PlanID       uuid.NullUUID `json:"planId"`
+	MilestoneIDs []uuid.UUID   `json:"milestoneIds"`
+	Covered      string        `json:"covered"`
+	Strengths    *string       `json:"strengths"`
+	Improvements *string       `json:"improvements"`
+	NextSteps    *string       `json:"nextSteps"`
+}
+
+func (r *LessonNoteRequest) Validate() error {
+	r.Covered = strings.TrimSpace(r.Covered)
+	if r.Covered == "" {
+		return errors.New("covered is required")
+	}
+
+	if len(r.MilestoneIDs) > 0 && !r.PlanID.Valid {
+		return errors.New("planId is required to link milestones")
+	}
+
+	return nil
+}
+
+type GetLessonNotesRequest struct {
+	model.Pagination
+}
+
+type LearningPlanResponse struct {
+	ID                 uuid.UUID                       `json:"id"`
+	StudentID          uuid.UUID                       `json:"studentId"`
+	TutorID            uuid.UUID                       `json:"tutorId"`
+	TutorName          string                          `json:"tutorName"`
+	CourseCategoryID   uuid.UUID                       `json:"courseCategoryId"`
+	Subject            string                          `json:"subject"`
+	Title              string                          `json:"title"`
+	Description        null.String                     `json:"description"`
+	Status             model.LearningPlanStatus        `json:"status"`
+	MilestonesTotal    int                             `json:"milestonesTotal"`
+	MilestonesAchieved int                             `json:"milestonesAchieved"`
+	Milestones         []LearningPlanMilestoneResponse `json:"milestones"`
+	CreatedAt          time.Time                       `json:"createdAt"`
+	UpdatedAt          time.Time                       `json:"updatedAt"`
+}
+
+type LearningPlanMilestoneResponse struct {
+	ID          uuid.UUID             `json:"id"`
+	Title       string                `json:"title"`
+	Description null.String           `json:"description"`
+	TargetDate  null.String           `json:"targetDate"`
+	Status      model.MilestoneStatus `json:"status"`
+	Position    int                   `json:"position"`
+	IsOverdue   bool                  `json:"isOverdue"`
+	AchievedAt  null.Time             `json:"achievedAt"`
+}
+
+func NewLearningPlanMilestoneResponse(milestone model.LearningPlanMilestone, now time.Time) LearningPlanMilestoneResponse {
+	res := LearningPlanMilestoneResponse{
+		ID:          milestone.ID,
+		Title:       milestone.Title,
+		Description: milestone.Description,
+		Status:      milestone.Status,
+		Position:    milestone.Position,
+		IsOverdue:   milestone.IsOverdue(now),
+		AchievedAt:  milestone.AchievedAt,
+	}
+	if milestone.TargetDate.Valid {
+		res.TargetDate = null.StringFrom(milestone.TargetDate.Time.Format(time.DateOnly))
+	}
+
+	return res
+}
+
+func NewLearningPlanResponse(plan model.LearningPlan) LearningPlanResponse {
+	now := time.Now()
+	res := LearningPlanResponse{
+		ID:               plan.ID,
+		StudentID:        plan.StudentID,
+		TutorID:          plan.TutorID,
+		TutorName:        plan.Tutor.User.Name,
+		CourseCategoryID: plan.CourseCategoryID,
+		Subject:          plan.CourseCategory.Name,
+		Title:            plan.Title,
+		Description:      plan.Description,
+		Status:           plan.Status,
+		MilestonesTotal:  len(plan.Milestones),
+		Milestones:       make([]LearningPlanMilestoneResponse, 0, len(plan.Milestones)),
+		CreatedAt:        plan.CreatedAt,
+		UpdatedAt:        plan.UpdatedAt,
+	}
+
+	for _, milestone := range plan.Milestones {
+		if milestone.Status == model.MilestoneStatusAchieved {
+			res.MilestonesAchieved++
+		}
+		res.Milestones = append(res.Milestones, NewLearningPlanMilestoneResponse(milestone, now))
+	}
+
+	return res
+}
+
+func NewLearningPlanResponses(plans []model.LearningPlan) []LearningPlanResponse {
+	res := make([]LearningPlanResponse, 0, len(plans))
+	for _, plan := range plans {
+		res = append(res, NewLearningPlanResponse(plan))
+	}
+
+	return res
+}
+
+// LessonNoteResponse is the lesson note of a session with the milestones it
+// worked on.
+type LessonNoteResponse struct {
+	ID           uuid.UUID                     `json:"id"`
+	BookingID    uuid.UUID                     `json:"bookingId"`
+	PlanID       uuid.NullUUID                 `json:"planId"`
+	SessionDate  string                        `json:"sessionDate,omitempty"`
+	SessionTime  string                        `json:"sessionTime,omitempty"`
+	TutorName    string                        `json:"tutorName,omitempty"`
+	Covered      string                        `json:"covered"`
+	Strengths    null.String                   `json:"strengths"`
+	Improvements null.String                   `json:"improvements"`
+	NextSteps    null.String                   `json:"nextSteps"`
+	Milestones   []LessonNoteMilestoneResponse `json:"milestones"`
+	CreatedAt    time.Time                     `json:"createdAt"`
+	UpdatedAt    time.Time                     `json:"updatedAt"`
+}
+
+type LessonNoteMilestoneResponse struct {
+	ID     uuid.UUID             `json:"id"`
+	Title  string                `json:"title"`
+	Status model.MilestoneStatus `json:"status"`
+}
+
+func NewLessonNoteResponse(note model.LessonNote) LessonNoteResponse {
+	res := LessonNoteResponse{
+		ID:           note.ID,
+		BookingID:    note.BookingID,
+		PlanID:       note.PlanID,
+		Covered:      note.Covered,
+		Strengths:    note.Strengths,
+		Improvements: note.Improvements,
+		NextSteps:    note.NextSteps,
+		Milestones:   make([]LessonNoteMilestoneResponse, 0, len(note.Milestones)),
+		CreatedAt:    note.CreatedAt,
+		UpdatedAt:    note.UpdatedAt,
+	}
+
+	if note.Booking != nil {
+		res.SessionDate = note.Booking.BookingDate.Format(time.DateOnly)
+		res.SessionTime = note.Booking.BookingTime
+		res.TutorName = note.Booking.Tutor.User.Name
+	}
+
+	for _, milestone := range note.Milestones {
+		res.Milestones = append(res.Milestones, LessonNoteMilestoneResponse{
+			ID:     milestone.ID,
+			Title:  milestone.Title,
+			Status: milestone.Status,
+		})
+	}
+
+	return res
+}
+
+func NewLessonNoteResponses(notes []model.LessonNote) []LessonNoteResponse {
+	res := make([]LessonNoteResponse, 0, len(notes))
+	for _, note := range notes {
+		res = append(res, NewLessonNoteResponse(note))
+	}
+
+	return res
+}
@@ -0,0 +1,129 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestLearningPlanRequest_Validate(t *testing.T) {
+	req := LearningPlanRequest{
+		CourseCategoryID: uuid.New(),
+		Title:            " Persiapan UTBK ",
+		Milestones:       []LearningPlanMilestoneRequest{{Title: "Aljabar"}},
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+	if req.Title != "Persiapan UTBK" || req.Milestones[0].Status != model.MilestoneStatusNotStarted {
+		t.Errorf("Validate() = %+v, want the title trimmed and the milestone not started", req)
+	}
+
+	tests := []struct {
+		name string
+		req  LearningPlanRequest
+	}{
+		{name: "no subject", req: LearningPlanRequest{Title: "Persiapan UTBK"}},
+		{name: "no title", req: LearningPlanRequest{CourseCategoryID: uuid.New(), Title: " "}},
+		{name: "invalid milestone", req: LearningPlanRequest{CourseCategoryID: uuid.New(), Title: "Persiapan UTBK", Milestones: []LearningPlanMilestoneRequest{{}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); err == nil {
+				t.Errorf("Validate() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestUpdateLearningPlanRequest_Validate(t *testing.T) {
+	req := UpdateLearningPlanRequest{Title: "Persiapan UTBK"}
+	if err := req.Validate(); err != nil || req.Status != model.LearningPlanStatusActive {
+		t.Errorf("Validate() = (%v, %s), want an active plan by default", err, req.Status)
+	}
+
+	req = UpdateLearningPlanRequest{Title: "Persiapan UTBK", Status: "done"}
+	if err := req.Validate(); err == nil {
+		t.Errorf("Validate() error = nil, want an error for an unknown status")
+	}
+}
+
+func TestLearningPlanMilestoneRequest_Validate(t *testing.T) {
+	position := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		req     LearningPlanMilestoneRequest
+		wantErr bool
+	}{
+		{name: "valid", req: LearningPlanMilestoneRequest{Title: "Aljabar", TargetDate: "2026-04-30", Status: model.MilestoneStatusInProgress, Position: position(0)}},
+		{name: "no title", req: LearningPlanMilestoneRequest{Title: " "}, wantErr: true},
+		{name: "invalid target date", req: LearningPlanMilestoneRequest{Title: "Aljabar", TargetDate: "30/04/2026"}, wantErr: true},
+		{name: "invalid status", req: LearningPlanMilestoneRequest{Title: "Aljabar", Status: "done"}, wantErr: true},
+		{name: "negative position", req: LearningPlanMilestoneRequest{Title: "Aljabar", Position: position(-1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLearningPlanMilestoneRequest_GetTargetDate(t *testing.T) {
+	req := LearningPlanMilestoneRequest{TargetDate: "2026-04-30"}
+	if got := req.GetTargetDate(); !got.Valid || !got.Time.Equal(time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("GetTargetDate() = %v, want 2026-04-30", got)
+	}
+
+	req = LearningPlanMilestoneRequest{}
+	if got := req.GetTargetDate(); got.Valid {
+		t.Errorf("GetTargetDate() = %v, want null", got)
+	}
+}
+
+func TestLessonNoteRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     LessonNoteRequest
+		wantErr bool
+	}{
+		{name: "valid", req: LessonNoteRequest{Covered: "Persamaan kuadrat"}},
+		{name: "linked milestones", req: LessonNoteRequest{Covered: "Persamaan kuadrat", PlanID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, MilestoneIDs: []uuid.UUID{uuid.New()}}},
+		{name: "nothing covered", req: LessonNoteRequest{Covered: "  "}, wantErr: true},
+		{name: "milestones without plan", req: LessonNoteRequest{Covered: "Persamaan kuadrat", MilestoneIDs: []uuid.UUID{uuid.New()}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewLearningPlanResponse(t *testing.T) {
+	plan := model.LearningPlan{
+		Milestones: []model.LearningPlanMilestone{
+			{Status: model.MilestoneStatusAchieved},
+			{Status: model.MilestoneStatusInProgress, TargetDate: null.TimeFrom(time.Now().AddDate(0, 0, -7))},
+			{Status: model.MilestoneStatusNotStarted},
+		},
+	}
+
+	res := NewLearningPlanResponse(plan)
+	if res.MilestonesTotal != 3 || res.MilestonesAchieved != 1 {
+		t.Errorf("milestones = %d/%d, want 1/3 achieved", res.MilestonesAchieved, res.MilestonesTotal)
+	}
+	if !res.Milestones[1].IsOverdue || !res.Milestones[1].TargetDate.Valid || res.Milestones[2].TargetDate.Valid {
+		t.Errorf("Milestones = %+v, want the second overdue and only it with a target date", res.Milestones)
+	}
+}
@@ -0,0 +1,207 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type GetMentorGroupsRequest struct {
+	Query string `form:"q"`
+	model.Pagination
+}
+
+type MentorGroupRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (r *MentorGroupRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(r.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+
+	return nil
+}
+
+type GetMentorGroupMembersRequest struct {
+	model.Pagination
+}
+
+// AddMentorGroupMembersRequest puts mentored students in a group
+type AddMentorGroupMembersRequest struct {
+	StudentIDs []uuid.UUID `json:"studentIds"`
+}
+
+func (r *AddMentorGroupMembersRequest) Validate() error {
+	if len(r.StudentIDs) == 0 {
+		return errors.New("studentIds is required")
+	}
+
+	return nil
+}
+
+// CreateGroupInviteCodeRequest limits a new invite code of a group, the code
+// never expires or runs out when left empty
+type CreateGroupInviteCodeRequest struct {
+	ExpiresAt *time.Time `json:"expiresAt"`
+	MaxUses   *int       `json:"maxUses"`
+}
+
+func (r *CreateGroupInviteCodeRequest) Validate() error {
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return errors.New("expiresAt must be in the future")
+	}
+
+	if r.MaxUses != nil && *r.MaxUses < 1 {
+		return errors.New("maxUses must be at least 1")
+	}
+
+	return nil
+}
+
+type MentorGroupAnnouncementRequest struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+func (r *MentorGroupAnnouncementRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Message = strings.TrimSpace(r.Message)
+
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+
+	if len(r.Title) > 150 {
+		return errors.New("title must be at most 150 characters")
+	}
+
+	if r.Message == "" {
+		return errors.New("message is required")
+	}
+
+	return nil
+}
+
+type MentorGroupResponse struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Description null.String `json:"description"`
+	MemberCount int64       `json:"memberCount"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
+
+func NewMentorGroupResponse(group model.MentorGroup) MentorGroupResponse {
+	return MentorGroupResponse{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		MemberCount: group.MemberCount,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+	}
+}
+
+func NewMentorGroupResponses(groups []model.MentorGroup) []MentorGroupResponse {
+	res := make([]MentorGroupResponse, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, NewMentorGroupResponse(group))
+	}
+
+	return res
+}
+
+type MentorGroupMemberResponse struct {
+	StudentID    uuid.UUID   `json:"studentId"`
+	Name         string      `json:"name"`
+	Email        string      `json:"email"`
+	PhotoProfile null.String `json:"photoProfile"`
+	JoinedAt     time.Time   `json:"joinedAt"`
+}
+
+func NewMentorGroupMemberResponses(members []model.MentorGroupMember) []MentorGroupMemberResponse {
+	res := make([]MentorGroupMemberResponse, 0, len(members))
+	for _, member := range members {
+		res = append(res, MentorGroupMemberResponse{
+			StudentID:    member.StudentID,
+			Name:         member.Student.User.Name,
+			Email:        member.Student.User.Email,
+			PhotoProfile: member.Student.PhotoProfile,
+			JoinedAt:     member.JoinedAt,
+		})
+	}
+
+	return res
+}
+
+type GroupInviteCodeResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	ExpiresAt null.Time `json:"expiresAt"`
+	MaxUses   null.Int  `json:"maxUses"`
+	UsedCount int       `json:"usedCount"`
+	RevokedAt null.Time `json:"revokedAt"`
+	IsUsable  bool      `json:"isUsable"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewGroupInviteCodeResponse(code model.MentorInviteCode) GroupInviteCodeResponse {
+	return GroupInviteCodeResponse{
+		ID:        code.ID,
+		Code:      code.Code,
+		ExpiresAt: code.ExpiresAt,
+		MaxUses:   code.MaxUses,
+		UsedCount: code.UsedCount,
+		RevokedAt: code.RevokedAt,
+		IsUsable:  code.IsUsable(time.Now()),
+		CreatedAt: code.CreatedAt,
+	}
+}
+
+func NewGroupInviteCodeResponses(codes []model.MentorInviteCode) []GroupInviteCodeResponse {
+	res := make([]GroupInviteCodeResponse, 0, len(codes))
+	for _, code := range codes {
+		res = append(res, NewGroupInviteCodeResponse(code))
+	}
+
+	return res
+}
+
+type MentorGroupAnnouncementResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Message    string    `json:"message"`
+	Recipients int       `json:"recipients"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func NewMentorGroupAnnouncementResponse(announcement model.MentorGroupAnnouncement) MentorGroupAnnouncementResponse {
+	return MentorGroupAnnouncementResponse{
+		ID:         announcement.ID,
+		Title:      announcement.Title,
+		Message:    announcement.Message,
+		Recipients: announcement.Recipients,
+		CreatedAt:  announcement.CreatedAt,
+	}
+}
+
+func NewMentorGroupAnnouncementResponses(announcements []model.MentorGroupAnnouncement) []MentorGroupAnnouncementResponse {
+	res := make([]MentorGroupAnnouncementResponse, 0, len(announcements))
+	for _, announcement := range announcements {
+		res = append(res, NewMentorGroupAnnouncementResponse(announcement))
+	}
+
+	return res
+}
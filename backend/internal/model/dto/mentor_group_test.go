@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestMentorGroupRequest_Validate(t *testing.T) {
+	req := MentorGroupRequest{Name: "  Kelas 9 Matematika Sabtu "}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+	if req.Name != "Kelas 9 Matematika Sabtu" {
+		t.Errorf("Name = %q, want it trimmed", req.Name)
+	}
+
+	for _, name := range []string{"   ", strings.Repeat("a", 101)} {
+		req := MentorGroupRequest{Name: name}
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate() with name %q error = nil, want an error", name)
+		}
+	}
+}
+
+func TestAddMentorGroupMembersRequest_Validate(t *testing.T) {
+	if err := (&AddMentorGroupMembersRequest{}).Validate(); err == nil {
+		t.Errorf("Validate() error = nil, want an error without students")
+	}
+	if err := (&AddMentorGroupMembersRequest{StudentIDs: []uuid.UUID{uuid.New()}}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
+
+func TestCreateGroupInviteCodeRequest_Validate(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+	uses := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		req     CreateGroupInviteCodeRequest
+		wantErr bool
+	}{
+		{name: "unlimited"},
+		{name: "limited", req: CreateGroupInviteCodeRequest{ExpiresAt: &future, MaxUses: uses(30)}},
+		{name: "expired", req: CreateGroupInviteCodeRequest{ExpiresAt: &past}, wantErr: true},
+		{name: "no uses", req: CreateGroupInviteCodeRequest{MaxUses: uses(0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMentorGroupAnnouncementRequest_Validate(t *testing.T) {
+	req := MentorGroupAnnouncementRequest{Title: " Libur ", Message: " Kelas Sabtu diliburkan \n"}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+	if req.Title != "Libur" || req.Message != "Kelas Sabtu diliburkan" {
+		t.Errorf("Validate() = (%q, %q), want the title and message trimmed", req.Title, req.Message)
+	}
+
+	tests := []struct {
+		name string
+		req  MentorGroupAnnouncementRequest
+	}{
+		{name: "no title", req: MentorGroupAnnouncementRequest{Title: " ", Message: "Kelas Sabtu diliburkan"}},
+		{name: "long title", req: MentorGroupAnnouncementRequest{Title: strings.Repeat("a", 151), Message: "Kelas Sabtu diliburkan"}},
+		{name: "no message", req: MentorGroupAnnouncementRequest{Title: "Libur", Message: "\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); err == nil {
+				t.Errorf("Validate() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestNewGroupInviteCodeResponse(t *testing.T) {
+	code := model.MentorInviteCode{Code: "ABCD1234", MaxUses: null.IntFrom(2), UsedCount: 1}
+	if res := NewGroupInviteCodeResponse(code); !res.IsUsable {
+		t.Errorf("IsUsable = false, want true with a use left")
+	}
+
+	code.UsedCount = 2
+	if res := NewGroupInviteCodeResponse(code); res.IsUsable {
+		t.Errorf("IsUsable = true, want false once used up")
+	}
+}
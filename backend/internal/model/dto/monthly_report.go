@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type CreateMonthlyReportRequest struct {
+	Month int `json:"month"`
+	Year  int `json:"year"`
+}
+
+func (r *CreateMonthlyReportRequest) Validate() error {
+	if r.Month < 1 || r.Month > 12 {
+		return errors.New("month must be between 1 and 12")
+	}
+
+	if r.Year < 2000 {
+		return errors.New("invalid year")
+	}
+
+	now := time.Now()
+	if time.Date(r.Year, time.Month(r.Month), 1, 0, 0, 0, 0, time.Local).After(now) {
+		return errors.New("month has not started yet")
+	}
+
+	return nil
+}
+
+type GetMonthlyReportsRequest struct {
+	model.Pagination
+}
+
+// MonthlyReportResponse is a report job. DownloadURL is set once the report
+// is completed and works until DownloadExpiresAt, fetch the report again for
+// a fresh one.
+type MonthlyReportResponse struct {
+	ID                uuid.UUID   `json:"id"`
+	StudentID         uuid.UUID   `json:"studentId"`
+	Month             int         `json:"month"`
+	Year              int         `json:"year"`
+	Status            string      `json:"status"`
+	Filename          null.String `json:"filename"`
+	GeneratedAt       null.Time   `json:"generatedAt"`
+	DownloadURL       null.String `json:"downloadUrl"`
+	DownloadExpiresAt null.Time   `json:"downloadExpiresAt"`
+	CreatedAt         time.Time   `json:"createdAt"`
+}
+
+func NewMonthlyReportResponse(report model.MonthlyReport) MonthlyReportResponse {
+	start := report.Start()
+	return MonthlyReportResponse{
+		ID:          report.ID,
+		StudentID:   report.StudentID,
+		Month:       int(start.Month()),
+		Year:        start.Year(),
+		Status:      report.Status,
+		Filename:    report.Filename,
+		GeneratedAt: report.GeneratedAt,
+		CreatedAt:   report.CreatedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateMonthlyReportRequest_Validate(t *testing.T) {
+	now := time.Now()
+	next := now.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name    string
+		req     CreateMonthlyReportRequest
+		wantErr bool
+	}{
+		{name: "current month", req: CreateMonthlyReportRequest{Month: int(now.Month()), Year: now.Year()}},
+		{name: "past month", req: CreateMonthlyReportRequest{Month: 1, Year: 2024}},
+		{name: "month zero", req: CreateMonthlyReportRequest{Month: 0, Year: 2024}, wantErr: true},
+		{name: "month 13", req: CreateMonthlyReportRequest{Month: 13, Year: 2024}, wantErr: true},
+		{name: "year too old", req: CreateMonthlyReportRequest{Month: 1, Year: 1999}, wantErr: true},
+		{name: "next month", req: CreateMonthlyReportRequest{Month: int(next.Month()), Year: next.Year()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
 	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
 )
 
 // UpdateProfileRequest represents the request payload for updating user profile
@@ -21,6 +24,10 @@ type UpdateProfileRequest struct {
 	Address         string            `json:"address"`
 	SocialMediaLink map[string]string `json:"socialMediaLink"`
 	Bio             string            `json:"bio"`
+	TaxID           string            `json:"taxId"`
+	TaxName         string            `json:"taxName"`
+	TaxAddress      string            `json:"taxAddress"`
+	Currency        string            `json:"currency"`
 }
 
 // Validate validates the update profile request
@@ -40,6 +47,23 @@ func (r *UpdateProfileRequest) Validate() error {
 		}
 	}
 
+	// NPWP has 15 digits, or 16 digits for the NIK based NPWP
+	if r.TaxID != "" {
+		taxID := model.NormalizeTaxID(r.TaxID)
+		if len(taxID) != 15 && len(taxID) != 16 {
+			return fmt.Errorf("invalid taxId, NPWP must have 15 or 16 digits")
+		}
+		r.TaxID = taxID
+	}
+
+	// Currency prices are shown in, as set when empty
+	if r.Currency != "" {
+		r.Currency = strings.ToUpper(r.Currency)
+		if _, ok := model.CurrencyByCode(r.Currency); !ok {
+			return fmt.Errorf("unsupported currency %s", r.Currency)
+		}
+	}
+
 	return nil
 }
 
@@ -69,11 +93,14 @@ type ProfileResponse struct {
 	SocialMediaLink     map[string]string   `json:"social_media_link"`
 	Latitude            decimal.NullDecimal `json:"latitude"`
 	Longitude           decimal.NullDecimal `json:"longitude"`
-	LevelPoint          uint                `json:"level_point"`
 	Level               null.String         `json:"level,omitempty"`
 	FinishUpdateProfile bool                `json:"finish_update_profile"`
 	Location            Location            `json:"location"`
 	IsPremium           bool                `json:"isPremium"`
+	TaxID               null.String         `json:"tax_id"`
+	TaxName             null.String         `json:"tax_name"`
+	TaxAddress          null.String         `json:"tax_address"`
+	Currency            null.String         `json:"currency"`
 	Address             null.String         `json:"address"`
 	Bio                 string              `json:"bio"`
 	TotalSessions       int64               `json:"total_sessions"`
@@ -81,13 +108,6 @@ type ProfileResponse struct {
 	JoinedAt            time.Time           `json:"joined_at"`
 }
 
-type TutorLevelInfo struct {
-	CurrentPoint uint   `json:"current_point"`
-	CurrentLevel string `json:"current_level"`
-	NextLevel    string `json:"next_level"`
-	PointsNeeded uint   `json:"points_needed"`
-}
-
 type StudentTutorResponse struct {
 	TutorID      uuid.UUID   `json:"tutor_id"`
 	Name         string      `json:"name"`
@@ -0,0 +1,432 @@
+package dto
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+const (
+	// maxQuizQuestions is the number of questions a quiz can have
+	maxQuizQuestions = 100
+	// maxQuizTimeLimit is the longest time limit of a quiz in minutes
+	maxQuizTimeLimit = 600
+)
+
+type GetQuizQuestionsRequest struct {
+	SubCourseCategoryID uuid.UUID              `form:"subCourseCategoryId"`
+	LevelOfEducation    string                 `form:"levelOfEducation"`
+	Type                model.QuizQuestionType `form:"type"`
+	Query               string                 `form:"q"`
+	model.Pagination
+}
+
+// QuizQuestionRequest is a question of the bank. Points default to 1.
+type QuizQuestionRequest struct {
+	SubCourseCategoryID *uuid.UUID             `json:"subCourseCategoryId"`
+	LevelOfEducation    *string                `json:"levelOfEducation"`
+	Type                model.QuizQuestionType `json:"type"`
+	Prompt              string                 `json:"prompt"`
+	Options             []model.QuizOption     `json:"options"`
+	Answer              model.QuizAnswer       `json:"answer"`
+	Explanation         *string                `json:"explanation"`
+	Points              *decimal.Decimal       `json:"points"`
+}
+
+func (r *QuizQuestionRequest) Validate() error {
+	r.Prompt = strings.TrimSpace(r.Prompt)
+	if r.Prompt == "" {
+		return errors.New("prompt is required")
+	}
+
+	if r.Points == nil {
+		points := decimal.NewFromInt(1)
+		r.Points = &points
+	}
+	if !r.Points.IsPositive() || r.Points.GreaterThan(decimal.NewFromInt(100)) {
+		return errors.New("points must be between 0 and 100")
+	}
+
+	return model.ValidateQuizQuestion(r.Type, r.Options, r.Answer)
+}
+
+type GetQuizzesRequest struct {
+	Query string `form:"q"`
+	model.Pagination
+}
+
+// QuizRequest assembles questions of the bank into a quiz, asked in the
+// order given.
+type QuizRequest struct {
+	Title            string      `json:"title"`
+	Description      *string     `json:"description"`
+	TimeLimitMinutes *int        `json:"timeLimitMinutes"`
+	QuestionIDs      []uuid.UUID `json:"questionIds"`
+}
+
+func (r *QuizRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+
+	if r.TimeLimitMinutes != nil && (*r.TimeLimitMinutes < 1 || *r.TimeLimitMinutes > maxQuizTimeLimit) {
+		return errors.New("time limit must be between 1 and 600 minutes")
+	}
+
+	if len(r.QuestionIDs) == 0 || len(r.QuestionIDs) > maxQuizQuestions {
+		return errors.New("a quiz needs between 1 and 100 questions")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(r.QuestionIDs))
+	for _, id := range r.QuestionIDs {
+		if seen[id] {
+			return errors.New("duplicate question " + id.String())
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// GetQuizAnalyticsRequest narrows the analytics of a quiz down to the
+// attempt of one task
+type GetQuizAnalyticsRequest struct {
+	SessionTaskID uuid.UUID `form:"taskId"`
+}
+
+// QuizAnswersRequest holds the answers of the student to questions of the
+// attempt. Questions left out keep the answer saved before.
+type QuizAnswersRequest struct {
+	Answers []QuizAnswerRequest `json:"answers"`
+}
+
+type QuizAnswerRequest struct {
+	QuestionID uuid.UUID        `json:"questionId"`
+	Answer     model.QuizAnswer `json:"answer"`
+}
+
+func (r *QuizAnswersRequest) Validate() error {
+	seen := make(map[uuid.UUID]bool, len(r.Answers))
+	for _, answer := range r.Answers {
+		if answer.QuestionID == uuid.Nil {
+			return errors.New("questionId is required")
+		}
+		if seen[answer.QuestionID] {
+			return errors.New("duplicate answer to question " + answer.QuestionID.String())
+		}
+		seen[answer.QuestionID] = true
+	}
+
+	return nil
+}
+
+type QuizQuestionResponse struct {
+	ID                    uuid.UUID              `json:"id"`
+	Type                  model.QuizQuestionType `json:"type"`
+	Prompt                string                 `json:"prompt"`
+	Options               []model.QuizOption     `json:"options"`
+	Answer                model.QuizAnswer       `json:"answer"`
+	Explanation           null.String            `json:"explanation"`
+	Points                decimal.Decimal        `json:"points"`
+	SubCourseCategoryID   uuid.NullUUID          `json:"subCourseCategoryId"`
+	SubCourseCategoryName string                 `json:"subCourseCategoryName"`
+	LevelOfEducation      null.String            `json:"levelOfEducation"`
+	CreatedAt             time.Time              `json:"createdAt"`
+	UpdatedAt             time.Time              `json:"updatedAt"`
+}
+
+func NewQuizQuestionResponse(question model.QuizQuestion) QuizQuestionResponse {
+	res := QuizQuestionResponse{
+		ID:                  question.ID,
+		Type:                question.Type,
+		Prompt:              question.Prompt,
+		Options:             question.GetOptions(),
+		Answer:              question.GetAnswer(),
+		Explanation:         question.Explanation,
+		Points:              question.Points,
+		SubCourseCategoryID: question.SubCourseCategoryID,
+		LevelOfEducation:    question.LevelOfEducation,
+		CreatedAt:           question.CreatedAt,
+		UpdatedAt:           question.UpdatedAt,
+	}
+
+	if question.SubCourseCategory != nil {
+		res.SubCourseCategoryName = question.SubCourseCategory.Name
+	}
+
+	return res
+}
+
+type QuizResponse struct {
+	ID               uuid.UUID              `json:"id"`
+	Title            string                 `json:"title"`
+	Description      null.String            `json:"description"`
+	TimeLimitMinutes null.Int               `json:"timeLimitMinutes"`
+	QuestionsTotal   int                    `json:"questionsTotal"`
+	MaxPoints        decimal.Decimal        `json:"maxPoints"`
+	Questions        []QuizQuestionResponse `json:"questions"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+}
+
+func NewQuizResponse(quiz model.Quiz) QuizResponse {
+	res := QuizResponse{
+		ID:               quiz.ID,
+		Title:            quiz.Title,
+		Description:      quiz.Description,
+		TimeLimitMinutes: quiz.TimeLimitMinutes,
+		MaxPoints:        quiz.MaxPoints(),
+		Questions:        make([]QuizQuestionResponse, 0, len(quiz.Items)),
+		CreatedAt:        quiz.CreatedAt,
+		UpdatedAt:        quiz.UpdatedAt,
+	}
+
+	for _, item := range quiz.Items {
+		if item.Question != nil {
+			res.Questions = append(res.Questions, NewQuizQuestionResponse(*item.Question))
+		}
+	}
+	res.QuestionsTotal = len(res.Questions)
+
+	return res
+}
+
+// TaskQuizResponse is the quiz a student takes for a task
+type TaskQuizResponse struct {
+	ID               uuid.UUID   `json:"id"`
+	Title            string      `json:"title"`
+	Description      null.String `json:"description"`
+	TimeLimitMinutes null.Int    `json:"timeLimitMinutes"`
+	QuestionsTotal   int         `json:"questionsTotal"`
+}
+
+func NewTaskQuizResponse(quiz model.Quiz) TaskQuizResponse {
+	return TaskQuizResponse{
+		ID:               quiz.ID,
+		Title:            quiz.Title,
+		Description:      quiz.Description,
+		TimeLimitMinutes: quiz.TimeLimitMinutes,
+		QuestionsTotal:   len(quiz.Items),
+	}
+}
+
+// QuizAttemptResponse is the attempt of the student at the quiz of a task.
+// The answer key is only shown once the attempt has been submitted.
+type QuizAttemptResponse struct {
+	ID          uuid.UUID                     `json:"id"`
+	TaskID      uuid.UUID                     `json:"taskId"`
+	Quiz        TaskQuizResponse              `json:"quiz"`
+	StartedAt   time.Time                     `json:"startedAt"`
+	DeadlineAt  null.Time                     `json:"deadlineAt"`
+	SubmittedAt null.Time                     `json:"submittedAt"`
+	Points      *decimal.Decimal              `json:"points,omitempty"`
+	MaxPoints   decimal.Decimal               `json:"maxPoints"`
+	Score       decimal.NullDecimal           `json:"score"`
+	Questions   []QuizAttemptQuestionResponse `json:"questions"`
+}
+
+type QuizAttemptQuestionResponse struct {
+	QuestionID    uuid.UUID              `json:"questionId"`
+	Position      int                    `json:"position"`
+	Type          model.QuizQuestionType `json:"type"`
+	Prompt        string                 `json:"prompt"`
+	Options       []model.QuizOption     `json:"options"`
+	Points        decimal.Decimal        `json:"points"`
+	Answer        *model.QuizAnswer      `json:"answer"`
+	IsCorrect     *bool                  `json:"isCorrect,omitempty"`
+	EarnedPoints  *decimal.Decimal       `json:"earnedPoints,omitempty"`
+	CorrectAnswer *model.QuizAnswer      `json:"correctAnswer,omitempty"`
+	Explanation   null.String            `json:"explanation,omitempty"`
+}
+
+func NewQuizAttemptResponse(attempt model.QuizAttempt, quiz model.Quiz) QuizAttemptResponse {
+	res := QuizAttemptResponse{
+		ID:          attempt.ID,
+		TaskID:      attempt.SessionTaskID,
+		Quiz:        NewTaskQuizResponse(quiz),
+		StartedAt:   attempt.StartedAt,
+		DeadlineAt:  attempt.DeadlineAt,
+		SubmittedAt: attempt.SubmittedAt,
+		MaxPoints:   decimal.Zero,
+		Score:       attempt.Score,
+		Questions:   make([]QuizAttemptQuestionResponse, 0, len(attempt.Answers)),
+	}
+	res.Quiz.QuestionsTotal = len(attempt.Answers)
+
+	submitted := attempt.IsSubmitted()
+	if submitted {
+		res.Points = &attempt.Points
+	}
+
+	for _, answer := range attempt.Answers {
+		asked := answer.AskedQuestion()
+		question := QuizAttemptQuestionResponse{
+			QuestionID: answer.QuestionID,
+			Position:   answer.Position,
+			Type:       asked.Type,
+			Prompt:     asked.Prompt,
+			Options:    asked.GetOptions(),
+			Points:     asked.Points,
+		}
+		res.MaxPoints = res.MaxPoints.Add(asked.Points)
+
+		if answer.IsAnswered() {
+			given := answer.GetAnswer()
+			question.Answer = &given
+		}
+
+		if submitted {
+			correct := asked.GetAnswer()
+			question.IsCorrect = &answer.IsCorrect
+			question.EarnedPoints = &answer.Points
+			question.CorrectAnswer = &correct
+			question.Explanation = asked.Explanation
+		}
+
+		res.Questions = append(res.Questions, question)
+	}
+
+	return res
+}
+
+// QuizAnalyticsResponse shows how the students did on each question of a
+// quiz across the submitted attempts.
+type QuizAnalyticsResponse struct {
+	QuizID       uuid.UUID                   `json:"quizId"`
+	Title        string                      `json:"title"`
+	Attempts     int                         `json:"attempts"`
+	AverageScore decimal.Decimal             `json:"averageScore"`
+	Questions    []QuizQuestionAnalyticsItem `json:"questions"`
+}
+
+// QuizQuestionAnalyticsItem counts the answers to one question. CorrectRate
+// is the percentage of attempts answering it correctly. Choice questions
+// count how often each option was picked.
+type QuizQuestionAnalyticsItem struct {
+	QuestionID  uuid.UUID                 `json:"questionId"`
+	Type        model.QuizQuestionType    `json:"type"`
+	Prompt      string                    `json:"prompt"`
+	Attempts    int                       `json:"attempts"`
+	Answered    int                       `json:"answered"`
+	Correct     int                       `json:"correct"`
+	CorrectRate decimal.Decimal           `json:"correctRate"`
+	Options     []QuizOptionAnalyticsItem `json:"options,omitempty"`
+}
+
+type QuizOptionAnalyticsItem struct {
+	Key       string `json:"key"`
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"isCorrect"`
+	Picked    int    `json:"picked"`
+}
+
+var hundred = decimal.NewFromInt(100)
+
+func percentage(part, whole int) decimal.Decimal {
+	if whole == 0 {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(int64(part)).Div(decimal.NewFromInt(int64(whole))).Mul(hundred).Round(2)
+}
+
+func NewQuizAnalyticsResponse(quiz model.Quiz, answers []model.QuizAttemptAnswer) QuizAnalyticsResponse {
+	res := QuizAnalyticsResponse{
+		QuizID:       quiz.ID,
+		Title:        quiz.Title,
+		AverageScore: decimal.Zero,
+		Questions:    []QuizQuestionAnalyticsItem{},
+	}
+
+	type attemptPoints struct{ points, max decimal.Decimal }
+	var (
+		attempts  = map[uuid.UUID]*attemptPoints{}
+		questions = map[uuid.UUID]*QuizQuestionAnalyticsItem{}
+		asked     = map[uuid.UUID]model.QuizQuestion{}
+		positions = map[uuid.UUID]int{}
+		picked    = map[uuid.UUID]map[string]int{}
+	)
+
+	for _, answer := range answers {
+		question := answer.AskedQuestion()
+
+		attempt, ok := attempts[answer.AttemptID]
+		if !ok {
+			attempt = &attemptPoints{}
+			attempts[answer.AttemptID] = attempt
+		}
+		attempt.points = attempt.points.Add(answer.Points)
+		attempt.max = attempt.max.Add(question.Points)
+
+		item, ok := questions[answer.QuestionID]
+		if !ok {
+			item = &QuizQuestionAnalyticsItem{
+				QuestionID: answer.QuestionID,
+				Type:       question.Type,
+				Prompt:     question.Prompt,
+			}
+			questions[answer.QuestionID] = item
+			asked[answer.QuestionID] = question
+			positions[answer.QuestionID] = answer.Position
+			picked[answer.QuestionID] = map[string]int{}
+		}
+
+		item.Attempts++
+		if answer.IsAnswered() {
+			item.Answered++
+			for _, key := range answer.GetAnswer().Options {
+				picked[answer.QuestionID][key]++
+			}
+		}
+		if answer.IsCorrect {
+			item.Correct++
+		}
+	}
+
+	res.Attempts = len(attempts)
+	if res.Attempts > 0 {
+		total := decimal.Zero
+		for _, attempt := range attempts {
+			if attempt.max.IsPositive() {
+				total = total.Add(attempt.points.Div(attempt.max).Mul(hundred))
+			}
+		}
+		res.AverageScore = total.Div(decimal.NewFromInt(int64(res.Attempts))).Round(2)
+	}
+
+	for id, item := range questions {
+		item.CorrectRate = percentage(item.Correct, item.Attempts)
+
+		question := asked[id]
+		if question.Type == model.QuizQuestionMultipleChoice || question.Type == model.QuizQuestionMultipleAnswer {
+			correct := map[string]bool{}
+			for _, key := range question.GetAnswer().Options {
+				correct[key] = true
+			}
+
+			for _, option := range question.GetOptions() {
+				item.Options = append(item.Options, QuizOptionAnalyticsItem{
+					Key:       option.Key,
+					Text:      option.Text,
+					IsCorrect: correct[option.Key],
+					Picked:    picked[id][option.Key],
+				})
+			}
+		}
+
+		res.Questions = append(res.Questions, *item)
+	}
+
+	sort.Slice(res.Questions, func(i, j int) bool {
+		return positions[res.Questions[i].QuestionID] < positions[res.Questions[j].QuestionID]
+	})
+
+	return res
+}
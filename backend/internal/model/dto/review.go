@@ -2,11 +2,13 @@ package dto
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
 	"github.com/lesprivate/backend/internal/model"
+	"github.com/shopspring/decimal"
 )
 
 type ListReviewRequest struct {
@@ -15,24 +17,78 @@ type ListReviewRequest struct {
 }
 
 type Review struct {
-	ID                 uuid.UUID   `json:"id"`
-	CourseTitle        string      `json:"courseTitle"`
-	Name               string      `json:"name"`
-	Email              string      `json:"email"`
-	PhotoProfile       null.String `json:"photoProfile"`
-	Review             null.String `json:"review"`
-	Rate               null.Int    `json:"rate"`
-	IsReviewed         bool        `json:"isReviewed"`
-	RecommendByStudent null.String `json:"recommendByStudent,omitempty"`
-	CreatedAt          time.Time   `json:"createdAt"`
-	UpdatedAt          time.Time   `json:"updatedAt"`
+	ID                 uuid.UUID     `json:"id"`
+	CourseTitle        string        `json:"courseTitle"`
+	Name               string        `json:"name"`
+	Email              string        `json:"email"`
+	PhotoProfile       null.String   `json:"photoProfile"`
+	Review             null.String   `json:"review"`
+	Rate               null.Int      `json:"rate"`
+	Criteria           *ReviewRating `json:"criteria,omitempty"`
+	IsReviewed         bool          `json:"isReviewed"`
+	RecommendByStudent null.String   `json:"recommendByStudent,omitempty"`
+	TutorReply         null.String   `json:"tutorReply,omitempty"`
+	HelpfulCount       int           `json:"helpfulCount"`
+	ModerationStatus   string        `json:"moderationStatus,omitempty"`
+	CreatedAt          time.Time     `json:"createdAt"`
+	UpdatedAt          time.Time     `json:"updatedAt"`
+}
+
+// ReviewRating holds the per-criterion rates a student gives a tutor.
+type ReviewRating struct {
+	Punctuality null.Int `json:"punctuality"`
+	Clarity     null.Int `json:"clarity"`
+	Patience    null.Int `json:"patience"`
+	Materials   null.Int `json:"materials"`
+}
+
+func NewReviewRating(review model.TutorReview) ReviewRating {
+	return ReviewRating{
+		Punctuality: review.PunctualityRate,
+		Clarity:     review.ClarityRate,
+		Patience:    review.PatienceRate,
+		Materials:   review.MaterialsRate,
+	}
+}
+
+func (r ReviewRating) Validate() error {
+	for name, rate := range map[string]null.Int{
+		"punctuality": r.Punctuality,
+		"clarity":     r.Clarity,
+		"patience":    r.Patience,
+		"materials":   r.Materials,
+	} {
+		if rate.Valid && (rate.Int64 < 1 || rate.Int64 > 5) {
+			return errors.New(name + " must be between 1 and 5")
+		}
+	}
+
+	return nil
+}
+
+// RatingCriteria is the denormalised per-criterion average of a tutor.
+type RatingCriteria struct {
+	Punctuality decimal.Decimal `json:"punctuality"`
+	Clarity     decimal.Decimal `json:"clarity"`
+	Patience    decimal.Decimal `json:"patience"`
+	Materials   decimal.Decimal `json:"materials"`
+}
+
+func NewRatingCriteria(tutor model.Tutor) RatingCriteria {
+	return RatingCriteria{
+		Punctuality: tutor.PunctualityRating,
+		Clarity:     tutor.ClarityRating,
+		Patience:    tutor.PatienceRating,
+		Materials:   tutor.MaterialsRating,
+	}
 }
 
 type UpdateReviewRequest struct {
-	ID                 uuid.UUID   `json:"-"`
-	Review             string      `json:"review"`
-	Rate               int         `json:"rate"`
-	RecommendByStudent null.String `json:"recommendByStudent,omitempty"`
+	ID                 uuid.UUID     `json:"-"`
+	Review             string        `json:"review"`
+	Rate               int           `json:"rate"`
+	Criteria           *ReviewRating `json:"criteria,omitempty"`
+	RecommendByStudent null.String   `json:"recommendByStudent,omitempty"`
 }
 
 func (r *UpdateReviewRequest) Validate() error {
@@ -44,6 +100,46 @@ func (r *UpdateReviewRequest) Validate() error {
 		return errors.New("rate must be between 1 and 5")
 	}
 
+	if r.Criteria != nil {
+		return r.Criteria.Validate()
+	}
+
+	return nil
+}
+
+type ReplyReviewRequest struct {
+	ID    uuid.UUID `json:"-"`
+	Reply string    `json:"reply"`
+}
+
+func (r *ReplyReviewRequest) Validate() error {
+	r.Reply = strings.TrimSpace(r.Reply)
+	if r.Reply == "" {
+		return errors.New("reply is required")
+	}
+
+	if len(r.Reply) > 2000 {
+		return errors.New("reply must be at most 2000 characters")
+	}
+
+	return nil
+}
+
+type ReportReviewRequest struct {
+	ID     uuid.UUID `json:"-"`
+	Reason string    `json:"reason"`
+}
+
+func (r *ReportReviewRequest) Validate() error {
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	if len(r.Reason) > 500 {
+		return errors.New("reason must be at most 500 characters")
+	}
+
 	return nil
 }
 
@@ -66,10 +162,11 @@ func (r *UpdateStudentReviewAdminRequest) Validate() error {
 }
 
 type UpdateTutorReviewAdminRequest struct {
-	ID                 uuid.UUID   `json:"-"`
-	Review             string      `json:"review"`
-	Rate               int         `json:"rate"`
-	RecommendByStudent null.String `json:"recommendByStudent,omitempty"`
+	ID                 uuid.UUID     `json:"-"`
+	Review             string        `json:"review"`
+	Rate               int           `json:"rate"`
+	Criteria           *ReviewRating `json:"criteria,omitempty"`
+	RecommendByStudent null.String   `json:"recommendByStudent,omitempty"`
 }
 
 func (r *UpdateTutorReviewAdminRequest) Validate() error {
@@ -81,5 +178,97 @@ func (r *UpdateTutorReviewAdminRequest) Validate() error {
 		return errors.New("rate must be between 1 and 5")
 	}
 
+	if r.Criteria != nil {
+		return r.Criteria.Validate()
+	}
+
 	return nil
 }
+
+type ReviewModerationAction string
+
+const (
+	ReviewModerationActionApprove ReviewModerationAction = "approve"
+	ReviewModerationActionHide    ReviewModerationAction = "hide"
+)
+
+type ListReviewModerationRequest struct {
+	Status  string    `form:"status"`
+	TutorID uuid.UUID `form:"tutorId"`
+	model.Pagination
+	model.Sort
+}
+
+type ModerateReviewRequest struct {
+	ID     uuid.UUID              `json:"-"`
+	Action ReviewModerationAction `json:"action"`
+	Note   null.String            `json:"note"`
+}
+
+func (r *ModerateReviewRequest) Validate() error {
+	if r.Action != ReviewModerationActionApprove && r.Action != ReviewModerationActionHide {
+		return errors.New("action must be approve or hide")
+	}
+
+	if r.Action == ReviewModerationActionHide && strings.TrimSpace(r.Note.String) == "" {
+		return errors.New("note is required when hiding a review")
+	}
+
+	return nil
+}
+
+type ReviewReport struct {
+	ID        uuid.UUID `json:"id"`
+	Source    string    `json:"source"`
+	Reporter  string    `json:"reporter"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ReviewModerationItem struct {
+	ID               uuid.UUID      `json:"id"`
+	CourseTitle      string         `json:"courseTitle"`
+	TutorName        string         `json:"tutorName"`
+	StudentName      string         `json:"studentName"`
+	Review           null.String    `json:"review"`
+	Rate             null.Int       `json:"rate"`
+	Criteria         ReviewRating   `json:"criteria"`
+	TutorReply       null.String    `json:"tutorReply"`
+	ModerationStatus string         `json:"moderationStatus"`
+	ModerationNote   null.String    `json:"moderationNote"`
+	Reports          []ReviewReport `json:"reports"`
+	CreatedAt        time.Time      `json:"createdAt"`
+}
+
+func NewReviewModerationItems(reviews []model.TutorReview) []ReviewModerationItem {
+	resp := make([]ReviewModerationItem, len(reviews))
+	for i, review := range reviews {
+		reports := make([]ReviewReport, len(review.Reports))
+		for j, report := range review.Reports {
+			reports[j] = ReviewReport{
+				ID:        report.ID,
+				Source:    string(report.Source),
+				Reporter:  report.Reporter.Name,
+				Reason:    report.Reason,
+				CreatedAt: report.CreatedAt,
+			}
+		}
+
+		resp[i] = ReviewModerationItem{
+			ID:               review.ID,
+			CourseTitle:      review.Course.Title,
+			TutorName:        review.Tutor.User.Name,
+			StudentName:      review.Student.User.Name,
+			Review:           review.Review,
+			Rate:             review.Rate,
+			Criteria:         NewReviewRating(review),
+			TutorReply:       review.TutorReply,
+			ModerationStatus: string(review.ModerationStatus),
+			ModerationNote:   review.ModerationNote,
+			Reports:          reports,
+			CreatedAt:        review.CreatedAt,
+		}
+	}
+
+	return resp
+}
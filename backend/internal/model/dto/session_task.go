@@ -0,0 +1,211 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type GetStudentTasksRequest struct {
+	// Status of the submission: pending, submitted or graded
+	Status string `form:"status"`
+	model.Pagination
+}
+
+func (r *GetStudentTasksRequest) Validate() error {
+	switch r.Status {
+	case "", model.TaskSubmissionStatusPending, model.TaskSubmissionStatusSubmitted, model.TaskSubmissionStatusGraded:
+		return nil
+	}
+
+	return fmt.Errorf("invalid status %s", r.Status)
+}
+
+type GetGradingQueueRequest struct {
+	// Status of the submission, submitted (the ungraded ones) by default
+	Status string `form:"status"`
+	model.Pagination
+}
+
+func (r *GetGradingQueueRequest) Validate() error {
+	switch r.Status {
+	case "":
+		r.Status = model.TaskSubmissionStatusSubmitted
+	case model.TaskSubmissionStatusSubmitted, model.TaskSubmissionStatusGraded:
+	default:
+		return fmt.Errorf("invalid status %s", r.Status)
+	}
+
+	return nil
+}
+
+// GradeTaskSubmissionRequest scores a submission. Tasks with a rubric are
+// scored per criterion and the score is their sum, other tasks take the
+// score between 0 and 100.
+type GradeTaskSubmissionRequest struct {
+	Score        *float64            `json:"score"`
+	RubricScores []model.RubricScore `json:"rubricScores"`
+	Feedback     *string             `json:"feedback"`
+}
+
+func (r *GradeTaskSubmissionRequest) Validate() error {
+	if r.Score == nil && len(r.RubricScores) == 0 {
+		return errors.New("score or rubric scores is required")
+	}
+
+	if r.Score != nil && (*r.Score < 0 || *r.Score > 100) {
+		return errors.New("score must be between 0 and 100")
+	}
+
+	for _, score := range r.RubricScores {
+		if score.Criterion == "" {
+			return errors.New("criterion is required")
+		}
+		if score.Score.IsNegative() {
+			return fmt.Errorf("score of %s must not be negative", score.Criterion)
+		}
+	}
+
+	return nil
+}
+
+type TaskSubmissionFileResponse struct {
+	ID       uuid.UUID `json:"id"`
+	URL      string    `json:"url"`
+	Filename string    `json:"filename"`
+	Size     int64     `json:"size"`
+}
+
+type TaskSubmissionResponse struct {
+	ID            uuid.UUID                    `json:"id"`
+	Status        string                       `json:"status"`
+	SubmissionURL null.String                  `json:"submissionUrl"`
+	SubmittedAt   null.Time                    `json:"submittedAt"`
+	IsLate        bool                         `json:"isLate"`
+	Score         decimal.NullDecimal          `json:"score"`
+	RubricScores  []model.RubricScore          `json:"rubricScores"`
+	Feedback      null.String                  `json:"feedback"`
+	GradedAt      null.Time                    `json:"gradedAt"`
+	Files         []TaskSubmissionFileResponse `json:"files"`
+}
+
+func NewTaskSubmissionResponse(submission model.TaskSubmission) TaskSubmissionResponse {
+	res := TaskSubmissionResponse{
+		ID:            submission.ID,
+		Status:        submission.Status,
+		SubmissionURL: submission.SubmissionURL,
+		SubmittedAt:   submission.SubmittedAt,
+		IsLate:        submission.IsLate,
+		Score:         submission.Score,
+		RubricScores:  submission.GetRubricScores(),
+		Feedback:      submission.Feedback,
+		GradedAt:      submission.GradedAt,
+		Files:         make([]TaskSubmissionFileResponse, 0, len(submission.Files)),
+	}
+
+	for _, file := range submission.Files {
+		res.Files = append(res.Files, TaskSubmissionFileResponse{
+			ID:       file.ID,
+			URL:      file.URL,
+			Filename: file.Filename,
+			Size:     file.Size,
+		})
+	}
+
+	return res
+}
+
+// StudentTaskResponse is a task of one of the student's bookings with the
+// latest submission of the student
+type StudentTaskResponse struct {
+	ID            uuid.UUID               `json:"id"`
+	BookingID     uuid.UUID               `json:"bookingId"`
+	Title         string                  `json:"title"`
+	Description   null.String             `json:"description"`
+	AttachmentURL null.String             `json:"attachmentUrl"`
+	DueAt         null.Time               `json:"dueAt"`
+	IsPastDue     bool                    `json:"isPastDue"`
+	Rubric        []model.RubricCriterion `json:"rubric"`
+	Quiz          *TaskQuizResponse       `json:"quiz,omitempty"`
+	CourseTitle   string                  `json:"courseTitle"`
+	TutorName     string                  `json:"tutorName"`
+	BookingDate   string                  `json:"bookingDate"`
+	Status        string                  `json:"status"`
+	Submission    *TaskSubmissionResponse `json:"submission,omitempty"`
+	CreatedAt     time.Time               `json:"createdAt"`
+}
+
+func NewStudentTaskResponse(task model.SessionTask) StudentTaskResponse {
+	res := StudentTaskResponse{
+		ID:            task.ID,
+		BookingID:     task.BookingID,
+		Title:         task.Title,
+		Description:   task.Description,
+		AttachmentURL: task.AttachmentURL,
+		DueAt:         task.DueAt,
+		IsPastDue:     task.IsPastDue(time.Now()),
+		Rubric:        task.GetRubric(),
+		Status:        model.TaskSubmissionStatusPending,
+		CreatedAt:     task.CreatedAt,
+	}
+
+	if task.Booking != nil {
+		res.CourseTitle = task.Booking.Course.Title
+		res.TutorName = task.Booking.Tutor.User.Name
+		res.BookingDate = task.Booking.BookingDate.Format("2006-01-02")
+	}
+
+	if task.Quiz != nil {
+		quiz := NewTaskQuizResponse(*task.Quiz)
+		res.Quiz = &quiz
+	}
+
+	if len(task.TaskSubmissions) > 0 {
+		submission := NewTaskSubmissionResponse(task.TaskSubmissions[0])
+		res.Status = submission.Status
+		res.Submission = &submission
+	}
+
+	return res
+}
+
+// GradingQueueResponse is a submission waiting on the tutor with the task it
+// was submitted for
+type GradingQueueResponse struct {
+	TaskSubmissionResponse
+	TaskID      uuid.UUID               `json:"taskId"`
+	TaskTitle   string                  `json:"taskTitle"`
+	DueAt       null.Time               `json:"dueAt"`
+	Rubric      []model.RubricCriterion `json:"rubric"`
+	BookingID   uuid.UUID               `json:"bookingId"`
+	StudentID   uuid.UUID               `json:"studentId"`
+	StudentName string                  `json:"studentName"`
+	CourseTitle string                  `json:"courseTitle"`
+}
+
+func NewGradingQueueResponse(submission model.TaskSubmission) GradingQueueResponse {
+	res := GradingQueueResponse{
+		TaskSubmissionResponse: NewTaskSubmissionResponse(submission),
+		TaskID:                 submission.SessionTaskID,
+	}
+
+	if task := submission.SessionTask; task != nil {
+		res.TaskTitle = task.Title
+		res.DueAt = task.DueAt
+		res.Rubric = task.GetRubric()
+		res.BookingID = task.BookingID
+		if task.Booking != nil {
+			res.StudentID = task.Booking.StudentID
+			res.StudentName = task.Booking.Student.User.Name
+			res.CourseTitle = task.Booking.Course.Title
+		}
+	}
+
+	return res
+}
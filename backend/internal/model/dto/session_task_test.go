@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestGetStudentTasksRequest_Validate(t *testing.T) {
+	for _, status := range []string{"", model.TaskSubmissionStatusPending, model.TaskSubmissionStatusSubmitted, model.TaskSubmissionStatusGraded} {
+		req := GetStudentTasksRequest{Status: status}
+		if err := req.Validate(); err != nil {
+			t.Errorf("Validate() with status %q error = %v, want nil", status, err)
+		}
+	}
+
+	req := GetStudentTasksRequest{Status: "late"}
+	if err := req.Validate(); err == nil {
+		t.Errorf("Validate() with status late error = nil, want an error")
+	}
+}
+
+func TestGetGradingQueueRequest_Validate(t *testing.T) {
+	req := GetGradingQueueRequest{}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+	if req.Status != model.TaskSubmissionStatusSubmitted {
+		t.Errorf("Status = %s, want the ungraded submissions by default", req.Status)
+	}
+
+	req = GetGradingQueueRequest{Status: model.TaskSubmissionStatusPending}
+	if err := req.Validate(); err == nil {
+		t.Errorf("Validate() with status pending error = nil, want an error")
+	}
+}
+
+func TestGradeTaskSubmissionRequest_Validate(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		req     GradeTaskSubmissionRequest
+		wantErr bool
+	}{
+		{name: "score", req: GradeTaskSubmissionRequest{Score: score(85)}},
+		{name: "rubric scores", req: GradeTaskSubmissionRequest{RubricScores: []model.RubricScore{{Criterion: "Isi", Score: decimal.NewFromInt(30)}}}},
+		{name: "nothing scored", req: GradeTaskSubmissionRequest{}, wantErr: true},
+		{name: "negative score", req: GradeTaskSubmissionRequest{Score: score(-1)}, wantErr: true},
+		{name: "score over 100", req: GradeTaskSubmissionRequest{Score: score(100.5)}, wantErr: true},
+		{name: "missing criterion", req: GradeTaskSubmissionRequest{RubricScores: []model.RubricScore{{Score: decimal.NewFromInt(30)}}}, wantErr: true},
+		{name: "negative criterion score", req: GradeTaskSubmissionRequest{RubricScores: []model.RubricScore{{Criterion: "Isi", Score: decimal.NewFromInt(-5)}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewStudentTaskResponse(t *testing.T) {
+	task := model.SessionTask{Title: "Esai"}
+	if res := NewStudentTaskResponse(task); res.Status != model.TaskSubmissionStatusPending || res.Submission != nil {
+		t.Errorf("NewStudentTaskResponse() = %+v, want a pending task without submission", res)
+	}
+
+	task.TaskSubmissions = []model.TaskSubmission{{Status: model.TaskSubmissionStatusGraded, Score: decimal.NewNullDecimal(decimal.NewFromInt(90))}}
+	res := NewStudentTaskResponse(task)
+	if res.Status != model.TaskSubmissionStatusGraded || res.Submission == nil || !res.Submission.Score.Decimal.Equal(decimal.NewFromInt(90)) {
+		t.Errorf("NewStudentTaskResponse() = %+v, want the graded submission", res)
+	}
+	if res.Submission.Files == nil || res.Rubric == nil {
+		t.Errorf("NewStudentTaskResponse() = %+v, want empty files and rubric rather than null", res)
+	}
+}
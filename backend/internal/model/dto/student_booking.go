@@ -11,13 +11,14 @@ import (
 )
 
 type CreateStudentBookingRequest struct {
-	CourseID    uuid.UUID       `json:"courseID"`
-	ClassType   model.ClassType `json:"classType"`
-	BookingDate string          `json:"bookingDate"`
-	BookingTime string          `json:"bookingTime"`
-	Notes       null.String     `json:"notes"`
-	Latitude    decimal.Decimal `json:"latitude"`
-	Longitude   decimal.Decimal `json:"longitude"`
+	CourseID    uuid.UUID         `json:"courseID"`
+	ClassType   model.ClassType   `json:"classType"`
+	SessionType model.SessionType `json:"sessionType"` // group books a seat of the group schedule of the slot
+	BookingDate string            `json:"bookingDate"`
+	BookingTime string            `json:"bookingTime"`
+	Notes       null.String       `json:"notes"`
+	Latitude    decimal.Decimal   `json:"latitude"`
+	Longitude   decimal.Decimal   `json:"longitude"`
 }
 
 func (r *CreateStudentBookingRequest) Validate() error {
@@ -45,6 +46,14 @@ func (r *CreateStudentBookingRequest) Validate() error {
 		return errors.New("classType is required")
 	}
 
+	switch r.SessionType {
+	case "":
+		r.SessionType = model.PrivateSessionType
+	case model.PrivateSessionType, model.GroupSessionType:
+	default:
+		return errors.New("sessionType must be one of private, group")
+	}
+
 	bookingDate, err := time.Parse(time.DateOnly, r.BookingDate)
 	if err != nil {
 		return err
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+type GetStudentProgressRequest struct {
+	// Months is the number of months up to the current one, 6 by default
+	Months           int       `form:"months"`
+	CourseCategoryID uuid.UUID `form:"courseCategoryId"`
+}
+
+func (r *GetStudentProgressRequest) Validate() error {
+	if r.Months == 0 {
+		r.Months = 6
+	}
+
+	if r.Months < 1 || r.Months > 24 {
+		return errors.New("months must be between 1 and 24")
+	}
+
+	return nil
+}
+
+// ProgressFigures are the totals of a range of months. Rates are percentages
+// and null, like the average score, when there is nothing to rate.
+type ProgressFigures struct {
+	Sessions           int        `json:"sessions"`
+	AverageScore       null.Float `json:"averageScore"`
+	TaskCompletionRate null.Float `json:"taskCompletionRate"`
+	AttendanceRate     null.Float `json:"attendanceRate"`
+}
+
+// ProgressSeries holds one value per period of the response, null for the
+// periods without data, ready to be plotted.
+type ProgressSeries struct {
+	Sessions           []int        `json:"sessions"`
+	AverageScore       []null.Float `json:"averageScore"`
+	TaskCompletionRate []null.Float `json:"taskCompletionRate"`
+	AttendanceRate     []null.Float `json:"attendanceRate"`
+}
+
+// ProgressTrend is the change per month of a metric: averageScore,
+// taskCompletionRate or attendanceRate. Direction is up, down or flat.
+type ProgressTrend struct {
+	Metric    string  `json:"metric"`
+	Slope     float64 `json:"slope"`
+	Direction string  `json:"direction"`
+	Declining bool    `json:"declining"`
+}
+
+type ProgressBreakdown struct {
+	Summary   ProgressFigures `json:"summary"`
+	Series    ProgressSeries  `json:"series"`
+	Trends    []ProgressTrend `json:"trends"`
+	Declining bool            `json:"declining"`
+}
+
+type SubjectProgress struct {
+	CourseCategoryID uuid.UUID `json:"courseCategoryId"`
+	Name             string    `json:"name"`
+	ProgressBreakdown
+}
+
+// StudentProgressResponse is the progress of a student per month over all
+// their subjects and per subject. Periods are the months as YYYYMM.
+type StudentProgressResponse struct {
+	StudentID uuid.UUID         `json:"studentId"`
+	Periods   []string          `json:"periods"`
+	Overall   ProgressBreakdown `json:"overall"`
+	Subjects  []SubjectProgress `json:"subjects"`
+}
+
+type CohortStudentProgress struct {
+	StudentID uuid.UUID `json:"studentId"`
+	Name      string    `json:"name"`
+	ProgressFigures
+	// ScoreDelta is the difference of the student's average score to the cohort's
+	ScoreDelta null.Float      `json:"scoreDelta"`
+	Trends     []ProgressTrend `json:"trends"`
+	Declining  bool            `json:"declining"`
+}
+
+// CohortProgressResponse compares the students of a mentor with the cohort
+// of all of them
+type CohortProgressResponse struct {
+	Periods  []string                `json:"periods"`
+	Cohort   ProgressBreakdown       `json:"cohort"`
+	Students []CohortStudentProgress `json:"students"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "testing"
+
+func TestGetStudentProgressRequest_Validate(t *testing.T) {
+	req := GetStudentProgressRequest{}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+	if req.Months != 6 {
+		t.Errorf("Months = %d, want 6 by default", req.Months)
+	}
+
+	for _, months := range []int{1, 24} {
+		req := GetStudentProgressRequest{Months: months}
+		if err := req.Validate(); err != nil {
+			t.Errorf("Validate() with %d months error = %v, want nil", months, err)
+		}
+	}
+
+	for _, months := range []int{-1, 25} {
+		req := GetStudentProgressRequest{Months: months}
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate() with %d months error = nil, want an error", months)
+		}
+	}
+}
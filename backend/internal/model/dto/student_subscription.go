@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"github.com/shopspring/decimal"
 
 	"github.com/lesprivate/backend/internal/model"
@@ -53,3 +54,65 @@ type GetStudentSubscriptionResponse struct {
 	EndAt         time.Time                  `json:"endAt"`
 	Status        model.SubscriptionStatus   `json:"status"`
 }
+
+type ChangeStudentSubscriptionPlanRequest struct {
+	ID             uuid.UUID `json:"-"`
+	SubscriptionID uuid.UUID `json:"subscriptionId"`
+	IntervalCount  int       `json:"intervalCount"`
+}
+
+func (r *ChangeStudentSubscriptionPlanRequest) Validate() error {
+	return (&CreateStudentSubscriptionRequest{
+		SubscriptionID: r.SubscriptionID,
+		IntervalCount:  r.IntervalCount,
+	}).Validate()
+}
+
+type ChangeStudentSubscriptionPlanResponse struct {
+	SubscriptionID uuid.UUID       `json:"subscriptionId"`
+	Amount         decimal.Decimal `json:"amount"`
+	ProratedCredit decimal.Decimal `json:"proratedCredit"`
+	NextBillingAt  time.Time       `json:"nextBillingAt"`
+	URL            string          `json:"url"`
+}
+
+type StudentRecurringSubscriptionResponse struct {
+	ID                uuid.UUID                  `json:"id"`
+	Name              string                     `json:"name"`
+	Price             decimal.Decimal            `json:"price"`
+	Interval          model.SubscriptionInterval `json:"interval"`
+	IntervalCount     int                        `json:"intervalCount"`
+	Status            model.SubscriptionStatus   `json:"status"`
+	StartAt           time.Time                  `json:"startAt"`
+	EndAt             time.Time                  `json:"endAt"`
+	TrialEndsAt       null.Time                  `json:"trialEndsAt"`
+	CancelAtPeriodEnd bool                       `json:"cancelAtPeriodEnd"`
+	CanceledAt        null.Time                  `json:"canceledAt"`
+	RetryCount        int                        `json:"retryCount"`
+	GraceUntil        null.Time                  `json:"graceUntil"`
+	ProratedCredit    decimal.Decimal            `json:"proratedCredit"`
+}
+
+func NewStudentRecurringSubscriptionResponses(subscriptions []model.Subscription) []StudentRecurringSubscriptionResponse {
+	resp := make([]StudentRecurringSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		resp = append(resp, StudentRecurringSubscriptionResponse{
+			ID:                subscription.ID,
+			Name:              subscription.Name(),
+			Price:             subscription.Amount,
+			Interval:          subscription.Interval,
+			IntervalCount:     subscription.IntervalCount,
+			Status:            subscription.Status,
+			StartAt:           subscription.StartDate,
+			EndAt:             subscription.EndDate,
+			TrialEndsAt:       subscription.TrialEndsAt,
+			CancelAtPeriodEnd: subscription.CancelAtPeriodEnd,
+			CanceledAt:        subscription.CanceledAt,
+			RetryCount:        subscription.RetryCount,
+			GraceUntil:        subscription.GraceUntil,
+			ProratedCredit:    subscription.ProratedCredit,
+		})
+	}
+
+	return resp
+}
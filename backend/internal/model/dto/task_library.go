@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type GetTaskLibraryRequest struct {
+	CourseID            uuid.UUID `form:"courseId"`
+	SubCourseCategoryID uuid.UUID `form:"subCourseCategoryId"`
+	Query               string    `form:"q"`
+	model.Pagination
+}
+
+type TaskRubricRequest struct {
+	Name                string                  `json:"name"`
+	Description         *string                 `json:"description"`
+	CourseID            *uuid.UUID              `json:"courseId"`
+	SubCourseCategoryID *uuid.UUID              `json:"subCourseCategoryId"`
+	Criteria            []model.RubricCriterion `json:"criteria"`
+}
+
+func (r *TaskRubricRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	return model.ValidateRubric(r.Criteria)
+}
+
+type TaskTemplateRequest struct {
+	Title               string     `json:"title"`
+	Description         *string    `json:"description"`
+	AttachmentURL       *string    `json:"attachmentUrl"`
+	CourseID            *uuid.UUID `json:"courseId"`
+	SubCourseCategoryID *uuid.UUID `json:"subCourseCategoryId"`
+	TaskRubricID        *uuid.UUID `json:"taskRubricId"`
+	// DueDays is the number of days after the session the task is due
+	DueDays *int `json:"dueDays"`
+}
+
+func (r *TaskTemplateRequest) Validate() error {
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+
+	if r.DueDays != nil && (*r.DueDays < 0 || *r.DueDays > 365) {
+		return errors.New("due days must be between 0 and 365")
+	}
+
+	return nil
+}
+
+// AssignTaskTemplateRequest assigns a template to one booking, or without a
+// booking to every upcoming session of the mentor's students, only the
+// members of the group when GroupID is set or else the given ones when
+// StudentIDs is set
+type AssignTaskTemplateRequest struct {
+	BookingID  *uuid.UUID  `json:"bookingId"`
+	GroupID    *uuid.UUID  `json:"groupId"`
+	StudentIDs []uuid.UUID `json:"studentIds"`
+}
+
+type AssignTaskTemplateResponse struct {
+	// Assigned holds the tasks created, Skipped counts the sessions which
+	// already had a task from the template
+	Assigned []model.SessionTask `json:"assigned"`
+	Skipped  int                 `json:"skipped"`
+}
+
+type TaskRubricResponse struct {
+	ID                    uuid.UUID               `json:"id"`
+	Name                  string                  `json:"name"`
+	Description           null.String             `json:"description"`
+	CourseID              uuid.NullUUID           `json:"courseId"`
+	CourseTitle           string                  `json:"courseTitle"`
+	SubCourseCategoryID   uuid.NullUUID           `json:"subCourseCategoryId"`
+	SubCourseCategoryName string                  `json:"subCourseCategoryName"`
+	Criteria              []model.RubricCriterion `json:"criteria"`
+	CreatedAt             time.Time               `json:"createdAt"`
+	UpdatedAt             time.Time               `json:"updatedAt"`
+}
+
+func NewTaskRubricResponse(rubric model.TaskRubric) TaskRubricResponse {
+	res := TaskRubricResponse{
+		ID:                  rubric.ID,
+		Name:                rubric.Name,
+		Description:         rubric.Description,
+		CourseID:            rubric.CourseID,
+		SubCourseCategoryID: rubric.SubCourseCategoryID,
+		Criteria:            rubric.GetCriteria(),
+		CreatedAt:           rubric.CreatedAt,
+		UpdatedAt:           rubric.UpdatedAt,
+	}
+
+	if rubric.Course != nil {
+		res.CourseTitle = rubric.Course.Title
+	}
+
+	if rubric.SubCourseCategory != nil {
+		res.SubCourseCategoryName = rubric.SubCourseCategory.Name
+	}
+
+	return res
+}
+
+type TaskTemplateResponse struct {
+	ID                    uuid.UUID           `json:"id"`
+	Title                 string              `json:"title"`
+	Description           null.String         `json:"description"`
+	AttachmentURL         null.String         `json:"attachmentUrl"`
+	DueDays               null.Int            `json:"dueDays"`
+	CourseID              uuid.NullUUID       `json:"courseId"`
+	CourseTitle           string              `json:"courseTitle"`
+	SubCourseCategoryID   uuid.NullUUID       `json:"subCourseCategoryId"`
+	SubCourseCategoryName string              `json:"subCourseCategoryName"`
+	Rubric                *TaskRubricResponse `json:"rubric"`
+	CreatedAt             time.Time           `json:"createdAt"`
+	UpdatedAt             time.Time           `json:"updatedAt"`
+}
+
+func NewTaskTemplateResponse(template model.TaskTemplate) TaskTemplateResponse {
+	res := TaskTemplateResponse{
+		ID:                  template.ID,
+		Title:               template.Title,
+		Description:         template.Description,
+		AttachmentURL:       template.AttachmentURL,
+		DueDays:             template.DueDays,
+		CourseID:            template.CourseID,
+		SubCourseCategoryID: template.SubCourseCategoryID,
+		CreatedAt:           template.CreatedAt,
+		UpdatedAt:           template.UpdatedAt,
+	}
+
+	if template.Course != nil {
+		res.CourseTitle = template.Course.Title
+	}
+
+	if template.SubCourseCategory != nil {
+		res.SubCourseCategoryName = template.SubCourseCategory.Name
+	}
+
+	if template.TaskRubric != nil {
+		rubric := NewTaskRubricResponse(*template.TaskRubric)
+		res.Rubric = &rubric
+	}
+
+	return res
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestTaskRubricRequest_Validate(t *testing.T) {
+	criteria := []model.RubricCriterion{{Name: "Isi", MaxScore: decimal.NewFromInt(100)}}
+
+	tests := []struct {
+		name    string
+		req     TaskRubricRequest
+		wantErr bool
+	}{
+		{name: "valid", req: TaskRubricRequest{Name: "Esai", Criteria: criteria}},
+		{name: "missing name", req: TaskRubricRequest{Criteria: criteria}, wantErr: true},
+		{name: "no criteria", req: TaskRubricRequest{Name: "Esai"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTaskTemplateRequest_Validate(t *testing.T) {
+	days := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		req     TaskTemplateRequest
+		wantErr bool
+	}{
+		{name: "valid", req: TaskTemplateRequest{Title: "Esai", DueDays: days(7)}},
+		{name: "due on the session day", req: TaskTemplateRequest{Title: "Esai", DueDays: days(0)}},
+		{name: "no due date", req: TaskTemplateRequest{Title: "Esai"}},
+		{name: "missing title", req: TaskTemplateRequest{DueDays: days(7)}, wantErr: true},
+		{name: "negative due days", req: TaskTemplateRequest{Title: "Esai", DueDays: days(-1)}, wantErr: true},
+		{name: "due over a year later", req: TaskTemplateRequest{Title: "Esai", DueDays: days(366)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -33,9 +33,10 @@ type CreateTutorBookingRequest struct {
 	Notes           null.String `json:"notes"`
 }
 type TutorBookingStats struct {
-	Pending   int `json:"pending"`
-	Accepted  int `json:"accepted"`
-	Rejected  int `json:"rejected"`
-	Completed int `json:"completed"`
-	Total     int `json:"total"`
+	Pending      int      `json:"pending"`
+	Accepted     int      `json:"accepted"`
+	Rejected     int      `json:"rejected"`
+	Completed    int      `json:"completed"`
+	Total        int      `json:"total"`
+	ResponseTime null.Int `json:"responseTime"`
 }
@@ -0,0 +1,141 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type TutorLevelInfo struct {
+	CurrentLevel string                   `json:"current_level"`
+	NextLevel    null.String              `json:"next_level"`
+	IsMaxLevel   bool                     `json:"is_max_level"`
+	Progress     int                      `json:"progress"`
+	Criteria     []TutorLevelCriterion    `json:"criteria"`
+	EvaluatedAt  null.Time                `json:"evaluated_at"`
+	Histories    []TutorLevelHistoryEntry `json:"histories"`
+}
+
+// TutorLevelCriterion is the progress of a single criterion towards the next
+// level, or of the current level once the tutor is on the highest level.
+type TutorLevelCriterion struct {
+	Criterion string `json:"criterion"`
+	Current   string `json:"current"`
+	Required  string `json:"required"`
+	Met       bool   `json:"met"`
+}
+
+type TutorLevelHistoryEntry struct {
+	ID        uuid.UUID   `json:"id"`
+	FromLevel null.String `json:"from_level"`
+	ToLevel   string      `json:"to_level"`
+	Reason    string      `json:"reason"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func NewTutorLevelCriteria(checks []model.TutorLevelCheck) ([]TutorLevelCriterion, int) {
+	var (
+		criteria = make([]TutorLevelCriterion, len(checks))
+		met      int
+	)
+	for i, check := range checks {
+		criteria[i] = TutorLevelCriterion{
+			Criterion: check.Criterion,
+			Current:   check.Current,
+			Required:  check.Required,
+			Met:       check.Met,
+		}
+		if check.Met {
+			met++
+		}
+	}
+
+	if len(checks) == 0 {
+		return criteria, 100
+	}
+
+	return criteria, met * 100 / len(checks)
+}
+
+func NewTutorLevelHistoryEntries(histories []model.TutorLevelHistory) []TutorLevelHistoryEntry {
+	entries := make([]TutorLevelHistoryEntry, len(histories))
+	for i, h := range histories {
+		entries[i] = TutorLevelHistoryEntry{
+			ID:        h.ID,
+			FromLevel: h.FromLevel,
+			ToLevel:   h.ToLevel,
+			Reason:    h.Reason,
+			CreatedAt: h.CreatedAt,
+		}
+	}
+	return entries
+}
+
+type AdminTutorLevelCriteriaResponse struct {
+	ID                   uuid.UUID       `json:"id"`
+	Level                string          `json:"level"`
+	Sequence             int             `json:"sequence"`
+	MinCompletedSessions int             `json:"minCompletedSessions"`
+	MinRating            decimal.Decimal `json:"minRating"`
+	RatingWindowDays     int             `json:"ratingWindowDays"`
+	MaxResponseTime      null.Int        `json:"maxResponseTime"`
+	MaxCancellationRate  null.Float      `json:"maxCancellationRate"`
+	MinVerifiedDocuments int             `json:"minVerifiedDocuments"`
+	UpdatedAt            time.Time       `json:"updatedAt"`
+}
+
+func NewAdminTutorLevelCriteriaResponses(criteria []model.TutorLevelCriteria) []AdminTutorLevelCriteriaResponse {
+	responses := make([]AdminTutorLevelCriteriaResponse, len(criteria))
+	for i, c := range criteria {
+		responses[i] = AdminTutorLevelCriteriaResponse{
+			ID:                   c.ID,
+			Level:                string(c.Level),
+			Sequence:             c.Sequence,
+			MinCompletedSessions: c.MinCompletedSessions,
+			MinRating:            c.MinRating,
+			RatingWindowDays:     c.RatingWindowDays,
+			MaxResponseTime:      c.MaxResponseTime,
+			MaxCancellationRate:  c.MaxCancellationRate,
+			MinVerifiedDocuments: c.MinVerifiedDocuments,
+			UpdatedAt:            c.UpdatedAt,
+		}
+	}
+	return responses
+}
+
+type UpdateAdminTutorLevelCriteriaRequest struct {
+	ID                   uuid.UUID       `json:"-"`
+	MinCompletedSessions int             `json:"minCompletedSessions"`
+	MinRating            decimal.Decimal `json:"minRating"`
+	RatingWindowDays     int             `json:"ratingWindowDays"`
+	MaxResponseTime      null.Int        `json:"maxResponseTime"`
+	MaxCancellationRate  null.Float      `json:"maxCancellationRate"`
+	MinVerifiedDocuments int             `json:"minVerifiedDocuments"`
+}
+
+func (r *UpdateAdminTutorLevelCriteriaRequest) Validate() error {
+	if r.MinCompletedSessions < 0 {
+		return errors.New("min completed sessions must not be negative")
+	}
+	if r.MinRating.IsNegative() || r.MinRating.GreaterThan(decimal.NewFromInt(5)) {
+		return errors.New("min rating must be between 0 and 5")
+	}
+	if r.RatingWindowDays < 1 {
+		return errors.New("rating window days must be at least 1")
+	}
+	if r.MaxResponseTime.Valid && r.MaxResponseTime.Int64 < 0 {
+		return errors.New("max response time must not be negative")
+	}
+	if r.MaxCancellationRate.Valid && (r.MaxCancellationRate.Float64 < 0 || r.MaxCancellationRate.Float64 > 100) {
+		return errors.New("max cancellation rate must be between 0 and 100")
+	}
+	if r.MinVerifiedDocuments < 0 {
+		return errors.New("min verified documents must not be negative")
+	}
+	return nil
+}
@@ -62,7 +62,7 @@ func (r *RegisterRequest) Validate() error {
 		return errors.New("role name is required")
 	}
 	// Validate role name against allowed values
-	allowedRoles := []string{"student", "tutor"}
+	allowedRoles := []string{"student", "tutor", "guardian"}
 	roleValid := false
 	for _, role := range allowedRoles {
 		if r.RoleName == role {
@@ -71,7 +71,7 @@ func (r *RegisterRequest) Validate() error {
 		}
 	}
 	if !roleValid {
-		return errors.New("invalid role name. Allowed roles: student, tutor")
+		return errors.New("invalid role name. Allowed roles: student, tutor, guardian")
 	}
 
 	return nil
@@ -177,7 +177,7 @@ func (r *GoogleLoginRequest) Validate() error {
 			return errors.New("role name cannot be empty when provided")
 		}
 		// Validate role name against allowed values
-		allowedRoles := []string{"student", "tutor"}
+		allowedRoles := []string{"student", "tutor", "guardian"}
 		roleValid := false
 		for _, role := range allowedRoles {
 			if roleName == role {
@@ -186,7 +186,7 @@ func (r *GoogleLoginRequest) Validate() error {
 			}
 		}
 		if !roleValid {
-			return errors.New("invalid role name. Allowed roles: student, tutor, admin")
+			return errors.New("invalid role name. Allowed roles: student, tutor, guardian")
 		}
 	}
 
@@ -6,9 +6,12 @@ import (
 
 const (
 	WebhookXenditEventTypeRecurringCycleSucceeded = "recurring.cycle.succeeded"
+	WebhookXenditEventTypeRecurringCycleRetrying  = "recurring.cycle.retrying"
 	WebhookXenditEventTypeRecurringCycleFailed    = "recurring.cycle.failed"
 	WebhookXenditEventTypePaymentSessionCompleted = "payment_session.completed"
 	WebhookXenditEventTypePaymentSessionExpired   = "payment_session.expired"
+	WebhookXenditEventTypeRefundSucceeded         = "refund.succeeded"
+	WebhookXenditEventTypeRefundFailed            = "refund.failed"
 )
 
 type WebhookXenditRecurringCycle struct {
@@ -64,6 +67,34 @@ type WebhookXenditPaymentSessionCompleted struct {
 	SuccessReturnURL string    `json:"success_return_url"`
 }
 
+type WebhookXenditRefund struct {
+	ID               string    `json:"id"`
+	PaymentRequestID string    `json:"payment_request_id"`
+	ReferenceID      string    `json:"reference_id"`
+	Amount           int       `json:"amount"`
+	Currency         string    `json:"currency"`
+	Status           string    `json:"status"`
+	FailureCode      string    `json:"failure_code"`
+	Reason           string    `json:"reason"`
+	Created          time.Time `json:"created"`
+	Updated          time.Time `json:"updated"`
+}
+
+// WebhookXenditDisbursement is the legacy disbursement callback body. It is
+// not wrapped in an event like the other Xendit webhooks.
+type WebhookXenditDisbursement struct {
+	ID                string    `json:"id"`
+	ExternalID        string    `json:"external_id"`
+	Amount            int       `json:"amount"`
+	BankCode          string    `json:"bank_code"`
+	AccountHolderName string    `json:"account_holder_name"`
+	Status            string    `json:"status"`
+	FailureCode       string    `json:"failure_code"`
+	Created           time.Time `json:"created"`
+	Updated           time.Time `json:"updated"`
+	WebhookKey        string    `json:"-"`
+}
+
 type WebhookXenditRequest struct {
 	Created    time.Time `json:"created"`
 	BusinessID string    `json:"business_id"`
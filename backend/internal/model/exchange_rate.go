@@ -0,0 +1,94 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const (
+	ExchangeRateSourceManual   = "manual"
+	ExchangeRateSourceProvider = "provider"
+)
+
+// ExchangeRate is the value of one unit of Currency in IDR on RateDate. A
+// rate holds until a later snapshot of the same currency replaces it.
+type ExchangeRate struct {
+	ID        uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	Currency  string          `gorm:"type:char(3);not null" json:"currency"`
+	Rate      decimal.Decimal `gorm:"type:decimal(20,6);not null" json:"rate"`
+	RateDate  time.Time       `gorm:"type:date;not null" json:"rateDate"`
+	Source    string          `gorm:"type:varchar(20);not null" json:"source"`
+	CreatedBy uuid.NullUUID   `gorm:"type:char(36)" json:"createdBy"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (r *ExchangeRate) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+type ExchangeRateFilter struct {
+	Currency string
+	From     time.Time
+	To       time.Time
+
+	Pagination
+}
+
+// ExchangeRates holds the IDR value of a unit of each currency.
+type ExchangeRates map[string]decimal.Decimal
+
+// Rate returns how many units of to one unit of from is worth. IDR is always
+// known.
+func (r ExchangeRates) Rate(from, to string) (decimal.Decimal, bool) {
+	if from == to {
+		return decimal.NewFromInt(1), true
+	}
+
+	fromRate, ok := r.idr(from)
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	toRate, ok := r.idr(to)
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	return fromRate.DivRound(toRate, 10), true
+}
+
+func (r ExchangeRates) idr(currency string) (decimal.Decimal, bool) {
+	if currency == CurrencyIDR {
+		return decimal.NewFromInt(1), true
+	}
+
+	rate, ok := r[currency]
+	if !ok || !rate.IsPositive() {
+		return decimal.Zero, false
+	}
+
+	return rate, true
+}
+
+// Convert converts an amount and rounds it to the minor unit of to. It
+// returns the rate used.
+func (r ExchangeRates) Convert(amount decimal.Decimal, from, to string) (decimal.Decimal, decimal.Decimal, bool) {
+	rate, ok := r.Rate(from, to)
+	if !ok {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	return MustCurrency(to).Round(amount.Mul(rate)), rate, true
+}
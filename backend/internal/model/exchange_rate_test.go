@@ -0,0 +1,100 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestExchangeRatesConvert(t *testing.T) {
+	rates := ExchangeRates{
+		"USD": decimal.NewFromInt(16000),
+		"SGD": decimal.NewFromInt(12000),
+		"JPY": decimal.RequireFromString("107.5"),
+		"EUR": decimal.Zero,
+	}
+
+	tests := []struct {
+		name     string
+		amount   string
+		from     string
+		to       string
+		want     string
+		wantRate string
+		wantOK   bool
+	}{
+		{name: "same currency", amount: "150000", from: CurrencyIDR, to: CurrencyIDR, want: "150000", wantRate: "1", wantOK: true},
+		{name: "foreign currency to rupiah", amount: "25.50", from: "USD", to: CurrencyIDR, want: "408000", wantRate: "16000", wantOK: true},
+		{name: "rupiah to foreign currency is rounded to cents", amount: "100000", from: CurrencyIDR, to: "USD", want: "6.25", wantRate: "0.0000625", wantOK: true},
+		{name: "cross rate through rupiah", amount: "30", from: "USD", to: "SGD", want: "40", wantRate: "1.3333333333", wantOK: true},
+		{name: "currency without minor unit", amount: "10", from: "USD", to: "JPY", want: "1488", wantRate: "148.8372093023", wantOK: true},
+		{name: "unknown currency", amount: "10", from: "GBP", to: CurrencyIDR},
+		{name: "non-positive rate", amount: "10", from: "EUR", to: CurrencyIDR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rate, ok := rates.Convert(decimal.RequireFromString(tt.amount), tt.from, tt.to)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("amount = %s, want %s", got, tt.want)
+			}
+			if !rate.Equal(decimal.RequireFromString(tt.wantRate)) {
+				t.Errorf("rate = %s, want %s", rate, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestCurrencyFormat(t *testing.T) {
+	tests := []struct {
+		code   string
+		amount string
+		want   string
+	}{
+		{code: CurrencyIDR, amount: "1500000", want: "Rp1.500.000,00"},
+		{code: "USD", amount: "92.5", want: "US$92.50"},
+		{code: "JPY", amount: "1488.4", want: "¥1,488"},
+		{code: "XXX", amount: "1000", want: "Rp1.000,00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := MustCurrency(tt.code).Format(decimal.RequireFromString(tt.amount)); got != tt.want {
+				t.Errorf("Format() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitCommission(t *testing.T) {
+	tests := []struct {
+		name           string
+		amount         string
+		currency       string
+		wantNet        string
+		wantCommission string
+	}{
+		{name: "rupiah", amount: "150000", currency: CurrencyIDR, wantNet: "135000", wantCommission: "15000"},
+		{name: "commission rounded to cents", amount: "9.99", currency: "USD", wantNet: "8.99", wantCommission: "1"},
+		{name: "commission rounded to whole yen", amount: "1005", currency: "JPY", wantNet: "904", wantCommission: "101"},
+		{name: "nothing paid", amount: "0", currency: CurrencyIDR, wantNet: "0", wantCommission: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net, commission := SplitCommission(decimal.RequireFromString(tt.amount), tt.currency)
+			if !net.Equal(decimal.RequireFromString(tt.wantNet)) {
+				t.Errorf("net = %s, want %s", net, tt.wantNet)
+			}
+			if !commission.Equal(decimal.RequireFromString(tt.wantCommission)) {
+				t.Errorf("commission = %s, want %s", commission, tt.wantCommission)
+			}
+		})
+	}
+}
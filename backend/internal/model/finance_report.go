@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FinanceGranularityDay   = "day"
+	FinanceGranularityWeek  = "week"
+	FinanceGranularityMonth = "month"
+)
+
+var FinanceGranularities = []string{
+	FinanceGranularityDay,
+	FinanceGranularityWeek,
+	FinanceGranularityMonth,
+}
+
+// FinanceReportFilter selects the rows aggregated by a finance report. From
+// is inclusive and To exclusive. Tutor and category only narrow the figures
+// booked against mentors; payments of students are not tied to a mentor.
+type FinanceReportFilter struct {
+	From        time.Time
+	To          time.Time
+	Granularity string
+	TutorID     uuid.UUID
+	CategoryID  uuid.UUID
+}
+
+// ScopedToTutors tells whether the filter only keeps some mentors.
+func (f FinanceReportFilter) ScopedToTutors() bool {
+	return f.TutorID != uuid.Nil || f.CategoryID != uuid.Nil
+}
+
+// PeriodFormat is the MySQL DATE_FORMAT pattern of a period. It formats the
+// same keys as PeriodKey.
+func (f FinanceReportFilter) PeriodFormat() string {
+	switch f.Granularity {
+	case FinanceGranularityDay:
+		return "%Y-%m-%d"
+	case FinanceGranularityWeek:
+		return "%x-W%v"
+	default:
+		return "%Y-%m"
+	}
+}
+
+// PeriodKey returns the period t falls in, e.g. 2025-01-31, 2025-W05 or
+// 2025-01. Weeks are ISO weeks.
+func (f FinanceReportFilter) PeriodKey(t time.Time) string {
+	switch f.Granularity {
+	case FinanceGranularityDay:
+		return t.Format(time.DateOnly)
+	case FinanceGranularityWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	default:
+		return t.Format("2006-01")
+	}
+}
+
+// Periods lists every period between From and To in order.
+func (f FinanceReportFilter) Periods() []string {
+	var (
+		periods []string
+		seen    = map[string]bool{}
+	)
+
+	for t := f.From; t.Before(f.To); t = t.AddDate(0, 0, 1) {
+		key := f.PeriodKey(t)
+		if !seen[key] {
+			seen[key] = true
+			periods = append(periods, key)
+		}
+	}
+
+	return periods
+}
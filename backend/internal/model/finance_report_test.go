@@ -0,0 +1,103 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFinanceReportFilterPeriodKey(t *testing.T) {
+	at := time.Date(2027, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		granularity string
+		want        string
+	}{
+		{granularity: FinanceGranularityDay, want: "2027-01-02"},
+		// 2 January 2027 is a Saturday, still in the last ISO week of 2026
+		{granularity: FinanceGranularityWeek, want: "2026-W53"},
+		{granularity: FinanceGranularityMonth, want: "2027-01"},
+		{granularity: "", want: "2027-01"},
+	}
+
+	for _, tt := range tests {
+		filter := FinanceReportFilter{Granularity: tt.granularity}
+		if got := filter.PeriodKey(at); got != tt.want {
+			t.Errorf("PeriodKey(%q) = %q, want %q", tt.granularity, got, tt.want)
+		}
+	}
+}
+
+func TestFinanceReportFilterPeriods(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter FinanceReportFilter
+		want   []string
+	}{
+		{
+			name: "days, To excluded",
+			filter: FinanceReportFilter{
+				From:        time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+				Granularity: FinanceGranularityDay,
+			},
+			want: []string{"2026-02-27", "2026-02-28", "2026-03-01"},
+		},
+		{
+			name: "weeks across the new year",
+			filter: FinanceReportFilter{
+				From:        time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2027, 1, 12, 0, 0, 0, 0, time.UTC),
+				Granularity: FinanceGranularityWeek,
+			},
+			want: []string{"2026-W52", "2026-W53", "2027-W01", "2027-W02"},
+		},
+		{
+			name: "months",
+			filter: FinanceReportFilter{
+				From:        time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC),
+				Granularity: FinanceGranularityMonth,
+			},
+			want: []string{"2026-11", "2026-12", "2027-01"},
+		},
+		{
+			name: "empty range",
+			filter: FinanceReportFilter{
+				From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Periods(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Periods() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinanceReportFilterScopedToTutors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter FinanceReportFilter
+		want   bool
+	}{
+		{name: "every mentor", filter: FinanceReportFilter{}},
+		{name: "one mentor", filter: FinanceReportFilter{TutorID: uuid.New()}, want: true},
+		{name: "one category", filter: FinanceReportFilter{CategoryID: uuid.New()}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.ScopedToTutors(); got != tt.want {
+				t.Errorf("ScopedToTutors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+)
+
+const (
+	GiftCodeStatusPending  = "pending"
+	GiftCodeStatusActive   = "active"
+	GiftCodeStatusRedeemed = "redeemed"
+	GiftCodeStatusCanceled = "canceled"
+)
+
+// GiftCode is a premium period bought by a guardian that any student can
+// redeem. Codes become active once their payment is paid.
+type GiftCode struct {
+	ID         uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	Code       string        `gorm:"type:varchar(20);not null" json:"code"`
+	PaymentID  uuid.UUID     `gorm:"type:char(36);not null" json:"paymentId"`
+	GuardianID uuid.UUID     `gorm:"type:char(36);not null" json:"guardianId"`
+	Status     string        `gorm:"type:enum('pending','active','redeemed','canceled');default:'pending'" json:"status"`
+	RedeemedBy uuid.NullUUID `gorm:"type:char(36)" json:"redeemedBy"`
+	RedeemedAt null.Time     `json:"redeemedAt"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+
+	Payment Payment `gorm:"foreignKey:PaymentID" json:"-"`
+}
+
+func (GiftCode) TableName() string {
+	return "gift_codes"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (g *GiftCode) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+// GenerateCode sets a random code without the characters that are easily
+// confused when typed from a card.
+func (g *GiftCode) GenerateCode() {
+	code := RandomCode(12)
+	g.Code = "GIFT-" + code[:4] + "-" + code[4:8] + "-" + code[8:]
+}
+
+// RandomCode returns a random code of length without the characters that
+// are easily confused when typed.
+func RandomCode(length int) string {
+	const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	code := make([]byte, length)
+	for i := range code {
+		num, _ := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		code[i] = charset[num.Int64()]
+	}
+
+	return string(code)
+}
+
+type GiftCodeFilter struct {
+	GuardianID uuid.UUID
+	PaymentID  uuid.UUID
+	Status     string
+
+	Pagination
+}
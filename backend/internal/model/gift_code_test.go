@@ -0,0 +1,36 @@
+package model
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestGiftCodeGenerateCode(t *testing.T) {
+	pattern := regexp.MustCompile(`^GIFT-[A-HJ-NP-Z2-9]{4}-[A-HJ-NP-Z2-9]{4}-[A-HJ-NP-Z2-9]{4}$`)
+
+	seen := map[string]bool{}
+	for range 100 {
+		var code GiftCode
+		code.GenerateCode()
+
+		if !pattern.MatchString(code.Code) {
+			t.Fatalf("GenerateCode() = %q, want GIFT-XXXX-XXXX-XXXX without confusable characters", code.Code)
+		}
+		if seen[code.Code] {
+			t.Fatalf("GenerateCode() repeated %q", code.Code)
+		}
+		seen[code.Code] = true
+	}
+}
+
+func TestRandomCode(t *testing.T) {
+	for _, length := range []int{0, 1, 8, 32} {
+		code := RandomCode(length)
+		if len(code) != length {
+			t.Errorf("RandomCode(%d) has length %d", length, len(code))
+		}
+		if regexp.MustCompile(`[01IO]`).MatchString(code) {
+			t.Errorf("RandomCode(%d) = %q contains a confusable character", length, code)
+		}
+	}
+}
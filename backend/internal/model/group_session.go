@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+type GroupSessionStatus string
+
+const (
+	// An open session takes seats until its cutoff, it is confirmed as
+	// soon as enough students booked and cancelled at the cutoff otherwise.
+	GroupSessionStatusOpen      GroupSessionStatus = "open"
+	GroupSessionStatusConfirmed GroupSessionStatus = "confirmed"
+	GroupSessionStatusCancelled GroupSessionStatus = "cancelled"
+)
+
+// GroupSession is one occurrence of a group schedule of a course. Every
+// student taking part holds a seat, a booking linked to the session, which
+// counts while its status is one of BookedStatuses.
+type GroupSession struct {
+	ID           uuid.UUID          `gorm:"type:char(36);primaryKey" json:"id"`
+	CourseID     uuid.UUID          `gorm:"type:char(36);not null" json:"course_id"`
+	TutorID      uuid.UUID          `gorm:"type:char(36);not null" json:"tutor_id"`
+	ClassType    ClassType          `gorm:"type:varchar(20);not null" json:"class_type"`
+	SessionDate  time.Time          `gorm:"type:date;not null" json:"session_date"`
+	SessionTime  string             `gorm:"type:time;not null" json:"session_time"`
+	Timezone     string             `gorm:"type:varchar(50);not null" json:"timezone"`
+	Capacity     int                `gorm:"not null" json:"capacity"`
+	MinAttendees int                `gorm:"not null" json:"min_attendees"`
+	Status       GroupSessionStatus `gorm:"type:varchar(20);not null" json:"status"`
+	CutoffAt     time.Time          `json:"cutoff_at"`
+	ConfirmedAt  null.Time          `json:"confirmed_at"`
+	CancelledAt  null.Time          `json:"cancelled_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+
+	// SeatsTaken is only loaded when listing sessions
+	SeatsTaken int64 `gorm:"->;-:migration" json:"seats_taken"`
+
+	Course Course    `gorm:"foreignKey:CourseID" json:"course"`
+	Seats  []Booking `gorm:"foreignKey:GroupSessionID" json:"seats,omitempty"`
+}
+
+func (GroupSession) TableName() string {
+	return "group_sessions"
+}
+
+// StartsAt returns when the session starts, like Booking.StartsAt.
+func (g *GroupSession) StartsAt() time.Time {
+	booking := Booking{BookingDate: g.SessionDate, BookingTime: g.SessionTime}
+	return booking.StartsAt()
+}
+
+// IsBookable reports whether students can still take a seat. Confirmed
+// sessions keep taking seats until they start.
+func (g *GroupSession) IsBookable(now time.Time) bool {
+	switch g.Status {
+	case GroupSessionStatusOpen:
+		return now.Before(g.CutoffAt)
+	case GroupSessionStatusConfirmed:
+		return now.Before(g.StartsAt())
+	}
+	return false
+}
+
+type GroupSessionFilter struct {
+	TutorID  uuid.UUID
+	CourseID uuid.UUID
+	Status   GroupSessionStatus
+	DateFrom time.Time
+	DateTo   time.Time
+	Pagination
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestGroupSession_IsBookable(t *testing.T) {
+	session := func(status GroupSessionStatus) GroupSession {
+		return GroupSession{
+			SessionDate: time.Date(2026, 3, 14, 0, 0, 0, 0, time.Local),
+			SessionTime: "09:00:00",
+			Status:      status,
+			CutoffAt:    time.Date(2026, 3, 13, 9, 0, 0, 0, time.Local),
+		}
+	}
+	beforeCutoff := time.Date(2026, 3, 13, 8, 0, 0, 0, time.Local)
+	afterCutoff := time.Date(2026, 3, 13, 10, 0, 0, 0, time.Local)
+	afterStart := time.Date(2026, 3, 14, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		status GroupSessionStatus
+		now    time.Time
+		want   bool
+	}{
+		{name: "open before the cutoff", status: GroupSessionStatusOpen, now: beforeCutoff, want: true},
+		{name: "open after the cutoff", status: GroupSessionStatusOpen, now: afterCutoff},
+		{name: "confirmed after the cutoff", status: GroupSessionStatusConfirmed, now: afterCutoff, want: true},
+		{name: "confirmed once started", status: GroupSessionStatusConfirmed, now: afterStart},
+		{name: "cancelled", status: GroupSessionStatusCancelled, now: beforeCutoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := session(tt.status)
+			if got := s.IsBookable(tt.now); got != tt.want {
+				t.Errorf("IsBookable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBooking_GetStatusOfGroupSeat(t *testing.T) {
+	expiredAt := time.Now().Add(-time.Hour)
+
+	booking := Booking{Status: BookingStatusPending, ExpiredAt: expiredAt}
+	if got := booking.GetStatus(); got != BookingStatusExpired {
+		t.Errorf("GetStatus() = %s, want %s for a private booking", got, BookingStatusExpired)
+	}
+
+	seat := Booking{Status: BookingStatusPending, ExpiredAt: expiredAt, GroupSessionID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	if got := seat.GetStatus(); got != BookingStatusPending {
+		t.Errorf("GetStatus() = %s, want the seat pending until the session is decided", got)
+	}
+}
+
+func TestCourseSchedule_IsGroup(t *testing.T) {
+	if (CourseSchedule{}).IsGroup() || (CourseSchedule{SessionType: PrivateSessionType}).IsGroup() {
+		t.Errorf("IsGroup() = true, want false for private schedules")
+	}
+	if !(CourseSchedule{SessionType: GroupSessionType}).IsGroup() {
+		t.Errorf("IsGroup() = false, want true")
+	}
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+)
+
+const (
+	GuardianStudentStatusPending = "pending"
+	GuardianStudentStatusActive  = "active"
+)
+
+// Guardian is a parent or other payer who buys premium for the students
+// linked to them.
+type Guardian struct {
+	ID         uuid.UUID     `gorm:"type:char(36);primary_key" json:"id"`
+	UserID     uuid.UUID     `gorm:"type:char(36);not null" json:"user_id"`
+	User       User          `gorm:"foreignKey:UserID" json:"user"`
+	CustomerID null.String   `gorm:"type:varchar(255)" json:"customer_id"`
+	TaxID      null.String   `gorm:"type:varchar(20)" json:"tax_id"`
+	TaxName    null.String   `gorm:"type:varchar(255)" json:"tax_name"`
+	TaxAddress null.String   `gorm:"type:varchar(500)" json:"tax_address"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	DeletedAt  null.Time     `gorm:"index" json:"deleted_at"`
+	CreatedBy  uuid.NullUUID `gorm:"type:char(36)" json:"created_by"`
+	UpdatedBy  uuid.NullUUID `gorm:"type:char(36)" json:"updated_by"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (g *Guardian) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+// GuardianStudent links a guardian to a student. The link stays pending until
+// the student accepts it in the app, confirms the emailed link, or the
+// guardian enters an invite code of the student.
+type GuardianStudent struct {
+	ID                uuid.UUID   `gorm:"type:char(36);primaryKey" json:"id"`
+	GuardianID        uuid.UUID   `gorm:"type:char(36);not null" json:"guardianId"`
+	StudentID         uuid.UUID   `gorm:"type:char(36);not null" json:"studentId"`
+	Status            string      `gorm:"type:enum('pending','active');default:'pending'" json:"status"`
+	ConfirmationToken null.String `gorm:"type:varchar(64)" json:"-"`
+	TokenExpiresAt    null.Time   `json:"-"`
+	AcceptedAt        null.Time   `json:"acceptedAt"`
+	LastReportPeriod  null.String `gorm:"type:char(6)" json:"-"`
+	CreatedAt         time.Time   `json:"createdAt"`
+	UpdatedAt         time.Time   `json:"updatedAt"`
+
+	Guardian Guardian `gorm:"foreignKey:GuardianID" json:"guardian"`
+	Student  Student  `gorm:"foreignKey:StudentID" json:"student"`
+}
+
+func (GuardianStudent) TableName() string {
+	return "guardian_students"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (g *GuardianStudent) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+func (g *GuardianStudent) IsActive() bool {
+	return g.Status == GuardianStudentStatusActive
+}
+
+// Accept records the consent of the student.
+func (g *GuardianStudent) Accept(at time.Time) {
+	g.Status = GuardianStudentStatusActive
+	g.AcceptedAt = null.TimeFrom(at)
+	g.ConfirmationToken = null.String{}
+	g.TokenExpiresAt = null.Time{}
+	g.UpdatedAt = at
+}
+
+// GuardianInvite is a code a student shares with a guardian. Entering it
+// links the guardian without asking the student again.
+type GuardianInvite struct {
+	ID        uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID uuid.UUID     `gorm:"type:char(36);not null" json:"studentId"`
+	Code      string        `gorm:"type:varchar(20);not null" json:"code"`
+	ExpiresAt time.Time     `json:"expiresAt"`
+	UsedBy    uuid.NullUUID `gorm:"type:char(36)" json:"usedBy"`
+	UsedAt    null.Time     `json:"usedAt"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+func (GuardianInvite) TableName() string {
+	return "guardian_invites"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (g *GuardianInvite) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+func (g *GuardianInvite) IsUsable(now time.Time) bool {
+	return !g.UsedAt.Valid && now.Before(g.ExpiresAt)
+}
+
+type GuardianStudentFilter struct {
+	GuardianID uuid.UUID
+	StudentID  uuid.UUID
+	Status     string
+
+	// ReportPeriodNot keeps the links whose monthly report of the period was
+	// not emailed yet.
+	ReportPeriodNot string
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+func TestGuardianStudentAccept(t *testing.T) {
+	var (
+		now  = time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+		link = GuardianStudent{
+			Status:            GuardianStudentStatusPending,
+			ConfirmationToken: null.StringFrom("token"),
+			TokenExpiresAt:    null.TimeFrom(now.Add(24 * time.Hour)),
+		}
+	)
+
+	if link.IsActive() {
+		t.Fatal("IsActive() = true before the student consented")
+	}
+
+	link.Accept(now)
+
+	if !link.IsActive() {
+		t.Error("IsActive() = false after Accept()")
+	}
+	if !link.AcceptedAt.Valid || !link.AcceptedAt.Time.Equal(now) || !link.UpdatedAt.Equal(now) {
+		t.Errorf("Accept() times = (%v, %v), want %s", link.AcceptedAt, link.UpdatedAt, now)
+	}
+	if link.ConfirmationToken.Valid || link.TokenExpiresAt.Valid {
+		t.Error("Accept() kept the confirmation token, want it cleared so it can not be used again")
+	}
+}
+
+func TestGuardianInviteIsUsable(t *testing.T) {
+	now := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		invite GuardianInvite
+		want   bool
+	}{
+		{name: "unused", invite: GuardianInvite{ExpiresAt: now.Add(time.Hour)}, want: true},
+		{name: "expired", invite: GuardianInvite{ExpiresAt: now}},
+		{
+			name:   "used",
+			invite: GuardianInvite{ExpiresAt: now.Add(time.Hour), UsedBy: uuid.NullUUID{UUID: uuid.New(), Valid: true}, UsedAt: null.TimeFrom(now.Add(-time.Minute))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.invite.IsUsable(now); got != tt.want {
+				t.Errorf("IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
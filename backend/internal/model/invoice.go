@@ -0,0 +1,130 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// Invoice is the tax invoice issued once a payment is paid. Buyer, tax and
+// amounts are copied at issue time so the invoice never changes afterwards.
+type Invoice struct {
+	ID              uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	Number          string          `gorm:"type:varchar(50);not null" json:"number"`
+	Period          string          `gorm:"type:char(6);not null" json:"period"`
+	Sequence        int             `json:"sequence"`
+	PaymentID       uuid.UUID       `gorm:"type:char(36);not null" json:"paymentId"`
+	StudentID       uuid.NullUUID   `gorm:"type:char(36)" json:"studentId"`
+	GuardianID      uuid.NullUUID   `gorm:"type:char(36)" json:"guardianId"`
+	BuyerName       string          `json:"buyerName"`
+	BuyerEmail      string          `json:"buyerEmail"`
+	BuyerTaxID      null.String     `json:"buyerTaxId"`
+	BuyerAddress    null.String     `json:"buyerAddress"`
+	TaxRateID       uuid.UUID       `gorm:"type:char(36);not null" json:"taxRateId"`
+	Currency        string          `gorm:"type:char(3);not null;default:'IDR'" json:"currency"`
+	TaxName         string          `json:"taxName"`
+	TaxRate         decimal.Decimal `gorm:"type:decimal(7,4)" json:"taxRate"`
+	BaseNumerator   int             `json:"baseNumerator"`
+	BaseDenominator int             `json:"baseDenominator"`
+	Subtotal        decimal.Decimal `gorm:"type:decimal(12,2)" json:"subtotal"`
+	TaxBase         decimal.Decimal `gorm:"type:decimal(12,2)" json:"taxBase"`
+	TaxAmount       decimal.Decimal `gorm:"type:decimal(12,2)" json:"taxAmount"`
+	Total           decimal.Decimal `gorm:"type:decimal(12,2)" json:"total"`
+	FileKey         null.String     `json:"-"`
+	IssuedAt        time.Time       `json:"issuedAt"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+
+	Items []InvoiceItem `gorm:"foreignKey:InvoiceID" json:"items"`
+}
+
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (i *Invoice) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// SetBuyer copies the buyer of the payment: the guardian who paid for
+// family and gift payments, the student otherwise.
+func (i *Invoice) SetBuyer(payment Payment) {
+	if payment.GuardianID.Valid {
+		guardian := payment.Guardian
+		i.BuyerName = guardian.TaxName.ValueOr(guardian.User.Name)
+		i.BuyerEmail = guardian.User.Email
+		i.BuyerTaxID = guardian.TaxID
+		i.BuyerAddress = guardian.TaxAddress
+		return
+	}
+
+	student := payment.Student
+	i.BuyerName = student.TaxName.ValueOr(student.User.Name)
+	i.BuyerEmail = student.User.Email
+	i.BuyerTaxID = student.TaxID
+	i.BuyerAddress = student.TaxAddress
+}
+
+// SetSequence sets the sequence taken for the invoice period and the number
+// derived from it.
+func (i *Invoice) SetSequence(prefix string, sequence int) {
+	i.Sequence = sequence
+	i.Number = fmt.Sprintf("%s-%s-%05d", prefix, i.Period, sequence)
+}
+
+// TaxRateSnapshot returns the tax rate the invoice was issued with.
+func (i Invoice) TaxRateSnapshot() TaxRate {
+	return TaxRate{
+		ID:              i.TaxRateID,
+		Name:            i.TaxName,
+		Rate:            i.TaxRate,
+		BaseNumerator:   i.BaseNumerator,
+		BaseDenominator: i.BaseDenominator,
+	}
+}
+
+func InvoicePeriod(t time.Time) string {
+	return t.Format("200601")
+}
+
+type InvoiceItem struct {
+	ID          uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	InvoiceID   uuid.UUID       `gorm:"type:char(36);not null" json:"invoiceId"`
+	Description string          `json:"description"`
+	Quantity    int             `json:"quantity"`
+	UnitPrice   decimal.Decimal `gorm:"type:decimal(12,2)" json:"unitPrice"`
+	Amount      decimal.Decimal `gorm:"type:decimal(12,2)" json:"amount"`
+	TaxBase     decimal.Decimal `gorm:"type:decimal(12,2)" json:"taxBase"`
+	TaxAmount   decimal.Decimal `gorm:"type:decimal(12,2)" json:"taxAmount"`
+	PeriodStart null.Time       `json:"periodStart"`
+	PeriodEnd   null.Time       `json:"periodEnd"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+func (InvoiceItem) TableName() string {
+	return "invoice_items"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (i *InvoiceItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+type InvoiceFilter struct {
+	StudentID    uuid.UUID
+	Query        string
+	IssuedAtFrom time.Time
+	IssuedAtTo   time.Time
+	Pagination
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInvoiceSetSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		sequence int
+		want     string
+	}{
+		{
+			name:     "first invoice of the month",
+			issuedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			sequence: 1,
+			want:     "INV-202603-00001",
+		},
+		{
+			name:     "numbers are zero padded",
+			issuedAt: time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC),
+			sequence: 1234,
+			want:     "INV-202603-01234",
+		},
+		{
+			name:     "numbers restart in the next month",
+			issuedAt: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			sequence: 1,
+			want:     "INV-202604-00001",
+		},
+		{
+			name:     "numbers restart in the next year",
+			issuedAt: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			sequence: 1,
+			want:     "INV-202701-00001",
+		},
+		{
+			name:     "numbers past five digits keep growing",
+			issuedAt: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+			sequence: 123456,
+			want:     "INV-202612-123456",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := Invoice{Period: InvoicePeriod(tt.issuedAt)}
+			invoice.SetSequence("INV", tt.sequence)
+			if invoice.Number != tt.want {
+				t.Errorf("Number = %s, want %s", invoice.Number, tt.want)
+			}
+			if invoice.Sequence != tt.sequence {
+				t.Errorf("Sequence = %d, want %d", invoice.Sequence, tt.sequence)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+type LearningPlanStatus string
+
+const (
+	LearningPlanStatusActive   LearningPlanStatus = "active"
+	LearningPlanStatusArchived LearningPlanStatus = "archived"
+)
+
+type MilestoneStatus string
+
+const (
+	MilestoneStatusNotStarted MilestoneStatus = "not_started"
+	MilestoneStatusInProgress MilestoneStatus = "in_progress"
+	MilestoneStatusAchieved   MilestoneStatus = "achieved"
+)
+
+// LearningPlan holds the goals of a student in one subject with a tutor as
+// milestones, in the order the tutor set them.
+type LearningPlan struct {
+	ID               uuid.UUID          `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID        uuid.UUID          `gorm:"type:char(36);not null" json:"student_id"`
+	TutorID          uuid.UUID          `gorm:"type:char(36);not null" json:"tutor_id"`
+	CourseCategoryID uuid.UUID          `gorm:"type:char(36);not null" json:"course_category_id"`
+	Title            string             `gorm:"type:varchar(255);not null" json:"title"`
+	Description      null.String        `gorm:"type:text" json:"description"`
+	Status           LearningPlanStatus `gorm:"type:varchar(20);not null" json:"status"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+
+	Student        Student                 `gorm:"foreignKey:StudentID" json:"student"`
+	Tutor          Tutor                   `gorm:"foreignKey:TutorID" json:"tutor"`
+	CourseCategory CourseCategory          `gorm:"foreignKey:CourseCategoryID" json:"course_category"`
+	Milestones     []LearningPlanMilestone `gorm:"foreignKey:PlanID" json:"milestones"`
+}
+
+func (LearningPlan) TableName() string {
+	return "learning_plans"
+}
+
+// Milestone returns the milestone of the plan with the id, nil when the plan
+// has none.
+func (p *LearningPlan) Milestone(id uuid.UUID) *LearningPlanMilestone {
+	for i := range p.Milestones {
+		if p.Milestones[i].ID == id {
+			return &p.Milestones[i]
+		}
+	}
+	return nil
+}
+
+type LearningPlanMilestone struct {
+	ID          uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	PlanID      uuid.UUID       `gorm:"type:char(36);not null" json:"plan_id"`
+	Title       string          `gorm:"type:varchar(255);not null" json:"title"`
+	Description null.String     `gorm:"type:text" json:"description"`
+	TargetDate  null.Time       `gorm:"type:date" json:"target_date"`
+	Status      MilestoneStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Position    int             `gorm:"not null" json:"position"`
+	AchievedAt  null.Time       `json:"achieved_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+func (LearningPlanMilestone) TableName() string {
+	return "learning_plan_milestones"
+}
+
+// SetStatus moves the milestone to the status, stamping when it was
+// achieved.
+func (m *LearningPlanMilestone) SetStatus(status MilestoneStatus, now time.Time) {
+	if status == m.Status {
+		return
+	}
+
+	m.Status = status
+	m.AchievedAt = null.Time{}
+	if status == MilestoneStatusAchieved {
+		m.AchievedAt = null.TimeFrom(now)
+	}
+}
+
+// IsOverdue reports whether the target date of the milestone passed before
+// it was achieved.
+func (m *LearningPlanMilestone) IsOverdue(now time.Time) bool {
+	if !m.TargetDate.Valid || m.Status == MilestoneStatusAchieved {
+		return false
+	}
+
+	return m.TargetDate.Time.Format(time.DateOnly) < now.Format(time.DateOnly)
+}
+
+type LearningPlanFilter struct {
+	StudentID        uuid.UUID
+	TutorID          uuid.UUID
+	CourseCategoryID uuid.UUID
+	Status           LearningPlanStatus
+}
+
+// LessonNote is what the tutor wrote about one session, linked to the plan
+// and the milestones the session worked on.
+type LessonNote struct {
+	ID           uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	BookingID    uuid.UUID     `gorm:"type:char(36);not null" json:"booking_id"`
+	PlanID       uuid.NullUUID `gorm:"type:char(36)" json:"plan_id"`
+	TutorID      uuid.UUID     `gorm:"type:char(36);not null" json:"tutor_id"`
+	StudentID    uuid.UUID     `gorm:"type:char(36);not null" json:"student_id"`
+	Covered      string        `gorm:"type:text;not null" json:"covered"`
+	Strengths    null.String   `gorm:"type:text" json:"strengths"`
+	Improvements null.String   `gorm:"type:text" json:"improvements"`
+	NextSteps    null.String   `gorm:"type:text" json:"next_steps"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+
+	Booking    *Booking                `gorm:"foreignKey:BookingID" json:"booking,omitempty"`
+	Milestones []LearningPlanMilestone `gorm:"many2many:lesson_note_milestones;joinForeignKey:NoteID;joinReferences:MilestoneID" json:"milestones"`
+}
+
+func (LessonNote) TableName() string {
+	return "lesson_notes"
+}
+
+type LessonNoteFilter struct {
+	PlanID    uuid.UUID
+	StudentID uuid.UUID
+	Pagination
+}
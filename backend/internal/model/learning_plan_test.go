@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+func TestLearningPlanMilestone_SetStatus(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	milestone := LearningPlanMilestone{Status: MilestoneStatusNotStarted}
+
+	milestone.SetStatus(MilestoneStatusInProgress, now)
+	if milestone.Status != MilestoneStatusInProgress || milestone.AchievedAt.Valid {
+		t.Errorf("SetStatus(in_progress) = %+v, want in progress without achievement", milestone)
+	}
+
+	milestone.SetStatus(MilestoneStatusAchieved, now)
+	if milestone.Status != MilestoneStatusAchieved || milestone.AchievedAt.Time != now {
+		t.Errorf("SetStatus(achieved) = %+v, want achieved at %v", milestone, now)
+	}
+
+	// Saving an achieved milestone again keeps when it was achieved
+	milestone.SetStatus(MilestoneStatusAchieved, now.Add(24*time.Hour))
+	if milestone.AchievedAt.Time != now {
+		t.Errorf("AchievedAt = %v, want %v kept", milestone.AchievedAt, now)
+	}
+
+	milestone.SetStatus(MilestoneStatusInProgress, now)
+	if milestone.AchievedAt.Valid {
+		t.Errorf("AchievedAt = %v, want it cleared once reopened", milestone.AchievedAt)
+	}
+}
+
+func TestLearningPlanMilestone_IsOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 23, 0, 0, 0, time.Local)
+	date := func(day int) null.Time { return null.TimeFrom(time.Date(2026, 3, day, 0, 0, 0, 0, time.Local)) }
+
+	tests := []struct {
+		name      string
+		milestone LearningPlanMilestone
+		want      bool
+	}{
+		{name: "no target date", milestone: LearningPlanMilestone{Status: MilestoneStatusInProgress}},
+		{name: "due later", milestone: LearningPlanMilestone{Status: MilestoneStatusInProgress, TargetDate: date(11)}},
+		{name: "due today", milestone: LearningPlanMilestone{Status: MilestoneStatusInProgress, TargetDate: date(10)}},
+		{name: "due yesterday", milestone: LearningPlanMilestone{Status: MilestoneStatusNotStarted, TargetDate: date(9)}, want: true},
+		{name: "achieved late", milestone: LearningPlanMilestone{Status: MilestoneStatusAchieved, TargetDate: date(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.milestone.IsOverdue(now); got != tt.want {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLearningPlan_Milestone(t *testing.T) {
+	id := uuid.New()
+	plan := LearningPlan{Milestones: []LearningPlanMilestone{{ID: uuid.New()}, {ID: id, Title: "Persamaan kuadrat"}}}
+
+	milestone := plan.Milestone(id)
+	if milestone == nil || milestone.Title != "Persamaan kuadrat" {
+		t.Fatalf("Milestone() = %v, want the milestone with the id", milestone)
+	}
+
+	// The milestone is the one of the plan, not a copy
+	milestone.Title = "Fungsi kuadrat"
+	if plan.Milestones[1].Title != "Fungsi kuadrat" {
+		t.Errorf("Milestone() returned a copy, want the milestone of the plan")
+	}
+
+	if got := plan.Milestone(uuid.New()); got != nil {
+		t.Errorf("Milestone() = %v, want nil for a milestone of another plan", got)
+	}
+}
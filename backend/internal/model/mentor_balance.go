@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"github.com/shopspring/decimal"
 )
 
@@ -11,6 +12,7 @@ type MentorBalance struct {
 	ID        uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
 	TutorID   uuid.UUID       `gorm:"type:char(36);not null;uniqueIndex" json:"tutor_id"`
 	Balance   decimal.Decimal `gorm:"type:decimal(15,2);default:0" json:"balance"`
+	Currency  string          `gorm:"type:char(3);not null;default:'IDR'" json:"currency"`
 	UpdatedAt time.Time       `json:"updated_at"`
 
 	Tutor Tutor `gorm:"foreignKey:TutorID" json:"tutor"`
@@ -27,6 +29,13 @@ const (
 	BalanceTransactionDebit  BalanceTransactionType = "debit"
 )
 
+const (
+	BalanceReferenceBookingPayment = "booking_payment"
+	BalanceReferenceBookingSession = "booking_session"
+	BalanceReferenceWithdrawal     = "withdrawal"
+	BalanceReferenceRefund         = "refund"
+)
+
 type BalanceTransaction struct {
 	ID            uuid.UUID              `gorm:"type:char(36);primaryKey" json:"id"`
 	TutorID       uuid.UUID              `gorm:"type:char(36);not null;index" json:"tutor_id"`
@@ -38,9 +47,66 @@ type BalanceTransaction struct {
 	Description   string                 `gorm:"type:varchar(255)" json:"description"`
 	CreatedAt     time.Time              `json:"created_at"`
 
+	// Currency is the currency of the mentor balance. Amounts paid in
+	// another currency keep what was paid and the rate converting it.
+	Currency         string              `gorm:"type:char(3);not null;default:'IDR'" json:"currency"`
+	OriginalAmount   decimal.NullDecimal `gorm:"type:decimal(15,2)" json:"original_amount"`
+	OriginalCurrency null.String         `gorm:"type:char(3)" json:"original_currency"`
+	ExchangeRate     decimal.NullDecimal `gorm:"type:decimal(20,10)" json:"exchange_rate"`
+
 	Tutor Tutor `gorm:"foreignKey:TutorID" json:"tutor"`
 }
 
 func (BalanceTransaction) TableName() string {
 	return "balance_transactions"
 }
+
+// CommissionRate is the share of a payment the platform keeps.
+var CommissionRate = decimal.NewFromFloat(0.10)
+
+// SplitCommission splits an amount credited to a mentor into what the mentor
+// keeps and the commission, rounded to the minor unit of the currency.
+func SplitCommission(amount decimal.Decimal, currency string) (decimal.Decimal, decimal.Decimal) {
+	commission := MustCurrency(currency).Round(amount.Mul(CommissionRate))
+	return amount.Sub(commission), commission
+}
+
+// ReverseCredits returns the debits taking back the given share of the
+// credits, the commission included, recorded against the refund.
+func ReverseCredits(credits []BalanceTransaction, ratio decimal.Decimal, refundID uuid.UUID) []BalanceTransaction {
+	var debits []BalanceTransaction
+	for _, credit := range credits {
+		if credit.Type != BalanceTransactionCredit {
+			continue
+		}
+
+		currency := MustCurrency(credit.Currency)
+		debit := BalanceTransaction{
+			ID:            uuid.New(),
+			TutorID:       credit.TutorID,
+			Type:          BalanceTransactionDebit,
+			Amount:        currency.Round(credit.Amount.Mul(ratio)),
+			Commission:    currency.Round(credit.Commission.Mul(ratio)),
+			ReferenceType: BalanceReferenceRefund,
+			ReferenceID:   refundID,
+			Description:   "Refund of booking payment",
+			Currency:      credit.Currency,
+			ExchangeRate:  credit.ExchangeRate,
+		}
+		if credit.OriginalAmount.Valid {
+			debit.OriginalAmount = decimal.NewNullDecimal(MustCurrency(credit.OriginalCurrency.String).Round(credit.OriginalAmount.Decimal.Mul(ratio)))
+			debit.OriginalCurrency = credit.OriginalCurrency
+		}
+
+		debits = append(debits, debit)
+	}
+
+	return debits
+}
+
+type BalanceTransactionStats struct {
+	TotalCredit     decimal.Decimal
+	TotalDebit      decimal.Decimal
+	TotalCommission decimal.Decimal
+	TotalCount      int64
+}
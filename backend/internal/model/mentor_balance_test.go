@@ -0,0 +1,111 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+func TestReverseCredits(t *testing.T) {
+	var (
+		tutorID  = uuid.New()
+		refundID = uuid.New()
+	)
+
+	tests := []struct {
+		name           string
+		credits        []BalanceTransaction
+		ratio          string
+		wantAmount     []string
+		wantCommission []string
+		wantOriginal   []string
+	}{
+		{
+			name: "full refund reverses the whole credit",
+			credits: []BalanceTransaction{
+				{TutorID: tutorID, Type: BalanceTransactionCredit, Amount: decimal.NewFromInt(90000), Commission: decimal.NewFromInt(10000), Currency: CurrencyIDR},
+			},
+			ratio:          "1",
+			wantAmount:     []string{"90000"},
+			wantCommission: []string{"10000"},
+			wantOriginal:   []string{""},
+		},
+		{
+			name: "partial refund reverses its share",
+			credits: []BalanceTransaction{
+				{TutorID: tutorID, Type: BalanceTransactionCredit, Amount: decimal.NewFromInt(90000), Commission: decimal.NewFromInt(10000), Currency: CurrencyIDR},
+			},
+			ratio:          "0.25",
+			wantAmount:     []string{"22500"},
+			wantCommission: []string{"2500"},
+			wantOriginal:   []string{""},
+		},
+		{
+			name: "debits are not reversed",
+			credits: []BalanceTransaction{
+				{TutorID: tutorID, Type: BalanceTransactionDebit, Amount: decimal.NewFromInt(50000), Currency: CurrencyIDR},
+				{TutorID: tutorID, Type: BalanceTransactionCredit, Amount: decimal.NewFromInt(90000), Commission: decimal.NewFromInt(10000), Currency: CurrencyIDR},
+			},
+			ratio:          "0.5",
+			wantAmount:     []string{"45000"},
+			wantCommission: []string{"5000"},
+			wantOriginal:   []string{""},
+		},
+		{
+			name: "converted credit reverses the original amount",
+			credits: []BalanceTransaction{
+				{
+					TutorID:          tutorID,
+					Type:             BalanceTransactionCredit,
+					Amount:           decimal.RequireFromString("9.00"),
+					Commission:       decimal.RequireFromString("1.00"),
+					Currency:         "USD",
+					OriginalAmount:   decimal.NewNullDecimal(decimal.NewFromInt(160000)),
+					OriginalCurrency: null.StringFrom(CurrencyIDR),
+					ExchangeRate:     decimal.NewNullDecimal(decimal.RequireFromString("0.0000625")),
+				},
+			},
+			ratio:          "0.333",
+			wantAmount:     []string{"3.00"},
+			wantCommission: []string{"0.33"},
+			wantOriginal:   []string{"53280"},
+		},
+		{
+			name:  "nothing credited",
+			ratio: "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debits := ReverseCredits(tt.credits, decimal.RequireFromString(tt.ratio), refundID)
+			if len(debits) != len(tt.wantAmount) {
+				t.Fatalf("got %d debits, want %d", len(debits), len(tt.wantAmount))
+			}
+
+			for i, debit := range debits {
+				if debit.Type != BalanceTransactionDebit || debit.ReferenceType != BalanceReferenceRefund || debit.ReferenceID != refundID {
+					t.Errorf("debit %d is %s of %s %s, want debit of the refund", i, debit.Type, debit.ReferenceType, debit.ReferenceID)
+				}
+				if debit.TutorID != tutorID {
+					t.Errorf("debit %d tutor = %s, want %s", i, debit.TutorID, tutorID)
+				}
+				if !debit.Amount.Equal(decimal.RequireFromString(tt.wantAmount[i])) {
+					t.Errorf("debit %d amount = %s, want %s", i, debit.Amount, tt.wantAmount[i])
+				}
+				if !debit.Commission.Equal(decimal.RequireFromString(tt.wantCommission[i])) {
+					t.Errorf("debit %d commission = %s, want %s", i, debit.Commission, tt.wantCommission[i])
+				}
+				if tt.wantOriginal[i] == "" {
+					if debit.OriginalAmount.Valid {
+						t.Errorf("debit %d original amount = %s, want none", i, debit.OriginalAmount.Decimal)
+					}
+				} else if !debit.OriginalAmount.Decimal.Equal(decimal.RequireFromString(tt.wantOriginal[i])) {
+					t.Errorf("debit %d original amount = %s, want %s", i, debit.OriginalAmount.Decimal, tt.wantOriginal[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// Bank is a payout destination supported by the disbursement provider.
+type Bank struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Banks lists the Xendit disbursement bank codes mentors can be paid to.
+var Banks = []Bank{
+	{Code: "BCA", Name: "Bank Central Asia (BCA)"},
+	{Code: "BNI", Name: "Bank Negara Indonesia (BNI)"},
+	{Code: "BRI", Name: "Bank Rakyat Indonesia (BRI)"},
+	{Code: "MANDIRI", Name: "Bank Mandiri"},
+	{Code: "BSI", Name: "Bank Syariah Indonesia (BSI)"},
+	{Code: "BTN", Name: "Bank Tabungan Negara (BTN)"},
+	{Code: "CIMB", Name: "Bank CIMB Niaga"},
+	{Code: "DANAMON", Name: "Bank Danamon"},
+	{Code: "PERMATA", Name: "Bank Permata"},
+	{Code: "MAYBANK", Name: "Bank Maybank Indonesia"},
+	{Code: "OCBC", Name: "Bank OCBC NISP"},
+	{Code: "PANIN", Name: "Bank Panin"},
+	{Code: "MEGA", Name: "Bank Mega"},
+	{Code: "BJB", Name: "Bank BJB"},
+	{Code: "DKI", Name: "Bank DKI"},
+	{Code: "JAGO", Name: "Bank Jago"},
+	{Code: "SEABANK", Name: "SeaBank Indonesia"},
+}
+
+// BankByCode looks a bank up in Banks.
+func BankByCode(code string) (Bank, bool) {
+	i := slices.IndexFunc(Banks, func(bank Bank) bool {
+		return bank.Code == code
+	})
+	if i < 0 {
+		return Bank{}, false
+	}
+
+	return Banks[i], true
+}
+
+type MentorBankAccount struct {
+	ID            uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID       uuid.UUID     `gorm:"type:char(36);not null;index" json:"tutor_id"`
+	BankCode      string        `gorm:"type:varchar(50);not null" json:"bank_code"`
+	AccountNumber string        `gorm:"type:varchar(50);not null" json:"account_number"`
+	AccountName   string        `gorm:"type:varchar(100);not null" json:"account_name"`
+	IsDefault     bool          `json:"is_default"`
+	VerifiedAt    *time.Time    `json:"verified_at"`
+	VerifiedBy    uuid.NullUUID `gorm:"type:char(36)" json:"verified_by"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+
+	Tutor Tutor `gorm:"foreignKey:TutorID" json:"tutor"`
+}
+
+func (MentorBankAccount) TableName() string {
+	return "mentor_bank_accounts"
+}
+
+func (a MentorBankAccount) Verified() bool {
+	return a.VerifiedAt != nil
+}
+
+// BankName is the display name of the bank code, or the code itself when it
+// is no longer in Banks.
+func (a MentorBankAccount) BankName() string {
+	bank, ok := BankByCode(a.BankCode)
+	if !ok {
+		return a.BankCode
+	}
+
+	return bank.Name
+}
+
+type MentorBankAccountFilter struct {
+	TutorID  uuid.UUID
+	Verified null.Bool
+	Pagination
+}
@@ -0,0 +1,23 @@
+package model
+
+import "testing"
+
+func TestMentorBankAccountBankName(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "BCA", want: "Bank Central Asia (BCA)"},
+		{code: "SEABANK", want: "SeaBank Indonesia"},
+		// Codes dropped from Banks are still shown on existing accounts
+		{code: "BUKOPIN", want: "BUKOPIN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := (MentorBankAccount{BankCode: tt.code}).BankName(); got != tt.want {
+				t.Errorf("BankName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
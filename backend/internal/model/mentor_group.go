@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// MentorGroup is a class a tutor runs for some of their mentored students,
+// e.g. "Kelas 9 Matematika Sabtu".
+type MentorGroup struct {
+	ID          uuid.UUID   `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID     uuid.UUID   `gorm:"type:char(36);not null" json:"tutor_id"`
+	Name        string      `gorm:"type:varchar(100);not null" json:"name"`
+	Description null.String `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+
+	// MemberCount is only loaded when listing groups
+	MemberCount int64 `gorm:"->;-:migration" json:"member_count"`
+
+	Tutor Tutor `gorm:"foreignKey:TutorID" json:"tutor"`
+}
+
+func (MentorGroup) TableName() string {
+	return "mentor_groups"
+}
+
+type MentorGroupFilter struct {
+	TutorID uuid.UUID
+	Query   string
+	Pagination
+}
+
+type MentorGroupMember struct {
+	ID        uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	GroupID   uuid.UUID `gorm:"type:char(36);not null" json:"group_id"`
+	StudentID uuid.UUID `gorm:"type:char(36);not null" json:"student_id"`
+	JoinedAt  time.Time `json:"joined_at"`
+
+	Student Student `gorm:"foreignKey:StudentID" json:"student"`
+}
+
+func (MentorGroupMember) TableName() string {
+	return "mentor_group_members"
+}
+
+// MentorGroupAnnouncement is a message a tutor broadcast to the members of a
+// group, Recipients being how many were notified.
+type MentorGroupAnnouncement struct {
+	ID         uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	GroupID    uuid.UUID `gorm:"type:char(36);not null" json:"group_id"`
+	TutorID    uuid.UUID `gorm:"type:char(36);not null" json:"tutor_id"`
+	Title      string    `gorm:"type:varchar(255);not null" json:"title"`
+	Message    string    `gorm:"type:text;not null" json:"message"`
+	Recipients int       `gorm:"not null;default:0" json:"recipients"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (MentorGroupAnnouncement) TableName() string {
+	return "mentor_group_announcements"
+}
@@ -4,17 +4,39 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 )
 
+// MentorInviteCode lets students join a tutor, into one of the tutor's
+// groups when GroupID is set. Group codes can expire or run out of uses.
 type MentorInviteCode struct {
-	ID        uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
-	TutorID   uuid.UUID `gorm:"type:char(36);not null;uniqueIndex" json:"tutor_id"`
-	Code      string    `gorm:"type:varchar(50);not null;uniqueIndex" json:"code"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID   uuid.UUID     `gorm:"type:char(36);not null;index" json:"tutor_id"`
+	GroupID   uuid.NullUUID `gorm:"type:char(36);index" json:"group_id"`
+	Code      string        `gorm:"type:varchar(50);not null;uniqueIndex" json:"code"`
+	ExpiresAt null.Time     `json:"expires_at"`
+	MaxUses   null.Int      `json:"max_uses"`
+	UsedCount int           `gorm:"not null;default:0" json:"used_count"`
+	RevokedAt null.Time     `json:"revoked_at"`
+	CreatedAt time.Time     `json:"created_at"`
 
-	Tutor Tutor `gorm:"foreignKey:TutorID" json:"tutor"`
+	Tutor Tutor        `gorm:"foreignKey:TutorID" json:"tutor"`
+	Group *MentorGroup `gorm:"foreignKey:GroupID" json:"group,omitempty"`
 }
 
 func (MentorInviteCode) TableName() string {
 	return "mentor_invite_codes"
 }
+
+// IsUsable reports whether students can still join with the code.
+func (c *MentorInviteCode) IsUsable(now time.Time) bool {
+	if c.RevokedAt.Valid {
+		return false
+	}
+
+	if c.ExpiresAt.Valid && !now.Before(c.ExpiresAt.Time) {
+		return false
+	}
+
+	return !c.MaxUses.Valid || int64(c.UsedCount) < c.MaxUses.Int64
+}
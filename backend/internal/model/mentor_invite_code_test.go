@@ -0,0 +1,33 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+func TestMentorInviteCode_IsUsable(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		code MentorInviteCode
+		want bool
+	}{
+		{name: "unlimited", code: MentorInviteCode{UsedCount: 250}, want: true},
+		{name: "not expired yet", code: MentorInviteCode{ExpiresAt: null.TimeFrom(now.Add(time.Minute))}, want: true},
+		{name: "expiring right now", code: MentorInviteCode{ExpiresAt: null.TimeFrom(now)}},
+		{name: "uses left", code: MentorInviteCode{MaxUses: null.IntFrom(30), UsedCount: 29}, want: true},
+		{name: "ran out of uses", code: MentorInviteCode{MaxUses: null.IntFrom(30), UsedCount: 30}},
+		{name: "revoked", code: MentorInviteCode{RevokedAt: null.TimeFrom(now.Add(-time.Hour))}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.code.IsUsable(now); got != tt.want {
+				t.Errorf("IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+)
+
+const (
+	MonthlyReportStatusQueued     = "queued"
+	MonthlyReportStatusProcessing = "processing"
+	MonthlyReportStatusCompleted  = "completed"
+	MonthlyReportStatusFailed     = "failed"
+)
+
+// MonthlyReport is the progress report of a student for one month. Reports
+// are generated by a worker and stored in the bucket under FileKey.
+type MonthlyReport struct {
+	ID          uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID   uuid.UUID     `gorm:"type:char(36);not null" json:"studentId"`
+	Period      string        `gorm:"type:char(6);not null" json:"period"`
+	Status      string        `gorm:"type:enum('queued','processing','completed','failed');default:'queued'" json:"status"`
+	FileKey     null.String   `gorm:"type:varchar(255)" json:"-"`
+	Filename    null.String   `gorm:"type:varchar(255)" json:"filename"`
+	Error       null.String   `gorm:"type:text" json:"-"`
+	Deliver     bool          `json:"-"` // email the report once generated
+	RequestedBy uuid.NullUUID `gorm:"type:char(36)" json:"requestedBy"`
+	GeneratedAt null.Time     `json:"generatedAt"`
+	DeliveredAt null.Time     `json:"deliveredAt"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+
+	Student Student `gorm:"foreignKey:StudentID" json:"-"`
+}
+
+func (MonthlyReport) TableName() string {
+	return "monthly_reports"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (m *MonthlyReport) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// MonthlyReportPeriod returns the period of the month, e.g. 202601.
+func MonthlyReportPeriod(month, year int) string {
+	return fmt.Sprintf("%04d%02d", year, month)
+}
+
+// Start returns the first moment of the period.
+func (m *MonthlyReport) Start() time.Time {
+	start, _ := time.ParseInLocation("200601", m.Period, time.Local)
+	return start
+}
+
+// IsFinal tells whether the report was generated after its month ended, so
+// it will not change anymore.
+func (m *MonthlyReport) IsFinal() bool {
+	return m.Status == MonthlyReportStatusCompleted &&
+		m.GeneratedAt.Valid &&
+		!m.GeneratedAt.Time.Before(m.Start().AddDate(0, 1, 0))
+}
+
+type MonthlyReportFilter struct {
+	StudentID     uuid.UUID
+	StatusIn      []string
+	UpdatedBefore time.Time
+	Pagination
+}
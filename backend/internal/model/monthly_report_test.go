@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+func TestMonthlyReportPeriod(t *testing.T) {
+	if got := MonthlyReportPeriod(1, 2026); got != "202601" {
+		t.Errorf("MonthlyReportPeriod() = %s, want 202601", got)
+	}
+	if got := MonthlyReportPeriod(12, 2025); got != "202512" {
+		t.Errorf("MonthlyReportPeriod() = %s, want 202512", got)
+	}
+}
+
+func TestMonthlyReport_Start(t *testing.T) {
+	report := MonthlyReport{Period: "202602"}
+	want := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	if got := report.Start(); !got.Equal(want) {
+		t.Errorf("Start() = %v, want %v", got, want)
+	}
+}
+
+func TestMonthlyReport_IsFinal(t *testing.T) {
+	monthEnd := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		report MonthlyReport
+		want   bool
+	}{
+		{
+			name:   "generated after the month ended",
+			report: MonthlyReport{Period: "202602", Status: MonthlyReportStatusCompleted, GeneratedAt: null.TimeFrom(monthEnd.Add(time.Hour))},
+			want:   true,
+		},
+		{
+			name:   "generated right when the month ended",
+			report: MonthlyReport{Period: "202602", Status: MonthlyReportStatusCompleted, GeneratedAt: null.TimeFrom(monthEnd)},
+			want:   true,
+		},
+		{
+			name:   "generated during the month",
+			report: MonthlyReport{Period: "202602", Status: MonthlyReportStatusCompleted, GeneratedAt: null.TimeFrom(monthEnd.Add(-time.Hour))},
+			want:   false,
+		},
+		{
+			name:   "not generated yet",
+			report: MonthlyReport{Period: "202602", Status: MonthlyReportStatusProcessing},
+			want:   false,
+		},
+		{
+			name:   "failed",
+			report: MonthlyReport{Period: "202602", Status: MonthlyReportStatusFailed, GeneratedAt: null.TimeFrom(monthEnd.Add(time.Hour))},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.report.IsFinal(); got != tt.want {
+				t.Errorf("IsFinal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,16 +11,25 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const (
+	PaymentTypePremium = "premium"
+	PaymentTypeGift    = "gift"
+)
+
 type Payment struct {
 	ID            uuid.UUID
 	ReferenceID   string
-	StudentID     uuid.UUID
+	StudentID     uuid.NullUUID
+	GuardianID    uuid.NullUUID
 	InvoiceNumber string
+	Type          string `gorm:"type:varchar(20);not null;default:'premium'"`
 	Interval      SubscriptionInterval
 	IntervalCount int
+	Seats         int `gorm:"not null;default:1"`
 	StartDate     time.Time
 	EndDate       time.Time
 	Amount        decimal.Decimal
+	Currency      string `gorm:"type:char(3);not null;default:'IDR'"`
 	PaidAt        null.Time
 	URL           string
 	Status        SubscriptionStatus
@@ -31,21 +40,79 @@ type Payment struct {
 	UpdatedBy     uuid.UUID
 	DeletedBy     uuid.NullUUID
 
-	Student Student `gorm:"foreignKey:StudentID"`
+	Student  Student  `gorm:"foreignKey:StudentID"`
+	Guardian Guardian `gorm:"foreignKey:GuardianID"`
+
+	// Beneficiaries are the students covered by a family payment.
+	Beneficiaries []PaymentBeneficiary `gorm:"foreignKey:PaymentID"`
 
 	TutorID uuid.UUID `gorm:"type:char(36)"`
 	Tutor   Tutor     `gorm:"foreignKey:TutorID"`
+
+	// PaymentRequestID is the Xendit payment captured by the session. Refunds
+	// are issued against it.
+	PaymentRequestID null.String
+
+	// TaxAmount is the tax charged at creation. Payments created before tax
+	// rates were configurable have none and were taxed 11%.
+	TaxAmount decimal.NullDecimal
+}
+
+// Total returns the amount charged to the student, VAT included.
+func (p *Payment) Total() decimal.Decimal {
+	return p.Amount.Add(p.VatAmount())
+}
+
+// ChargedTotal returns the total Xendit charged, in whole rupiah. Refunds are
+// capped at it and reverse their share of it.
+func (p *Payment) ChargedTotal() decimal.Decimal {
+	return p.Total().Floor()
 }
 
 func (p *Payment) Name() string {
+	var name string
 	switch p.Interval {
 	case SubscriptionIntervalMonthly:
-		return "Premium Bulanan"
+		name = "Premium Bulanan"
 	case SubscriptionIntervalYearly:
-		return "Premium Tahunan"
+		name = "Premium Tahunan"
 	default:
 		return ""
 	}
+
+	switch {
+	case p.Type == PaymentTypeGift:
+		return fmt.Sprintf("Hadiah %s (%d kode)", name, p.Seats)
+	case p.GuardianID.Valid:
+		return fmt.Sprintf("%s Keluarga (%d siswa)", name, p.Seats)
+	default:
+		return name
+	}
+}
+
+// Payer returns the user who pays: the guardian for family and gift
+// payments, the student otherwise.
+func (p *Payment) Payer() User {
+	if p.GuardianID.Valid {
+		return p.Guardian.User
+	}
+
+	return p.Student.User
+}
+
+// Recipients returns the students who get premium once the payment is paid.
+// Gift payments have none until their codes are redeemed.
+func (p *Payment) Recipients() []Student {
+	if p.StudentID.Valid {
+		return []Student{p.Student}
+	}
+
+	students := make([]Student, 0, len(p.Beneficiaries))
+	for _, beneficiary := range p.Beneficiaries {
+		students = append(students, beneficiary.Student)
+	}
+
+	return students
 }
 
 func (p *Payment) GenerateInvoiceNumber() {
@@ -62,9 +129,25 @@ func (p *Payment) GenerateInvoiceNumber() {
 }
 
 func (p *Payment) VatAmount() decimal.Decimal {
+	if p.TaxAmount.Valid {
+		return p.TaxAmount.Decimal
+	}
+
 	return p.Amount.Mul(decimal.NewFromFloat(0.11))
 }
 
+// PremiumAfterRefund returns the premium end shortened by the refunded share
+// of the paid period, never before now.
+func (p *Payment) PremiumAfterRefund(premiumUntil time.Time, ratio decimal.Decimal, now time.Time) time.Time {
+	period := decimal.NewFromInt(int64(p.EndDate.Sub(p.StartDate))).Mul(ratio)
+	premiumUntil = premiumUntil.Add(-time.Duration(period.IntPart()))
+	if premiumUntil.Before(now) {
+		return now
+	}
+
+	return premiumUntil
+}
+
 func (p *Payment) StatusLabel() SubscriptionStatus {
 	if time.Now().After(p.EndDate) {
 		return SubscriptionStatusInActive
@@ -74,9 +157,22 @@ func (p *Payment) StatusLabel() SubscriptionStatus {
 }
 
 type PaymentFilter struct {
-	StudentID uuid.UUID
-	StatusIn  []string
+	StudentID  uuid.UUID
+	GuardianID uuid.UUID
+	StatusIn   []string
 
 	Pagination
 	Sort
 }
+
+// PaymentBeneficiary is a student covered by a family payment.
+type PaymentBeneficiary struct {
+	PaymentID uuid.UUID `gorm:"type:char(36);primaryKey"`
+	StudentID uuid.UUID `gorm:"type:char(36);primaryKey"`
+
+	Student Student `gorm:"foreignKey:StudentID"`
+}
+
+func (PaymentBeneficiary) TableName() string {
+	return "payment_beneficiaries"
+}
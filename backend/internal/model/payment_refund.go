@@ -0,0 +1,139 @@
+package model
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed"
+)
+
+// Refund reasons accepted by Xendit.
+const (
+	RefundReasonRequestedByCustomer = "REQUESTED_BY_CUSTOMER"
+	RefundReasonCancellation        = "CANCELLATION"
+	RefundReasonDuplicate           = "DUPLICATE"
+	RefundReasonFraudulent          = "FRAUDULENT"
+	RefundReasonOthers              = "OTHERS"
+)
+
+var RefundReasons = []string{
+	RefundReasonRequestedByCustomer,
+	RefundReasonCancellation,
+	RefundReasonDuplicate,
+	RefundReasonFraudulent,
+	RefundReasonOthers,
+}
+
+func IsValidRefundReason(reason string) bool {
+	return slices.Contains(RefundReasons, reason)
+}
+
+// PaymentRefund is a full or partial refund of a payment. Each refund has its
+// own credit note.
+type PaymentRefund struct {
+	ID                 uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	PaymentID          uuid.UUID       `gorm:"type:char(36);not null" json:"paymentId"`
+	ReferenceID        string          `gorm:"type:varchar(255);not null" json:"referenceId"`
+	CreditNoteNumber   string          `gorm:"type:varchar(255);not null" json:"creditNoteNumber"`
+	Amount             decimal.Decimal `gorm:"type:decimal(12,2);not null" json:"amount"`
+	Reason             string          `gorm:"type:varchar(50);not null" json:"reason"`
+	Note               null.String     `json:"note"`
+	Status             RefundStatus    `gorm:"type:varchar(50);not null" json:"status"`
+	FailureCode        null.String     `json:"failureCode"`
+	BalanceReversed    decimal.Decimal `gorm:"type:decimal(15,2)" json:"balanceReversed"`
+	CommissionReversed decimal.Decimal `gorm:"type:decimal(15,2)" json:"commissionReversed"`
+	PremiumUntilBefore null.Time       `json:"premiumUntilBefore"`
+	PremiumUntilAfter  null.Time       `json:"premiumUntilAfter"`
+	RefundedAt         null.Time       `json:"refundedAt"`
+	CreatedAt          time.Time       `json:"createdAt"`
+	UpdatedAt          time.Time       `json:"updatedAt"`
+	CreatedBy          uuid.UUID       `gorm:"type:char(36)" json:"createdBy"`
+	UpdatedBy          uuid.UUID       `gorm:"type:char(36)" json:"updatedBy"`
+
+	Payment Payment `gorm:"foreignKey:PaymentID" json:"-"`
+}
+
+func (PaymentRefund) TableName() string {
+	return "payment_refunds"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (r *PaymentRefund) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+func (r *PaymentRefund) GenerateCreditNoteNumber() {
+	const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	const length = 5
+
+	randomCode := make([]byte, length)
+	for i := range randomCode {
+		num, _ := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		randomCode[i] = charset[num.Int64()]
+	}
+
+	r.CreditNoteNumber = fmt.Sprintf("CN%s%s", time.Now().Format("060102"), string(randomCode))
+}
+
+// Subtotal returns the refunded amount without the VAT share, split with
+// the tax the payment was charged.
+func (r PaymentRefund) Subtotal() decimal.Decimal {
+	total := r.Payment.Total()
+	if !total.IsPositive() {
+		return r.Amount
+	}
+
+	return r.Amount.Mul(r.Payment.Amount).Div(total).Round(2)
+}
+
+func (r PaymentRefund) VatAmount() decimal.Decimal {
+	return r.Amount.Sub(r.Subtotal())
+}
+
+// IsUnsettled reports whether Xendit may still settle the refund. Besides
+// pending refunds this covers the ones failed here before Xendit answered with
+// a refund of its own.
+func (r PaymentRefund) IsUnsettled() bool {
+	return r.Status == RefundStatusPending || (r.Status == RefundStatusFailed && r.ReferenceID == "")
+}
+
+// Ratio returns the share of the charged total the refund gives back, 1 for a
+// full refund.
+func (r PaymentRefund) Ratio() decimal.Decimal {
+	total := r.Payment.ChargedTotal()
+	if !total.IsPositive() {
+		return decimal.Zero
+	}
+
+	return decimal.Min(r.Amount.Div(total), decimal.NewFromInt(1))
+}
+
+// RefundAmount returns the amount to refund out of what was charged, the
+// whole refundable rest when no amount is requested, and the refundable
+// rest. ok is false when the requested amount is not within it.
+func RefundAmount(charged, refunded decimal.Decimal, requested decimal.NullDecimal) (amount, refundable decimal.Decimal, ok bool) {
+	refundable = charged.Sub(refunded)
+	amount = refundable
+	if requested.Valid {
+		amount = requested.Decimal
+	}
+
+	return amount, refundable, amount.IsPositive() && amount.LessThanOrEqual(refundable)
+}
@@ -0,0 +1,250 @@
+package model
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestIsValidRefundReason(t *testing.T) {
+	for _, reason := range RefundReasons {
+		if !IsValidRefundReason(reason) {
+			t.Errorf("IsValidRefundReason(%q) = false, want true", reason)
+		}
+	}
+
+	for _, reason := range []string{"", "requested_by_customer", "CHANGED_MIND"} {
+		if IsValidRefundReason(reason) {
+			t.Errorf("IsValidRefundReason(%q) = true, want false", reason)
+		}
+	}
+}
+
+func TestPaymentRefundGenerateCreditNoteNumber(t *testing.T) {
+	pattern := regexp.MustCompile(`^CN\d{6}[A-Z0-9]{5}$`)
+
+	var refund PaymentRefund
+	refund.GenerateCreditNoteNumber()
+
+	if !pattern.MatchString(refund.CreditNoteNumber) {
+		t.Errorf("GenerateCreditNoteNumber() = %q, want CN followed by the date and 5 characters", refund.CreditNoteNumber)
+	}
+}
+
+func TestRefundAmount(t *testing.T) {
+	tests := []struct {
+		name           string
+		charged        string
+		refunded       string
+		requested      decimal.NullDecimal
+		wantAmount     string
+		wantRefundable string
+		wantOK         bool
+	}{
+		{
+			name:           "full refund takes the whole charge",
+			charged:        "111000",
+			refunded:       "0",
+			wantAmount:     "111000",
+			wantRefundable: "111000",
+			wantOK:         true,
+		},
+		{
+			name:           "partial refund",
+			charged:        "111000",
+			refunded:       "0",
+			requested:      decimal.NewNullDecimal(decimal.NewFromInt(50000)),
+			wantAmount:     "50000",
+			wantRefundable: "111000",
+			wantOK:         true,
+		},
+		{
+			name:           "partial refund up to the charge",
+			charged:        "111000",
+			refunded:       "0",
+			requested:      decimal.NewNullDecimal(decimal.NewFromInt(111000)),
+			wantAmount:     "111000",
+			wantRefundable: "111000",
+			wantOK:         true,
+		},
+		{
+			name:           "partial refund over the charge",
+			charged:        "111000",
+			refunded:       "0",
+			requested:      decimal.NewNullDecimal(decimal.NewFromInt(111001)),
+			wantAmount:     "111001",
+			wantRefundable: "111000",
+		},
+		{
+			name:           "second refund takes the rest",
+			charged:        "111000",
+			refunded:       "50000",
+			wantAmount:     "61000",
+			wantRefundable: "61000",
+			wantOK:         true,
+		},
+		{
+			name:           "second partial refund within the rest",
+			charged:        "111000",
+			refunded:       "50000",
+			requested:      decimal.NewNullDecimal(decimal.NewFromInt(61000)),
+			wantAmount:     "61000",
+			wantRefundable: "61000",
+			wantOK:         true,
+		},
+		{
+			name:           "second partial refund over the rest",
+			charged:        "111000",
+			refunded:       "50000",
+			requested:      decimal.NewNullDecimal(decimal.NewFromInt(61001)),
+			wantAmount:     "61001",
+			wantRefundable: "61000",
+		},
+		{
+			name:           "nothing left after a full refund",
+			charged:        "111000",
+			refunded:       "111000",
+			wantAmount:     "0",
+			wantRefundable: "0",
+		},
+		{
+			name:           "zero amount",
+			charged:        "111000",
+			refunded:       "0",
+			requested:      decimal.NewNullDecimal(decimal.Zero),
+			wantAmount:     "0",
+			wantRefundable: "111000",
+		},
+		{
+			name:           "negative amount",
+			charged:        "111000",
+			refunded:       "0",
+			requested:      decimal.NewNullDecimal(decimal.NewFromInt(-1)),
+			wantAmount:     "-1",
+			wantRefundable: "111000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, refundable, ok := RefundAmount(decimal.RequireFromString(tt.charged), decimal.RequireFromString(tt.refunded), tt.requested)
+			if !amount.Equal(decimal.RequireFromString(tt.wantAmount)) {
+				t.Errorf("amount = %s, want %s", amount, tt.wantAmount)
+			}
+			if !refundable.Equal(decimal.RequireFromString(tt.wantRefundable)) {
+				t.Errorf("refundable = %s, want %s", refundable, tt.wantRefundable)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("ok = %v, want %v", ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPaymentRefundSubtotal(t *testing.T) {
+	tests := []struct {
+		name         string
+		payment      Payment
+		amount       string
+		wantSubtotal string
+		wantVat      string
+	}{
+		{
+			name:         "payment without stored tax is taxed 11%",
+			payment:      Payment{Amount: decimal.NewFromInt(100000)},
+			amount:       "111000",
+			wantSubtotal: "100000",
+			wantVat:      "11000",
+		},
+		{
+			name:         "stored tax of 12%",
+			payment:      Payment{Amount: decimal.NewFromInt(100000), TaxAmount: decimal.NewNullDecimal(decimal.NewFromInt(12000))},
+			amount:       "112000",
+			wantSubtotal: "100000",
+			wantVat:      "12000",
+		},
+		{
+			name:         "partial refund of a 12% payment",
+			payment:      Payment{Amount: decimal.NewFromInt(100000), TaxAmount: decimal.NewNullDecimal(decimal.NewFromInt(12000))},
+			amount:       "56000",
+			wantSubtotal: "50000",
+			wantVat:      "6000",
+		},
+		{
+			name:         "untaxed payment",
+			payment:      Payment{Amount: decimal.NewFromInt(100000), TaxAmount: decimal.NewNullDecimal(decimal.Zero)},
+			amount:       "40000",
+			wantSubtotal: "40000",
+			wantVat:      "0",
+		},
+		{
+			name:         "free payment",
+			payment:      Payment{Amount: decimal.Zero},
+			amount:       "0",
+			wantSubtotal: "0",
+			wantVat:      "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund := PaymentRefund{Amount: decimal.RequireFromString(tt.amount), Payment: tt.payment}
+			if got := refund.Subtotal(); !got.Equal(decimal.RequireFromString(tt.wantSubtotal)) {
+				t.Errorf("Subtotal() = %s, want %s", got, tt.wantSubtotal)
+			}
+			if got := refund.VatAmount(); !got.Equal(decimal.RequireFromString(tt.wantVat)) {
+				t.Errorf("VatAmount() = %s, want %s", got, tt.wantVat)
+			}
+		})
+	}
+}
+
+func TestPaymentRefundRatio(t *testing.T) {
+	// 99900.50 plus 11% VAT is 110889.555, charged as 110889 whole rupiah
+	payment := Payment{Amount: decimal.RequireFromString("99900.50")}
+
+	tests := []struct {
+		name   string
+		amount string
+		want   string
+	}{
+		{name: "full refund of a fractional total", amount: payment.ChargedTotal().String(), want: "1"},
+		{name: "half refund", amount: payment.ChargedTotal().Div(decimal.NewFromInt(2)).String(), want: "0.5"},
+		{name: "nothing refunded", amount: "0", want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund := PaymentRefund{Amount: decimal.RequireFromString(tt.amount), Payment: payment}
+			if got := refund.Ratio(); !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Ratio() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if got := (PaymentRefund{Amount: decimal.NewFromInt(1)}).Ratio(); !got.IsZero() {
+		t.Errorf("Ratio() of a free payment = %s, want 0", got)
+	}
+}
+
+func TestPaymentRefundIsUnsettled(t *testing.T) {
+	tests := []struct {
+		name   string
+		refund PaymentRefund
+		want   bool
+	}{
+		{name: "pending", refund: PaymentRefund{Status: RefundStatusPending, ReferenceID: "rfd-1"}, want: true},
+		{name: "rejected before xendit answered", refund: PaymentRefund{Status: RefundStatusFailed}, want: true},
+		{name: "failed by xendit", refund: PaymentRefund{Status: RefundStatusFailed, ReferenceID: "rfd-1"}},
+		{name: "succeeded", refund: PaymentRefund{Status: RefundStatusSucceeded, ReferenceID: "rfd-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.refund.IsUnsettled(); got != tt.want {
+				t.Errorf("IsUnsettled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestPaymentPremiumAfterRefund(t *testing.T) {
+	var (
+		start   = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		payment = Payment{StartDate: start, EndDate: start.AddDate(0, 0, 30)}
+		now     = start.AddDate(0, 0, 10)
+	)
+
+	tests := []struct {
+		name         string
+		premiumUntil time.Time
+		ratio        string
+		want         time.Time
+	}{
+		{
+			name:         "half refund takes back half the period",
+			premiumUntil: start.AddDate(0, 0, 30),
+			ratio:        "0.5",
+			want:         start.AddDate(0, 0, 15),
+		},
+		{
+			name:         "premium extended by a later payment keeps the rest",
+			premiumUntil: start.AddDate(0, 0, 60),
+			ratio:        "1",
+			want:         start.AddDate(0, 0, 30),
+		},
+		{
+			name:         "full refund ends the premium now",
+			premiumUntil: start.AddDate(0, 0, 30),
+			ratio:        "1",
+			want:         now,
+		},
+		{
+			name:         "nothing refunded",
+			premiumUntil: start.AddDate(0, 0, 30),
+			ratio:        "0",
+			want:         start.AddDate(0, 0, 30),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := payment.PremiumAfterRefund(tt.premiumUntil, decimal.RequireFromString(tt.ratio), now)
+			if !got.Equal(tt.want) {
+				t.Errorf("PremiumAfterRefund() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentName(t *testing.T) {
+	guardianID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	tests := []struct {
+		name    string
+		payment Payment
+		want    string
+	}{
+		{name: "student monthly", payment: Payment{Interval: SubscriptionIntervalMonthly, Type: PaymentTypePremium}, want: "Premium Bulanan"},
+		{name: "family yearly", payment: Payment{Interval: SubscriptionIntervalYearly, Type: PaymentTypePremium, GuardianID: guardianID, Seats: 3}, want: "Premium Tahunan Keluarga (3 siswa)"},
+		{name: "gift", payment: Payment{Interval: SubscriptionIntervalMonthly, Type: PaymentTypeGift, GuardianID: guardianID, Seats: 2}, want: "Hadiah Premium Bulanan (2 kode)"},
+		{name: "unknown interval", payment: Payment{Type: PaymentTypePremium}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payment.Name(); got != tt.want {
+				t.Errorf("Name() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentPayerAndRecipients(t *testing.T) {
+	var (
+		student  = Student{ID: uuid.New(), User: User{Name: "Sari"}}
+		sibling  = Student{ID: uuid.New(), User: User{Name: "Budi"}}
+		guardian = Guardian{ID: uuid.New(), User: User{Name: "Ibu Rina"}}
+	)
+
+	tests := []struct {
+		name           string
+		payment        Payment
+		wantPayer      string
+		wantRecipients []uuid.UUID
+	}{
+		{
+			name:           "student pays for themselves",
+			payment:        Payment{StudentID: uuid.NullUUID{UUID: student.ID, Valid: true}, Student: student},
+			wantPayer:      "Sari",
+			wantRecipients: []uuid.UUID{student.ID},
+		},
+		{
+			name: "guardian pays for the family",
+			payment: Payment{
+				GuardianID: uuid.NullUUID{UUID: guardian.ID, Valid: true},
+				Guardian:   guardian,
+				Beneficiaries: []PaymentBeneficiary{
+					{StudentID: student.ID, Student: student},
+					{StudentID: sibling.ID, Student: sibling},
+				},
+			},
+			wantPayer:      "Ibu Rina",
+			wantRecipients: []uuid.UUID{student.ID, sibling.ID},
+		},
+		{
+			name:           "gift codes have no recipients until redeemed",
+			payment:        Payment{Type: PaymentTypeGift, GuardianID: uuid.NullUUID{UUID: guardian.ID, Valid: true}, Guardian: guardian},
+			wantPayer:      "Ibu Rina",
+			wantRecipients: []uuid.UUID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payment.Payer().Name; got != tt.wantPayer {
+				t.Errorf("Payer() = %q, want %q", got, tt.wantPayer)
+			}
+
+			recipients := tt.payment.Recipients()
+			if len(recipients) != len(tt.wantRecipients) {
+				t.Fatalf("Recipients() = %d students, want %d", len(recipients), len(tt.wantRecipients))
+			}
+			for i, recipient := range recipients {
+				if recipient.ID != tt.wantRecipients[i] {
+					t.Errorf("Recipients()[%d] = %s, want %s", i, recipient.ID, tt.wantRecipients[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PayoutBatchStatus string
+
+const (
+	PayoutBatchStatusProcessing PayoutBatchStatus = "processing"
+	PayoutBatchStatusCompleted  PayoutBatchStatus = "completed"
+)
+
+// PayoutBatch groups approved withdrawal requests sent to the disbursement
+// provider together. It is completed once every request was paid or failed.
+type PayoutBatch struct {
+	ID          uuid.UUID         `gorm:"type:char(36);primaryKey" json:"id"`
+	Status      PayoutBatchStatus `gorm:"type:varchar(50);not null" json:"status"`
+	TotalCount  int               `json:"total_count"`
+	TotalAmount decimal.Decimal   `gorm:"type:decimal(15,2)" json:"total_amount"`
+	SentAt      *time.Time        `json:"sent_at"`
+	CompletedAt *time.Time        `json:"completed_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	CreatedBy   uuid.UUID         `gorm:"type:char(36);not null" json:"created_by"`
+
+	Withdrawals []WithdrawalRequest `gorm:"foreignKey:PayoutBatchID" json:"withdrawals,omitempty"`
+}
+
+func (PayoutBatch) TableName() string {
+	return "payout_batches"
+}
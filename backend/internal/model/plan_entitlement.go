@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+)
+
+const (
+	EntitlementBookingPerDay      = "booking_per_day"
+	EntitlementBookingPerCategory = "booking_per_category"
+	EntitlementFreeFirstCourse    = "free_first_course"
+	EntitlementMonthlyReport      = "monthly_report"
+	EntitlementPriorityResponse   = "priority_response"
+)
+
+// PlanEntitlement holds the limits and perks of a subscription plan. The free
+// plan has no SubscriptionPriceID. A null limit means unlimited.
+type PlanEntitlement struct {
+	ID                            uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	SubscriptionPriceID           null.String   `gorm:"type:char(36)" json:"subscriptionPriceId"`
+	Name                          string        `gorm:"type:varchar(255);not null" json:"name"`
+	MaxBookingPerDay              null.Int      `json:"maxBookingPerDay"`
+	MaxBookingPerCategory         null.Int      `json:"maxBookingPerCategory"`
+	MaxFreeFirstCoursePerCategory null.Int      `json:"maxFreeFirstCoursePerCategory"`
+	MonthlyReport                 bool          `json:"monthlyReport"`
+	PriorityResponse              bool          `json:"priorityResponse"`
+	CreatedAt                     time.Time     `json:"createdAt"`
+	UpdatedAt                     time.Time     `json:"updatedAt"`
+	UpdatedBy                     uuid.NullUUID `gorm:"type:char(36)" json:"updatedBy"`
+}
+
+func (PlanEntitlement) TableName() string {
+	return "plan_entitlements"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (e *PlanEntitlement) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+func (e PlanEntitlement) IsFree() bool {
+	return !e.SubscriptionPriceID.Valid
+}
+
+// EntitlementQuota is the usage of a counted entitlement in its current
+// window. Limit and Remaining are null when the plan has no limit.
+type EntitlementQuota struct {
+	Feature   string    `json:"feature"`
+	Limit     null.Int  `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining null.Int  `json:"remaining"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+func NewEntitlementQuota(feature string, limit null.Int, used int64, resetAt time.Time) EntitlementQuota {
+	quota := EntitlementQuota{
+		Feature: feature,
+		Limit:   limit,
+		Used:    used,
+		ResetAt: resetAt,
+	}
+
+	if limit.Valid {
+		quota.Remaining = null.IntFrom(max(limit.Int64-used, 0))
+	}
+
+	return quota
+}
+
+// Exceeded reports whether no use is left in the current window.
+func (q EntitlementQuota) Exceeded() bool {
+	return q.Remaining.Valid && q.Remaining.Int64 <= 0
+}
+
+type CategoryBookingCount struct {
+	CourseCategoryID uuid.UUID
+	Total            int64
+	FreeFirstCourse  int64
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+func TestNewEntitlementQuota(t *testing.T) {
+	resetAt := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		limit         null.Int
+		used          int64
+		wantRemaining null.Int
+		wantExceeded  bool
+	}{
+		{name: "unlimited", limit: null.Int{}, used: 100, wantRemaining: null.Int{}},
+		{name: "uses left", limit: null.IntFrom(3), used: 1, wantRemaining: null.IntFrom(2)},
+		{name: "last use taken", limit: null.IntFrom(3), used: 3, wantRemaining: null.IntFrom(0), wantExceeded: true},
+		{name: "over the limit after a downgrade", limit: null.IntFrom(1), used: 4, wantRemaining: null.IntFrom(0), wantExceeded: true},
+		{name: "disabled perk", limit: null.IntFrom(0), used: 0, wantRemaining: null.IntFrom(0), wantExceeded: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := NewEntitlementQuota(EntitlementBookingPerDay, tt.limit, tt.used, resetAt)
+			if quota.Remaining != tt.wantRemaining {
+				t.Errorf("Remaining = %v, want %v", quota.Remaining, tt.wantRemaining)
+			}
+			if got := quota.Exceeded(); got != tt.wantExceeded {
+				t.Errorf("Exceeded() = %v, want %v", got, tt.wantExceeded)
+			}
+			if quota.Feature != EntitlementBookingPerDay || quota.Used != tt.used || !quota.ResetAt.Equal(resetAt) {
+				t.Errorf("NewEntitlementQuota() = %+v, want the feature, usage and reset time passed in", quota)
+			}
+		})
+	}
+}
+
+func TestPlanEntitlementIsFree(t *testing.T) {
+	if !(PlanEntitlement{}).IsFree() {
+		t.Error("IsFree() = false, want true without a subscription price")
+	}
+	if (PlanEntitlement{SubscriptionPriceID: null.StringFrom("price-monthly")}).IsFree() {
+		t.Error("IsFree() = true, want false for a paid plan")
+	}
+}
@@ -0,0 +1,399 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type QuizQuestionType string
+
+const (
+	QuizQuestionMultipleChoice QuizQuestionType = "multiple_choice"
+	QuizQuestionMultipleAnswer QuizQuestionType = "multiple_answer"
+	QuizQuestionNumeric        QuizQuestionType = "numeric"
+	QuizQuestionTrueFalse      QuizQuestionType = "true_false"
+)
+
+// maxQuizOptions is the number of options a choice question can have
+const maxQuizOptions = 10
+
+// quizGracePeriod is how long after the deadline answers of an attempt are
+// still accepted, covering the time the request takes to arrive
+const quizGracePeriod = 30 * time.Second
+
+// QuizQuestion is an objective question in the bank of a tutor, tagged by
+// sub course category and level of education.
+type QuizQuestion struct {
+	ID                  uuid.UUID        `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID             uuid.UUID        `gorm:"type:char(36);not null" json:"tutorId"`
+	SubCourseCategoryID uuid.NullUUID    `gorm:"type:char(36)" json:"subCourseCategoryId"`
+	LevelOfEducation    null.String      `gorm:"type:varchar(50)" json:"levelOfEducation"`
+	Type                QuizQuestionType `gorm:"type:varchar(20);not null" json:"type"`
+	Prompt              string           `gorm:"type:text;not null" json:"prompt"`
+	// Options holds the []QuizOption of choice questions
+	Options datatypes.JSON `gorm:"type:json" json:"-"`
+	// Answer holds the QuizAnswer key of the question
+	Answer      datatypes.JSON  `gorm:"type:json;not null" json:"-"`
+	Explanation null.String     `gorm:"type:text" json:"explanation"`
+	Points      decimal.Decimal `gorm:"type:decimal(5,2);not null" json:"points"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	DeletedAt   null.Time       `json:"-"`
+
+	SubCourseCategory *SubCourseCategory `gorm:"foreignKey:SubCourseCategoryID" json:"-"`
+}
+
+func (QuizQuestion) TableName() string {
+	return "quiz_questions"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (q *QuizQuestion) BeforeCreate(tx *gorm.DB) error {
+	if q.ID == uuid.Nil {
+		q.ID = uuid.New()
+	}
+	return nil
+}
+
+// QuizOption is one option of a choice question, referred to by its key in
+// the answers.
+type QuizOption struct {
+	Key  string `json:"key"`
+	Text string `json:"text"`
+}
+
+// QuizAnswer is the answer key of a question or the answer of a student.
+// Choice questions are answered with the keys of the options, numeric ones
+// with a number, within Tolerance of the key, and true/false ones with Value.
+type QuizAnswer struct {
+	Options   []string         `json:"options,omitempty"`
+	Number    *decimal.Decimal `json:"number,omitempty"`
+	Tolerance decimal.Decimal  `json:"tolerance,omitempty"`
+	Value     *bool            `json:"value,omitempty"`
+}
+
+// GetOptions returns the options of a choice question
+func (q *QuizQuestion) GetOptions() []QuizOption {
+	options := []QuizOption{}
+	if len(q.Options) == 0 {
+		return options
+	}
+	_ = json.Unmarshal(q.Options, &options)
+	return options
+}
+
+// GetAnswer returns the answer key of the question
+func (q *QuizQuestion) GetAnswer() QuizAnswer {
+	var answer QuizAnswer
+	if len(q.Answer) == 0 {
+		return answer
+	}
+	_ = json.Unmarshal(q.Answer, &answer)
+	return answer
+}
+
+// ValidateQuizQuestion checks the options and the answer key fit the type of
+// the question. Choice questions have from 2 up to 10 options with a unique
+// key, multiple choice ones exactly one of them correct.
+func ValidateQuizQuestion(questionType QuizQuestionType, options []QuizOption, answer QuizAnswer) error {
+	switch questionType {
+	case QuizQuestionMultipleChoice, QuizQuestionMultipleAnswer:
+		if len(options) < 2 || len(options) > maxQuizOptions {
+			return fmt.Errorf("choice questions need between 2 and %d options", maxQuizOptions)
+		}
+
+		keys := make(map[string]bool, len(options))
+		for _, option := range options {
+			if option.Key == "" || option.Text == "" {
+				return errors.New("option key and text are required")
+			}
+			if keys[option.Key] {
+				return fmt.Errorf("duplicate option %s", option.Key)
+			}
+			keys[option.Key] = true
+		}
+
+		if len(answer.Options) == 0 {
+			return errors.New("answer needs the correct options")
+		}
+		if questionType == QuizQuestionMultipleChoice && len(answer.Options) != 1 {
+			return errors.New("multiple choice questions have exactly one correct option")
+		}
+
+		correct := make(map[string]bool, len(answer.Options))
+		for _, key := range answer.Options {
+			if !keys[key] || correct[key] {
+				return fmt.Errorf("unknown or duplicate correct option %s", key)
+			}
+			correct[key] = true
+		}
+	case QuizQuestionNumeric:
+		if len(options) > 0 {
+			return errors.New("numeric questions have no options")
+		}
+		if answer.Number == nil {
+			return errors.New("answer needs the number")
+		}
+		if answer.Tolerance.IsNegative() {
+			return errors.New("tolerance must not be negative")
+		}
+	case QuizQuestionTrueFalse:
+		if len(options) > 0 {
+			return errors.New("true/false questions have no options")
+		}
+		if answer.Value == nil {
+			return errors.New("answer needs the value")
+		}
+	default:
+		return errors.New("type must be one of multiple_choice, multiple_answer, numeric, true_false")
+	}
+
+	return nil
+}
+
+// IsCorrect reports whether the answer of a student matches the answer key.
+// Multiple answer questions are only correct with every correct option and
+// none other picked.
+func (q *QuizQuestion) IsCorrect(answer QuizAnswer) bool {
+	key := q.GetAnswer()
+
+	switch q.Type {
+	case QuizQuestionMultipleChoice, QuizQuestionMultipleAnswer:
+		if len(answer.Options) != len(key.Options) {
+			return false
+		}
+
+		correct := make(map[string]bool, len(key.Options))
+		for _, option := range key.Options {
+			correct[option] = true
+		}
+		for _, option := range answer.Options {
+			if !correct[option] {
+				return false
+			}
+			delete(correct, option)
+		}
+		return true
+	case QuizQuestionNumeric:
+		if answer.Number == nil || key.Number == nil {
+			return false
+		}
+		return answer.Number.Sub(*key.Number).Abs().LessThanOrEqual(key.Tolerance)
+	case QuizQuestionTrueFalse:
+		return answer.Value != nil && key.Value != nil && *answer.Value == *key.Value
+	}
+
+	return false
+}
+
+type QuizQuestionFilter struct {
+	TutorID             uuid.UUID
+	SubCourseCategoryID uuid.UUID
+	LevelOfEducation    string
+	Type                QuizQuestionType
+	Query               string
+	Pagination
+}
+
+// Quiz is a set of questions of the bank of a tutor, taken within
+// TimeLimitMinutes when set.
+type Quiz struct {
+	ID               uuid.UUID   `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID          uuid.UUID   `gorm:"type:char(36);not null" json:"tutorId"`
+	Title            string      `gorm:"type:varchar(255);not null" json:"title"`
+	Description      null.String `gorm:"type:text" json:"description"`
+	TimeLimitMinutes null.Int    `json:"timeLimitMinutes"`
+	CreatedAt        time.Time   `json:"createdAt"`
+	UpdatedAt        time.Time   `json:"updatedAt"`
+	DeletedAt        null.Time   `json:"-"`
+
+	Items []QuizItem `gorm:"foreignKey:QuizID" json:"-"`
+}
+
+func (Quiz) TableName() string {
+	return "quizzes"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (q *Quiz) BeforeCreate(tx *gorm.DB) error {
+	if q.ID == uuid.Nil {
+		q.ID = uuid.New()
+	}
+	return nil
+}
+
+// MaxPoints returns the points of every question of the quiz
+func (q *Quiz) MaxPoints() decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range q.Items {
+		if item.Question != nil {
+			total = total.Add(item.Question.Points)
+		}
+	}
+	return total
+}
+
+// QuizItem places a question of the bank in a quiz
+type QuizItem struct {
+	QuizID     uuid.UUID `gorm:"type:char(36);primaryKey" json:"quizId"`
+	QuestionID uuid.UUID `gorm:"type:char(36);primaryKey" json:"questionId"`
+	Position   int       `gorm:"not null" json:"position"`
+
+	Question *QuizQuestion `gorm:"foreignKey:QuestionID" json:"-"`
+}
+
+func (QuizItem) TableName() string {
+	return "quiz_items"
+}
+
+type QuizFilter struct {
+	TutorID uuid.UUID
+	Query   string
+	Pagination
+}
+
+// QuizAttempt is the student taking the quiz of a session task. Points are
+// rolled up into the 0-100 Score when the attempt is submitted.
+type QuizAttempt struct {
+	ID            uuid.UUID           `gorm:"type:char(36);primaryKey" json:"id"`
+	SessionTaskID uuid.UUID           `gorm:"type:char(36);not null" json:"sessionTaskId"`
+	QuizID        uuid.UUID           `gorm:"type:char(36);not null" json:"quizId"`
+	StudentID     uuid.UUID           `gorm:"type:char(36);not null" json:"studentId"`
+	StartedAt     time.Time           `gorm:"not null" json:"startedAt"`
+	DeadlineAt    null.Time           `json:"deadlineAt"`
+	SubmittedAt   null.Time           `json:"submittedAt"`
+	Points        decimal.Decimal     `gorm:"type:decimal(7,2);not null" json:"points"`
+	MaxPoints     decimal.Decimal     `gorm:"type:decimal(7,2);not null" json:"maxPoints"`
+	Score         decimal.NullDecimal `gorm:"type:decimal(5,2)" json:"score"`
+	CreatedAt     time.Time           `json:"createdAt"`
+	UpdatedAt     time.Time           `json:"updatedAt"`
+
+	Answers []QuizAttemptAnswer `gorm:"foreignKey:AttemptID" json:"-"`
+}
+
+func (QuizAttempt) TableName() string {
+	return "quiz_attempts"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (a *QuizAttempt) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsSubmitted returns true if the attempt has been graded
+func (a *QuizAttempt) IsSubmitted() bool {
+	return a.SubmittedAt.Valid
+}
+
+// IsExpired returns true if the time limit of the attempt ran out at now,
+// allowing a short grace period
+func (a *QuizAttempt) IsExpired(now time.Time) bool {
+	return a.DeadlineAt.Valid && now.After(a.DeadlineAt.Time.Add(quizGracePeriod))
+}
+
+// Grade checks every answer against the question as it was asked and rolls
+// the points up into the score out of 100.
+func (a *QuizAttempt) Grade() {
+	a.Points = decimal.Zero
+	a.MaxPoints = decimal.Zero
+	for i := range a.Answers {
+		answer := &a.Answers[i]
+		question := answer.AskedQuestion()
+
+		a.MaxPoints = a.MaxPoints.Add(question.Points)
+		answer.IsCorrect = question.IsCorrect(answer.GetAnswer())
+		answer.Points = decimal.Zero
+		if answer.IsCorrect {
+			answer.Points = question.Points
+			a.Points = a.Points.Add(answer.Points)
+		}
+	}
+
+	score := decimal.Zero
+	if a.MaxPoints.IsPositive() {
+		score = a.Points.Div(a.MaxPoints).Mul(hundred).Round(2)
+	}
+	a.Score = decimal.NewNullDecimal(score)
+}
+
+// QuizAttemptAnswer is the answer of the student to one question of the
+// attempt, empty until answered. The question is copied into the answer when
+// the attempt starts, so the attempt is shown and graded as it was asked even
+// when the tutor edits or deletes the question afterwards.
+type QuizAttemptAnswer struct {
+	AttemptID    uuid.UUID        `gorm:"type:char(36);primaryKey" json:"attemptId"`
+	QuestionID   uuid.UUID        `gorm:"type:char(36);primaryKey" json:"questionId"`
+	Position     int              `gorm:"not null" json:"position"`
+	QuestionType QuizQuestionType `gorm:"type:varchar(20);not null" json:"questionType"`
+	Prompt       string           `gorm:"type:text;not null" json:"prompt"`
+	Options      datatypes.JSON   `gorm:"type:json" json:"-"`
+	AnswerKey    datatypes.JSON   `gorm:"type:json;not null" json:"-"`
+	Explanation  null.String      `gorm:"type:text" json:"-"`
+	MaxPoints    decimal.Decimal  `gorm:"type:decimal(5,2);not null" json:"maxPoints"`
+	Answer       datatypes.JSON   `gorm:"type:json" json:"-"`
+	IsCorrect    bool             `json:"isCorrect"`
+	Points       decimal.Decimal  `gorm:"type:decimal(5,2);not null" json:"points"`
+}
+
+// NewQuizAttemptAnswer returns the empty answer to the question asked at
+// position in an attempt
+func NewQuizAttemptAnswer(question QuizQuestion, position int) QuizAttemptAnswer {
+	return QuizAttemptAnswer{
+		QuestionID:   question.ID,
+		Position:     position,
+		QuestionType: question.Type,
+		Prompt:       question.Prompt,
+		Options:      question.Options,
+		AnswerKey:    question.Answer,
+		Explanation:  question.Explanation,
+		MaxPoints:    question.Points,
+	}
+}
+
+// AskedQuestion returns the question as it was when the attempt started
+func (a *QuizAttemptAnswer) AskedQuestion() QuizQuestion {
+	return QuizQuestion{
+		ID:          a.QuestionID,
+		Type:        a.QuestionType,
+		Prompt:      a.Prompt,
+		Options:     a.Options,
+		Answer:      a.AnswerKey,
+		Explanation: a.Explanation,
+		Points:      a.MaxPoints,
+	}
+}
+
+func (QuizAttemptAnswer) TableName() string {
+	return "quiz_attempt_answers"
+}
+
+// IsAnswered returns true if the student answered the question
+func (a *QuizAttemptAnswer) IsAnswered() bool {
+	return len(a.Answer) > 0 && string(a.Answer) != "null"
+}
+
+// GetAnswer returns the answer of the student
+func (a *QuizAttemptAnswer) GetAnswer() QuizAnswer {
+	var answer QuizAnswer
+	if !a.IsAnswered() {
+		return answer
+	}
+	_ = json.Unmarshal(a.Answer, &answer)
+	return answer
+}
+
+type QuizAttemptFilter struct {
+	QuizID        uuid.UUID
+	SessionTaskID uuid.UUID
+}
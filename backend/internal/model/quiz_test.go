@@ -0,0 +1,189 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+func boolPtr(v bool) *bool { return &v }
+
+func decimalPtr(v string) *decimal.Decimal {
+	d := decimal.RequireFromString(v)
+	return &d
+}
+
+func newQuizQuestion(t *testing.T, questionType QuizQuestionType, options []QuizOption, answer QuizAnswer, points int64) QuizQuestion {
+	t.Helper()
+	question := QuizQuestion{
+		ID:     uuid.New(),
+		Type:   questionType,
+		Prompt: "Question",
+		Answer: mustJSON(t, answer),
+		Points: decimal.NewFromInt(points),
+	}
+	if options != nil {
+		question.Options = mustJSON(t, options)
+	}
+	return question
+}
+
+var quizOptions = []QuizOption{{Key: "a", Text: "Satu"}, {Key: "b", Text: "Dua"}, {Key: "c", Text: "Tiga"}}
+
+func TestValidateQuizQuestion(t *testing.T) {
+	tests := []struct {
+		name         string
+		questionType QuizQuestionType
+		options      []QuizOption
+		answer       QuizAnswer
+		wantErr      bool
+	}{
+		{name: "multiple choice", questionType: QuizQuestionMultipleChoice, options: quizOptions, answer: QuizAnswer{Options: []string{"a"}}},
+		{name: "multiple choice with two correct options", questionType: QuizQuestionMultipleChoice, options: quizOptions, answer: QuizAnswer{Options: []string{"a", "b"}}, wantErr: true},
+		{name: "multiple answer", questionType: QuizQuestionMultipleAnswer, options: quizOptions, answer: QuizAnswer{Options: []string{"a", "c"}}},
+		{name: "one option", questionType: QuizQuestionMultipleChoice, options: quizOptions[:1], answer: QuizAnswer{Options: []string{"a"}}, wantErr: true},
+		{name: "duplicate option key", questionType: QuizQuestionMultipleAnswer, options: []QuizOption{{Key: "a", Text: "Satu"}, {Key: "a", Text: "Dua"}}, answer: QuizAnswer{Options: []string{"a"}}, wantErr: true},
+		{name: "unknown correct option", questionType: QuizQuestionMultipleChoice, options: quizOptions, answer: QuizAnswer{Options: []string{"d"}}, wantErr: true},
+		{name: "no correct option", questionType: QuizQuestionMultipleAnswer, options: quizOptions, wantErr: true},
+		{name: "numeric", questionType: QuizQuestionNumeric, answer: QuizAnswer{Number: decimalPtr("3.14"), Tolerance: decimal.RequireFromString("0.01")}},
+		{name: "numeric without number", questionType: QuizQuestionNumeric, answer: QuizAnswer{}, wantErr: true},
+		{name: "numeric with negative tolerance", questionType: QuizQuestionNumeric, answer: QuizAnswer{Number: decimalPtr("1"), Tolerance: decimal.NewFromInt(-1)}, wantErr: true},
+		{name: "numeric with options", questionType: QuizQuestionNumeric, options: quizOptions, answer: QuizAnswer{Number: decimalPtr("1")}, wantErr: true},
+		{name: "true/false", questionType: QuizQuestionTrueFalse, answer: QuizAnswer{Value: boolPtr(false)}},
+		{name: "true/false without value", questionType: QuizQuestionTrueFalse, answer: QuizAnswer{}, wantErr: true},
+		{name: "unknown type", questionType: "essay", answer: QuizAnswer{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuizQuestion(tt.questionType, tt.options, tt.answer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateQuizQuestion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuizQuestionIsCorrect(t *testing.T) {
+	var (
+		choice   = newQuizQuestion(t, QuizQuestionMultipleChoice, quizOptions, QuizAnswer{Options: []string{"b"}}, 1)
+		multiple = newQuizQuestion(t, QuizQuestionMultipleAnswer, quizOptions, QuizAnswer{Options: []string{"a", "c"}}, 1)
+		numeric  = newQuizQuestion(t, QuizQuestionNumeric, nil, QuizAnswer{Number: decimalPtr("3.14"), Tolerance: decimal.RequireFromString("0.01")}, 1)
+		exact    = newQuizQuestion(t, QuizQuestionNumeric, nil, QuizAnswer{Number: decimalPtr("42")}, 1)
+		truth    = newQuizQuestion(t, QuizQuestionTrueFalse, nil, QuizAnswer{Value: boolPtr(true)}, 1)
+	)
+
+	tests := []struct {
+		name     string
+		question QuizQuestion
+		answer   QuizAnswer
+		want     bool
+	}{
+		{name: "choice correct", question: choice, answer: QuizAnswer{Options: []string{"b"}}, want: true},
+		{name: "choice wrong", question: choice, answer: QuizAnswer{Options: []string{"a"}}},
+		{name: "choice unanswered", question: choice, answer: QuizAnswer{}},
+		{name: "multiple answer in any order", question: multiple, answer: QuizAnswer{Options: []string{"c", "a"}}, want: true},
+		{name: "multiple answer missing one", question: multiple, answer: QuizAnswer{Options: []string{"a"}}},
+		{name: "multiple answer with an extra one", question: multiple, answer: QuizAnswer{Options: []string{"a", "b", "c"}}},
+		{name: "multiple answer repeating one", question: multiple, answer: QuizAnswer{Options: []string{"a", "a"}}},
+		{name: "numeric within tolerance", question: numeric, answer: QuizAnswer{Number: decimalPtr("3.15")}, want: true},
+		{name: "numeric outside tolerance", question: numeric, answer: QuizAnswer{Number: decimalPtr("3.16")}},
+		{name: "numeric exact", question: exact, answer: QuizAnswer{Number: decimalPtr("42.00")}, want: true},
+		{name: "numeric unanswered", question: exact, answer: QuizAnswer{}},
+		{name: "true/false correct", question: truth, answer: QuizAnswer{Value: boolPtr(true)}, want: true},
+		{name: "true/false wrong", question: truth, answer: QuizAnswer{Value: boolPtr(false)}},
+		{name: "true/false unanswered", question: truth, answer: QuizAnswer{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.question.IsCorrect(tt.answer); got != tt.want {
+				t.Errorf("IsCorrect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuizAttemptIsExpired(t *testing.T) {
+	now := time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		deadline null.Time
+		want     bool
+	}{
+		{name: "no time limit", deadline: null.Time{}},
+		{name: "before the deadline", deadline: null.TimeFrom(now.Add(time.Minute))},
+		{name: "within the grace period", deadline: null.TimeFrom(now.Add(-quizGracePeriod))},
+		{name: "after the grace period", deadline: null.TimeFrom(now.Add(-quizGracePeriod - time.Second)), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt := QuizAttempt{DeadlineAt: tt.deadline}
+			if got := attempt.IsExpired(now); got != tt.want {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuizAttemptGrade(t *testing.T) {
+	var (
+		choice = newQuizQuestion(t, QuizQuestionMultipleChoice, quizOptions, QuizAnswer{Options: []string{"b"}}, 2)
+		truth  = newQuizQuestion(t, QuizQuestionTrueFalse, nil, QuizAnswer{Value: boolPtr(true)}, 1)
+		number = newQuizQuestion(t, QuizQuestionNumeric, nil, QuizAnswer{Number: decimalPtr("10")}, 3)
+	)
+
+	attempt := QuizAttempt{Answers: []QuizAttemptAnswer{
+		NewQuizAttemptAnswer(choice, 0),
+		NewQuizAttemptAnswer(truth, 1),
+		NewQuizAttemptAnswer(number, 2),
+	}}
+	attempt.Answers[0].Answer = mustJSON(t, QuizAnswer{Options: []string{"b"}})
+	attempt.Answers[1].Answer = mustJSON(t, QuizAnswer{Value: boolPtr(false)})
+
+	// The tutor editing the bank after the attempt started changes neither
+	// the answer key nor the points the attempt is graded with.
+	choice.Answer = mustJSON(t, QuizAnswer{Options: []string{"a"}})
+	choice.Points = decimal.NewFromInt(10)
+
+	attempt.Grade()
+
+	if !attempt.Points.Equal(decimal.NewFromInt(2)) || !attempt.MaxPoints.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("Grade() points = %s/%s, want 2/6", attempt.Points, attempt.MaxPoints)
+	}
+	if !attempt.Score.Valid || !attempt.Score.Decimal.Equal(decimal.RequireFromString("33.33")) {
+		t.Errorf("Grade() score = %v, want 33.33", attempt.Score)
+	}
+
+	want := []struct {
+		correct bool
+		points  int64
+	}{{true, 2}, {false, 0}, {false, 0}}
+	for i, w := range want {
+		answer := attempt.Answers[i]
+		if answer.IsCorrect != w.correct || !answer.Points.Equal(decimal.NewFromInt(w.points)) {
+			t.Errorf("answer %d = (%v, %s), want (%v, %d)", i, answer.IsCorrect, answer.Points, w.correct, w.points)
+		}
+	}
+
+	// Grading again after the answer is corrected resets the earlier result.
+	attempt.Answers[0].Answer = mustJSON(t, QuizAnswer{Options: []string{"c"}})
+	attempt.Grade()
+	if attempt.Answers[0].IsCorrect || !attempt.Score.Decimal.IsZero() {
+		t.Errorf("Grade() again score = %v, first answer correct = %v, want 0 and false", attempt.Score, attempt.Answers[0].IsCorrect)
+	}
+}
+
+func TestQuizAttemptGradeWithoutPoints(t *testing.T) {
+	attempt := QuizAttempt{}
+	attempt.Grade()
+
+	if !attempt.Score.Valid || !attempt.Score.Decimal.IsZero() {
+		t.Errorf("Grade() score = %v, want 0", attempt.Score)
+	}
+}
@@ -6,9 +6,12 @@ import (
 )
 
 type ReviewFilter struct {
-	StudentID       uuid.UUID
-	TutorID         uuid.UUID
-	DeletedAtIsNull null.Bool
+	StudentID        uuid.UUID
+	TutorID          uuid.UUID
+	CourseID         uuid.UUID
+	IsSubmitted      null.Bool
+	ModerationStatus ReviewModerationStatus
+	DeletedAtIsNull  null.Bool
 	Pagination
 	Sort
 }
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+)
+
+type ReviewReportSource string
+
+const (
+	ReviewReportSourceUser   ReviewReportSource = "user"
+	ReviewReportSourceSystem ReviewReportSource = "system"
+)
+
+type ReviewReportStatus string
+
+const (
+	ReviewReportStatusPending   ReviewReportStatus = "pending"
+	ReviewReportStatusResolved  ReviewReportStatus = "resolved"
+	ReviewReportStatusDismissed ReviewReportStatus = "dismissed"
+)
+
+// ReviewReport is an entry in the review moderation queue. Reports are filed
+// by users or raised by the system when a review trips the profanity filter.
+type ReviewReport struct {
+	ID            uuid.UUID          `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorReviewID uuid.UUID          `gorm:"type:char(36);not null;index" json:"tutor_review_id"`
+	ReporterID    uuid.NullUUID      `gorm:"type:char(36)" json:"reporter_id"`
+	Source        ReviewReportSource `gorm:"type:enum('user','system');not null;default:'user'" json:"source"`
+	Reason        string             `gorm:"type:varchar(500);not null" json:"reason"`
+	Status        ReviewReportStatus `gorm:"type:enum('pending','resolved','dismissed');not null;default:'pending'" json:"status"`
+	ResolvedAt    null.Time          `json:"resolved_at"`
+	ResolvedBy    uuid.NullUUID      `gorm:"type:char(36)" json:"resolved_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+
+	Reporter User `gorm:"foreignKey:ReporterID" json:"reporter"`
+}
+
+func (ReviewReport) TableName() string {
+	return "review_reports"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (r *ReviewReport) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+type ReviewHelpfulVote struct {
+	ID            uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorReviewID uuid.UUID `gorm:"type:char(36);not null" json:"tutor_review_id"`
+	UserID        uuid.UUID `gorm:"type:char(36);not null" json:"user_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (ReviewHelpfulVote) TableName() string {
+	return "review_helpful_votes"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (v *ReviewHelpfulVote) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+type ReviewModerationFilter struct {
+	ModerationStatus ReviewModerationStatus
+	TutorID          uuid.UUID
+	Pagination
+	Sort
+}
@@ -7,9 +7,10 @@ import (
 )
 
 const (
-	RoleNameAdmin   = "admin"
-	RoleNameStudent = "student"
-	RoleNameTutor   = "tutor"
+	RoleNameAdmin    = "admin"
+	RoleNameStudent  = "student"
+	RoleNameTutor    = "tutor"
+	RoleNameGuardian = "guardian"
 )
 
 // Role represents a role in the system
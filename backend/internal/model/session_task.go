@@ -1,24 +1,38 @@
 package model
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
 type SessionTask struct {
-	ID            uuid.UUID        `gorm:"type:char(36);primary_key" json:"id"`
-	BookingID     uuid.UUID        `gorm:"type:char(36);not null" json:"booking_id"`
-	Title         string           `gorm:"type:varchar(255);not null" json:"title"`
-	Description   null.String      `gorm:"type:text" json:"description"`
-	AttachmentURL null.String      `gorm:"type:varchar(255)" json:"attachment_url"`
-	CreatedAt     time.Time        `json:"created_at"`
-	UpdatedAt     time.Time        `json:"updated_at"`
-	DeletedAt     null.Time        `gorm:"index" json:"deleted_at"`
+	ID            uuid.UUID   `gorm:"type:char(36);primary_key" json:"id"`
+	BookingID     uuid.UUID   `gorm:"type:char(36);not null" json:"booking_id"`
+	Title         string      `gorm:"type:varchar(255);not null" json:"title"`
+	Description   null.String `gorm:"type:text" json:"description"`
+	AttachmentURL null.String `gorm:"type:varchar(255)" json:"attachment_url"`
+	DueAt         null.Time   `gorm:"type:timestamp" json:"due_at"`
+	// Rubric holds the []RubricCriterion the submissions are scored with
+	Rubric datatypes.JSON `gorm:"type:json" json:"rubric,omitempty"`
+	// TaskTemplateID is the library template the task was assigned from
+	TaskTemplateID uuid.NullUUID `gorm:"type:char(36)" json:"task_template_id"`
+	// QuizID is the quiz the student takes for the task, graded automatically
+	QuizID    uuid.NullUUID `gorm:"type:char(36)" json:"quiz_id"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	DeletedAt null.Time     `gorm:"index" json:"deleted_at"`
 
 	TaskSubmissions []TaskSubmission `gorm:"foreignKey:SessionTaskID" json:"task_submissions"`
+	Booking         *Booking         `gorm:"foreignKey:BookingID" json:"booking,omitempty"`
+	Quiz            *Quiz            `gorm:"foreignKey:QuizID" json:"quiz,omitempty"`
 }
 
 // BeforeCreate will set a UUID rather than numeric ID.
@@ -28,3 +42,113 @@ func (s *SessionTask) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// RubricCriterion is one criterion of a task rubric, scored from 0 up to
+// MaxScore. Weighted criteria count for Weight percent of the task score.
+type RubricCriterion struct {
+	Name        string          `json:"name" validate:"required"`
+	Description string          `json:"description,omitempty"`
+	MaxScore    decimal.Decimal `json:"max_score"`
+	Weight      decimal.Decimal `json:"weight,omitempty"`
+}
+
+var hundred = decimal.NewFromInt(100)
+
+// ValidateRubric checks the criteria have a unique name and a positive max
+// score. Either every criterion is weighted, the weights adding up to 100,
+// or none is and the max scores add up to at most 100.
+func ValidateRubric(rubric []RubricCriterion) error {
+	if len(rubric) == 0 {
+		return errors.New("rubric needs at least one criterion")
+	}
+
+	names := make(map[string]bool, len(rubric))
+	weighted := rubric[0].Weight.IsPositive()
+	total := decimal.Zero
+	for _, criterion := range rubric {
+		if criterion.Name == "" {
+			return errors.New("rubric criterion name is required")
+		}
+		if names[criterion.Name] {
+			return fmt.Errorf("duplicate rubric criterion %s", criterion.Name)
+		}
+		if !criterion.MaxScore.IsPositive() {
+			return fmt.Errorf("max score of %s must be positive", criterion.Name)
+		}
+		if criterion.Weight.IsNegative() || criterion.Weight.IsPositive() != weighted {
+			return errors.New("either every rubric criterion is weighted or none is")
+		}
+
+		names[criterion.Name] = true
+		if weighted {
+			total = total.Add(criterion.Weight)
+		} else {
+			total = total.Add(criterion.MaxScore)
+		}
+	}
+
+	if weighted && !total.Equal(hundred) {
+		return errors.New("rubric weights must add up to 100")
+	}
+	if !weighted && total.GreaterThan(hundred) {
+		return errors.New("rubric max scores must add up to at most 100")
+	}
+
+	return nil
+}
+
+// RubricTotal rolls the criteria scores up into the task score. Each
+// criterion must be scored once. Weighted criteria contribute their share
+// of the weight, other criteria their score.
+func RubricTotal(rubric []RubricCriterion, scores []RubricScore) (decimal.Decimal, error) {
+	if len(scores) != len(rubric) {
+		return decimal.Zero, errors.New("every rubric criterion must be scored once")
+	}
+
+	criteria := make(map[string]RubricCriterion, len(rubric))
+	for _, criterion := range rubric {
+		criteria[criterion.Name] = criterion
+	}
+
+	total := decimal.Zero
+	for _, score := range scores {
+		criterion, ok := criteria[score.Criterion]
+		if !ok {
+			return decimal.Zero, fmt.Errorf("unknown or duplicate rubric criterion %s", score.Criterion)
+		}
+		if score.Score.IsNegative() || score.Score.GreaterThan(criterion.MaxScore) {
+			return decimal.Zero, fmt.Errorf("score of %s must be between 0 and %s", score.Criterion, criterion.MaxScore)
+		}
+
+		delete(criteria, score.Criterion)
+		if criterion.Weight.IsPositive() {
+			total = total.Add(score.Score.Div(criterion.MaxScore).Mul(criterion.Weight))
+		} else {
+			total = total.Add(score.Score)
+		}
+	}
+
+	return total.Round(2), nil
+}
+
+// GetRubric returns the criteria the submissions of the task are scored with
+func (s *SessionTask) GetRubric() []RubricCriterion {
+	criteria := []RubricCriterion{}
+	if len(s.Rubric) == 0 {
+		return criteria
+	}
+	_ = json.Unmarshal(s.Rubric, &criteria)
+	return criteria
+}
+
+// IsPastDue returns true if the task has a due date that has passed at now
+func (s *SessionTask) IsPastDue(now time.Time) bool {
+	return s.DueAt.Valid && now.After(s.DueAt.Time)
+}
+
+type SessionTaskFilter struct {
+	StudentID uuid.UUID
+	// Status is the submission status, pending for tasks without a submission
+	Status string
+	Pagination
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,6 +24,9 @@ type Student struct {
 	Gender          null.String         `gorm:"type:varchar(50)" json:"gender"`
 	DateOfBirth     null.Time           `gorm:"type:date" json:"date_of_birth"`
 	PhoneNumber     null.String         `gorm:"type:varchar(20)" json:"phone_number"`
+	TaxID           null.String         `gorm:"type:varchar(20)" json:"tax_id"`
+	TaxName         null.String         `gorm:"type:varchar(255)" json:"tax_name"`
+	TaxAddress      null.String         `gorm:"type:varchar(500)" json:"tax_address"`
 	SocialMediaLink []SocialMediaLink   `gorm:"serializer:social_media_link" json:"social_media_link"`
 	Latitude        decimal.NullDecimal `json:"latitude"`
 	Longitude       decimal.NullDecimal `json:"longitude"`
@@ -44,6 +48,17 @@ func (s *Student) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// NormalizeTaxID keeps the digits of an NPWP, dropping the dots and dashes
+// of its printed form.
+func NormalizeTaxID(taxID string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, taxID)
+}
+
 func (s *Student) IsPremium() bool {
 	return s.PremiumUntil.Valid && s.PremiumUntil.Time.After(time.Now())
 }
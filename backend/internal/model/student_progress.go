@@ -0,0 +1,126 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+// StudentProgressSummary is the progress of a student with a tutor in a
+// subject for one month, materialised from bookings and task submissions.
+// Sessions count the bookings not declined or expired, attended ones being
+// completed.
+type StudentProgressSummary struct {
+	ID                uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID         uuid.UUID       `gorm:"type:char(36);not null" json:"studentId"`
+	TutorID           uuid.UUID       `gorm:"type:char(36);not null" json:"tutorId"`
+	CourseCategoryID  uuid.UUID       `gorm:"type:char(36);not null" json:"courseCategoryId"`
+	Period            string          `gorm:"type:char(6);not null" json:"period"`
+	SessionsScheduled int             `json:"sessionsScheduled"`
+	SessionsAttended  int             `json:"sessionsAttended"`
+	TasksTotal        int             `json:"tasksTotal"`
+	TasksSubmitted    int             `json:"tasksSubmitted"`
+	ScoresCount       int             `json:"scoresCount"`
+	ScoreSum          decimal.Decimal `gorm:"type:decimal(12,2)" json:"scoreSum"`
+	ComputedAt        time.Time       `json:"computedAt"`
+
+	Student        *Student        `gorm:"foreignKey:StudentID" json:"-"`
+	CourseCategory *CourseCategory `gorm:"foreignKey:CourseCategoryID" json:"-"`
+}
+
+func (StudentProgressSummary) TableName() string {
+	return "student_progress_summaries"
+}
+
+type StudentProgressFilter struct {
+	StudentID        uuid.UUID
+	TutorID          uuid.UUID
+	CourseCategoryID uuid.UUID
+	PeriodFrom       string
+	PeriodUntil      string
+	WithStudent      bool // preloads the student and their user
+}
+
+// ProgressPeriods returns the periods of the months months up to until,
+// oldest first.
+func ProgressPeriods(until time.Time, months int) []string {
+	periods := make([]string, 0, months)
+	start := time.Date(until.Year(), until.Month(), 1, 0, 0, 0, 0, until.Location()).AddDate(0, 1-months, 0)
+	for i := 0; i < months; i++ {
+		month := start.AddDate(0, i, 0)
+		periods = append(periods, MonthlyReportPeriod(int(month.Month()), month.Year()))
+	}
+	return periods
+}
+
+// ProgressTotals adds up summaries into rates and averages.
+type ProgressTotals struct {
+	SessionsScheduled int
+	SessionsAttended  int
+	TasksTotal        int
+	TasksSubmitted    int
+	ScoresCount       int
+	ScoreSum          decimal.Decimal
+}
+
+func (t *ProgressTotals) Add(summary StudentProgressSummary) {
+	t.SessionsScheduled += summary.SessionsScheduled
+	t.SessionsAttended += summary.SessionsAttended
+	t.TasksTotal += summary.TasksTotal
+	t.TasksSubmitted += summary.TasksSubmitted
+	t.ScoresCount += summary.ScoresCount
+	t.ScoreSum = t.ScoreSum.Add(summary.ScoreSum)
+}
+
+// AverageScore returns the average task score, null without scored tasks
+func (t ProgressTotals) AverageScore() null.Float {
+	if t.ScoresCount == 0 {
+		return null.Float{}
+	}
+	average, _ := t.ScoreSum.Div(decimal.NewFromInt(int64(t.ScoresCount))).Round(1).Float64()
+	return null.FloatFrom(average)
+}
+
+// TaskCompletionRate returns the percentage of tasks submitted, null without tasks
+func (t ProgressTotals) TaskCompletionRate() null.Float {
+	return progressRate(t.TasksSubmitted, t.TasksTotal)
+}
+
+// AttendanceRate returns the percentage of sessions attended, null without sessions
+func (t ProgressTotals) AttendanceRate() null.Float {
+	return progressRate(t.SessionsAttended, t.SessionsScheduled)
+}
+
+func progressRate(count, total int) null.Float {
+	if total == 0 {
+		return null.Float{}
+	}
+	return null.FloatFrom(math.Round(float64(count)/float64(total)*1000) / 10)
+}
+
+// ProgressSlope returns the least squares slope per period of the values,
+// skipping periods without a value. It needs at least minPoints values.
+func ProgressSlope(values []null.Float, minPoints int) (float64, bool) {
+	var n, sumX, sumY, sumXY, sumXX float64
+	for i, value := range values {
+		if !value.Valid {
+			continue
+		}
+		x := float64(i)
+		n++
+		sumX += x
+		sumY += value.Float64
+		sumXY += x * value.Float64
+		sumXX += x * x
+	}
+
+	if n < float64(minPoints) || n*sumXX == sumX*sumX {
+		return 0, false
+	}
+
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	return math.Round(slope*100) / 100, true
+}
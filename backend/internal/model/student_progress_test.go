@@ -0,0 +1,74 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+func TestProgressPeriods(t *testing.T) {
+	until := time.Date(2026, 2, 15, 10, 0, 0, 0, time.Local)
+
+	got := ProgressPeriods(until, 4)
+	want := []string{"202511", "202512", "202601", "202602"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProgressPeriods() = %v, want %v", got, want)
+	}
+
+	// The last day of a long month must not skip the short one after it
+	got = ProgressPeriods(time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local), 2)
+	want = []string{"202602", "202603"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProgressPeriods() = %v, want %v", got, want)
+	}
+}
+
+func TestProgressTotals(t *testing.T) {
+	var totals ProgressTotals
+	if totals.AverageScore().Valid || totals.TaskCompletionRate().Valid || totals.AttendanceRate().Valid {
+		t.Fatalf("ProgressTotals{} = %+v, want null figures without data", totals)
+	}
+
+	totals.Add(StudentProgressSummary{SessionsScheduled: 2, SessionsAttended: 2, TasksTotal: 2, TasksSubmitted: 1, ScoresCount: 1, ScoreSum: decimal.NewFromInt(80)})
+	totals.Add(StudentProgressSummary{SessionsScheduled: 1, TasksTotal: 1, ScoresCount: 2, ScoreSum: decimal.RequireFromString("175.5")})
+
+	if got := totals.AverageScore(); !got.Valid || got.Float64 != 85.2 {
+		t.Errorf("AverageScore() = %v, want 85.2", got)
+	}
+	if got := totals.TaskCompletionRate(); !got.Valid || got.Float64 != 33.3 {
+		t.Errorf("TaskCompletionRate() = %v, want 33.3", got)
+	}
+	if got := totals.AttendanceRate(); !got.Valid || got.Float64 != 66.7 {
+		t.Errorf("AttendanceRate() = %v, want 66.7", got)
+	}
+}
+
+func TestProgressSlope(t *testing.T) {
+	value := null.FloatFrom
+
+	tests := []struct {
+		name   string
+		values []null.Float
+		want   float64
+		wantOk bool
+	}{
+		{name: "rising", values: []null.Float{value(60), value(70), value(80)}, want: 10, wantOk: true},
+		{name: "falling with a gap", values: []null.Float{value(90), {}, value(80), value(75)}, want: -5, wantOk: true},
+		{name: "flat", values: []null.Float{value(50), value(50), value(50)}, want: 0, wantOk: true},
+		{name: "rounded", values: []null.Float{value(1), value(2), value(2)}, want: 0.5, wantOk: true},
+		{name: "too few points", values: []null.Float{value(60), {}, value(80)}},
+		{name: "no values", values: []null.Float{{}, {}, {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ProgressSlope(tt.values, 3)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("ProgressSlope() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
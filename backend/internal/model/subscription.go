@@ -1,6 +1,7 @@
 package model
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +21,16 @@ const (
 	SubscriptionIntervalYearly  SubscriptionInterval = "yearly"
 )
 
+// After returns the time count intervals after t.
+func (i SubscriptionInterval) After(t time.Time, count int) time.Time {
+	switch i {
+	case SubscriptionIntervalYearly:
+		return t.AddDate(count, 0, 0)
+	default:
+		return t.AddDate(0, count, 0)
+	}
+}
+
 type SubscriptionStatus string
 
 func (s SubscriptionStatus) InvoiceLabel() string {
@@ -27,6 +38,8 @@ func (s SubscriptionStatus) InvoiceLabel() string {
 	case SubscriptionStatusActive,
 		SubscriptionStatusInActive:
 		return "Paid"
+	case SubscriptionStatusRefunded:
+		return "Refunded"
 	default:
 		return "Unpaid"
 	}
@@ -38,8 +51,28 @@ const (
 	SubscriptionStatusPending  SubscriptionStatus = "pending"
 	SubscriptionStatusCanceled SubscriptionStatus = "canceled"
 	SubscriptionStatusExpired  SubscriptionStatus = "expired"
+	SubscriptionStatusTrialing SubscriptionStatus = "trialing"
+	SubscriptionStatusPastDue  SubscriptionStatus = "past_due"
+	SubscriptionStatusGrace    SubscriptionStatus = "grace"
+	SubscriptionStatusRefunded SubscriptionStatus = "refunded"
 )
 
+// subscriptionTransitions lists the statuses a recurring subscription can
+// move to from each status. Canceled and expired are final.
+var subscriptionTransitions = map[SubscriptionStatus][]SubscriptionStatus{
+	SubscriptionStatusPending:  {SubscriptionStatusTrialing, SubscriptionStatusActive, SubscriptionStatusExpired},
+	SubscriptionStatusTrialing: {SubscriptionStatusActive, SubscriptionStatusPastDue, SubscriptionStatusCanceled},
+	SubscriptionStatusActive:   {SubscriptionStatusActive, SubscriptionStatusPastDue, SubscriptionStatusCanceled},
+	SubscriptionStatusPastDue:  {SubscriptionStatusActive, SubscriptionStatusGrace, SubscriptionStatusExpired, SubscriptionStatusCanceled},
+	SubscriptionStatusGrace:    {SubscriptionStatusActive, SubscriptionStatusExpired, SubscriptionStatusCanceled},
+	// in_active is the failed status used before past_due and grace existed.
+	SubscriptionStatusInActive: {SubscriptionStatusActive, SubscriptionStatusExpired, SubscriptionStatusCanceled},
+}
+
+func (s SubscriptionStatus) CanTransitionTo(to SubscriptionStatus) bool {
+	return slices.Contains(subscriptionTransitions[s], to)
+}
+
 type Subscription struct {
 	ID            uuid.UUID            `gorm:"type:char(36);primary_key" json:"id"`
 	ReferenceID   string               `gorm:"type:varchar(255);not null" json:"reference_id"`
@@ -48,15 +81,26 @@ type Subscription struct {
 	IntervalCount int                  `json:"interval_count"`
 	StartDate     time.Time            `json:"start_date"`
 	EndDate       time.Time            `json:"end_date"`
+	TrialEndsAt   null.Time            `json:"trial_ends_at"`
 	Currency      string               `gorm:"type:char(3);not null" json:"currency"`
 	Amount        decimal.Decimal      `gorm:"type:decimal(12,2);not null" json:"amount"`
 	Status        SubscriptionStatus   `gorm:"type:varchar(255);not null" json:"status"`
-	CreatedAt     time.Time            `json:"created_at"`
-	UpdatedAt     time.Time            `json:"updated_at"`
-	DeletedAt     null.Time            `json:"deleted_at"`
-	CreatedBy     uuid.UUID            `gorm:"type:char(36)" json:"created_by"`
-	UpdatedBy     uuid.UUID            `gorm:"type:char(36)" json:"updated_by"`
-	DeletedBy     uuid.NullUUID        `gorm:"type:char(36)" json:"deleted_by"`
+
+	CancelAtPeriodEnd      bool            `json:"cancel_at_period_end"`
+	CanceledAt             null.Time       `json:"canceled_at"`
+	RetryCount             int             `json:"retry_count"`
+	LastFailedAt           null.Time       `json:"last_failed_at"`
+	GraceUntil             null.Time       `json:"grace_until"`
+	RemindedEndDate        null.Time       `json:"reminded_end_date"`
+	PreviousSubscriptionID uuid.NullUUID   `gorm:"type:char(36)" json:"previous_subscription_id"`
+	ProratedCredit         decimal.Decimal `gorm:"type:decimal(12,2);not null" json:"prorated_credit"`
+
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	DeletedAt null.Time     `json:"deleted_at"`
+	CreatedBy uuid.UUID     `gorm:"type:char(36)" json:"created_by"`
+	UpdatedBy uuid.UUID     `gorm:"type:char(36)" json:"updated_by"`
+	DeletedBy uuid.NullUUID `gorm:"type:char(36)" json:"deleted_by"`
 
 	Student       Student `gorm:"foreignKey:StudentID;references:ID"`
 	InvoiceNumber string  `gorm:"-"`
@@ -73,12 +117,95 @@ func (s Subscription) Name() string {
 	}
 }
 
-func (s Subscription) VatAmount() decimal.Decimal {
-	return s.Amount.Mul(decimal.NewFromFloat(0.11))
+func (s Subscription) VatAmount(rate TaxRate) decimal.Decimal {
+	return rate.Tax(s.Amount)
+}
+
+// AddPeriod returns t moved by the given number of billing periods.
+func (s Subscription) AddPeriod(t time.Time, periods int) time.Time {
+	switch s.Interval {
+	case SubscriptionIntervalYearly:
+		return t.AddDate(periods*s.IntervalCount, 0, 0)
+	default:
+		return t.AddDate(0, periods*s.IntervalCount, 0)
+	}
+}
+
+// PeriodStart returns the start of the billing period ending at EndDate.
+func (s Subscription) PeriodStart() time.Time {
+	start := s.AddPeriod(s.EndDate, -1)
+	if start.Before(s.StartDate) {
+		return s.StartDate
+	}
+
+	return start
+}
+
+// UnusedCredit returns the share of Amount not used yet at now, rounded to
+// whole rupiah. Trials and ended periods have no credit.
+func (s Subscription) UnusedCredit(now time.Time) decimal.Decimal {
+	start := s.PeriodStart()
+	if s.Status == SubscriptionStatusTrialing || !now.Before(s.EndDate) || !s.EndDate.After(start) {
+		return decimal.Zero
+	}
+
+	if now.Before(start) {
+		now = start
+	}
+
+	remaining := decimal.NewFromInt(int64(s.EndDate.Sub(now)))
+	total := decimal.NewFromInt(int64(s.EndDate.Sub(start)))
+
+	return s.Amount.Mul(remaining).Div(total).Round(0)
+}
+
+// CreditedPeriodEnd returns when the ProratedCredit of a new plan starting
+// at now is used up: the credit buys the same share of a period of the plan.
+func (s Subscription) CreditedPeriodEnd(now time.Time) time.Time {
+	if !s.Amount.IsPositive() {
+		return now
+	}
+
+	period := decimal.NewFromInt(int64(s.AddPeriod(now, 1).Sub(now)))
+	return now.Add(time.Duration(period.Mul(s.ProratedCredit).Div(s.Amount).IntPart()))
+}
+
+// CyclePayment returns the payment recording a paid cycle of the
+// subscription, for the period ending at EndDate, so the cycle is invoiced.
+func (s Subscription) CyclePayment(referenceID string, paidAt time.Time, rate TaxRate) Payment {
+	payment := Payment{
+		ID:            uuid.New(),
+		ReferenceID:   referenceID,
+		StudentID:     uuid.NullUUID{UUID: s.StudentID, Valid: true},
+		Type:          PaymentTypePremium,
+		Interval:      s.Interval,
+		IntervalCount: s.IntervalCount,
+		Seats:         1,
+		StartDate:     s.PeriodStart(),
+		EndDate:       s.EndDate,
+		Amount:        s.Amount,
+		Currency:      s.Currency,
+		PaidAt:        null.TimeFrom(paidAt),
+		Status:        SubscriptionStatusActive,
+		CreatedAt:     paidAt,
+		UpdatedAt:     paidAt,
+		CreatedBy:     uuid.MustParse(SystemID),
+		UpdatedBy:     uuid.MustParse(SystemID),
+		Student:       s.Student,
+		TaxAmount:     decimal.NewNullDecimal(rate.Tax(s.Amount)),
+	}
+	payment.GenerateInvoiceNumber()
+
+	return payment
 }
 
 type SubscriptionFilter struct {
-	StudentID uuid.UUID
-	Status    SubscriptionStatus
+	StudentID         uuid.UUID
+	Status            SubscriptionStatus
+	StatusIn          []SubscriptionStatus
+	EndDateBefore     time.Time
+	GraceUntilBefore  time.Time
+	CancelAtPeriodEnd null.Bool
+	NotRemindedOnly   bool
 	Pagination
 }
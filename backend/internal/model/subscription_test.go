@@ -0,0 +1,187 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestSubscriptionUnusedCredit(t *testing.T) {
+	var (
+		start = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end   = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name         string
+		subscription Subscription
+		now          time.Time
+		want         string
+	}{
+		{
+			name:         "half of the period left",
+			subscription: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, StartDate: start, EndDate: end, Amount: decimal.NewFromInt(100000), Status: SubscriptionStatusActive},
+			now:          start.Add(end.Sub(start) / 2),
+			want:         "50000",
+		},
+		{
+			name:         "credit is rounded to whole rupiah",
+			subscription: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, StartDate: start, EndDate: end, Amount: decimal.NewFromInt(100000), Status: SubscriptionStatusActive},
+			now:          start.AddDate(0, 0, 10),
+			want:         "67742",
+		},
+		{
+			name:         "renewed period is credited from its own start",
+			subscription: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, StartDate: start.AddDate(0, -3, 0), EndDate: end, Amount: decimal.NewFromInt(100000), Status: SubscriptionStatusActive},
+			now:          start.Add(end.Sub(start) / 2),
+			want:         "50000",
+		},
+		{
+			name:         "whole period left before it starts",
+			subscription: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, StartDate: start, EndDate: end, Amount: decimal.NewFromInt(100000), Status: SubscriptionStatusActive},
+			now:          start.Add(-time.Hour),
+			want:         "100000",
+		},
+		{
+			name:         "nothing left at the period end",
+			subscription: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, StartDate: start, EndDate: end, Amount: decimal.NewFromInt(100000), Status: SubscriptionStatusActive},
+			now:          end,
+			want:         "0",
+		},
+		{
+			name:         "nothing left after the period end",
+			subscription: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, StartDate: start, EndDate: end, Amount: decimal.NewFromInt(100000), Status: SubscriptionStatusActive},
+			now:          end.Add(time.Second),
+			want:         "0",
+		},
+		{
+			name:         "trial has no credit",
+			subscription: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, StartDate: start, EndDate: end, Amount: decimal.NewFromInt(100000), Status: SubscriptionStatusTrialing},
+			now:          start.Add(end.Sub(start) / 2),
+			want:         "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.subscription.UnusedCredit(tt.now); !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("UnusedCredit() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionCreditedPeriodEnd(t *testing.T) {
+	now := time.Date(2026, 1, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		next Subscription
+		want time.Time
+	}{
+		{
+			name: "mid-period upgrade to yearly",
+			next: Subscription{Interval: SubscriptionIntervalYearly, IntervalCount: 1, Amount: decimal.NewFromInt(1000000), ProratedCredit: decimal.NewFromInt(50000)},
+			want: now.Add(time.Duration(365*24*time.Hour) / 20),
+		},
+		{
+			name: "mid-period downgrade to monthly",
+			next: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, Amount: decimal.NewFromInt(100000), ProratedCredit: decimal.NewFromInt(600000)},
+			want: now.Add(6 * 31 * 24 * time.Hour),
+		},
+		{
+			name: "no credit is charged now",
+			next: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, Amount: decimal.NewFromInt(100000)},
+			want: now,
+		},
+		{
+			name: "free plan is charged now",
+			next: Subscription{Interval: SubscriptionIntervalMonthly, IntervalCount: 1, ProratedCredit: decimal.NewFromInt(50000)},
+			want: now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.next.CreditedPeriodEnd(now); !got.Equal(tt.want) {
+				t.Errorf("CreditedPeriodEnd() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from SubscriptionStatus
+		to   SubscriptionStatus
+		want bool
+	}{
+		{from: SubscriptionStatusPending, to: SubscriptionStatusActive, want: true},
+		{from: SubscriptionStatusPending, to: SubscriptionStatusTrialing, want: true},
+		{from: SubscriptionStatusPending, to: SubscriptionStatusExpired, want: true},
+		{from: SubscriptionStatusPending, to: SubscriptionStatusCanceled},
+		{from: SubscriptionStatusTrialing, to: SubscriptionStatusActive, want: true},
+		{from: SubscriptionStatusTrialing, to: SubscriptionStatusGrace},
+		{from: SubscriptionStatusActive, to: SubscriptionStatusActive, want: true},
+		{from: SubscriptionStatusActive, to: SubscriptionStatusPastDue, want: true},
+		{from: SubscriptionStatusActive, to: SubscriptionStatusCanceled, want: true},
+		{from: SubscriptionStatusActive, to: SubscriptionStatusExpired},
+		{from: SubscriptionStatusActive, to: SubscriptionStatusPending},
+		{from: SubscriptionStatusPastDue, to: SubscriptionStatusGrace, want: true},
+		{from: SubscriptionStatusPastDue, to: SubscriptionStatusActive, want: true},
+		{from: SubscriptionStatusGrace, to: SubscriptionStatusExpired, want: true},
+		{from: SubscriptionStatusGrace, to: SubscriptionStatusPastDue},
+		{from: SubscriptionStatusInActive, to: SubscriptionStatusActive, want: true},
+		{from: SubscriptionStatusCanceled, to: SubscriptionStatusActive},
+		{from: SubscriptionStatusCanceled, to: SubscriptionStatusCanceled},
+		{from: SubscriptionStatusExpired, to: SubscriptionStatusActive},
+		{from: SubscriptionStatusRefunded, to: SubscriptionStatusActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionCyclePayment(t *testing.T) {
+	var (
+		paidAt       = time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+		rate         = TaxRate{Rate: decimal.RequireFromString("0.12"), BaseNumerator: 11, BaseDenominator: 12}
+		subscription = Subscription{
+			StudentID:     uuid.New(),
+			Interval:      SubscriptionIntervalMonthly,
+			IntervalCount: 1,
+			StartDate:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:       time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			Currency:      CurrencyIDR,
+			Amount:        decimal.NewFromInt(120000),
+		}
+	)
+
+	payment := subscription.CyclePayment("cycle-1", paidAt, rate)
+
+	if payment.ReferenceID != "cycle-1" || !payment.StudentID.Valid || payment.StudentID.UUID != subscription.StudentID {
+		t.Errorf("payment = %+v, want the cycle of the student", payment)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !payment.StartDate.Equal(want) || !payment.EndDate.Equal(subscription.EndDate) {
+		t.Errorf("period = %s - %s, want %s - %s", payment.StartDate, payment.EndDate, want, subscription.EndDate)
+	}
+	if payment.Status != SubscriptionStatusActive || !payment.PaidAt.Valid || !payment.PaidAt.Time.Equal(paidAt) {
+		t.Errorf("payment is %s paid at %v, want active paid at %s", payment.Status, payment.PaidAt, paidAt)
+	}
+	if want := decimal.NewFromInt(13200); !payment.VatAmount().Equal(want) {
+		t.Errorf("VatAmount() = %s, want %s", payment.VatAmount(), want)
+	}
+	if want := decimal.NewFromInt(133200); !payment.Total().Equal(want) {
+		t.Errorf("Total() = %s, want %s", payment.Total(), want)
+	}
+	if payment.InvoiceNumber == "" {
+		t.Error("InvoiceNumber is empty")
+	}
+}
@@ -1,22 +1,41 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
 	"github.com/shopspring/decimal"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+const (
+	TaskSubmissionStatusPending   = "pending" // no submission yet, only used to filter tasks
+	TaskSubmissionStatusSubmitted = "submitted"
+	TaskSubmissionStatusGraded    = "graded"
+)
+
 type TaskSubmission struct {
 	ID            uuid.UUID           `gorm:"type:char(36);primary_key" json:"id"`
 	SessionTaskID uuid.UUID           `gorm:"type:char(36);not null" json:"session_task_id"`
+	Status        string              `gorm:"type:enum('submitted','graded');default:'submitted'" json:"status"`
 	SubmissionURL null.String         `gorm:"type:varchar(255)" json:"submission_url"`
 	Score         decimal.NullDecimal `gorm:"type:decimal(5,2)" json:"score"` // example: 100.00
-	CreatedAt     time.Time           `json:"created_at"`
-	UpdatedAt     time.Time           `json:"updated_at"`
-	DeletedAt     null.Time           `gorm:"index" json:"deleted_at"`
+	SubmittedAt   null.Time           `gorm:"type:timestamp" json:"submitted_at"`
+	IsLate        bool                `json:"is_late"`
+	// RubricScores holds the []RubricScore given per criterion of the task rubric
+	RubricScores datatypes.JSON `gorm:"type:json" json:"rubric_scores,omitempty"`
+	Feedback     null.String    `gorm:"type:text" json:"feedback"`
+	GradedAt     null.Time      `gorm:"type:timestamp" json:"graded_at"`
+	GradedBy     uuid.NullUUID  `gorm:"type:char(36)" json:"graded_by"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    null.Time      `gorm:"index" json:"deleted_at"`
+
+	Files       []TaskSubmissionFile `gorm:"foreignKey:TaskSubmissionID" json:"files"`
+	SessionTask *SessionTask         `gorm:"foreignKey:SessionTaskID" json:"session_task,omitempty"`
 }
 
 // BeforeCreate will set a UUID rather than numeric ID.
@@ -26,3 +45,50 @@ func (t *TaskSubmission) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// IsGraded returns true if the tutor has scored the submission
+func (t *TaskSubmission) IsGraded() bool {
+	return t.Status == TaskSubmissionStatusGraded
+}
+
+// GetRubricScores returns the scores given per criterion of the task rubric
+func (t *TaskSubmission) GetRubricScores() []RubricScore {
+	scores := []RubricScore{}
+	if len(t.RubricScores) == 0 {
+		return scores
+	}
+	_ = json.Unmarshal(t.RubricScores, &scores)
+	return scores
+}
+
+// RubricScore is the score given for one criterion of the task rubric.
+type RubricScore struct {
+	Criterion string          `json:"criterion" validate:"required"`
+	Score     decimal.Decimal `json:"score"`
+	Comment   string          `json:"comment,omitempty"`
+}
+
+// TaskSubmissionFile is a file uploaded by the student for a submission.
+type TaskSubmissionFile struct {
+	ID               uuid.UUID `gorm:"type:char(36);primary_key" json:"id"`
+	TaskSubmissionID uuid.UUID `gorm:"type:char(36);not null" json:"task_submission_id"`
+	URL              string    `gorm:"type:varchar(255);not null" json:"url"`
+	FileKey          string    `gorm:"type:varchar(255);not null" json:"-"`
+	Filename         string    `gorm:"type:varchar(255);not null" json:"filename"`
+	Size             int64     `json:"size"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (f *TaskSubmissionFile) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+type TaskSubmissionFilter struct {
+	TutorID uuid.UUID
+	Status  string
+	Pagination
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestSessionTask_IsPastDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		dueAt null.Time
+		want  bool
+	}{
+		{name: "no due date"},
+		{name: "due later", dueAt: null.TimeFrom(now.Add(time.Hour))},
+		{name: "due right now", dueAt: null.TimeFrom(now)},
+		{name: "due earlier", dueAt: null.TimeFrom(now.Add(-time.Hour)), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := SessionTask{DueAt: tt.dueAt}
+			if got := task.IsPastDue(now); got != tt.want {
+				t.Errorf("IsPastDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionTask_GetRubric(t *testing.T) {
+	task := SessionTask{}
+	if got := task.GetRubric(); got == nil || len(got) != 0 {
+		t.Errorf("GetRubric() = %v, want an empty rubric", got)
+	}
+
+	task.Rubric = mustJSON(t, []RubricCriterion{
+		{Name: "Isi", MaxScore: decimal.NewFromInt(60)},
+		{Name: "Tata bahasa", MaxScore: decimal.NewFromInt(40)},
+	})
+	got := task.GetRubric()
+	if len(got) != 2 || got[0].Name != "Isi" || !got[1].MaxScore.Equal(decimal.NewFromInt(40)) {
+		t.Errorf("GetRubric() = %+v, want the stored criteria", got)
+	}
+}
+
+func TestTaskSubmission_GetRubricScores(t *testing.T) {
+	submission := TaskSubmission{}
+	if got := submission.GetRubricScores(); got == nil || len(got) != 0 {
+		t.Errorf("GetRubricScores() = %v, want no scores", got)
+	}
+	if submission.IsGraded() {
+		t.Errorf("IsGraded() = true, want false for a new submission")
+	}
+
+	scores, err := json.Marshal([]RubricScore{{Criterion: "Isi", Score: decimal.NewFromInt(55), Comment: "Lengkap"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	submission = TaskSubmission{Status: TaskSubmissionStatusGraded, RubricScores: scores}
+	got := submission.GetRubricScores()
+	if len(got) != 1 || got[0].Criterion != "Isi" || !got[0].Score.Equal(decimal.NewFromInt(55)) || got[0].Comment != "Lengkap" {
+		t.Errorf("GetRubricScores() = %+v, want the stored scores", got)
+	}
+	if !submission.IsGraded() {
+		t.Errorf("IsGraded() = false, want true")
+	}
+}
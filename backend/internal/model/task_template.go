@@ -0,0 +1,122 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// TaskRubric is a grading rubric in the library of a tutor. Tasks copy the
+// criteria when assigned.
+type TaskRubric struct {
+	ID                  uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID             uuid.UUID     `gorm:"type:char(36);not null" json:"tutorId"`
+	CourseID            uuid.NullUUID `gorm:"type:char(36)" json:"courseId"`
+	SubCourseCategoryID uuid.NullUUID `gorm:"type:char(36)" json:"subCourseCategoryId"`
+	Name                string        `gorm:"type:varchar(255);not null" json:"name"`
+	Description         null.String   `gorm:"type:text" json:"description"`
+	// Criteria holds the []RubricCriterion of the rubric
+	Criteria  datatypes.JSON `gorm:"type:json;not null" json:"-"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt null.Time      `json:"-"`
+
+	Course            *Course            `gorm:"foreignKey:CourseID" json:"-"`
+	SubCourseCategory *SubCourseCategory `gorm:"foreignKey:SubCourseCategoryID" json:"-"`
+}
+
+func (TaskRubric) TableName() string {
+	return "task_rubrics"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (r *TaskRubric) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// GetCriteria returns the criteria of the rubric
+func (r *TaskRubric) GetCriteria() []RubricCriterion {
+	criteria := []RubricCriterion{}
+	if len(r.Criteria) == 0 {
+		return criteria
+	}
+	_ = json.Unmarshal(r.Criteria, &criteria)
+	return criteria
+}
+
+// TaskTemplate is a reusable task in the library of a tutor. DueDays sets
+// the due date of assigned tasks relative to the day of the session.
+type TaskTemplate struct {
+	ID                  uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID             uuid.UUID     `gorm:"type:char(36);not null" json:"tutorId"`
+	CourseID            uuid.NullUUID `gorm:"type:char(36)" json:"courseId"`
+	SubCourseCategoryID uuid.NullUUID `gorm:"type:char(36)" json:"subCourseCategoryId"`
+	TaskRubricID        uuid.NullUUID `gorm:"type:char(36)" json:"taskRubricId"`
+	Title               string        `gorm:"type:varchar(255);not null" json:"title"`
+	Description         null.String   `gorm:"type:text" json:"description"`
+	AttachmentURL       null.String   `gorm:"type:varchar(255)" json:"attachmentUrl"`
+	DueDays             null.Int      `json:"dueDays"`
+	CreatedAt           time.Time     `json:"createdAt"`
+	UpdatedAt           time.Time     `json:"updatedAt"`
+	DeletedAt           null.Time     `json:"-"`
+
+	TaskRubric        *TaskRubric        `gorm:"foreignKey:TaskRubricID" json:"-"`
+	Course            *Course            `gorm:"foreignKey:CourseID" json:"-"`
+	SubCourseCategory *SubCourseCategory `gorm:"foreignKey:SubCourseCategoryID" json:"-"`
+}
+
+func (TaskTemplate) TableName() string {
+	return "task_templates"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (t *TaskTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// DueAt returns the due date of the task assigned to a session on
+// bookingDate, the end of the day DueDays after it.
+func (t *TaskTemplate) DueAt(bookingDate time.Time) null.Time {
+	if !t.DueDays.Valid {
+		return null.Time{}
+	}
+
+	day := bookingDate.AddDate(0, 0, int(t.DueDays.Int64))
+	return null.TimeFrom(time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, time.Local))
+}
+
+// Task returns the session task of the template for the booking
+func (t *TaskTemplate) Task(booking Booking) SessionTask {
+	task := SessionTask{
+		BookingID:      booking.ID,
+		TaskTemplateID: uuid.NullUUID{UUID: t.ID, Valid: true},
+		Title:          t.Title,
+		Description:    t.Description,
+		AttachmentURL:  t.AttachmentURL,
+		DueAt:          t.DueAt(booking.BookingDate),
+	}
+
+	if t.TaskRubric != nil {
+		task.Rubric = t.TaskRubric.Criteria
+	}
+
+	return task
+}
+
+type TaskLibraryFilter struct {
+	TutorID             uuid.UUID
+	CourseID            uuid.UUID
+	SubCourseCategoryID uuid.UUID
+	Query               string
+	Pagination
+}
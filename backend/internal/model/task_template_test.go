@@ -0,0 +1,126 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+func criterion(name string, maxScore, weight int64) RubricCriterion {
+	return RubricCriterion{Name: name, MaxScore: decimal.NewFromInt(maxScore), Weight: decimal.NewFromInt(weight)}
+}
+
+func TestValidateRubric(t *testing.T) {
+	tests := []struct {
+		name    string
+		rubric  []RubricCriterion
+		wantErr bool
+	}{
+		{name: "max scores up to 100", rubric: []RubricCriterion{criterion("Isi", 60, 0), criterion("Tata bahasa", 40, 0)}},
+		{name: "max scores under 100", rubric: []RubricCriterion{criterion("Isi", 10, 0)}},
+		{name: "weights adding up to 100", rubric: []RubricCriterion{criterion("Isi", 4, 70), criterion("Tata bahasa", 5, 30)}},
+		{name: "empty", wantErr: true},
+		{name: "max scores over 100", rubric: []RubricCriterion{criterion("Isi", 60, 0), criterion("Tata bahasa", 41, 0)}, wantErr: true},
+		{name: "weights under 100", rubric: []RubricCriterion{criterion("Isi", 4, 70), criterion("Tata bahasa", 5, 20)}, wantErr: true},
+		{name: "partly weighted", rubric: []RubricCriterion{criterion("Isi", 4, 100), criterion("Tata bahasa", 5, 0)}, wantErr: true},
+		{name: "negative weight", rubric: []RubricCriterion{criterion("Isi", 4, 110), criterion("Tata bahasa", 5, -10)}, wantErr: true},
+		{name: "missing name", rubric: []RubricCriterion{criterion("", 10, 0)}, wantErr: true},
+		{name: "duplicate name", rubric: []RubricCriterion{criterion("Isi", 10, 0), criterion("Isi", 10, 0)}, wantErr: true},
+		{name: "zero max score", rubric: []RubricCriterion{criterion("Isi", 0, 0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRubric(tt.rubric); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRubric() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRubricTotal(t *testing.T) {
+	score := func(name, value string) RubricScore {
+		return RubricScore{Criterion: name, Score: decimal.RequireFromString(value)}
+	}
+	plain := []RubricCriterion{criterion("Isi", 60, 0), criterion("Tata bahasa", 40, 0)}
+	weighted := []RubricCriterion{criterion("Isi", 4, 70), criterion("Tata bahasa", 3, 30)}
+
+	tests := []struct {
+		name    string
+		rubric  []RubricCriterion
+		scores  []RubricScore
+		want    string
+		wantErr bool
+	}{
+		{name: "scores are summed", rubric: plain, scores: []RubricScore{score("Isi", "45"), score("Tata bahasa", "32.5")}, want: "77.5"},
+		// 3/4 of 70 plus 2/3 of 30
+		{name: "weighted scores", rubric: weighted, scores: []RubricScore{score("Isi", "3"), score("Tata bahasa", "2")}, want: "72.5"},
+		{name: "full marks", rubric: weighted, scores: []RubricScore{score("Tata bahasa", "3"), score("Isi", "4")}, want: "100"},
+		{name: "rounded to 2 decimals", rubric: weighted, scores: []RubricScore{score("Isi", "0"), score("Tata bahasa", "1")}, want: "10"},
+		{name: "missing criterion", rubric: plain, scores: []RubricScore{score("Isi", "45")}, wantErr: true},
+		{name: "duplicate criterion", rubric: plain, scores: []RubricScore{score("Isi", "45"), score("Isi", "10")}, wantErr: true},
+		{name: "unknown criterion", rubric: plain, scores: []RubricScore{score("Isi", "45"), score("Kerapian", "10")}, wantErr: true},
+		{name: "over max score", rubric: plain, scores: []RubricScore{score("Isi", "61"), score("Tata bahasa", "10")}, wantErr: true},
+		{name: "negative score", rubric: plain, scores: []RubricScore{score("Isi", "-1"), score("Tata bahasa", "10")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RubricTotal(tt.rubric, tt.scores)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RubricTotal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("RubricTotal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskRubric_GetCriteria(t *testing.T) {
+	rubric := TaskRubric{}
+	if got := rubric.GetCriteria(); got == nil || len(got) != 0 {
+		t.Errorf("GetCriteria() = %v, want no criteria", got)
+	}
+
+	rubric.Criteria = mustJSON(t, []RubricCriterion{criterion("Isi", 4, 70), criterion("Tata bahasa", 3, 30)})
+	got := rubric.GetCriteria()
+	if len(got) != 2 || got[1].Name != "Tata bahasa" || !got[1].Weight.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("GetCriteria() = %+v, want the stored criteria", got)
+	}
+}
+
+func TestTaskTemplate_Task(t *testing.T) {
+	booking := Booking{ID: uuid.New(), BookingDate: time.Date(2026, 3, 30, 10, 0, 0, 0, time.Local)}
+	rubric := &TaskRubric{Criteria: mustJSON(t, []RubricCriterion{criterion("Isi", 10, 0)})}
+	template := TaskTemplate{
+		ID:          uuid.New(),
+		Title:       "Esai",
+		Description: null.StringFrom("500 kata"),
+		DueDays:     null.IntFrom(3),
+		TaskRubric:  rubric,
+	}
+
+	task := template.Task(booking)
+	if task.BookingID != booking.ID || task.TaskTemplateID.UUID != template.ID || !task.TaskTemplateID.Valid {
+		t.Errorf("Task() = %+v, want the task of the booking from the template", task)
+	}
+	if task.Title != "Esai" || task.Description.String != "500 kata" || string(task.Rubric) != string(rubric.Criteria) {
+		t.Errorf("Task() = %+v, want the content and rubric of the template", task)
+	}
+	// Due at the end of the day, 3 days after the session, across the month
+	want := time.Date(2026, 4, 2, 23, 59, 59, 0, time.Local)
+	if !task.DueAt.Valid || !task.DueAt.Time.Equal(want) {
+		t.Errorf("Task().DueAt = %v, want %v", task.DueAt, want)
+	}
+
+	template.DueDays = null.Int{}
+	template.TaskRubric = nil
+	task = template.Task(booking)
+	if task.DueAt.Valid || task.Rubric != nil {
+		t.Errorf("Task() = %+v, want no due date and no rubric", task)
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const TaxNameVAT = "PPN"
+
+// TaxRate is a tax in effect from EffectiveFrom until the next rate of the
+// same name. The tax is charged on the price multiplied by
+// BaseNumerator/BaseDenominator (DPP nilai lain).
+type TaxRate struct {
+	ID              uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	Name            string          `gorm:"type:varchar(50);not null" json:"name"`
+	Rate            decimal.Decimal `gorm:"type:decimal(7,4);not null" json:"rate"`
+	BaseNumerator   int             `json:"baseNumerator"`
+	BaseDenominator int             `json:"baseDenominator"`
+	EffectiveFrom   time.Time       `json:"effectiveFrom"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+	CreatedBy       uuid.NullUUID   `gorm:"type:char(36)" json:"createdBy"`
+	UpdatedBy       uuid.NullUUID   `gorm:"type:char(36)" json:"updatedBy"`
+}
+
+func (TaxRate) TableName() string {
+	return "tax_rates"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (t *TaxRate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+func (t TaxRate) TaxBase(amount decimal.Decimal) decimal.Decimal {
+	if t.BaseDenominator == 0 {
+		return amount.Round(2)
+	}
+
+	return amount.Mul(decimal.NewFromInt(int64(t.BaseNumerator))).
+		Div(decimal.NewFromInt(int64(t.BaseDenominator))).
+		Round(2)
+}
+
+func (t TaxRate) Tax(amount decimal.Decimal) decimal.Decimal {
+	return t.TaxBase(amount).Mul(t.Rate).Round(2)
+}
+
+// Percent returns the rate in percent, e.g. 12 for 0.12.
+func (t TaxRate) Percent() decimal.Decimal {
+	return t.Rate.Mul(decimal.NewFromInt(100))
+}
+
+// Label returns the tax line label printed on invoices.
+func (t TaxRate) Label() string {
+	if t.BaseNumerator == t.BaseDenominator {
+		return fmt.Sprintf("VAT Out (%s%%)", t.Percent().String())
+	}
+
+	return fmt.Sprintf("VAT Out (%s%% * %d/%d)", t.Percent().String(), t.BaseNumerator, t.BaseDenominator)
+}
@@ -75,36 +75,42 @@ func (oc *OnlineChannel) Scan(value interface{}) error {
 }
 
 type Tutor struct {
-	ID               uuid.UUID           `gorm:"type:char(36);primary_key" json:"id"`
-	UserID           uuid.UUID           `gorm:"type:char(36);not null" json:"user_id"`
-	User             User                `gorm:"foreignKey:UserID" json:"user"`
-	Gender           null.String         `gorm:"type:varchar(50)" json:"gender"`
-	DateOfBirth      null.Time           `gorm:"type:date" json:"date_of_birth"`
-	PhoneNumber      null.String         `gorm:"type:varchar(20)" json:"phone_number"`
-	SocialMediaLink  []SocialMediaLink   `gorm:"serializer:social_media_link" json:"social_media_link"`
-	Description      string              `json:"description"`
-	PhotoProfile     null.String         `json:"photo_profile"`
-	ClassType        ClassType           `gorm:"type:enum('all','offline','online');default:'all'" json:"class_type"`
-	OnlineChannel    OnlineChannel       `json:"online_channel"`
-	LinkedinLink     null.String         `json:"linkedin_link"`
-	TiktokLink       null.String         `json:"tiktok_link"`
-	InstagramLink    null.String         `json:"instagram_link"`
-	Latitude         decimal.NullDecimal `json:"latitude"`
-	Longitude        decimal.NullDecimal `json:"longitude"`
-	LocationID       uuid.NullUUID       `gorm:"type:char(36);null" json:"location_id"`
-	Location         Location            `gorm:"foreignKey:LocationID" json:"location"`
-	Level            null.String         `json:"level"`
-	LevelPoint       uint                `gorm:"type:int unsigned;default:0" json:"level_point"`
-	LevelOfEducation null.String         `json:"level_of_education"`
-	ResponseTime     null.Int            `json:"response_time"`
-	Status           null.String         `json:"status"`
-	Address          null.String         `json:"address"`
-	CreatedAt        time.Time           `json:"created_at"`
-	UpdatedAt        time.Time           `json:"updated_at"`
-	DeletedAt        null.Time           `gorm:"index" json:"deleted_at"`
-	CreatedBy        uuid.NullUUID       `gorm:"type:char(36)" json:"created_by"`
-	UpdatedBy        uuid.NullUUID       `gorm:"type:char(36)" json:"updated_by"`
-	DeletedBy        uuid.NullUUID       `gorm:"type:char(36)" json:"deleted_by"`
+	ID                uuid.UUID           `gorm:"type:char(36);primary_key" json:"id"`
+	UserID            uuid.UUID           `gorm:"type:char(36);not null" json:"user_id"`
+	User              User                `gorm:"foreignKey:UserID" json:"user"`
+	Gender            null.String         `gorm:"type:varchar(50)" json:"gender"`
+	DateOfBirth       null.Time           `gorm:"type:date" json:"date_of_birth"`
+	PhoneNumber       null.String         `gorm:"type:varchar(20)" json:"phone_number"`
+	SocialMediaLink   []SocialMediaLink   `gorm:"serializer:social_media_link" json:"social_media_link"`
+	Description       string              `json:"description"`
+	PhotoProfile      null.String         `json:"photo_profile"`
+	ClassType         ClassType           `gorm:"type:enum('all','offline','online');default:'all'" json:"class_type"`
+	OnlineChannel     OnlineChannel       `json:"online_channel"`
+	LinkedinLink      null.String         `json:"linkedin_link"`
+	TiktokLink        null.String         `json:"tiktok_link"`
+	InstagramLink     null.String         `json:"instagram_link"`
+	Latitude          decimal.NullDecimal `json:"latitude"`
+	Longitude         decimal.NullDecimal `json:"longitude"`
+	LocationID        uuid.NullUUID       `gorm:"type:char(36);null" json:"location_id"`
+	Location          Location            `gorm:"foreignKey:LocationID" json:"location"`
+	Level             null.String         `json:"level"`
+	LevelEvaluatedAt  null.Time           `json:"level_evaluated_at"`
+	LevelOfEducation  null.String         `json:"level_of_education"`
+	ResponseTime      null.Int            `json:"response_time"`
+	Rating            decimal.Decimal     `gorm:"type:decimal(4,2);not null;default:0" json:"rating"`
+	TotalRating       int                 `gorm:"not null;default:0" json:"total_rating"`
+	PunctualityRating decimal.Decimal     `gorm:"type:decimal(3,2);not null;default:0" json:"punctuality_rating"`
+	ClarityRating     decimal.Decimal     `gorm:"type:decimal(3,2);not null;default:0" json:"clarity_rating"`
+	PatienceRating    decimal.Decimal     `gorm:"type:decimal(3,2);not null;default:0" json:"patience_rating"`
+	MaterialsRating   decimal.Decimal     `gorm:"type:decimal(3,2);not null;default:0" json:"materials_rating"`
+	Status            null.String         `json:"status"`
+	Address           null.String         `json:"address"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+	DeletedAt         null.Time           `gorm:"index" json:"deleted_at"`
+	CreatedBy         uuid.NullUUID       `gorm:"type:char(36)" json:"created_by"`
+	UpdatedBy         uuid.NullUUID       `gorm:"type:char(36)" json:"updated_by"`
+	DeletedBy         uuid.NullUUID       `gorm:"type:char(36)" json:"deleted_by"`
 }
 
 // BeforeCreate will set a UUID rather than numeric ID.
@@ -115,20 +121,14 @@ func (t *Tutor) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-func (t *Tutor) LevelByPoint() string {
-	if t.LevelPoint < 10 {
+// LevelName returns the level assigned by the levelling engine, falling back
+// to the entry level for tutors that have not been evaluated yet.
+func (t *Tutor) LevelName() string {
+	if !t.Level.Valid || t.Level.String == "" {
 		return string(TutorLevelGuru)
 	}
 
-	if t.LevelPoint < 25 {
-		return string(TutorLevelGuruAktif)
-	}
-
-	if t.LevelPoint >= 25 {
-		return string(TutorLevelGuruFavorit)
-	}
-
-	return string(TutorLevelGuru)
+	return t.Level.String
 }
 
 func (t *Tutor) StatusLabel() string {
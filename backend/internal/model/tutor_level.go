@@ -0,0 +1,159 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// TutorLevelCriteria is the admin-configured requirement a tutor has to meet
+// to hold a level. Levels are evaluated in ascending Sequence and a tutor is
+// placed on the highest level whose criteria are all met.
+type TutorLevelCriteria struct {
+	ID                   uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	Level                TutorLevel      `gorm:"type:varchar(100);not null;uniqueIndex" json:"level"`
+	Sequence             int             `gorm:"not null" json:"sequence"`
+	MinCompletedSessions int             `gorm:"not null;default:0" json:"min_completed_sessions"`
+	MinRating            decimal.Decimal `gorm:"type:decimal(3,2);not null;default:0" json:"min_rating"`
+	RatingWindowDays     int             `gorm:"not null;default:90" json:"rating_window_days"`
+	MaxResponseTime      null.Int        `json:"max_response_time"`
+	MaxCancellationRate  null.Float      `gorm:"type:decimal(5,2)" json:"max_cancellation_rate"`
+	MinVerifiedDocuments int             `gorm:"not null;default:0" json:"min_verified_documents"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            time.Time       `json:"updated_at"`
+	UpdatedBy            uuid.NullUUID   `gorm:"type:char(36)" json:"updated_by"`
+}
+
+func (TutorLevelCriteria) TableName() string {
+	return "tutor_level_criteria"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (c *TutorLevelCriteria) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TutorLevelMetrics is a snapshot of the numbers a tutor is evaluated on.
+// Rating, response time and cancellation rate are measured over the rolling
+// window of the criteria being checked.
+type TutorLevelMetrics struct {
+	CompletedSessions int
+	AverageRating     decimal.Decimal
+	TotalRating       int
+	ResponseTime      null.Int
+	CancellationRate  float64
+	VerifiedDocuments int
+}
+
+// TutorLevelCheck is the outcome of one criterion for one level.
+type TutorLevelCheck struct {
+	Criterion string
+	Current   string
+	Required  string
+	Met       bool
+}
+
+// Evaluate checks the metrics against every criterion of the level.
+func (c TutorLevelCriteria) Evaluate(metrics TutorLevelMetrics) []TutorLevelCheck {
+	checks := []TutorLevelCheck{
+		{
+			Criterion: "completed_sessions",
+			Current:   fmt.Sprintf("%d", metrics.CompletedSessions),
+			Required:  fmt.Sprintf(">= %d", c.MinCompletedSessions),
+			Met:       metrics.CompletedSessions >= c.MinCompletedSessions,
+		},
+		{
+			Criterion: "average_rating",
+			Current:   metrics.AverageRating.StringFixed(2),
+			Required:  fmt.Sprintf(">= %s in the last %d days", c.MinRating.StringFixed(2), c.RatingWindowDays),
+			Met:       c.MinRating.IsZero() || metrics.AverageRating.GreaterThanOrEqual(c.MinRating),
+		},
+		{
+			Criterion: "verified_documents",
+			Current:   fmt.Sprintf("%d", metrics.VerifiedDocuments),
+			Required:  fmt.Sprintf(">= %d", c.MinVerifiedDocuments),
+			Met:       metrics.VerifiedDocuments >= c.MinVerifiedDocuments,
+		},
+	}
+
+	if c.MaxResponseTime.Valid {
+		current := "-"
+		if metrics.ResponseTime.Valid {
+			current = fmt.Sprintf("%d", metrics.ResponseTime.Int64)
+		}
+
+		// Tutors without any answered booking yet have no response time to
+		// hold against them.
+		checks = append(checks, TutorLevelCheck{
+			Criterion: "response_time",
+			Current:   current,
+			Required:  fmt.Sprintf("<= %d minutes", c.MaxResponseTime.Int64),
+			Met:       !metrics.ResponseTime.Valid || metrics.ResponseTime.Int64 <= c.MaxResponseTime.Int64,
+		})
+	}
+
+	if c.MaxCancellationRate.Valid {
+		checks = append(checks, TutorLevelCheck{
+			Criterion: "cancellation_rate",
+			Current:   fmt.Sprintf("%.2f", metrics.CancellationRate),
+			Required:  fmt.Sprintf("<= %.2f%%", c.MaxCancellationRate.Float64),
+			Met:       metrics.CancellationRate <= c.MaxCancellationRate.Float64,
+		})
+	}
+
+	return checks
+}
+
+// TutorLevelHistory records every level change together with the metrics and
+// the reason that caused it.
+type TutorLevelHistory struct {
+	ID                uuid.UUID       `gorm:"type:char(36);primaryKey" json:"id"`
+	TutorID           uuid.UUID       `gorm:"type:char(36);not null;index" json:"tutor_id"`
+	FromLevel         null.String     `gorm:"type:varchar(100)" json:"from_level"`
+	ToLevel           string          `gorm:"type:varchar(100);not null" json:"to_level"`
+	Reason            string          `gorm:"type:text;not null" json:"reason"`
+	CompletedSessions int             `json:"completed_sessions"`
+	AverageRating     decimal.Decimal `gorm:"type:decimal(3,2)" json:"average_rating"`
+	ResponseTime      null.Int        `json:"response_time"`
+	CancellationRate  decimal.Decimal `gorm:"type:decimal(5,2)" json:"cancellation_rate"`
+	VerifiedDocuments int             `json:"verified_documents"`
+	CreatedAt         time.Time       `json:"created_at"`
+	CreatedBy         uuid.NullUUID   `gorm:"type:char(36)" json:"created_by"`
+}
+
+func (TutorLevelHistory) TableName() string {
+	return "tutor_level_histories"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (h *TutorLevelHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
+
+// LevelChangeReason describes why a tutor moved from one level to another
+// based on the checks of the level they were evaluated against.
+func LevelChangeReason(promoted bool, level TutorLevel, checks []TutorLevelCheck) string {
+	if promoted {
+		return fmt.Sprintf("Met all criteria for %s", level)
+	}
+
+	unmet := make([]string, 0)
+	for _, check := range checks {
+		if !check.Met {
+			unmet = append(unmet, fmt.Sprintf("%s %s (required %s)", check.Criterion, check.Current, check.Required))
+		}
+	}
+
+	return fmt.Sprintf("No longer meets criteria for %s: %s", level, strings.Join(unmet, "; "))
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+)
+
+func TestTutorLevelCriteriaEvaluate(t *testing.T) {
+	criteria := TutorLevelCriteria{
+		Level:                "Expert",
+		MinCompletedSessions: 50,
+		MinRating:            decimal.RequireFromString("4.5"),
+		RatingWindowDays:     90,
+		MaxResponseTime:      null.IntFrom(60),
+		MaxCancellationRate:  null.FloatFrom(5),
+		MinVerifiedDocuments: 2,
+	}
+
+	passing := TutorLevelMetrics{
+		CompletedSessions: 50,
+		AverageRating:     decimal.RequireFromString("4.5"),
+		ResponseTime:      null.IntFrom(60),
+		CancellationRate:  5,
+		VerifiedDocuments: 2,
+	}
+
+	tests := []struct {
+		name     string
+		criteria TutorLevelCriteria
+		metrics  func(m *TutorLevelMetrics)
+		wantLen  int
+		unmet    []string
+	}{
+		{name: "every criterion met on the boundary", criteria: criteria, wantLen: 5},
+		{
+			name:     "too few sessions and low rating",
+			criteria: criteria,
+			metrics: func(m *TutorLevelMetrics) {
+				m.CompletedSessions = 49
+				m.AverageRating = decimal.RequireFromString("4.49")
+			},
+			wantLen: 5,
+			unmet:   []string{"completed_sessions", "average_rating"},
+		},
+		{
+			name:     "slow response and many cancellations",
+			criteria: criteria,
+			metrics: func(m *TutorLevelMetrics) {
+				m.ResponseTime = null.IntFrom(61)
+				m.CancellationRate = 5.01
+			},
+			wantLen: 5,
+			unmet:   []string{"response_time", "cancellation_rate"},
+		},
+		{
+			name:     "no response time yet is not held against the tutor",
+			criteria: criteria,
+			metrics:  func(m *TutorLevelMetrics) { m.ResponseTime = null.Int{} },
+			wantLen:  5,
+		},
+		{
+			name:     "missing documents",
+			criteria: criteria,
+			metrics:  func(m *TutorLevelMetrics) { m.VerifiedDocuments = 1 },
+			wantLen:  5,
+			unmet:    []string{"verified_documents"},
+		},
+		{
+			name:     "no minimum rating accepts unrated tutors",
+			criteria: TutorLevelCriteria{Level: "Starter", RatingWindowDays: 90},
+			metrics: func(m *TutorLevelMetrics) {
+				m.AverageRating = decimal.Zero
+				m.ResponseTime = null.IntFrom(10000)
+				m.CancellationRate = 100
+			},
+			wantLen: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := passing
+			if tt.metrics != nil {
+				tt.metrics(&metrics)
+			}
+
+			checks := tt.criteria.Evaluate(metrics)
+			if len(checks) != tt.wantLen {
+				t.Fatalf("Evaluate() returned %d checks, want %d", len(checks), tt.wantLen)
+			}
+
+			var unmet []string
+			for _, check := range checks {
+				if !check.Met {
+					unmet = append(unmet, check.Criterion)
+				}
+			}
+			if strings.Join(unmet, ",") != strings.Join(tt.unmet, ",") {
+				t.Errorf("unmet criteria = %v, want %v", unmet, tt.unmet)
+			}
+		})
+	}
+}
+
+func TestLevelChangeReason(t *testing.T) {
+	checks := []TutorLevelCheck{
+		{Criterion: "completed_sessions", Current: "12", Required: ">= 50", Met: false},
+		{Criterion: "average_rating", Current: "4.80", Required: ">= 4.50 in the last 90 days", Met: true},
+		{Criterion: "verified_documents", Current: "0", Required: ">= 2", Met: false},
+	}
+
+	if got, want := LevelChangeReason(true, "Expert", checks), "Met all criteria for Expert"; got != want {
+		t.Errorf("LevelChangeReason(promoted) = %q, want %q", got, want)
+	}
+
+	want := "No longer meets criteria for Expert: completed_sessions 12 (required >= 50); verified_documents 0 (required >= 2)"
+	if got := LevelChangeReason(false, "Expert", checks); got != want {
+		t.Errorf("LevelChangeReason(demoted) = %q, want %q", got, want)
+	}
+}
@@ -7,25 +7,51 @@ import (
 	"github.com/guregu/null/v6"
 )
 
+type ReviewModerationStatus string
+
+const (
+	ReviewModerationStatusPublished ReviewModerationStatus = "published"
+	ReviewModerationStatusPending   ReviewModerationStatus = "pending"
+	ReviewModerationStatusHidden    ReviewModerationStatus = "hidden"
+)
+
 type TutorReview struct {
-	ID                 uuid.UUID     `gorm:"type:char(36);primary_key" json:"id"`
-	BookingID          uuid.UUID     `gorm:"type:char(36);not null" json:"booking_id"`
-	CourseID           uuid.UUID     `gorm:"type:char(36);not null" json:"course_id"`
-	TutorID            uuid.UUID     `gorm:"type:char(36);not null" json:"tutor_id"`
-	StudentID          uuid.UUID     `gorm:"type:char(36);not null" json:"student_id"`
-	Review             null.String   `gorm:"type:text" json:"review"`
-	Rate               null.Int      `json:"rate"`
-	RecommendByStudent null.String   `gorm:"type:varchar(255)" json:"recommend_by_student"`
-	IsSubmitted        bool          `json:"is_submitted"`
-	CreatedAt          time.Time     `json:"created_at"`
-	UpdatedAt          time.Time     `json:"updated_at"`
-	DeletedAt          null.Time     `json:"deleted_at"`
-	CreatedBy          uuid.NullUUID `gorm:"type:char(36)" json:"created_by"`
-	UpdatedBy          uuid.NullUUID `gorm:"type:char(36)" json:"updated_by"`
-	DeletedBy          uuid.NullUUID `gorm:"type:char(36)" json:"deleted_by"`
+	ID                 uuid.UUID              `gorm:"type:char(36);primary_key" json:"id"`
+	BookingID          uuid.UUID              `gorm:"type:char(36);not null" json:"booking_id"`
+	CourseID           uuid.UUID              `gorm:"type:char(36);not null" json:"course_id"`
+	TutorID            uuid.UUID              `gorm:"type:char(36);not null" json:"tutor_id"`
+	StudentID          uuid.UUID              `gorm:"type:char(36);not null" json:"student_id"`
+	Review             null.String            `gorm:"type:text" json:"review"`
+	Rate               null.Int               `json:"rate"`
+	PunctualityRate    null.Int               `json:"punctuality_rate"`
+	ClarityRate        null.Int               `json:"clarity_rate"`
+	PatienceRate       null.Int               `json:"patience_rate"`
+	MaterialsRate      null.Int               `json:"materials_rate"`
+	RecommendByStudent null.String            `gorm:"type:varchar(255)" json:"recommend_by_student"`
+	TutorReply         null.String            `gorm:"type:text" json:"tutor_reply"`
+	TutorRepliedAt     null.Time              `json:"tutor_replied_at"`
+	HelpfulCount       int                    `gorm:"not null;default:0" json:"helpful_count"`
+	IsSubmitted        bool                   `json:"is_submitted"`
+	ModerationStatus   ReviewModerationStatus `gorm:"type:varchar(50);not null;default:'published'" json:"moderation_status"`
+	ModerationNote     null.String            `gorm:"type:varchar(500)" json:"moderation_note"`
+	ModeratedAt        null.Time              `json:"moderated_at"`
+	ModeratedBy        uuid.NullUUID          `gorm:"type:char(36)" json:"moderated_by"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	DeletedAt          null.Time              `json:"deleted_at"`
+	CreatedBy          uuid.NullUUID          `gorm:"type:char(36)" json:"created_by"`
+	UpdatedBy          uuid.NullUUID          `gorm:"type:char(36)" json:"updated_by"`
+	DeletedBy          uuid.NullUUID          `gorm:"type:char(36)" json:"deleted_by"`
+
+	Booking Booking        `gorm:"foreignKey:BookingID" json:"booking"`
+	Course  Course         `gorm:"foreignKey:CourseID" json:"course"`
+	Tutor   Tutor          `gorm:"foreignKey:TutorID" json:"tutor"`
+	Student Student        `gorm:"foreignKey:StudentID" json:"student"`
+	Reports []ReviewReport `gorm:"foreignKey:TutorReviewID" json:"reports"`
+}
 
-	Booking Booking `gorm:"foreignKey:BookingID" json:"booking"`
-	Course  Course  `gorm:"foreignKey:CourseID" json:"course"`
-	Tutor   Tutor   `gorm:"foreignKey:TutorID" json:"tutor"`
-	Student Student `gorm:"foreignKey:StudentID" json:"student"`
+// IsPublic reports whether the review can be shown on course and tutor pages
+// and counted towards the aggregated ratings.
+func (r *TutorReview) IsPublic() bool {
+	return r.IsSubmitted && !r.DeletedAt.Valid && r.ModerationStatus == ReviewModerationStatusPublished
 }
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+func TestTutorReviewIsPublic(t *testing.T) {
+	tests := []struct {
+		name   string
+		review TutorReview
+		want   bool
+	}{
+		{
+			name:   "submitted and published",
+			review: TutorReview{IsSubmitted: true, ModerationStatus: ReviewModerationStatusPublished},
+			want:   true,
+		},
+		{
+			name:   "not submitted",
+			review: TutorReview{ModerationStatus: ReviewModerationStatusPublished},
+		},
+		{
+			name:   "held for moderation",
+			review: TutorReview{IsSubmitted: true, ModerationStatus: ReviewModerationStatusPending},
+		},
+		{
+			name:   "hidden by moderator",
+			review: TutorReview{IsSubmitted: true, ModerationStatus: ReviewModerationStatusHidden},
+		},
+		{
+			name:   "deleted",
+			review: TutorReview{IsSubmitted: true, ModerationStatus: ReviewModerationStatusPublished, DeletedAt: null.TimeFrom(time.Now())},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.review.IsPublic(); got != tt.want {
+				t.Errorf("IsPublic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
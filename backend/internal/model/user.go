@@ -22,6 +22,7 @@ type User struct {
 	Name        string
 	Email       string
 	PhoneNumber string
+	Currency    null.String
 	Password    string
 	LoginSource LoginSource `gorm:"type:enum('email','google');default:'email';not null"`
 	VerifiedAt  null.Time
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"github.com/shopspring/decimal"
 )
 
@@ -11,28 +12,74 @@ type WithdrawalStatus string
 
 const (
 	WithdrawalStatusPending    WithdrawalStatus = "pending"
+	WithdrawalStatusApproved   WithdrawalStatus = "approved"
 	WithdrawalStatusProcessing WithdrawalStatus = "processing"
 	WithdrawalStatusCompleted  WithdrawalStatus = "completed"
 	WithdrawalStatusRejected   WithdrawalStatus = "rejected"
+	WithdrawalStatusFailed     WithdrawalStatus = "failed"
 )
 
 type WithdrawalRequest struct {
 	ID            uuid.UUID        `gorm:"type:char(36);primaryKey" json:"id"`
 	TutorID       uuid.UUID        `gorm:"type:char(36);not null;index" json:"tutor_id"`
 	Amount        decimal.Decimal  `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Currency      string           `gorm:"type:char(3);not null;default:'IDR'" json:"currency"`
+	BankAccountID uuid.NullUUID    `gorm:"type:char(36)" json:"bank_account_id"`
+	BankCode      string           `gorm:"type:varchar(50)" json:"bank_code"`
 	BankName      string           `gorm:"type:varchar(100)" json:"bank_name"`
 	AccountNumber string           `gorm:"type:varchar(50)" json:"account_number"`
 	AccountName   string           `gorm:"type:varchar(100)" json:"account_name"`
-	Status        WithdrawalStatus `gorm:"type:enum('pending','processing','completed','rejected');default:'pending'" json:"status"`
+	Status        WithdrawalStatus `gorm:"type:enum('pending','approved','processing','completed','rejected','failed');default:'pending'" json:"status"`
 	AdminNote     string           `gorm:"type:varchar(500)" json:"admin_note"`
 	ProcessedAt   *time.Time       `json:"processed_at"`
 	ProcessedBy   uuid.NullUUID    `gorm:"type:char(36)" json:"processed_by"`
 	CreatedAt     time.Time        `json:"created_at"`
 	UpdatedAt     time.Time        `json:"updated_at"`
 
+	// Set once the request is sent through the disbursement provider.
+	PayoutBatchID  uuid.NullUUID `gorm:"type:char(36)" json:"payout_batch_id"`
+	DisbursementID null.String   `gorm:"type:varchar(255)" json:"disbursement_id"`
+	FailureCode    null.String   `gorm:"type:varchar(255)" json:"failure_code"`
+
 	Tutor Tutor `gorm:"foreignKey:TutorID" json:"tutor"`
 }
 
 func (WithdrawalRequest) TableName() string {
 	return "withdrawal_requests"
 }
+
+// Held tells whether the amount was taken from the mentor balance and not
+// paid out or released yet.
+func (w WithdrawalRequest) Held() bool {
+	return w.Status == WithdrawalStatusApproved || w.Status == WithdrawalStatusProcessing
+}
+
+// HoldTransaction returns the debit holding the withdrawal amount from the
+// mentor balance once it is approved.
+func (w WithdrawalRequest) HoldTransaction(currency string) BalanceTransaction {
+	return BalanceTransaction{
+		ID:            uuid.New(),
+		TutorID:       w.TutorID,
+		Type:          BalanceTransactionDebit,
+		Amount:        w.Amount,
+		ReferenceType: BalanceReferenceWithdrawal,
+		ReferenceID:   w.ID,
+		Description:   "Withdrawal approved",
+		Currency:      currency,
+	}
+}
+
+// ReleaseTransaction returns the credit giving the held amount back to the
+// mentor balance when the payout failed.
+func (w WithdrawalRequest) ReleaseTransaction(currency string) BalanceTransaction {
+	return BalanceTransaction{
+		ID:            uuid.New(),
+		TutorID:       w.TutorID,
+		Type:          BalanceTransactionCredit,
+		Amount:        w.Amount,
+		ReferenceType: BalanceReferenceWithdrawal,
+		ReferenceID:   w.ID,
+		Description:   "Withdrawal payout failed, funds released",
+		Currency:      currency,
+	}
+}
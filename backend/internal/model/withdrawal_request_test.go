@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestWithdrawalRequestHeld(t *testing.T) {
+	tests := []struct {
+		status WithdrawalStatus
+		want   bool
+	}{
+		{status: WithdrawalStatusPending},
+		{status: WithdrawalStatusApproved, want: true},
+		{status: WithdrawalStatusProcessing, want: true},
+		{status: WithdrawalStatusCompleted},
+		{status: WithdrawalStatusRejected},
+		{status: WithdrawalStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := (WithdrawalRequest{Status: tt.status}).Held(); got != tt.want {
+				t.Errorf("Held() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithdrawalRequestHoldAndRelease(t *testing.T) {
+	w := WithdrawalRequest{ID: uuid.New(), TutorID: uuid.New(), Amount: decimal.NewFromInt(150000)}
+
+	hold := w.HoldTransaction(CurrencyIDR)
+	release := w.ReleaseTransaction(CurrencyIDR)
+
+	tests := []struct {
+		name     string
+		tx       BalanceTransaction
+		wantType BalanceTransactionType
+	}{
+		{name: "approval holds the amount", tx: hold, wantType: BalanceTransactionDebit},
+		{name: "failed payout credits it back", tx: release, wantType: BalanceTransactionCredit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tx.Type != tt.wantType {
+				t.Errorf("Type = %s, want %s", tt.tx.Type, tt.wantType)
+			}
+			if !tt.tx.Amount.Equal(w.Amount) || tt.tx.Currency != CurrencyIDR {
+				t.Errorf("Amount = %s %s, want %s %s", tt.tx.Amount, tt.tx.Currency, w.Amount, CurrencyIDR)
+			}
+			if tt.tx.TutorID != w.TutorID || tt.tx.ReferenceType != BalanceReferenceWithdrawal || tt.tx.ReferenceID != w.ID {
+				t.Errorf("transaction = %+v, want one of the withdrawal of the mentor", tt.tx)
+			}
+		})
+	}
+
+	if hold.ID == release.ID {
+		t.Error("hold and release share the same id")
+	}
+}
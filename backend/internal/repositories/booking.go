@@ -34,8 +34,16 @@ func (r *BookingRepository) Get(ctx context.Context, filter model.BookingFilter)
 		Preload("Student.User").
 		Preload("Tutor.User").
 		Preload("Course").
+		Preload("Meeting").
 		Where("bookings.deleted_at IS NULL")
 
+	if filter.WithProgress {
+		db = db.Preload("Course.CourseCategory").
+			Preload("ReportBooking").
+			Preload("SessionTasks.TaskSubmissions.Files").
+			Preload("LessonNote.Milestones")
+	}
+
 	if len(filter.NotIDs) > 0 {
 		db = db.Where("id NOT IN (?)", filter.NotIDs)
 	}
@@ -52,6 +60,10 @@ func (r *BookingRepository) Get(ctx context.Context, filter model.BookingFilter)
 		db = db.Where("student_id = ?", filter.StudentID)
 	}
 
+	if len(filter.StudentIDs) > 0 {
+		db = db.Where("student_id IN (?)", filter.StudentIDs)
+	}
+
 	if filter.TutorID != uuid.Nil {
 		db = db.Where("tutor_id = ?", filter.TutorID)
 	}
@@ -98,6 +110,14 @@ func (r *BookingRepository) Get(ctx context.Context, filter model.BookingFilter)
 		db = db.Where("booking_date = ?", filter.BookingDate.Format(time.DateOnly))
 	}
 
+	if !filter.BookingDateFrom.IsZero() {
+		db = db.Where("booking_date >= ?", filter.BookingDateFrom.Format(time.DateOnly))
+	}
+
+	if !filter.BookingDateUntil.IsZero() {
+		db = db.Where("booking_date <= ?", filter.BookingDateUntil.Format(time.DateOnly))
+	}
+
 	if !filter.BookingTime.IsZero() {
 		db = db.Where("booking_time = ?", filter.BookingTime.Format(time.TimeOnly))
 	}
@@ -126,6 +146,18 @@ func (r *BookingRepository) Get(ctx context.Context, filter model.BookingFilter)
 		db = db.Where("is_reviewed = ?", filter.IsReviewed.Bool)
 	}
 
+	if filter.IsGroupSession.Valid {
+		if filter.IsGroupSession.Bool {
+			db = db.Where("bookings.group_session_id IS NOT NULL")
+		} else {
+			db = db.Where("bookings.group_session_id IS NULL")
+		}
+	}
+
+	if filter.GroupSessionID != uuid.Nil {
+		db = db.Where("bookings.group_session_id = ?", filter.GroupSessionID)
+	}
+
 	if filter.DeletedAtIsNil.Valid {
 		if filter.DeletedAtIsNil.Bool {
 			db = db.Where("bookings.deleted_at IS NULL")
@@ -163,7 +195,10 @@ func (r *BookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.B
 		Preload("Course.CourseCategory").
 		Preload("ReportBooking").
 		Preload("SessionTasks").
-		Preload("SessionTasks.TaskSubmissions")
+		Preload("SessionTasks.TaskSubmissions.Files").
+		Preload("LessonNote.Milestones").
+		Preload("Attendance").
+		Preload("Meeting")
 	err := db.Where("id = ?", id).First(&result).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -192,6 +227,10 @@ func (r *BookingRepository) Count(ctx context.Context, filter model.BookingFilte
 		db = db.Where("student_id = ?", filter.StudentID)
 	}
 
+	if filter.TutorID != uuid.Nil {
+		db = db.Where("tutor_id = ?", filter.TutorID)
+	}
+
 	if filter.CourseCategoryID != uuid.Nil {
 		subQuery := r.db.Read.Model(&model.Course{}).Select("id").
 			Where("course_category_id = ?", filter.CourseCategoryID)
@@ -259,6 +298,24 @@ func (r *BookingRepository) BulkUpdate(ctx context.Context, bookings []model.Boo
 	})
 }
 
+// GetStudentIDs returns the students with an accepted booking between the
+// dates.
+func (r *BookingRepository) GetStudentIDs(ctx context.Context, from, until time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Read.WithContext(ctx).
+		Model(&model.Booking{}).
+		Distinct("student_id").
+		Where("deleted_at IS NULL AND status IN ?", []model.BookingStatus{model.BookingStatusAccepted, model.BookingStatusCompleted}).
+		Where("booking_date BETWEEN ? AND ?", from.Format(time.DateOnly), until.Format(time.DateOnly)).
+		Pluck("student_id", &ids).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentIDs] Error getting students with bookings")
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 func (r *BookingRepository) GetTopBookedTutors(ctx context.Context, limit int) ([]model.TutorBookingStatistic, error) {
 	var results []model.TutorBookingStatistic
 
@@ -321,3 +378,27 @@ func (r *BookingRepository) GetTopBookedCategories(ctx context.Context, limit in
 
 	return results, nil
 }
+
+// CountByCategory counts the bookings of a student created on the given day
+// per course category. Total only counts bookings that are still pending or
+// accepted while FreeFirstCourse counts every free first course booking.
+func (r *BookingRepository) CountByCategory(ctx context.Context, studentID uuid.UUID, date time.Time) ([]model.CategoryBookingCount, error) {
+	var results []model.CategoryBookingCount
+
+	err := r.db.Read.WithContext(ctx).Model(&model.Booking{}).
+		Select("courses.course_category_id, "+
+			"SUM(CASE WHEN bookings.status IN (?) THEN 1 ELSE 0 END) as total, "+
+			"SUM(CASE WHEN bookings.is_free_first_course THEN 1 ELSE 0 END) as free_first_course",
+			model.BookedStatuses).
+		Joins("JOIN courses ON courses.id = bookings.course_id").
+		Where("bookings.student_id = ? AND date(bookings.created_at) = ?", studentID, date.Format(time.DateOnly)).
+		Where("bookings.deleted_at IS NULL").
+		Group("courses.course_category_id").
+		Scan(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("student_id", studentID.String()).Msg("[CountByCategory] Error counting bookings by category")
+		return nil, err
+	}
+
+	return results, nil
+}
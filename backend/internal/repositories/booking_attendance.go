@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type BookingAttendanceRepository struct {
+	db *infras.MySQL
+}
+
+func NewBookingAttendanceRepository(db *infras.MySQL) *BookingAttendanceRepository {
+	return &BookingAttendanceRepository{db: db}
+}
+
+func (r *BookingAttendanceRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) (*model.BookingAttendance, error) {
+	var result model.BookingAttendance
+	err := r.db.Read.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", bookingID.String()).Msg("[GetByBookingID] Error getting attendance")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// errAttendanceChanged rolls back a save whose attendance was updated in the
+// meantime.
+var errAttendanceChanged = errors.New("attendance changed")
+
+// Save sets the given columns of the attendance, creating it on the first
+// check-in to the session, while the booking is in the from status, moving the
+// booking to its status when given. Each column is only ever set once, so the
+// save is skipped when one of them is set already or the booking left the
+// from status. It reports whether the attendance was saved, reloading it with
+// what the other party saved meanwhile.
+func (r *BookingAttendanceRepository) Save(ctx context.Context, attendance *model.BookingAttendance, columns []string, booking *model.Booking, from model.BookingStatus) (bool, error) {
+	saved := false
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The booking row is locked so saves of both parties run one after another
+		if booking != nil {
+			result := tx.Model(&model.Booking{}).
+				Where("id = ? AND status = ?", booking.ID, from).
+				Updates(map[string]any{
+					"status":       booking.Status,
+					"completed_at": booking.CompletedAt,
+					"updated_at":   booking.UpdatedAt,
+					"updated_by":   booking.UpdatedBy,
+				})
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+		} else {
+			var current model.Booking
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "status").
+				Where("id = ?", attendance.BookingID).
+				Take(&current).Error
+			if err != nil || current.Status != from {
+				return err
+			}
+		}
+
+		// Both parties may check in first, the later insert keeps the row
+		err := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.BookingAttendance{ID: attendance.ID, BookingID: attendance.BookingID}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(columns) > 0 {
+			db := tx.Model(&model.BookingAttendance{}).Where("booking_id = ?", attendance.BookingID)
+			for _, column := range columns {
+				db = db.Where(column + " IS NULL")
+			}
+
+			result := db.Select(append([]string{"updated_at"}, columns...)).Updates(attendance)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errAttendanceChanged
+			}
+		}
+
+		var current model.BookingAttendance
+		err = tx.Where("booking_id = ?", attendance.BookingID).Take(&current).Error
+		if err != nil {
+			return err
+		}
+
+		*attendance = current
+		saved = true
+		return nil
+	})
+	if errors.Is(err, errAttendanceChanged) {
+		return false, nil
+	}
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", attendance.BookingID.String()).Msg("[Save] Error saving attendance")
+		return false, err
+	}
+
+	return saved, nil
+}
+
+func preloadAttendanceBooking(db *gorm.DB) *gorm.DB {
+	return db.Preload("Booking.Student.User").
+		Preload("Booking.Tutor.User").
+		Preload("Booking.Course")
+}
+
+// GetDisputes returns the disputed no-shows waiting for an admin, oldest
+// first.
+func (r *BookingAttendanceRepository) GetDisputes(ctx context.Context, pagination model.Pagination) ([]model.BookingAttendance, model.Metadata, error) {
+	var (
+		results  []model.BookingAttendance
+		total    int64
+		metadata = model.Metadata{
+			Page:     pagination.Page,
+			PageSize: pagination.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.BookingAttendance{}).
+		Where("disputed_at IS NOT NULL AND resolved_at IS NULL")
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetDisputes] Error counting disputes")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !pagination.IsEmpty() {
+		db = db.Limit(pagination.Limit()).
+			Offset(pagination.Offset())
+	}
+
+	err = preloadAttendanceBooking(db).
+		Order("disputed_at").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetDisputes] Error getting disputes")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// GetUnfinished returns the attendances of accepted sessions started before
+// the time which both parties checked in to, without the tutor checking out.
+func (r *BookingAttendanceRepository) GetUnfinished(ctx context.Context, startedBefore time.Time) ([]model.BookingAttendance, error) {
+	var results []model.BookingAttendance
+	err := preloadAttendanceBooking(r.db.Read.WithContext(ctx).Model(&model.BookingAttendance{})).
+		Joins("JOIN bookings ON bookings.id = booking_attendances.booking_id").
+		Where("bookings.status = ? AND bookings.deleted_at IS NULL", model.BookingStatusAccepted).
+		Where("TIMESTAMP(bookings.booking_date, bookings.booking_time) <= ?", startedBefore).
+		Where("booking_attendances.tutor_check_in_at IS NOT NULL AND booking_attendances.student_check_in_at IS NOT NULL").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetUnfinished] Error getting unfinished attendances")
+		return nil, err
+	}
+
+	return results, nil
+}
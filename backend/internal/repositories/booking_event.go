@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+)
+
+type BookingEventRepository struct {
+	db *infras.MySQL
+}
+
+func NewBookingEventRepository(db *infras.MySQL) *BookingEventRepository {
+	return &BookingEventRepository{db: db}
+}
+
+func (r *BookingEventRepository) Create(ctx context.Context, event *model.BookingEvent) error {
+	return r.db.Write.WithContext(ctx).Create(event).Error
+}
+
+func (r *BookingEventRepository) BulkCreate(ctx context.Context, events []model.BookingEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Write.WithContext(ctx).Create(&events).Error
+}
+
+func (r *BookingEventRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]model.BookingEvent, error) {
+	var events []model.BookingEvent
+	err := r.db.Read.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("occurred_at asc").
+		Find(&events).Error
+	return events, err
+}
+
+// GetResponseTimes returns, in minutes, how long the tutor took to approve or
+// decline each booking answered since the given time. Bookings answered by
+// whoever created them and status changes made by the system or an admin are
+// not tutor responses and are left out.
+func (r *BookingEventRepository) GetResponseTimes(ctx context.Context, tutorID uuid.UUID, since time.Time) ([]int64, error) {
+	return tutorResponseTimes(r.db.Read.WithContext(ctx), tutorID, since)
+}
+
+func tutorResponseTimes(db *gorm.DB, tutorID uuid.UUID, since time.Time) ([]int64, error) {
+	var minutes []int64
+	err := db.Table("booking_events AS responded").
+		Select("TIMESTAMPDIFF(MINUTE, created.occurred_at, responded.occurred_at)").
+		Joins("JOIN booking_events AS created ON created.booking_id = responded.booking_id AND created.type = ?", model.BookingEventCreated).
+		Where("responded.tutor_id = ?", tutorID).
+		Where("responded.type IN ?", []model.BookingEventType{model.BookingEventApproved, model.BookingEventDeclined}).
+		Where("responded.occurred_at >= ?", since).
+		Where("responded.actor_id <> created.actor_id").
+		Where("responded.actor_id <> ?", model.SystemID).
+		Scan(&minutes).Error
+	return minutes, err
+}
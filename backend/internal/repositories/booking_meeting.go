@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type BookingMeetingRepository struct {
+	db *infras.MySQL
+}
+
+func NewBookingMeetingRepository(db *infras.MySQL) *BookingMeetingRepository {
+	return &BookingMeetingRepository{db: db}
+}
+
+func (r *BookingMeetingRepository) Create(ctx context.Context, meeting *model.BookingMeeting) error {
+	err := r.db.Write.WithContext(ctx).Omit("Booking").Create(meeting).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", meeting.BookingID.String()).Msg("[Create] Error creating meeting")
+	}
+
+	return err
+}
+
+func (r *BookingMeetingRepository) MarkExpired(ctx context.Context, meeting *model.BookingMeeting) error {
+	err := r.db.Write.WithContext(ctx).Model(meeting).
+		Update("expired_at", meeting.ExpiredAt).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", meeting.BookingID.String()).Msg("[MarkExpired] Error expiring meeting")
+	}
+
+	return err
+}
+
+// GetExpiring returns the rooms still open at the provider whose time ran out
+// or whose session already ended.
+func (r *BookingMeetingRepository) GetExpiring(ctx context.Context, now time.Time) ([]model.BookingMeeting, error) {
+	var results []model.BookingMeeting
+	err := r.db.Read.WithContext(ctx).
+		Joins("JOIN bookings ON bookings.id = booking_meetings.booking_id").
+		Where("booking_meetings.expired_at IS NULL").
+		Where("booking_meetings.expires_at <= ? OR bookings.status NOT IN ? OR bookings.deleted_at IS NOT NULL",
+			now, []model.BookingStatus{model.BookingStatusPending, model.BookingStatusAccepted}).
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetExpiring] Error getting expiring meetings")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetBookingsWithoutRoom returns the accepted online bookings without a room
+// whose session ends after the time, those whose room could not be created
+// when they were accepted.
+func (r *BookingMeetingRepository) GetBookingsWithoutRoom(ctx context.Context, endsAfter time.Time, lifetime time.Duration) ([]model.Booking, error) {
+	var results []model.Booking
+	err := r.db.Read.WithContext(ctx).Model(&model.Booking{}).
+		Preload("Course").
+		Joins("LEFT JOIN booking_meetings ON booking_meetings.booking_id = bookings.id").
+		Where("booking_meetings.id IS NULL").
+		Where("bookings.status = ? AND bookings.class_type = ? AND bookings.deleted_at IS NULL",
+			model.BookingStatusAccepted, model.OnlineClassType).
+		Where("TIMESTAMP(bookings.booking_date, bookings.booking_time) > ?", endsAfter.Add(-lifetime)).
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetBookingsWithoutRoom] Error getting bookings")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetGroupRoom returns an open room of another seat of the group session,
+// the room every seat of the session joins.
+func (r *BookingMeetingRepository) GetGroupRoom(ctx context.Context, groupSessionID uuid.UUID) (*model.BookingMeeting, error) {
+	var result model.BookingMeeting
+	err := r.db.Read.WithContext(ctx).
+		Joins("JOIN bookings ON bookings.id = booking_meetings.booking_id").
+		Where("bookings.group_session_id = ? AND booking_meetings.expired_at IS NULL", groupSessionID).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Str("group_session_id", groupSessionID.String()).Msg("[GetGroupRoom] Error getting group room")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// IsRoomShared reports whether other seats still have the room of the
+// meeting open.
+func (r *BookingMeetingRepository) IsRoomShared(ctx context.Context, meeting model.BookingMeeting) (bool, error) {
+	var count int64
+	err := r.db.Read.WithContext(ctx).Model(&model.BookingMeeting{}).
+		Where("provider = ? AND room_id = ? AND id <> ? AND expired_at IS NULL", meeting.Provider, meeting.RoomID, meeting.ID).
+		Count(&count).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", meeting.BookingID.String()).Msg("[IsRoomShared] Error counting meetings")
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,337 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type ConversationRepository struct {
+	db *infras.MySQL
+}
+
+func NewConversationRepository(db *infras.MySQL) *ConversationRepository {
+	return &ConversationRepository{db: db}
+}
+
+func preloadConversationParties(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tutor.User").
+		Preload("Student.User").
+		Preload("Booking")
+}
+
+// participantScope limits conversations to those the user is the tutor or
+// the student of.
+func participantScope(userID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins("JOIN tutors ON tutors.id = conversations.tutor_id").
+			Joins("JOIN students ON students.id = conversations.student_id").
+			Where("tutors.user_id = ? OR students.user_id = ?", userID, userID)
+	}
+}
+
+func (r *ConversationRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Conversation, error) {
+	var result model.Conversation
+	err := preloadConversationParties(r.db.Read.WithContext(ctx)).
+		Where("id = ?", id).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetByID] Error getting conversation")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetByParticipants returns the conversation of the pair, the one about the
+// booking when one is given.
+func (r *ConversationRepository) GetByParticipants(ctx context.Context, tutorID, studentID uuid.UUID, bookingID uuid.NullUUID) (*model.Conversation, error) {
+	var result model.Conversation
+	db := preloadConversationParties(r.db.Read.WithContext(ctx)).
+		Where("tutor_id = ? AND student_id = ?", tutorID, studentID)
+	if bookingID.Valid {
+		db = db.Where("booking_id = ?", bookingID.UUID)
+	} else {
+		db = db.Where("booking_id IS NULL")
+	}
+
+	err := db.First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByParticipants] Error getting conversation")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (r *ConversationRepository) Create(ctx context.Context, conversation *model.Conversation) error {
+	err := r.db.Write.WithContext(ctx).Omit(clause.Associations).Create(conversation).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Create] Error creating conversation")
+	}
+
+	return err
+}
+
+// Get returns the conversations of the user, the latest message first.
+func (r *ConversationRepository) Get(ctx context.Context, filter model.ConversationFilter) ([]model.Conversation, model.Metadata, error) {
+	var (
+		results  []model.Conversation
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.Conversation{}).
+		Scopes(participantScope(filter.UserID))
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting conversations")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Limit()).
+			Offset(filter.Offset())
+	}
+
+	err = preloadConversationParties(db).
+		Order("COALESCE(conversations.last_message_at, conversations.created_at) DESC").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting conversations")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// CountUnread returns the number of messages the user has not read per
+// conversation.
+func (r *ConversationRepository) CountUnread(ctx context.Context, conversationIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		ConversationID uuid.UUID
+		Total          int64
+	}
+
+	counts := make(map[uuid.UUID]int64, len(conversationIDs))
+	if len(conversationIDs) == 0 {
+		return counts, nil
+	}
+
+	err := r.db.Read.WithContext(ctx).Model(&model.ConversationMessage{}).
+		Select("conversation_id, COUNT(*) AS total").
+		Where("conversation_id IN ? AND sender_id <> ? AND read_at IS NULL", conversationIDs, userID).
+		Group("conversation_id").
+		Scan(&rows).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CountUnread] Error counting unread messages")
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ConversationID] = row.Total
+	}
+
+	return counts, nil
+}
+
+// CountAllUnread returns the number of messages the user has not read
+// across their conversations.
+func (r *ConversationRepository) CountAllUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.Read.WithContext(ctx).Model(&model.ConversationMessage{}).
+		Joins("JOIN conversations ON conversations.id = conversation_messages.conversation_id").
+		Scopes(participantScope(userID)).
+		Where("conversation_messages.sender_id <> ? AND conversation_messages.read_at IS NULL", userID).
+		Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CountAllUnread] Error counting unread messages")
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// CreateMessage stores the message with its attachments and moves the
+// conversation up to it.
+func (r *ConversationRepository) CreateMessage(ctx context.Context, message *model.ConversationMessage) error {
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Conversation{}).
+			Where("id = ?", message.ConversationID).
+			Updates(map[string]any{
+				"last_message_at":      message.CreatedAt,
+				"last_message_preview": message.Preview(),
+			}).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("conversation_id", message.ConversationID.String()).Msg("[CreateMessage] Error creating message")
+	}
+
+	return err
+}
+
+// GetMessages returns the messages of the conversation, the latest first.
+func (r *ConversationRepository) GetMessages(ctx context.Context, conversationID uuid.UUID, pagination model.Pagination) ([]model.ConversationMessage, model.Metadata, error) {
+	var (
+		results  []model.ConversationMessage
+		total    int64
+		metadata = model.Metadata{
+			Page:     pagination.Page,
+			PageSize: pagination.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.ConversationMessage{}).
+		Where("conversation_id = ?", conversationID)
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMessages] Error counting messages")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !pagination.IsEmpty() {
+		db = db.Limit(pagination.Limit()).
+			Offset(pagination.Offset())
+	}
+
+	err = db.Preload("Attachments").
+		Order("created_at DESC").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMessages] Error getting messages")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// MarkRead marks the messages the other party sent read, returning how many
+// were unread.
+func (r *ConversationRepository) MarkRead(ctx context.Context, conversationID, readerID uuid.UUID, at time.Time) (int64, error) {
+	result := r.db.Write.WithContext(ctx).Model(&model.ConversationMessage{}).
+		Where("conversation_id = ? AND sender_id <> ? AND read_at IS NULL", conversationID, readerID).
+		Update("read_at", at)
+	if result.Error != nil {
+		logger.ErrorCtx(ctx).Err(result.Error).Str("conversation_id", conversationID.String()).Msg("[MarkRead] Error marking messages read")
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *ConversationRepository) HasPendingReport(ctx context.Context, conversationID, reporterID uuid.UUID) (bool, error) {
+	var total int64
+	err := r.db.Read.WithContext(ctx).Model(&model.ConversationReport{}).
+		Where("conversation_id = ? AND reporter_id = ? AND status = ?",
+			conversationID, reporterID, model.ConversationReportStatusPending).
+		Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[HasPendingReport] Error checking report")
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+func (r *ConversationRepository) CreateReport(ctx context.Context, report *model.ConversationReport) error {
+	err := r.db.Write.WithContext(ctx).Omit(clause.Associations).Create(report).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateReport] Error creating report")
+	}
+
+	return err
+}
+
+func (r *ConversationRepository) UpdateReport(ctx context.Context, report *model.ConversationReport) error {
+	err := r.db.Write.WithContext(ctx).Omit(clause.Associations).Save(report).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", report.ID.String()).Msg("[UpdateReport] Error updating report")
+	}
+
+	return err
+}
+
+func (r *ConversationRepository) GetReportByID(ctx context.Context, id uuid.UUID) (*model.ConversationReport, error) {
+	var result model.ConversationReport
+	err := r.db.Read.WithContext(ctx).
+		Preload("Conversation.Tutor.User").
+		Preload("Conversation.Student.User").
+		Preload("Reporter").
+		Where("id = ?", id).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetReportByID] Error getting report")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetReports returns the reports filed on conversations, the oldest first.
+func (r *ConversationRepository) GetReports(ctx context.Context, filter model.ConversationReportFilter) ([]model.ConversationReport, model.Metadata, error) {
+	var (
+		results  []model.ConversationReport
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.ConversationReport{})
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetReports] Error counting reports")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Limit()).
+			Offset(filter.Offset())
+	}
+
+	err = db.Preload("Conversation.Tutor.User").
+		Preload("Conversation.Student.User").
+		Preload("Reporter").
+		Order("created_at").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetReports] Error getting reports")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/google/uuid"
@@ -40,7 +41,10 @@ func (r *CourseRepository) Get(ctx context.Context, filter model.CourseFilter) (
 			return db
 		}).
 		Preload("TutorReviews", func(db *gorm.DB) *gorm.DB {
-			return db.Where("tutor_reviews.is_submitted = ?", 1)
+			return db.Where("tutor_reviews.is_submitted = ?", 1).
+				Where("tutor_reviews.deleted_at IS NULL").
+				Where("tutor_reviews.moderation_status = ?", model.ReviewModerationStatusPublished).
+				Order("tutor_reviews.helpful_count desc, tutor_reviews.updated_at desc")
 		}).
 		Preload("TutorReviews.Student.User").
 		Preload("Tutor.User").
@@ -76,11 +80,11 @@ func (r *CourseRepository) Get(ctx context.Context, filter model.CourseFilter) (
 	}
 
 	if filter.MinRating.Valid {
-		db = db.Where("rating >= ?", filter.MinRating)
+		db = db.Where("courses.rating >= ?", filter.MinRating)
 	}
 
 	if filter.MaxRating.Valid {
-		db = db.Where("rating < ?", filter.MaxRating)
+		db = db.Where("courses.rating < ?", filter.MaxRating)
 	}
 
 	if filter.MaxPrice.GreaterThan(decimal.Zero) {
@@ -238,7 +242,7 @@ func (r *CourseRepository) GetTutorCourse(ctx context.Context, filter model.Cour
 	return results, metadata, nil
 }
 
-func (r *CourseRepository) ApproveCourse(ctx context.Context, course *model.Course, draft *model.CourseDraft) error {
+func (r *CourseRepository) ApproveCourse(ctx context.Context, course *model.Course, draft *model.CourseDraft, change *model.CourseVersionChange) error {
 	return r.db.Write.Transaction(func(tx *gorm.DB) error {
 		if draft == nil {
 			logger.ErrorCtx(ctx).Msg("[ApproveCourse] Course draft is nil")
@@ -248,9 +252,12 @@ func (r *CourseRepository) ApproveCourse(ctx context.Context, course *model.Cour
 		err := tx.Model(&model.CourseDraft{}).
 			Where("id = ?", draft.ID).
 			Updates(map[string]any{
-				"status":     draft.Status,
-				"updated_by": draft.UpdatedBy,
-				"updated_at": draft.UpdatedAt,
+				"status":       draft.Status,
+				"reviewed_at":  draft.ReviewedAt,
+				"reviewed_by":  draft.ReviewedBy,
+				"review_notes": draft.ReviewNotes,
+				"updated_by":   draft.UpdatedBy,
+				"updated_at":   draft.UpdatedAt,
 			}).Error
 		if err != nil {
 			logger.ErrorCtx(ctx).Err(err).
@@ -325,11 +332,11 @@ func (r *CourseRepository) ApproveCourse(ctx context.Context, course *model.Cour
 			return err
 		}
 
-		return nil
+		return createCourseVersion(ctx, tx, change)
 	})
 }
 
-func (r *CourseRepository) UpdateAll(ctx context.Context, course *model.Course) error {
+func (r *CourseRepository) UpdateAll(ctx context.Context, course *model.Course, change *model.CourseVersionChange) error {
 	return r.db.Write.Transaction(func(tx *gorm.DB) error {
 		err := tx.Delete(&model.CoursePrice{}, "course_id = ?", course.ID).Error
 		if err != nil {
@@ -395,10 +402,115 @@ func (r *CourseRepository) UpdateAll(ctx context.Context, course *model.Course)
 			return err
 		}
 
+		return createCourseVersion(ctx, tx, change)
+	})
+}
+
+// createCourseVersion stores the change as the next version of the course.
+// Courses without any version yet get the state being replaced recorded as
+// version 1 first so there is always something to roll back to.
+func createCourseVersion(ctx context.Context, tx *gorm.DB, change *model.CourseVersionChange) error {
+	if change == nil {
+		return nil
+	}
+
+	var latest int
+	err := tx.Model(&model.CourseVersion{}).
+		Where("course_id = ?", change.Version.CourseID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[createCourseVersion] Error getting latest course version")
+		return err
+	}
+
+	if latest == 0 {
+		snapshot, err := json.Marshal(change.Previous)
+		if err != nil {
+			return err
+		}
+
+		latest++
+		initial := model.CourseVersion{
+			CourseID:  change.Version.CourseID,
+			Version:   latest,
+			Snapshot:  snapshot,
+			Source:    model.CourseVersionSourceInitial,
+			CreatedAt: change.Version.CreatedAt,
+			CreatedBy: change.Version.CreatedBy,
+		}
+		if err := tx.Create(&initial).Error; err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[createCourseVersion] Error creating initial course version")
+			return err
+		}
+	}
+
+	change.Version.Version = latest + 1
+	if err := tx.Create(&change.Version).Error; err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[createCourseVersion] Error creating course version")
+		return err
+	}
+
+	return nil
+}
+
+// RejectCourse stores the rejected status of the course together with the
+// review of its draft
+func (r *CourseRepository) RejectCourse(ctx context.Context, course *model.Course, draft *model.CourseDraft) error {
+	return r.db.Write.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Course{}).
+			Where("id = ?", course.ID).
+			Updates(map[string]any{
+				"status":       course.Status,
+				"status_notes": course.StatusNotes,
+				"updated_by":   course.UpdatedBy,
+				"updated_at":   course.UpdatedAt,
+			}).Error
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("course_id", course.ID.String()).Msg("[RejectCourse] Error updating course status")
+			return err
+		}
+
+		if draft == nil {
+			return nil
+		}
+
+		err = tx.Model(&model.CourseDraft{}).
+			Where("id = ?", draft.ID).
+			Updates(map[string]any{
+				"status":              draft.Status,
+				"reviewed_at":         draft.ReviewedAt,
+				"reviewed_by":         draft.ReviewedBy,
+				"review_notes":        draft.ReviewNotes,
+				"rejection_checklist": draft.RejectionChecklist,
+				"updated_by":          draft.UpdatedBy,
+				"updated_at":          draft.UpdatedAt,
+			}).Error
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("course_id", course.ID.String()).Msg("[RejectCourse] Error updating course draft status")
+			return err
+		}
+
 		return nil
 	})
 }
 
+// ExistsByTutorTitle reports whether the tutor has another course with the
+// same title, ignoring case and surrounding spaces
+func (r *CourseRepository) ExistsByTutorTitle(ctx context.Context, tutorID, excludeID uuid.UUID, title string) (bool, error) {
+	var count int64
+	err := r.db.Read.Model(&model.Course{}).
+		Where("tutor_id = ? AND id <> ? AND deleted_at IS NULL", tutorID, excludeID).
+		Where("LOWER(TRIM(title)) = LOWER(TRIM(?))", title).
+		Count(&count).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("tutor_id", tutorID.String()).Msg("[ExistsByTutorTitle] Error counting courses")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 // UpdateStatus updates the status of a course
 func (r *CourseRepository) UpdateStatus(ctx context.Context, course *model.Course) error {
 	err := r.db.Write.Model(&model.Course{}).
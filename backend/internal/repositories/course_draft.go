@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"gorm.io/gorm"
 
 	"github.com/lesprivate/backend/infras"
@@ -286,3 +287,101 @@ func (r *CourseDraftRepository) GetDraftsByCreator(ctx context.Context, creatorI
 
 	return drafts, metadata, nil
 }
+
+// GetModerationQueue gets the drafts waiting for review, oldest submission first
+func (r *CourseDraftRepository) GetModerationQueue(ctx context.Context, filter model.CourseModerationFilter) ([]model.CourseDraft, model.Metadata, error) {
+	var (
+		drafts   []model.CourseDraft
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+
+	db := r.db.Read.WithContext(ctx).Model(&model.CourseDraft{}).
+		Preload("Course").
+		Preload("Course.Tutor.User").
+		Preload("Assignee").
+		Joins("JOIN courses ON courses.id = course_drafts.course_id AND courses.deleted_at IS NULL").
+		Where("course_drafts.status = ? AND course_drafts.deleted_at IS NULL", model.DraftStatusPendingApproval).
+		Where("courses.status = ?", model.CourseStatusWaitingForApproval)
+
+	if filter.AssignedTo != uuid.Nil {
+		db = db.Where("course_drafts.assigned_to = ?", filter.AssignedTo)
+	}
+
+	if filter.Unassigned {
+		db = db.Where("course_drafts.assigned_to IS NULL")
+	}
+
+	if filter.SubmittedAfter.Valid {
+		db = db.Where("course_drafts.submitted_at > ?", filter.SubmittedAfter.Time)
+	}
+
+	if filter.SubmittedBefore.Valid {
+		db = db.Where("course_drafts.submitted_at <= ?", filter.SubmittedBefore.Time)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetModerationQueue] Error counting drafts")
+		return nil, metadata, err
+	}
+	metadata.Total = total
+
+	err = db.
+		Select("course_drafts.*").
+		Order("course_drafts.submitted_at ASC").
+		Limit(filter.Pagination.Limit()).
+		Offset(filter.Pagination.Offset()).
+		Find(&drafts).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetModerationQueue] Error getting drafts")
+		return nil, metadata, err
+	}
+
+	return drafts, metadata, nil
+}
+
+// Claim assigns the draft to the admin unless another admin already holds it.
+// It reports whether the claim went through.
+func (r *CourseDraftRepository) Claim(ctx context.Context, id uuid.UUID, adminID uuid.UUID) (bool, error) {
+	now := time.Now()
+	result := r.db.Write.WithContext(ctx).Model(&model.CourseDraft{}).
+		Where("id = ? AND (assigned_to IS NULL OR assigned_to = ?)", id, adminID).
+		Updates(map[string]any{
+			"assigned_to": adminID,
+			"assigned_at": now,
+			"updated_at":  now,
+		})
+	if result.Error != nil {
+		logger.ErrorCtx(ctx).Err(result.Error).Str("draft_id", id.String()).Msg("[Claim] Error claiming course draft")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Assign sets or, with an invalid assignee, clears the reviewer of the draft
+func (r *CourseDraftRepository) Assign(ctx context.Context, id uuid.UUID, assignedTo uuid.NullUUID) error {
+	now := time.Now()
+	assignedAt := null.TimeFrom(now)
+	if !assignedTo.Valid {
+		assignedAt = null.Time{}
+	}
+
+	err := r.db.Write.WithContext(ctx).Model(&model.CourseDraft{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"assigned_to": assignedTo,
+			"assigned_at": assignedAt,
+			"updated_at":  now,
+		}).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("draft_id", id.String()).Msg("[Assign] Error assigning course draft")
+		return err
+	}
+
+	return nil
+}
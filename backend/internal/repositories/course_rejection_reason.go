@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type CourseRejectionReasonRepository struct {
+	db *infras.MySQL
+}
+
+func NewCourseRejectionReasonRepository(db *infras.MySQL) *CourseRejectionReasonRepository {
+	return &CourseRejectionReasonRepository{db: db}
+}
+
+func (r *CourseRejectionReasonRepository) Get(ctx context.Context, activeOnly bool) ([]model.CourseRejectionReason, error) {
+	var reasons []model.CourseRejectionReason
+	db := r.db.Read.WithContext(ctx).Order("sequence asc, title asc")
+	if activeOnly {
+		db = db.Where("is_active = ?", true)
+	}
+
+	err := db.Find(&reasons).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting course rejection reasons")
+		return nil, err
+	}
+
+	return reasons, nil
+}
+
+// GetByCodes returns the active reasons with the given codes in display order.
+func (r *CourseRejectionReasonRepository) GetByCodes(ctx context.Context, codes []string) ([]model.CourseRejectionReason, error) {
+	var reasons []model.CourseRejectionReason
+	if len(codes) == 0 {
+		return reasons, nil
+	}
+
+	err := r.db.Read.WithContext(ctx).
+		Where("code IN ? AND is_active = ?", codes, true).
+		Order("sequence asc").
+		Find(&reasons).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByCodes] Error getting course rejection reasons")
+		return nil, err
+	}
+
+	return reasons, nil
+}
+
+func (r *CourseRejectionReasonRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.CourseRejectionReason, error) {
+	var reason model.CourseRejectionReason
+	err := r.db.Read.WithContext(ctx).Where("id = ?", id).First(&reason).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetByID] Error getting course rejection reason")
+		return nil, err
+	}
+
+	return &reason, nil
+}
+
+func (r *CourseRejectionReasonRepository) ExistsByCode(ctx context.Context, code string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Read.WithContext(ctx).Model(&model.CourseRejectionReason{}).
+		Where("code = ? AND id <> ?", code, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *CourseRejectionReasonRepository) Create(ctx context.Context, reason *model.CourseRejectionReason) error {
+	return r.db.Write.WithContext(ctx).Create(reason).Error
+}
+
+func (r *CourseRejectionReasonRepository) Update(ctx context.Context, reason *model.CourseRejectionReason) error {
+	return r.db.Write.WithContext(ctx).
+		Model(&model.CourseRejectionReason{}).
+		Where("id = ?", reason.ID).
+		Updates(map[string]any{
+			"code":        reason.Code,
+			"title":       reason.Title,
+			"description": reason.Description,
+			"sequence":    reason.Sequence,
+			"is_active":   reason.IsActive,
+			"updated_at":  reason.UpdatedAt,
+			"updated_by":  reason.UpdatedBy,
+		}).Error
+}
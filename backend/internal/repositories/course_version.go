@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type CourseVersionRepository struct {
+	db *infras.MySQL
+}
+
+func NewCourseVersionRepository(db *infras.MySQL) *CourseVersionRepository {
+	return &CourseVersionRepository{db: db}
+}
+
+// GetByCourseID returns the versions of a course, newest first.
+func (r *CourseVersionRepository) GetByCourseID(ctx context.Context, courseID uuid.UUID) ([]model.CourseVersion, error) {
+	var versions []model.CourseVersion
+	err := r.db.Read.WithContext(ctx).
+		Where("course_id = ?", courseID).
+		Order("version desc").
+		Find(&versions).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", courseID.String()).Msg("[GetByCourseID] Error getting course versions")
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (r *CourseVersionRepository) GetByVersion(ctx context.Context, courseID uuid.UUID, version int) (*model.CourseVersion, error) {
+	var courseVersion model.CourseVersion
+	err := r.db.Read.WithContext(ctx).
+		Where("course_id = ? AND version = ?", courseID, version).
+		First(&courseVersion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", courseID.String()).Int("version", version).Msg("[GetByVersion] Error getting course version")
+		return nil, err
+	}
+
+	return &courseVersion, nil
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type ExchangeRateRepository struct {
+	db *infras.MySQL
+}
+
+func NewExchangeRateRepository(db *infras.MySQL) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+func (r *ExchangeRateRepository) Get(ctx context.Context, filter model.ExchangeRateFilter) ([]model.ExchangeRate, model.Metadata, error) {
+	var (
+		results  []model.ExchangeRate
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+
+	db := r.db.Read.WithContext(ctx).Model(&model.ExchangeRate{})
+
+	if filter.Currency != "" {
+		db = db.Where("currency = ?", filter.Currency)
+	}
+
+	if !filter.From.IsZero() {
+		db = db.Where("rate_date >= ?", filter.From.Format(time.DateOnly))
+	}
+
+	if !filter.To.IsZero() {
+		db = db.Where("rate_date <= ?", filter.To.Format(time.DateOnly))
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting exchange rates")
+		return []model.ExchangeRate{}, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Order("rate_date desc, currency asc").Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting exchange rates")
+		return []model.ExchangeRate{}, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// Latest returns the most recent rate of each currency recorded on or before
+// the given day.
+func (r *ExchangeRateRepository) Latest(ctx context.Context, on time.Time) (model.ExchangeRates, error) {
+	var results []model.ExchangeRate
+	err := r.db.Read.WithContext(ctx).
+		Table("exchange_rates er").
+		Select("er.currency, er.rate").
+		Joins(`JOIN (
+			SELECT currency, MAX(rate_date) AS rate_date
+			FROM exchange_rates
+			WHERE rate_date <= ?
+			GROUP BY currency
+		) latest ON latest.currency = er.currency AND latest.rate_date = er.rate_date`, on.Format(time.DateOnly)).
+		Scan(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Latest] Error getting latest exchange rates")
+		return nil, err
+	}
+
+	rates := make(model.ExchangeRates, len(results))
+	for _, rate := range results {
+		rates[rate.Currency] = rate.Rate
+	}
+
+	return rates, nil
+}
+
+// Upsert records the rates, replacing any already recorded for the same
+// currency and day.
+func (r *ExchangeRateRepository) Upsert(ctx context.Context, rates []model.ExchangeRate) error {
+	err := r.db.Write.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "currency"}, {Name: "rate_date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate", "source", "created_by", "updated_at"}),
+		}).
+		Create(&rates).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Upsert] Error saving exchange rates")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// Mentor earnings are the booking credits less what refunds took back.
+const (
+	earningsAmount     = "CASE WHEN type = 'credit' THEN amount ELSE -amount END"
+	earningsGross      = "CASE WHEN type = 'credit' THEN amount + commission ELSE -(amount + commission) END"
+	earningsCommission = "CASE WHEN type = 'credit' THEN commission ELSE -commission END"
+)
+
+// Figures are reported in IDR, except those of a single mentor which stay in
+// the currency of their balance. Earnings are converted back with the rate
+// recorded on the transaction, payouts with the rate of the day they were
+// made.
+func inIDR(filter model.FinanceReportFilter, amount string) string {
+	if filter.TutorID != uuid.Nil {
+		return amount
+	}
+
+	return fmt.Sprintf("ROUND((%s) / COALESCE(exchange_rate, 1), 2)", amount)
+}
+
+func payoutInIDR(filter model.FinanceReportFilter, dateColumn string) string {
+	if filter.TutorID != uuid.Nil {
+		return "amount"
+	}
+
+	return fmt.Sprintf(`ROUND(amount * COALESCE((
+		SELECT er.rate FROM exchange_rates er
+		WHERE er.currency = withdrawal_requests.currency AND er.rate_date <= DATE(withdrawal_requests.%s)
+		ORDER BY er.rate_date DESC LIMIT 1
+	), 1), 2)`, dateColumn)
+}
+
+// FinanceReportRepository aggregates the finance figures per period in SQL.
+type FinanceReportRepository struct {
+	db *infras.MySQL
+}
+
+func NewFinanceReportRepository(db *infras.MySQL) *FinanceReportRepository {
+	return &FinanceReportRepository{db: db}
+}
+
+type periodTotal struct {
+	Period string
+	Total  decimal.Decimal
+}
+
+// sumByPeriod sums amount per period of dateColumn within the filter dates.
+func (r *FinanceReportRepository) sumByPeriod(ctx context.Context, db *gorm.DB, dateColumn, amount string, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+	var rows []periodTotal
+	err := db.
+		Select(fmt.Sprintf("DATE_FORMAT(%s, ?) AS period, COALESCE(SUM(%s), 0) AS total", dateColumn, amount), filter.PeriodFormat()).
+		Where(dateColumn+" >= ? AND "+dateColumn+" < ?", filter.From, filter.To).
+		Group("period").
+		Scan(&rows).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[sumByPeriod] Error summing finance figures")
+		return nil, err
+	}
+
+	totals := make(map[string]decimal.Decimal, len(rows))
+	for _, row := range rows {
+		totals[row.Period] = row.Total
+	}
+
+	return totals, nil
+}
+
+// sumBefore sums amount of the rows dated before the given time.
+func (r *FinanceReportRepository) sumBefore(ctx context.Context, db *gorm.DB, dateColumn, amount string, before time.Time) (decimal.Decimal, error) {
+	var total decimal.NullDecimal
+	err := db.
+		Select(fmt.Sprintf("SUM(%s)", amount)).
+		Where(dateColumn+" < ?", before).
+		Scan(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[sumBefore] Error summing finance figures")
+		return decimal.Zero, err
+	}
+
+	return total.Decimal, nil
+}
+
+// scopeTutors keeps the rows of the filtered tutor, or of the tutors teaching
+// a course in the filtered category.
+func (r *FinanceReportRepository) scopeTutors(db *gorm.DB, column string, filter model.FinanceReportFilter) *gorm.DB {
+	if filter.TutorID != uuid.Nil {
+		db = db.Where(column+" = ?", filter.TutorID)
+	}
+
+	if filter.CategoryID != uuid.Nil {
+		db = db.Where(column+" IN (SELECT tutor_id FROM courses WHERE course_category_id = ? AND deleted_at IS NULL)", filter.CategoryID)
+	}
+
+	return db
+}
+
+// earnings selects the booking and session credits of mentors and the refund
+// debits reversing them.
+func (r *FinanceReportRepository) earnings(ctx context.Context, filter model.FinanceReportFilter) *gorm.DB {
+	db := r.db.Read.WithContext(ctx).
+		Model(&model.BalanceTransaction{}).
+		Where("reference_type IN ?", []string{model.BalanceReferenceBookingPayment, model.BalanceReferenceBookingSession, model.BalanceReferenceRefund})
+
+	return r.scopeTutors(db, "tutor_id", filter)
+}
+
+// payments selects the paid student payments. They are not tied to a mentor,
+// so nothing is selected when the filter is scoped to mentors.
+func (r *FinanceReportRepository) payments(ctx context.Context, filter model.FinanceReportFilter) *gorm.DB {
+	db := r.db.Read.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("paid_at IS NOT NULL AND deleted_at IS NULL")
+
+	if filter.ScopedToTutors() {
+		db = db.Where("1 = 0")
+	}
+
+	return db
+}
+
+func (r *FinanceReportRepository) withdrawals(ctx context.Context, filter model.FinanceReportFilter, statuses ...model.WithdrawalStatus) *gorm.DB {
+	db := r.db.Read.WithContext(ctx).
+		Model(&model.WithdrawalRequest{}).
+		Where("status IN ?", statuses)
+
+	return r.scopeTutors(db, "tutor_id", filter)
+}
+
+// BookingVolume is the gross amount of the booking payments credited to
+// mentors, commission included, less refunds.
+func (r *FinanceReportRepository) BookingVolume(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+	return r.sumByPeriod(ctx, r.earnings(ctx, filter), "created_at", inIDR(filter, earningsGross), filter)
+}
+
+// Commission is the platform commission kept on booking payments, less the
+// commission given back by refunds.
+func (r *FinanceReportRepository) Commission(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+	return r.sumByPeriod(ctx, r.earnings(ctx, filter), "created_at", inIDR(filter, earningsCommission), filter)
+}
+
+// MentorEarnings is what mentors earned after commission, less refunds.
+func (r *FinanceReportRepository) MentorEarnings(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+	return r.sumByPeriod(ctx, r.earnings(ctx, filter), "created_at", inIDR(filter, earningsAmount), filter)
+}
+
+// MentorEarningsBefore is what mentors earned before the given time.
+func (r *FinanceReportRepository) MentorEarningsBefore(ctx context.Context, filter model.FinanceReportFilter, before time.Time) (decimal.Decimal, error) {
+	return r.sumBefore(ctx, r.earnings(ctx, filter), "created_at", inIDR(filter, earningsAmount), before)
+}
+
+// SubscriptionRevenue is the amount of the paid subscription payments,
+// VAT excluded.
+func (r *FinanceReportRepository) SubscriptionRevenue(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+	return r.sumByPeriod(ctx, r.payments(ctx, filter), "paid_at", "amount", filter)
+}
+
+// VATCollected is the VAT charged on paid payments. Payments made before tax
+// rates were configurable were taxed 11%.
+func (r *FinanceReportRepository) VATCollected(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+	return r.sumByPeriod(ctx, r.payments(ctx, filter), "paid_at", "COALESCE(tax_amount, ROUND(amount * 0.11, 2))", filter)
+}
+
+// SubscriptionRefunds is the amount refunded to students.
+func (r *FinanceReportRepository) SubscriptionRefunds(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+	db := r.db.Read.WithContext(ctx).
+		Model(&model.PaymentRefund{}).
+		Where("status = ?", model.RefundStatusSucceeded)
+
+	if filter.ScopedToTutors() {
+		db = db.Where("1 = 0")
+	}
+
+	return r.sumByPeriod(ctx, db, "refunded_at", "amount", filter)
+}
+
+// PayoutsRequested is the amount mentors asked to withdraw, rejected requests
+// excluded.
+func (r *FinanceReportRepository) PayoutsRequested(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+	db := r.withdrawals(ctx, filter,
+		model.WithdrawalStatusPending,
+		model.WithdrawalStatusApproved,
+		model.WithdrawalStatusProcessing,
+		model.WithdrawalStatusCompleted,
+		model.WithdrawalStatusFailed,
+	)
+
+	return r.sumByPeriod(ctx, db, "created_at", payoutInIDR(filter, "created_at"), filter)
+}
+
+// Payouts is the amount of the withdrawals settled with the given status.
+func (r *FinanceReportRepository) Payouts(ctx context.Context, filter model.FinanceReportFilter, status model.WithdrawalStatus) (map[string]decimal.Decimal, error) {
+	return r.sumByPeriod(ctx, r.withdrawals(ctx, filter, status), "processed_at", payoutInIDR(filter, "processed_at"), filter)
+}
+
+// PaidOutBefore is the amount paid out to mentors before the given time.
+func (r *FinanceReportRepository) PaidOutBefore(ctx context.Context, filter model.FinanceReportFilter, before time.Time) (decimal.Decimal, error) {
+	return r.sumBefore(ctx, r.withdrawals(ctx, filter, model.WithdrawalStatusCompleted), "processed_at", payoutInIDR(filter, "processed_at"), before)
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type GiftCodeRepository struct {
+	db *infras.MySQL
+}
+
+func NewGiftCodeRepository(db *infras.MySQL) *GiftCodeRepository {
+	return &GiftCodeRepository{db: db}
+}
+
+func (r *GiftCodeRepository) Get(ctx context.Context, filter model.GiftCodeFilter) ([]model.GiftCode, model.Metadata, error) {
+	var (
+		results  []model.GiftCode
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+
+	db := r.db.Read.WithContext(ctx).Model(&model.GiftCode{})
+
+	if filter.GuardianID != uuid.Nil {
+		db = db.Where("guardian_id = ?", filter.GuardianID)
+	}
+
+	if filter.PaymentID != uuid.Nil {
+		db = db.Where("payment_id = ?", filter.PaymentID)
+	}
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting gift codes")
+		return []model.GiftCode{}, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Order("created_at desc, code asc").Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting gift codes")
+		return []model.GiftCode{}, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+func (r *GiftCodeRepository) GetByCode(ctx context.Context, code string) (*model.GiftCode, error) {
+	var giftCode model.GiftCode
+	err := r.db.Read.WithContext(ctx).
+		Preload("Payment").
+		Where("code = ?", code).
+		First(&giftCode).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByCode] Error getting gift code")
+		return nil, err
+	}
+
+	return &giftCode, nil
+}
+
+// UpdateStatusByPayment moves the codes of a payment from one status to
+// another and returns how many were moved.
+func (r *GiftCodeRepository) UpdateStatusByPayment(ctx context.Context, paymentID uuid.UUID, from, to string) (int64, error) {
+	result := r.db.Write.WithContext(ctx).
+		Model(&model.GiftCode{}).
+		Where("payment_id = ? AND status = ?", paymentID, from).
+		Updates(map[string]any{
+			"status":     to,
+			"updated_at": time.Now(),
+		})
+
+	return result.RowsAffected, result.Error
+}
+
+// Redeem marks an active code as redeemed by the student and extends the
+// student premium in one transaction. It returns false when the code was
+// redeemed or canceled in the meantime.
+func (r *GiftCodeRepository) Redeem(ctx context.Context, giftCode *model.GiftCode, student *model.Student) (bool, error) {
+	var redeemed bool
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.GiftCode{}).
+			Where("id = ? AND status = ?", giftCode.ID, model.GiftCodeStatusActive).
+			Updates(map[string]any{
+				"status":      model.GiftCodeStatusRedeemed,
+				"redeemed_by": giftCode.RedeemedBy,
+				"redeemed_at": giftCode.RedeemedAt,
+				"updated_at":  time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		err := tx.Model(&model.Student{}).
+			Where("id = ?", student.ID).
+			Updates(map[string]any{
+				"premium_until": student.PremiumUntil,
+				"updated_at":    time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		redeemed = true
+		return nil
+	})
+
+	return redeemed, err
+}
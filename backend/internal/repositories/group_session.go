@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// seatsTakenSelect loads the number of seats taken in each group session
+const seatsTakenSelect = "group_sessions.*, (SELECT COUNT(*) FROM bookings WHERE bookings.group_session_id = group_sessions.id " +
+	"AND bookings.status IN ? AND bookings.deleted_at IS NULL) AS seats_taken"
+
+type GroupSessionRepository struct {
+	db *infras.MySQL
+}
+
+func NewGroupSessionRepository(db *infras.MySQL) *GroupSessionRepository {
+	return &GroupSessionRepository{db: db}
+}
+
+func (r *GroupSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.GroupSession, error) {
+	var result model.GroupSession
+	err := r.db.Read.WithContext(ctx).
+		Preload("Course").
+		Select(seatsTakenSelect, model.BookedStatuses).
+		Where("id = ?", id).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetByID] Error getting group session")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Get returns the group sessions with the number of seats taken in each, the
+// earliest first.
+func (r *GroupSessionRepository) Get(ctx context.Context, filter model.GroupSessionFilter) ([]model.GroupSession, model.Metadata, error) {
+	var (
+		results  []model.GroupSession
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.GroupSession{})
+
+	if filter.TutorID != uuid.Nil {
+		db = db.Where("tutor_id = ?", filter.TutorID)
+	}
+
+	if filter.CourseID != uuid.Nil {
+		db = db.Where("course_id = ?", filter.CourseID)
+	}
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	if !filter.DateFrom.IsZero() {
+		db = db.Where("session_date >= ?", filter.DateFrom.Format(time.DateOnly))
+	}
+
+	if !filter.DateTo.IsZero() {
+		db = db.Where("session_date <= ?", filter.DateTo.Format(time.DateOnly))
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting group sessions")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Limit()).
+			Offset(filter.Offset())
+	}
+
+	err = db.Preload("Course").
+		Select(seatsTakenSelect, model.BookedStatuses).
+		Order("session_date, session_time").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting group sessions")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// GetSlots returns the group sessions of the course between the dates with
+// the number of seats taken in each.
+func (r *GroupSessionRepository) GetSlots(ctx context.Context, courseID uuid.UUID, from, until string) ([]model.GroupSession, error) {
+	var results []model.GroupSession
+	err := r.db.Read.WithContext(ctx).
+		Select(seatsTakenSelect, model.BookedStatuses).
+		Where("course_id = ? AND session_date BETWEEN ? AND ?", courseID, from, until).
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", courseID.String()).Msg("[GetSlots] Error getting group sessions")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetDue returns the open sessions whose cutoff passed, with the number of
+// seats taken in each.
+func (r *GroupSessionRepository) GetDue(ctx context.Context, now time.Time) ([]model.GroupSession, error) {
+	var results []model.GroupSession
+	err := r.db.Read.WithContext(ctx).
+		Preload("Course").
+		Select(seatsTakenSelect, model.BookedStatuses).
+		Where("status = ? AND cutoff_at <= ?", model.GroupSessionStatusOpen, now).
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetDue] Error getting due group sessions")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetSeats returns the bookings holding a seat in the session, the first
+// booked first.
+func (r *GroupSessionRepository) GetSeats(ctx context.Context, id uuid.UUID) ([]model.Booking, error) {
+	var results []model.Booking
+	err := r.db.Read.WithContext(ctx).
+		Preload("Student.User").
+		Preload("Tutor.User").
+		Preload("Course").
+		Preload("ReportBooking").
+		Preload("SessionTasks").
+		Preload("Attendance").
+		Preload("Meeting").
+		Where("group_session_id = ? AND status IN ? AND deleted_at IS NULL", id, model.BookedStatuses).
+		Order("created_at").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetSeats] Error getting seats")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ReserveSeat creates the seat in the session of its slot, creating the
+// session with its first seat. The session row is locked while the seats
+// are counted so two students cannot take the last seat. Seats of a
+// confirmed session are accepted right away. It returns false when the
+// session is full or no longer takes seats, session is then the one of the
+// slot.
+func (r *GroupSessionRepository) ReserveSeat(ctx context.Context, session *model.GroupSession, seat *model.Booking) (bool, error) {
+	var reserved bool
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(session).Error
+		if err != nil {
+			return err
+		}
+
+		var locked model.GroupSession
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("course_id = ? AND class_type = ? AND session_date = ? AND session_time = ?",
+				session.CourseID, session.ClassType, session.SessionDate.Format(time.DateOnly), session.SessionTime).
+			First(&locked).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.Booking{}).
+			Where("group_session_id = ? AND status IN ? AND deleted_at IS NULL", locked.ID, model.BookedStatuses).
+			Count(&locked.SeatsTaken).Error
+		if err != nil {
+			return err
+		}
+
+		locked.Course = session.Course
+		*session = locked
+
+		if !session.IsBookable(time.Now()) || session.SeatsTaken >= int64(session.Capacity) {
+			return nil
+		}
+
+		seat.GroupSessionID = uuid.NullUUID{UUID: session.ID, Valid: true}
+		seat.ExpiredAt = session.CutoffAt
+		if session.Status == model.GroupSessionStatusConfirmed {
+			seat.Status = model.BookingStatusAccepted
+		}
+
+		err = tx.Omit(clause.Associations).Create(seat).Error
+		if err != nil {
+			return err
+		}
+
+		session.SeatsTaken++
+		reserved = true
+		return nil
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", session.CourseID.String()).Msg("[ReserveSeat] Error reserving seat")
+	}
+
+	return reserved, err
+}
+
+// Confirm confirms the open session and accepts its pending seats. It
+// returns false when the session was no longer open.
+func (r *GroupSessionRepository) Confirm(ctx context.Context, session *model.GroupSession, now time.Time) (bool, error) {
+	return r.close(ctx, session, model.GroupSessionStatusConfirmed, "confirmed_at", model.BookingStatusAccepted, now)
+}
+
+// Cancel cancels the open session and its pending seats. It returns false
+// when the session was no longer open.
+func (r *GroupSessionRepository) Cancel(ctx context.Context, session *model.GroupSession, now time.Time) (bool, error) {
+	return r.close(ctx, session, model.GroupSessionStatusCancelled, "cancelled_at", model.BookingStatusCancelled, now)
+}
+
+// close moves the open session to the status and its pending seats along.
+func (r *GroupSessionRepository) close(ctx context.Context, session *model.GroupSession, status model.GroupSessionStatus, column string, seatStatus model.BookingStatus, now time.Time) (bool, error) {
+	var closed bool
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.GroupSession{}).
+			Where("id = ? AND status = ?", session.ID, model.GroupSessionStatusOpen).
+			Updates(map[string]any{
+				"status":     status,
+				column:       now,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		err := tx.Model(&model.Booking{}).
+			Where("group_session_id = ? AND status = ? AND deleted_at IS NULL", session.ID, model.BookingStatusPending).
+			Updates(map[string]any{
+				"status":     seatStatus,
+				"updated_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		closed = true
+		return nil
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", session.ID.String()).Msg("[close] Error closing group session")
+		return false, err
+	}
+
+	if closed {
+		session.Status = status
+	}
+
+	return closed, nil
+}
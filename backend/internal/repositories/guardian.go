@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type GuardianRepository struct {
+	db *infras.MySQL
+}
+
+func NewGuardianRepository(db *infras.MySQL) *GuardianRepository {
+	return &GuardianRepository{db: db}
+}
+
+func (r *GuardianRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*model.Guardian, error) {
+	var guardian model.Guardian
+	err := r.db.Read.WithContext(ctx).
+		Preload("User").
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		First(&guardian).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByUserID] Error getting guardian")
+		return nil, err
+	}
+
+	return &guardian, nil
+}
+
+func (r *GuardianRepository) Update(ctx context.Context, guardian *model.Guardian) error {
+	return r.db.Write.WithContext(ctx).Omit("User").Save(guardian).Error
+}
+
+func (r *GuardianRepository) GetLinks(ctx context.Context, filter model.GuardianStudentFilter) ([]model.GuardianStudent, error) {
+	var links []model.GuardianStudent
+	db := r.db.Read.WithContext(ctx).
+		Preload("Guardian.User").
+		Preload("Student.User")
+
+	if filter.GuardianID != uuid.Nil {
+		db = db.Where("guardian_id = ?", filter.GuardianID)
+	}
+
+	if filter.StudentID != uuid.Nil {
+		db = db.Where("student_id = ?", filter.StudentID)
+	}
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	if filter.ReportPeriodNot != "" {
+		db = db.Where("(last_report_period IS NULL OR last_report_period <> ?)", filter.ReportPeriodNot)
+	}
+
+	err := db.Order("created_at asc").Find(&links).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetLinks] Error getting guardian students")
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *GuardianRepository) GetLink(ctx context.Context, id uuid.UUID) (*model.GuardianStudent, error) {
+	var link model.GuardianStudent
+	err := r.db.Read.WithContext(ctx).
+		Preload("Guardian.User").
+		Preload("Student.User").
+		Where("id = ?", id).
+		First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetLink] Error getting guardian student")
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+func (r *GuardianRepository) GetLinkByToken(ctx context.Context, token string) (*model.GuardianStudent, error) {
+	var link model.GuardianStudent
+	err := r.db.Read.WithContext(ctx).
+		Preload("Guardian.User").
+		Preload("Student.User").
+		Where("confirmation_token = ?", token).
+		First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetLinkByToken] Error getting guardian student")
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+func (r *GuardianRepository) CreateLink(ctx context.Context, link *model.GuardianStudent) error {
+	return r.db.Write.WithContext(ctx).Omit("Guardian", "Student").Create(link).Error
+}
+
+func (r *GuardianRepository) UpdateLink(ctx context.Context, link *model.GuardianStudent) error {
+	return r.db.Write.WithContext(ctx).Omit("Guardian", "Student").Save(link).Error
+}
+
+func (r *GuardianRepository) DeleteLink(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).Delete(&model.GuardianStudent{}, "id = ?", id).Error
+}
+
+func (r *GuardianRepository) GetInvites(ctx context.Context, studentID uuid.UUID) ([]model.GuardianInvite, error) {
+	var invites []model.GuardianInvite
+	err := r.db.Read.WithContext(ctx).
+		Where("student_id = ? AND used_at IS NULL AND expires_at > ?", studentID, time.Now()).
+		Order("created_at desc").
+		Find(&invites).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetInvites] Error getting guardian invites")
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (r *GuardianRepository) GetInviteByCode(ctx context.Context, code string) (*model.GuardianInvite, error) {
+	var invite model.GuardianInvite
+	err := r.db.Read.WithContext(ctx).
+		Where("code = ?", code).
+		First(&invite).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetInviteByCode] Error getting guardian invite")
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (r *GuardianRepository) CreateInvite(ctx context.Context, invite *model.GuardianInvite) error {
+	return r.db.Write.WithContext(ctx).Create(invite).Error
+}
+
+// UseInvite marks the invite as used and saves the link in one
+// transaction. It returns false when the invite was used in the meantime.
+func (r *GuardianRepository) UseInvite(ctx context.Context, invite *model.GuardianInvite, link *model.GuardianStudent) (bool, error) {
+	used := false
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.GuardianInvite{}).
+			Where("id = ? AND used_at IS NULL", invite.ID).
+			Updates(map[string]interface{}{
+				"used_by":    invite.UsedBy,
+				"used_at":    invite.UsedAt,
+				"updated_at": invite.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Omit("Guardian", "Student").Save(link).Error; err != nil {
+			return err
+		}
+
+		used = true
+		return nil
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UseInvite] Error using guardian invite")
+		return false, err
+	}
+
+	return used, nil
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type InvoiceRepository struct {
+	db *infras.MySQL
+}
+
+func NewInvoiceRepository(db *infras.MySQL) *InvoiceRepository {
+	return &InvoiceRepository{db: db}
+}
+
+// Create takes the next number of the invoice period and stores the invoice
+// with its items. The sequence row stays locked until the invoice is stored,
+// so a failed insert gives the number back and numbers stay gap-free.
+func (r *InvoiceRepository) Create(ctx context.Context, invoice *model.Invoice, prefix string) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(
+			"INSERT INTO invoice_sequences (period, last_number) VALUES (?, 1) ON DUPLICATE KEY UPDATE last_number = last_number + 1",
+			invoice.Period,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		var sequence int
+		err = tx.Raw("SELECT last_number FROM invoice_sequences WHERE period = ?", invoice.Period).
+			Scan(&sequence).Error
+		if err != nil {
+			return err
+		}
+
+		invoice.SetSequence(prefix, sequence)
+
+		return tx.Create(invoice).Error
+	})
+}
+
+func (r *InvoiceRepository) UpdateFileKey(ctx context.Context, id uuid.UUID, key string) error {
+	return r.db.Write.WithContext(ctx).
+		Model(&model.Invoice{}).
+		Where("id = ?", id).
+		Update("file_key", key).Error
+}
+
+func (r *InvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Invoice, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).Where("id = ?", id))
+}
+
+func (r *InvoiceRepository) GetByPaymentID(ctx context.Context, paymentID uuid.UUID) (*model.Invoice, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).Where("payment_id = ?", paymentID))
+}
+
+func (r *InvoiceRepository) first(ctx context.Context, db *gorm.DB) (*model.Invoice, error) {
+	var invoice model.Invoice
+	err := db.Preload("Items").First(&invoice).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[first] Error getting invoice")
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+func (r *InvoiceRepository) Get(ctx context.Context, filter model.InvoiceFilter) ([]model.Invoice, model.Metadata, error) {
+	var (
+		results  []model.Invoice
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+
+	db := r.db.Read.WithContext(ctx).Model(&model.Invoice{})
+
+	if filter.StudentID != uuid.Nil {
+		db = db.Where("student_id = ?", filter.StudentID)
+	}
+
+	if filter.Query != "" {
+		query := "%" + filter.Query + "%"
+		db = db.Where("(number LIKE ? OR buyer_name LIKE ? OR buyer_email LIKE ?)", query, query, query)
+	}
+
+	if !filter.IssuedAtFrom.IsZero() {
+		db = db.Where("issued_at >= ?", filter.IssuedAtFrom)
+	}
+
+	if !filter.IssuedAtTo.IsZero() {
+		db = db.Where("issued_at < ?", filter.IssuedAtTo)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting invoices")
+		return []model.Invoice{}, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Preload("Items").Order("period desc, sequence desc").Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting invoices")
+		return []model.Invoice{}, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
@@ -0,0 +1,227 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type LearningPlanRepository struct {
+	db *infras.MySQL
+}
+
+func NewLearningPlanRepository(db *infras.MySQL) *LearningPlanRepository {
+	return &LearningPlanRepository{db: db}
+}
+
+// preloadMilestones loads the milestones in the order the tutor set them
+func preloadMilestones(db *gorm.DB) *gorm.DB {
+	return db.Order("position, created_at")
+}
+
+// Create saves the plan with its milestones.
+func (r *LearningPlanRepository) Create(ctx context.Context, plan *model.LearningPlan) error {
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Create(plan).Error
+		if err != nil {
+			return err
+		}
+
+		if len(plan.Milestones) == 0 {
+			return nil
+		}
+
+		return tx.Create(&plan.Milestones).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Create] Error creating learning plan")
+	}
+
+	return err
+}
+
+func (r *LearningPlanRepository) Update(ctx context.Context, plan *model.LearningPlan) error {
+	err := r.db.Write.WithContext(ctx).Model(plan).
+		Select("title", "description", "status", "updated_at").
+		Updates(plan).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", plan.ID.String()).Msg("[Update] Error updating learning plan")
+	}
+
+	return err
+}
+
+// GetByID returns the plan with its milestones.
+func (r *LearningPlanRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.LearningPlan, error) {
+	var result model.LearningPlan
+	err := r.db.Read.WithContext(ctx).
+		Preload("Student.User").
+		Preload("Tutor.User").
+		Preload("CourseCategory").
+		Preload("Milestones", preloadMilestones).
+		Where("id = ?", id).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetByID] Error getting learning plan")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Get returns the plans with their milestones, the latest first.
+func (r *LearningPlanRepository) Get(ctx context.Context, filter model.LearningPlanFilter) ([]model.LearningPlan, error) {
+	db := r.db.Read.WithContext(ctx).Model(&model.LearningPlan{})
+
+	if filter.StudentID != uuid.Nil {
+		db = db.Where("student_id = ?", filter.StudentID)
+	}
+
+	if filter.TutorID != uuid.Nil {
+		db = db.Where("tutor_id = ?", filter.TutorID)
+	}
+
+	if filter.CourseCategoryID != uuid.Nil {
+		db = db.Where("course_category_id = ?", filter.CourseCategoryID)
+	}
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	var results []model.LearningPlan
+	err := db.Preload("Tutor.User").
+		Preload("CourseCategory").
+		Preload("Milestones", preloadMilestones).
+		Order("created_at DESC").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting learning plans")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CreateMilestone appends the milestone to its plan.
+func (r *LearningPlanRepository) CreateMilestone(ctx context.Context, milestone *model.LearningPlanMilestone) error {
+	err := r.db.Write.WithContext(ctx).Create(milestone).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("plan_id", milestone.PlanID.String()).Msg("[CreateMilestone] Error creating milestone")
+	}
+
+	return err
+}
+
+func (r *LearningPlanRepository) UpdateMilestone(ctx context.Context, milestone *model.LearningPlanMilestone) error {
+	err := r.db.Write.WithContext(ctx).Model(milestone).
+		Select("title", "description", "target_date", "status", "position", "achieved_at", "updated_at").
+		Updates(milestone).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", milestone.ID.String()).Msg("[UpdateMilestone] Error updating milestone")
+	}
+
+	return err
+}
+
+// DeleteMilestone removes the milestone, unlinking it from the lesson notes.
+func (r *LearningPlanRepository) DeleteMilestone(ctx context.Context, id uuid.UUID) error {
+	err := r.db.Write.WithContext(ctx).Where("id = ?", id).Delete(&model.LearningPlanMilestone{}).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[DeleteMilestone] Error deleting milestone")
+	}
+
+	return err
+}
+
+// GetNotes returns the lesson notes with their session and milestones, the
+// latest session first.
+func (r *LearningPlanRepository) GetNotes(ctx context.Context, filter model.LessonNoteFilter) ([]model.LessonNote, model.Metadata, error) {
+	var (
+		results  []model.LessonNote
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.LessonNote{})
+
+	if filter.PlanID != uuid.Nil {
+		db = db.Where("lesson_notes.plan_id = ?", filter.PlanID)
+	}
+
+	if filter.StudentID != uuid.Nil {
+		db = db.Where("lesson_notes.student_id = ?", filter.StudentID)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetNotes] Error counting lesson notes")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Limit()).
+			Offset(filter.Offset())
+	}
+
+	err = db.Joins("JOIN bookings ON bookings.id = lesson_notes.booking_id").
+		Preload("Booking.Tutor.User").
+		Preload("Milestones", preloadMilestones).
+		Order("bookings.booking_date DESC, bookings.booking_time DESC").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetNotes] Error getting lesson notes")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+func (r *LearningPlanRepository) GetNoteByBookingID(ctx context.Context, bookingID uuid.UUID) (*model.LessonNote, error) {
+	var result model.LessonNote
+	err := r.db.Read.WithContext(ctx).
+		Preload("Milestones", preloadMilestones).
+		Where("booking_id = ?", bookingID).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", bookingID.String()).Msg("[GetNoteByBookingID] Error getting lesson note")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SaveNote creates or replaces the lesson note of its session together with
+// the milestones it is linked to.
+func (r *LearningPlanRepository) SaveNote(ctx context.Context, note *model.LessonNote) error {
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Save(note).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(note).Association("Milestones").Replace(note.Milestones)
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", note.BookingID.String()).Msg("[SaveNote] Error saving lesson note")
+	}
+
+	return err
+}
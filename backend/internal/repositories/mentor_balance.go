@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lesprivate/backend/internal/model"
@@ -50,15 +51,46 @@ func (r *MentorBalanceRepository) GetOrCreate(ctx context.Context, tutorID uuid.
 	return &mb, nil
 }
 
-func (r *MentorBalanceRepository) UpdateBalance(ctx context.Context, tutorID uuid.UUID, amount decimal.Decimal) error {
-	// Use atomic update: balance = balance + amount
-	return r.db.WithContext(ctx).Model(&model.MentorBalance{}).
-		Where("tutor_id = ?", tutorID).
-		Update("balance", gorm.Expr("balance + ?", amount)).Error
+// UpdateCurrency changes the currency of an empty balance. It returns false
+// when the balance is not empty.
+func (r *MentorBalanceRepository) UpdateCurrency(ctx context.Context, tutorID uuid.UUID, currency string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.MentorBalance{}).
+		Where("tutor_id = ? AND balance = 0", tutorID).
+		Update("currency", currency)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Credit records the credit and adds it to the mentor balance in one
+// transaction. It reports false when the reference was credited already,
+// leaving the balance as it was.
+func (r *MentorBalanceRepository) Credit(ctx context.Context, credit *model.BalanceTransaction) (bool, error) {
+	credited := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(credit)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		credited = true
+		return tx.Model(&model.MentorBalance{}).
+			Where("tutor_id = ?", credit.TutorID).
+			Update("balance", gorm.Expr("balance + ?", credit.Amount)).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return credited, nil
 }
 
-func (r *MentorBalanceRepository) CreateTransaction(ctx context.Context, tx *model.BalanceTransaction) error {
-	return r.db.WithContext(ctx).Create(tx).Error
+func (r *MentorBalanceRepository) GetTransactionsByReference(ctx context.Context, referenceType string, referenceID uuid.UUID) ([]model.BalanceTransaction, error) {
+	var transactions []model.BalanceTransaction
+	err := r.db.WithContext(ctx).
+		Where("reference_type = ? AND reference_id = ?", referenceType, referenceID).
+		Find(&transactions).Error
+	return transactions, err
 }
 
 func (r *MentorBalanceRepository) ListTransactions(ctx context.Context, tutorID uuid.UUID, filter model.Pagination) ([]model.BalanceTransaction, model.Metadata, error) {
@@ -87,6 +119,27 @@ func (r *MentorBalanceRepository) ListTransactions(ctx context.Context, tutorID
 	return transactions, metadata, nil
 }
 
+// ListTransactionsBetween lists the transactions of a tutor created from
+// from until to, to excluded, oldest first.
+func (r *MentorBalanceRepository) ListTransactionsBetween(ctx context.Context, tutorID uuid.UUID, from, to time.Time) ([]model.BalanceTransaction, error) {
+	var transactions []model.BalanceTransaction
+	err := r.db.WithContext(ctx).
+		Where("tutor_id = ? AND created_at >= ? AND created_at < ?", tutorID, from, to).
+		Order("created_at ASC").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// BalanceAt is the balance of a tutor just before the given time.
+func (r *MentorBalanceRepository) BalanceAt(ctx context.Context, tutorID uuid.UUID, at time.Time) (decimal.Decimal, error) {
+	var balance decimal.NullDecimal
+	err := r.db.WithContext(ctx).Model(&model.BalanceTransaction{}).
+		Select("SUM(CASE WHEN type = 'credit' THEN amount ELSE -amount END)").
+		Where("tutor_id = ? AND created_at < ?", tutorID, at).
+		Scan(&balance).Error
+	return balance.Decimal, err
+}
+
 func (r *MentorBalanceRepository) ListAllTransactions(ctx context.Context, filter model.Pagination, tutorName string, txType string) ([]model.BalanceTransaction, model.Metadata, error) {
 	var (
 		transactions []model.BalanceTransaction
@@ -121,3 +174,19 @@ func (r *MentorBalanceRepository) ListAllTransactions(ctx context.Context, filte
 	metadata.Total = total
 	return transactions, metadata, nil
 }
+
+// TransactionStats sums every balance transaction. Holds released by failed
+// payouts net out the withdrawal debits instead of counting as credits.
+func (r *MentorBalanceRepository) TransactionStats(ctx context.Context) (model.BalanceTransactionStats, error) {
+	var stats model.BalanceTransactionStats
+	err := r.db.WithContext(ctx).Model(&model.BalanceTransaction{}).
+		Select(`
+			COALESCE(SUM(CASE WHEN type = 'credit' AND COALESCE(reference_type, '') <> ? THEN amount END), 0) AS total_credit,
+			COALESCE(SUM(CASE WHEN type = 'debit' THEN amount WHEN reference_type = ? THEN -amount END), 0) AS total_debit,
+			COALESCE(SUM(CASE WHEN type = 'credit' THEN commission ELSE -commission END), 0) AS total_commission,
+			COUNT(*) AS total_count`,
+			model.BalanceReferenceWithdrawal, model.BalanceReferenceWithdrawal,
+		).
+		Scan(&stats).Error
+	return stats, err
+}
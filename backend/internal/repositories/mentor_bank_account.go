@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type MentorBankAccountRepository struct {
+	db *infras.MySQL
+}
+
+func NewMentorBankAccountRepository(db *infras.MySQL) *MentorBankAccountRepository {
+	return &MentorBankAccountRepository{db: db}
+}
+
+// Create stores the account. The first account of a mentor becomes the
+// default one.
+func (r *MentorBankAccountRepository) Create(ctx context.Context, account *model.MentorBankAccount) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&model.MentorBankAccount{}).
+			Where("tutor_id = ?", account.TutorID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		account.IsDefault = count == 0
+		return tx.Create(account).Error
+	})
+}
+
+func (r *MentorBankAccountRepository) Update(ctx context.Context, account *model.MentorBankAccount) error {
+	return r.db.Write.WithContext(ctx).Omit("Tutor").Save(account).Error
+}
+
+// SetDefault makes the account the only default account of its mentor.
+func (r *MentorBankAccountRepository) SetDefault(ctx context.Context, account *model.MentorBankAccount) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.MentorBankAccount{}).
+			Where("tutor_id = ? AND id <> ?", account.TutorID, account.ID).
+			Update("is_default", false).Error
+		if err != nil {
+			return err
+		}
+
+		account.IsDefault = true
+		return tx.Model(account).Update("is_default", true).Error
+	})
+}
+
+func (r *MentorBankAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).Delete(&model.MentorBankAccount{}, "id = ?", id).Error
+}
+
+func (r *MentorBankAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.MentorBankAccount, error) {
+	var account model.MentorBankAccount
+	err := r.db.Read.WithContext(ctx).Preload("Tutor.User").Where("id = ?", id).First(&account).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByID] Error getting mentor bank account")
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *MentorBankAccountRepository) GetDefault(ctx context.Context, tutorID uuid.UUID) (*model.MentorBankAccount, error) {
+	var account model.MentorBankAccount
+	err := r.db.Read.WithContext(ctx).Where("tutor_id = ? AND is_default = ?", tutorID, true).First(&account).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetDefault] Error getting default mentor bank account")
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *MentorBankAccountRepository) Get(ctx context.Context, filter model.MentorBankAccountFilter) ([]model.MentorBankAccount, model.Metadata, error) {
+	var (
+		results  []model.MentorBankAccount
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+
+	db := r.db.Read.WithContext(ctx).Model(&model.MentorBankAccount{})
+
+	if filter.TutorID != uuid.Nil {
+		db = db.Where("tutor_id = ?", filter.TutorID)
+	}
+
+	if filter.Verified.Valid {
+		if filter.Verified.Bool {
+			db = db.Where("verified_at IS NOT NULL")
+		} else {
+			db = db.Where("verified_at IS NULL")
+		}
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting mentor bank accounts")
+		return []model.MentorBankAccount{}, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Preload("Tutor.User").Order("is_default desc, created_at desc").Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting mentor bank accounts")
+		return []model.MentorBankAccount{}, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
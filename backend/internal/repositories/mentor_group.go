@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type MentorGroupRepository struct {
+	db *infras.MySQL
+}
+
+func NewMentorGroupRepository(db *infras.MySQL) *MentorGroupRepository {
+	return &MentorGroupRepository{db: db}
+}
+
+func (r *MentorGroupRepository) Create(ctx context.Context, group *model.MentorGroup) error {
+	err := r.db.Write.WithContext(ctx).Omit(clause.Associations).Create(group).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Create] Error creating mentor group")
+	}
+
+	return err
+}
+
+func (r *MentorGroupRepository) Update(ctx context.Context, group *model.MentorGroup) error {
+	err := r.db.Write.WithContext(ctx).Model(group).
+		Select("name", "description", "updated_at").
+		Updates(group).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", group.ID.String()).Msg("[Update] Error updating mentor group")
+	}
+
+	return err
+}
+
+// Delete removes the group with its members, invite codes and announcements.
+// The students stay mentored by the tutor.
+func (r *MentorGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.db.Write.WithContext(ctx).Where("id = ?", id).Delete(&model.MentorGroup{}).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[Delete] Error deleting mentor group")
+	}
+
+	return err
+}
+
+func (r *MentorGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.MentorGroup, error) {
+	var result model.MentorGroup
+	err := r.db.Read.WithContext(ctx).
+		Where("id = ?", id).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetByID] Error getting mentor group")
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Get returns the groups of the tutor with the number of members of each.
+func (r *MentorGroupRepository) Get(ctx context.Context, filter model.MentorGroupFilter) ([]model.MentorGroup, model.Metadata, error) {
+	var (
+		results  []model.MentorGroup
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.MentorGroup{}).
+		Where("tutor_id = ?", filter.TutorID)
+
+	if filter.Query != "" {
+		db = db.Where("name LIKE ?", fmt.Sprintf("%%%s%%", filter.Query))
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting mentor groups")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Limit()).
+			Offset(filter.Offset())
+	}
+
+	err = db.Select("mentor_groups.*, (SELECT COUNT(*) FROM mentor_group_members WHERE mentor_group_members.group_id = mentor_groups.id) AS member_count").
+		Order("name").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting mentor groups")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// AddMembers puts the students in the group, skipping those already in it.
+func (r *MentorGroupRepository) AddMembers(ctx context.Context, members []model.MentorGroupMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	err := r.db.Write.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(&members).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AddMembers] Error adding group members")
+	}
+
+	return err
+}
+
+// RemoveMember takes the student out of the group, returning whether they
+// were in it.
+func (r *MentorGroupRepository) RemoveMember(ctx context.Context, groupID, studentID uuid.UUID) (bool, error) {
+	result := r.db.Write.WithContext(ctx).
+		Where("group_id = ? AND student_id = ?", groupID, studentID).
+		Delete(&model.MentorGroupMember{})
+	if result.Error != nil {
+		logger.ErrorCtx(ctx).Err(result.Error).Str("group_id", groupID.String()).Msg("[RemoveMember] Error removing group member")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// RemoveStudent takes the student out of every group of the tutor.
+func (r *MentorGroupRepository) RemoveStudent(ctx context.Context, tutorID, studentID uuid.UUID) error {
+	err := r.db.Write.WithContext(ctx).
+		Where("student_id = ? AND group_id IN (?)", studentID,
+			r.db.Write.Model(&model.MentorGroup{}).Select("id").Where("tutor_id = ?", tutorID)).
+		Delete(&model.MentorGroupMember{}).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("student_id", studentID.String()).Msg("[RemoveStudent] Error removing student from groups")
+	}
+
+	return err
+}
+
+func (r *MentorGroupRepository) GetMembers(ctx context.Context, groupID uuid.UUID, pagination model.Pagination) ([]model.MentorGroupMember, model.Metadata, error) {
+	var (
+		results  []model.MentorGroupMember
+		total    int64
+		metadata = model.Metadata{
+			Page:     pagination.Page,
+			PageSize: pagination.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.MentorGroupMember{}).
+		Where("group_id = ?", groupID)
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMembers] Error counting group members")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !pagination.IsEmpty() {
+		db = db.Limit(pagination.Limit()).
+			Offset(pagination.Offset())
+	}
+
+	err = db.Preload("Student.User").
+		Order("joined_at").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMembers] Error getting group members")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// GetMemberStudentIDs returns the students in the group who are still
+// actively mentored by the tutor of the group.
+func (r *MentorGroupRepository) GetMemberStudentIDs(ctx context.Context, groupID uuid.UUID) ([]uuid.UUID, error) {
+	var studentIDs []uuid.UUID
+	err := r.db.Read.WithContext(ctx).Model(&model.MentorGroupMember{}).
+		Joins("JOIN mentor_groups ON mentor_groups.id = mentor_group_members.group_id").
+		Joins("JOIN mentor_students ON mentor_students.tutor_id = mentor_groups.tutor_id AND mentor_students.student_id = mentor_group_members.student_id").
+		Where("mentor_group_members.group_id = ? AND mentor_students.status = ?", groupID, "active").
+		Pluck("mentor_group_members.student_id", &studentIDs).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("group_id", groupID.String()).Msg("[GetMemberStudentIDs] Error getting group members")
+		return nil, err
+	}
+
+	return studentIDs, nil
+}
+
+// GetMemberUserIDs returns the users of the students GetMemberStudentIDs
+// returns.
+func (r *MentorGroupRepository) GetMemberUserIDs(ctx context.Context, groupID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.Read.WithContext(ctx).Model(&model.MentorGroupMember{}).
+		Joins("JOIN mentor_groups ON mentor_groups.id = mentor_group_members.group_id").
+		Joins("JOIN mentor_students ON mentor_students.tutor_id = mentor_groups.tutor_id AND mentor_students.student_id = mentor_group_members.student_id").
+		Joins("JOIN students ON students.id = mentor_group_members.student_id").
+		Where("mentor_group_members.group_id = ? AND mentor_students.status = ?", groupID, "active").
+		Pluck("students.user_id", &userIDs).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("group_id", groupID.String()).Msg("[GetMemberUserIDs] Error getting group members")
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+func (r *MentorGroupRepository) CreateAnnouncement(ctx context.Context, announcement *model.MentorGroupAnnouncement) error {
+	err := r.db.Write.WithContext(ctx).Create(announcement).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateAnnouncement] Error creating announcement")
+	}
+
+	return err
+}
+
+// GetAnnouncements returns the announcements of the group, the latest first.
+func (r *MentorGroupRepository) GetAnnouncements(ctx context.Context, groupID uuid.UUID, pagination model.Pagination) ([]model.MentorGroupAnnouncement, model.Metadata, error) {
+	var (
+		results  []model.MentorGroupAnnouncement
+		total    int64
+		metadata = model.Metadata{
+			Page:     pagination.Page,
+			PageSize: pagination.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.MentorGroupAnnouncement{}).
+		Where("group_id = ?", groupID)
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetAnnouncements] Error counting announcements")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !pagination.IsEmpty() {
+		db = db.Limit(pagination.Limit()).
+			Offset(pagination.Offset())
+	}
+
+	err = db.Order("created_at DESC").Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetAnnouncements] Error getting announcements")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
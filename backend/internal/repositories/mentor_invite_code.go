@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lesprivate/backend/internal/model"
@@ -22,9 +23,10 @@ func (r *MentorInviteCodeRepository) Create(ctx context.Context, code *model.Men
 	return r.db.WithContext(ctx).Create(code).Error
 }
 
+// GetByTutor returns the general code of the tutor, the one without a group
 func (r *MentorInviteCodeRepository) GetByTutor(ctx context.Context, tutorID uuid.UUID) (*model.MentorInviteCode, error) {
 	var code model.MentorInviteCode
-	if err := r.db.WithContext(ctx).Where("tutor_id = ?", tutorID).First(&code).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("tutor_id = ? AND group_id IS NULL", tutorID).First(&code).Error; err != nil {
 		return nil, err
 	}
 	return &code, nil
@@ -37,3 +39,41 @@ func (r *MentorInviteCodeRepository) GetByCode(ctx context.Context, codeStr stri
 	}
 	return &code, nil
 }
+
+func (r *MentorInviteCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.MentorInviteCode, error) {
+	var code model.MentorInviteCode
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&code).Error; err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// ListByGroup returns the invite codes of the group, the newest first
+func (r *MentorInviteCodeRepository) ListByGroup(ctx context.Context, groupID uuid.UUID) ([]model.MentorInviteCode, error) {
+	var codes []model.MentorInviteCode
+	if err := r.db.WithContext(ctx).
+		Where("group_id = ?", groupID).
+		Order("created_at DESC").
+		Find(&codes).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Use counts one join on the code, returning false when the code ran out of
+// uses in the meantime
+func (r *MentorInviteCodeRepository) Use(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.MentorInviteCode{}).
+		Where("id = ? AND (max_uses IS NULL OR used_count < max_uses)", id).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *MentorInviteCodeRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&model.MentorInviteCode{}).
+		Where("id = ?", id).
+		Update("revoked_at", at).Error
+}
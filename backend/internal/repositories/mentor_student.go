@@ -43,7 +43,7 @@ func (r *MentorStudentRepository) ListByTutor(ctx context.Context, tutorID uuid.
 	)
 
 	query := r.db.WithContext(ctx).Model(&model.MentorStudent{}).
-		Select("mentor_students.*, (SELECT COUNT(*) FROM bookings WHERE bookings.student_id = mentor_students.student_id AND bookings.tutor_id = mentor_students.tutor_id AND bookings.status = 'accepted') as total_sessions").
+		Select("mentor_students.*, (SELECT COUNT(*) FROM bookings WHERE bookings.student_id = mentor_students.student_id AND bookings.tutor_id = mentor_students.tutor_id AND bookings.status IN ('accepted', 'completed')) as total_sessions").
 		Where("tutor_id = ?", tutorID).
 		Preload("Student").Preload("Student.User")
 
@@ -94,3 +94,12 @@ func (r *MentorStudentRepository) ListByStudent(ctx context.Context, studentID u
 	}
 	return mentorStudents, nil
 }
+
+// GetActiveStudentIDs returns the students actively mentored by the tutor
+func (r *MentorStudentRepository) GetActiveStudentIDs(ctx context.Context, tutorID uuid.UUID) ([]uuid.UUID, error) {
+	var studentIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&model.MentorStudent{}).
+		Where("tutor_id = ? AND status = ?", tutorID, "active").
+		Pluck("student_id", &studentIDs).Error
+	return studentIDs, err
+}
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type MonthlyReportRepository struct {
+	db *infras.MySQL
+}
+
+func NewMonthlyReportRepository(db *infras.MySQL) *MonthlyReportRepository {
+	return &MonthlyReportRepository{db: db}
+}
+
+func (r *MonthlyReportRepository) Get(ctx context.Context, filter model.MonthlyReportFilter) ([]model.MonthlyReport, model.Metadata, error) {
+	var (
+		results  []model.MonthlyReport
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.MonthlyReport{})
+
+	if filter.StudentID != uuid.Nil {
+		db = db.Where("student_id = ?", filter.StudentID)
+	}
+
+	if len(filter.StatusIn) > 0 {
+		db = db.Where("status IN (?)", filter.StatusIn)
+	}
+
+	if !filter.UpdatedBefore.IsZero() {
+		db = db.Where("updated_at < ?", filter.UpdatedBefore)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting monthly reports")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Order("period desc").Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting monthly reports")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+func (r *MonthlyReportRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.MonthlyReport, error) {
+	var report model.MonthlyReport
+	err := r.db.Read.WithContext(ctx).
+		Preload("Student.User").
+		Where("id = ?", id).
+		First(&report).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByID] Error getting monthly report")
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (r *MonthlyReportRepository) GetByPeriod(ctx context.Context, studentID uuid.UUID, period string) (*model.MonthlyReport, error) {
+	var report model.MonthlyReport
+	err := r.db.Read.WithContext(ctx).
+		Where("student_id = ? AND period = ?", studentID, period).
+		First(&report).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByPeriod] Error getting monthly report")
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// Create inserts the report unless the student already has one for the
+// period, in which case the existing one is returned.
+func (r *MonthlyReportRepository) Create(ctx context.Context, report *model.MonthlyReport) (*model.MonthlyReport, error) {
+	err := r.db.Write.WithContext(ctx).
+		Omit("Student").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(report).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Create] Error creating monthly report")
+		return nil, err
+	}
+
+	var created model.MonthlyReport
+	err = r.db.Write.WithContext(ctx).
+		Where("student_id = ? AND period = ?", report.StudentID, report.Period).
+		First(&created).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Create] Error getting monthly report")
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (r *MonthlyReportRepository) Update(ctx context.Context, report *model.MonthlyReport) error {
+	return r.db.Write.WithContext(ctx).Omit("Student").Save(report).Error
+}
+
+// Claim moves a queued report to processing. It returns false when another
+// worker claimed it first.
+func (r *MonthlyReportRepository) Claim(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.Write.WithContext(ctx).
+		Model(&model.MonthlyReport{}).
+		Where("id = ? AND status = ?", id, model.MonthlyReportStatusQueued).
+		Updates(map[string]interface{}{
+			"status":     model.MonthlyReportStatusProcessing,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		logger.ErrorCtx(ctx).Err(result.Error).Msg("[Claim] Error claiming monthly report")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Requeue moves reports stuck in processing, e.g. after a restart, back to
+// the queue.
+func (r *MonthlyReportRepository) Requeue(ctx context.Context, updatedBefore time.Time) error {
+	return r.db.Write.WithContext(ctx).
+		Model(&model.MonthlyReport{}).
+		Where("status = ? AND updated_at < ?", model.MonthlyReportStatusProcessing, updatedBefore).
+		Updates(map[string]interface{}{
+			"status":     model.MonthlyReportStatusQueued,
+			"updated_at": time.Now(),
+		}).Error
+}
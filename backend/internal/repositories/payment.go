@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/lesprivate/backend/infras"
 	"github.com/lesprivate/backend/internal/model"
@@ -19,12 +21,16 @@ func NewPaymentRepository(db *infras.MySQL) *PaymentRepository {
 
 func (r *PaymentRepository) Get(ctx context.Context, filter model.PaymentFilter) ([]model.Payment, error) {
 	var payments []model.Payment
-	db := r.db.Read.WithContext(ctx)
+	db := r.db.Read.WithContext(ctx).Preload("Beneficiaries.Student.User")
 
 	if filter.StudentID != uuid.Nil {
 		db = db.Where("student_id = ?", filter.StudentID)
 	}
 
+	if filter.GuardianID != uuid.Nil {
+		db = db.Where("guardian_id = ?", filter.GuardianID)
+	}
+
 	if len(filter.StatusIn) > 0 {
 		db = db.Where("status IN (?)", filter.StatusIn)
 	}
@@ -46,18 +52,45 @@ func (r *PaymentRepository) Create(ctx context.Context, payment *model.Payment)
 	return r.db.Write.WithContext(ctx).Save(payment).Error
 }
 
+// CreateGift creates a gift payment together with its pending codes.
+func (r *PaymentRepository) CreateGift(ctx context.Context, payment *model.Payment, codes []model.GiftCode) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Payment").Create(&codes).Error
+	})
+}
+
 func (r *PaymentRepository) GetByID(ctx context.Context, id string) (*model.Payment, error) {
 	var payment model.Payment
-	err := r.db.Read.WithContext(ctx).Preload("Student.User").Where("id = ?", id).First(&payment).Error
+	err := r.db.Read.WithContext(ctx).Preload("Student.User").Preload("Guardian.User").Preload("Beneficiaries.Student.User").Where("id = ?", id).First(&payment).Error
 	return &payment, err
 }
 
 func (r *PaymentRepository) GetByInvoiceNumber(ctx context.Context, id string) (*model.Payment, error) {
 	var payment model.Payment
-	err := r.db.Read.WithContext(ctx).Preload("Student.User").Where("invoice_number = ?", id).First(&payment).Error
+	err := r.db.Read.WithContext(ctx).Preload("Student.User").Preload("Guardian.User").Preload("Beneficiaries.Student.User").Where("invoice_number = ?", id).First(&payment).Error
 	return &payment, err
 }
 
+// GetByReferenceID returns the payment recorded for a Xendit reference, nil
+// when there is none.
+func (r *PaymentRepository) GetByReferenceID(ctx context.Context, referenceID string) (*model.Payment, error) {
+	var payment model.Payment
+	err := r.db.Read.WithContext(ctx).Preload("Student.User").Where("reference_id = ?", referenceID).First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
 func (r *PaymentRepository) Update(ctx context.Context, payment *model.Payment) error {
 	return r.db.Write.WithContext(ctx).Save(payment).Error
 }
@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type PaymentRefundRepository struct {
+	db *infras.MySQL
+}
+
+func NewPaymentRefundRepository(db *infras.MySQL) *PaymentRefundRepository {
+	return &PaymentRefundRepository{db: db}
+}
+
+func (r *PaymentRefundRepository) Create(ctx context.Context, refund *model.PaymentRefund) error {
+	return r.db.Write.WithContext(ctx).Create(refund).Error
+}
+
+func (r *PaymentRefundRepository) Update(ctx context.Context, refund *model.PaymentRefund) error {
+	return r.db.Write.WithContext(ctx).Omit("Payment").Save(refund).Error
+}
+
+// UpdatePending stores the status and Xendit reference of the refund unless
+// it was settled in the meantime.
+func (r *PaymentRefundRepository) UpdatePending(ctx context.Context, refund *model.PaymentRefund) error {
+	err := r.db.Write.WithContext(ctx).
+		Model(refund).
+		Where("status = ? OR (status = ? AND reference_id = '')", model.RefundStatusPending, model.RefundStatusFailed).
+		Select("reference_id", "status", "failure_code", "updated_at", "updated_by").
+		Updates(refund).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdatePending] Error updating payment refund")
+	}
+
+	return err
+}
+
+// Reserve stores the pending refund of the requested amount, the whole
+// refundable rest when none is requested. The payment row is locked while
+// its refunds are summed so two refunds cannot exceed what was charged. It
+// returns the refundable rest and false when the amount is not within it.
+func (r *PaymentRefundRepository) Reserve(ctx context.Context, refund *model.PaymentRefund, requested decimal.NullDecimal, charged decimal.Decimal) (decimal.Decimal, bool, error) {
+	var (
+		refundable decimal.Decimal
+		reserved   bool
+	)
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", refund.PaymentID).
+			First(&model.Payment{}).Error
+		if err != nil {
+			return err
+		}
+
+		refunded, err := sumRefunded(tx, refund.PaymentID)
+		if err != nil {
+			return err
+		}
+
+		var amount decimal.Decimal
+		amount, refundable, reserved = model.RefundAmount(charged, refunded, requested)
+		if !reserved {
+			return nil
+		}
+
+		refund.Amount = amount
+		return tx.Omit(clause.Associations).Create(refund).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("payment_id", refund.PaymentID.String()).Msg("[Reserve] Error reserving payment refund")
+	}
+
+	return refundable, reserved, err
+}
+
+// Complete records the succeeded refund in one transaction: the debits
+// reversing the mentor credits, the shortened premium of the students, the
+// gift codes no longer redeemable and the payment once refunded in full.
+// The refund row is locked so a refund is completed once, and debits
+// already recorded against it are not recorded again. It returns false when
+// the refund was settled in the meantime.
+func (r *PaymentRefundRepository) Complete(ctx context.Context, refund *model.PaymentRefund, debits []model.BalanceTransaction, students []model.Student) (bool, error) {
+	var completed bool
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked model.PaymentRefund
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", refund.ID).
+			First(&locked).Error
+		if err != nil {
+			return err
+		}
+
+		if !locked.IsUnsettled() {
+			return nil
+		}
+
+		var reversed int64
+		err = tx.Model(&model.BalanceTransaction{}).
+			Where("reference_type = ? AND reference_id = ?", model.BalanceReferenceRefund, refund.ID).
+			Count(&reversed).Error
+		if err != nil {
+			return err
+		}
+
+		if reversed == 0 {
+			for _, debit := range debits {
+				// The balance may go negative when the mentor already withdrew it.
+				err = tx.Model(&model.MentorBalance{}).
+					Where("tutor_id = ?", debit.TutorID).
+					Update("balance", gorm.Expr("balance - ?", debit.Amount)).Error
+				if err != nil {
+					return err
+				}
+
+				err = tx.Omit(clause.Associations).Create(&debit).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, student := range students {
+			err = tx.Model(&model.Student{}).
+				Where("id = ?", student.ID).
+				Update("premium_until", student.PremiumUntil).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		payment := refund.Payment
+		now := time.Now()
+
+		// Redeemed gift codes keep their premium, the others can no longer be
+		// redeemed.
+		if payment.Type == model.PaymentTypeGift {
+			err = tx.Model(&model.GiftCode{}).
+				Where("payment_id = ? AND status = ?", payment.ID, model.GiftCodeStatusActive).
+				Updates(map[string]any{
+					"status":     model.GiftCodeStatusCanceled,
+					"updated_at": now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Omit(clause.Associations).Save(refund).Error
+		if err != nil {
+			return err
+		}
+
+		refunded, err := sumRefunded(tx, payment.ID)
+		if err != nil {
+			return err
+		}
+
+		if refunded.GreaterThanOrEqual(payment.ChargedTotal()) {
+			err = tx.Model(&model.Payment{}).
+				Where("id = ?", payment.ID).
+				Updates(map[string]any{
+					"status":     model.SubscriptionStatusRefunded,
+					"updated_at": now,
+					"updated_by": model.SystemID,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		completed = true
+		return nil
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("refund_id", refund.ID.String()).Msg("[Complete] Error completing payment refund")
+	}
+
+	return completed, err
+}
+
+func (r *PaymentRefundRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.PaymentRefund, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).Where("id = ?", id))
+}
+
+func (r *PaymentRefundRepository) GetByReferenceID(ctx context.Context, referenceID string) (*model.PaymentRefund, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).Where("reference_id = ?", referenceID))
+}
+
+func (r *PaymentRefundRepository) first(ctx context.Context, db *gorm.DB) (*model.PaymentRefund, error) {
+	var refund model.PaymentRefund
+	err := db.Preload("Payment.Student.User").Preload("Payment.Guardian.User").Preload("Payment.Beneficiaries.Student.User").First(&refund).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[first] Error getting payment refund")
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+func (r *PaymentRefundRepository) GetByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]model.PaymentRefund, error) {
+	var refunds []model.PaymentRefund
+	err := r.db.Read.WithContext(ctx).
+		Where("payment_id = ?", paymentID).
+		Order("created_at desc").
+		Find(&refunds).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByPaymentID] Error getting payment refunds")
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+// sumRefunded returns the amount of the payment refunded or being refunded.
+func sumRefunded(db *gorm.DB, paymentID uuid.UUID) (decimal.Decimal, error) {
+	var total decimal.NullDecimal
+	err := db.Model(&model.PaymentRefund{}).
+		Select("SUM(amount)").
+		Where("payment_id = ? AND status <> ?", paymentID, model.RefundStatusFailed).
+		Scan(&total).Error
+
+	return total.Decimal, err
+}
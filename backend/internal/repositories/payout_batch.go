@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// ErrWithdrawalNotApproved is returned when a withdrawal of a new batch is no
+// longer approved or already belongs to another batch.
+var ErrWithdrawalNotApproved = errors.New("withdrawal is not approved")
+
+type PayoutBatchRepository struct {
+	db *infras.MySQL
+}
+
+func NewPayoutBatchRepository(db *infras.MySQL) *PayoutBatchRepository {
+	return &PayoutBatchRepository{db: db}
+}
+
+// Create stores the batch and assigns the withdrawals to it. It fails with
+// ErrWithdrawalNotApproved when another batch took one of them first.
+func (r *PayoutBatchRepository) Create(ctx context.Context, batch *model.PayoutBatch, withdrawalIDs []uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Withdrawals").Create(batch).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&model.WithdrawalRequest{}).
+			Where("id IN ? AND status = ? AND payout_batch_id IS NULL", withdrawalIDs, model.WithdrawalStatusApproved).
+			Update("payout_batch_id", batch.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected != int64(len(withdrawalIDs)) {
+			return ErrWithdrawalNotApproved
+		}
+
+		return nil
+	})
+}
+
+func (r *PayoutBatchRepository) Update(ctx context.Context, batch *model.PayoutBatch) error {
+	return r.db.Write.WithContext(ctx).Omit("Withdrawals").Save(batch).Error
+}
+
+// Complete marks the batch completed once none of its withdrawals waits for
+// the disbursement provider anymore.
+func (r *PayoutBatchRepository) Complete(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).
+		Model(&model.PayoutBatch{}).
+		Where("id = ? AND status = ?", id, model.PayoutBatchStatusProcessing).
+		Where("NOT EXISTS (SELECT 1 FROM withdrawal_requests WHERE payout_batch_id = ? AND status IN ?)",
+			id, []model.WithdrawalStatus{model.WithdrawalStatusApproved, model.WithdrawalStatusProcessing}).
+		Updates(map[string]any{
+			"status":       model.PayoutBatchStatusCompleted,
+			"completed_at": time.Now(),
+		}).Error
+}
+
+func (r *PayoutBatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.PayoutBatch, error) {
+	var batch model.PayoutBatch
+	err := r.db.Read.WithContext(ctx).
+		Preload("Withdrawals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		Preload("Withdrawals.Tutor.User").
+		Where("id = ?", id).
+		First(&batch).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetByID] Error getting payout batch")
+		return nil, err
+	}
+
+	return &batch, nil
+}
+
+func (r *PayoutBatchRepository) Get(ctx context.Context, status string, filter model.Pagination) ([]model.PayoutBatch, model.Metadata, error) {
+	var (
+		results  []model.PayoutBatch
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+
+	db := r.db.Read.WithContext(ctx).Model(&model.PayoutBatch{})
+
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting payout batches")
+		return []model.PayoutBatch{}, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.IsEmpty() {
+		db = db.Limit(filter.Limit()).
+			Offset(filter.Offset())
+	}
+
+	err = db.Order("created_at desc").Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting payout batches")
+		return []model.PayoutBatch{}, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type PlanEntitlementRepository struct {
+	db *infras.MySQL
+}
+
+func NewPlanEntitlementRepository(db *infras.MySQL) *PlanEntitlementRepository {
+	return &PlanEntitlementRepository{db: db}
+}
+
+// Get returns every plan, the free plan first.
+func (r *PlanEntitlementRepository) Get(ctx context.Context) ([]model.PlanEntitlement, error) {
+	var entitlements []model.PlanEntitlement
+	err := r.db.Read.WithContext(ctx).
+		Order("subscription_price_id IS NOT NULL, name asc").
+		Find(&entitlements).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting plan entitlements")
+		return nil, err
+	}
+
+	return entitlements, nil
+}
+
+func (r *PlanEntitlementRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.PlanEntitlement, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).Where("id = ?", id))
+}
+
+// GetFree returns the entitlements of students without a subscription.
+func (r *PlanEntitlementRepository) GetFree(ctx context.Context) (*model.PlanEntitlement, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).Where("subscription_price_id IS NULL"))
+}
+
+func (r *PlanEntitlementRepository) GetBySubscriptionPriceID(ctx context.Context, subscriptionPriceID string) (*model.PlanEntitlement, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).Where("subscription_price_id = ?", subscriptionPriceID))
+}
+
+// GetByInterval returns the entitlements of the plan sold with the interval.
+func (r *PlanEntitlementRepository) GetByInterval(ctx context.Context, interval model.SubscriptionInterval) (*model.PlanEntitlement, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).
+		Select("plan_entitlements.*").
+		Joins("JOIN subscription_prices ON subscription_prices.id = plan_entitlements.subscription_price_id").
+		Where("subscription_prices.`interval` = ?", interval).
+		Order("subscription_prices.price asc"))
+}
+
+func (r *PlanEntitlementRepository) first(ctx context.Context, db *gorm.DB) (*model.PlanEntitlement, error) {
+	var entitlement model.PlanEntitlement
+	err := db.First(&entitlement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[first] Error getting plan entitlement")
+		return nil, err
+	}
+
+	return &entitlement, nil
+}
+
+func (r *PlanEntitlementRepository) Update(ctx context.Context, entitlement *model.PlanEntitlement) error {
+	return r.db.Write.WithContext(ctx).
+		Model(&model.PlanEntitlement{}).
+		Where("id = ?", entitlement.ID).
+		Updates(map[string]any{
+			"name":                               entitlement.Name,
+			"max_booking_per_day":                entitlement.MaxBookingPerDay,
+			"max_booking_per_category":           entitlement.MaxBookingPerCategory,
+			"max_free_first_course_per_category": entitlement.MaxFreeFirstCoursePerCategory,
+			"monthly_report":                     entitlement.MonthlyReport,
+			"priority_response":                  entitlement.PriorityResponse,
+			"updated_at":                         entitlement.UpdatedAt,
+			"updated_by":                         entitlement.UpdatedBy,
+		}).Error
+}
@@ -0,0 +1,379 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type QuizRepository struct {
+	db *infras.MySQL
+}
+
+func NewQuizRepository(db *infras.MySQL) *QuizRepository {
+	return &QuizRepository{db: db}
+}
+
+// preloadByPosition loads quiz items and attempt answers in the order the
+// questions are asked
+func preloadByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+func (r *QuizRepository) GetQuestions(ctx context.Context, filter model.QuizQuestionFilter) ([]model.QuizQuestion, model.Metadata, error) {
+	var (
+		results  []model.QuizQuestion
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.QuizQuestion{}).
+		Where("tutor_id = ? AND deleted_at IS NULL", filter.TutorID)
+
+	if filter.SubCourseCategoryID != uuid.Nil {
+		db = db.Where("sub_course_category_id = ?", filter.SubCourseCategoryID)
+	}
+
+	if filter.LevelOfEducation != "" {
+		db = db.Where("level_of_education = ?", filter.LevelOfEducation)
+	}
+
+	if filter.Type != "" {
+		db = db.Where("type = ?", filter.Type)
+	}
+
+	if filter.Query != "" {
+		db = db.Where("prompt LIKE ?", fmt.Sprintf("%%%s%%", filter.Query))
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetQuestions] Error counting questions")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Preload("SubCourseCategory").
+		Order("created_at DESC").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetQuestions] Error getting questions")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+func (r *QuizRepository) GetQuestionByID(ctx context.Context, id uuid.UUID) (*model.QuizQuestion, error) {
+	var question model.QuizQuestion
+	err := r.db.Read.WithContext(ctx).
+		Preload("SubCourseCategory").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&question).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetQuestionByID] Error getting question")
+		return nil, err
+	}
+
+	return &question, nil
+}
+
+// GetQuestionsByIDs returns which of the questions are in the bank of the tutor
+func (r *QuizRepository) GetQuestionsByIDs(ctx context.Context, tutorID uuid.UUID, ids []uuid.UUID) ([]model.QuizQuestion, error) {
+	var results []model.QuizQuestion
+	err := r.db.Read.WithContext(ctx).
+		Where("tutor_id = ? AND id IN (?) AND deleted_at IS NULL", tutorID, ids).
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetQuestionsByIDs] Error getting questions")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *QuizRepository) CreateQuestion(ctx context.Context, question *model.QuizQuestion) error {
+	return r.db.Write.WithContext(ctx).Omit("SubCourseCategory").Create(question).Error
+}
+
+func (r *QuizRepository) UpdateQuestion(ctx context.Context, question *model.QuizQuestion) error {
+	return r.db.Write.WithContext(ctx).Omit("SubCourseCategory").Save(question).Error
+}
+
+// DeleteQuestion deletes the question from the bank and takes it out of the
+// quizzes. Attempts already started keep grading against it.
+func (r *QuizRepository) DeleteQuestion(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.QuizQuestion{}).
+			Where("id = ?", id).
+			Update("deleted_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("question_id = ?", id).Delete(&model.QuizItem{}).Error
+	})
+}
+
+func (r *QuizRepository) Get(ctx context.Context, filter model.QuizFilter) ([]model.Quiz, model.Metadata, error) {
+	var (
+		results  []model.Quiz
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.Quiz{}).
+		Where("tutor_id = ? AND deleted_at IS NULL", filter.TutorID)
+
+	if filter.Query != "" {
+		db = db.Where("title LIKE ?", fmt.Sprintf("%%%s%%", filter.Query))
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error counting quizzes")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Preload("Items", preloadByPosition).
+		Preload("Items.Question").
+		Order("title").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting quizzes")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// GetByID returns the quiz with its questions in order, deleted ones too as
+// the tasks given keep their quiz.
+func (r *QuizRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Quiz, error) {
+	var quiz model.Quiz
+	err := r.db.Read.WithContext(ctx).
+		Preload("Items", preloadByPosition).
+		Preload("Items.Question.SubCourseCategory").
+		Where("id = ?", id).
+		First(&quiz).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Str("id", id.String()).Msg("[GetByID] Error getting quiz")
+		return nil, err
+	}
+
+	return &quiz, nil
+}
+
+// Save creates or updates the quiz, replacing its questions.
+func (r *QuizRepository) Save(ctx context.Context, quiz *model.Quiz) error {
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Save(quiz).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("quiz_id = ?", quiz.ID).Delete(&model.QuizItem{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(quiz.Items) == 0 {
+			return nil
+		}
+
+		for i := range quiz.Items {
+			quiz.Items[i].QuizID = quiz.ID
+		}
+
+		return tx.Omit(clause.Associations).Create(&quiz.Items).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", quiz.ID.String()).Msg("[Save] Error saving quiz")
+	}
+
+	return err
+}
+
+// Delete deletes the quiz from the library. Tasks already given keep it.
+func (r *QuizRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).
+		Model(&model.Quiz{}).
+		Where("id = ?", id).
+		Update("deleted_at", time.Now()).Error
+}
+
+// GetAttemptByTaskID returns the attempt of the task with its answers in the
+// order the questions are asked.
+func (r *QuizRepository) GetAttemptByTaskID(ctx context.Context, taskID uuid.UUID) (*model.QuizAttempt, error) {
+	var attempt model.QuizAttempt
+	err := r.db.Read.WithContext(ctx).
+		Preload("Answers", preloadByPosition).
+		Where("session_task_id = ?", taskID).
+		First(&attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Str("task_id", taskID.String()).Msg("[GetAttemptByTaskID] Error getting quiz attempt")
+		return nil, err
+	}
+
+	return &attempt, nil
+}
+
+// CreateAttempt starts the attempt with an empty answer for each question.
+func (r *QuizRepository) CreateAttempt(ctx context.Context, attempt *model.QuizAttempt) error {
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Create(attempt).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range attempt.Answers {
+			attempt.Answers[i].AttemptID = attempt.ID
+		}
+
+		return tx.Omit(clause.Associations).Create(&attempt.Answers).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("task_id", attempt.SessionTaskID.String()).Msg("[CreateAttempt] Error creating quiz attempt")
+	}
+
+	return err
+}
+
+// SaveAnswers stores the answers of an attempt still being taken.
+func (r *QuizRepository) SaveAnswers(ctx context.Context, answers []model.QuizAttemptAnswer) error {
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, answer := range answers {
+			err := tx.Model(&model.QuizAttemptAnswer{}).
+				Where("attempt_id = ? AND question_id = ?", answer.AttemptID, answer.QuestionID).
+				Update("answer", answer.Answer).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SaveAnswers] Error saving quiz answers")
+	}
+
+	return err
+}
+
+// SubmitAttempt stores the graded attempt with its answers and the task
+// submission the score is written into, reloading the submission as saved.
+// It reports false and leaves both untouched when the attempt was submitted
+// in the meantime.
+func (r *QuizRepository) SubmitAttempt(ctx context.Context, attempt *model.QuizAttempt, submission *model.TaskSubmission) (bool, error) {
+	submitted := false
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.QuizAttempt
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "submitted_at").
+			Where("id = ?", attempt.ID).
+			Take(&current).Error
+		if err != nil {
+			return err
+		}
+		if current.SubmittedAt.Valid {
+			return nil
+		}
+
+		err = tx.Omit(clause.Associations).Save(attempt).Error
+		if err != nil {
+			return err
+		}
+
+		for _, answer := range attempt.Answers {
+			err = tx.Model(&model.QuizAttemptAnswer{}).
+				Where("attempt_id = ? AND question_id = ?", answer.AttemptID, answer.QuestionID).
+				Updates(map[string]any{
+					"answer":     answer.Answer,
+					"is_correct": answer.IsCorrect,
+					"points":     answer.Points,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// A task has one submission, so one already there is graded in place
+		err = tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "session_task_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"status", "score", "submitted_at", "is_late", "graded_at", "graded_by", "updated_at"}),
+			}).
+			Create(submission).Error
+		if err != nil {
+			return err
+		}
+
+		submitted = true
+		return tx.Where("session_task_id = ?", submission.SessionTaskID).Take(submission).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", attempt.ID.String()).Msg("[SubmitAttempt] Error submitting quiz attempt")
+		return false, err
+	}
+
+	return submitted, nil
+}
+
+// GetSubmittedAnswers returns the answers of the submitted attempts of the
+// quiz, of one task only when SessionTaskID is set.
+func (r *QuizRepository) GetSubmittedAnswers(ctx context.Context, filter model.QuizAttemptFilter) ([]model.QuizAttemptAnswer, error) {
+	db := r.db.Read.WithContext(ctx).Model(&model.QuizAttemptAnswer{}).
+		Joins("JOIN quiz_attempts ON quiz_attempts.id = quiz_attempt_answers.attempt_id").
+		Where("quiz_attempts.quiz_id = ? AND quiz_attempts.submitted_at IS NOT NULL", filter.QuizID)
+
+	if filter.SessionTaskID != uuid.Nil {
+		db = db.Where("quiz_attempts.session_task_id = ?", filter.SessionTaskID)
+	}
+
+	var results []model.QuizAttemptAnswer
+	err := db.Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("quiz_id", filter.QuizID.String()).Msg("[GetSubmittedAnswers] Error getting quiz answers")
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/lesprivate/backend/infras"
 	"github.com/lesprivate/backend/internal/model"
@@ -86,6 +87,18 @@ func (r *ReviewRepository) GetTutorReviews(ctx context.Context, filter model.Rev
 		db = db.Where("tutor_id = ?", filter.TutorID)
 	}
 
+	if filter.CourseID != uuid.Nil {
+		db = db.Where("course_id = ?", filter.CourseID)
+	}
+
+	if filter.IsSubmitted.Valid {
+		db = db.Where("is_submitted = ?", filter.IsSubmitted.Bool)
+	}
+
+	if filter.ModerationStatus != "" {
+		db = db.Where("moderation_status = ?", filter.ModerationStatus)
+	}
+
 	if filter.DeletedAtIsNull.Valid {
 		if filter.DeletedAtIsNull.Bool {
 			db = db.Where("deleted_at IS NULL")
@@ -172,14 +185,217 @@ func (r *ReviewRepository) GetTotalRatingStudent(ctx context.Context, studentID
 	return rating, err
 }
 
-func (r *ReviewRepository) GetTotalRatingTutor(ctx context.Context, tutorID uuid.UUID) (float64, error) {
-	var rating float64
-	err := r.db.Read.WithContext(ctx).Model(&model.TutorReview{}).
-		Select("AVG(rate) as rating").
-		Where("tutor_id = ?", tutorID).
-		Where("rate is not null").
-		Group("tutor_id").
-		Find(&rating).Error
+// RecalculateRating refreshes the denormalised ratings on the course and the
+// tutor from their published reviews. It must be called whenever a review is
+// submitted, edited or moderated.
+func (r *ReviewRepository) RecalculateRating(ctx context.Context, tutorID, courseID uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var course struct {
+			Rating      float64
+			TotalRating int
+		}
+		err := tx.Model(&model.TutorReview{}).
+			Scopes(publicTutorReviews).
+			Select("COALESCE(AVG(rate), 0) AS rating, COUNT(*) AS total_rating").
+			Where("course_id = ?", courseID).
+			Scan(&course).Error
+		if err != nil {
+			return err
+		}
 
-	return rating, err
+		err = tx.Model(&model.Course{}).
+			Where("id = ?", courseID).
+			UpdateColumns(map[string]any{
+				"rating":       course.Rating,
+				"total_rating": course.TotalRating,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		var tutor struct {
+			Rating            float64
+			TotalRating       int
+			PunctualityRating float64
+			ClarityRating     float64
+			PatienceRating    float64
+			MaterialsRating   float64
+		}
+		err = tx.Model(&model.TutorReview{}).
+			Scopes(publicTutorReviews).
+			Select(`COALESCE(AVG(rate), 0) AS rating,
+				COUNT(*) AS total_rating,
+				COALESCE(AVG(punctuality_rate), 0) AS punctuality_rating,
+				COALESCE(AVG(clarity_rate), 0) AS clarity_rating,
+				COALESCE(AVG(patience_rate), 0) AS patience_rating,
+				COALESCE(AVG(materials_rate), 0) AS materials_rating`).
+			Where("tutor_id = ?", tutorID).
+			Scan(&tutor).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Tutor{}).
+			Where("id = ?", tutorID).
+			UpdateColumns(map[string]any{
+				"rating":             tutor.Rating,
+				"total_rating":       tutor.TotalRating,
+				"punctuality_rating": tutor.PunctualityRating,
+				"clarity_rating":     tutor.ClarityRating,
+				"patience_rating":    tutor.PatienceRating,
+				"materials_rating":   tutor.MaterialsRating,
+			}).Error
+	})
+}
+
+// publicTutorReviews limits a query to reviews that count towards ratings.
+func publicTutorReviews(db *gorm.DB) *gorm.DB {
+	return db.Where("is_submitted = ?", true).
+		Where("rate IS NOT NULL").
+		Where("deleted_at IS NULL").
+		Where("moderation_status = ?", model.ReviewModerationStatusPublished)
+}
+
+func (r *ReviewRepository) GetModerationQueue(ctx context.Context, filter model.ReviewModerationFilter) ([]model.TutorReview, model.Metadata, error) {
+	var (
+		results  []model.TutorReview
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.Read.WithContext(ctx).Model(&model.TutorReview{}).
+		Preload("Student.User").
+		Preload("Tutor.User").
+		Preload("Course").
+		Preload("Reports", func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", model.ReviewReportStatusPending).Order("created_at asc")
+		}).
+		Preload("Reports.Reporter").
+		Where("deleted_at IS NULL")
+
+	if filter.ModerationStatus != "" {
+		db = db.Where("moderation_status = ?", filter.ModerationStatus)
+	} else {
+		// Default queue: reviews held by the profanity filter plus published
+		// reviews that users have reported.
+		reported := r.db.Read.WithContext(ctx).Model(&model.ReviewReport{}).
+			Select("tutor_review_id").
+			Where("status = ?", model.ReviewReportStatusPending)
+		db = db.Where("(moderation_status = ? OR id IN (?))", model.ReviewModerationStatusPending, reported)
+	}
+
+	if filter.TutorID != uuid.Nil {
+		db = db.Where("tutor_id = ?", filter.TutorID)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetModerationQueue] Error counting reviews")
+		return []model.TutorReview{}, model.Metadata{}, err
+	}
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	if filter.Sort.String() != "" {
+		db = db.Order(filter.Sort.String())
+	}
+
+	err = db.Find(&results).Error
+	return results, metadata, err
+}
+
+func (r *ReviewRepository) CreateReport(ctx context.Context, report *model.ReviewReport) error {
+	return r.db.Write.WithContext(ctx).Create(report).Error
+}
+
+func (r *ReviewRepository) HasPendingReport(ctx context.Context, reviewID, reporterID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Read.WithContext(ctx).Model(&model.ReviewReport{}).
+		Where("tutor_review_id = ?", reviewID).
+		Where("reporter_id = ?", reporterID).
+		Where("status = ?", model.ReviewReportStatusPending).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// UpdateFlaggedTutorReview saves a review held by the profanity filter and
+// files its system report in one transaction. A review edited again before
+// moderation keeps its original report.
+func (r *ReviewRepository) UpdateFlaggedTutorReview(ctx context.Context, review *model.TutorReview, report *model.ReviewReport) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		err := tx.Model(&model.ReviewReport{}).
+			Where("tutor_review_id = ?", review.ID).
+			Where("source = ?", model.ReviewReportSourceSystem).
+			Where("status = ?", model.ReviewReportStatusPending).
+			Count(&count).Error
+		if err != nil || count > 0 {
+			return err
+		}
+
+		return tx.Create(report).Error
+	})
+}
+
+// ModerateTutorReview saves the moderation decision on the review and closes
+// all of its pending reports in one transaction.
+func (r *ReviewRepository) ModerateTutorReview(ctx context.Context, review *model.TutorReview, reportStatus model.ReviewReportStatus) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(review).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.ReviewReport{}).
+			Where("tutor_review_id = ?", review.ID).
+			Where("status = ?", model.ReviewReportStatusPending).
+			Updates(map[string]any{
+				"status":      reportStatus,
+				"resolved_at": review.ModeratedAt,
+				"resolved_by": review.ModeratedBy,
+			}).Error
+	})
+}
+
+// SetHelpfulVote adds or removes the user's helpful vote and keeps the counter
+// on the review in sync.
+func (r *ReviewRepository) SetHelpfulVote(ctx context.Context, reviewID, userID uuid.UUID, helpful bool) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		err := tx.Model(&model.ReviewHelpfulVote{}).
+			Where("tutor_review_id = ? AND user_id = ?", reviewID, userID).
+			Count(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case helpful && existing == 0:
+			err = tx.Create(&model.ReviewHelpfulVote{TutorReviewID: reviewID, UserID: userID}).Error
+		case !helpful && existing > 0:
+			err = tx.Where("tutor_review_id = ? AND user_id = ?", reviewID, userID).
+				Delete(&model.ReviewHelpfulVote{}).Error
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.TutorReview{}).
+			Where("id = ?", reviewID).
+			UpdateColumn("helpful_count", tx.Model(&model.ReviewHelpfulVote{}).
+				Select("COUNT(*)").
+				Where("tutor_review_id = ?", reviewID)).Error
+	})
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"github.com/lesprivate/backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SessionTaskRepository struct {
@@ -20,6 +22,20 @@ func (r *SessionTaskRepository) Create(ctx context.Context, task *model.SessionT
 	return r.db.WithContext(ctx).Create(task).Error
 }
 
+// CreateBatch creates the tasks of a template assigned to many bookings at once
+func (r *SessionTaskRepository) CreateBatch(ctx context.Context, tasks []model.SessionTask) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(&tasks).Error
+}
+
+// GetAssignedBookingIDs returns which of the bookings already got a task from the template
+func (r *SessionTaskRepository) GetAssignedBookingIDs(ctx context.Context, templateID uuid.UUID, bookingIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var results []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&model.SessionTask{}).
+		Where("task_template_id = ? AND booking_id IN (?) AND deleted_at IS NULL", templateID, bookingIDs).
+		Pluck("booking_id", &results).Error
+	return results, err
+}
+
 func (r *SessionTaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.SessionTask, error) {
 	var task model.SessionTask
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&task).Error
@@ -38,7 +54,7 @@ func (r *SessionTaskRepository) CreateSubmission(ctx context.Context, submission
 
 func (r *SessionTaskRepository) GetSubmissionByTaskID(ctx context.Context, taskID uuid.UUID) (*model.TaskSubmission, error) {
 	var submission model.TaskSubmission
-	err := r.db.WithContext(ctx).Where("session_task_id = ?", taskID).First(&submission).Error
+	err := r.db.WithContext(ctx).Preload("Files").Where("session_task_id = ?", taskID).First(&submission).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -49,5 +65,162 @@ func (r *SessionTaskRepository) GetSubmissionByTaskID(ctx context.Context, taskI
 }
 
 func (r *SessionTaskRepository) UpdateSubmission(ctx context.Context, submission *model.TaskSubmission) error {
-	return r.db.WithContext(ctx).Save(submission).Error
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(submission).Error
+}
+
+// GetStudentTasks returns the tasks of every booking of the student, the ones due first
+func (r *SessionTaskRepository) GetStudentTasks(ctx context.Context, filter model.SessionTaskFilter) ([]model.SessionTask, model.Metadata, error) {
+	var (
+		results  []model.SessionTask
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.WithContext(ctx).Model(&model.SessionTask{}).
+		Joins("JOIN bookings ON bookings.id = session_tasks.booking_id").
+		Where("bookings.student_id = ? AND session_tasks.deleted_at IS NULL", filter.StudentID)
+
+	switch filter.Status {
+	case "":
+	case model.TaskSubmissionStatusPending:
+		db = db.Where("NOT EXISTS (SELECT 1 FROM task_submissions WHERE task_submissions.session_task_id = session_tasks.id AND task_submissions.deleted_at IS NULL)")
+	default:
+		db = db.Where("EXISTS (SELECT 1 FROM task_submissions WHERE task_submissions.session_task_id = session_tasks.id AND task_submissions.deleted_at IS NULL AND task_submissions.status = ?)", filter.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Preload("TaskSubmissions.Files").
+		Preload("Booking.Tutor.User").
+		Preload("Booking.Course").
+		Preload("Quiz.Items").
+		Order("session_tasks.due_at IS NULL, session_tasks.due_at, session_tasks.created_at desc").
+		Find(&results).Error
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+// GetSubmissions returns the submissions on the bookings of the tutor, the oldest submitted first
+func (r *SessionTaskRepository) GetSubmissions(ctx context.Context, filter model.TaskSubmissionFilter) ([]model.TaskSubmission, model.Metadata, error) {
+	var (
+		results  []model.TaskSubmission
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := r.db.WithContext(ctx).Model(&model.TaskSubmission{}).
+		Joins("JOIN session_tasks ON session_tasks.id = task_submissions.session_task_id").
+		Joins("JOIN bookings ON bookings.id = session_tasks.booking_id").
+		Where("task_submissions.deleted_at IS NULL AND session_tasks.deleted_at IS NULL")
+
+	if filter.TutorID != uuid.Nil {
+		db = db.Where("bookings.tutor_id = ?", filter.TutorID)
+	}
+
+	if filter.Status != "" {
+		db = db.Where("task_submissions.status = ?", filter.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Preload("Files").
+		Preload("SessionTask.Booking.Student.User").
+		Preload("SessionTask.Booking.Course").
+		Order("task_submissions.submitted_at").
+		Find(&results).Error
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+func (r *SessionTaskRepository) GetSubmissionByID(ctx context.Context, id uuid.UUID) (*model.TaskSubmission, error) {
+	var submission model.TaskSubmission
+	err := r.db.WithContext(ctx).
+		Preload("Files").
+		Preload("SessionTask").
+		Where("id = ?", id).
+		First(&submission).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// SaveSubmissionFiles saves the submission of the student, replacing the files of an earlier submission.
+// A first submission is only stored when the task has none yet, and an earlier one only replaced while it
+// is ungraded and still submitted at submittedAt, the time it was read with. It returns false otherwise.
+func (r *SessionTaskRepository) SaveSubmissionFiles(ctx context.Context, submission *model.TaskSubmission, submittedAt null.Time, files []model.TaskSubmissionFile) (bool, error) {
+	saved := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var result *gorm.DB
+		if submission.ID == uuid.Nil {
+			result = tx.Omit(clause.Associations).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(submission)
+		} else {
+			result = tx.Model(&model.TaskSubmission{}).
+				Where("id = ? AND status = ?", submission.ID, model.TaskSubmissionStatusSubmitted).
+				Where("submitted_at <=> ?", submittedAt).
+				Updates(map[string]any{
+					"submission_url": submission.SubmissionURL,
+					"submitted_at":   submission.SubmittedAt,
+					"is_late":        submission.IsLate,
+				})
+		}
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		err := tx.Where("task_submission_id = ?", submission.ID).Delete(&model.TaskSubmissionFile{}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range files {
+			files[i].TaskSubmissionID = submission.ID
+		}
+
+		err = tx.Create(&files).Error
+		if err != nil {
+			return err
+		}
+
+		submission.Files = files
+		saved = true
+		return nil
+	})
+
+	return saved, err
 }
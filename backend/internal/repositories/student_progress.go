@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// refreshProgressSummaries aggregates the bookings of a date range into
+// progress summaries, replacing the figures of rows already there.
+const refreshProgressSummaries = `
+INSERT INTO student_progress_summaries (
+	id, student_id, tutor_id, course_category_id, period,
+	sessions_scheduled, sessions_attended, tasks_total, tasks_submitted,
+	scores_count, score_sum, computed_at
+)
+SELECT
+	UUID(), b.student_id, b.tutor_id, c.course_category_id, DATE_FORMAT(b.booking_date, '%Y%m') AS period,
+	COUNT(*),
+	SUM(CASE WHEN b.status = 'completed' AND b.booking_date <= ? THEN 1 ELSE 0 END),
+	COALESCE(SUM(t.tasks), 0),
+	COALESCE(SUM(t.submitted), 0),
+	COALESCE(SUM(t.scored), 0),
+	COALESCE(SUM(t.score_sum), 0),
+	?
+FROM bookings b
+JOIN courses c ON c.id = b.course_id
+LEFT JOIN (
+	SELECT st.booking_id,
+		COUNT(*) AS tasks,
+		COUNT(ts.id) AS submitted,
+		COUNT(ts.score) AS scored,
+		SUM(ts.score) AS score_sum
+	FROM session_tasks st
+	LEFT JOIN task_submissions ts ON ts.session_task_id = st.id AND ts.deleted_at IS NULL
+	WHERE st.deleted_at IS NULL
+	GROUP BY st.booking_id
+) t ON t.booking_id = b.id
+WHERE b.deleted_at IS NULL
+	AND b.status NOT IN ('declined', 'expired')
+	AND b.booking_date >= ? AND b.booking_date < ?
+GROUP BY b.student_id, b.tutor_id, c.course_category_id, period
+ON DUPLICATE KEY UPDATE
+	sessions_scheduled = VALUES(sessions_scheduled),
+	sessions_attended = VALUES(sessions_attended),
+	tasks_total = VALUES(tasks_total),
+	tasks_submitted = VALUES(tasks_submitted),
+	scores_count = VALUES(scores_count),
+	score_sum = VALUES(score_sum),
+	computed_at = VALUES(computed_at)`
+
+type StudentProgressRepository struct {
+	db *infras.MySQL
+}
+
+func NewStudentProgressRepository(db *infras.MySQL) *StudentProgressRepository {
+	return &StudentProgressRepository{db: db}
+}
+
+// Refresh recomputes the summaries of the months from the month of from up
+// to the one before until. Summaries of the months no longer backed by a
+// booking are removed.
+func (r *StudentProgressRepository) Refresh(ctx context.Context, from, until, now time.Time) error {
+	periodFrom := model.MonthlyReportPeriod(int(from.Month()), from.Year())
+	last := until.AddDate(0, 0, -1)
+	periodUntil := model.MonthlyReportPeriod(int(last.Month()), last.Year())
+	computedAt := now.Truncate(time.Second)
+
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(refreshProgressSummaries,
+			now.Format(time.DateOnly),
+			computedAt,
+			from.Format(time.DateOnly),
+			until.Format(time.DateOnly),
+		).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("period BETWEEN ? AND ? AND computed_at < ?", periodFrom, periodUntil, computedAt).
+			Delete(&model.StudentProgressSummary{}).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Refresh] Error refreshing progress summaries")
+		return err
+	}
+
+	return nil
+}
+
+func (r *StudentProgressRepository) Get(ctx context.Context, filter model.StudentProgressFilter) ([]model.StudentProgressSummary, error) {
+	var results []model.StudentProgressSummary
+	db := r.db.Read.WithContext(ctx).
+		Model(&model.StudentProgressSummary{}).
+		Preload("CourseCategory")
+
+	if filter.WithStudent {
+		db = db.Preload("Student.User")
+	}
+
+	if filter.StudentID != uuid.Nil {
+		db = db.Where("student_id = ?", filter.StudentID)
+	}
+
+	if filter.TutorID != uuid.Nil {
+		db = db.Where("tutor_id = ?", filter.TutorID)
+	}
+
+	if filter.CourseCategoryID != uuid.Nil {
+		db = db.Where("course_category_id = ?", filter.CourseCategoryID)
+	}
+
+	if filter.PeriodFrom != "" {
+		db = db.Where("period >= ?", filter.PeriodFrom)
+	}
+
+	if filter.PeriodUntil != "" {
+		db = db.Where("period <= ?", filter.PeriodUntil)
+	}
+
+	err := db.Order("period").Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting progress summaries")
+		return nil, err
+	}
+
+	return results, nil
+}
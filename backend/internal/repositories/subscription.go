@@ -6,9 +6,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/lesprivate/backend/infras"
 	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
 )
 
 type SubscriptionRepository struct {
@@ -33,9 +36,79 @@ func (r *SubscriptionRepository) Update(ctx context.Context, subscription *model
 	return r.db.Write.WithContext(ctx).Save(subscription).Error
 }
 
+// Replace stores the ended subscription, the next one replacing it and the
+// premium period of the student in one transaction.
+func (r *SubscriptionRepository) Replace(ctx context.Context, subscription, next *model.Subscription, premiumUntil time.Time) error {
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Save(subscription).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Omit(clause.Associations).Create(next).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Student{}).
+			Where("id = ?", subscription.StudentID).
+			Update("premium_until", premiumUntil).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("subscription_id", subscription.ID.String()).Msg("[Replace] Error replacing subscription")
+	}
+
+	return err
+}
+
+// Renew stores the subscription extended by a paid cycle, the payment of the
+// cycle and the premium period of the student in one transaction. The
+// subscription is locked while it is checked that the cycle was not paid
+// before, so a cycle delivered twice only extends the subscription once. It
+// reports whether the cycle was saved.
+func (r *SubscriptionRepository) Renew(ctx context.Context, subscription *model.Subscription, payment *model.Payment, premiumUntil time.Time) (bool, error) {
+	renewed := false
+	err := r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", subscription.ID).
+			Take(&model.Subscription{}).Error
+		if err != nil {
+			return err
+		}
+
+		var paid int64
+		err = tx.Model(&model.Payment{}).Where("reference_id = ?", payment.ReferenceID).Count(&paid).Error
+		if err != nil || paid > 0 {
+			return err
+		}
+
+		err = tx.Omit(clause.Associations).Save(subscription).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Omit(clause.Associations).Create(payment).Error
+		if err != nil {
+			return err
+		}
+
+		renewed = true
+		return tx.Model(&model.Student{}).
+			Where("id = ?", subscription.StudentID).
+			Update("premium_until", premiumUntil).Error
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("subscription_id", subscription.ID.String()).Msg("[Renew] Error renewing subscription")
+		return false, err
+	}
+
+	return renewed, nil
+}
+
 func (r *SubscriptionRepository) Get(ctx context.Context, filter model.SubscriptionFilter) ([]model.Subscription, error) {
 	var subscriptions []model.Subscription
-	db := r.db.Read.WithContext(ctx)
+	db := r.db.Read.WithContext(ctx).Preload("Student.User")
 
 	if filter.StudentID != uuid.Nil {
 		db = db.Where("student_id = ?", filter.StudentID)
@@ -45,7 +118,27 @@ func (r *SubscriptionRepository) Get(ctx context.Context, filter model.Subscript
 		db = db.Where("status = ?", filter.Status)
 	}
 
-	err := db.Find(&subscriptions).Error
+	if len(filter.StatusIn) > 0 {
+		db = db.Where("status IN ?", filter.StatusIn)
+	}
+
+	if !filter.EndDateBefore.IsZero() {
+		db = db.Where("end_date <= ?", filter.EndDateBefore)
+	}
+
+	if !filter.GraceUntilBefore.IsZero() {
+		db = db.Where("grace_until <= ?", filter.GraceUntilBefore)
+	}
+
+	if filter.CancelAtPeriodEnd.Valid {
+		db = db.Where("cancel_at_period_end = ?", filter.CancelAtPeriodEnd.Bool)
+	}
+
+	if filter.NotRemindedOnly {
+		db = db.Where("(reminded_end_date IS NULL OR reminded_end_date <> end_date)")
+	}
+
+	err := db.Where("deleted_at IS NULL").Order("created_at desc").Find(&subscriptions).Error
 	return subscriptions, err
 }
 
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type TaskLibraryRepository struct {
+	db *infras.MySQL
+}
+
+func NewTaskLibraryRepository(db *infras.MySQL) *TaskLibraryRepository {
+	return &TaskLibraryRepository{db: db}
+}
+
+// libraryFilter applies the filter shared by rubrics and templates, column
+// being the one searched by the query
+func libraryFilter(db *gorm.DB, filter model.TaskLibraryFilter, column string) *gorm.DB {
+	db = db.Where("tutor_id = ? AND deleted_at IS NULL", filter.TutorID)
+
+	if filter.CourseID != uuid.Nil {
+		db = db.Where("course_id = ?", filter.CourseID)
+	}
+
+	if filter.SubCourseCategoryID != uuid.Nil {
+		db = db.Where("sub_course_category_id = ?", filter.SubCourseCategoryID)
+	}
+
+	if filter.Query != "" {
+		db = db.Where(column+" LIKE ?", fmt.Sprintf("%%%s%%", filter.Query))
+	}
+
+	return db
+}
+
+func (r *TaskLibraryRepository) GetRubrics(ctx context.Context, filter model.TaskLibraryFilter) ([]model.TaskRubric, model.Metadata, error) {
+	var (
+		results  []model.TaskRubric
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := libraryFilter(r.db.Read.WithContext(ctx).Model(&model.TaskRubric{}), filter, "name")
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetRubrics] Error counting rubrics")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Preload("Course").
+		Preload("SubCourseCategory").
+		Order("name").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetRubrics] Error getting rubrics")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+func (r *TaskLibraryRepository) GetRubricByID(ctx context.Context, id uuid.UUID) (*model.TaskRubric, error) {
+	var rubric model.TaskRubric
+	err := r.db.Read.WithContext(ctx).
+		Preload("Course").
+		Preload("SubCourseCategory").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&rubric).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetRubricByID] Error getting rubric")
+		return nil, err
+	}
+
+	return &rubric, nil
+}
+
+func (r *TaskLibraryRepository) CreateRubric(ctx context.Context, rubric *model.TaskRubric) error {
+	return r.db.Write.WithContext(ctx).Omit("Course", "SubCourseCategory").Create(rubric).Error
+}
+
+func (r *TaskLibraryRepository) UpdateRubric(ctx context.Context, rubric *model.TaskRubric) error {
+	return r.db.Write.WithContext(ctx).Omit("Course", "SubCourseCategory").Save(rubric).Error
+}
+
+// DeleteRubric deletes the rubric and detaches it from the templates using
+// it. Tasks already assigned keep their copy of the criteria.
+func (r *TaskLibraryRepository) DeleteRubric(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.TaskRubric{}).
+			Where("id = ?", id).
+			Update("deleted_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.TaskTemplate{}).
+			Where("task_rubric_id = ?", id).
+			Update("task_rubric_id", nil).Error
+	})
+}
+
+func (r *TaskLibraryRepository) GetTemplates(ctx context.Context, filter model.TaskLibraryFilter) ([]model.TaskTemplate, model.Metadata, error) {
+	var (
+		results  []model.TaskTemplate
+		total    int64
+		metadata = model.Metadata{
+			Page:     filter.Page,
+			PageSize: filter.PageSize,
+		}
+	)
+	db := libraryFilter(r.db.Read.WithContext(ctx).Model(&model.TaskTemplate{}), filter, "title")
+
+	err := db.Count(&total).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetTemplates] Error counting templates")
+		return nil, model.Metadata{}, err
+	}
+
+	metadata.Total = total
+
+	if !filter.Pagination.IsEmpty() {
+		db = db.Limit(filter.Pagination.Limit()).
+			Offset(filter.Pagination.Offset())
+	}
+
+	err = db.Preload("TaskRubric").
+		Preload("Course").
+		Preload("SubCourseCategory").
+		Order("title").
+		Find(&results).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetTemplates] Error getting templates")
+		return nil, model.Metadata{}, err
+	}
+
+	return results, metadata, nil
+}
+
+func (r *TaskLibraryRepository) GetTemplateByID(ctx context.Context, id uuid.UUID) (*model.TaskTemplate, error) {
+	var template model.TaskTemplate
+	err := r.db.Read.WithContext(ctx).
+		Preload("TaskRubric").
+		Preload("Course").
+		Preload("SubCourseCategory").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetTemplateByID] Error getting template")
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *TaskLibraryRepository) CreateTemplate(ctx context.Context, template *model.TaskTemplate) error {
+	return r.db.Write.WithContext(ctx).Omit("TaskRubric", "Course", "SubCourseCategory").Create(template).Error
+}
+
+func (r *TaskLibraryRepository) UpdateTemplate(ctx context.Context, template *model.TaskTemplate) error {
+	return r.db.Write.WithContext(ctx).Omit("TaskRubric", "Course", "SubCourseCategory").Save(template).Error
+}
+
+func (r *TaskLibraryRepository) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).
+		Model(&model.TaskTemplate{}).
+		Where("id = ?", id).
+		Update("deleted_at", time.Now()).Error
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type TaxRateRepository struct {
+	db *infras.MySQL
+}
+
+func NewTaxRateRepository(db *infras.MySQL) *TaxRateRepository {
+	return &TaxRateRepository{db: db}
+}
+
+func (r *TaxRateRepository) Get(ctx context.Context) ([]model.TaxRate, error) {
+	var rates []model.TaxRate
+	err := r.db.Read.WithContext(ctx).
+		Order("name asc, effective_from desc").
+		Find(&rates).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Get] Error getting tax rates")
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *TaxRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.TaxRate, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).Where("id = ?", id))
+}
+
+// GetEffective returns the rate of the tax in effect at the given time.
+func (r *TaxRateRepository) GetEffective(ctx context.Context, name string, at time.Time) (*model.TaxRate, error) {
+	return r.first(ctx, r.db.Read.WithContext(ctx).
+		Where("name = ? AND effective_from <= ?", name, at).
+		Order("effective_from desc"))
+}
+
+func (r *TaxRateRepository) Create(ctx context.Context, rate *model.TaxRate) error {
+	return r.db.Write.WithContext(ctx).Create(rate).Error
+}
+
+func (r *TaxRateRepository) Update(ctx context.Context, rate *model.TaxRate) error {
+	return r.db.Write.WithContext(ctx).Save(rate).Error
+}
+
+func (r *TaxRateRepository) first(ctx context.Context, db *gorm.DB) (*model.TaxRate, error) {
+	var rate model.TaxRate
+	err := db.First(&rate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[first] Error getting tax rate")
+		return nil, err
+	}
+
+	return &rate, nil
+}
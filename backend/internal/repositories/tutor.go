@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"gorm.io/gorm"
 
 	"github.com/lesprivate/backend/infras"
@@ -110,6 +111,22 @@ func (r *TutorRepository) Update(ctx context.Context, tutor *model.Tutor) error
 	return nil
 }
 
+// GetIDs returns the IDs of every tutor that is not deleted
+func (r *TutorRepository) GetIDs(ctx context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Read.WithContext(ctx).Model(&model.Tutor{}).
+		Where("deleted_at IS NULL").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// UpdateResponseTime stores the tutor response time in minutes
+func (r *TutorRepository) UpdateResponseTime(ctx context.Context, tutorID uuid.UUID, responseTime null.Int) error {
+	return r.db.Write.WithContext(ctx).Model(&model.Tutor{}).
+		Where("id = ?", tutorID).
+		UpdateColumn("response_time", responseTime).Error
+}
+
 func (r *TutorRepository) Get(ctx context.Context, filter model.TutorFilter) ([]model.Tutor, model.Metadata, error) {
 	var (
 		results  []model.Tutor
@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type TutorLevelRepository struct {
+	db *infras.MySQL
+}
+
+func NewTutorLevelRepository(db *infras.MySQL) *TutorLevelRepository {
+	return &TutorLevelRepository{db: db}
+}
+
+// GetCriteria returns the level criteria ordered from the entry level up.
+func (r *TutorLevelRepository) GetCriteria(ctx context.Context) ([]model.TutorLevelCriteria, error) {
+	var criteria []model.TutorLevelCriteria
+	err := r.db.Read.WithContext(ctx).Order("sequence asc").Find(&criteria).Error
+	return criteria, err
+}
+
+func (r *TutorLevelRepository) GetCriteriaByID(ctx context.Context, id uuid.UUID) (*model.TutorLevelCriteria, error) {
+	var criteria model.TutorLevelCriteria
+	err := r.db.Read.WithContext(ctx).Where("id = ?", id).First(&criteria).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &criteria, nil
+}
+
+func (r *TutorLevelRepository) UpdateCriteria(ctx context.Context, criteria *model.TutorLevelCriteria) error {
+	return r.db.Write.WithContext(ctx).Save(criteria).Error
+}
+
+// GetMetrics collects the numbers the tutor is levelled on. Completed
+// sessions and verified documents are lifetime totals, the rest is measured
+// over the last windowDays days.
+func (r *TutorLevelRepository) GetMetrics(ctx context.Context, tutorID uuid.UUID, windowDays int) (model.TutorLevelMetrics, error) {
+	var (
+		metrics model.TutorLevelMetrics
+		since   = time.Now().AddDate(0, 0, -windowDays)
+		db      = r.db.Read.WithContext(ctx)
+	)
+
+	var completed int64
+	err := db.Model(&model.Booking{}).
+		Where("tutor_id = ? AND deleted_at IS NULL", tutorID).
+		Where("status = ?", model.BookingStatusCompleted).
+		Count(&completed).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMetrics] Error counting completed sessions")
+		return metrics, err
+	}
+	metrics.CompletedSessions = int(completed)
+
+	var rating struct {
+		Rating      float64
+		TotalRating int
+	}
+	err = db.Model(&model.TutorReview{}).
+		Scopes(publicTutorReviews).
+		Select("COALESCE(AVG(rate), 0) AS rating, COUNT(*) AS total_rating").
+		Where("tutor_id = ? AND updated_at >= ?", tutorID, since).
+		Scan(&rating).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMetrics] Error calculating rating")
+		return metrics, err
+	}
+	metrics.AverageRating = decimal.NewFromFloat(rating.Rating).Round(2)
+	metrics.TotalRating = rating.TotalRating
+
+	responseTimes, err := tutorResponseTimes(db, tutorID, since)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMetrics] Error getting response times")
+		return metrics, err
+	}
+	metrics.ResponseTime = model.MedianMinutes(responseTimes)
+
+	var bookings struct {
+		Total     int
+		Cancelled int
+	}
+	err = db.Model(&model.Booking{}).
+		Select(`COUNT(*) AS total,
+			COALESCE(SUM(status IN (?, ?) OR (status = ? AND expired_at < ?)), 0) AS cancelled`,
+			model.BookingStatusDeclined, model.BookingStatusExpired, model.BookingStatusPending, time.Now()).
+		Where("tutor_id = ? AND deleted_at IS NULL AND created_at >= ?", tutorID, since).
+		Scan(&bookings).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMetrics] Error calculating cancellation rate")
+		return metrics, err
+	}
+	if bookings.Total > 0 {
+		metrics.CancellationRate = float64(bookings.Cancelled) / float64(bookings.Total) * 100
+	}
+
+	var documents int64
+	err = db.Table("tutor_documents").
+		Where("tutor_id = ? AND deleted_at IS NULL", tutorID).
+		Where("status = ?", model.TutorDocumentStatusActive).
+		Count(&documents).Error
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMetrics] Error counting verified documents")
+		return metrics, err
+	}
+	metrics.VerifiedDocuments = int(documents)
+
+	return metrics, nil
+}
+
+// ChangeLevel stores the new level on the tutor and records the change in the
+// level history.
+func (r *TutorLevelRepository) ChangeLevel(ctx context.Context, tutorID uuid.UUID, history *model.TutorLevelHistory) error {
+	return r.db.Write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Tutor{}).
+			Where("id = ?", tutorID).
+			UpdateColumns(map[string]any{
+				"level":              history.ToLevel,
+				"level_evaluated_at": time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(history).Error
+	})
+}
+
+func (r *TutorLevelRepository) MarkEvaluated(ctx context.Context, tutorID uuid.UUID) error {
+	return r.db.Write.WithContext(ctx).Model(&model.Tutor{}).
+		Where("id = ?", tutorID).
+		UpdateColumn("level_evaluated_at", time.Now()).Error
+}
+
+func (r *TutorLevelRepository) GetHistories(ctx context.Context, tutorID uuid.UUID, limit int) ([]model.TutorLevelHistory, error) {
+	var histories []model.TutorLevelHistory
+	err := r.db.Read.WithContext(ctx).
+		Where("tutor_id = ?", tutorID).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&histories).Error
+	return histories, err
+}
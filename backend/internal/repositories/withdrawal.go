@@ -2,10 +2,22 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/lesprivate/backend/internal/model"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrWithdrawalNotPending is returned when a withdrawal was approved or
+	// rejected in the meantime.
+	ErrWithdrawalNotPending = errors.New("withdrawal is not pending")
+	// ErrInsufficientBalance is returned when the mentor balance does not
+	// cover the withdrawal.
+	ErrInsufficientBalance = errors.New("insufficient balance")
 )
 
 type WithdrawalRepository struct {
@@ -91,6 +103,141 @@ func (r *WithdrawalRepository) ListAll(ctx context.Context, status string, filte
 	return withdrawals, metadata, nil
 }
 
+// CountOpen counts the withdrawals of a tutor not paid out, rejected or
+// failed yet.
+func (r *WithdrawalRepository) CountOpen(ctx context.Context, tutorID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.WithdrawalRequest{}).
+		Where("tutor_id = ? AND status IN ?", tutorID, []model.WithdrawalStatus{
+			model.WithdrawalStatusPending,
+			model.WithdrawalStatusApproved,
+			model.WithdrawalStatusProcessing,
+		}).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *WithdrawalRepository) Update(ctx context.Context, w *model.WithdrawalRequest) error {
 	return r.db.WithContext(ctx).Save(w).Error
 }
+
+// ListApproved returns the approved withdrawals not sent in a payout batch
+// yet, limited to ids when given.
+func (r *WithdrawalRepository) ListApproved(ctx context.Context, ids []uuid.UUID) ([]model.WithdrawalRequest, error) {
+	var withdrawals []model.WithdrawalRequest
+
+	query := r.db.WithContext(ctx).
+		Where("status = ? AND payout_batch_id IS NULL", model.WithdrawalStatusApproved)
+
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	if err := query.Order("created_at ASC").Find(&withdrawals).Error; err != nil {
+		return nil, err
+	}
+	return withdrawals, nil
+}
+
+func (r *WithdrawalRepository) GetByDisbursementID(ctx context.Context, disbursementID string) (*model.WithdrawalRequest, error) {
+	var w model.WithdrawalRequest
+	if err := r.db.WithContext(ctx).Preload("Tutor.User").Where("disbursement_id = ?", disbursementID).First(&w).Error; err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// UpdateStatus saves the payout fields of w only while the stored status is
+// still from. It reports whether the row was updated, so concurrent callbacks
+// settle a withdrawal once.
+func (r *WithdrawalRepository) UpdateStatus(ctx context.Context, w *model.WithdrawalRequest, from model.WithdrawalStatus) (bool, error) {
+	return updateStatus(r.db.WithContext(ctx), w, from)
+}
+
+func updateStatus(db *gorm.DB, w *model.WithdrawalRequest, from model.WithdrawalStatus) (bool, error) {
+	result := db.
+		Model(&model.WithdrawalRequest{}).
+		Where("id = ? AND status = ?", w.ID, from).
+		Select("status", "disbursement_id", "failure_code", "admin_note", "processed_at", "processed_by", "updated_at").
+		Updates(w)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Approve approves the pending withdrawal and holds its amount from the
+// mentor balance in one transaction. The balance row is locked while it is
+// checked so two approvals can not hold more than the balance. It fails with
+// ErrWithdrawalNotPending or ErrInsufficientBalance.
+func (r *WithdrawalRepository) Approve(ctx context.Context, w *model.WithdrawalRequest) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var mb model.MentorBalance
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tutor_id = ?", w.TutorID).
+			First(&mb).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInsufficientBalance
+		}
+		if err != nil {
+			return err
+		}
+
+		if mb.Balance.LessThan(w.Amount) {
+			return ErrInsufficientBalance
+		}
+
+		updated, err := updateStatus(tx, w, model.WithdrawalStatusPending)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrWithdrawalNotPending
+		}
+
+		hold := w.HoldTransaction(mb.Currency)
+		return moveBalance(tx, &hold, w.Amount.Neg())
+	})
+}
+
+// Release saves the failed withdrawal while its stored status is still from
+// and gives the held amount back to the mentor balance in one transaction.
+// It reports whether the withdrawal was updated, so concurrent callbacks
+// release it once.
+func (r *WithdrawalRepository) Release(ctx context.Context, w *model.WithdrawalRequest, from model.WithdrawalStatus) (bool, error) {
+	var released bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updated, err := updateStatus(tx, w, from)
+		if err != nil || !updated {
+			return err
+		}
+
+		var mb model.MentorBalance
+		err = tx.Where("tutor_id = ?", w.TutorID).First(&mb).Error
+		if err != nil {
+			return err
+		}
+
+		release := w.ReleaseTransaction(mb.Currency)
+		if err := moveBalance(tx, &release, w.Amount); err != nil {
+			return err
+		}
+
+		released = true
+		return nil
+	})
+
+	return released, err
+}
+
+// moveBalance adds amount to the mentor balance and records the transaction.
+func moveBalance(tx *gorm.DB, balanceTx *model.BalanceTransaction, amount decimal.Decimal) error {
+	err := tx.Model(&model.MentorBalance{}).
+		Where("tutor_id = ?", balanceTx.TutorID).
+		Update("balance", gorm.Expr("balance + ?", amount)).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Omit(clause.Associations).Create(balanceTx).Error
+}
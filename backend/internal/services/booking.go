@@ -26,6 +26,7 @@ type BookingService struct {
 	notification        *repositories.NotificationRepository
 	review              *repositories.ReviewRepository
 	notificationService *NotificationService
+	bookingEvent        *BookingEventService
 	redis               *infras.Redis
 }
 
@@ -37,6 +38,7 @@ func NewBookingService(
 	notification *repositories.NotificationRepository,
 	review *repositories.ReviewRepository,
 	notificationService *NotificationService,
+	bookingEvent *BookingEventService,
 	redis *infras.Redis,
 ) *BookingService {
 	return &BookingService{
@@ -47,6 +49,7 @@ func NewBookingService(
 		notification:        notification,
 		review:              review,
 		notificationService: notificationService,
+		bookingEvent:        bookingEvent,
 		redis:               redis,
 	}
 }
@@ -55,6 +58,7 @@ func (s *BookingService) ExpiredBooking(ctx context.Context) error {
 	bookings, _, err := s.booking.Get(ctx, model.BookingFilter{
 		ExpiredAtBefore: time.Now(),
 		Status:          model.BookingStatusPending,
+		IsGroupSession:  null.BoolFrom(false),
 	})
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[ExpiredBooking] Error getting bookings")
@@ -96,6 +100,8 @@ func (s *BookingService) ExpiredBooking(ctx context.Context) error {
 		return err
 	}
 
+	s.bookingEvent.RecordExpired(ctx, bookings)
+
 	if err := s.notification.BulkCreate(ctx, notifications); err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[StudentBookingCourse] Error creating notification for tutor")
 	}
@@ -129,7 +135,8 @@ func (s *BookingService) ReminderExpiredBooking(ctx context.Context) error {
 			time.Now(),
 			time.Now().Add(reminderDuration),
 		},
-		Status: model.BookingStatusPending,
+		Status:         model.BookingStatusPending,
+		IsGroupSession: null.BoolFrom(false),
 	})
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[ReminderExpiredBooking] Error getting bookings")
@@ -249,11 +256,13 @@ func (s *BookingService) ReminderCourseBooking(ctx context.Context) error {
 	return nil
 }
 
+// CreateReviewBooking requests the reviews of the completed sessions not
+// reviewed yet, sessions which did not take place are never reviewed.
 func (s *BookingService) CreateReviewBooking(ctx context.Context) error {
 	duration := s.config.Booking.CreateReviewDuration
 	bookings, _, err := s.booking.Get(ctx, model.BookingFilter{
 		BookingDateTimeAdd: duration,
-		Status:             model.BookingStatusAccepted,
+		Status:             model.BookingStatusCompleted,
 		IsReviewed:         null.BoolFrom(false),
 	})
 	if err != nil {
@@ -261,6 +270,12 @@ func (s *BookingService) CreateReviewBooking(ctx context.Context) error {
 		return err
 	}
 
+	return s.RequestReviews(ctx, bookings)
+}
+
+// RequestReviews creates the pending reviews of the tutor and the student of
+// the bookings and asks them to write them.
+func (s *BookingService) RequestReviews(ctx context.Context, bookings []model.Booking) error {
 	if len(bookings) == 0 {
 		return nil
 	}
@@ -270,13 +285,14 @@ func (s *BookingService) CreateReviewBooking(ctx context.Context) error {
 	for i, booking := range bookings {
 		bookings[i].IsReviewed = true
 		tutorReviews = append(tutorReviews, model.TutorReview{
-			ID:        uuid.New(),
-			BookingID: booking.ID,
-			CourseID:  booking.CourseID,
-			TutorID:   booking.TutorID,
-			StudentID: booking.StudentID,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			ID:               uuid.New(),
+			BookingID:        booking.ID,
+			CourseID:         booking.CourseID,
+			TutorID:          booking.TutorID,
+			StudentID:        booking.StudentID,
+			ModerationStatus: model.ReviewModerationStatusPublished,
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
 			CreatedBy: uuid.NullUUID{
 				UUID:  uuid.MustParse(model.SystemID),
 				Valid: true,
@@ -305,22 +321,22 @@ func (s *BookingService) CreateReviewBooking(ctx context.Context) error {
 		})
 	}
 
-	err = s.booking.BulkUpdate(ctx, bookings)
+	err := s.booking.BulkUpdate(ctx, bookings)
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateReviewBooking] Error bulk updating bookings")
+		logger.ErrorCtx(ctx).Err(err).Msg("[RequestReviews] Error bulk updating bookings")
 		return err
 	}
 
 	err = s.review.CreateReview(ctx, studentReviews, tutorReviews)
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateReviewBooking] Error bulk creating reviews")
+		logger.ErrorCtx(ctx).Err(err).Msg("[RequestReviews] Error bulk creating reviews")
 		return err
 	}
 
 	go func() {
-		err = s.notificationService.CreateReviewBooking(context.Background(), bookings)
+		err := s.notificationService.CreateReviewBooking(context.Background(), bookings)
 		if err != nil {
-			logger.ErrorCtx(ctx).Err(err).Msg("[CreateReviewBooking] Error bulk creating notifications")
+			logger.ErrorCtx(ctx).Err(err).Msg("[RequestReviews] Error bulk creating notifications")
 		}
 	}()
 
@@ -524,6 +540,8 @@ func (s *BookingService) CreateBookingForAdmin(ctx context.Context, req dto.Admi
 		return nil, shared.MakeError(ErrInternalServer)
 	}
 
+	s.bookingEvent.RecordCreated(ctx, *booking, uuid.MustParse(model.SystemID))
+
 	// Fetch created booking with relations
 	createdBooking, err := s.booking.GetByID(ctx, booking.ID)
 	if err != nil {
@@ -553,6 +571,7 @@ func (s *BookingService) UpdateBookingForAdmin(ctx context.Context, id uuid.UUID
 	}
 
 	// Update fields if provided
+	previousStatus := booking.Status
 	if req.Status != nil {
 		booking.Status = model.BookingStatus(*req.Status)
 		// Clear expiration for non-pending bookings
@@ -584,6 +603,10 @@ func (s *BookingService) UpdateBookingForAdmin(ctx context.Context, id uuid.UUID
 		return nil, shared.MakeError(ErrInternalServer)
 	}
 
+	if booking.Status != previousStatus {
+		s.bookingEvent.RecordStatus(ctx, *booking, uuid.MustParse(model.SystemID))
+	}
+
 	// Re-fetch with relations
 	updatedBooking, err := s.booking.GetByID(ctx, id)
 	if err != nil {
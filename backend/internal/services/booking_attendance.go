@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+// Defaults for the BOOKING attendance settings left unconfigured.
+const (
+	defaultCheckInBefore       = 15 * time.Minute
+	defaultNoShowAfter         = 15 * time.Minute
+	defaultNoShowDisputeWindow = 48 * time.Hour
+	defaultAutoCompleteAfter   = 3 * time.Hour
+)
+
+// BookingAttendanceService tracks the tutor and the student turning up to
+// the session of an accepted booking. A session both checked in to ends
+// completed, which requests the reviews, credits the mentor and counts
+// towards the tutor level. A party not turning up is reported as a no-show.
+type BookingAttendanceService struct {
+	config         *config.Config
+	booking        *repositories.BookingRepository
+	attendance     *repositories.BookingAttendanceRepository
+	bookingService *BookingService
+	bookingEvent   *BookingEventService
+	mentorBalance  *MentorBalanceService
+	tutorLevel     *TutorLevelService
+	notification   *NotificationService
+}
+
+func NewBookingAttendanceService(
+	config *config.Config,
+	booking *repositories.BookingRepository,
+	attendance *repositories.BookingAttendanceRepository,
+	bookingService *BookingService,
+	bookingEvent *BookingEventService,
+	mentorBalance *MentorBalanceService,
+	tutorLevel *TutorLevelService,
+	notification *NotificationService,
+) *BookingAttendanceService {
+	return &BookingAttendanceService{
+		config:         config,
+		booking:        booking,
+		attendance:     attendance,
+		bookingService: bookingService,
+		bookingEvent:   bookingEvent,
+		mentorBalance:  mentorBalance,
+		tutorLevel:     tutorLevel,
+		notification:   notification,
+	}
+}
+
+func durationOrDefault(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
+
+func (s *BookingAttendanceService) checkInBefore() time.Duration {
+	return durationOrDefault(s.config.Booking.CheckInBefore, defaultCheckInBefore)
+}
+
+func (s *BookingAttendanceService) noShowAfter() time.Duration {
+	return durationOrDefault(s.config.Booking.NoShowAfter, defaultNoShowAfter)
+}
+
+func (s *BookingAttendanceService) noShowDisputeWindow() time.Duration {
+	return durationOrDefault(s.config.Booking.NoShowDisputeWindow, defaultNoShowDisputeWindow)
+}
+
+func (s *BookingAttendanceService) autoCompleteAfter() time.Duration {
+	return durationOrDefault(s.config.Booking.AutoCompleteAfter, defaultAutoCompleteAfter)
+}
+
+// session returns the booking with its attendance, a new one when nobody
+// checked in yet, and the party the current user is on.
+func (s *BookingAttendanceService) session(ctx context.Context, bookingID uuid.UUID) (*model.Booking, *model.BookingAttendance, model.AttendanceParty, error) {
+	booking, err := s.booking.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, nil, "", shared.MakeError(ErrInternalServer)
+	}
+	if booking == nil || booking.DeletedAt.Valid {
+		return nil, nil, "", shared.MakeError(ErrEntityNotFound, "booking")
+	}
+
+	var party model.AttendanceParty
+	switch middleware.GetUserID(ctx) {
+	case booking.Tutor.UserID:
+		party = model.AttendancePartyTutor
+	case booking.Student.UserID:
+		party = model.AttendancePartyStudent
+	default:
+		return nil, nil, "", shared.MakeError(ErrEntityNotFound, "booking")
+	}
+
+	attendance := booking.Attendance
+	if attendance == nil {
+		attendance = &model.BookingAttendance{
+			ID:        uuid.New(),
+			BookingID: booking.ID,
+		}
+	}
+	// The attendance is saved on its own, never through the booking
+	booking.Attendance = nil
+
+	return booking, attendance, party, nil
+}
+
+// CheckIn records the current user turning up to the session. Check-ins open
+// shortly before the session starts and, for offline lessons, have to be made
+// near the lesson location when a geofence is configured.
+func (s *BookingAttendanceService) CheckIn(ctx context.Context, bookingID uuid.UUID, request dto.CheckInRequest) (*model.BookingAttendance, error) {
+	booking, attendance, party, err := s.session(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err = s.checkInError(booking, attendance, party, request, now); err != nil {
+		return nil, err
+	}
+
+	attendance.CheckIn(party, now, request.Latitude, request.Longitude)
+
+	saved, err := s.attendance.Save(ctx, attendance, party.CheckInColumns(), nil, model.BookingStatusAccepted)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if !saved {
+		return nil, shared.MakeError(ErrAttendanceNotAllowed, "sesi sudah diperbarui, silakan muat ulang")
+	}
+
+	return attendance, nil
+}
+
+// checkInError returns why the party can not check in to the session at now,
+// nil when they can.
+func (s *BookingAttendanceService) checkInError(booking *model.Booking, attendance *model.BookingAttendance, party model.AttendanceParty, request dto.CheckInRequest, now time.Time) error {
+	if booking.Status != model.BookingStatusAccepted {
+		return shared.MakeError(ErrAttendanceNotAllowed, "sesi tidak sedang dijadwalkan")
+	}
+
+	start := booking.StartsAt()
+	if now.Before(start.Add(-s.checkInBefore())) {
+		return shared.MakeError(ErrAttendanceNotAllowed, "sesi belum dimulai")
+	}
+	if now.After(start.Add(s.autoCompleteAfter())) {
+		return shared.MakeError(ErrAttendanceNotAllowed, "sesi sudah berakhir")
+	}
+	if attendance.CheckedIn(party) {
+		return shared.MakeError(ErrAttendanceNotAllowed, "kamu sudah check-in")
+	}
+
+	if radius := s.config.Booking.GeofenceRadius; radius > 0 && booking.ClassType == model.OfflineClassType {
+		if request.Latitude == nil || request.Longitude == nil {
+			return shared.MakeError(ErrAttendanceNotAllowed, "lokasi wajib diisi untuk kelas offline")
+		}
+
+		distance := model.DistanceMeters(
+			*request.Latitude, *request.Longitude,
+			booking.Latitude.InexactFloat64(), booking.Longitude.InexactFloat64(),
+		)
+		if distance > float64(radius) {
+			return shared.MakeError(ErrAttendanceNotAllowed, "kamu berada di luar lokasi les")
+		}
+	}
+
+	return nil
+}
+
+// CheckOut records the current user leaving the session. The tutor checking
+// out of a session both parties checked in to completes it.
+func (s *BookingAttendanceService) CheckOut(ctx context.Context, bookingID uuid.UUID) (*model.BookingAttendance, error) {
+	booking, attendance, party, err := s.session(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkOutError(booking, attendance, party); err != nil {
+		return nil, err
+	}
+
+	attendance.CheckOut(party, time.Now())
+	columns := []string{party.CheckOutColumn()}
+
+	var saved bool
+	if completesSession(attendance, party) {
+		saved, err = s.complete(ctx, booking, attendance, columns, model.BookingStatusAccepted, middleware.GetUserID(ctx))
+	} else {
+		saved, err = s.attendance.Save(ctx, attendance, columns, nil, model.BookingStatusAccepted)
+	}
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if !saved {
+		return nil, shared.MakeError(ErrAttendanceNotAllowed, "sesi sudah diperbarui, silakan muat ulang")
+	}
+
+	return attendance, nil
+}
+
+// checkOutError returns why the party can not check out of the session, nil
+// when they can.
+func checkOutError(booking *model.Booking, attendance *model.BookingAttendance, party model.AttendanceParty) error {
+	if booking.Status != model.BookingStatusAccepted {
+		return shared.MakeError(ErrAttendanceNotAllowed, "sesi tidak sedang dijadwalkan")
+	}
+	if !attendance.CheckedIn(party) {
+		return shared.MakeError(ErrAttendanceNotAllowed, "kamu belum check-in")
+	}
+	if attendance.CheckedOut(party) {
+		return shared.MakeError(ErrAttendanceNotAllowed, "kamu sudah check-out")
+	}
+
+	return nil
+}
+
+// completesSession reports whether the party checking out ends the session,
+// which only the tutor does once the student checked in.
+func completesSession(attendance *model.BookingAttendance, party model.AttendanceParty) bool {
+	return party == model.AttendancePartyTutor && attendance.CheckedIn(party.Other())
+}
+
+// ReportNoShow reports the other party absent from the session. The reporter
+// has to be checked in and the session started for a while.
+func (s *BookingAttendanceService) ReportNoShow(ctx context.Context, bookingID uuid.UUID, request dto.ReportNoShowRequest) (*model.BookingAttendance, error) {
+	booking, attendance, party, err := s.session(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err = s.noShowError(booking, attendance, party, now); err != nil {
+		return nil, err
+	}
+
+	absent := party.Other()
+
+	attendance.NoShowParty = null.StringFrom(string(absent))
+	attendance.NoShowReason = null.StringFrom(request.Reason)
+	attendance.NoShowReportedBy = uuid.NullUUID{UUID: middleware.GetUserID(ctx), Valid: true}
+	attendance.NoShowReportedAt = null.TimeFrom(now)
+	attendance.DisputeUntil = null.TimeFrom(now.Add(s.noShowDisputeWindow()))
+
+	booking.Status = model.BookingStatusNoShow
+	booking.UpdatedAt = now
+	booking.UpdatedBy = middleware.GetUserID(ctx)
+
+	// The check-in of the absent party is written unset too, so the report
+	// is not saved when they checked in meanwhile
+	columns := append(absent.CheckInColumns(), "no_show_party", "no_show_reason", "no_show_reported_by", "no_show_reported_at", "dispute_until")
+	saved, err := s.attendance.Save(ctx, attendance, columns, booking, model.BookingStatusAccepted)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if !saved {
+		return nil, shared.MakeError(ErrAttendanceNotAllowed, "sesi sudah diperbarui, silakan muat ulang")
+	}
+
+	go func(booking model.Booking, attendance model.BookingAttendance) {
+		ctx := context.Background()
+		s.bookingEvent.RecordStatus(ctx, booking, booking.UpdatedBy)
+
+		if err := s.notification.NoShowReported(ctx, booking, attendance); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ReportNoShow] Error sending no-show notification")
+		}
+	}(*booking, *attendance)
+
+	return attendance, nil
+}
+
+// noShowError returns why the party can not report the other absent at now,
+// nil when they can.
+func (s *BookingAttendanceService) noShowError(booking *model.Booking, attendance *model.BookingAttendance, party model.AttendanceParty, now time.Time) error {
+	if booking.Status != model.BookingStatusAccepted {
+		return shared.MakeError(ErrAttendanceNotAllowed, "sesi tidak sedang dijadwalkan")
+	}
+	if now.Before(booking.StartsAt().Add(s.noShowAfter())) {
+		return shared.MakeError(ErrAttendanceNotAllowed, "ketidakhadiran baru dapat dilaporkan setelah sesi berjalan")
+	}
+	if !attendance.CheckedIn(party) {
+		return shared.MakeError(ErrAttendanceNotAllowed, "kamu belum check-in")
+	}
+	if attendance.CheckedIn(party.Other()) {
+		return shared.MakeError(ErrAttendanceNotAllowed, "peserta sesi sudah check-in")
+	}
+
+	return nil
+}
+
+// DisputeNoShow lets the party reported absent contest the report within the
+// dispute window, leaving it to an admin to settle.
+func (s *BookingAttendanceService) DisputeNoShow(ctx context.Context, bookingID uuid.UUID, request dto.DisputeNoShowRequest) (*model.BookingAttendance, error) {
+	booking, attendance, party, err := s.session(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !canDisputeNoShow(booking, attendance, party, now) {
+		return nil, shared.MakeError(ErrAttendanceNotAllowed, "laporan tidak hadir tidak dapat diajukan keberatan")
+	}
+
+	attendance.DisputedAt = null.TimeFrom(now)
+	attendance.DisputeReason = null.StringFrom(request.Reason)
+
+	saved, err := s.attendance.Save(ctx, attendance, []string{"disputed_at", "dispute_reason"}, nil, model.BookingStatusNoShow)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if !saved {
+		return nil, shared.MakeError(ErrAttendanceNotAllowed, "laporan tidak hadir tidak dapat diajukan keberatan")
+	}
+
+	return attendance, nil
+}
+
+// canDisputeNoShow reports whether the party may still contest the no-show
+// reported on the booking at now.
+func canDisputeNoShow(booking *model.Booking, attendance *model.BookingAttendance, party model.AttendanceParty, now time.Time) bool {
+	return booking.Status == model.BookingStatusNoShow && attendance.CanDispute(party, now)
+}
+
+// GetNoShowDisputes returns the disputed no-shows waiting to be settled.
+func (s *BookingAttendanceService) GetNoShowDisputes(ctx context.Context, pagination model.Pagination) ([]dto.NoShowDisputeResponse, model.Metadata, error) {
+	attendances, metadata, err := s.attendance.GetDisputes(ctx, pagination)
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewNoShowDisputeResponses(attendances), metadata, nil
+}
+
+// ResolveNoShowDispute settles a disputed no-show. A session found to have
+// been held is completed, otherwise the no-show stands.
+func (s *BookingAttendanceService) ResolveNoShowDispute(ctx context.Context, bookingID uuid.UUID, request dto.ResolveNoShowDisputeRequest) (*model.BookingAttendance, error) {
+	booking, err := s.booking.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if booking == nil || booking.Attendance == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "booking")
+	}
+
+	attendance := booking.Attendance
+	booking.Attendance = nil
+	if booking.Status != model.BookingStatusNoShow || !attendance.IsDisputed() {
+		return nil, shared.MakeError(ErrBadRequest, "the no-show of the booking is not disputed")
+	}
+
+	adminID := middleware.GetUserID(ctx)
+	attendance.ResolvedAt = null.TimeFrom(time.Now())
+	attendance.ResolvedBy = uuid.NullUUID{UUID: adminID, Valid: true}
+
+	columns := []string{"resolved_at", "resolved_by"}
+
+	var saved bool
+	if request.SessionHeld {
+		saved, err = s.complete(ctx, booking, attendance, columns, model.BookingStatusNoShow, adminID)
+	} else {
+		saved, err = s.attendance.Save(ctx, attendance, columns, nil, model.BookingStatusNoShow)
+	}
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if !saved {
+		return nil, shared.MakeError(ErrBadRequest, "the no-show of the booking is not disputed")
+	}
+
+	go func(booking model.Booking) {
+		ctx := context.Background()
+		if err := s.notification.NoShowResolved(ctx, booking, request.SessionHeld); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ResolveNoShowDispute] Error sending dispute notification")
+		}
+	}(*booking)
+
+	return attendance, nil
+}
+
+// CompleteUnfinishedSessions completes the sessions both parties checked in
+// to which the tutor did not check out of in time.
+func (s *BookingAttendanceService) CompleteUnfinishedSessions(ctx context.Context) error {
+	attendances, err := s.attendance.GetUnfinished(ctx, time.Now().Add(-s.autoCompleteAfter()))
+	if err != nil {
+		return err
+	}
+
+	for i := range attendances {
+		attendance := &attendances[i]
+		booking := attendance.Booking
+		attendance.Booking = nil
+
+		_, err = s.complete(ctx, booking, attendance, nil, model.BookingStatusAccepted, uuid.MustParse(model.SystemID))
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("booking_id", booking.ID.String()).Msg("[CompleteUnfinishedSessions] Error completing session")
+		}
+	}
+
+	return nil
+}
+
+// complete marks the session as held, saving the given columns of the
+// attendance with it, and then requests the reviews, credits the mentor and
+// re-evaluates the tutor level. It reports false when the booking left the
+// from status meanwhile, so a session is only completed once.
+func (s *BookingAttendanceService) complete(ctx context.Context, booking *model.Booking, attendance *model.BookingAttendance, columns []string, from model.BookingStatus, actorID uuid.UUID) (bool, error) {
+	now := time.Now()
+	attendance.Complete()
+	if attendance.DurationMinutes.Valid {
+		columns = append(columns, "duration_minutes")
+	}
+
+	booking.Status = model.BookingStatusCompleted
+	booking.CompletedAt = null.TimeFrom(now)
+	booking.UpdatedAt = now
+	booking.UpdatedBy = actorID
+
+	saved, err := s.attendance.Save(ctx, attendance, columns, booking, from)
+	if err != nil || !saved {
+		return false, err
+	}
+
+	go func(booking model.Booking) {
+		ctx := context.Background()
+		s.bookingEvent.RecordStatus(ctx, booking, actorID)
+
+		if err := s.bookingService.RequestReviews(ctx, []model.Booking{booking}); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[CompleteSession] Error requesting reviews")
+		}
+
+		if err := s.mentorBalance.CreditFromSession(ctx, booking); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[CompleteSession] Error crediting mentor balance")
+		}
+
+		if err := s.tutorLevel.Evaluate(ctx, booking.TutorID); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[CompleteSession] Error evaluating tutor level")
+		}
+	}(*booking)
+
+	return true, nil
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/shared"
+)
+
+func TestBookingAttendanceServiceDurations(t *testing.T) {
+	s := &BookingAttendanceService{config: &config.Config{}}
+	if s.checkInBefore() != defaultCheckInBefore || s.noShowAfter() != defaultNoShowAfter ||
+		s.noShowDisputeWindow() != defaultNoShowDisputeWindow || s.autoCompleteAfter() != defaultAutoCompleteAfter {
+		t.Errorf("durations = (%s, %s, %s, %s), want the defaults",
+			s.checkInBefore(), s.noShowAfter(), s.noShowDisputeWindow(), s.autoCompleteAfter())
+	}
+
+	cfg := &config.Config{}
+	cfg.Booking.CheckInBefore = 30 * time.Minute
+	cfg.Booking.NoShowAfter = 20 * time.Minute
+	cfg.Booking.NoShowDisputeWindow = 24 * time.Hour
+	cfg.Booking.AutoCompleteAfter = -time.Hour
+
+	s = &BookingAttendanceService{config: cfg}
+	if s.checkInBefore() != 30*time.Minute || s.noShowAfter() != 20*time.Minute || s.noShowDisputeWindow() != 24*time.Hour {
+		t.Errorf("durations = (%s, %s, %s), want the configured ones", s.checkInBefore(), s.noShowAfter(), s.noShowDisputeWindow())
+	}
+	if s.autoCompleteAfter() != defaultAutoCompleteAfter {
+		t.Errorf("autoCompleteAfter() = %s, want the default for a negative setting", s.autoCompleteAfter())
+	}
+}
+
+func newAttendanceBooking(classType model.ClassType) *model.Booking {
+	return &model.Booking{
+		Status:      model.BookingStatusAccepted,
+		ClassType:   classType,
+		BookingDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local),
+		BookingTime: "10:00:00",
+		Latitude:    decimal.RequireFromString("-6.2"),
+		Longitude:   decimal.RequireFromString("106.8"),
+	}
+}
+
+func attendanceError(message string) string {
+	return shared.MakeError(ErrAttendanceNotAllowed, message).Error()
+}
+
+func TestBookingAttendanceServiceCheckInError(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Booking.GeofenceRadius = 100
+	s := &BookingAttendanceService{config: cfg}
+
+	start := newAttendanceBooking(model.OnlineClassType).StartsAt()
+	coordinate := func(v float64) *float64 { return &v }
+	nearby := dto.CheckInRequest{Latitude: coordinate(-6.2005), Longitude: coordinate(106.8)}
+	away := dto.CheckInRequest{Latitude: coordinate(-6.202), Longitude: coordinate(106.8)}
+
+	tests := []struct {
+		name       string
+		classType  model.ClassType
+		status     model.BookingStatus
+		attendance model.BookingAttendance
+		request    dto.CheckInRequest
+		now        time.Time
+		want       string
+	}{
+		{name: "shortly before the start", classType: model.OnlineClassType, now: start.Add(-15 * time.Minute)},
+		{name: "too early", classType: model.OnlineClassType, now: start.Add(-16 * time.Minute), want: attendanceError("sesi belum dimulai")},
+		{name: "after the session ended", classType: model.OnlineClassType, now: start.Add(3*time.Hour + time.Minute), want: attendanceError("sesi sudah berakhir")},
+		{name: "booking not accepted", classType: model.OnlineClassType, status: model.BookingStatusCancelled, now: start, want: attendanceError("sesi tidak sedang dijadwalkan")},
+		{
+			name:       "checked in already",
+			classType:  model.OnlineClassType,
+			attendance: model.BookingAttendance{TutorCheckInAt: null.TimeFrom(start)},
+			now:        start,
+			want:       attendanceError("kamu sudah check-in"),
+		},
+		{name: "online lessons ignore the location", classType: model.OnlineClassType, request: away, now: start},
+		{name: "offline within the geofence", classType: model.OfflineClassType, request: nearby, now: start},
+		{name: "offline outside the geofence", classType: model.OfflineClassType, request: away, now: start, want: attendanceError("kamu berada di luar lokasi les")},
+		{name: "offline without a location", classType: model.OfflineClassType, now: start, want: attendanceError("lokasi wajib diisi untuk kelas offline")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := newAttendanceBooking(tt.classType)
+			if tt.status != "" {
+				booking.Status = tt.status
+			}
+
+			err := s.checkInError(booking, &tt.attendance, model.AttendancePartyTutor, tt.request, tt.now)
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("checkInError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookingAttendanceServiceCheckInWithoutGeofence(t *testing.T) {
+	s := &BookingAttendanceService{config: &config.Config{}}
+	booking := newAttendanceBooking(model.OfflineClassType)
+
+	if err := s.checkInError(booking, &model.BookingAttendance{}, model.AttendancePartyStudent, dto.CheckInRequest{}, booking.StartsAt()); err != nil {
+		t.Errorf("checkInError() = %v, want no error without a geofence radius", err)
+	}
+}
+
+func TestCheckOutError(t *testing.T) {
+	at := null.TimeFrom(time.Date(2026, 3, 10, 10, 0, 0, 0, time.Local))
+
+	tests := []struct {
+		name         string
+		status       model.BookingStatus
+		attendance   model.BookingAttendance
+		want         string
+		wantComplete bool
+	}{
+		{name: "not checked in", want: attendanceError("kamu belum check-in")},
+		{name: "student not checked in yet", attendance: model.BookingAttendance{TutorCheckInAt: at}},
+		{
+			name:         "both checked in",
+			attendance:   model.BookingAttendance{TutorCheckInAt: at, StudentCheckInAt: at},
+			wantComplete: true,
+		},
+		{
+			name:       "checked out already",
+			attendance: model.BookingAttendance{TutorCheckInAt: at, TutorCheckOutAt: at},
+			want:       attendanceError("kamu sudah check-out"),
+		},
+		{
+			name:       "session over",
+			status:     model.BookingStatusCompleted,
+			attendance: model.BookingAttendance{TutorCheckInAt: at, StudentCheckInAt: at},
+			want:       attendanceError("sesi tidak sedang dijadwalkan"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := newAttendanceBooking(model.OnlineClassType)
+			if tt.status != "" {
+				booking.Status = tt.status
+			}
+
+			err := checkOutError(booking, &tt.attendance, model.AttendancePartyTutor)
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("checkOutError() = %q, want %q", got, tt.want)
+			}
+			if tt.want == "" && completesSession(&tt.attendance, model.AttendancePartyTutor) != tt.wantComplete {
+				t.Errorf("completesSession() = %v, want %v", !tt.wantComplete, tt.wantComplete)
+			}
+		})
+	}
+
+	both := model.BookingAttendance{TutorCheckInAt: at, StudentCheckInAt: at}
+	if completesSession(&both, model.AttendancePartyStudent) {
+		t.Errorf("completesSession() = true, want only the tutor to complete the session")
+	}
+}
+
+func TestBookingAttendanceServiceNoShowError(t *testing.T) {
+	s := &BookingAttendanceService{config: &config.Config{}}
+	start := newAttendanceBooking(model.OnlineClassType).StartsAt()
+	checkedIn := null.TimeFrom(start)
+
+	tests := []struct {
+		name       string
+		status     model.BookingStatus
+		attendance model.BookingAttendance
+		now        time.Time
+		want       string
+	}{
+		{name: "other party absent", attendance: model.BookingAttendance{StudentCheckInAt: checkedIn}, now: start.Add(15 * time.Minute)},
+		{
+			name:       "too soon after the start",
+			attendance: model.BookingAttendance{StudentCheckInAt: checkedIn},
+			now:        start.Add(14 * time.Minute),
+			want:       attendanceError("ketidakhadiran baru dapat dilaporkan setelah sesi berjalan"),
+		},
+		{name: "reporter not checked in", now: start.Add(time.Hour), want: attendanceError("kamu belum check-in")},
+		{
+			name:       "other party checked in",
+			attendance: model.BookingAttendance{StudentCheckInAt: checkedIn, TutorCheckInAt: checkedIn},
+			now:        start.Add(time.Hour),
+			want:       attendanceError("peserta sesi sudah check-in"),
+		},
+		{
+			name:       "booking not accepted",
+			status:     model.BookingStatusNoShow,
+			attendance: model.BookingAttendance{StudentCheckInAt: checkedIn},
+			now:        start.Add(time.Hour),
+			want:       attendanceError("sesi tidak sedang dijadwalkan"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := newAttendanceBooking(model.OnlineClassType)
+			if tt.status != "" {
+				booking.Status = tt.status
+			}
+
+			err := s.noShowError(booking, &tt.attendance, model.AttendancePartyStudent, tt.now)
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("noShowError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanDisputeNoShow(t *testing.T) {
+	s := &BookingAttendanceService{config: &config.Config{}}
+	reportedAt := time.Date(2026, 3, 10, 11, 0, 0, 0, time.Local)
+	attendance := model.BookingAttendance{
+		NoShowParty:  null.StringFrom(string(model.AttendancePartyTutor)),
+		DisputeUntil: null.TimeFrom(reportedAt.Add(s.noShowDisputeWindow())),
+	}
+
+	tests := []struct {
+		name   string
+		status model.BookingStatus
+		party  model.AttendanceParty
+		now    time.Time
+		want   bool
+	}{
+		{name: "within the window", status: model.BookingStatusNoShow, party: model.AttendancePartyTutor, now: reportedAt.Add(47 * time.Hour), want: true},
+		{name: "window closed", status: model.BookingStatusNoShow, party: model.AttendancePartyTutor, now: reportedAt.Add(48 * time.Hour)},
+		{name: "party who reported", status: model.BookingStatusNoShow, party: model.AttendancePartyStudent, now: reportedAt.Add(time.Hour)},
+		{name: "no-show settled", status: model.BookingStatusCompleted, party: model.AttendancePartyTutor, now: reportedAt.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := newAttendanceBooking(model.OnlineClassType)
+			booking.Status = tt.status
+
+			if got := canDisputeNoShow(booking, &attendance, tt.party, tt.now); got != tt.want {
+				t.Errorf("canDisputeNoShow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
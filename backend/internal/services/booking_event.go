@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// defaultResponseTimeWindow is used when BOOKING.RESPONSE_TIME_WINDOW is not
+// configured.
+const defaultResponseTimeWindow = 90 * 24 * time.Hour
+
+// BookingEventService records the booking lifecycle and derives the tutor
+// response time from it.
+type BookingEventService struct {
+	config       *config.Config
+	bookingEvent *repositories.BookingEventRepository
+	tutor        *repositories.TutorRepository
+}
+
+func NewBookingEventService(
+	config *config.Config,
+	bookingEvent *repositories.BookingEventRepository,
+	tutor *repositories.TutorRepository,
+) *BookingEventService {
+	return &BookingEventService{
+		config:       config,
+		bookingEvent: bookingEvent,
+		tutor:        tutor,
+	}
+}
+
+func newBookingEvent(booking model.Booking, eventType model.BookingEventType, actorID uuid.UUID) model.BookingEvent {
+	return model.BookingEvent{
+		ID:         uuid.New(),
+		BookingID:  booking.ID,
+		TutorID:    booking.TutorID,
+		Type:       eventType,
+		OccurredAt: time.Now(),
+		ActorID:    uuid.NullUUID{UUID: actorID, Valid: actorID != uuid.Nil},
+	}
+}
+
+// RecordCreated records a new booking. Bookings that are created already
+// answered also get the matching answer event.
+func (s *BookingEventService) RecordCreated(ctx context.Context, booking model.Booking, actorID uuid.UUID) {
+	events := []model.BookingEvent{newBookingEvent(booking, model.BookingEventCreated, actorID)}
+	if eventType, ok := model.BookingEventTypeByStatus[booking.Status]; ok {
+		events = append(events, newBookingEvent(booking, eventType, actorID))
+	}
+
+	err := s.bookingEvent.BulkCreate(ctx, events)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", booking.ID.String()).Msg("[RecordCreated] Error creating booking event")
+	}
+}
+
+// RecordStatus records the booking moving to its current status. Approvals
+// and declines refresh the tutor response time.
+func (s *BookingEventService) RecordStatus(ctx context.Context, booking model.Booking, actorID uuid.UUID) {
+	eventType, ok := model.BookingEventTypeByStatus[booking.Status]
+	if !ok {
+		return
+	}
+
+	event := newBookingEvent(booking, eventType, actorID)
+	err := s.bookingEvent.Create(ctx, &event)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("booking_id", booking.ID.String()).Msg("[RecordStatus] Error creating booking event")
+		return
+	}
+
+	if eventType == model.BookingEventApproved || eventType == model.BookingEventDeclined {
+		err = s.RecalculateResponseTime(ctx, booking.TutorID)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("tutor_id", booking.TutorID.String()).Msg("[RecordStatus] Error recalculating response time")
+		}
+	}
+}
+
+// RecordExpired records a batch of bookings that expired without an answer.
+func (s *BookingEventService) RecordExpired(ctx context.Context, bookings []model.Booking) {
+	events := make([]model.BookingEvent, len(bookings))
+	for i, booking := range bookings {
+		events[i] = newBookingEvent(booking, model.BookingEventExpired, uuid.MustParse(model.SystemID))
+	}
+
+	err := s.bookingEvent.BulkCreate(ctx, events)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RecordExpired] Error creating booking events")
+	}
+}
+
+func (s *BookingEventService) responseTimeWindow() time.Duration {
+	if s.config.Booking.ResponseTimeWindow > 0 {
+		return s.config.Booking.ResponseTimeWindow
+	}
+	return defaultResponseTimeWindow
+}
+
+// RecalculateResponseTime stores the median time, in minutes, the tutor took
+// to answer bookings within the rolling window.
+func (s *BookingEventService) RecalculateResponseTime(ctx context.Context, tutorID uuid.UUID) error {
+	minutes, err := s.bookingEvent.GetResponseTimes(ctx, tutorID, time.Now().Add(-s.responseTimeWindow()))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RecalculateResponseTime] Error getting response times")
+		return err
+	}
+
+	return s.tutor.UpdateResponseTime(ctx, tutorID, model.MedianMinutes(minutes))
+}
+
+// RecalculateAllResponseTimes refreshes every tutor so answers that left the
+// rolling window stop counting.
+func (s *BookingEventService) RecalculateAllResponseTimes(ctx context.Context) error {
+	ids, err := s.tutor.GetIDs(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RecalculateAllResponseTimes] Error getting tutors")
+		return err
+	}
+
+	for _, id := range ids {
+		err = s.RecalculateResponseTime(ctx, id)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("tutor_id", id.String()).Msg("[RecalculateAllResponseTimes] Error recalculating response time")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestNewBookingEvent(t *testing.T) {
+	booking := model.Booking{ID: uuid.New(), TutorID: uuid.New()}
+
+	event := newBookingEvent(booking, model.BookingEventApproved, uuid.Nil)
+	if event.BookingID != booking.ID || event.TutorID != booking.TutorID || event.Type != model.BookingEventApproved {
+		t.Errorf("newBookingEvent() = %+v, want the booking and tutor of the booking", event)
+	}
+	if event.ActorID.Valid {
+		t.Errorf("newBookingEvent() actor = %v, want none for a nil actor", event.ActorID)
+	}
+
+	actorID := uuid.New()
+	if event := newBookingEvent(booking, model.BookingEventCreated, actorID); !event.ActorID.Valid || event.ActorID.UUID != actorID {
+		t.Errorf("newBookingEvent() actor = %v, want %s", event.ActorID, actorID)
+	}
+}
+
+func TestBookingEventServiceResponseTimeWindow(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured time.Duration
+		want       time.Duration
+	}{
+		{name: "default", want: defaultResponseTimeWindow},
+		{name: "configured", configured: 30 * 24 * time.Hour, want: 30 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Booking.ResponseTimeWindow = tt.configured
+
+			s := &BookingEventService{config: cfg}
+			if got := s.responseTimeWindow(); got != tt.want {
+				t.Errorf("responseTimeWindow() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,527 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/infras"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+const maxMessageAttachments = 5
+
+// ConversationService runs the message threads between tutors and students.
+// New messages and read receipts are published on Redis so the stream of a
+// user gets them whichever instance serves it.
+type ConversationService struct {
+	conversation *repositories.ConversationRepository
+	booking      *repositories.BookingRepository
+	tutor        *repositories.TutorRepository
+	student      *repositories.StudentRepository
+	file         *FileService
+	redis        *infras.Redis
+}
+
+func NewConversationService(
+	conversation *repositories.ConversationRepository,
+	booking *repositories.BookingRepository,
+	tutor *repositories.TutorRepository,
+	student *repositories.StudentRepository,
+	file *FileService,
+	redis *infras.Redis,
+) *ConversationService {
+	return &ConversationService{
+		conversation: conversation,
+		booking:      booking,
+		tutor:        tutor,
+		student:      student,
+		file:         file,
+		redis:        redis,
+	}
+}
+
+// StartConversation returns the thread of the current user with the other
+// party, opening it on first contact. Students may contact any tutor, tutors
+// only the students who booked them.
+func (s *ConversationService) StartConversation(ctx context.Context, request dto.StartConversationRequest) (*dto.ConversationResponse, error) {
+	userID := middleware.GetUserID(ctx)
+
+	var (
+		tutorID, studentID uuid.UUID
+		bookingID          uuid.NullUUID
+	)
+
+	switch {
+	case request.BookingID != uuid.Nil:
+		booking, err := s.booking.GetByID(ctx, request.BookingID)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Error getting booking")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+		if booking == nil || (booking.Tutor.UserID != userID && booking.Student.UserID != userID) {
+			return nil, shared.MakeError(ErrEntityNotFound, "booking")
+		}
+		tutorID, studentID = booking.TutorID, booking.StudentID
+		bookingID = uuid.NullUUID{UUID: booking.ID, Valid: true}
+
+	case request.TutorID != uuid.Nil:
+		student, err := s.student.GetByUserID(ctx, userID)
+		if err != nil || student == nil {
+			return nil, shared.MakeError(ErrEntityNotFound, "student")
+		}
+		tutor, err := s.tutor.GetByID(ctx, request.TutorID)
+		if err != nil || tutor == nil {
+			return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+		}
+		tutorID, studentID = tutor.ID, student.ID
+
+	default:
+		tutor, err := s.tutor.GetByUserID(ctx, userID)
+		if err != nil || tutor == nil {
+			return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+		}
+		booked, err := s.booking.Count(ctx, model.BookingFilter{
+			TutorID:   tutor.ID,
+			StudentID: request.StudentID,
+		})
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Error counting bookings")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+		if booked == 0 {
+			return nil, shared.MakeError(ErrEntityNotFound, "student")
+		}
+		tutorID, studentID = tutor.ID, request.StudentID
+	}
+
+	conversation, err := s.conversation.GetByParticipants(ctx, tutorID, studentID, bookingID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Error getting conversation")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if conversation == nil {
+		now := time.Now()
+		err = s.conversation.Create(ctx, &model.Conversation{
+			ID:        uuid.New(),
+			TutorID:   tutorID,
+			StudentID: studentID,
+			BookingID: bookingID,
+			CreatedBy: userID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Error creating conversation")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+
+		conversation, err = s.conversation.GetByParticipants(ctx, tutorID, studentID, bookingID)
+		if err != nil || conversation == nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Error getting created conversation")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+	}
+
+	unread, err := s.conversation.CountUnread(ctx, []uuid.UUID{conversation.ID}, userID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StartConversation] Error counting unread messages")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewConversationResponse(*conversation, unread[conversation.ID])
+	return &res, nil
+}
+
+// GetConversations lists the threads of the current user with the number of
+// messages they have not read in each.
+func (s *ConversationService) GetConversations(ctx context.Context, request dto.GetConversationsRequest) ([]dto.ConversationResponse, model.Metadata, error) {
+	userID := middleware.GetUserID(ctx)
+
+	conversations, metadata, err := s.conversation.Get(ctx, model.ConversationFilter{
+		UserID:     userID,
+		Pagination: request.Pagination,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversations] Error getting conversations")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	ids := make([]uuid.UUID, 0, len(conversations))
+	for _, conversation := range conversations {
+		ids = append(ids, conversation.ID)
+	}
+
+	unread, err := s.conversation.CountUnread(ctx, ids, userID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversations] Error counting unread messages")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	res := make([]dto.ConversationResponse, 0, len(conversations))
+	for _, conversation := range conversations {
+		res = append(res, dto.NewConversationResponse(conversation, unread[conversation.ID]))
+	}
+
+	return res, metadata, nil
+}
+
+func (s *ConversationService) GetUnreadMessages(ctx context.Context) (*dto.UnreadMessagesResponse, error) {
+	total, err := s.conversation.CountAllUnread(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetUnreadMessages] Error counting unread messages")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return &dto.UnreadMessagesResponse{Total: total}, nil
+}
+
+// participantConversation returns the conversation when the current user
+// takes part in it.
+func (s *ConversationService) participantConversation(ctx context.Context, id uuid.UUID) (*model.Conversation, error) {
+	conversation, err := s.conversation.GetByID(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[participantConversation] Error getting conversation")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if conversation == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "conversation")
+	}
+
+	if _, ok := conversation.Party(middleware.GetUserID(ctx)); !ok {
+		return nil, shared.MakeError(ErrEntityNotFound, "conversation")
+	}
+
+	return conversation, nil
+}
+
+func (s *ConversationService) GetMessages(ctx context.Context, id uuid.UUID, request dto.GetConversationMessagesRequest) ([]dto.ConversationMessageResponse, model.Metadata, error) {
+	conversation, err := s.participantConversation(ctx, id)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	messages, metadata, err := s.conversation.GetMessages(ctx, conversation.ID, request.Pagination)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetMessages] Error getting messages")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewConversationMessageResponses(messages), metadata, nil
+}
+
+// SendMessage posts a message of the current user with its files. Until the
+// pair has a confirmed booking, email addresses and phone numbers in the
+// text are masked so lessons are not arranged off the platform.
+func (s *ConversationService) SendMessage(ctx context.Context, id uuid.UUID, request dto.SendMessageRequest, files []*multipart.FileHeader) (*dto.ConversationMessageResponse, error) {
+	if len(files) > maxMessageAttachments {
+		return nil, shared.MakeError(ErrBadRequest, fmt.Sprintf("at most %d files can be sent", maxMessageAttachments))
+	}
+
+	for _, header := range files {
+		if err := s.file.ValidateFileType(header.Filename); err != nil {
+			return nil, shared.MakeError(ErrBadRequest, err.Error())
+		}
+	}
+
+	conversation, err := s.participantConversation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := middleware.GetUserID(ctx)
+	message := model.ConversationMessage{
+		ID:             uuid.New(),
+		ConversationID: conversation.ID,
+		SenderID:       userID,
+		CreatedAt:      time.Now(),
+	}
+
+	if request.Body != "" {
+		body := request.Body
+
+		confirmed, err := s.booking.Count(ctx, model.BookingFilter{
+			TutorID:   conversation.TutorID,
+			StudentID: conversation.StudentID,
+			StatusIn:  []model.BookingStatus{model.BookingStatusAccepted, model.BookingStatusCompleted, model.BookingStatusNoShow},
+		})
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[SendMessage] Error counting confirmed bookings")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+
+		if confirmed == 0 {
+			body, message.IsMasked = model.MaskContactDetails(body)
+		}
+		message.Body = null.StringFrom(body)
+	}
+
+	for _, header := range files {
+		upload, err := s.uploadAttachment(ctx, header)
+		if err != nil {
+			s.deleteAttachments(ctx, message.Attachments)
+			return nil, err
+		}
+
+		message.Attachments = append(message.Attachments, model.ConversationMessageAttachment{
+			ID:        uuid.New(),
+			MessageID: message.ID,
+			URL:       upload.URL,
+			FileKey:   upload.Key,
+			Filename:  upload.Filename,
+			Size:      upload.Size,
+			CreatedAt: message.CreatedAt,
+		})
+	}
+
+	err = s.conversation.CreateMessage(ctx, &message)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SendMessage] Error creating message")
+		s.deleteAttachments(ctx, message.Attachments)
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewConversationMessageResponse(message)
+	event := dto.ConversationEvent{
+		Type:           dto.ConversationEventMessage,
+		ConversationID: conversation.ID,
+		Message:        &res,
+	}
+	s.publish(ctx, event, conversation.Recipient(userID), userID)
+
+	return &res, nil
+}
+
+// deleteAttachments removes the uploaded files of a message that was not
+// sent.
+func (s *ConversationService) deleteAttachments(ctx context.Context, attachments []model.ConversationMessageAttachment) {
+	for _, attachment := range attachments {
+		err := s.file.DeleteFile(ctx, attachment.FileKey)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("key", attachment.FileKey).Msg("[SendMessage] Error deleting unsent attachment")
+		}
+	}
+}
+
+func (s *ConversationService) uploadAttachment(ctx context.Context, header *multipart.FileHeader) (*dto.UploadResult, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, shared.MakeError(ErrBadRequest, err.Error())
+	}
+	defer file.Close()
+
+	result, err := s.file.UploadFile(ctx, file, header)
+	if err != nil {
+		if header.Size > s.file.config.File.MaxUploadSize {
+			return nil, shared.MakeError(ErrBadRequest, err.Error())
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MarkRead marks the messages of the other party read and lets them know.
+func (s *ConversationService) MarkRead(ctx context.Context, id uuid.UUID) error {
+	conversation, err := s.participantConversation(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	userID := middleware.GetUserID(ctx)
+	now := time.Now()
+	read, err := s.conversation.MarkRead(ctx, conversation.ID, userID, now)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[MarkRead] Error marking messages read")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if read > 0 {
+		s.publish(ctx, dto.ConversationEvent{
+			Type:           dto.ConversationEventRead,
+			ConversationID: conversation.ID,
+			ReadBy:         uuid.NullUUID{UUID: userID, Valid: true},
+			ReadAt:         null.TimeFrom(now),
+		}, conversation.Recipient(userID), userID)
+	}
+
+	return nil
+}
+
+// ReportConversation files a report on the conversation, opening it to the
+// admins.
+func (s *ConversationService) ReportConversation(ctx context.Context, id uuid.UUID, request dto.ReportConversationRequest) error {
+	conversation, err := s.participantConversation(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	userID := middleware.GetUserID(ctx)
+	reported, err := s.conversation.HasPendingReport(ctx, conversation.ID, userID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportConversation] Error checking pending report")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if reported {
+		return shared.MakeError(ErrBadRequest, "conversation already reported")
+	}
+
+	now := time.Now()
+	err = s.conversation.CreateReport(ctx, &model.ConversationReport{
+		ID:             uuid.New(),
+		ConversationID: conversation.ID,
+		ReporterID:     userID,
+		Reason:         request.Reason,
+		Status:         model.ConversationReportStatusPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportConversation] Error creating report")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+// Subscribe streams the events of the conversations of the user until ctx is
+// done.
+func (s *ConversationService) Subscribe(ctx context.Context, userID uuid.UUID) (<-chan dto.ConversationEvent, error) {
+	pubsub := s.redis.Client.Subscribe(ctx, model.BuildCacheKey(model.ConversationEventsChannel, userID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Subscribe] Error subscribing to conversation events")
+		_ = pubsub.Close()
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	events := make(chan dto.ConversationEvent)
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event dto.ConversationEvent
+				if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+					logger.ErrorCtx(ctx).Err(err).Msg("[Subscribe] Error decoding conversation event")
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// publish sends the event to the streams of the users, failing quietly as
+// the messages are stored either way.
+func (s *ConversationService) publish(ctx context.Context, event dto.ConversationEvent, userIDs ...uuid.UUID) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[publish] Error encoding conversation event")
+		return
+	}
+
+	for _, userID := range userIDs {
+		err := s.redis.Client.Publish(ctx, model.BuildCacheKey(model.ConversationEventsChannel, userID), payload).Err()
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("user_id", userID.String()).Msg("[publish] Error publishing conversation event")
+		}
+	}
+}
+
+// GetConversationReports lists the reports filed on conversations for the
+// admins.
+func (s *ConversationService) GetConversationReports(ctx context.Context, request dto.GetConversationReportsRequest) ([]dto.ConversationReportResponse, model.Metadata, error) {
+	reports, metadata, err := s.conversation.GetReports(ctx, model.ConversationReportFilter{
+		Status:     model.ConversationReportStatus(request.Status),
+		Pagination: request.Pagination,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetConversationReports] Error getting reports")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewConversationReportResponses(reports), metadata, nil
+}
+
+// GetReportedMessages returns the messages of a reported conversation, the
+// only way admins can read a thread.
+func (s *ConversationService) GetReportedMessages(ctx context.Context, reportID uuid.UUID, request dto.GetConversationMessagesRequest) ([]dto.ConversationMessageResponse, model.Metadata, error) {
+	report, err := s.conversation.GetReportByID(ctx, reportID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetReportedMessages] Error getting report")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if report == nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrEntityNotFound, "report")
+	}
+
+	messages, metadata, err := s.conversation.GetMessages(ctx, report.ConversationID, request.Pagination)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetReportedMessages] Error getting messages")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewConversationMessageResponses(messages), metadata, nil
+}
+
+func (s *ConversationService) ResolveConversationReport(ctx context.Context, reportID uuid.UUID, request dto.ResolveConversationReportRequest) (*dto.ConversationReportResponse, error) {
+	report, err := s.conversation.GetReportByID(ctx, reportID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ResolveConversationReport] Error getting report")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if report == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "report")
+	}
+
+	if report.Status != model.ConversationReportStatusPending {
+		return nil, shared.MakeError(ErrBadRequest, "report is already closed")
+	}
+
+	now := time.Now()
+	report.Status = model.ConversationReportStatus(request.Status)
+	report.ResolutionNote = request.Note
+	report.ResolvedAt = null.TimeFrom(now)
+	report.ResolvedBy = uuid.NullUUID{UUID: middleware.GetUserID(ctx), Valid: true}
+	report.UpdatedAt = now
+
+	err = s.conversation.UpdateReport(ctx, report)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ResolveConversationReport] Error updating report")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewConversationReportResponse(*report)
+	return &res, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,8 +31,10 @@ type CourseService struct {
 	tutor             *repositories.TutorRepository
 	location          *repositories.LocationRepository
 	booking           *repositories.BookingRepository
+	groupSession      *repositories.GroupSessionRepository
 	student           *repositories.StudentRepository
 	courseDraft       *CourseDraftService
+	rejectionReason   *repositories.CourseRejectionReasonRepository
 	maps              *maps.Client
 	redis             *infras.Redis
 }
@@ -45,8 +48,10 @@ func NewCourseService(
 	location *repositories.LocationRepository,
 	user *repositories.UserRepository,
 	booking *repositories.BookingRepository,
+	groupSession *repositories.GroupSessionRepository,
 	student *repositories.StudentRepository,
 	courseDraft *CourseDraftService,
+	rejectionReason *repositories.CourseRejectionReasonRepository,
 	maps *maps.Client,
 	redis *infras.Redis,
 ) *CourseService {
@@ -59,8 +64,10 @@ func NewCourseService(
 		location:          location,
 		user:              user,
 		booking:           booking,
+		groupSession:      groupSession,
 		student:           student,
 		courseDraft:       courseDraft,
+		rejectionReason:   rejectionReason,
 		maps:              maps,
 		redis:             redis,
 	}
@@ -94,15 +101,6 @@ func (s *CourseService) GetCourses(ctx context.Context, request dto.GetCoursesRe
 	for i, course := range courses {
 		courseIDs = append(courseIDs, course.ID)
 
-		total := int64(0)
-		for _, review := range course.TutorReviews {
-			total += review.Rate.Int64
-		}
-
-		if total > 0 {
-			courses[i].Rating = decimal.NewFromFloat(float64(total) / float64(len(course.TutorReviews)))
-		}
-
 		if !course.Tutor.Latitude.Valid || !course.Tutor.Longitude.Valid {
 			continue
 		}
@@ -273,15 +271,6 @@ func (s *CourseService) GetCourse(ctx context.Context, id uuid.UUID) (model.Cour
 		return model.Course{}, shared.MakeError(ErrEntityNotFound, "course")
 	}
 
-	total := int64(0)
-	for _, review := range courses[0].TutorReviews {
-		total += review.Rate.Int64
-	}
-
-	if total > 0 {
-		courses[0].Rating = decimal.NewFromFloat(float64(total) / float64(len(courses[0].TutorReviews)))
-	}
-
 	relatedCourse, _, err := s.course.Get(ctx, model.CourseFilter{
 		TutorID:     courses[0].TutorID,
 		IsPublished: null.BoolFrom(true),
@@ -339,21 +328,21 @@ ReturnResponse:
 	return courses[0], nil
 }
 
-func (s *CourseService) GetBookingCourse(ctx context.Context, request dto.GetBookingCourseRequest) ([]model.CourseSchedule, []model.Booking, error) {
+func (s *CourseService) GetBookingCourse(ctx context.Context, request dto.GetBookingCourseRequest) ([]model.CourseSchedule, []model.Booking, []model.GroupSession, error) {
 	course, err := s.course.GetByID(ctx, request.ID)
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[GetBookingCourse] Error getting course")
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if course == nil {
 		logger.WarnCtx(ctx).Str("course_id", request.ID.String()).Msg("[GetBookingCourse] Course not found")
-		return nil, nil, shared.MakeError(ErrEntityNotFound, "course")
+		return nil, nil, nil, shared.MakeError(ErrEntityNotFound, "course")
 	}
 
 	if !course.IsPublished.Bool {
 		logger.WarnCtx(ctx).Str("course_id", request.ID.String()).Msg("[GetBookingCourse] Course is not published")
-		return nil, nil, shared.MakeError(ErrEntityNotFound, "course")
+		return nil, nil, nil, shared.MakeError(ErrEntityNotFound, "course")
 	}
 
 	bookings, _, err := s.booking.Get(ctx, model.BookingFilter{
@@ -366,10 +355,16 @@ func (s *CourseService) GetBookingCourse(ctx context.Context, request dto.GetBoo
 	})
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[GetBookingCourse] Error getting bookings")
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	return course.CourseSchedules, bookings, nil
+	sessions, err := s.groupSession.GetSlots(ctx, course.ID, request.StartDate, request.EndDate)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetBookingCourse] Error getting group sessions")
+		return nil, nil, nil, err
+	}
+
+	return course.CourseSchedules, bookings, sessions, nil
 }
 
 func (s *CourseService) GetRelatedCourse(ctx context.Context, request dto.GetCoursesRequest) ([]model.Course, model.Metadata, error) {
@@ -742,6 +737,19 @@ func (s *CourseService) SubmitCourseForReview(ctx context.Context, courseID uuid
 		return shared.MakeError(ErrInternalServer)
 	}
 
+	checks, err := s.runPreChecks(ctx, course)
+	if err != nil {
+		return err
+	}
+
+	if failed := model.FailedCoursePreChecks(checks); len(failed) > 0 {
+		logger.InfoCtx(ctx).
+			Str("course_id", courseID.String()).
+			Strs("failed", failed).
+			Msg("[SubmitCourseForReview] Course did not pass the pre-checks")
+		return shared.MakeError(ErrCoursePreCheckFailed, strings.Join(failed, " "))
+	}
+
 	course.Status = model.CourseStatusWaitingForApproval
 	course.UpdatedAt = time.Now()
 	course.UpdatedBy = uuid.NullUUID{
@@ -801,9 +809,17 @@ func (s *CourseService) ApproveCourse(ctx context.Context, req dto.ApproveCourse
 		return shared.MakeError(ErrBadRequest, "course has no draft")
 	}
 
+	if course.Draft.AssignedTo.Valid && course.Draft.AssignedTo.UUID != userId {
+		logger.WarnCtx(ctx).Str("course_id", req.ID.String()).Msg("[ApproveCourse] Course is claimed by another reviewer")
+		return shared.MakeError(ErrBadRequest, "course is claimed by another reviewer")
+	}
+
 	var draft *model.CourseDraft
 	draft = course.Draft
 	draft.Status = model.DraftStatusApproved
+	draft.ReviewedAt = null.TimeFrom(time.Now())
+	draft.ReviewedBy = uuid.NullUUID{UUID: userId, Valid: true}
+	draft.ReviewNotes = req.ReviewNotes
 	draft.UpdatedAt = time.Now()
 	draft.UpdatedBy = uuid.NullUUID{
 		UUID:  userId,
@@ -817,6 +833,7 @@ func (s *CourseService) ApproveCourse(ctx context.Context, req dto.ApproveCourse
 		return shared.MakeError(ErrInternalServer)
 	}
 
+	previous := model.NewCourseSnapshot(*course)
 	course.FillFromDraft(newCourse)
 	course.Status = model.CourseStatusAccepted
 	course.StatusNotes = req.ReviewNotes
@@ -826,7 +843,14 @@ func (s *CourseService) ApproveCourse(ctx context.Context, req dto.ApproveCourse
 		Valid: true,
 	}
 
-	err = s.course.ApproveCourse(ctx, course, draft)
+	change, err := model.NewCourseVersionChange(previous, *course, model.CourseVersionSourceApproval, userId)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.ID.String()).Msg("[ApproveCourse] Error creating course version")
+		return shared.MakeError(ErrInternalServer)
+	}
+	change.Version.DraftID = uuid.NullUUID{UUID: draft.ID, Valid: true}
+
+	err = s.course.ApproveCourse(ctx, course, draft, change)
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).
 			Str("course_id", req.ID.String()).
@@ -863,6 +887,36 @@ func (s *CourseService) RejectCourse(ctx context.Context, req dto.RejectCourseRe
 		return shared.MakeError(ErrBadRequest, "can reject only 'waiting for approval' course")
 	}
 
+	if course.Draft != nil && course.Draft.AssignedTo.Valid && course.Draft.AssignedTo.UUID != userId {
+		logger.WarnCtx(ctx).Str("course_id", req.ID.String()).Msg("[RejectCourse] Course is claimed by another reviewer")
+		return shared.MakeError(ErrBadRequest, "course is claimed by another reviewer")
+	}
+
+	reasons, err := s.rejectionReason.GetByCodes(ctx, req.ReasonCodes)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if len(reasons) != len(req.ReasonCodes) {
+		logger.WarnCtx(ctx).Strs("reason_codes", req.ReasonCodes).Msg("[RejectCourse] Unknown rejection reason")
+		return shared.MakeError(ErrEntityNotFound, "rejection reason")
+	}
+
+	checklist := make([]model.CourseRejectionItem, 0, len(reasons))
+	for _, reason := range reasons {
+		checklist = append(checklist, model.CourseRejectionItem{
+			Code:        reason.Code,
+			Title:       reason.Title,
+			Description: reason.Description,
+		})
+	}
+
+	checklistData, err := json.Marshal(checklist)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.ID.String()).Msg("[RejectCourse] Error marshalling rejection checklist")
+		return shared.MakeError(ErrInternalServer)
+	}
+
 	course.Status = model.CourseStatusRejected
 	course.StatusNotes = null.StringFrom(req.ReviewNotes)
 	course.UpdatedAt = time.Now()
@@ -873,6 +927,10 @@ func (s *CourseService) RejectCourse(ctx context.Context, req dto.RejectCourseRe
 
 	if course.Draft != nil {
 		course.Draft.Status = model.DraftStatusRejected
+		course.Draft.ReviewedAt = null.TimeFrom(time.Now())
+		course.Draft.ReviewedBy = uuid.NullUUID{UUID: userId, Valid: true}
+		course.Draft.ReviewNotes = null.NewString(req.ReviewNotes, req.ReviewNotes != "")
+		course.Draft.RejectionChecklist = checklistData
 		course.Draft.UpdatedAt = time.Now()
 		course.Draft.UpdatedBy = uuid.NullUUID{
 			UUID:  userId,
@@ -880,7 +938,7 @@ func (s *CourseService) RejectCourse(ctx context.Context, req dto.RejectCourseRe
 		}
 	}
 
-	err = s.course.UpdateStatus(ctx, course)
+	err = s.course.RejectCourse(ctx, course, course.Draft)
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).
 			Str("course_id", req.ID.String()).
@@ -1052,15 +1110,6 @@ func (s *CourseService) GetCourseDetailForAdmin(ctx context.Context, id uuid.UUI
 		return model.Course{}, shared.MakeError(ErrEntityNotFound, "course")
 	}
 
-	total := int64(0)
-	for _, review := range courses[0].TutorReviews {
-		total += review.Rate.Int64
-	}
-
-	if total > 0 {
-		courses[0].Rating = decimal.NewFromFloat(float64(total) / float64(len(courses[0].TutorReviews)))
-	}
-
 	relatedCourse, _, err := s.course.Get(ctx, model.CourseFilter{
 		TutorID:     courses[0].TutorID,
 		IsPublished: null.BoolFrom(true),
@@ -1241,10 +1290,17 @@ func (s *CourseService) UpdateCourseForAdmin(ctx context.Context, req dto.AdminU
 	}
 
 	// Convert to TutorCourseRequest and update the course
+	previous := model.NewCourseSnapshot(*course)
 	tutorReq := req.ToTutorCourseRequest()
 	tutorReq.UpdateCourse(course, subCategories)
 
-	err = s.course.UpdateAll(ctx, course)
+	change, err := model.NewCourseVersionChange(previous, *course, model.CourseVersionSourceAdminUpdate, req.AdminID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", courseID.String()).Msg("[UpdateCourseForAdmin] Error creating course version")
+		return model.Course{}, shared.MakeError(ErrInternalServer)
+	}
+
+	err = s.course.UpdateAll(ctx, course, change)
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Str("course_id", courseID.String()).Msg("[UpdateCourseForAdmin] Error updating course")
 		return model.Course{}, err
@@ -1289,3 +1345,157 @@ func (s *CourseService) DeleteCourseForAdmin(ctx context.Context, courseID uuid.
 
 	return nil
 }
+
+// GetTutorCourseDraft returns the active draft of a tutor's course with the
+// changes it makes to the live course.
+func (s *CourseService) GetTutorCourseDraft(ctx context.Context, req dto.GetTutorCourseRequest) (dto.CourseDraftResponse, error) {
+	course, err := s.GetCoursesForTutor(ctx, req)
+	if err != nil {
+		return dto.CourseDraftResponse{}, err
+	}
+
+	if course.Draft == nil {
+		return dto.CourseDraftResponse{}, shared.MakeError(ErrEntityNotFound, "course draft")
+	}
+
+	changes, err := courseDraftChanges(course, *course.Draft)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.ID.String()).Msg("[GetTutorCourseDraft] Error unmarshalling draft data")
+		return dto.CourseDraftResponse{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewCourseDraftResponse(*course.Draft, changes), nil
+}
+
+// DiscardTutorCourseDraft deletes the pending edits of an approved course and
+// puts the course back to its live state.
+func (s *CourseService) DiscardTutorCourseDraft(ctx context.Context, req dto.GetTutorCourseRequest) error {
+	course, err := s.GetCoursesForTutor(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if course.Draft == nil {
+		return shared.MakeError(ErrEntityNotFound, "course draft")
+	}
+
+	if course.Draft.DraftType == model.DraftTypeCreate {
+		return shared.MakeError(ErrBadRequest, "draft of a new course cannot be discarded")
+	}
+
+	if course.Draft.Status == model.DraftStatusPendingApproval {
+		return shared.MakeError(ErrBadRequest, "draft is waiting for approval")
+	}
+
+	err = s.courseDraft.DeleteDraft(ctx, course.ID, req.UserID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.ID.String()).Msg("[DiscardTutorCourseDraft] Error deleting draft")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	course.Status = model.CourseStatusAccepted
+	course.UpdatedAt = time.Now()
+	course.UpdatedBy = uuid.NullUUID{UUID: req.UserID, Valid: true}
+	err = s.course.UpdateStatus(ctx, &course)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.ID.String()).Msg("[DiscardTutorCourseDraft] Error updating course status")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+// PreviewTutorCourse returns the course as it will look once the active draft
+// is approved.
+func (s *CourseService) PreviewTutorCourse(ctx context.Context, req dto.GetTutorCourseRequest) (model.Course, error) {
+	if _, err := s.GetCoursesForTutor(ctx, req); err != nil {
+		return model.Course{}, err
+	}
+
+	course, err := s.courseDraft.PreviewCourse(ctx, req.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.ID.String()).Msg("[PreviewTutorCourse] Error previewing course")
+		return model.Course{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return *course, nil
+}
+
+// CheckTutorCourseDraftConflicts reports whether the live course changed
+// after the tutor started the active draft.
+func (s *CourseService) CheckTutorCourseDraftConflicts(ctx context.Context, req dto.GetTutorCourseRequest) (model.ConflictInfo, error) {
+	course, err := s.GetCoursesForTutor(ctx, req)
+	if err != nil {
+		return model.ConflictInfo{}, err
+	}
+
+	if course.Draft == nil {
+		return model.ConflictInfo{}, shared.MakeError(ErrEntityNotFound, "course draft")
+	}
+
+	conflict, err := s.courseDraft.CheckForConflicts(ctx, course.Draft.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.ID.String()).Msg("[CheckTutorCourseDraftConflicts] Error checking draft conflicts")
+		return model.ConflictInfo{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return *conflict, nil
+}
+
+func (s *CourseService) ResolveTutorCourseDraftConflict(ctx context.Context, req dto.ResolveCourseDraftConflictRequest) error {
+	course, err := s.GetCoursesForTutor(ctx, dto.GetTutorCourseRequest{ID: req.ID, UserID: req.UserID})
+	if err != nil {
+		return err
+	}
+
+	if course.Draft == nil {
+		return shared.MakeError(ErrEntityNotFound, "course draft")
+	}
+
+	// Submitting also has to move the course into the approval queue.
+	if req.Resolution == model.ConflictResolutionForceSubmit {
+		return s.SubmitCourseForReview(ctx, course.ID, req.UserID)
+	}
+
+	if !course.Draft.CanBeSubmitted() {
+		return shared.MakeError(ErrBadRequest, "draft can no longer be changed")
+	}
+
+	err = s.courseDraft.ResolveConflict(ctx, course.Draft.ID, req.Resolution, req.UserID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.ID.String()).Msg("[ResolveTutorCourseDraftConflict] Error resolving draft conflict")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+// runPreChecks checks the content the tutor is about to submit, the active
+// draft when there is one and the live course otherwise.
+func (s *CourseService) runPreChecks(ctx context.Context, course *model.Course) ([]model.CoursePreCheck, error) {
+	content := *course
+	if course.Draft != nil {
+		if err := json.Unmarshal(course.Draft.DraftData, &content); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("course_id", course.ID.String()).Msg("[runPreChecks] Error unmarshalling draft data")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+	}
+
+	duplicate, err := s.course.ExistsByTutorTitle(ctx, course.TutorID, course.ID, content.Title)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return model.RunCoursePreChecks(content, duplicate), nil
+}
+
+// GetTutorCoursePreChecks lets the tutor see which pre-checks the course
+// passes before submitting it for review.
+func (s *CourseService) GetTutorCoursePreChecks(ctx context.Context, req dto.GetTutorCourseRequest) ([]model.CoursePreCheck, error) {
+	course, err := s.GetCoursesForTutor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.runPreChecks(ctx, &course)
+}
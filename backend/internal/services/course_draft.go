@@ -19,18 +19,21 @@ import (
 
 // CourseDraftService handles business logic for course draft operations
 type CourseDraftService struct {
-	courseDraftRepo *repositories.CourseDraftRepository
-	courseRepo      *repositories.CourseRepository
+	courseDraftRepo   *repositories.CourseDraftRepository
+	courseRepo        *repositories.CourseRepository
+	courseVersionRepo *repositories.CourseVersionRepository
 }
 
 // NewCourseDraftService creates a new CourseDraftService
 func NewCourseDraftService(
 	courseDraftRepo *repositories.CourseDraftRepository,
 	courseRepo *repositories.CourseRepository,
+	courseVersionRepo *repositories.CourseVersionRepository,
 ) *CourseDraftService {
 	return &CourseDraftService{
-		courseDraftRepo: courseDraftRepo,
-		courseRepo:      courseRepo,
+		courseDraftRepo:   courseDraftRepo,
+		courseRepo:        courseRepo,
+		courseVersionRepo: courseVersionRepo,
 	}
 }
 
@@ -154,6 +157,11 @@ func (s *CourseDraftService) UpdateDraft(ctx context.Context, courseID uuid.UUID
 		return nil, fmt.Errorf("invalid course data format: %w", err)
 	}
 
+	// Editing a rejected draft reopens it so it can be submitted again
+	if draft.Status == model.DraftStatusRejected {
+		draft.Status = model.DraftStatusDraft
+	}
+
 	// Update draft data
 	draft.DraftData = datatypes.JSON(draftData)
 	draft.UpdatedBy = uuid.NullUUID{UUID: updatedBy, Valid: true}
@@ -604,8 +612,33 @@ func (s *CourseDraftService) CheckForConflicts(ctx context.Context, draftID uuid
 		return nil, fmt.Errorf("failed to get live course: %w", err)
 	}
 
-	// Check if live course was modified after draft creation
-	hasConflict := liveCourse.UpdatedAt.After(draft.CreatedAt)
+	if liveCourse == nil {
+		return nil, fmt.Errorf("course not found")
+	}
+
+	versions, err := s.courseVersionRepo.GetByCourseID(ctx, draft.CourseID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("course_id", draft.CourseID.String()).
+			Msg("[CheckForConflicts] Error getting course versions")
+		return nil, fmt.Errorf("failed to get course versions: %w", err)
+	}
+
+	// The live course conflicts with the draft when a version went live after
+	// the draft was created. The newest older version is what the draft was
+	// based on.
+	var (
+		hasConflict bool
+		base        *model.CourseVersion
+	)
+	for i := range versions {
+		if versions[i].CreatedAt.After(draft.CreatedAt) {
+			hasConflict = true
+			continue
+		}
+		base = &versions[i]
+		break
+	}
 
 	conflictInfo := &model.ConflictInfo{
 		HasConflict:         hasConflict,
@@ -616,7 +649,7 @@ func (s *CourseDraftService) CheckForConflicts(ctx context.Context, draftID uuid
 
 	if hasConflict {
 		// Identify specific fields that have conflicts
-		conflictFields := s.identifyConflictFields(ctx, draft, liveCourse)
+		conflictFields := s.identifyConflictFields(ctx, draft, base, liveCourse)
 		conflictInfo.ConflictFields = conflictFields
 
 		logger.WarnCtx(ctx).
@@ -632,9 +665,21 @@ func (s *CourseDraftService) CheckForConflicts(ctx context.Context, draftID uuid
 }
 
 // identifyConflictFields compares draft data with live course to identify specific conflicts
-func (s *CourseDraftService) identifyConflictFields(ctx context.Context, draft *model.CourseDraft, liveCourse *model.Course) []string {
+func (s *CourseDraftService) identifyConflictFields(ctx context.Context, draft *model.CourseDraft, base *model.CourseVersion, liveCourse *model.Course) []string {
 	var conflictFields []string
 
+	// Fields changed on the live course since the version the draft was based on
+	if base != nil {
+		snapshot, err := base.GetSnapshot()
+		if err == nil {
+			for _, diff := range model.DiffCourseSnapshots(snapshot, model.NewCourseSnapshot(*liveCourse)) {
+				conflictFields = append(conflictFields, diff.Field)
+			}
+			return conflictFields
+		}
+		logger.ErrorCtx(ctx).Err(err).Msg("[identifyConflictFields] Error unmarshaling base version snapshot")
+	}
+
 	// Parse draft data
 	var draftCourse model.Course
 	if err := json.Unmarshal(draft.DraftData, &draftCourse); err != nil {
@@ -684,7 +729,7 @@ func (s *CourseDraftService) ResolveConflict(ctx context.Context, draftID uuid.U
 
 	case model.ConflictResolutionForceSubmit:
 		// Force submit draft despite conflicts
-		_, err := s.SubmitForApproval(ctx, draftID, userID)
+		_, err := s.SubmitForApproval(ctx, draft.CourseID, userID)
 		return err
 
 	default:
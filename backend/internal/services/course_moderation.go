@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+const (
+	defaultCourseModerationSLA        = 48 * time.Hour
+	defaultCourseModerationSLAWarning = 24 * time.Hour
+)
+
+// CourseModerationService runs the admin review queue of submitted courses:
+// who is reviewing what, how long submissions have waited and bulk decisions.
+type CourseModerationService struct {
+	config          *config.Config
+	courseDraft     *repositories.CourseDraftRepository
+	rejectionReason *repositories.CourseRejectionReasonRepository
+	user            *repositories.UserRepository
+	courseService   *CourseService
+}
+
+func NewCourseModerationService(
+	config *config.Config,
+	courseDraft *repositories.CourseDraftRepository,
+	rejectionReason *repositories.CourseRejectionReasonRepository,
+	user *repositories.UserRepository,
+	courseService *CourseService,
+) *CourseModerationService {
+	return &CourseModerationService{
+		config:          config,
+		courseDraft:     courseDraft,
+		rejectionReason: rejectionReason,
+		user:            user,
+		courseService:   courseService,
+	}
+}
+
+// slaDurations returns the warning threshold and the limit of the review SLA.
+func (s *CourseModerationService) slaDurations() (time.Duration, time.Duration) {
+	limit := s.config.CourseModeration.SLADuration
+	if limit <= 0 {
+		limit = defaultCourseModerationSLA
+	}
+
+	warning := s.config.CourseModeration.SLAWarningDuration
+	if warning <= 0 || warning > limit {
+		warning = min(defaultCourseModerationSLAWarning, limit)
+	}
+
+	return warning, limit
+}
+
+func (s *CourseModerationService) GetQueue(ctx context.Context, req dto.CourseModerationQueueRequest) ([]dto.CourseModerationQueueItem, model.Metadata, error) {
+	var (
+		now            = time.Now()
+		warning, limit = s.slaDurations()
+		filter         = model.CourseModerationFilter{Pagination: req.Pagination}
+	)
+
+	switch req.Assignee {
+	case dto.CourseModerationAssigneeMe:
+		filter.AssignedTo = req.AdminID
+	case dto.CourseModerationAssigneeUnassigned:
+		filter.Unassigned = true
+	}
+
+	switch req.SLA {
+	case model.CourseModerationSLAOnTrack:
+		filter.SubmittedAfter = null.TimeFrom(now.Add(-warning))
+	case model.CourseModerationSLAAtRisk:
+		filter.SubmittedAfter = null.TimeFrom(now.Add(-limit))
+		filter.SubmittedBefore = null.TimeFrom(now.Add(-warning))
+	case model.CourseModerationSLABreached:
+		filter.SubmittedBefore = null.TimeFrom(now.Add(-limit))
+	}
+
+	drafts, metadata, err := s.courseDraft.GetModerationQueue(ctx, filter)
+	if err != nil {
+		return nil, metadata, shared.MakeError(ErrInternalServer)
+	}
+
+	items := make([]dto.CourseModerationQueueItem, 0, len(drafts))
+	for _, draft := range drafts {
+		items = append(items, dto.NewCourseModerationQueueItem(draft, now, warning, limit))
+	}
+
+	return items, metadata, nil
+}
+
+// pendingDraft returns the draft of the course that is waiting for review.
+func (s *CourseModerationService) pendingDraft(ctx context.Context, courseID uuid.UUID) (*model.CourseDraft, error) {
+	draft, err := s.courseDraft.GetByCourseID(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.MakeError(ErrEntityNotFound, "course draft")
+		}
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if draft.Status != model.DraftStatusPendingApproval {
+		return nil, shared.MakeError(ErrBadRequest, "course is not waiting for review")
+	}
+
+	return draft, nil
+}
+
+// Claim assigns the course to the admin for review. A course claimed by
+// another admin has to be released or reassigned first.
+func (s *CourseModerationService) Claim(ctx context.Context, courseID, adminID uuid.UUID) error {
+	draft, err := s.pendingDraft(ctx, courseID)
+	if err != nil {
+		return err
+	}
+
+	claimed, err := s.courseDraft.Claim(ctx, draft.ID, adminID)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if !claimed {
+		return shared.MakeError(ErrBadRequest, "course is claimed by another reviewer")
+	}
+
+	logger.InfoCtx(ctx).
+		Str("course_id", courseID.String()).
+		Str("admin_id", adminID.String()).
+		Msg("[Claim] Course claimed for review")
+
+	return nil
+}
+
+// Release puts a course claimed by the admin back in the unassigned queue.
+func (s *CourseModerationService) Release(ctx context.Context, courseID, adminID uuid.UUID) error {
+	draft, err := s.pendingDraft(ctx, courseID)
+	if err != nil {
+		return err
+	}
+
+	if !draft.AssignedTo.Valid {
+		return nil
+	}
+
+	if draft.AssignedTo.UUID != adminID {
+		return shared.MakeError(ErrBadRequest, "course is claimed by another reviewer")
+	}
+
+	if err := s.courseDraft.Assign(ctx, draft.ID, uuid.NullUUID{}); err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+// Assign hands the course to another admin, or unassigns it when no admin is
+// given, regardless of who currently holds it.
+func (s *CourseModerationService) Assign(ctx context.Context, req dto.AssignCourseModerationRequest) error {
+	draft, err := s.pendingDraft(ctx, req.CourseID)
+	if err != nil {
+		return err
+	}
+
+	if req.AdminID.Valid {
+		user, err := s.user.GetByID(ctx, req.AdminID.UUID)
+		if err != nil {
+			return shared.MakeError(ErrInternalServer)
+		}
+
+		isAdmin := user != nil && slices.ContainsFunc(user.Roles, func(role model.Role) bool {
+			return role.Name == model.RoleNameAdmin
+		})
+		if !isAdmin {
+			return shared.MakeError(ErrEntityNotFound, "admin")
+		}
+	}
+
+	if err := s.courseDraft.Assign(ctx, draft.ID, req.AdminID); err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+// BulkApprove approves every course in the request. Each course is handled on
+// its own so one failure does not block the rest.
+func (s *CourseModerationService) BulkApprove(ctx context.Context, req dto.BulkApproveCoursesRequest) []dto.CourseModerationBulkResult {
+	results := make([]dto.CourseModerationBulkResult, 0, len(req.CourseIDs))
+	for _, courseID := range req.CourseIDs {
+		err := s.courseService.ApproveCourse(ctx, dto.ApproveCourseRequest{
+			ID:          courseID,
+			ReviewNotes: req.ReviewNotes,
+		})
+		results = append(results, newCourseModerationBulkResult(courseID, err))
+	}
+
+	return results
+}
+
+// BulkReject rejects every course in the request with the same reasons.
+func (s *CourseModerationService) BulkReject(ctx context.Context, req dto.BulkRejectCoursesRequest) []dto.CourseModerationBulkResult {
+	results := make([]dto.CourseModerationBulkResult, 0, len(req.CourseIDs))
+	for _, courseID := range req.CourseIDs {
+		err := s.courseService.RejectCourse(ctx, dto.RejectCourseRequest{
+			ID:          courseID,
+			ReviewNotes: req.ReviewNotes,
+			ReasonCodes: req.ReasonCodes,
+		})
+		results = append(results, newCourseModerationBulkResult(courseID, err))
+	}
+
+	return results
+}
+
+func newCourseModerationBulkResult(courseID uuid.UUID, err error) dto.CourseModerationBulkResult {
+	if err != nil {
+		return dto.CourseModerationBulkResult{CourseID: courseID, Error: Error(err).GetMessage()}
+	}
+
+	return dto.CourseModerationBulkResult{CourseID: courseID, Success: true}
+}
+
+func (s *CourseModerationService) ListRejectionReasons(ctx context.Context, activeOnly bool) ([]model.CourseRejectionReason, error) {
+	reasons, err := s.rejectionReason.Get(ctx, activeOnly)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return reasons, nil
+}
+
+func (s *CourseModerationService) CreateRejectionReason(ctx context.Context, req dto.CourseRejectionReasonRequest) (model.CourseRejectionReason, error) {
+	exists, err := s.rejectionReason.ExistsByCode(ctx, req.Code, uuid.Nil)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("code", req.Code).Msg("[CreateRejectionReason] Error checking rejection reason code")
+		return model.CourseRejectionReason{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if exists {
+		return model.CourseRejectionReason{}, shared.MakeError(ErrBadRequest, "rejection reason code already exists")
+	}
+
+	reason := model.CourseRejectionReason{
+		Code:        req.Code,
+		Title:       req.Title,
+		Description: req.Description,
+		Sequence:    req.Sequence,
+		IsActive:    req.IsActive.ValueOrZero() || !req.IsActive.Valid,
+		CreatedBy:   uuid.NullUUID{UUID: req.AdminID, Valid: true},
+		UpdatedBy:   uuid.NullUUID{UUID: req.AdminID, Valid: true},
+	}
+
+	if err := s.rejectionReason.Create(ctx, &reason); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("code", req.Code).Msg("[CreateRejectionReason] Error creating rejection reason")
+		return model.CourseRejectionReason{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return reason, nil
+}
+
+func (s *CourseModerationService) UpdateRejectionReason(ctx context.Context, req dto.CourseRejectionReasonRequest) (model.CourseRejectionReason, error) {
+	reason, err := s.rejectionReason.GetByID(ctx, req.ID)
+	if err != nil {
+		return model.CourseRejectionReason{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if reason == nil {
+		return model.CourseRejectionReason{}, shared.MakeError(ErrEntityNotFound, "rejection reason")
+	}
+
+	exists, err := s.rejectionReason.ExistsByCode(ctx, req.Code, req.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("code", req.Code).Msg("[UpdateRejectionReason] Error checking rejection reason code")
+		return model.CourseRejectionReason{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if exists {
+		return model.CourseRejectionReason{}, shared.MakeError(ErrBadRequest, "rejection reason code already exists")
+	}
+
+	reason.Code = req.Code
+	reason.Title = req.Title
+	reason.Description = req.Description
+	reason.Sequence = req.Sequence
+	if req.IsActive.Valid {
+		reason.IsActive = req.IsActive.Bool
+	}
+	reason.UpdatedAt = time.Now()
+	reason.UpdatedBy = uuid.NullUUID{UUID: req.AdminID, Valid: true}
+
+	if err := s.rejectionReason.Update(ctx, reason); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", req.ID.String()).Msg("[UpdateRejectionReason] Error updating rejection reason")
+		return model.CourseRejectionReason{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return *reason, nil
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lesprivate/backend/config"
+)
+
+func TestCourseModerationServiceSLADurations(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       time.Duration
+		warning     time.Duration
+		wantWarning time.Duration
+		wantLimit   time.Duration
+	}{
+		{name: "defaults", wantWarning: defaultCourseModerationSLAWarning, wantLimit: defaultCourseModerationSLA},
+		{name: "configured", limit: 72 * time.Hour, warning: 60 * time.Hour, wantWarning: 60 * time.Hour, wantLimit: 72 * time.Hour},
+		{name: "warning after the limit", limit: 72 * time.Hour, warning: 96 * time.Hour, wantWarning: defaultCourseModerationSLAWarning, wantLimit: 72 * time.Hour},
+		{name: "limit shorter than the default warning", limit: 12 * time.Hour, wantWarning: 12 * time.Hour, wantLimit: 12 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.CourseModeration.SLADuration = tt.limit
+			cfg.CourseModeration.SLAWarningDuration = tt.warning
+
+			s := &CourseModerationService{config: cfg}
+			warning, limit := s.slaDurations()
+			if warning != tt.wantWarning || limit != tt.wantLimit {
+				t.Errorf("slaDurations() = (%s, %s), want (%s, %s)", warning, limit, tt.wantWarning, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// CourseVersionService exposes the history of approved course changes and
+// lets admins compare and restore them.
+type CourseVersionService struct {
+	course        *repositories.CourseRepository
+	courseVersion *repositories.CourseVersionRepository
+	courseService *CourseService
+	courseDraft   *CourseDraftService
+}
+
+func NewCourseVersionService(
+	course *repositories.CourseRepository,
+	courseVersion *repositories.CourseVersionRepository,
+	courseService *CourseService,
+	courseDraft *CourseDraftService,
+) *CourseVersionService {
+	return &CourseVersionService{
+		course:        course,
+		courseVersion: courseVersion,
+		courseService: courseService,
+		courseDraft:   courseDraft,
+	}
+}
+
+// courseDraftChanges lists the fields the draft changes on the live course.
+func courseDraftChanges(course model.Course, draft model.CourseDraft) ([]model.CourseFieldDiff, error) {
+	var draftCourse model.Course
+	if err := json.Unmarshal(draft.DraftData, &draftCourse); err != nil {
+		return nil, err
+	}
+
+	return model.DiffCourseSnapshots(model.NewCourseSnapshot(course), model.NewCourseSnapshot(draftCourse)), nil
+}
+
+func (s *CourseVersionService) getCourse(ctx context.Context, courseID uuid.UUID) (*model.Course, error) {
+	course, err := s.course.GetByID(ctx, courseID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", courseID.String()).Msg("[getCourse] Error getting course")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if course == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "course")
+	}
+
+	return course, nil
+}
+
+func (s *CourseVersionService) getSnapshot(ctx context.Context, courseID uuid.UUID, version int) (*model.CourseVersion, model.CourseSnapshot, error) {
+	courseVersion, err := s.courseVersion.GetByVersion(ctx, courseID, version)
+	if err != nil {
+		return nil, model.CourseSnapshot{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if courseVersion == nil {
+		return nil, model.CourseSnapshot{}, shared.MakeError(ErrEntityNotFound, "course version")
+	}
+
+	snapshot, err := courseVersion.GetSnapshot()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("course_id", courseID.String()).
+			Int("version", version).
+			Msg("[getSnapshot] Error unmarshalling course version snapshot")
+		return nil, model.CourseSnapshot{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return courseVersion, snapshot, nil
+}
+
+func (s *CourseVersionService) ListVersions(ctx context.Context, courseID uuid.UUID) ([]dto.CourseVersionResponse, error) {
+	if _, err := s.getCourse(ctx, courseID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.courseVersion.GetByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewCourseVersionResponses(versions), nil
+}
+
+// ListVersionsForTutor lists the versions of a course owned by the tutor.
+func (s *CourseVersionService) ListVersionsForTutor(ctx context.Context, req dto.GetTutorCourseRequest) ([]dto.CourseVersionResponse, error) {
+	course, err := s.courseService.GetCoursesForTutor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.courseVersion.GetByCourseID(ctx, course.ID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewCourseVersionResponses(versions), nil
+}
+
+func (s *CourseVersionService) GetVersion(ctx context.Context, courseID uuid.UUID, version int) (dto.CourseVersionResponse, error) {
+	courseVersion, snapshot, err := s.getSnapshot(ctx, courseID, version)
+	if err != nil {
+		return dto.CourseVersionResponse{}, err
+	}
+
+	resp := dto.NewCourseVersionResponse(*courseVersion)
+	resp.Snapshot = &snapshot
+	return resp, nil
+}
+
+// DiffVersions lists the fields that changed going from one version to another.
+func (s *CourseVersionService) DiffVersions(ctx context.Context, req dto.CourseVersionDiffRequest) (dto.CourseVersionDiffResponse, error) {
+	_, from, err := s.getSnapshot(ctx, req.CourseID, req.From)
+	if err != nil {
+		return dto.CourseVersionDiffResponse{}, err
+	}
+
+	_, to, err := s.getSnapshot(ctx, req.CourseID, req.To)
+	if err != nil {
+		return dto.CourseVersionDiffResponse{}, err
+	}
+
+	return dto.CourseVersionDiffResponse{
+		From:    req.From,
+		To:      req.To,
+		Changes: model.DiffCourseSnapshots(from, to),
+	}, nil
+}
+
+// GetDraftDiff compares the active draft of a course against the live course
+// for the approval screen.
+func (s *CourseVersionService) GetDraftDiff(ctx context.Context, courseID uuid.UUID) (dto.CourseDraftResponse, error) {
+	course, err := s.getCourse(ctx, courseID)
+	if err != nil {
+		return dto.CourseDraftResponse{}, err
+	}
+
+	if course.Draft == nil {
+		return dto.CourseDraftResponse{}, shared.MakeError(ErrEntityNotFound, "course draft")
+	}
+
+	changes, err := courseDraftChanges(*course, *course.Draft)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", courseID.String()).Msg("[GetDraftDiff] Error unmarshalling draft data")
+		return dto.CourseDraftResponse{}, shared.MakeError(ErrInternalServer)
+	}
+
+	conflict, err := s.courseDraft.CheckForConflicts(ctx, course.Draft.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", courseID.String()).Msg("[GetDraftDiff] Error checking draft conflicts")
+		return dto.CourseDraftResponse{}, shared.MakeError(ErrInternalServer)
+	}
+
+	resp := dto.NewCourseDraftResponse(*course.Draft, changes)
+	resp.Conflict = conflict
+	return resp, nil
+}
+
+// Rollback restores the course to the content of a previous version. The
+// restore is recorded as a new version so the history stays append-only.
+func (s *CourseVersionService) Rollback(ctx context.Context, req dto.RollbackCourseVersionRequest) (model.Course, error) {
+	course, err := s.getCourse(ctx, req.CourseID)
+	if err != nil {
+		return model.Course{}, err
+	}
+
+	_, snapshot, err := s.getSnapshot(ctx, req.CourseID, req.Version)
+	if err != nil {
+		return model.Course{}, err
+	}
+
+	previous := model.NewCourseSnapshot(*course)
+	snapshot.Apply(course, req.AdminID)
+
+	change, err := model.NewCourseVersionChange(previous, *course, model.CourseVersionSourceRollback, req.AdminID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("course_id", req.CourseID.String()).Msg("[Rollback] Error creating course version")
+		return model.Course{}, shared.MakeError(ErrInternalServer)
+	}
+	change.Version.RestoredFrom = null.IntFrom(int64(req.Version))
+
+	err = s.course.UpdateAll(ctx, course, change)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("course_id", req.CourseID.String()).
+			Int("version", req.Version).
+			Msg("[Rollback] Error restoring course version")
+		return model.Course{}, shared.MakeError(ErrInternalServer)
+	}
+
+	logger.InfoCtx(ctx).
+		Str("course_id", req.CourseID.String()).
+		Int("version", req.Version).
+		Int("new_version", change.Version.Version).
+		Str("admin_id", req.AdminID.String()).
+		Msg("[Rollback] Course rolled back successfully")
+
+	updated, err := s.getCourse(ctx, req.CourseID)
+	if err != nil {
+		return model.Course{}, err
+	}
+
+	return *updated, nil
+}
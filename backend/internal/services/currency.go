@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/external/exchangerate"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// CurrencyService keeps the daily exchange rate snapshots and converts
+// amounts between the supported currencies.
+type CurrencyService struct {
+	exchangeRate *repositories.ExchangeRateRepository
+	user         *repositories.UserRepository
+	provider     exchangerate.Provider
+}
+
+func NewCurrencyService(
+	exchangeRate *repositories.ExchangeRateRepository,
+	user *repositories.UserRepository,
+	provider exchangerate.Provider,
+) *CurrencyService {
+	return &CurrencyService{
+		exchangeRate: exchangeRate,
+		user:         user,
+		provider:     provider,
+	}
+}
+
+func (s *CurrencyService) GetCurrencies() []model.Currency {
+	return model.Currencies
+}
+
+func (s *CurrencyService) GetRates(ctx context.Context, req dto.GetExchangeRatesRequest) ([]model.ExchangeRate, model.Metadata, error) {
+	filter, err := req.Filter()
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrBadRequest, err.Error())
+	}
+
+	filter.Pagination.SetDefault()
+
+	return s.exchangeRate.Get(ctx, filter)
+}
+
+// UploadRates records rates entered by an admin.
+func (s *CurrencyService) UploadRates(ctx context.Context, req dto.UploadExchangeRatesRequest) ([]model.ExchangeRate, error) {
+	rates := req.ExchangeRates()
+	err := s.exchangeRate.Upsert(ctx, rates)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UploadRates] Error saving exchange rates")
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// SyncRates records today's rates of every supported currency from the
+// provider.
+func (s *CurrencyService) SyncRates(ctx context.Context) ([]model.ExchangeRate, error) {
+	var currencies []string
+	for _, currency := range model.Currencies {
+		if currency.Code != model.CurrencyIDR {
+			currencies = append(currencies, currency.Code)
+		}
+	}
+
+	fetched, err := s.provider.Rates(ctx, currencies)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SyncRates] Error fetching exchange rates")
+		return nil, err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	rates := make([]model.ExchangeRate, 0, len(currencies))
+	for _, currency := range currencies {
+		rate, ok := fetched[currency]
+		if !ok {
+			logger.WarnCtx(ctx).Msgf("[SyncRates] Provider has no rate for %s", currency)
+			continue
+		}
+
+		rates = append(rates, model.ExchangeRate{
+			Currency: currency,
+			Rate:     rate,
+			RateDate: today,
+			Source:   model.ExchangeRateSourceProvider,
+		})
+	}
+
+	if len(rates) == 0 {
+		return rates, nil
+	}
+
+	err = s.exchangeRate.Upsert(ctx, rates)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SyncRates] Error saving exchange rates")
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// Convert converts an amount with the rates in effect on the given day. It
+// returns the rate used.
+func (s *CurrencyService) Convert(ctx context.Context, amount decimal.Decimal, from, to string, on time.Time) (decimal.Decimal, decimal.Decimal, error) {
+	if from == to {
+		return amount, decimal.NewFromInt(1), nil
+	}
+
+	rates, err := s.exchangeRate.Latest(ctx, on)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Convert] Error getting exchange rates")
+		return decimal.Zero, decimal.Zero, err
+	}
+
+	converted, rate, ok := rates.Convert(amount, from, to)
+	if !ok {
+		return decimal.Zero, decimal.Zero, shared.MakeError(ErrExchangeRateNotFound, from, to)
+	}
+
+	return converted, rate, nil
+}
+
+// ViewerRates returns the currency prices should be shown in and today's
+// rates: the requested currency when supported, otherwise the one the user
+// chose in their profile. The currency is empty when prices are shown as
+// set.
+func (s *CurrencyService) ViewerRates(ctx context.Context, userID uuid.UUID, requested string) (string, model.ExchangeRates) {
+	currency := strings.ToUpper(requested)
+	if _, ok := model.CurrencyByCode(currency); !ok {
+		currency = ""
+	}
+
+	if currency == "" && userID != uuid.Nil {
+		user, err := s.user.GetByID(ctx, userID)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ViewerRates] Error getting user")
+		}
+		if user != nil {
+			currency = user.Currency.String
+		}
+	}
+
+	if currency == "" {
+		return "", nil
+	}
+
+	rates, err := s.exchangeRate.Latest(ctx, time.Now())
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ViewerRates] Error getting exchange rates")
+		return "", nil
+	}
+
+	return currency, rates
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// EntitlementService decides what a student may do on their current plan and
+// how much of each quota is left.
+type EntitlementService struct {
+	entitlement  *repositories.PlanEntitlementRepository
+	student      *repositories.StudentRepository
+	subscription *repositories.SubscriptionRepository
+	payment      *repositories.PaymentRepository
+	booking      *repositories.BookingRepository
+	config       *config.Config
+}
+
+func NewEntitlementService(
+	entitlement *repositories.PlanEntitlementRepository,
+	student *repositories.StudentRepository,
+	subscription *repositories.SubscriptionRepository,
+	payment *repositories.PaymentRepository,
+	booking *repositories.BookingRepository,
+	config *config.Config,
+) *EntitlementService {
+	return &EntitlementService{
+		entitlement:  entitlement,
+		student:      student,
+		subscription: subscription,
+		payment:      payment,
+		booking:      booking,
+		config:       config,
+	}
+}
+
+// quotaResetAt is when the daily quotas start over.
+func quotaResetAt(now time.Time) time.Time {
+	year, month, day := now.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+}
+
+// Resolve returns the entitlements of the plan the student is on. Premium
+// students are matched to a plan through the interval of their latest active
+// subscription or payment.
+func (s *EntitlementService) Resolve(ctx context.Context, student model.Student) (model.PlanEntitlement, error) {
+	if student.IsPremium() {
+		interval, err := s.premiumInterval(ctx, student.ID)
+		if err != nil {
+			return model.PlanEntitlement{}, shared.MakeError(ErrInternalServer)
+		}
+
+		entitlement, err := s.entitlement.GetByInterval(ctx, interval)
+		if err != nil {
+			return model.PlanEntitlement{}, shared.MakeError(ErrInternalServer)
+		}
+
+		if entitlement != nil {
+			return *entitlement, nil
+		}
+
+		logger.WarnCtx(ctx).
+			Str("student_id", student.ID.String()).
+			Str("interval", string(interval)).
+			Msg("[Resolve] No entitlements for premium plan, falling back to the free plan")
+	}
+
+	entitlement, err := s.entitlement.GetFree(ctx)
+	if err != nil {
+		return model.PlanEntitlement{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if entitlement != nil {
+		return *entitlement, nil
+	}
+
+	return model.PlanEntitlement{
+		Name:                          "Free",
+		MaxBookingPerDay:              null.IntFrom(int64(s.config.Booking.MaxBookingPerDay)),
+		MaxBookingPerCategory:         null.IntFrom(int64(s.config.Booking.MaxBookingPerCategory)),
+		MaxFreeFirstCoursePerCategory: null.IntFrom(int64(s.config.Booking.MaxBookingFreeFirstCourse)),
+	}, nil
+}
+
+// premiumInterval returns the interval of the subscription or one-off
+// payment that ends last. Premium granted by an admin without either falls
+// back to the monthly plan.
+func (s *EntitlementService) premiumInterval(ctx context.Context, studentID uuid.UUID) (model.SubscriptionInterval, error) {
+	var (
+		interval = model.SubscriptionIntervalMonthly
+		endDate  time.Time
+	)
+
+	subscriptions, err := s.subscription.Get(ctx, model.SubscriptionFilter{
+		StudentID: studentID,
+		Status:    model.SubscriptionStatusActive,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("student_id", studentID.String()).Msg("[premiumInterval] Error getting subscriptions")
+		return "", err
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.EndDate.After(endDate) {
+			interval, endDate = subscription.Interval, subscription.EndDate
+		}
+	}
+
+	payments, err := s.payment.Get(ctx, model.PaymentFilter{
+		StudentID: studentID,
+		StatusIn:  []string{string(model.SubscriptionStatusActive)},
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("student_id", studentID.String()).Msg("[premiumInterval] Error getting payments")
+		return "", err
+	}
+
+	for _, payment := range payments {
+		if payment.EndDate.After(endDate) {
+			interval, endDate = payment.Interval, payment.EndDate
+		}
+	}
+
+	return interval, nil
+}
+
+func (s *EntitlementService) getStudent(ctx context.Context, userID uuid.UUID) (*model.Student, error) {
+	student, err := s.student.GetByUserID(ctx, userID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("user_id", userID.String()).Msg("[getStudent] Error getting student by user ID")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if student == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	return student, nil
+}
+
+// RequireMonthlyReport returns the student of the user when their plan
+// includes monthly reports.
+func (s *EntitlementService) RequireMonthlyReport(ctx context.Context, userID uuid.UUID) (*model.Student, error) {
+	student, err := s.getStudent(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.RequireStudentMonthlyReport(ctx, *student); err != nil {
+		return nil, err
+	}
+
+	return student, nil
+}
+
+// RequireStudentMonthlyReport fails unless the plan of the student includes
+// monthly reports.
+func (s *EntitlementService) RequireStudentMonthlyReport(ctx context.Context, student model.Student) error {
+	entitlement, err := s.Resolve(ctx, student)
+	if err != nil {
+		return err
+	}
+
+	if !entitlement.MonthlyReport {
+		return shared.MakeError(ErrEntitlementRequired, "monthly reports")
+	}
+
+	return nil
+}
+
+// BookingQuota returns how many bookings the student has left today.
+func (s *EntitlementService) BookingQuota(ctx context.Context, studentID uuid.UUID, entitlement model.PlanEntitlement) (model.EntitlementQuota, error) {
+	return s.countQuota(ctx, model.EntitlementBookingPerDay, entitlement.MaxBookingPerDay, model.BookingFilter{
+		StudentID: studentID,
+		StatusIn:  model.BookedStatuses,
+	})
+}
+
+// CategoryBookingQuota returns how many bookings the student has left today
+// in the course category.
+func (s *EntitlementService) CategoryBookingQuota(ctx context.Context, studentID, courseCategoryID uuid.UUID, entitlement model.PlanEntitlement) (model.EntitlementQuota, error) {
+	return s.countQuota(ctx, model.EntitlementBookingPerCategory, entitlement.MaxBookingPerCategory, model.BookingFilter{
+		StudentID:        studentID,
+		CourseCategoryID: courseCategoryID,
+		StatusIn:         model.BookedStatuses,
+	})
+}
+
+// FreeFirstCourseQuota returns how many free first course bookings the
+// student has left today in the course category.
+func (s *EntitlementService) FreeFirstCourseQuota(ctx context.Context, studentID, courseCategoryID uuid.UUID, entitlement model.PlanEntitlement) (model.EntitlementQuota, error) {
+	return s.countQuota(ctx, model.EntitlementFreeFirstCourse, entitlement.MaxFreeFirstCoursePerCategory, model.BookingFilter{
+		StudentID:         studentID,
+		CourseCategoryID:  courseCategoryID,
+		IsFreeFirstCourse: null.BoolFrom(true),
+	})
+}
+
+func (s *EntitlementService) countQuota(ctx context.Context, feature string, limit null.Int, filter model.BookingFilter) (model.EntitlementQuota, error) {
+	now := time.Now()
+	filter.DateCreatedAt = now
+	filter.DeletedAtIsNil = null.BoolFrom(true)
+
+	used, err := s.booking.Count(ctx, filter)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("feature", feature).Msg("[countQuota] Error counting bookings")
+		return model.EntitlementQuota{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return model.NewEntitlementQuota(feature, limit, used, quotaResetAt(now)), nil
+}
+
+// GetStudentEntitlements returns the plan of the student with today's usage.
+func (s *EntitlementService) GetStudentEntitlements(ctx context.Context, userID uuid.UUID) (dto.StudentEntitlementsResponse, error) {
+	student, err := s.getStudent(ctx, userID)
+	if err != nil {
+		return dto.StudentEntitlementsResponse{}, err
+	}
+
+	entitlement, err := s.Resolve(ctx, *student)
+	if err != nil {
+		return dto.StudentEntitlementsResponse{}, err
+	}
+
+	bookingQuota, err := s.BookingQuota(ctx, student.ID, entitlement)
+	if err != nil {
+		return dto.StudentEntitlementsResponse{}, err
+	}
+
+	now := time.Now()
+	counts, err := s.booking.CountByCategory(ctx, student.ID, now)
+	if err != nil {
+		return dto.StudentEntitlementsResponse{}, shared.MakeError(ErrInternalServer)
+	}
+
+	categories := make([]dto.CategoryEntitlementQuota, 0, len(counts))
+	for _, count := range counts {
+		categories = append(categories, dto.CategoryEntitlementQuota{
+			CourseCategoryID: count.CourseCategoryID,
+			Booking:          model.NewEntitlementQuota(model.EntitlementBookingPerCategory, entitlement.MaxBookingPerCategory, count.Total, quotaResetAt(now)),
+			FreeFirstCourse:  model.NewEntitlementQuota(model.EntitlementFreeFirstCourse, entitlement.MaxFreeFirstCoursePerCategory, count.FreeFirstCourse, quotaResetAt(now)),
+		})
+	}
+
+	return dto.StudentEntitlementsResponse{
+		Plan:               entitlement.Name,
+		IsPremium:          student.IsPremium(),
+		PremiumUntil:       student.PremiumUntil,
+		BookingPerDay:      bookingQuota,
+		BookingPerCategory: categories,
+		Limits:             entitlement,
+	}, nil
+}
+
+// GetCourseBookingQuota returns the quotas that apply when the user books the
+// course. It returns nil for guests and users who are not students.
+func (s *EntitlementService) GetCourseBookingQuota(ctx context.Context, userID uuid.UUID, course model.Course) (*dto.CourseBookingQuota, error) {
+	if userID == uuid.Nil {
+		return nil, nil
+	}
+
+	student, err := s.student.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if student == nil {
+		return nil, nil
+	}
+
+	entitlement, err := s.Resolve(ctx, *student)
+	if err != nil {
+		return nil, err
+	}
+
+	var quota dto.CourseBookingQuota
+	quota.BookingPerDay, err = s.BookingQuota(ctx, student.ID, entitlement)
+	if err != nil {
+		return nil, err
+	}
+
+	quota.BookingPerCategory, err = s.CategoryBookingQuota(ctx, student.ID, course.CourseCategoryID, entitlement)
+	if err != nil {
+		return nil, err
+	}
+
+	if course.IsFreeFirstCourse.Bool {
+		freeFirstCourse, err := s.FreeFirstCourseQuota(ctx, student.ID, course.CourseCategoryID, entitlement)
+		if err != nil {
+			return nil, err
+		}
+		quota.FreeFirstCourse = &freeFirstCourse
+	}
+
+	return &quota, nil
+}
+
+func (s *EntitlementService) ListPlans(ctx context.Context) ([]model.PlanEntitlement, error) {
+	entitlements, err := s.entitlement.Get(ctx)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return entitlements, nil
+}
+
+func (s *EntitlementService) UpdatePlan(ctx context.Context, req dto.UpdatePlanEntitlementRequest) (model.PlanEntitlement, error) {
+	entitlement, err := s.entitlement.GetByID(ctx, req.ID)
+	if err != nil {
+		return model.PlanEntitlement{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if entitlement == nil {
+		return model.PlanEntitlement{}, shared.MakeError(ErrEntityNotFound, "plan entitlement")
+	}
+
+	entitlement.Name = req.Name
+	entitlement.MaxBookingPerDay = req.MaxBookingPerDay
+	entitlement.MaxBookingPerCategory = req.MaxBookingPerCategory
+	entitlement.MaxFreeFirstCoursePerCategory = req.MaxFreeFirstCoursePerCategory
+	entitlement.MonthlyReport = req.MonthlyReport
+	entitlement.PriorityResponse = req.PriorityResponse
+	entitlement.UpdatedAt = time.Now()
+	entitlement.UpdatedBy = uuid.NullUUID{UUID: req.AdminID, Valid: true}
+
+	if err := s.entitlement.Update(ctx, entitlement); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("id", req.ID.String()).Msg("[UpdatePlan] Error updating plan entitlement")
+		return model.PlanEntitlement{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return *entitlement, nil
+}
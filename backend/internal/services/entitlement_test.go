@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuotaResetAt(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{name: "mid day", now: time.Date(2026, 3, 5, 14, 30, 0, 0, jakarta), want: time.Date(2026, 3, 6, 0, 0, 0, 0, jakarta)},
+		{name: "just after midnight", now: time.Date(2026, 3, 5, 0, 0, 1, 0, jakarta), want: time.Date(2026, 3, 6, 0, 0, 0, 0, jakarta)},
+		{name: "end of month", now: time.Date(2026, 2, 28, 23, 59, 0, 0, jakarta), want: time.Date(2026, 3, 1, 0, 0, 0, 0, jakarta)},
+		{name: "end of year", now: time.Date(2026, 12, 31, 8, 0, 0, 0, jakarta), want: time.Date(2027, 1, 1, 0, 0, 0, 0, jakarta)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quotaResetAt(tt.now); !got.Equal(tt.want) {
+				t.Errorf("quotaResetAt(%s) = %s, want %s", tt.now, got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,14 @@ const (
 	ErrCodeMaxBookingFreeFirstCourse
 	ErrCodeBookingAlreadExists
 	ErrCodeStudentAlreadyHasPayment
+	ErrCodeCoursePreCheckFailed
+	ErrCodeEntitlementRequired
+	ErrCodeInvalidSubscriptionStatus
+	ErrCodeExchangeRateNotFound
+	ErrCodeGiftCodeNotRedeemable
+	ErrCodeTaskSubmissionClosed
+	ErrCodeAttendanceNotAllowed
+	ErrCodeGroupSessionUnavailable
 )
 
 const (
@@ -34,6 +42,14 @@ const (
 	ErrMaxBookingFreeFirstCourse        = "Oops! hanya bisa booking<br><strong>“kursus pertama gratis”</strong> sekali per hari"
 	ErrBookingAlreadyExists             = "booking already exists"
 	ErrStudentAlreadyHasPayment         = "Payment sudah terbuat di halaman Kelola Langganan"
+	ErrCoursePreCheckFailed             = "course pre-check failed"
+	ErrEntitlementRequired              = "entitlement required"
+	ErrInvalidSubscriptionStatus        = "invalid subscription status"
+	ErrExchangeRateNotFound             = "exchange rate not found"
+	ErrGiftCodeNotRedeemable            = "gift code not redeemable"
+	ErrTaskSubmissionClosed             = "task submission closed"
+	ErrAttendanceNotAllowed             = "attendance not allowed"
+	ErrGroupSessionUnavailable          = "group session unavailable"
 )
 
 var (
@@ -49,6 +65,14 @@ var (
 		ErrMaxBookingFreeFirstCourse:        "Booking “kursus pertama gratis” dibatasi 1 kali/mata pelajaran per hari. Apabila ingin booking dengan label yang sama, pilih mata pelajaran lain.",
 		ErrBookingAlreadyExists:             "Booking already exists",
 		ErrStudentAlreadyHasPayment:         "Payment sudah terbuat di halaman Kelola Langganan",
+		ErrCoursePreCheckFailed:             "Course did not pass the pre-checks: %s",
+		ErrEntitlementRequired:              "Your plan does not include %s. Upgrade to premium to unlock it",
+		ErrInvalidSubscriptionStatus:        "Subscription can not move from %s to %s",
+		ErrExchangeRateNotFound:             "No exchange rate from %s to %s",
+		ErrGiftCodeNotRedeemable:            "Kode hadiah tidak dapat digunakan: %s",
+		ErrTaskSubmissionClosed:             "Tugas tidak dapat dikumpulkan lagi: %s",
+		ErrAttendanceNotAllowed:             "Kehadiran tidak dapat dicatat: %s",
+		ErrGroupSessionUnavailable:          "Kelas grup tidak dapat dipesan: %s",
 	}
 
 	errorMapHttpCode = map[string]int{
@@ -63,6 +87,14 @@ var (
 		ErrMaxBookingFreeFirstCourse:        http.StatusBadRequest,
 		ErrBookingAlreadyExists:             http.StatusBadRequest,
 		ErrStudentAlreadyHasPayment:         http.StatusBadRequest,
+		ErrCoursePreCheckFailed:             http.StatusBadRequest,
+		ErrEntitlementRequired:              http.StatusForbidden,
+		ErrInvalidSubscriptionStatus:        http.StatusConflict,
+		ErrExchangeRateNotFound:             http.StatusUnprocessableEntity,
+		ErrGiftCodeNotRedeemable:            http.StatusBadRequest,
+		ErrTaskSubmissionClosed:             http.StatusConflict,
+		ErrAttendanceNotAllowed:             http.StatusConflict,
+		ErrGroupSessionUnavailable:          http.StatusConflict,
 	}
 
 	errorMapCode = map[string]int{
@@ -73,6 +105,14 @@ var (
 		ErrMaxBookingFreeFirstCourse:        ErrCodeMaxBookingFreeFirstCourse,
 		ErrBookingAlreadyExists:             ErrCodeBookingAlreadExists,
 		ErrStudentAlreadyHasPayment:         ErrCodeStudentAlreadyHasPayment,
+		ErrCoursePreCheckFailed:             ErrCodeCoursePreCheckFailed,
+		ErrEntitlementRequired:              ErrCodeEntitlementRequired,
+		ErrInvalidSubscriptionStatus:        ErrCodeInvalidSubscriptionStatus,
+		ErrExchangeRateNotFound:             ErrCodeExchangeRateNotFound,
+		ErrGiftCodeNotRedeemable:            ErrCodeGiftCodeNotRedeemable,
+		ErrTaskSubmissionClosed:             ErrCodeTaskSubmissionClosed,
+		ErrAttendanceNotAllowed:             ErrCodeAttendanceNotAllowed,
+		ErrGroupSessionUnavailable:          ErrCodeGroupSessionUnavailable,
 	}
 )
 
@@ -88,6 +88,71 @@ func (s *FileService) UploadFile(ctx context.Context, file multipart.File, heade
 	}, nil
 }
 
+// PutPrivateFile stores generated content under key without public access.
+func (s *FileService) PutPrivateFile(ctx context.Context, key string, content []byte) error {
+	_, err := s.linode.Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.config.Linode.BucketName),
+		Key:           aws.String(key),
+		Body:          bytes.NewReader(content),
+		ContentLength: aws.Int64(int64(len(content))),
+		ContentType:   aws.String(s.detectContentType(key, content)),
+		ACL:           aws.String("private"),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to put file to Linode")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteFile removes the file stored under key.
+func (s *FileService) DeleteFile(ctx context.Context, key string) error {
+	_, err := s.linode.Client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.config.Linode.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to delete file from Linode")
+		return err
+	}
+
+	return nil
+}
+
+// GetFile returns the content stored under key.
+func (s *FileService) GetFile(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.linode.Client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.config.Linode.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to get file from Linode")
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
+
+// PresignFile returns a URL that downloads the private file under key until
+// the ttl runs out.
+func (s *FileService) PresignFile(key, filename string, ttl time.Duration) (string, error) {
+	req, _ := s.linode.Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     aws.String(s.config.Linode.BucketName),
+		Key:                        aws.String(key),
+		ResponseContentDisposition: aws.String(fmt.Sprintf("attachment; filename=\"%s\"", filename)),
+	})
+
+	url, err := req.Presign(ttl)
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to presign file from Linode")
+		return "", err
+	}
+
+	return url, nil
+}
+
 // detectContentType detects the content type based on file extension
 func (s *FileService) detectContentType(filename string, content []byte) string {
 	ext := strings.ToLower(filepath.Ext(filename))
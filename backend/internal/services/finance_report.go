@@ -0,0 +1,451 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+const (
+	financeGrossBookingVolume = "gross_booking_volume"
+	financeSubscriptionRev    = "subscription_revenue"
+	financeSubscriptionRefund = "subscription_refunds"
+	financeVATCollected       = "vat_collected"
+	financeCommission         = "commission"
+	financeMentorEarnings     = "mentor_earnings"
+	financePayoutsRequested   = "payouts_requested"
+	financePayoutsPaid        = "payouts_paid"
+	financePayoutsFailed      = "payouts_failed"
+	financeOutstanding        = "outstanding"
+)
+
+// financeMetric is a column of a finance report and the query summing it per
+// period.
+type financeMetric struct {
+	column dto.FinanceReportColumn
+	sum    func(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error)
+}
+
+type FinanceReportService struct {
+	finance *repositories.FinanceReportRepository
+	balance *repositories.MentorBalanceRepository
+	tutor   *repositories.TutorRepository
+}
+
+func NewFinanceReportService(
+	finance *repositories.FinanceReportRepository,
+	balance *repositories.MentorBalanceRepository,
+	tutor *repositories.TutorRepository,
+) *FinanceReportService {
+	return &FinanceReportService{
+		finance: finance,
+		balance: balance,
+		tutor:   tutor,
+	}
+}
+
+func (s *FinanceReportService) metrics(report string) []financeMetric {
+	paid := func(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+		return s.finance.Payouts(ctx, filter, model.WithdrawalStatusCompleted)
+	}
+	failed := func(ctx context.Context, filter model.FinanceReportFilter) (map[string]decimal.Decimal, error) {
+		return s.finance.Payouts(ctx, filter, model.WithdrawalStatusFailed)
+	}
+
+	switch report {
+	case dto.FinanceReportRevenue:
+		return []financeMetric{
+			{dto.FinanceReportColumn{Key: financeGrossBookingVolume, Label: "Gross Booking Volume"}, s.finance.BookingVolume},
+			{dto.FinanceReportColumn{Key: financeSubscriptionRev, Label: "Subscription Revenue"}, s.finance.SubscriptionRevenue},
+			{dto.FinanceReportColumn{Key: financeSubscriptionRefund, Label: "Subscription Refunds"}, s.finance.SubscriptionRefunds},
+			{dto.FinanceReportColumn{Key: financeVATCollected, Label: "VAT Collected"}, s.finance.VATCollected},
+		}
+	case dto.FinanceReportCommission:
+		return []financeMetric{
+			{dto.FinanceReportColumn{Key: financeGrossBookingVolume, Label: "Gross Booking Volume"}, s.finance.BookingVolume},
+			{dto.FinanceReportColumn{Key: financeCommission, Label: "Platform Commission"}, s.finance.Commission},
+			{dto.FinanceReportColumn{Key: financeMentorEarnings, Label: "Mentor Earnings"}, s.finance.MentorEarnings},
+		}
+	case dto.FinanceReportPayouts:
+		return []financeMetric{
+			{dto.FinanceReportColumn{Key: financePayoutsRequested, Label: "Payouts Requested"}, s.finance.PayoutsRequested},
+			{dto.FinanceReportColumn{Key: financePayoutsPaid, Label: "Payouts Paid"}, paid},
+			{dto.FinanceReportColumn{Key: financePayoutsFailed, Label: "Payouts Failed"}, failed},
+		}
+	default:
+		return []financeMetric{
+			{dto.FinanceReportColumn{Key: financeMentorEarnings, Label: "Mentor Earnings"}, s.finance.MentorEarnings},
+			{dto.FinanceReportColumn{Key: financePayoutsPaid, Label: "Payouts Paid"}, paid},
+		}
+	}
+}
+
+// Report sums the figures of the requested report per period. The
+// liabilities report also carries what is owed to mentors at the end of each
+// period.
+func (s *FinanceReportService) Report(ctx context.Context, req dto.GetFinanceReportRequest) (*dto.FinanceReportResponse, error) {
+	filter := req.Filter()
+	metrics := s.metrics(req.Report)
+
+	res := &dto.FinanceReportResponse{
+		Report:      req.Report,
+		Granularity: req.Granularity,
+		From:        req.From,
+		To:          req.To,
+		Totals:      map[string]decimal.Decimal{},
+	}
+
+	sums := make([]map[string]decimal.Decimal, len(metrics))
+	for i, metric := range metrics {
+		sum, err := metric.sum(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		sums[i] = sum
+		res.Columns = append(res.Columns, metric.column)
+		res.Totals[metric.column.Key] = sumPeriods(sum)
+	}
+
+	var outstanding decimal.Decimal
+	if req.Report == dto.FinanceReportLiabilities {
+		earned, err := s.finance.MentorEarningsBefore(ctx, filter, filter.From)
+		if err != nil {
+			return nil, err
+		}
+
+		paid, err := s.finance.PaidOutBefore(ctx, filter, filter.From)
+		if err != nil {
+			return nil, err
+		}
+
+		outstanding = earned.Sub(paid)
+		res.Columns = append(res.Columns, dto.FinanceReportColumn{Key: financeOutstanding, Label: "Outstanding Liabilities"})
+	}
+
+	for _, period := range filter.Periods() {
+		row := dto.FinanceReportRow{Period: period, Values: map[string]decimal.Decimal{}}
+		for i, metric := range metrics {
+			row.Values[metric.column.Key] = sums[i][period]
+		}
+
+		if req.Report == dto.FinanceReportLiabilities {
+			outstanding = outstanding.Add(row.Values[financeMentorEarnings]).Sub(row.Values[financePayoutsPaid])
+			row.Values[financeOutstanding] = outstanding
+		}
+
+		res.Rows = append(res.Rows, row)
+	}
+
+	// Outstanding liabilities are a closing figure, not summed over periods.
+	if req.Report == dto.FinanceReportLiabilities {
+		res.Totals[financeOutstanding] = outstanding
+	}
+
+	return res, nil
+}
+
+// Export writes the requested report as a CSV or XLSX file.
+func (s *FinanceReportService) Export(ctx context.Context, req dto.GetFinanceReportRequest) ([]byte, string, error) {
+	report, err := s.Report(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := fmt.Sprintf("finance_%s_%s_%s_%s.%s", report.Report, report.Granularity, report.From, report.To, req.Format)
+	if req.Format == dto.FinanceExportFormatXLSX {
+		content, err := financeReportXLSX(report)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[Export] Error writing xlsx")
+			return nil, "", err
+		}
+
+		return content, filename, nil
+	}
+
+	content, err := financeReportCSV(report)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Export] Error writing csv")
+		return nil, "", err
+	}
+
+	return content, filename, nil
+}
+
+// financeReportTable lays the report out as a header, a row per period and a
+// totals row.
+func financeReportTable(report *dto.FinanceReportResponse) ([]string, [][]decimal.Decimal, []decimal.Decimal) {
+	header := []string{"Period"}
+	totals := make([]decimal.Decimal, 0, len(report.Columns))
+	for _, column := range report.Columns {
+		header = append(header, column.Label)
+		totals = append(totals, report.Totals[column.Key])
+	}
+
+	rows := make([][]decimal.Decimal, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		values := make([]decimal.Decimal, 0, len(report.Columns))
+		for _, column := range report.Columns {
+			values = append(values, row.Values[column.Key])
+		}
+		rows = append(rows, values)
+	}
+
+	return header, rows, totals
+}
+
+func financeReportCSV(report *dto.FinanceReportResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header, rows, totals := financeReportTable(report)
+	records := [][]string{header}
+	for i, row := range rows {
+		records = append(records, financeRecord(report.Rows[i].Period, row))
+	}
+	records = append(records, financeRecord("Total", totals))
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func financeRecord(period string, values []decimal.Decimal) []string {
+	record := []string{period}
+	for _, value := range values {
+		record = append(record, value.StringFixed(2))
+	}
+
+	return record
+}
+
+func financeReportXLSX(report *dto.FinanceReportResponse) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	if err := f.SetSheetName(sheet, report.Report); err != nil {
+		return nil, err
+	}
+	sheet = report.Report
+
+	money, err := f.NewStyle(&excelize.Style{NumFmt: 4})
+	if err != nil {
+		return nil, err
+	}
+
+	header, rows, totals := financeReportTable(report)
+	if err := f.SetSheetRow(sheet, "A1", &header); err != nil {
+		return nil, err
+	}
+
+	write := func(line int, period string, values []decimal.Decimal) error {
+		record := []any{period}
+		for _, value := range values {
+			record = append(record, value.InexactFloat64())
+		}
+
+		cell, _ := excelize.CoordinatesToCellName(1, line)
+		return f.SetSheetRow(sheet, cell, &record)
+	}
+
+	for i, row := range rows {
+		if err := write(i+2, report.Rows[i].Period, row); err != nil {
+			return nil, err
+		}
+	}
+
+	last := len(rows) + 2
+	if err := write(last, "Total", totals); err != nil {
+		return nil, err
+	}
+
+	lastCell, _ := excelize.CoordinatesToCellName(len(header), last)
+	if err := f.SetCellStyle(sheet, "B2", lastCell, money); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// EarningsStatementData represents the data passed to the HTML template
+type EarningsStatementData struct {
+	TutorName      string
+	MonthYear      string
+	Date           string
+	OpeningBalance string
+	TotalCredit    string
+	TotalDebit     string
+	TotalFee       string
+	ClosingBalance string
+	Transactions   []StatementTransactionData
+}
+
+type StatementTransactionData struct {
+	Date        string
+	Description string
+	Commission  string
+	Credit      string
+	Debit       string
+	Balance     string
+}
+
+// GenerateMentorEarningsStatement renders the earnings statement of the
+// mentor behind the user.
+func (s *FinanceReportService) GenerateMentorEarningsStatement(ctx context.Context, userID uuid.UUID, month int, year int) ([]byte, string, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if tutor == nil {
+		return nil, "", shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	return s.GenerateEarningsStatement(ctx, tutor.ID, month, year)
+}
+
+// GenerateEarningsStatement renders the monthly earnings statement of a
+// tutor: the opening balance, every balance movement of the month and the
+// closing balance.
+func (s *FinanceReportService) GenerateEarningsStatement(ctx context.Context, tutorID uuid.UUID, month int, year int) ([]byte, string, error) {
+	tutor, err := s.tutor.GetByID(ctx, tutorID)
+	if err != nil {
+		return nil, "", err
+	}
+	if tutor == nil {
+		return nil, "", shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 1, 0)
+
+	opening, err := s.balance.BalanceAt(ctx, tutor.ID, startDate)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GenerateEarningsStatement] Error getting opening balance")
+		return nil, "", err
+	}
+
+	transactions, err := s.balance.ListTransactionsBetween(ctx, tutor.ID, startDate, endDate)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GenerateEarningsStatement] Error listing transactions")
+		return nil, "", err
+	}
+
+	mb, err := s.balance.GetOrCreate(ctx, tutor.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GenerateEarningsStatement] Error getting balance")
+		return nil, "", err
+	}
+
+	ac := model.MustCurrency(mb.Currency)
+
+	var (
+		balance            = opening
+		credit, debit, fee decimal.Decimal
+		transactionsData   []StatementTransactionData
+	)
+	for _, tx := range transactions {
+		data := StatementTransactionData{
+			Date:        tx.CreatedAt.Format("02/01/2006"),
+			Description: tx.Description,
+			Commission:  "-",
+			Credit:      "-",
+			Debit:       "-",
+		}
+
+		if tx.Type == model.BalanceTransactionCredit {
+			balance = balance.Add(tx.Amount)
+			credit = credit.Add(tx.Amount)
+			fee = fee.Add(tx.Commission)
+			data.Credit = ac.Format(tx.Amount)
+		} else {
+			balance = balance.Sub(tx.Amount)
+			debit = debit.Add(tx.Amount)
+			fee = fee.Sub(tx.Commission)
+			data.Debit = ac.Format(tx.Amount)
+		}
+
+		if !tx.Commission.IsZero() {
+			data.Commission = ac.Format(tx.Commission)
+		}
+
+		data.Balance = ac.Format(balance)
+		transactionsData = append(transactionsData, data)
+	}
+
+	data := EarningsStatementData{
+		TutorName:      tutor.User.Name,
+		MonthYear:      startDate.Format("January 2006"),
+		Date:           time.Now().Format("02/01/2006"),
+		OpeningBalance: ac.Format(opening),
+		TotalCredit:    ac.Format(credit),
+		TotalDebit:     ac.Format(debit),
+		TotalFee:       ac.Format(fee),
+		ClosingBalance: ac.Format(balance),
+		Transactions:   transactionsData,
+	}
+
+	tmpl, err := template.ParseFiles("./templates/pdf/earnings_statement/index.html")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GenerateEarningsStatement] failed to parse template")
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GenerateEarningsStatement] failed to execute template")
+		return nil, "", err
+	}
+
+	pdfg, err := wkhtmltopdf.NewPDFGenerator()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GenerateEarningsStatement] failed to create pdf generator")
+		return nil, "", err
+	}
+
+	page := wkhtmltopdf.NewPageReader(bytes.NewReader(buf.Bytes()))
+	pdfg.AddPage(page)
+	pdfg.PageSize.Set(wkhtmltopdf.PageSizeA4)
+	pdfg.Orientation.Set(wkhtmltopdf.OrientationLandscape)
+
+	err = pdfg.Create()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GenerateEarningsStatement] failed to create pdf")
+		return nil, "", err
+	}
+
+	filename := fmt.Sprintf("Earnings_%s_%s.pdf", tutor.User.Name, data.MonthYear)
+	return pdfg.Bytes(), filename, nil
+}
+
+// sumPeriods adds up the totals of every period.
+func sumPeriods(totals map[string]decimal.Decimal) decimal.Decimal {
+	sum := decimal.Zero
+	for _, total := range totals {
+		sum = sum.Add(total)
+	}
+
+	return sum
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/lesprivate/backend/internal/model/dto"
+)
+
+func newTestFinanceReport() *dto.FinanceReportResponse {
+	return &dto.FinanceReportResponse{
+		Report:      dto.FinanceReportCommission,
+		Granularity: "month",
+		Columns: []dto.FinanceReportColumn{
+			{Key: "volume", Label: "Booking Volume"},
+			{Key: "commission", Label: "Commission"},
+		},
+		Rows: []dto.FinanceReportRow{
+			{Period: "2026-01", Values: map[string]decimal.Decimal{"volume": decimal.NewFromInt(1500000), "commission": decimal.NewFromInt(150000)}},
+			// Periods without any figure are still listed
+			{Period: "2026-02", Values: map[string]decimal.Decimal{}},
+		},
+		Totals: map[string]decimal.Decimal{"volume": decimal.NewFromInt(1500000), "commission": decimal.NewFromInt(150000)},
+	}
+}
+
+func TestFinanceReportCSV(t *testing.T) {
+	content, err := financeReportCSV(newTestFinanceReport())
+	if err != nil {
+		t.Fatalf("financeReportCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"Period", "Booking Volume", "Commission"},
+		{"2026-01", "1500000.00", "150000.00"},
+		{"2026-02", "0.00", "0.00"},
+		{"Total", "1500000.00", "150000.00"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("financeReportCSV() = %v, want %v", records, want)
+	}
+}
+
+func TestFinanceReportXLSX(t *testing.T) {
+	content, err := financeReportXLSX(newTestFinanceReport())
+	if err != nil {
+		t.Fatalf("financeReportXLSX() error = %v", err)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("opening XLSX: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(dto.FinanceReportCommission, excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatalf("reading sheet: %v", err)
+	}
+
+	want := [][]string{
+		{"Period", "Booking Volume", "Commission"},
+		{"2026-01", "1500000", "150000"},
+		{"2026-02", "0", "0"},
+		{"Total", "1500000", "150000"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("financeReportXLSX() rows = %v, want %v", rows, want)
+	}
+}
+
+func TestSumPeriods(t *testing.T) {
+	totals := map[string]decimal.Decimal{
+		"2026-01": decimal.RequireFromString("100.25"),
+		"2026-02": decimal.RequireFromString("-20.25"),
+		"2026-03": decimal.NewFromInt(0),
+	}
+
+	if got := sumPeriods(totals); !got.Equal(decimal.NewFromInt(80)) {
+		t.Errorf("sumPeriods() = %s, want 80", got)
+	}
+	if got := sumPeriods(nil); !got.IsZero() {
+		t.Errorf("sumPeriods(nil) = %s, want 0", got)
+	}
+}
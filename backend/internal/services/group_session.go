@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+// GroupSessionService runs the group sessions of group schedules, from the
+// seats students book to the confirmation or cancellation at the cutoff,
+// and lets the tutor manage the students of a session together.
+type GroupSessionService struct {
+	groupSession *repositories.GroupSessionRepository
+	booking      *repositories.BookingRepository
+	sessionTask  *repositories.SessionTaskRepository
+	tutor        *repositories.TutorRepository
+	bookingEvent *BookingEventService
+	meeting      *MeetingService
+	notification *NotificationService
+	config       *config.Config
+}
+
+func NewGroupSessionService(
+	groupSession *repositories.GroupSessionRepository,
+	booking *repositories.BookingRepository,
+	sessionTask *repositories.SessionTaskRepository,
+	tutor *repositories.TutorRepository,
+	bookingEvent *BookingEventService,
+	meeting *MeetingService,
+	notification *NotificationService,
+	config *config.Config,
+) *GroupSessionService {
+	return &GroupSessionService{
+		groupSession: groupSession,
+		booking:      booking,
+		sessionTask:  sessionTask,
+		tutor:        tutor,
+		bookingEvent: bookingEvent,
+		meeting:      meeting,
+		notification: notification,
+		config:       config,
+	}
+}
+
+// ReserveSeat books the seat in the group session of the schedule slot,
+// which starts when the seat does. The session is confirmed as soon as the
+// seat makes it reach its minimum attendees.
+func (s *GroupSessionService) ReserveSeat(ctx context.Context, course model.Course, schedule model.CourseSchedule, seat *model.Booking) error {
+	now := time.Now()
+	session := model.GroupSession{
+		ID:           uuid.New(),
+		CourseID:     course.ID,
+		TutorID:      course.TutorID,
+		ClassType:    schedule.ClassType,
+		SessionDate:  seat.BookingDate,
+		SessionTime:  seat.BookingTime,
+		Timezone:     schedule.Timezone,
+		Capacity:     int(schedule.Capacity.Int64),
+		MinAttendees: int(schedule.MinAttendees.Int64),
+		Status:       model.GroupSessionStatusOpen,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Course:       course,
+	}
+	session.CutoffAt = session.StartsAt().Add(-s.config.Booking.GroupSessionCutoff)
+
+	reserved, err := s.groupSession.ReserveSeat(ctx, &session, seat)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if !reserved {
+		if session.SeatsTaken >= int64(session.Capacity) {
+			return shared.MakeError(ErrGroupSessionUnavailable, "kursi sudah penuh")
+		}
+		return shared.MakeError(ErrGroupSessionUnavailable, "pendaftaran sudah ditutup")
+	}
+
+	seat.Course = course
+	s.bookingEvent.RecordCreated(ctx, *seat, seat.CreatedBy)
+
+	if session.Status == model.GroupSessionStatusConfirmed {
+		err = s.meeting.Provision(ctx, seat)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ReserveSeat] Error provisioning meeting")
+		}
+		return nil
+	}
+
+	// The seat is booked either way, so a confirmation left unfinished is
+	// only logged
+	if session.SeatsTaken >= int64(session.MinAttendees) {
+		_, err = s.confirm(ctx, &session)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ReserveSeat] Error confirming group session")
+		}
+	}
+
+	return nil
+}
+
+// ProcessCutoff confirms the open sessions whose cutoff passed with enough
+// students and cancels the others.
+func (s *GroupSessionService) ProcessCutoff(ctx context.Context) error {
+	sessions, err := s.groupSession.GetDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var confirmed, cancelled int
+	for i := range sessions {
+		if sessions[i].SeatsTaken >= int64(sessions[i].MinAttendees) {
+			ok, err := s.confirm(ctx, &sessions[i])
+			if err != nil {
+				logger.ErrorCtx(ctx).Err(err).Str("id", sessions[i].ID.String()).Msg("[ProcessCutoff] Error confirming group session")
+			}
+			if ok {
+				confirmed++
+			}
+			continue
+		}
+
+		ok, err := s.cancel(ctx, &sessions[i])
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("id", sessions[i].ID.String()).Msg("[ProcessCutoff] Error cancelling group session")
+		}
+		if ok {
+			cancelled++
+		}
+	}
+
+	logger.InfoCtx(ctx).
+		Int("confirmed", confirmed).
+		Int("cancelled", cancelled).
+		Msg("[ProcessCutoff] Group sessions processed")
+	return nil
+}
+
+// confirm accepts the seats of the open session, opens their classroom and
+// tells everyone the session takes place. It reports whether the session
+// was confirmed, with the error of a confirmation left unfinished.
+func (s *GroupSessionService) confirm(ctx context.Context, session *model.GroupSession) (bool, error) {
+	confirmed, err := s.groupSession.Confirm(ctx, session, time.Now())
+	if err != nil || !confirmed {
+		return false, err
+	}
+
+	seats, err := s.groupSession.GetSeats(ctx, session.ID)
+	if err != nil {
+		return true, err
+	}
+
+	systemID := uuid.MustParse(model.SystemID)
+	for i := range seats {
+		s.bookingEvent.RecordStatus(ctx, seats[i], systemID)
+		err = s.meeting.Provision(ctx, &seats[i])
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("booking_id", seats[i].ID.String()).Msg("[confirm] Error provisioning meeting")
+		}
+	}
+
+	err = s.notification.GroupSessionConfirmed(ctx, *session, seats)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[confirm] Error sending group session confirmed notification")
+	}
+
+	return true, nil
+}
+
+// cancel cancels the open session with its seats and tells everyone.
+func (s *GroupSessionService) cancel(ctx context.Context, session *model.GroupSession) (bool, error) {
+	seats, err := s.groupSession.GetSeats(ctx, session.ID)
+	if err != nil {
+		return false, err
+	}
+
+	cancelled, err := s.groupSession.Cancel(ctx, session, time.Now())
+	if err != nil || !cancelled {
+		return false, err
+	}
+
+	systemID := uuid.MustParse(model.SystemID)
+	for i := range seats {
+		seats[i].Status = model.BookingStatusCancelled
+		s.bookingEvent.RecordStatus(ctx, seats[i], systemID)
+	}
+
+	err = s.notification.GroupSessionCancelled(ctx, *session, seats)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[cancel] Error sending group session cancelled notification")
+	}
+
+	return true, nil
+}
+
+func (s *GroupSessionService) currentTutor(ctx context.Context) (*model.Tutor, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GroupSessionService] Error getting tutor")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	return tutor, nil
+}
+
+// ownSession returns the session with its seats when it belongs to the
+// current tutor
+func (s *GroupSessionService) ownSession(ctx context.Context, id uuid.UUID) (*model.GroupSession, []model.Booking, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session, err := s.groupSession.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, shared.MakeError(ErrInternalServer)
+	}
+	if session == nil || session.TutorID != tutor.ID {
+		return nil, nil, shared.MakeError(ErrEntityNotFound, "group session")
+	}
+
+	seats, err := s.groupSession.GetSeats(ctx, session.ID)
+	if err != nil {
+		return nil, nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return session, seats, nil
+}
+
+// selectSeats returns the seats of the given students, every seat when none
+// is given.
+func selectSeats(seats []model.Booking, studentIDs []uuid.UUID) ([]model.Booking, error) {
+	if len(studentIDs) == 0 {
+		return seats, nil
+	}
+
+	var selected []model.Booking
+	for _, studentID := range studentIDs {
+		i := slices.IndexFunc(seats, func(seat model.Booking) bool {
+			return seat.StudentID == studentID
+		})
+		if i < 0 {
+			return nil, shared.MakeError(ErrBadRequest, "student "+studentID.String()+" has no seat in the session")
+		}
+
+		selected = append(selected, seats[i])
+	}
+
+	return selected, nil
+}
+
+func (s *GroupSessionService) GetSessions(ctx context.Context, request dto.GetGroupSessionsRequest) ([]dto.GroupSessionResponse, model.Metadata, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	sessions, metadata, err := s.groupSession.Get(ctx, request.Filter(tutor.ID))
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewGroupSessionResponses(sessions), metadata, nil
+}
+
+// GetRoster returns the session with the students holding a seat.
+func (s *GroupSessionService) GetRoster(ctx context.Context, id uuid.UUID) (*dto.GroupSessionRosterResponse, error) {
+	session, seats, err := s.ownSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := dto.NewGroupSessionRosterResponse(*session, seats)
+	return &res, nil
+}
+
+// AssignTask gives each selected student of the session their own copy of
+// the task so it is submitted and graded per student.
+func (s *GroupSessionService) AssignTask(ctx context.Context, id uuid.UUID, request dto.GroupSessionTaskRequest) ([]model.SessionTask, error) {
+	session, seats, err := s.ownSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status == model.GroupSessionStatusCancelled {
+		return nil, shared.MakeError(ErrBadRequest, "group session is cancelled")
+	}
+
+	seats, err = selectSeats(seats, request.StudentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(seats) == 0 {
+		return []model.SessionTask{}, nil
+	}
+
+	var rubric []byte
+	if len(request.Rubric) > 0 {
+		rubric, err = json.Marshal(request.Rubric)
+		if err != nil {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+	}
+
+	now := time.Now()
+	tasks := make([]model.SessionTask, 0, len(seats))
+	for _, seat := range seats {
+		tasks = append(tasks, model.SessionTask{
+			ID:            uuid.New(),
+			BookingID:     seat.ID,
+			Title:         request.Title,
+			Description:   null.StringFromPtr(request.Description),
+			AttachmentURL: null.StringFromPtr(request.AttachmentURL),
+			DueAt:         null.TimeFromPtr(request.DueAt),
+			Rubric:        rubric,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+
+	err = s.sessionTask.CreateBatch(ctx, tasks)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AssignTask] Error creating tasks")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	for i, seat := range seats {
+		err = s.notification.TaskAssigned(ctx, seat, tasks[i])
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[AssignTask] Error sending task assigned notification")
+		}
+	}
+
+	return tasks, nil
+}
+
+// UpdateNotes sets the session notes the selected students of the session
+// read in their booking.
+func (s *GroupSessionService) UpdateNotes(ctx context.Context, id uuid.UUID, request dto.GroupSessionNotesRequest) error {
+	_, seats, err := s.ownSession(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	seats, err = selectSeats(seats, request.StudentIDs)
+	if err != nil {
+		return err
+	}
+
+	userID := middleware.GetUserID(ctx)
+	for i := range seats {
+		seats[i].NotesStudent = null.StringFrom(request.Notes)
+		seats[i].UpdatedAt = time.Now()
+		seats[i].UpdatedBy = userID
+	}
+
+	err = s.booking.BulkUpdate(ctx, seats)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateNotes] Error updating session notes")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestSelectSeats(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	seats := []model.Booking{{StudentID: first}, {StudentID: second}}
+
+	got, err := selectSeats(seats, nil)
+	if err != nil || len(got) != 2 {
+		t.Errorf("selectSeats() = (%v, %v), want every seat", got, err)
+	}
+
+	got, err = selectSeats(seats, []uuid.UUID{second})
+	if err != nil || len(got) != 1 || got[0].StudentID != second {
+		t.Errorf("selectSeats() = (%v, %v), want the seat of the student", got, err)
+	}
+
+	if _, err := selectSeats(seats, []uuid.UUID{first, uuid.New()}); err == nil {
+		t.Errorf("selectSeats() error = nil, want an error for a student without a seat")
+	}
+}
@@ -0,0 +1,491 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+const (
+	// guardianTokenTTL is how long the emailed confirmation link and the
+	// invite codes of a student stay valid.
+	guardianTokenTTL = 7 * 24 * time.Hour
+
+	guardianInviteCodeLength = 8
+)
+
+// GuardianService manages the links between guardians and students. A
+// guardian only acts for a student once the student accepted the link, and
+// then only reads the learning of the student.
+type GuardianService struct {
+	guardian      *repositories.GuardianRepository
+	student       *repositories.StudentRepository
+	user          *repositories.UserRepository
+	booking       *repositories.BookingRepository
+	entitlement   *EntitlementService
+	monthlyReport *MonthlyReportService
+	learningPlan  *LearningPlanService
+	notification  *NotificationService
+}
+
+func NewGuardianService(
+	guardian *repositories.GuardianRepository,
+	student *repositories.StudentRepository,
+	user *repositories.UserRepository,
+	booking *repositories.BookingRepository,
+	entitlement *EntitlementService,
+	monthlyReport *MonthlyReportService,
+	learningPlan *LearningPlanService,
+	notification *NotificationService,
+) *GuardianService {
+	return &GuardianService{
+		guardian:      guardian,
+		student:       student,
+		user:          user,
+		booking:       booking,
+		entitlement:   entitlement,
+		monthlyReport: monthlyReport,
+		learningPlan:  learningPlan,
+		notification:  notification,
+	}
+}
+
+// currentGuardian returns the guardian of the signed in user.
+func (s *GuardianService) currentGuardian(ctx context.Context) (*model.Guardian, error) {
+	guardian, err := s.guardian.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if guardian == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "guardian")
+	}
+
+	return guardian, nil
+}
+
+// currentStudent returns the student of the signed in user.
+func (s *GuardianService) currentStudent(ctx context.Context) (*model.Student, error) {
+	student, err := s.student.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if student == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	return student, nil
+}
+
+// RequestStudent asks the student with the email to accept the guardian. The
+// student accepts in the app or through the link emailed to them. The response
+// is the same whether or not the email belongs to a student, so guardians
+// cannot look accounts up with it.
+func (s *GuardianService) RequestStudent(ctx context.Context, req dto.CreateGuardianStudentRequest) (dto.CreateGuardianStudentResponse, error) {
+	res := dto.CreateGuardianStudentResponse{
+		Message: "If a student account exists with this email, they will receive the request shortly.",
+	}
+
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return dto.CreateGuardianStudentResponse{}, err
+	}
+
+	user, err := s.user.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WarnCtx(ctx).Str("email", req.Email).Msg("[RequestStudent] User not found")
+			return res, nil
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[RequestStudent] Error getting user")
+		return dto.CreateGuardianStudentResponse{}, err
+	}
+
+	student, err := s.student.GetByUserID(ctx, user.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RequestStudent] Error getting student")
+		return dto.CreateGuardianStudentResponse{}, err
+	}
+
+	if student == nil {
+		logger.WarnCtx(ctx).Str("email", req.Email).Msg("[RequestStudent] Student not found")
+		return res, nil
+	}
+
+	links, err := s.guardian.GetLinks(ctx, model.GuardianStudentFilter{
+		GuardianID: guardian.ID,
+		StudentID:  student.ID,
+	})
+	if err != nil {
+		return dto.CreateGuardianStudentResponse{}, err
+	}
+
+	// The guardian already sees the link in their list.
+	if len(links) > 0 {
+		return res, nil
+	}
+
+	token, err := generateGuardianToken()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RequestStudent] Error generating confirmation token")
+		return dto.CreateGuardianStudentResponse{}, shared.MakeError(ErrInternalServer)
+	}
+
+	link := &model.GuardianStudent{
+		ID:                uuid.New(),
+		GuardianID:        guardian.ID,
+		StudentID:         student.ID,
+		Status:            model.GuardianStudentStatusPending,
+		ConfirmationToken: null.StringFrom(token),
+		TokenExpiresAt:    null.TimeFrom(time.Now().Add(guardianTokenTTL)),
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+
+	err = s.guardian.CreateLink(ctx, link)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RequestStudent] Error creating guardian student")
+		return dto.CreateGuardianStudentResponse{}, err
+	}
+
+	link.Guardian = *guardian
+	link.Student = *student
+
+	go func(link model.GuardianStudent) {
+		if err := s.notification.GuardianLinkRequested(context.Background(), link); err != nil {
+			logger.ErrorCtx(context.Background()).Err(err).Msg("[RequestStudent] Error sending guardian link notification")
+		}
+	}(*link)
+
+	return res, nil
+}
+
+// GetStudents returns the students linked to the guardian, pending ones
+// included.
+func (s *GuardianService) GetStudents(ctx context.Context) ([]model.GuardianStudent, error) {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.guardian.GetLinks(ctx, model.GuardianStudentFilter{GuardianID: guardian.ID})
+}
+
+// RemoveStudent removes a link of the guardian.
+func (s *GuardianService) RemoveStudent(ctx context.Context, id uuid.UUID) error {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return err
+	}
+
+	link, err := s.guardian.GetLink(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if link == nil || link.GuardianID != guardian.ID {
+		return shared.MakeError(ErrEntityNotFound, "guardian student")
+	}
+
+	return s.guardian.DeleteLink(ctx, link.ID)
+}
+
+// GetGuardians returns the guardians who asked to link to the student.
+func (s *GuardianService) GetGuardians(ctx context.Context) ([]model.GuardianStudent, error) {
+	student, err := s.currentStudent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.guardian.GetLinks(ctx, model.GuardianStudentFilter{StudentID: student.ID})
+}
+
+// AcceptGuardian records the consent of the student to the link.
+func (s *GuardianService) AcceptGuardian(ctx context.Context, id uuid.UUID) (*model.GuardianStudent, error) {
+	student, err := s.currentStudent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.guardian.GetLink(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if link == nil || link.StudentID != student.ID {
+		return nil, shared.MakeError(ErrEntityNotFound, "guardian student")
+	}
+
+	return s.accept(ctx, link)
+}
+
+// ConfirmGuardian records the consent of the student through the token of
+// the emailed link. It needs no session since the student opens the link
+// from their inbox.
+func (s *GuardianService) ConfirmGuardian(ctx context.Context, req dto.ConfirmGuardianStudentRequest) (*model.GuardianStudent, error) {
+	link, err := s.guardian.GetLinkByToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if link == nil || !link.TokenExpiresAt.Valid || time.Now().After(link.TokenExpiresAt.Time) {
+		return nil, shared.MakeError(ErrBadRequest, "confirmation link is invalid or expired")
+	}
+
+	return s.accept(ctx, link)
+}
+
+func (s *GuardianService) accept(ctx context.Context, link *model.GuardianStudent) (*model.GuardianStudent, error) {
+	if link.IsActive() {
+		return link, nil
+	}
+
+	link.Accept(time.Now())
+
+	err := s.guardian.UpdateLink(ctx, link)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[accept] Error updating guardian student")
+		return nil, err
+	}
+
+	go func(link model.GuardianStudent) {
+		if err := s.notification.GuardianLinkAccepted(context.Background(), link); err != nil {
+			logger.ErrorCtx(context.Background()).Err(err).Msg("[accept] Error sending guardian link notification")
+		}
+	}(*link)
+
+	return link, nil
+}
+
+// RemoveGuardian declines a pending link or revokes an accepted one.
+func (s *GuardianService) RemoveGuardian(ctx context.Context, id uuid.UUID) error {
+	student, err := s.currentStudent(ctx)
+	if err != nil {
+		return err
+	}
+
+	link, err := s.guardian.GetLink(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if link == nil || link.StudentID != student.ID {
+		return shared.MakeError(ErrEntityNotFound, "guardian student")
+	}
+
+	return s.guardian.DeleteLink(ctx, link.ID)
+}
+
+// CreateInvite creates a code the student shares with a guardian.
+func (s *GuardianService) CreateInvite(ctx context.Context) (*model.GuardianInvite, error) {
+	student, err := s.currentStudent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &model.GuardianInvite{
+		ID:        uuid.New(),
+		StudentID: student.ID,
+		Code:      model.RandomCode(guardianInviteCodeLength),
+		ExpiresAt: time.Now().Add(guardianTokenTTL),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err = s.guardian.CreateInvite(ctx, invite)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvite] Error creating guardian invite")
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+// GetInvites returns the invite codes of the student that can still be used.
+func (s *GuardianService) GetInvites(ctx context.Context) ([]model.GuardianInvite, error) {
+	student, err := s.currentStudent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.guardian.GetInvites(ctx, student.ID)
+}
+
+// RedeemInvite links the guardian to the student of the invite code. The
+// code is the consent of the student, so the link is active right away.
+func (s *GuardianService) RedeemInvite(ctx context.Context, req dto.RedeemGuardianInviteRequest) (*model.GuardianStudent, error) {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invite, err := s.guardian.GetInviteByCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if invite == nil || !invite.IsUsable(time.Now()) {
+		return nil, shared.MakeError(ErrBadRequest, "invite code is invalid or expired")
+	}
+
+	links, err := s.guardian.GetLinks(ctx, model.GuardianStudentFilter{
+		GuardianID: guardian.ID,
+		StudentID:  invite.StudentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	link := &model.GuardianStudent{
+		ID:         uuid.New(),
+		GuardianID: guardian.ID,
+		StudentID:  invite.StudentID,
+		CreatedAt:  time.Now(),
+	}
+	if len(links) > 0 {
+		if links[0].IsActive() {
+			return nil, shared.MakeError(ErrBadRequest, "student already linked")
+		}
+
+		link = &links[0]
+	}
+
+	link.Accept(time.Now())
+	invite.UsedBy = uuid.NullUUID{UUID: guardian.ID, Valid: true}
+	invite.UsedAt = null.TimeFrom(time.Now())
+	invite.UpdatedAt = time.Now()
+
+	used, err := s.guardian.UseInvite(ctx, invite, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if !used {
+		return nil, shared.MakeError(ErrBadRequest, "invite code is invalid or expired")
+	}
+
+	return s.guardian.GetLink(ctx, link.ID)
+}
+
+// activeLink returns the accepted link between the guardian and the student.
+func (s *GuardianService) activeLink(ctx context.Context, studentID uuid.UUID) (*model.GuardianStudent, error) {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.guardian.GetLinks(ctx, model.GuardianStudentFilter{
+		GuardianID: guardian.ID,
+		StudentID:  studentID,
+		Status:     model.GuardianStudentStatusActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(links) == 0 {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	return &links[0], nil
+}
+
+// GetStudentBookings returns the upcoming bookings of a linked student.
+func (s *GuardianService) GetStudentBookings(ctx context.Context, studentID uuid.UUID, req dto.GetGuardianStudentBookingsRequest) ([]model.Booking, model.Metadata, error) {
+	link, err := s.activeLink(ctx, studentID)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	return s.booking.Get(ctx, model.BookingFilter{
+		StudentID:       link.StudentID,
+		StatusIn:        []model.BookingStatus{model.BookingStatusPending, model.BookingStatusAccepted},
+		BookingDateFrom: time.Now(),
+		Pagination:      req.Pagination,
+		Sort: model.Sort{
+			Sort:          "booking_date",
+			SortDirection: "asc",
+		},
+	})
+}
+
+// GetStudentSessions returns the past sessions of a linked student with the
+// tasks, their scores and the progress notes, latest first.
+func (s *GuardianService) GetStudentSessions(ctx context.Context, studentID uuid.UUID, req dto.GetGuardianStudentBookingsRequest) ([]model.Booking, model.Metadata, error) {
+	link, err := s.activeLink(ctx, studentID)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	return s.booking.Get(ctx, model.BookingFilter{
+		StudentID:        link.StudentID,
+		StatusIn:         []model.BookingStatus{model.BookingStatusAccepted, model.BookingStatusCompleted},
+		BookingDateUntil: time.Now(),
+		WithProgress:     true,
+		Pagination:       req.Pagination,
+		Sort: model.Sort{
+			Sort:          "booking_date",
+			SortDirection: "desc",
+		},
+	})
+}
+
+// RequestStudentMonthlyReport queues the monthly report of a linked student
+// when the plan of the student includes monthly reports.
+func (s *GuardianService) RequestStudentMonthlyReport(ctx context.Context, studentID uuid.UUID, req dto.CreateMonthlyReportRequest) (*dto.MonthlyReportResponse, error) {
+	link, err := s.activeLink(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.entitlement.RequireStudentMonthlyReport(ctx, link.Student); err != nil {
+		return nil, err
+	}
+
+	return s.monthlyReport.Request(ctx, link.StudentID, req)
+}
+
+// GetStudentLearningPlans returns the learning plans of a linked student.
+func (s *GuardianService) GetStudentLearningPlans(ctx context.Context, studentID uuid.UUID) ([]dto.LearningPlanResponse, error) {
+	link, err := s.activeLink(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.learningPlan.StudentPlans(ctx, link.StudentID)
+}
+
+// GetStudentLessonNotes returns the lesson notes of a learning plan of a
+// linked student.
+func (s *GuardianService) GetStudentLessonNotes(ctx context.Context, studentID, planID uuid.UUID, req dto.GetLessonNotesRequest) ([]dto.LessonNoteResponse, model.Metadata, error) {
+	link, err := s.activeLink(ctx, studentID)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	return s.learningPlan.StudentNotes(ctx, link.StudentID, planID, req)
+}
+
+func generateGuardianToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
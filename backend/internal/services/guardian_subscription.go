@@ -0,0 +1,469 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"github.com/xendit/xendit-go/v7"
+	xenditcustomer "github.com/xendit/xendit-go/v7/customer"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/config"
+	xenditext "github.com/lesprivate/backend/external/xendit"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+// GuardianSubscriptionService sells premium to guardians, either for their
+// linked students or as gift codes, and redeems the gift codes.
+type GuardianSubscriptionService struct {
+	config            *config.Config
+	guardian          *repositories.GuardianRepository
+	student           *repositories.StudentRepository
+	subscriptionPrice *repositories.SubscriptionPriceRepository
+	payment           *repositories.PaymentRepository
+	giftCode          *repositories.GiftCodeRepository
+	notification      *NotificationService
+	invoice           *InvoiceService
+	xendit            *xendit.APIClient
+	xenditExt         *xenditext.Client
+}
+
+func NewGuardianSubscriptionService(
+	config *config.Config,
+	guardian *repositories.GuardianRepository,
+	student *repositories.StudentRepository,
+	subscriptionPrice *repositories.SubscriptionPriceRepository,
+	payment *repositories.PaymentRepository,
+	giftCode *repositories.GiftCodeRepository,
+	notification *NotificationService,
+	invoice *InvoiceService,
+	xendit *xendit.APIClient,
+	xenditExt *xenditext.Client,
+) *GuardianSubscriptionService {
+	return &GuardianSubscriptionService{
+		config:            config,
+		guardian:          guardian,
+		student:           student,
+		subscriptionPrice: subscriptionPrice,
+		payment:           payment,
+		giftCode:          giftCode,
+		notification:      notification,
+		invoice:           invoice,
+		xendit:            xendit,
+		xenditExt:         xenditExt,
+	}
+}
+
+func (s *GuardianSubscriptionService) currentGuardian(ctx context.Context) (*model.Guardian, error) {
+	guardian, err := s.guardian.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if guardian == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "guardian")
+	}
+
+	return guardian, nil
+}
+
+// CreateFamily buys premium for linked students who accepted the guardian.
+// The payment is charged per student.
+func (s *GuardianSubscriptionService) CreateFamily(ctx context.Context, request dto.CreateFamilySubscriptionRequest) (dto.CreateStudentSubscriptionResponse, error) {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	links, err := s.guardian.GetLinks(ctx, model.GuardianStudentFilter{
+		GuardianID: guardian.ID,
+		Status:     model.GuardianStudentStatusActive,
+	})
+	if err != nil {
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	linked := make(map[uuid.UUID]model.Student, len(links))
+	for _, link := range links {
+		linked[link.StudentID] = link.Student
+	}
+
+	beneficiaries := make([]model.PaymentBeneficiary, 0, len(request.StudentIDs))
+	for _, id := range request.StudentIDs {
+		student, ok := linked[id]
+		if !ok {
+			return dto.CreateStudentSubscriptionResponse{}, shared.MakeError(ErrBadRequest, "student is not linked to the guardian")
+		}
+
+		if student.IsPremium() {
+			return dto.CreateStudentSubscriptionResponse{}, shared.MakeError(ErrBadRequest, "student already premium")
+		}
+
+		beneficiaries = append(beneficiaries, model.PaymentBeneficiary{StudentID: id})
+	}
+
+	payment, err := s.checkout(ctx, guardian, request.SubscriptionID, request.IntervalCount, len(beneficiaries))
+	if err != nil {
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	payment.Type = model.PaymentTypePremium
+	for i := range beneficiaries {
+		beneficiaries[i].PaymentID = payment.ID
+	}
+	payment.Beneficiaries = beneficiaries
+
+	err = s.payment.Create(ctx, payment)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateFamily] Error creating payment")
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	s.paymentCreated(guardian, *payment)
+
+	return dto.CreateStudentSubscriptionResponse{
+		URL: payment.URL,
+	}, nil
+}
+
+// CreateGift buys premium codes any student can redeem. The codes become
+// redeemable once the payment is paid.
+func (s *GuardianSubscriptionService) CreateGift(ctx context.Context, request dto.CreateGiftSubscriptionRequest) (dto.CreateStudentSubscriptionResponse, error) {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	payment, err := s.checkout(ctx, guardian, request.SubscriptionID, request.IntervalCount, request.Quantity)
+	if err != nil {
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	payment.Type = model.PaymentTypeGift
+
+	codes := make([]model.GiftCode, 0, request.Quantity)
+	for range request.Quantity {
+		code := model.GiftCode{
+			ID:         uuid.New(),
+			PaymentID:  payment.ID,
+			GuardianID: guardian.ID,
+			Status:     model.GiftCodeStatusPending,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+		code.GenerateCode()
+		codes = append(codes, code)
+	}
+
+	err = s.payment.CreateGift(ctx, payment, codes)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateGift] Error creating payment")
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	s.paymentCreated(guardian, *payment)
+
+	return dto.CreateStudentSubscriptionResponse{
+		URL: payment.URL,
+	}, nil
+}
+
+// checkout prices a guardian payment of seats premium periods and opens its
+// payment session. The payment is returned unsaved.
+func (s *GuardianSubscriptionService) checkout(ctx context.Context, guardian *model.Guardian, subscriptionID uuid.UUID, intervalCount, seats int) (*model.Payment, error) {
+	payments, err := s.payment.Get(ctx, model.PaymentFilter{
+		GuardianID: guardian.ID,
+		StatusIn:   []string{string(model.SubscriptionStatusPending)},
+		Pagination: model.Pagination{
+			PageSize: 1,
+		},
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[checkout] Error getting payments")
+		return nil, err
+	}
+
+	if len(payments) > 0 {
+		return nil, shared.MakeError(ErrStudentAlreadyHasPayment)
+	}
+
+	price, err := s.subscriptionPrice.GetByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.MakeError(ErrEntityNotFound, "subscription price")
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[checkout] Error getting subscription price")
+		return nil, err
+	}
+
+	if !guardian.CustomerID.Valid {
+		customer := *xenditcustomer.NewCustomerRequest(guardian.ID.String())
+		name := guardian.User.Name
+		if name == "" {
+			name = guardian.User.Email
+		}
+
+		customer.SetReferenceId(guardian.ID.String())
+		customer.SetIndividualDetail(xenditcustomer.IndividualDetail{
+			GivenNames: &name,
+		})
+		customer.SetType("INDIVIDUAL")
+		customer.SetEmail(guardian.User.Email)
+		if guardian.User.PhoneNumber != "" {
+			customer.SetPhoneNumber(guardian.User.PhoneNumber)
+		}
+
+		resp, r, e := s.xendit.CustomerApi.CreateCustomer(context.Background()).
+			IdempotencyKey(uuid.New().String()).
+			CustomerRequest(customer).
+			Execute()
+
+		if e != nil {
+			logger.ErrorCtx(ctx).Err(e).
+				Interface("fullError", e.FullError()).
+				Interface("resp", r).
+				Msg("[checkout] Error when calling CustomerApi.CreateCustomer")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+
+		guardian.CustomerID = null.StringFrom(resp.Id)
+
+		err = s.guardian.Update(ctx, guardian)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[checkout] Error updating guardian")
+			return nil, err
+		}
+	}
+
+	startDate := time.Now()
+	payment := &model.Payment{
+		ID:            uuid.New(),
+		GuardianID:    uuid.NullUUID{UUID: guardian.ID, Valid: true},
+		Interval:      price.Interval,
+		IntervalCount: intervalCount,
+		Seats:         seats,
+		StartDate:     startDate,
+		EndDate:       price.Interval.After(startDate, intervalCount),
+		Currency:      model.CurrencyIDR,
+		Amount:        price.Price.Mul(decimal.NewFromInt(int64(intervalCount * seats))),
+		Status:        model.SubscriptionStatusPending,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		CreatedBy:     guardian.UserID,
+		UpdatedBy:     guardian.UserID,
+	}
+
+	rate, err := s.invoice.GetTaxRate(ctx, payment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	payment.TaxAmount = decimal.NewNullDecimal(rate.Tax(payment.Amount))
+	payment.GenerateInvoiceNumber()
+
+	resp, err := s.xenditExt.CreatePaymentSession(ctx, xenditext.CreatePaymentSessionRequest{
+		ReferenceID:      payment.InvoiceNumber,
+		CustomerID:       guardian.CustomerID.String,
+		SessionType:      "PAY",
+		Currency:         payment.Currency,
+		Amount:           int(payment.Total().IntPart()),
+		Mode:             "PAYMENT_LINK",
+		Country:          "ID",
+		Locale:           "en",
+		Description:      "Les Private Subscription",
+		SuccessReturnURL: s.config.Frontend.BaseURL + s.config.Frontend.SubscriptionSuccess,
+		FailureReturnURL: s.config.Frontend.BaseURL + s.config.Frontend.SubscriptionFailure,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[checkout] Error when calling xenditExt.CreatePaymentSession")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	payment.URL = resp.PaymentLinkURL
+	payment.ReferenceID = resp.PaymentSessionID
+
+	return payment, nil
+}
+
+func (s *GuardianSubscriptionService) paymentCreated(guardian *model.Guardian, payment model.Payment) {
+	go func(user model.User) {
+		if err := s.notification.PaymentCreated(context.Background(), user, payment); err != nil {
+			logger.ErrorCtx(context.Background()).Err(err).Msg("[paymentCreated] Error sending payment created notification")
+		}
+	}(guardian.User)
+}
+
+// GetPayments returns the family and gift payments of the guardian.
+func (s *GuardianSubscriptionService) GetPayments(ctx context.Context, request dto.GetGuardianPaymentsRequest) ([]model.Payment, error) {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.payment.Get(ctx, model.PaymentFilter{
+		GuardianID: guardian.ID,
+		Pagination: request.Pagination,
+		Sort: model.Sort{
+			Sort:          "created_at",
+			SortDirection: "desc",
+		},
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetPayments] Error getting payments")
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// GetGiftCodes returns the gift codes bought by the guardian.
+func (s *GuardianSubscriptionService) GetGiftCodes(ctx context.Context, request dto.GetGiftCodesRequest) ([]model.GiftCode, model.Metadata, error) {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	return s.giftCode.Get(ctx, model.GiftCodeFilter{
+		GuardianID: guardian.ID,
+		Status:     request.Status,
+		Pagination: request.Pagination,
+	})
+}
+
+func (s *GuardianSubscriptionService) ownPayment(ctx context.Context, id uuid.UUID) (*model.Payment, error) {
+	guardian, err := s.currentGuardian(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.payment.GetByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.MakeError(ErrEntityNotFound, "payment")
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[ownPayment] Error getting payment")
+		return nil, err
+	}
+
+	if payment.GuardianID.UUID != guardian.ID {
+		return nil, shared.MakeError(ErrEntityNotFound, "payment")
+	}
+
+	return payment, nil
+}
+
+// CancelPayment cancels a pending payment of the guardian and its gift codes.
+func (s *GuardianSubscriptionService) CancelPayment(ctx context.Context, id uuid.UUID) error {
+	payment, err := s.ownPayment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if payment.Status != model.SubscriptionStatusPending {
+		return shared.MakeError(ErrEntityNotFound, "payment")
+	}
+
+	err = s.xenditExt.CancelPayment(ctx, payment.ReferenceID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CancelPayment] Error canceling payment")
+		return err
+	}
+
+	payment.Status = model.SubscriptionStatusCanceled
+	payment.UpdatedAt = time.Now()
+	payment.UpdatedBy = payment.Guardian.UserID
+	err = s.payment.Update(ctx, payment)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CancelPayment] Error updating payment")
+		return err
+	}
+
+	if payment.Type == model.PaymentTypeGift {
+		_, err = s.giftCode.UpdateStatusByPayment(ctx, payment.ID, model.GiftCodeStatusPending, model.GiftCodeStatusCanceled)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[CancelPayment] Error canceling gift codes")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateInvoice returns the invoice of a payment of the guardian.
+func (s *GuardianSubscriptionService) CreateInvoice(ctx context.Context, id uuid.UUID) ([]byte, string, error) {
+	payment, err := s.ownPayment(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.invoice.PaymentInvoice(ctx, *payment)
+}
+
+// RedeemGiftCode gives the signed in student the premium period of a gift
+// code. The period is added after the premium the student already has.
+func (s *GuardianSubscriptionService) RedeemGiftCode(ctx context.Context, request dto.RedeemGiftCodeRequest) (dto.RedeemGiftCodeResponse, error) {
+	student, err := s.student.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RedeemGiftCode] Error getting student")
+		return dto.RedeemGiftCodeResponse{}, err
+	}
+
+	if student == nil {
+		return dto.RedeemGiftCodeResponse{}, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	giftCode, err := s.giftCode.GetByCode(ctx, request.Code)
+	if err != nil {
+		return dto.RedeemGiftCodeResponse{}, err
+	}
+
+	if giftCode == nil {
+		return dto.RedeemGiftCodeResponse{}, shared.MakeError(ErrEntityNotFound, "gift code")
+	}
+
+	switch giftCode.Status {
+	case model.GiftCodeStatusActive:
+	case model.GiftCodeStatusPending:
+		return dto.RedeemGiftCodeResponse{}, shared.MakeError(ErrGiftCodeNotRedeemable, "pembayaran belum selesai")
+	case model.GiftCodeStatusRedeemed:
+		return dto.RedeemGiftCodeResponse{}, shared.MakeError(ErrGiftCodeNotRedeemable, "kode sudah digunakan")
+	default:
+		return dto.RedeemGiftCodeResponse{}, shared.MakeError(ErrGiftCodeNotRedeemable, "kode sudah dibatalkan")
+	}
+
+	now := time.Now()
+	start := now
+	if student.PremiumUntil.Valid && student.PremiumUntil.Time.After(now) {
+		start = student.PremiumUntil.Time
+	}
+
+	premiumUntil := giftCode.Payment.Interval.After(start, giftCode.Payment.IntervalCount)
+	student.PremiumUntil = null.TimeFrom(premiumUntil)
+	giftCode.RedeemedBy = uuid.NullUUID{UUID: student.ID, Valid: true}
+	giftCode.RedeemedAt = null.TimeFrom(now)
+
+	redeemed, err := s.giftCode.Redeem(ctx, giftCode, student)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RedeemGiftCode] Error redeeming gift code")
+		return dto.RedeemGiftCodeResponse{}, err
+	}
+
+	if !redeemed {
+		return dto.RedeemGiftCodeResponse{}, shared.MakeError(ErrGiftCodeNotRedeemable, "kode sudah digunakan")
+	}
+
+	return dto.RedeemGiftCodeResponse{
+		PremiumUntil: premiumUntil,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestGenerateGuardianToken(t *testing.T) {
+	first, err := generateGuardianToken()
+	if err != nil {
+		t.Fatalf("generateGuardianToken() error = %v", err)
+	}
+	second, err := generateGuardianToken()
+	if err != nil {
+		t.Fatalf("generateGuardianToken() error = %v", err)
+	}
+
+	if decoded, err := hex.DecodeString(first); err != nil || len(decoded) != 32 {
+		t.Errorf("generateGuardianToken() = %q, want 32 random bytes in hex", first)
+	}
+	if first == second {
+		t.Error("generateGuardianToken() returned the same token twice")
+	}
+}
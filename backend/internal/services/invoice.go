@@ -0,0 +1,391 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type InvoiceService struct {
+	config  *config.Config
+	invoice *repositories.InvoiceRepository
+	taxRate *repositories.TaxRateRepository
+	file    *FileService
+}
+
+func NewInvoiceService(
+	config *config.Config,
+	invoice *repositories.InvoiceRepository,
+	taxRate *repositories.TaxRateRepository,
+	file *FileService,
+) *InvoiceService {
+	return &InvoiceService{
+		config:  config,
+		invoice: invoice,
+		taxRate: taxRate,
+		file:    file,
+	}
+}
+
+// GetTaxRate returns the VAT rate in effect at the given time.
+func (s *InvoiceService) GetTaxRate(ctx context.Context, at time.Time) (*model.TaxRate, error) {
+	rate, err := s.taxRate.GetEffective(ctx, model.TaxNameVAT, at)
+	if err != nil {
+		return nil, err
+	}
+
+	if rate == nil {
+		logger.ErrorCtx(ctx).Msgf("[GetTaxRate] No tax rate in effect at %s", at)
+		return nil, shared.MakeError(ErrEntityNotFound, "tax rate")
+	}
+
+	return rate, nil
+}
+
+func (s *InvoiceService) GetTaxRates(ctx context.Context) ([]model.TaxRate, error) {
+	return s.taxRate.Get(ctx)
+}
+
+// CreateTaxRate schedules a new rate. Rates only take effect in the future so
+// prices already shown to students keep their tax.
+func (s *InvoiceService) CreateTaxRate(ctx context.Context, req dto.CreateTaxRateRequest) (*model.TaxRate, error) {
+	if req.EffectiveFrom.Before(time.Now()) {
+		return nil, shared.MakeError(ErrBadRequest, "effective date must be in the future")
+	}
+
+	rate := &model.TaxRate{
+		ID:              uuid.New(),
+		Name:            req.Name,
+		Rate:            req.Rate,
+		BaseNumerator:   req.BaseNumerator,
+		BaseDenominator: req.BaseDenominator,
+		EffectiveFrom:   req.EffectiveFrom,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		CreatedBy:       uuid.NullUUID{UUID: req.AdminID, Valid: true},
+		UpdatedBy:       uuid.NullUUID{UUID: req.AdminID, Valid: true},
+	}
+
+	err := s.taxRate.Create(ctx, rate)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateTaxRate] Error creating tax rate")
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// Issue issues the invoice of a paid payment with the next number of the
+// month and stores its PDF. Issuing twice returns the first invoice.
+func (s *InvoiceService) Issue(ctx context.Context, payment model.Payment) (*model.Invoice, error) {
+	invoice, err := s.invoice.GetByPaymentID(ctx, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice != nil {
+		return invoice, nil
+	}
+
+	rate, err := s.GetTaxRate(ctx, payment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	issuedAt := now
+	if payment.PaidAt.Valid {
+		issuedAt = payment.PaidAt.Time
+	}
+
+	invoice = &model.Invoice{
+		ID:              uuid.New(),
+		Period:          model.InvoicePeriod(issuedAt),
+		PaymentID:       payment.ID,
+		StudentID:       payment.StudentID,
+		GuardianID:      payment.GuardianID,
+		TaxRateID:       rate.ID,
+		Currency:        payment.Currency,
+		TaxName:         rate.Name,
+		TaxRate:         rate.Rate,
+		BaseNumerator:   rate.BaseNumerator,
+		BaseDenominator: rate.BaseDenominator,
+		Subtotal:        payment.Amount,
+		TaxBase:         rate.TaxBase(payment.Amount),
+		TaxAmount:       payment.VatAmount(),
+		IssuedAt:        issuedAt,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		Items:           []model.InvoiceItem{s.paymentItem(payment, *rate)},
+	}
+	invoice.Total = invoice.Subtotal.Add(invoice.TaxAmount)
+	invoice.SetBuyer(payment)
+
+	err = s.invoice.Create(ctx, invoice, s.config.Invoice.Prefix)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Issue] Error creating invoice")
+		return nil, err
+	}
+
+	// The invoice is issued even when the PDF can not be stored, Download
+	// renders it again from the stored invoice.
+	if _, err = s.store(ctx, invoice); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msgf("[Issue] Error storing pdf of invoice %s", invoice.Number)
+	}
+
+	return invoice, nil
+}
+
+func (s *InvoiceService) paymentItem(payment model.Payment, rate model.TaxRate) model.InvoiceItem {
+	item := model.InvoiceItem{
+		ID:          uuid.New(),
+		Description: "Les Private Booking Payment",
+		Quantity:    1,
+		UnitPrice:   payment.Amount,
+		Amount:      payment.Amount,
+		TaxBase:     rate.TaxBase(payment.Amount),
+		TaxAmount:   payment.VatAmount(),
+		CreatedAt:   time.Now(),
+	}
+
+	if payment.TutorID == uuid.Nil {
+		item.Description = "Les Private Premium Subscription"
+		if payment.GuardianID.Valid {
+			item.Description = "Les Private " + payment.Name()
+		}
+
+		// Gift codes start their period when they are redeemed.
+		if payment.Type != model.PaymentTypeGift {
+			item.PeriodStart = null.TimeFrom(payment.StartDate)
+			item.PeriodEnd = null.TimeFrom(payment.EndDate)
+		}
+
+		// Family and gift payments are charged per student or code.
+		if quantity := payment.IntervalCount * max(payment.Seats, 1); quantity > 0 {
+			item.Quantity = quantity
+			item.UnitPrice = payment.Amount.Div(decimal.NewFromInt(int64(quantity))).Round(2)
+		}
+	}
+
+	return item
+}
+
+func (s *InvoiceService) GetInvoices(ctx context.Context, req dto.GetAdminInvoicesRequest) ([]model.Invoice, model.Metadata, error) {
+	filter := model.InvoiceFilter{
+		Query:      req.Query,
+		Pagination: req.Pagination,
+	}
+
+	if req.IssuedAtFrom != "" {
+		from, err := time.Parse(time.DateOnly, req.IssuedAtFrom)
+		if err != nil {
+			return nil, model.Metadata{}, shared.MakeError(ErrBadRequest, "invalid issuedAtFrom format")
+		}
+		filter.IssuedAtFrom = from
+	}
+
+	if req.IssuedAtTo != "" {
+		to, err := time.Parse(time.DateOnly, req.IssuedAtTo)
+		if err != nil {
+			return nil, model.Metadata{}, shared.MakeError(ErrBadRequest, "invalid issuedAtTo format")
+		}
+		filter.IssuedAtTo = to.AddDate(0, 0, 1)
+	}
+
+	return s.invoice.Get(ctx, filter)
+}
+
+func (s *InvoiceService) GetInvoice(ctx context.Context, id uuid.UUID) (*model.Invoice, error) {
+	invoice, err := s.invoice.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "invoice")
+	}
+
+	return invoice, nil
+}
+
+// Download returns the stored PDF of an invoice. Invoices whose PDF was not
+// stored are rendered from the issued data and stored.
+func (s *InvoiceService) Download(ctx context.Context, invoice model.Invoice) ([]byte, string, error) {
+	filename := fmt.Sprintf("%s.pdf", invoice.Number)
+	if invoice.FileKey.Valid {
+		content, err := s.file.GetFile(ctx, invoice.FileKey.String)
+		if err == nil {
+			return content, filename, nil
+		}
+
+		logger.WarnCtx(ctx).Err(err).Msgf("[Download] Rendering invoice %s again", invoice.Number)
+	}
+
+	content, err := s.store(ctx, &invoice)
+	if content == nil {
+		return nil, "", err
+	}
+
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msgf("[Download] Error storing pdf of invoice %s", invoice.Number)
+	}
+
+	return content, filename, nil
+}
+
+// PaymentInvoice returns the issued invoice of the payment, or a proforma
+// rendered from the payment while it is not paid.
+func (s *InvoiceService) PaymentInvoice(ctx context.Context, payment model.Payment) ([]byte, string, error) {
+	invoice, err := s.invoice.GetByPaymentID(ctx, payment.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if invoice != nil {
+		return s.Download(ctx, *invoice)
+	}
+
+	rate, err := s.GetTaxRate(ctx, payment.CreatedAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	item := s.paymentItem(payment, *rate)
+	proforma := model.Invoice{
+		Number:          payment.InvoiceNumber,
+		TaxName:         rate.Name,
+		TaxRate:         rate.Rate,
+		BaseNumerator:   rate.BaseNumerator,
+		BaseDenominator: rate.BaseDenominator,
+		TaxAmount:       payment.VatAmount(),
+		Total:           payment.Total(),
+		IssuedAt:        time.Now(),
+		Items:           []model.InvoiceItem{item},
+	}
+	proforma.SetBuyer(payment)
+
+	data := s.invoiceData(proforma)
+	data.Status = payment.Status.InvoiceLabel()
+
+	content, err := s.render(ctx, data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return content, fmt.Sprintf("%s.pdf", payment.InvoiceNumber), nil
+}
+
+func (s *InvoiceService) store(ctx context.Context, invoice *model.Invoice) ([]byte, error) {
+	content, err := s.render(ctx, s.invoiceData(*invoice))
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("invoices/%s/%s.pdf", invoice.Period, invoice.Number)
+	err = s.file.PutPrivateFile(ctx, key, content)
+	if err != nil {
+		return content, err
+	}
+
+	err = s.invoice.UpdateFileKey(ctx, invoice.ID, key)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[store] Error updating invoice file key")
+		return content, err
+	}
+
+	invoice.FileKey = null.StringFrom(key)
+	return content, nil
+}
+
+func (s *InvoiceService) invoiceData(invoice model.Invoice) dto.InvoiceData {
+	ac := model.MustCurrency(invoice.Currency)
+
+	data := dto.InvoiceData{
+		InvoiceNumber:   invoice.Number,
+		InvoiceDate:     invoice.IssuedAt.Format("02/01/2006"),
+		CustomerName:    invoice.BuyerName,
+		CustomerEmail:   invoice.BuyerEmail,
+		CustomerTaxID:   invoice.BuyerTaxID.String,
+		CustomerAddress: invoice.BuyerAddress.String,
+		SellerName:      s.config.Invoice.SellerName,
+		SellerTaxID:     s.config.Invoice.SellerTaxID,
+		Status:          model.SubscriptionStatusActive.InvoiceLabel(),
+		VATLabel:        invoice.TaxRateSnapshot().Label(),
+		VATAmount:       ac.Format(invoice.TaxAmount),
+		TotalPrice:      ac.Format(invoice.Total),
+	}
+
+	for _, item := range invoice.Items {
+		itemData := dto.InvoiceItemData{
+			Description: item.Description,
+			Price:       ac.Format(item.Amount),
+		}
+		if item.PeriodStart.Valid && item.PeriodEnd.Valid {
+			itemData.StartDate = item.PeriodStart.Time.Format("02/01/2006")
+			itemData.EndDate = item.PeriodEnd.Time.Format("02/01/2006")
+		}
+
+		data.Items = append(data.Items, itemData)
+	}
+
+	if data.SellerName == "" {
+		data.SellerName = "LesPrivate.id"
+	}
+
+	return data
+}
+
+func (s *InvoiceService) render(ctx context.Context, data dto.InvoiceData) ([]byte, error) {
+	tmpl, err := template.ParseFiles("./templates/pdf/invoice/index.html")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[render] failed to parse template")
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[render] failed to execute template")
+		return nil, err
+	}
+
+	pdfg, err := wkhtmltopdf.NewPDFGenerator()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[render] failed to create pdf generator")
+		return nil, err
+	}
+
+	page := wkhtmltopdf.NewPageReader(bytes.NewReader(buf.Bytes()))
+	pdfg.AddPage(page)
+
+	pdfg.PageSize.Set(wkhtmltopdf.PageSizeA4)
+	pdfg.MarginTop.Set(10)
+	pdfg.MarginBottom.Set(10)
+	pdfg.MarginLeft.Set(10)
+	pdfg.MarginRight.Set(10)
+	pdfg.Dpi.Set(300)
+	pdfg.Orientation.Set(wkhtmltopdf.OrientationPortrait)
+	pdfg.Grayscale.Set(false)
+
+	err = pdfg.Create()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[render] failed to create pdf")
+		return nil, err
+	}
+
+	return pdfg.Bytes(), nil
+}
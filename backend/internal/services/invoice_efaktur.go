@@ -0,0 +1,234 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// Buyers without an NPWP are reported with a zero NPWP.
+const (
+	eFakturEmptyTaxID  = "000000000000000"
+	coretaxEmptyTaxID  = "0000000000000000"
+	coretaxEmptyBranch = "000000"
+)
+
+var (
+	eFakturFKHeader = []string{"FK", "KD_JENIS_TRANSAKSI", "FG_PENGGANTI", "NOMOR_FAKTUR", "MASA_PAJAK", "TAHUN_PAJAK", "TANGGAL_FAKTUR", "NPWP", "NAMA", "ALAMAT_LENGKAP", "JUMLAH_DPP", "JUMLAH_PPN", "JUMLAH_PPNBM", "ID_KETERANGAN_TAMBAHAN", "FG_UANG_MUKA", "UANG_MUKA_DPP", "UANG_MUKA_PPN", "UANG_MUKA_PPNBM", "REFERENSI", "KODE_DOKUMEN_PENDUKUNG"}
+	eFakturLTHeader = []string{"LT", "NPWP", "NAMA", "JALAN", "BLOK", "NOMOR", "RT", "RW", "KECAMATAN", "KELURAHAN", "KABUPATEN", "PROPINSI", "KODE_POS", "NOMOR_TELEPON"}
+	eFakturOFHeader = []string{"OF", "KODE_OBJEK", "NAMA", "HARGA_SATUAN", "JUMLAH_BARANG", "HARGA_TOTAL", "DISKON", "DPP", "PPN", "TARIF_PPNBM", "PPNBM"}
+)
+
+// ExportEFaktur exports the invoices issued in the requested dates in the
+// e-Faktur CSV import layout, or the Coretax XML import layout.
+func (s *InvoiceService) ExportEFaktur(ctx context.Context, req dto.ExportEFakturRequest) ([]byte, string, error) {
+	invoices, _, err := s.GetInvoices(ctx, dto.GetAdminInvoicesRequest{
+		IssuedAtFrom: req.From,
+		IssuedAtTo:   req.To,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := fmt.Sprintf("e-faktur_%s_%s.%s", req.From, req.To, req.Format)
+	if req.Format == dto.EFakturFormatXML {
+		content, err := s.coretaxXML(invoices)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[ExportEFaktur] Error writing xml")
+			return nil, "", err
+		}
+
+		return content, filename, nil
+	}
+
+	content, err := s.eFakturCSV(invoices)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ExportEFaktur] Error writing csv")
+		return nil, "", err
+	}
+
+	return content, filename, nil
+}
+
+func (s *InvoiceService) eFakturCSV(invoices []model.Invoice) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{eFakturFKHeader, eFakturLTHeader, eFakturOFHeader}
+	for _, invoice := range invoices {
+		taxID := invoice.BuyerTaxID.ValueOr(eFakturEmptyTaxID)
+		rows = append(rows, []string{
+			"FK",
+			transactionCode(invoice),
+			"0",
+			"",
+			strconv.Itoa(int(invoice.IssuedAt.Month())),
+			strconv.Itoa(invoice.IssuedAt.Year()),
+			invoice.IssuedAt.Format("02/01/2006"),
+			taxID,
+			invoice.BuyerName,
+			invoice.BuyerAddress.ValueOr("-"),
+			rupiah(invoice.TaxBase),
+			rupiah(invoice.TaxAmount),
+			"0",
+			"",
+			"0",
+			"0",
+			"0",
+			"0",
+			invoice.Number,
+			"",
+		})
+
+		for _, item := range invoice.Items {
+			rows = append(rows, []string{
+				"OF",
+				s.config.Invoice.ServiceCode,
+				item.Description,
+				rupiah(item.UnitPrice),
+				strconv.Itoa(item.Quantity),
+				rupiah(item.Amount),
+				"0",
+				rupiah(item.TaxBase),
+				rupiah(item.TaxAmount),
+				"0",
+				"0",
+			})
+		}
+	}
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type coretaxBulk struct {
+	XMLName  xml.Name         `xml:"TaxInvoiceBulk"`
+	XSI      string           `xml:"xmlns:xsi,attr"`
+	Schema   string           `xml:"xsi:noNamespaceSchemaLocation,attr"`
+	TIN      string           `xml:"TIN"`
+	Invoices []coretaxInvoice `xml:"ListOfTaxInvoice>TaxInvoice"`
+}
+
+type coretaxInvoice struct {
+	TaxInvoiceDate      string           `xml:"TaxInvoiceDate"`
+	TaxInvoiceOpt       string           `xml:"TaxInvoiceOpt"`
+	TrxCode             string           `xml:"TrxCode"`
+	AddInfo             string           `xml:"AddInfo"`
+	CustomDoc           string           `xml:"CustomDoc"`
+	RefDesc             string           `xml:"RefDesc"`
+	FacilityStamp       string           `xml:"FacilityStamp"`
+	SellerIDTKU         string           `xml:"SellerIDTKU"`
+	BuyerTin            string           `xml:"BuyerTin"`
+	BuyerDocument       string           `xml:"BuyerDocument"`
+	BuyerCountry        string           `xml:"BuyerCountry"`
+	BuyerDocumentNumber string           `xml:"BuyerDocumentNumber"`
+	BuyerName           string           `xml:"BuyerName"`
+	BuyerAdress         string           `xml:"BuyerAdress"`
+	BuyerEmail          string           `xml:"BuyerEmail"`
+	BuyerIDTKU          string           `xml:"BuyerIDTKU"`
+	GoodServices        []coretaxService `xml:"ListOfGoodService>GoodService"`
+}
+
+type coretaxService struct {
+	Opt           string `xml:"Opt"`
+	Code          string `xml:"Code"`
+	Name          string `xml:"Name"`
+	Unit          string `xml:"Unit"`
+	Price         string `xml:"Price"`
+	Qty           int    `xml:"Qty"`
+	TotalDiscount string `xml:"TotalDiscount"`
+	TaxBase       string `xml:"TaxBase"`
+	OtherTaxBase  string `xml:"OtherTaxBase"`
+	VATRate       string `xml:"VATRate"`
+	VAT           string `xml:"VAT"`
+	STLGRate      string `xml:"STLGRate"`
+	STLG          string `xml:"STLG"`
+}
+
+func (s *InvoiceService) coretaxXML(invoices []model.Invoice) ([]byte, error) {
+	bulk := coretaxBulk{
+		XSI:    "http://www.w3.org/2001/XMLSchema-instance",
+		Schema: "TaxInvoice.xsd",
+		TIN:    s.config.Invoice.SellerTaxID,
+	}
+
+	for _, invoice := range invoices {
+		taxInvoice := coretaxInvoice{
+			TaxInvoiceDate: invoice.IssuedAt.Format(time.DateOnly),
+			TaxInvoiceOpt:  "Normal",
+			TrxCode:        transactionCode(invoice),
+			RefDesc:        invoice.Number,
+			SellerIDTKU:    s.config.Invoice.SellerTaxID + coretaxEmptyBranch,
+			BuyerTin:       coretaxEmptyTaxID,
+			BuyerDocument:  "Other",
+			BuyerCountry:   "IDN",
+			BuyerName:      invoice.BuyerName,
+			BuyerAdress:    invoice.BuyerAddress.ValueOr("-"),
+			BuyerEmail:     invoice.BuyerEmail,
+			BuyerIDTKU:     coretaxEmptyBranch,
+		}
+
+		if invoice.BuyerTaxID.Valid {
+			taxInvoice.BuyerTin = invoice.BuyerTaxID.String
+			taxInvoice.BuyerDocument = "TIN"
+			taxInvoice.BuyerIDTKU = invoice.BuyerTaxID.String + coretaxEmptyBranch
+		} else {
+			taxInvoice.BuyerDocumentNumber = invoice.Number
+		}
+
+		for _, item := range invoice.Items {
+			taxInvoice.GoodServices = append(taxInvoice.GoodServices, coretaxService{
+				Opt:           "B",
+				Code:          s.config.Invoice.ServiceCode,
+				Name:          item.Description,
+				Unit:          s.config.Invoice.UnitCode,
+				Price:         item.UnitPrice.StringFixed(2),
+				Qty:           item.Quantity,
+				TotalDiscount: "0",
+				TaxBase:       item.Amount.StringFixed(2),
+				OtherTaxBase:  item.TaxBase.StringFixed(2),
+				VATRate:       invoice.TaxRateSnapshot().Percent().String(),
+				VAT:           item.TaxAmount.StringFixed(2),
+				STLGRate:      "0",
+				STLG:          "0",
+			})
+		}
+
+		bulk.Invoices = append(bulk.Invoices, taxInvoice)
+	}
+
+	content, err := xml.MarshalIndent(bulk, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), content...), nil
+}
+
+// transactionCode is 04 when the tax is charged on another tax base (DPP
+// nilai lain), 01 otherwise.
+func transactionCode(invoice model.Invoice) string {
+	if invoice.BaseNumerator != invoice.BaseDenominator {
+		return "04"
+	}
+
+	return "01"
+}
+
+// rupiah formats an amount in whole rupiah as e-Faktur expects.
+func rupiah(amount decimal.Decimal) string {
+	return amount.Floor().String()
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func newEFakturInvoiceService() *InvoiceService {
+	cfg := &config.Config{}
+	cfg.Invoice.SellerTaxID = "0123456789012345"
+	cfg.Invoice.ServiceCode = "090000"
+	cfg.Invoice.UnitCode = "UM.0030"
+
+	return &InvoiceService{config: cfg}
+}
+
+func eFakturInvoices() []model.Invoice {
+	issuedAt := time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+	return []model.Invoice{
+		{
+			Number:          "INV-202603-00001",
+			BuyerName:       "Budi",
+			BuyerEmail:      "budi@example.com",
+			BuyerTaxID:      null.StringFrom("0987654321098765"),
+			BuyerAddress:    null.StringFrom("Jl. Merdeka 1"),
+			TaxRate:         decimal.RequireFromString("0.12"),
+			BaseNumerator:   11,
+			BaseDenominator: 12,
+			TaxBase:         decimal.RequireFromString("91666.67"),
+			TaxAmount:       decimal.RequireFromString("11000.00"),
+			IssuedAt:        issuedAt,
+			Items: []model.InvoiceItem{
+				{
+					Description: "Les Private Premium Subscription",
+					Quantity:    1,
+					UnitPrice:   decimal.NewFromInt(100000),
+					Amount:      decimal.NewFromInt(100000),
+					TaxBase:     decimal.RequireFromString("91666.67"),
+					TaxAmount:   decimal.RequireFromString("11000.00"),
+				},
+			},
+		},
+		{
+			Number:          "INV-202603-00002",
+			BuyerName:       "Siti",
+			BuyerEmail:      "siti@example.com",
+			TaxRate:         decimal.RequireFromString("0.11"),
+			BaseNumerator:   1,
+			BaseDenominator: 1,
+			TaxBase:         decimal.NewFromInt(50000),
+			TaxAmount:       decimal.NewFromInt(5500),
+			IssuedAt:        issuedAt,
+			Items: []model.InvoiceItem{
+				{
+					Description: "Les Private Booking Payment",
+					Quantity:    1,
+					UnitPrice:   decimal.NewFromInt(50000),
+					Amount:      decimal.NewFromInt(50000),
+					TaxBase:     decimal.NewFromInt(50000),
+					TaxAmount:   decimal.NewFromInt(5500),
+				},
+			},
+		},
+	}
+}
+
+func TestEFakturCSV(t *testing.T) {
+	content, err := newEFakturInvoiceService().eFakturCSV(eFakturInvoices())
+	if err != nil {
+		t.Fatalf("eFakturCSV() error = %v", err)
+	}
+
+	// FK, LT and OF rows have their own number of columns.
+	r := csv.NewReader(strings.NewReader(string(content)))
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("reading csv: %v", err)
+	}
+
+	want := [][]string{
+		eFakturFKHeader,
+		eFakturLTHeader,
+		eFakturOFHeader,
+		{"FK", "04", "0", "", "3", "2026", "05/03/2026", "0987654321098765", "Budi", "Jl. Merdeka 1", "91666", "11000", "0", "", "0", "0", "0", "0", "INV-202603-00001", ""},
+		{"OF", "090000", "Les Private Premium Subscription", "100000", "1", "100000", "0", "91666", "11000", "0", "0"},
+		{"FK", "01", "0", "", "3", "2026", "05/03/2026", "000000000000000", "Siti", "-", "50000", "5500", "0", "", "0", "0", "0", "0", "INV-202603-00002", ""},
+		{"OF", "090000", "Les Private Booking Payment", "50000", "1", "50000", "0", "50000", "5500", "0", "0"},
+	}
+
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+
+	for i := range want {
+		if !reflect.DeepEqual(rows[i], want[i]) {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestCoretaxXML(t *testing.T) {
+	content, err := newEFakturInvoiceService().coretaxXML(eFakturInvoices())
+	if err != nil {
+		t.Fatalf("coretaxXML() error = %v", err)
+	}
+
+	if !strings.HasPrefix(string(content), xml.Header) {
+		t.Errorf("content does not start with the xml header")
+	}
+
+	var bulk coretaxBulk
+	if err := xml.Unmarshal(content, &bulk); err != nil {
+		t.Fatalf("reading xml: %v", err)
+	}
+
+	if bulk.TIN != "0123456789012345" || len(bulk.Invoices) != 2 {
+		t.Fatalf("bulk = %+v, want 2 invoices of the seller", bulk)
+	}
+
+	tests := []struct {
+		name    string
+		got     coretaxInvoice
+		want    coretaxInvoice
+		service coretaxService
+	}{
+		{
+			name: "buyer with NPWP",
+			got:  bulk.Invoices[0],
+			want: coretaxInvoice{
+				TaxInvoiceDate: "2026-03-05",
+				TaxInvoiceOpt:  "Normal",
+				TrxCode:        "04",
+				RefDesc:        "INV-202603-00001",
+				SellerIDTKU:    "0123456789012345000000",
+				BuyerTin:       "0987654321098765",
+				BuyerDocument:  "TIN",
+				BuyerCountry:   "IDN",
+				BuyerName:      "Budi",
+				BuyerAdress:    "Jl. Merdeka 1",
+				BuyerEmail:     "budi@example.com",
+				BuyerIDTKU:     "0987654321098765000000",
+			},
+			service: coretaxService{
+				Opt: "B", Code: "090000", Name: "Les Private Premium Subscription", Unit: "UM.0030",
+				Price: "100000.00", Qty: 1, TotalDiscount: "0", TaxBase: "100000.00", OtherTaxBase: "91666.67",
+				VATRate: "12", VAT: "11000.00", STLGRate: "0", STLG: "0",
+			},
+		},
+		{
+			name: "buyer without NPWP",
+			got:  bulk.Invoices[1],
+			want: coretaxInvoice{
+				TaxInvoiceDate:      "2026-03-05",
+				TaxInvoiceOpt:       "Normal",
+				TrxCode:             "01",
+				RefDesc:             "INV-202603-00002",
+				SellerIDTKU:         "0123456789012345000000",
+				BuyerTin:            "0000000000000000",
+				BuyerDocument:       "Other",
+				BuyerCountry:        "IDN",
+				BuyerDocumentNumber: "INV-202603-00002",
+				BuyerName:           "Siti",
+				BuyerAdress:         "-",
+				BuyerEmail:          "siti@example.com",
+				BuyerIDTKU:          "000000",
+			},
+			service: coretaxService{
+				Opt: "B", Code: "090000", Name: "Les Private Booking Payment", Unit: "UM.0030",
+				Price: "50000.00", Qty: 1, TotalDiscount: "0", TaxBase: "50000.00", OtherTaxBase: "50000.00",
+				VATRate: "11", VAT: "5500.00", STLGRate: "0", STLG: "0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := tt.got.GoodServices
+			tt.got.GoodServices = nil
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("invoice = %+v, want %+v", tt.got, tt.want)
+			}
+			if len(services) != 1 || services[0] != tt.service {
+				t.Errorf("services = %+v, want %+v", services, tt.service)
+			}
+		})
+	}
+}
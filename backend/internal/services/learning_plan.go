@@ -0,0 +1,465 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+// LearningPlanService lets mentors set the learning plan of their students
+// per subject, move its milestones along and write a lesson note after each
+// session. Students and their guardians read the plans and notes.
+type LearningPlanService struct {
+	learningPlan   *repositories.LearningPlanRepository
+	booking        *repositories.BookingRepository
+	student        *repositories.StudentRepository
+	tutor          *repositories.TutorRepository
+	mentorStudent  *repositories.MentorStudentRepository
+	courseCategory *repositories.CourseCategoryRepository
+	notification   *NotificationService
+}
+
+func NewLearningPlanService(
+	learningPlan *repositories.LearningPlanRepository,
+	booking *repositories.BookingRepository,
+	student *repositories.StudentRepository,
+	tutor *repositories.TutorRepository,
+	mentorStudent *repositories.MentorStudentRepository,
+	courseCategory *repositories.CourseCategoryRepository,
+	notification *NotificationService,
+) *LearningPlanService {
+	return &LearningPlanService{
+		learningPlan:   learningPlan,
+		booking:        booking,
+		student:        student,
+		tutor:          tutor,
+		mentorStudent:  mentorStudent,
+		courseCategory: courseCategory,
+		notification:   notification,
+	}
+}
+
+func (s *LearningPlanService) currentTutor(ctx context.Context) (*model.Tutor, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[LearningPlanService] Error getting tutor")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	return tutor, nil
+}
+
+// mentoredStudent returns the current tutor when they mentor the student
+func (s *LearningPlanService) mentoredStudent(ctx context.Context, studentID uuid.UUID) (*model.Tutor, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.mentorStudent.GetByTutorAndStudent(ctx, tutor.ID, studentID)
+	if err != nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	return tutor, nil
+}
+
+// ownPlan returns the plan when it belongs to the current tutor
+func (s *LearningPlanService) ownPlan(ctx context.Context, id uuid.UUID) (*model.LearningPlan, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.learningPlan.GetByID(ctx, id)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if plan == nil || plan.TutorID != tutor.ID {
+		return nil, shared.MakeError(ErrEntityNotFound, "learning plan")
+	}
+
+	return plan, nil
+}
+
+// GetMentorPlans returns the plans of a student of the current mentor with
+// the mentor.
+func (s *LearningPlanService) GetMentorPlans(ctx context.Context, studentID uuid.UUID) ([]dto.LearningPlanResponse, error) {
+	tutor, err := s.mentoredStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := s.learningPlan.Get(ctx, model.LearningPlanFilter{
+		StudentID: studentID,
+		TutorID:   tutor.ID,
+	})
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewLearningPlanResponses(plans), nil
+}
+
+// CreatePlan sets the plan of a mentored student in a subject, a student
+// having one plan per subject with each tutor.
+func (s *LearningPlanService) CreatePlan(ctx context.Context, studentID uuid.UUID, request dto.LearningPlanRequest) (*dto.LearningPlanResponse, error) {
+	tutor, err := s.mentoredStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.courseCategory.GetByID(ctx, request.CourseCategoryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.MakeError(ErrEntityNotFound, "course category")
+		}
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	existing, err := s.learningPlan.Get(ctx, model.LearningPlanFilter{
+		StudentID:        studentID,
+		TutorID:          tutor.ID,
+		CourseCategoryID: request.CourseCategoryID,
+	})
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if len(existing) > 0 {
+		return nil, shared.MakeError(ErrBadRequest, "the student already has a learning plan in this subject")
+	}
+
+	now := time.Now()
+	plan := model.LearningPlan{
+		ID:               uuid.New(),
+		StudentID:        studentID,
+		TutorID:          tutor.ID,
+		CourseCategoryID: request.CourseCategoryID,
+		Title:            request.Title,
+		Description:      null.StringFromPtr(request.Description),
+		Status:           model.LearningPlanStatusActive,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	for i, milestoneRequest := range request.Milestones {
+		milestone := model.LearningPlanMilestone{
+			ID:          uuid.New(),
+			PlanID:      plan.ID,
+			Title:       milestoneRequest.Title,
+			Description: null.StringFromPtr(milestoneRequest.Description),
+			TargetDate:  milestoneRequest.GetTargetDate(),
+			Status:      model.MilestoneStatusNotStarted,
+			Position:    i,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		milestone.SetStatus(milestoneRequest.Status, now)
+		plan.Milestones = append(plan.Milestones, milestone)
+	}
+
+	err = s.learningPlan.Create(ctx, &plan)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return s.planResponse(ctx, plan.ID)
+}
+
+func (s *LearningPlanService) UpdatePlan(ctx context.Context, id uuid.UUID, request dto.UpdateLearningPlanRequest) (*dto.LearningPlanResponse, error) {
+	plan, err := s.ownPlan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Title = request.Title
+	plan.Description = null.StringFromPtr(request.Description)
+	plan.Status = request.Status
+	plan.UpdatedAt = time.Now()
+
+	err = s.learningPlan.Update(ctx, plan)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewLearningPlanResponse(*plan)
+	return &res, nil
+}
+
+// AddMilestone adds a milestone to the plan, last unless a position is set.
+func (s *LearningPlanService) AddMilestone(ctx context.Context, planID uuid.UUID, request dto.LearningPlanMilestoneRequest) (*dto.LearningPlanResponse, error) {
+	plan, err := s.ownPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	milestone := model.LearningPlanMilestone{
+		ID:          uuid.New(),
+		PlanID:      plan.ID,
+		Title:       request.Title,
+		Description: null.StringFromPtr(request.Description),
+		TargetDate:  request.GetTargetDate(),
+		Status:      model.MilestoneStatusNotStarted,
+		Position:    len(plan.Milestones),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if request.Position != nil {
+		milestone.Position = *request.Position
+	}
+	milestone.SetStatus(request.Status, now)
+
+	err = s.learningPlan.CreateMilestone(ctx, &milestone)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if milestone.Status == model.MilestoneStatusAchieved {
+		_ = s.notification.MilestoneAchieved(ctx, *plan, milestone)
+	}
+
+	return s.planResponse(ctx, plan.ID)
+}
+
+// UpdateMilestone changes the milestone, telling the student once it is
+// achieved.
+func (s *LearningPlanService) UpdateMilestone(ctx context.Context, planID, milestoneID uuid.UUID, request dto.LearningPlanMilestoneRequest) (*dto.LearningPlanResponse, error) {
+	plan, err := s.ownPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone := plan.Milestone(milestoneID)
+	if milestone == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "milestone")
+	}
+
+	now := time.Now()
+	achieved := milestone.Status != model.MilestoneStatusAchieved && request.Status == model.MilestoneStatusAchieved
+
+	milestone.Title = request.Title
+	milestone.Description = null.StringFromPtr(request.Description)
+	milestone.TargetDate = request.GetTargetDate()
+	if request.Position != nil {
+		milestone.Position = *request.Position
+	}
+	milestone.SetStatus(request.Status, now)
+	milestone.UpdatedAt = now
+
+	err = s.learningPlan.UpdateMilestone(ctx, milestone)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if achieved {
+		_ = s.notification.MilestoneAchieved(ctx, *plan, *milestone)
+	}
+
+	return s.planResponse(ctx, plan.ID)
+}
+
+func (s *LearningPlanService) DeleteMilestone(ctx context.Context, planID, milestoneID uuid.UUID) error {
+	plan, err := s.ownPlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+
+	if plan.Milestone(milestoneID) == nil {
+		return shared.MakeError(ErrEntityNotFound, "milestone")
+	}
+
+	err = s.learningPlan.DeleteMilestone(ctx, milestoneID)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+// GetMentorNotes returns the lesson notes of a plan of the current tutor.
+func (s *LearningPlanService) GetMentorNotes(ctx context.Context, planID uuid.UUID, request dto.GetLessonNotesRequest) ([]dto.LessonNoteResponse, model.Metadata, error) {
+	plan, err := s.ownPlan(ctx, planID)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	return s.notes(ctx, plan.ID, request)
+}
+
+// SaveLessonNote writes the lesson note of a session of the current tutor
+// once it started, replacing the note written before.
+func (s *LearningPlanService) SaveLessonNote(ctx context.Context, bookingID uuid.UUID, request dto.LessonNoteRequest) (*dto.LessonNoteResponse, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.booking.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if booking == nil || booking.TutorID != tutor.ID {
+		return nil, shared.MakeError(ErrEntityNotFound, "booking")
+	}
+
+	status := booking.GetStatus()
+	if status != model.BookingStatusAccepted && status != model.BookingStatusCompleted {
+		return nil, shared.MakeError(ErrBadRequest, "lesson notes can only be written for accepted sessions")
+	}
+	if booking.StartsAt().After(time.Now()) {
+		return nil, shared.MakeError(ErrBadRequest, "lesson notes can only be written once the session started")
+	}
+
+	var milestones []model.LearningPlanMilestone
+	if request.PlanID.Valid {
+		plan, err := s.learningPlan.GetByID(ctx, request.PlanID.UUID)
+		if err != nil {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+		if plan == nil || plan.TutorID != tutor.ID || plan.StudentID != booking.StudentID {
+			return nil, shared.MakeError(ErrEntityNotFound, "learning plan")
+		}
+
+		for _, milestoneID := range request.MilestoneIDs {
+			milestone := plan.Milestone(milestoneID)
+			if milestone == nil {
+				return nil, shared.MakeError(ErrBadRequest, "milestone "+milestoneID.String()+" is not in the learning plan")
+			}
+			if !slices.ContainsFunc(milestones, func(m model.LearningPlanMilestone) bool { return m.ID == milestoneID }) {
+				milestones = append(milestones, *milestone)
+			}
+		}
+	}
+
+	existing, err := s.learningPlan.GetNoteByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	now := time.Now()
+	note := model.LessonNote{
+		ID:           uuid.New(),
+		BookingID:    booking.ID,
+		PlanID:       request.PlanID,
+		TutorID:      tutor.ID,
+		StudentID:    booking.StudentID,
+		Covered:      request.Covered,
+		Strengths:    null.StringFromPtr(request.Strengths),
+		Improvements: null.StringFromPtr(request.Improvements),
+		NextSteps:    null.StringFromPtr(request.NextSteps),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Milestones:   milestones,
+	}
+	if existing != nil {
+		note.ID = existing.ID
+		note.CreatedAt = existing.CreatedAt
+	}
+
+	err = s.learningPlan.SaveNote(ctx, &note)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if existing == nil {
+		_ = s.notification.LessonNoteAdded(ctx, *booking)
+	}
+
+	note.Booking = booking
+	res := dto.NewLessonNoteResponse(note)
+	return &res, nil
+}
+
+// GetStudentPlans returns the plans of the current student with all their
+// tutors.
+func (s *LearningPlanService) GetStudentPlans(ctx context.Context) ([]dto.LearningPlanResponse, error) {
+	student, err := s.currentStudent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.StudentPlans(ctx, student.ID)
+}
+
+// GetStudentNotes returns the lesson notes of a plan of the current student.
+func (s *LearningPlanService) GetStudentNotes(ctx context.Context, planID uuid.UUID, request dto.GetLessonNotesRequest) ([]dto.LessonNoteResponse, model.Metadata, error) {
+	student, err := s.currentStudent(ctx)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	return s.StudentNotes(ctx, student.ID, planID, request)
+}
+
+// StudentPlans returns the plans of the student with all their tutors.
+func (s *LearningPlanService) StudentPlans(ctx context.Context, studentID uuid.UUID) ([]dto.LearningPlanResponse, error) {
+	plans, err := s.learningPlan.Get(ctx, model.LearningPlanFilter{StudentID: studentID})
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewLearningPlanResponses(plans), nil
+}
+
+// StudentNotes returns the lesson notes of a plan of the student.
+func (s *LearningPlanService) StudentNotes(ctx context.Context, studentID, planID uuid.UUID, request dto.GetLessonNotesRequest) ([]dto.LessonNoteResponse, model.Metadata, error) {
+	plan, err := s.learningPlan.GetByID(ctx, planID)
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+	if plan == nil || plan.StudentID != studentID {
+		return nil, model.Metadata{}, shared.MakeError(ErrEntityNotFound, "learning plan")
+	}
+
+	return s.notes(ctx, plan.ID, request)
+}
+
+func (s *LearningPlanService) currentStudent(ctx context.Context) (*model.Student, error) {
+	student, err := s.student.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if student == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	return student, nil
+}
+
+func (s *LearningPlanService) notes(ctx context.Context, planID uuid.UUID, request dto.GetLessonNotesRequest) ([]dto.LessonNoteResponse, model.Metadata, error) {
+	notes, metadata, err := s.learningPlan.GetNotes(ctx, model.LessonNoteFilter{
+		PlanID:     planID,
+		Pagination: request.Pagination,
+	})
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewLessonNoteResponses(notes), metadata, nil
+}
+
+func (s *LearningPlanService) planResponse(ctx context.Context, id uuid.UUID) (*dto.LearningPlanResponse, error) {
+	plan, err := s.learningPlan.GetByID(ctx, id)
+	if err != nil || plan == nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewLearningPlanResponse(*plan)
+	return &res, nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/external/meeting"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// MeetingService provisions the video classrooms of online sessions and
+// closes them once the session is over.
+type MeetingService struct {
+	provider meeting.Provider
+	meeting  *repositories.BookingMeetingRepository
+	config   *config.Config
+}
+
+func NewMeetingService(
+	provider meeting.Provider,
+	meeting *repositories.BookingMeetingRepository,
+	config *config.Config,
+) *MeetingService {
+	return &MeetingService{
+		provider: provider,
+		meeting:  meeting,
+		config:   config,
+	}
+}
+
+// Provision creates the room of an accepted online booking and sets it on
+// the booking. Seats of a group session share the room of the first seat
+// provisioned. Failures are left to SyncRooms to retry, accepting a booking
+// does not depend on the provider.
+func (s *MeetingService) Provision(ctx context.Context, booking *model.Booking) error {
+	if booking.ClassType != model.OnlineClassType || booking.Meeting != nil {
+		return nil
+	}
+
+	bookingMeeting := &model.BookingMeeting{
+		ID:        uuid.New(),
+		BookingID: booking.ID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	var shared *model.BookingMeeting
+	if booking.GroupSessionID.Valid {
+		var err error
+		shared, err = s.meeting.GetGroupRoom(ctx, booking.GroupSessionID.UUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if shared != nil {
+		bookingMeeting.Provider = shared.Provider
+		bookingMeeting.RoomID = shared.RoomID
+		bookingMeeting.JoinURL = shared.JoinURL
+		bookingMeeting.ExpiresAt = shared.ExpiresAt
+	} else {
+		startsAt := booking.StartsAt()
+		expiresAt := startsAt.Add(s.config.Meeting.RoomLifetime)
+		room, err := s.provider.CreateRoom(ctx, meeting.CreateRoomRequest{
+			Reference: booking.Code,
+			Topic:     booking.Course.Title,
+			StartsAt:  startsAt,
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("booking_id", booking.ID.String()).Msg("[Provision] Error creating room")
+			return err
+		}
+
+		bookingMeeting.Provider = s.provider.Name()
+		bookingMeeting.RoomID = room.ID
+		bookingMeeting.JoinURL = room.JoinURL
+		bookingMeeting.ExpiresAt = expiresAt
+	}
+
+	err := s.meeting.Create(ctx, bookingMeeting)
+	if err != nil {
+		return err
+	}
+
+	booking.Meeting = bookingMeeting
+	return nil
+}
+
+// SyncRooms creates the rooms which could not be created when their booking
+// was accepted and expires the rooms of sessions which are over.
+func (s *MeetingService) SyncRooms(ctx context.Context) error {
+	now := time.Now()
+
+	bookings, err := s.meeting.GetBookingsWithoutRoom(ctx, now, s.config.Meeting.RoomLifetime)
+	if err != nil {
+		return err
+	}
+
+	provisioned := 0
+	for i := range bookings {
+		if err := s.Provision(ctx, &bookings[i]); err == nil {
+			provisioned++
+		}
+	}
+
+	meetings, err := s.meeting.GetExpiring(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	expired := 0
+	for i := range meetings {
+		// Rooms of a provider no longer configured cannot be reached and
+		// rooms other seats of a group session still use must stay open,
+		// they are only marked expired.
+		shared, err := s.meeting.IsRoomShared(ctx, meetings[i])
+		if err != nil {
+			continue
+		}
+
+		if meetings[i].Provider == s.provider.Name() && !shared {
+			err := s.provider.ExpireRoom(ctx, meetings[i].RoomID)
+			if err != nil {
+				logger.ErrorCtx(ctx).Err(err).Str("booking_id", meetings[i].BookingID.String()).Msg("[SyncRooms] Error expiring room")
+				continue
+			}
+		}
+
+		meetings[i].ExpiredAt = null.TimeFrom(now)
+		if err := s.meeting.MarkExpired(ctx, &meetings[i]); err != nil {
+			continue
+		}
+		expired++
+	}
+
+	logger.InfoCtx(ctx).
+		Int("provisioned", provisioned).
+		Int("expired", expired).
+		Msg("[SyncRooms] Meeting rooms synced")
+	return nil
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/external/meeting"
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestMeetingServiceProvisionSkips(t *testing.T) {
+	// Without a repository any attempt to create a room would panic
+	s := &MeetingService{provider: meeting.FakeProvider{}, config: &config.Config{}}
+
+	tests := []struct {
+		name    string
+		booking model.Booking
+	}{
+		{name: "offline session", booking: model.Booking{ClassType: model.OfflineClassType}},
+		{name: "room already provisioned", booking: model.Booking{ClassType: model.OnlineClassType, Meeting: &model.BookingMeeting{RoomID: "room"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meetingBefore := tt.booking.Meeting
+			if err := s.Provision(context.Background(), &tt.booking); err != nil {
+				t.Fatalf("Provision() error = %v", err)
+			}
+			if tt.booking.Meeting != meetingBefore {
+				t.Errorf("Provision() set meeting %+v, want it left alone", tt.booking.Meeting)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"github.com/lesprivate/backend/config"
 	"github.com/lesprivate/backend/internal/model"
 	"github.com/lesprivate/backend/internal/repositories"
@@ -14,23 +15,32 @@ import (
 )
 
 type MentorBalanceService struct {
-	tutor      *repositories.TutorRepository
-	balance    *repositories.MentorBalanceRepository
-	withdrawal *repositories.WithdrawalRepository
-	config     *config.Config
+	tutor       *repositories.TutorRepository
+	balance     *repositories.MentorBalanceRepository
+	withdrawal  *repositories.WithdrawalRepository
+	bankAccount *repositories.MentorBankAccountRepository
+	finance     *repositories.FinanceReportRepository
+	currency    *CurrencyService
+	config      *config.Config
 }
 
 func NewMentorBalanceService(
 	tutor *repositories.TutorRepository,
 	balance *repositories.MentorBalanceRepository,
 	withdrawal *repositories.WithdrawalRepository,
+	bankAccount *repositories.MentorBankAccountRepository,
+	finance *repositories.FinanceReportRepository,
+	currency *CurrencyService,
 	config *config.Config,
 ) *MentorBalanceService {
 	return &MentorBalanceService{
-		tutor:      tutor,
-		balance:    balance,
-		withdrawal: withdrawal,
-		config:     config,
+		tutor:       tutor,
+		balance:     balance,
+		withdrawal:  withdrawal,
+		bankAccount: bankAccount,
+		finance:     finance,
+		currency:    currency,
+		config:      config,
 	}
 }
 
@@ -81,16 +91,40 @@ func (s *MentorBalanceService) RequestWithdrawal(ctx context.Context, userID uui
 		return shared.MakeError("insufficient_balance", "Insufficient balance")
 	}
 
-	// 2. Create withdrawal request
-	// Note: In MVP we don't deduct balance immediately? Or should we?
-	// Design doc says: Deduct when approved. But to prevent double spend, usually we deduct or lock.
-	// Let's deduct immediately (Status: Pending) OR check balance again at approval.
-	// Best practice: Deduct/Hold immediately.
-	// But let's follow the design doc: Admin transfer manual -> Admin update status -> Saldo mentor berkurang.
-	// So create request only here.
+	// Paid out in the currency of the balance
+	req.Currency = mb.Currency
 
+	// 2. Pay out to a verified bank account, the default one unless chosen
+	var account *model.MentorBankAccount
+	if req.BankAccountID.Valid {
+		account, err = s.bankAccount.GetByID(ctx, req.BankAccountID.UUID)
+	} else {
+		account, err = s.bankAccount.GetDefault(ctx, tutor.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if account == nil || account.TutorID != tutor.ID {
+		return shared.MakeError("not_found", "bank account not found")
+	}
+
+	if !account.Verified() {
+		return shared.MakeError("invalid_bank_account", "bank account is not verified yet")
+	}
+
+	// Keep a copy so deleting the account does not change the payout
+	req.BankAccountID = uuid.NullUUID{UUID: account.ID, Valid: true}
+	req.BankCode = account.BankCode
+	req.BankName = account.BankName()
+	req.AccountNumber = account.AccountNumber
+	req.AccountName = account.AccountName
+
+	// 3. Create withdrawal request
+	// The balance is held when an admin approves the request, and released
+	// again when the payout fails.
 	req.ID = uuid.New()
-	req.Status = "pending"
+	req.Status = model.WithdrawalStatusPending
 
 	return s.withdrawal.Create(ctx, &req)
 }
@@ -107,31 +141,109 @@ func (s *MentorBalanceService) ListWithdrawals(ctx context.Context, userID uuid.
 	return s.withdrawal.ListByTutor(ctx, tutor.ID, status, filter)
 }
 
-// Logic to credit balance from booking
-func (s *MentorBalanceService) CreditFromBooking(ctx context.Context, tutorID uuid.UUID, amount decimal.Decimal, bookingID uuid.UUID) error {
-	// Get commission rate (default 10%)
-	commissionRate := decimal.NewFromFloat(0.10)
-	commission := amount.Mul(commissionRate)
-	netAmount := amount.Sub(commission)
+// SetCurrency changes the currency the mentor is settled in. It can only be
+// changed while the balance is empty and no withdrawal is open.
+func (s *MentorBalanceService) SetCurrency(ctx context.Context, userID uuid.UUID, currency string) (*model.MentorBalance, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tutor == nil {
+		return nil, shared.MakeError("not_found", "tutor not found")
+	}
+
+	if _, ok := model.CurrencyByCode(currency); !ok {
+		return nil, shared.MakeError(ErrBadRequest, "unsupported currency "+currency)
+	}
+
+	mb, err := s.balance.GetOrCreate(ctx, tutor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mb.Currency == currency {
+		return mb, nil
+	}
+
+	open, err := s.withdrawal.CountOpen(ctx, tutor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if open > 0 {
+		return nil, shared.MakeError(ErrBadRequest, "currency can not be changed while a withdrawal is open")
+	}
+
+	updated, err := s.balance.UpdateCurrency(ctx, tutor.ID, currency)
+	if err != nil {
+		return nil, err
+	}
 
-	// Update balance
-	if err := s.balance.UpdateBalance(ctx, tutorID, netAmount); err != nil {
+	if !updated {
+		return nil, shared.MakeError(ErrBadRequest, "currency can only be changed when the balance is empty")
+	}
+
+	mb.Currency = currency
+	return mb, nil
+}
+
+// CreditFromSession credits the mentor the course price for a session that
+// was held. Free first course sessions earn nothing and a session is only
+// credited once.
+func (s *MentorBalanceService) CreditFromSession(ctx context.Context, booking model.Booking) error {
+	if booking.IsFreeFirstCourse || !booking.Course.Price.IsPositive() {
+		return nil
+	}
+
+	return s.credit(ctx, booking.TutorID, booking.Course.Price, booking.Course.Currency,
+		model.BalanceReferenceBookingSession, booking.ID, "Session "+booking.Code)
+}
+
+func (s *MentorBalanceService) credit(ctx context.Context, tutorID uuid.UUID, amount decimal.Decimal, currency, referenceType string, referenceID uuid.UUID, description string) error {
+	mb, err := s.balance.GetOrCreate(ctx, tutorID)
+	if err != nil {
 		return err
 	}
 
-	// Record transaction
 	tx := &model.BalanceTransaction{
 		ID:            uuid.New(),
 		TutorID:       tutorID,
 		Type:          model.BalanceTransactionCredit,
-		Amount:        netAmount,
-		Commission:    commission,
-		ReferenceType: "booking_payment",
-		ReferenceID:   bookingID,
-		Description:   "Payment for booking",
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+		Description:   description,
+		Currency:      mb.Currency,
 	}
 
-	return s.balance.CreateTransaction(ctx, tx)
+	if currency != mb.Currency {
+		converted, rate, err := s.currency.Convert(ctx, amount, currency, mb.Currency, time.Now())
+		if err != nil {
+			return err
+		}
+
+		tx.OriginalAmount = decimal.NewNullDecimal(amount)
+		tx.OriginalCurrency = null.StringFrom(currency)
+		tx.ExchangeRate = decimal.NewNullDecimal(rate)
+		amount = converted
+	}
+
+	tx.Amount, tx.Commission = model.SplitCommission(amount, mb.Currency)
+
+	// A reference credited already is skipped by the unique key
+	_, err = s.balance.Credit(ctx, tx)
+	return err
+}
+
+// ReverseFromPayment returns the debits taking back the given share of the
+// mentor credits booked for a payment, to be recorded with the refund. The
+// reversed commission is kept on the debit so finance stats can net it out.
+func (s *MentorBalanceService) ReverseFromPayment(ctx context.Context, paymentID uuid.UUID, ratio decimal.Decimal, refundID uuid.UUID) ([]model.BalanceTransaction, error) {
+	credits, err := s.balance.GetTransactionsByReference(ctx, model.BalanceReferenceBookingPayment, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ReverseCredits(credits, ratio, refundID), nil
 }
 
 // GetFinanceStats calculates statistics for the finance dashboard
@@ -150,58 +262,40 @@ func (s *MentorBalanceService) GetFinanceStats(ctx context.Context, userID uuid.
 		return nil, err
 	}
 
-	// 2. Get all transactions (or last year/6 months to be efficient)
-	// For MVP, getting all list might be fine, but better to query with range.
-	// We'll use ListTransactions with a large limit for now as we don't have date range filter in repo yet.
-	filter := model.Pagination{Page: 1, PageSize: 1000}
-	txs, _, err := s.balance.ListTransactions(ctx, tutor.ID, filter)
+	// 2. Sum the earnings of the last 60 days and of the last 6 months
+	now := time.Now()
+	thirtyDaysAgo := now.AddDate(0, 0, -30)
+	last30d := model.FinanceReportFilter{From: thirtyDaysAgo, To: now, TutorID: tutor.ID}
+	prev30d := model.FinanceReportFilter{From: now.AddDate(0, 0, -60), To: thirtyDaysAgo, TutorID: tutor.ID}
+
+	earnings30d, err := s.finance.MentorEarnings(ctx, last30d)
+	if err != nil {
+		return nil, err
+	}
+	earningsPrev30d, err := s.finance.MentorEarnings(ctx, prev30d)
+	if err != nil {
+		return nil, err
+	}
+	commissions30d, err := s.finance.Commission(ctx, last30d)
 	if err != nil {
 		return nil, err
 	}
 
-	now := time.Now()
-	thirtyDaysAgo := now.AddDate(0, 0, -30)
-	sixtyDaysAgo := now.AddDate(0, 0, -60)
-
-	var income30d, incomePrev30d decimal.Decimal
-	var commission30d decimal.Decimal
-
-	// Chart data map: "Jan" -> amount
-	// We need last 6 months
-	chartMap := make(map[string]decimal.Decimal)
-	var months []string
-	for i := 5; i >= 0; i-- {
-		month := now.AddDate(0, -i, 0)
-		monthName := month.Format("Jan")
-		months = append(months, monthName)
-		chartMap[monthName] = decimal.Zero
-	}
-
-	for _, tx := range txs {
-		// Only count credits (income) for stats
-		if tx.Type == model.BalanceTransactionCredit {
-			// Income 30d
-			if tx.CreatedAt.After(thirtyDaysAgo) {
-				income30d = income30d.Add(tx.Amount)
-				commission30d = commission30d.Add(tx.Commission)
-			}
-			// Income Prev 30d (for pct change)
-			if tx.CreatedAt.After(sixtyDaysAgo) && tx.CreatedAt.Before(thirtyDaysAgo) {
-				incomePrev30d = incomePrev30d.Add(tx.Amount)
-			}
-
-			// Chart Data (last 6 months)
-			// Check if tx is within last 6 months
-			// Simple check: same year and month match
-			monthName := tx.CreatedAt.Format("Jan")
-			if _, ok := chartMap[monthName]; ok {
-				// Also check year to avoid adding last year's same month if query is huge
-				// (Assuming simple limit 1000 covers recent ones mostly)
-				if tx.CreatedAt.Year() == now.Year() || (tx.CreatedAt.Year() == now.Year()-1 && now.Month() < 6) {
-					chartMap[monthName] = chartMap[monthName].Add(tx.Amount)
-				}
-			}
-		}
+	income30d := sumPeriods(earnings30d)
+	incomePrev30d := sumPeriods(earningsPrev30d)
+	commission30d := sumPeriods(commissions30d)
+
+	// Chart data of the last 6 months, keyed by period
+	firstMonth := time.Date(now.Year(), now.Month()-5, 1, 0, 0, 0, 0, now.Location())
+	chartFilter := model.FinanceReportFilter{
+		From:        firstMonth,
+		To:          firstMonth.AddDate(0, 6, 0),
+		Granularity: model.FinanceGranularityMonth,
+		TutorID:     tutor.ID,
+	}
+	chartMap, err := s.finance.MentorEarnings(ctx, chartFilter)
+	if err != nil {
+		return nil, err
 	}
 
 	// Calc Percentage Change
@@ -216,10 +310,11 @@ func (s *MentorBalanceService) GetFinanceStats(ctx context.Context, userID uuid.
 
 	// Build Chart Data Array
 	var chartData []map[string]interface{}
-	for _, m := range months {
+	for i := 0; i < 6; i++ {
+		month := firstMonth.AddDate(0, i, 0)
 		chartData = append(chartData, map[string]interface{}{
-			"month":  m,
-			"amount": chartMap[m].IntPart(),
+			"month":  month.Format("Jan"),
+			"amount": chartMap[chartFilter.PeriodKey(month)].IntPart(),
 		})
 	}
 
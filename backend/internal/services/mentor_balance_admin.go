@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -58,30 +59,21 @@ func (s *MentorBalanceAdminService) ListAllTransactions(ctx context.Context, fil
 }
 
 func (s *MentorBalanceAdminService) GetTransactionStats(ctx context.Context) (*dto.AdminTransactionStats, error) {
-	// For MVP, we can iterate all or query summary from DB.
-	// Let's use ListAllTransactions with a large limit for simplicity or create a specific repo method.
-	// Since it's admin dashboard stats, a specific repo method is better.
-	// But let's check if we can just reuse ListAllTransactions for now.
-	filter := model.Pagination{Page: 1, PageSize: 10000}
-	txs, _, err := s.balance.ListAllTransactions(ctx, filter, "", "")
+	stats, err := s.balance.TransactionStats(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list all transactions for stats: %w", err)
+		return nil, fmt.Errorf("failed to sum transactions for stats: %w", err)
 	}
 
-	var stats dto.AdminTransactionStats
-	for _, tx := range txs {
-		if tx.Type == model.BalanceTransactionCredit {
-			stats.TotalCredit = stats.TotalCredit.Add(tx.Amount)
-			stats.TotalCommission = stats.TotalCommission.Add(tx.Commission)
-		} else if tx.Type == model.BalanceTransactionDebit {
-			stats.TotalDebit = stats.TotalDebit.Add(tx.Amount)
-		}
-	}
-	stats.TotalCount = int64(len(txs))
-
-	return &stats, nil
+	return &dto.AdminTransactionStats{
+		TotalCredit:     stats.TotalCredit,
+		TotalDebit:      stats.TotalDebit,
+		TotalCommission: stats.TotalCommission,
+		TotalCount:      stats.TotalCount,
+	}, nil
 }
 
+// ApproveWithdrawal holds the requested amount from the mentor balance. The
+// withdrawal is paid out by the next payout batch.
 func (s *MentorBalanceAdminService) ApproveWithdrawal(ctx context.Context, id uuid.UUID, adminID uuid.UUID) error {
 	w, err := s.withdrawal.GetByID(ctx, id)
 	if err != nil {
@@ -92,42 +84,27 @@ func (s *MentorBalanceAdminService) ApproveWithdrawal(ctx context.Context, id uu
 		return shared.MakeError("invalid_status", "Withdrawal request is not pending")
 	}
 
-	// Check balance again
-	mb, err := s.balance.GetOrCreate(ctx, w.TutorID)
-	if err != nil {
-		return err
-	}
-
-	if mb.Balance.LessThan(w.Amount) {
-		return shared.MakeError("insufficient_balance", "Insufficient balance")
-	}
-
-	// 1. Deduct balance
-	if err := s.balance.UpdateBalance(ctx, w.TutorID, w.Amount.Neg()); err != nil {
-		return err
+	// Requests made before bank accounts were verified can not be paid out
+	if w.BankCode == "" {
+		return shared.MakeError("invalid_bank_account", "Withdrawal request has no verified bank account")
 	}
 
-	// 2. Record debit transaction
-	tx := &model.BalanceTransaction{
-		ID:            uuid.New(),
-		TutorID:       w.TutorID,
-		Type:          model.BalanceTransactionDebit,
-		Amount:        w.Amount,
-		ReferenceType: "withdrawal",
-		ReferenceID:   w.ID,
-		Description:   "Withdrawal approved",
-	}
-	if err := s.balance.CreateTransaction(ctx, tx); err != nil {
-		return err // TODO: Transaction rollback needed in real implementation
-	}
-
-	// 3. Update request status
-	w.Status = model.WithdrawalStatusCompleted
+	w.Status = model.WithdrawalStatusApproved
 	now := time.Now()
 	w.ProcessedAt = &now
 	w.ProcessedBy = uuid.NullUUID{UUID: adminID, Valid: true}
 
-	return s.withdrawal.Update(ctx, w)
+	err = s.withdrawal.Approve(ctx, w)
+	switch {
+	case errors.Is(err, repositories.ErrInsufficientBalance):
+		return shared.MakeError("insufficient_balance", "Insufficient balance")
+	case errors.Is(err, repositories.ErrWithdrawalNotPending):
+		return shared.MakeError("invalid_status", "Withdrawal request is not pending")
+	case err != nil:
+		return err
+	}
+
+	return nil
 }
 
 func (s *MentorBalanceAdminService) RejectWithdrawal(ctx context.Context, id uuid.UUID, adminID uuid.UUID, note string) error {
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+var accountNumberRegex = regexp.MustCompile(`^[0-9]{5,20}$`)
+
+// MentorBankAccountService manages the bank accounts mentors are paid out
+// to. Accounts can only receive payouts once an admin verified them.
+type MentorBankAccountService struct {
+	tutor       *repositories.TutorRepository
+	bankAccount *repositories.MentorBankAccountRepository
+}
+
+func NewMentorBankAccountService(
+	tutor *repositories.TutorRepository,
+	bankAccount *repositories.MentorBankAccountRepository,
+) *MentorBankAccountService {
+	return &MentorBankAccountService{
+		tutor:       tutor,
+		bankAccount: bankAccount,
+	}
+}
+
+func (s *MentorBankAccountService) ListBanks() []model.Bank {
+	return model.Banks
+}
+
+func (s *MentorBankAccountService) getTutor(ctx context.Context, userID uuid.UUID) (*model.Tutor, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	return tutor, nil
+}
+
+// getOwned returns the bank account when it belongs to the mentor of userID.
+func (s *MentorBankAccountService) getOwned(ctx context.Context, userID, id uuid.UUID) (*model.MentorBankAccount, error) {
+	tutor, err := s.getTutor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.bankAccount.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || account.TutorID != tutor.ID {
+		return nil, shared.MakeError(ErrEntityNotFound, "bank account")
+	}
+
+	return account, nil
+}
+
+func (s *MentorBankAccountService) List(ctx context.Context, userID uuid.UUID) ([]model.MentorBankAccount, error) {
+	tutor, err := s.getTutor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, _, err := s.bankAccount.Get(ctx, model.MentorBankAccountFilter{TutorID: tutor.ID})
+	return accounts, err
+}
+
+// Create adds an unverified bank account. The bank code must be one of
+// model.Banks.
+func (s *MentorBankAccountService) Create(ctx context.Context, userID uuid.UUID, account model.MentorBankAccount) (*model.MentorBankAccount, error) {
+	tutor, err := s.getTutor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	account.BankCode = strings.ToUpper(strings.TrimSpace(account.BankCode))
+	account.AccountNumber = strings.NewReplacer(" ", "", "-", "", ".", "").Replace(account.AccountNumber)
+	account.AccountName = strings.TrimSpace(account.AccountName)
+
+	if _, ok := model.BankByCode(account.BankCode); !ok {
+		return nil, shared.MakeError(ErrBadRequest, "bank code is not supported")
+	}
+	if !accountNumberRegex.MatchString(account.AccountNumber) {
+		return nil, shared.MakeError(ErrBadRequest, "account number must be 5 to 20 digits")
+	}
+	if account.AccountName == "" {
+		return nil, shared.MakeError(ErrBadRequest, "account name is required")
+	}
+
+	existing, _, err := s.bankAccount.Get(ctx, model.MentorBankAccountFilter{TutorID: tutor.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range existing {
+		if e.BankCode == account.BankCode && e.AccountNumber == account.AccountNumber {
+			return nil, shared.MakeError(ErrBadRequest, "bank account already exists")
+		}
+	}
+
+	account.ID = uuid.New()
+	account.TutorID = tutor.ID
+	account.VerifiedAt = nil
+	account.VerifiedBy = uuid.NullUUID{}
+
+	if err := s.bankAccount.Create(ctx, &account); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Create] Error creating mentor bank account")
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (s *MentorBankAccountService) SetDefault(ctx context.Context, userID, id uuid.UUID) error {
+	account, err := s.getOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.bankAccount.SetDefault(ctx, account)
+}
+
+// Delete removes the bank account. Withdrawals keep a copy of the account
+// they are paid to, so requests in flight are not affected.
+func (s *MentorBankAccountService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	account, err := s.getOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.bankAccount.Delete(ctx, account.ID)
+}
+
+func (s *MentorBankAccountService) AdminList(ctx context.Context, req dto.AdminListMentorBankAccountsRequest) ([]model.MentorBankAccount, model.Metadata, error) {
+	filter := model.MentorBankAccountFilter{
+		Verified:   req.Verified,
+		Pagination: req.Pagination,
+	}
+	filter.SetDefault()
+
+	return s.bankAccount.Get(ctx, filter)
+}
+
+// Verify marks the account as checked by an admin against the mentor's
+// identity, so payouts can be sent to it.
+func (s *MentorBankAccountService) Verify(ctx context.Context, id, adminID uuid.UUID) (*model.MentorBankAccount, error) {
+	account, err := s.bankAccount.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "bank account")
+	}
+
+	if account.Verified() {
+		return account, nil
+	}
+
+	now := time.Now()
+	account.VerifiedAt = &now
+	account.VerifiedBy = uuid.NullUUID{UUID: adminID, Valid: true}
+
+	if err := s.bankAccount.Update(ctx, account); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Verify] Error verifying mentor bank account")
+		return nil, err
+	}
+
+	return account, nil
+}
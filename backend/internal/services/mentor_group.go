@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+// MentorGroupService lets tutors split their mentored students into groups,
+// each with its own invite codes and announcements.
+type MentorGroupService struct {
+	group         *repositories.MentorGroupRepository
+	mentorStudent *repositories.MentorStudentRepository
+	inviteCode    *repositories.MentorInviteCodeRepository
+	tutor         *repositories.TutorRepository
+	notification  *NotificationService
+}
+
+func NewMentorGroupService(
+	group *repositories.MentorGroupRepository,
+	mentorStudent *repositories.MentorStudentRepository,
+	inviteCode *repositories.MentorInviteCodeRepository,
+	tutor *repositories.TutorRepository,
+	notification *NotificationService,
+) *MentorGroupService {
+	return &MentorGroupService{
+		group:         group,
+		mentorStudent: mentorStudent,
+		inviteCode:    inviteCode,
+		tutor:         tutor,
+		notification:  notification,
+	}
+}
+
+func (s *MentorGroupService) currentTutor(ctx context.Context) (*model.Tutor, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[MentorGroupService] Error getting tutor")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	return tutor, nil
+}
+
+// ownGroup returns the group when it belongs to the current tutor
+func (s *MentorGroupService) ownGroup(ctx context.Context, id uuid.UUID) (*model.Tutor, *model.MentorGroup, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group, err := s.group.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, shared.MakeError(ErrInternalServer)
+	}
+	if group == nil || group.TutorID != tutor.ID {
+		return nil, nil, shared.MakeError(ErrEntityNotFound, "group")
+	}
+
+	return tutor, group, nil
+}
+
+func (s *MentorGroupService) GetGroups(ctx context.Context, request dto.GetMentorGroupsRequest) ([]dto.MentorGroupResponse, model.Metadata, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	groups, metadata, err := s.group.Get(ctx, model.MentorGroupFilter{
+		TutorID:    tutor.ID,
+		Query:      request.Query,
+		Pagination: request.Pagination,
+	})
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewMentorGroupResponses(groups), metadata, nil
+}
+
+func (s *MentorGroupService) CreateGroup(ctx context.Context, request dto.MentorGroupRequest) (*dto.MentorGroupResponse, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	group := model.MentorGroup{
+		ID:          uuid.New(),
+		TutorID:     tutor.ID,
+		Name:        request.Name,
+		Description: null.StringFromPtr(request.Description),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err = s.group.Create(ctx, &group)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewMentorGroupResponse(group)
+	return &res, nil
+}
+
+func (s *MentorGroupService) UpdateGroup(ctx context.Context, id uuid.UUID, request dto.MentorGroupRequest) (*dto.MentorGroupResponse, error) {
+	_, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Name = request.Name
+	group.Description = null.StringFromPtr(request.Description)
+	group.UpdatedAt = time.Now()
+
+	err = s.group.Update(ctx, group)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewMentorGroupResponse(*group)
+	return &res, nil
+}
+
+// DeleteGroup removes the group with its invite codes and announcements. Its
+// students stay mentored by the tutor.
+func (s *MentorGroupService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	_, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.group.Delete(ctx, group.ID)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *MentorGroupService) GetMembers(ctx context.Context, id uuid.UUID, request dto.GetMentorGroupMembersRequest) ([]dto.MentorGroupMemberResponse, model.Metadata, error) {
+	_, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	members, metadata, err := s.group.GetMembers(ctx, group.ID, request.Pagination)
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewMentorGroupMemberResponses(members), metadata, nil
+}
+
+// AddMembers puts students the tutor already mentors in the group.
+func (s *MentorGroupService) AddMembers(ctx context.Context, id uuid.UUID, request dto.AddMentorGroupMembersRequest) error {
+	tutor, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	mentored, err := s.mentorStudent.GetActiveStudentIDs(ctx, tutor.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AddMembers] Error getting mentored students")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	active := make(map[uuid.UUID]bool, len(mentored))
+	for _, studentID := range mentored {
+		active[studentID] = true
+	}
+
+	now := time.Now()
+	members := make([]model.MentorGroupMember, 0, len(request.StudentIDs))
+	for _, studentID := range request.StudentIDs {
+		if !active[studentID] {
+			return shared.MakeError(ErrBadRequest, "student "+studentID.String()+" is not mentored by the tutor")
+		}
+
+		members = append(members, model.MentorGroupMember{
+			ID:        uuid.New(),
+			GroupID:   group.ID,
+			StudentID: studentID,
+			JoinedAt:  now,
+		})
+	}
+
+	err = s.group.AddMembers(ctx, members)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *MentorGroupService) RemoveMember(ctx context.Context, id, studentID uuid.UUID) error {
+	_, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.group.RemoveMember(ctx, group.ID, studentID)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+	if !removed {
+		return shared.MakeError(ErrEntityNotFound, "group member")
+	}
+
+	return nil
+}
+
+func (s *MentorGroupService) GetInviteCodes(ctx context.Context, id uuid.UUID) ([]dto.GroupInviteCodeResponse, error) {
+	_, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.inviteCode.ListByGroup(ctx, group.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetInviteCodes] Error getting invite codes")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewGroupInviteCodeResponses(codes), nil
+}
+
+// CreateInviteCode issues a new code students join the group with.
+func (s *MentorGroupService) CreateInviteCode(ctx context.Context, id uuid.UUID, request dto.CreateGroupInviteCodeRequest) (*dto.GroupInviteCodeResponse, error) {
+	tutor, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := generateInviteCode()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInviteCode] Error generating invite code")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	invite := model.MentorInviteCode{
+		ID:        uuid.New(),
+		TutorID:   tutor.ID,
+		GroupID:   uuid.NullUUID{UUID: group.ID, Valid: true},
+		Code:      code,
+		ExpiresAt: null.TimeFromPtr(request.ExpiresAt),
+		CreatedAt: time.Now(),
+	}
+	if request.MaxUses != nil {
+		invite.MaxUses = null.IntFrom(int64(*request.MaxUses))
+	}
+
+	err = s.inviteCode.Create(ctx, &invite)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInviteCode] Error creating invite code")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewGroupInviteCodeResponse(invite)
+	return &res, nil
+}
+
+// RevokeInviteCode stops students from joining with the code. Students who
+// already joined stay in the group.
+func (s *MentorGroupService) RevokeInviteCode(ctx context.Context, id, codeID uuid.UUID) error {
+	_, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	code, err := s.inviteCode.GetByID(ctx, codeID)
+	if err != nil || !code.GroupID.Valid || code.GroupID.UUID != group.ID {
+		return shared.MakeError(ErrEntityNotFound, "invite code")
+	}
+
+	if code.RevokedAt.Valid {
+		return nil
+	}
+
+	err = s.inviteCode.Revoke(ctx, code.ID, time.Now())
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RevokeInviteCode] Error revoking invite code")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *MentorGroupService) GetAnnouncements(ctx context.Context, id uuid.UUID, pagination model.Pagination) ([]dto.MentorGroupAnnouncementResponse, model.Metadata, error) {
+	_, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	announcements, metadata, err := s.group.GetAnnouncements(ctx, group.ID, pagination)
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewMentorGroupAnnouncementResponses(announcements), metadata, nil
+}
+
+// Announce notifies the members of the group the tutor still mentors and
+// keeps the announcement in the history of the group.
+func (s *MentorGroupService) Announce(ctx context.Context, id uuid.UUID, request dto.MentorGroupAnnouncementRequest) (*dto.MentorGroupAnnouncementResponse, error) {
+	tutor, group, err := s.ownGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.group.GetMemberUserIDs(ctx, group.ID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	announcement := model.MentorGroupAnnouncement{
+		ID:         uuid.New(),
+		GroupID:    group.ID,
+		TutorID:    tutor.ID,
+		Title:      request.Title,
+		Message:    request.Message,
+		Recipients: len(userIDs),
+		CreatedAt:  time.Now(),
+	}
+
+	err = s.group.CreateAnnouncement(ctx, &announcement)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	err = s.notification.GroupAnnouncement(ctx, *tutor, *group, announcement, userIDs)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewMentorGroupAnnouncementResponse(announcement)
+	return &res, nil
+}
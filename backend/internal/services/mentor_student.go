@@ -18,6 +18,7 @@ type MentorStudentService struct {
 	inviteCode    *repositories.MentorInviteCodeRepository
 	student       *repositories.StudentRepository
 	user          *repositories.UserRepository
+	group         *repositories.MentorGroupRepository
 }
 
 func NewMentorStudentService(
@@ -26,6 +27,7 @@ func NewMentorStudentService(
 	inviteCode *repositories.MentorInviteCodeRepository,
 	student *repositories.StudentRepository,
 	user *repositories.UserRepository,
+	group *repositories.MentorGroupRepository,
 ) *MentorStudentService {
 	return &MentorStudentService{
 		mentorStudent: mentorStudent,
@@ -33,6 +35,7 @@ func NewMentorStudentService(
 		inviteCode:    inviteCode,
 		student:       student,
 		user:          user,
+		group:         group,
 	}
 }
 
@@ -40,34 +43,64 @@ func (s *MentorStudentService) JoinByCode(ctx context.Context, code string, user
 	// Resolve Student from UserID
 	student, err := s.student.GetByUserID(ctx, userID)
 	if err != nil {
-		return err
+		return shared.MakeError(ErrInternalServer)
 	}
 	if student == nil {
-		return shared.MakeError("not_found", "student not found")
+		return shared.MakeError(ErrEntityNotFound, "student")
 	}
 
 	// 1. Validate invite code
 	invite, err := s.inviteCode.GetByCode(ctx, code)
 	if err != nil {
-		return err // TODO: return specific error "Invalid invite code"
+		return shared.MakeError(ErrEntityNotFound, "invite code")
+	}
+
+	if !invite.IsUsable(time.Now()) {
+		return shared.MakeError(ErrBadRequest, "invite code is no longer valid")
 	}
 
 	// 2. Check if already joined
 	existing, err := s.mentorStudent.GetByTutorAndStudent(ctx, invite.TutorID, student.ID)
-	if err == nil && existing != nil {
+	joined := err == nil && existing != nil
+	if joined && !invite.GroupID.Valid {
 		return nil // Already joined, treat as success
 	}
 
-	// 3. Create relationship
-	ms := &model.MentorStudent{
-		ID:        uuid.New(),
-		TutorID:   invite.TutorID,
-		StudentID: student.ID,
-		Status:    "active",
-		JoinedAt:  time.Now(),
+	// 3. Count the use, limited codes can run out while students join
+	ok, err := s.inviteCode.Use(ctx, invite.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return shared.MakeError(ErrBadRequest, "invite code is no longer valid")
+	}
+
+	// 4. Create relationship
+	if !joined {
+		ms := &model.MentorStudent{
+			ID:        uuid.New(),
+			TutorID:   invite.TutorID,
+			StudentID: student.ID,
+			Status:    "active",
+			JoinedAt:  time.Now(),
+		}
+
+		if err := s.mentorStudent.Create(ctx, ms); err != nil {
+			return err
+		}
+	}
+
+	// 5. Join the group of the code
+	if invite.GroupID.Valid {
+		return s.group.AddMembers(ctx, []model.MentorGroupMember{{
+			ID:        uuid.New(),
+			GroupID:   invite.GroupID.UUID,
+			StudentID: student.ID,
+			JoinedAt:  time.Now(),
+		}})
 	}
 
-	return s.mentorStudent.Create(ctx, ms)
+	return nil
 }
 
 func (s *MentorStudentService) ListStudents(ctx context.Context, userID uuid.UUID, filter model.Pagination) ([]model.MentorStudent, model.Metadata, error) {
@@ -82,14 +115,32 @@ func (s *MentorStudentService) ListStudents(ctx context.Context, userID uuid.UUI
 	return s.mentorStudent.ListByTutor(ctx, tutor.ID, filter)
 }
 
-func (s *MentorStudentService) RemoveStudent(ctx context.Context, tutorID, studentID uuid.UUID) error {
+// RemoveStudent stops mentoring the student, taking them out of the groups
+// of the tutor as well
+func (s *MentorStudentService) RemoveStudent(ctx context.Context, userID, studentID uuid.UUID) error {
+	tutor, err := s.tutor.GetByUserID(ctx, userID)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+	if tutor == nil {
+		return shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
 	// Verify ownership/relationship first
-	ms, err := s.mentorStudent.GetByTutorAndStudent(ctx, tutorID, studentID)
+	ms, err := s.mentorStudent.GetByTutorAndStudent(ctx, tutor.ID, studentID)
 	if err != nil {
-		return err
+		return shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	if err := s.mentorStudent.Delete(ctx, ms.ID); err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if err := s.group.RemoveStudent(ctx, tutor.ID, studentID); err != nil {
+		return shared.MakeError(ErrInternalServer)
 	}
 
-	return s.mentorStudent.Delete(ctx, ms.ID)
+	return nil
 }
 
 func (s *MentorStudentService) GetInviteCode(ctx context.Context, userID uuid.UUID) (string, error) {
@@ -108,7 +159,7 @@ func (s *MentorStudentService) GetInviteCode(ctx context.Context, userID uuid.UU
 	}
 
 	// Generate new if not exists
-	newCodeStr, err := s.generateCode()
+	newCodeStr, err := generateInviteCode()
 	if err != nil {
 		return "", err
 	}
@@ -126,7 +177,7 @@ func (s *MentorStudentService) GetInviteCode(ctx context.Context, userID uuid.UU
 	return newCodeStr, nil
 }
 
-func (s *MentorStudentService) generateCode() (string, error) {
+func generateInviteCode() (string, error) {
 	// Simple random string generation 6 chars
 	const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, 6)
@@ -5,47 +5,556 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
 	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
 	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
 	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
 	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+const (
+	monthlyReportWorkers   = 2
+	monthlyReportQueueSize = 100
+
+	// monthlyReportURLTTL is how long the download URL of a report works.
+	monthlyReportURLTTL = 15 * time.Minute
+
+	// monthlyReportStaleAfter is when a report still processing is assumed to
+	// be lost, e.g. by a restart, and queued again.
+	monthlyReportStaleAfter = 30 * time.Minute
 )
 
+// MonthlyReportService generates the monthly progress reports of students.
+// Reports are requested as jobs, rendered by a pool of workers and stored in
+// the bucket, so a request returns right away with the id of the report to
+// poll for its download URL.
 type MonthlyReportService struct {
-	bookingRepo *repositories.BookingRepository
-	studentRepo *repositories.StudentRepository
+	bookingRepo  *repositories.BookingRepository
+	studentRepo  *repositories.StudentRepository
+	report       *repositories.MonthlyReportRepository
+	guardian     *repositories.GuardianRepository
+	tutor        *repositories.TutorRepository
+	learningPlan *repositories.LearningPlanRepository
+	entitlement  *EntitlementService
+	file         *FileService
+	notification *NotificationService
+	jobs         chan uuid.UUID
 }
 
 func NewMonthlyReportService(
 	bookingRepo *repositories.BookingRepository,
 	studentRepo *repositories.StudentRepository,
+	report *repositories.MonthlyReportRepository,
+	guardian *repositories.GuardianRepository,
+	tutor *repositories.TutorRepository,
+	learningPlan *repositories.LearningPlanRepository,
+	entitlement *EntitlementService,
+	file *FileService,
+	notification *NotificationService,
 ) *MonthlyReportService {
-	return &MonthlyReportService{
-		bookingRepo: bookingRepo,
-		studentRepo: studentRepo,
+	s := &MonthlyReportService{
+		bookingRepo:  bookingRepo,
+		studentRepo:  studentRepo,
+		report:       report,
+		guardian:     guardian,
+		tutor:        tutor,
+		learningPlan: learningPlan,
+		entitlement:  entitlement,
+		file:         file,
+		notification: notification,
+		jobs:         make(chan uuid.UUID, monthlyReportQueueSize),
+	}
+
+	for i := 0; i < monthlyReportWorkers; i++ {
+		go s.work()
+	}
+
+	return s
+}
+
+// RequestStudentMonthlyReport queues the monthly report of the student behind
+// the user when their plan includes monthly reports.
+func (s *MonthlyReportService) RequestStudentMonthlyReport(ctx context.Context, userID uuid.UUID, req dto.CreateMonthlyReportRequest) (*dto.MonthlyReportResponse, error) {
+	student, err := s.entitlement.RequireMonthlyReport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Request(ctx, student.ID, req)
+}
+
+// RequestTutorMonthlyReport queues the monthly report of a student the tutor
+// had sessions with. The plan of the student must include monthly reports.
+func (s *MonthlyReportService) RequestTutorMonthlyReport(ctx context.Context, userID, studentID uuid.UUID, req dto.CreateMonthlyReportRequest) (*dto.MonthlyReportResponse, error) {
+	student, err := s.tutorStudent(ctx, userID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.entitlement.RequireStudentMonthlyReport(ctx, *student); err != nil {
+		return nil, err
+	}
+
+	return s.Request(ctx, student.ID, req)
+}
+
+// GetStudentMonthlyReports returns the reports of the student behind the
+// user, latest period first.
+func (s *MonthlyReportService) GetStudentMonthlyReports(ctx context.Context, userID uuid.UUID, req dto.GetMonthlyReportsRequest) ([]dto.MonthlyReportResponse, model.Metadata, error) {
+	student, err := s.studentRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentMonthlyReports] Error getting student")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if student == nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	reports, metadata, err := s.report.Get(ctx, model.MonthlyReportFilter{
+		StudentID:  student.ID,
+		Pagination: req.Pagination,
+	})
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	resp := make([]dto.MonthlyReportResponse, 0, len(reports))
+	for _, report := range reports {
+		resp = append(resp, s.response(report))
+	}
+
+	return resp, metadata, nil
+}
+
+// GetUserMonthlyReport returns a report the user may see: their own, one of a
+// student who accepted them as guardian, or one of a student they tutored.
+func (s *MonthlyReportService) GetUserMonthlyReport(ctx context.Context, userID, id uuid.UUID) (*dto.MonthlyReportResponse, error) {
+	report, err := s.report.GetByID(ctx, id)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if report == nil || !s.canView(ctx, userID, report.Student) {
+		return nil, shared.MakeError(ErrEntityNotFound, "monthly report")
+	}
+
+	resp := s.response(*report)
+	return &resp, nil
+}
+
+// GetMonthlyReport returns any report, for admins.
+func (s *MonthlyReportService) GetMonthlyReport(ctx context.Context, id uuid.UUID) (*dto.MonthlyReportResponse, error) {
+	report, err := s.report.GetByID(ctx, id)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if report == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "monthly report")
+	}
+
+	resp := s.response(*report)
+	return &resp, nil
+}
+
+// Request queues the report of the student for the month. A report generated
+// after its month ended is final and returned as is.
+func (s *MonthlyReportService) Request(ctx context.Context, studentID uuid.UUID, req dto.CreateMonthlyReportRequest) (*dto.MonthlyReportResponse, error) {
+	student, err := s.studentRepo.GetByID(ctx, studentID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Request] Error getting student")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if student == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	report, err := s.queue(ctx, studentID, model.MonthlyReportPeriod(req.Month, req.Year), false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := s.response(*report)
+	return &resp, nil
+}
+
+// ScheduleMonthlyReports queues the report of the previous month for every
+// student with sessions in it whose plan includes monthly reports. The reports
+// are emailed to the student and their guardians once generated. Reports
+// left in the queue, e.g. by a restart, are picked up again.
+func (s *MonthlyReportService) ScheduleMonthlyReports(ctx context.Context) error {
+	s.resume(ctx)
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).AddDate(0, -1, 0)
+	period := model.MonthlyReportPeriod(int(start.Month()), start.Year())
+
+	studentIDs, err := s.bookingRepo.GetStudentIDs(ctx, start, start.AddDate(0, 1, -1))
+	if err != nil {
+		return err
+	}
+
+	for _, studentID := range studentIDs {
+		student, err := s.studentRepo.GetByID(ctx, studentID)
+		if err != nil || student == nil {
+			logger.ErrorCtx(ctx).Err(err).Str("student_id", studentID.String()).Msg("[ScheduleMonthlyReports] Error getting student")
+			continue
+		}
+
+		if err := s.entitlement.RequireStudentMonthlyReport(ctx, *student); err != nil {
+			continue
+		}
+
+		_, err = s.queue(ctx, studentID, period, true)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("student_id", studentID.String()).Msg("[ScheduleMonthlyReports] Error queueing monthly report")
+		}
+	}
+
+	return nil
+}
+
+func (s *MonthlyReportService) queue(ctx context.Context, studentID uuid.UUID, period string, deliver bool) (*model.MonthlyReport, error) {
+	var requestedBy uuid.NullUUID
+	if userID := middleware.GetUserID(ctx); userID != uuid.Nil {
+		requestedBy = uuid.NullUUID{UUID: userID, Valid: true}
+	}
+
+	report, err := s.report.GetByPeriod(ctx, studentID, period)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if report == nil {
+		report, err = s.report.Create(ctx, &model.MonthlyReport{
+			ID:          uuid.New(),
+			StudentID:   studentID,
+			Period:      period,
+			Status:      model.MonthlyReportStatusQueued,
+			Deliver:     deliver,
+			RequestedBy: requestedBy,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		})
+		if err != nil {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+
+		s.enqueue(ctx, report.ID)
+		return report, nil
+	}
+
+	switch {
+	case report.Status == model.MonthlyReportStatusQueued, report.Status == model.MonthlyReportStatusProcessing:
+		if !deliver || report.Deliver {
+			return report, nil
+		}
+
+		report.Deliver = true
+	case report.IsFinal():
+		if !deliver || report.DeliveredAt.Valid {
+			return report, nil
+		}
+
+		report.Deliver = true
+		report.UpdatedAt = time.Now()
+		if err := s.report.Update(ctx, report); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[queue] Error updating monthly report")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+
+		go func(report model.MonthlyReport) {
+			ctx := context.Background()
+			content, err := s.file.GetFile(ctx, report.FileKey.String)
+			if err != nil {
+				logger.ErrorCtx(ctx).Err(err).Str("monthly_report_id", report.ID.String()).Msg("[queue] Error getting monthly report file")
+				return
+			}
+
+			s.deliver(ctx, &report, content)
+		}(*report)
+
+		return report, nil
+	default:
+		// Failed, or generated while the month was still running.
+		report.Status = model.MonthlyReportStatusQueued
+		report.Error = null.String{}
+		report.Deliver = report.Deliver || deliver
+		report.RequestedBy = requestedBy
+	}
+
+	report.UpdatedAt = time.Now()
+	if err := s.report.Update(ctx, report); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[queue] Error updating monthly report")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	s.enqueue(ctx, report.ID)
+	return report, nil
+}
+
+// enqueue hands the report to the workers. When the queue is full the report
+// stays queued until the scheduled job picks it up.
+func (s *MonthlyReportService) enqueue(ctx context.Context, id uuid.UUID) {
+	select {
+	case s.jobs <- id:
+	default:
+		logger.WarnCtx(ctx).Str("monthly_report_id", id.String()).Msg("[enqueue] Monthly report queue is full")
+	}
+}
+
+// resume queues the reports that were lost by the workers again.
+func (s *MonthlyReportService) resume(ctx context.Context) {
+	err := s.report.Requeue(ctx, time.Now().Add(-monthlyReportStaleAfter))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[resume] Error requeueing monthly reports")
+	}
+
+	reports, _, err := s.report.Get(ctx, model.MonthlyReportFilter{
+		StatusIn: []string{model.MonthlyReportStatusQueued},
+	})
+	if err != nil {
+		return
+	}
+
+	for _, report := range reports {
+		s.enqueue(ctx, report.ID)
+	}
+}
+
+func (s *MonthlyReportService) work() {
+	for id := range s.jobs {
+		s.process(context.Background(), id)
+	}
+}
+
+// process generates, stores and, when asked, delivers a queued report.
+func (s *MonthlyReportService) process(ctx context.Context, id uuid.UUID) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.ErrorCtx(ctx).Interface("panic", r).Str("monthly_report_id", id.String()).Msg("[process] Monthly report worker recovered")
+		}
+	}()
+
+	claimed, err := s.report.Claim(ctx, id)
+	if err != nil || !claimed {
+		return
+	}
+
+	report, err := s.report.GetByID(ctx, id)
+	if err != nil || report == nil {
+		logger.ErrorCtx(ctx).Err(err).Str("monthly_report_id", id.String()).Msg("[process] Error getting monthly report")
+		return
+	}
+
+	start := report.Start()
+	content, filename, err := s.GenerateMonthlyReport(ctx, report.StudentID, int(start.Month()), start.Year())
+	if err == nil {
+		key := fmt.Sprintf("reports/monthly/%s/%s.pdf", report.Period, report.StudentID)
+		if err = s.file.PutPrivateFile(ctx, key, content); err == nil {
+			report.FileKey = null.StringFrom(key)
+			report.Filename = null.StringFrom(filename)
+		}
+	}
+
+	report.UpdatedAt = time.Now()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("monthly_report_id", id.String()).Msg("[process] Error generating monthly report")
+		report.Status = model.MonthlyReportStatusFailed
+		report.Error = null.StringFrom(err.Error())
+	} else {
+		report.Status = model.MonthlyReportStatusCompleted
+		report.Error = null.String{}
+		report.GeneratedAt = null.TimeFrom(time.Now())
+	}
+
+	if err := s.report.Update(ctx, report); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("monthly_report_id", id.String()).Msg("[process] Error updating monthly report")
+		return
+	}
+
+	if report.Status == model.MonthlyReportStatusCompleted && report.Deliver && !report.DeliveredAt.Valid {
+		s.deliver(ctx, report, content)
+	}
+}
+
+// deliver emails the report to the student and to each guardian who did not
+// get the report of the period yet.
+func (s *MonthlyReportService) deliver(ctx context.Context, report *model.MonthlyReport, content []byte) {
+	monthYear := report.Start().Format("January 2006")
+
+	err := s.notification.MonthlyReportReady(ctx, report.Student.User, report.Student, monthYear, report.Filename.String, content)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("monthly_report_id", report.ID.String()).Msg("[deliver] Error sending monthly report to student")
+	}
+
+	links, err := s.guardian.GetLinks(ctx, model.GuardianStudentFilter{
+		StudentID:       report.StudentID,
+		Status:          model.GuardianStudentStatusActive,
+		ReportPeriodNot: report.Period,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("monthly_report_id", report.ID.String()).Msg("[deliver] Error getting guardians")
 	}
+
+	for _, link := range links {
+		err := s.notification.MonthlyReportReady(ctx, link.Guardian.User, report.Student, monthYear, report.Filename.String, content)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("guardian_student_id", link.ID.String()).Msg("[deliver] Error sending monthly report to guardian")
+			continue
+		}
+
+		link.LastReportPeriod = null.StringFrom(report.Period)
+		link.UpdatedAt = time.Now()
+		if err := s.guardian.UpdateLink(ctx, &link); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("guardian_student_id", link.ID.String()).Msg("[deliver] Error updating guardian student")
+		}
+	}
+
+	report.DeliveredAt = null.TimeFrom(time.Now())
+	report.UpdatedAt = time.Now()
+	if err := s.report.Update(ctx, report); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Str("monthly_report_id", report.ID.String()).Msg("[deliver] Error updating monthly report")
+	}
+}
+
+func (s *MonthlyReportService) response(report model.MonthlyReport) dto.MonthlyReportResponse {
+	resp := dto.NewMonthlyReportResponse(report)
+	if report.Status != model.MonthlyReportStatusCompleted || !report.FileKey.Valid {
+		return resp
+	}
+
+	url, err := s.file.PresignFile(report.FileKey.String, report.Filename.String, monthlyReportURLTTL)
+	if err != nil {
+		return resp
+	}
+
+	resp.DownloadURL = null.StringFrom(url)
+	resp.DownloadExpiresAt = null.TimeFrom(time.Now().Add(monthlyReportURLTTL))
+	return resp
+}
+
+// tutorStudent returns the student when the tutor behind the user had an
+// accepted session with them.
+func (s *MonthlyReportService) tutorStudent(ctx context.Context, userID, studentID uuid.UUID) (*model.Student, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, userID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[tutorStudent] Error getting tutor")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	sessions, err := s.bookingRepo.Count(ctx, model.BookingFilter{
+		TutorID:   tutor.ID,
+		StudentID: studentID,
+		StatusIn:  []model.BookingStatus{model.BookingStatusAccepted, model.BookingStatusCompleted},
+	})
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if sessions == 0 {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	student, err := s.studentRepo.GetByID(ctx, studentID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[tutorStudent] Error getting student")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if student == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	return student, nil
+}
+
+func (s *MonthlyReportService) canView(ctx context.Context, userID uuid.UUID, student model.Student) bool {
+	if student.UserID == userID {
+		return true
+	}
+
+	guardian, err := s.guardian.GetByUserID(ctx, userID)
+	if err == nil && guardian != nil {
+		links, err := s.guardian.GetLinks(ctx, model.GuardianStudentFilter{
+			GuardianID: guardian.ID,
+			StudentID:  student.ID,
+			Status:     model.GuardianStudentStatusActive,
+		})
+		return err == nil && len(links) > 0
+	}
+
+	_, err = s.tutorStudent(ctx, userID, student.ID)
+	return err == nil
 }
 
 // MonthlyReportData represents the data passed to the HTML template
 type MonthlyReportData struct {
-	StudentName string
-	MonthYear   string
-	Date        string
-	Sessions    []ReportSessionData
+	StudentName        string
+	MonthYear          string
+	Date               string
+	TotalSessions      int
+	AttendanceRate     string
+	TaskCompletionRate string
+	AverageScore       string
+	Subjects           []ReportSubjectData
+	LearningPlans      []ReportLearningPlanData
+	Sessions           []ReportSessionData
+}
+
+// ReportSubjectData is the score trend of a subject over the month.
+type ReportSubjectData struct {
+	Name         string
+	Sessions     int
+	AverageScore string
+	Chart        template.HTML
+}
+
+// ReportLearningPlanData is where a learning plan of the student stands at
+// the end of the month.
+type ReportLearningPlanData struct {
+	Subject    string
+	Title      string
+	TutorName  string
+	Achieved   string
+	ThisMonth  string
+	InProgress string
+	Overdue    string
 }
 
 type ReportSessionData struct {
 	Date          string
 	Subject       string
 	TutorName     string
-	Tasks         int
+	Tasks         string
 	AverageScore  string
 	ProgressNotes string
+	TutorComment  string
 }
 
+// reportScore is the average score of one session.
+type reportScore struct {
+	Date  time.Time
+	Score float64
+}
+
+// GenerateMonthlyReport renders the monthly report of the student as PDF.
 func (s *MonthlyReportService) GenerateMonthlyReport(ctx context.Context, studentID uuid.UUID, month int, year int) ([]byte, string, error) {
 	student, err := s.studentRepo.GetByID(ctx, studentID)
 	if err != nil || student == nil {
@@ -53,71 +562,37 @@ func (s *MonthlyReportService) GenerateMonthlyReport(ctx context.Context, studen
 		return nil, "", fmt.Errorf("student not found")
 	}
 
-	// Filter bookings for this student in the given month and year
-	// We'll construct a date range
 	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
 	endDate := startDate.AddDate(0, 1, 0).Add(-time.Second)
 
-	filter := model.BookingFilter{
+	// All bookings of the month at once, with their tasks and reports.
+	bookings, _, err := s.bookingRepo.Get(ctx, model.BookingFilter{
 		StudentID:          studentID,
-		Status:             model.BookingStatusAccepted,
-		BookingDateBetween: []string{startDate.Format("2006-01-02"), endDate.Format("2006-01-02")},
-		Pagination:         model.Pagination{Page: 1, PageSize: 100},
-	}
-
-	bookings, _, err := s.bookingRepo.Get(ctx, filter)
+		BookingDateBetween: []string{startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)},
+		WithProgress:       true,
+		Sort: model.Sort{
+			Sort:          "booking_date",
+			SortDirection: "asc",
+		},
+	})
 	if err != nil {
 		return nil, "", err
 	}
 
-	// For each booking, we need full details including tasks and reports
-	var sessionsData []ReportSessionData
-	for _, b := range bookings {
-		fullBooking, err := s.bookingRepo.GetByID(ctx, b.ID)
-		if err != nil || fullBooking == nil {
-			continue
-		}
-
-		taskCount := len(fullBooking.SessionTasks)
-
-		totalTaskScore := 0.0
-		scoredCount := 0
-		for _, task := range fullBooking.SessionTasks {
-			if len(task.TaskSubmissions) > 0 && !task.TaskSubmissions[0].Score.Decimal.IsZero() {
-				scoreFloat, _ := task.TaskSubmissions[0].Score.Decimal.Float64()
-				totalTaskScore += scoreFloat
-				scoredCount++
-			}
-		}
-
-		avgScoreStr := "-"
-		if scoredCount > 0 {
-			avgScoreStr = fmt.Sprintf("%.1f", totalTaskScore/float64(scoredCount))
-		}
-
-		progressNotes := "-"
-		if fullBooking.ReportBooking.ID != uuid.Nil && fullBooking.ReportBooking.Body != "" {
-			progressNotes = fullBooking.ReportBooking.Body
-		}
-
-		sessionsData = append(sessionsData, ReportSessionData{
-			Date:          fullBooking.BookingDate.Format("02/01/2006"),
-			Subject:       fullBooking.Course.Title,
-			TutorName:     fullBooking.Tutor.User.Name,
-			Tasks:         taskCount,
-			AverageScore:  avgScoreStr,
-			ProgressNotes: progressNotes,
-		})
+	plans, err := s.learningPlan.Get(ctx, model.LearningPlanFilter{
+		StudentID: studentID,
+		Status:    model.LearningPlanStatusActive,
+	})
+	if err != nil {
+		return nil, "", err
 	}
 
-	data := MonthlyReportData{
-		StudentName: student.User.Name,
-		MonthYear:   startDate.Format("January 2006"),
-		Date:        time.Now().Format("02/01/2006"),
-		Sessions:    sessionsData,
-	}
+	data := monthlyReportData(bookings, time.Now())
+	data.LearningPlans = reportLearningPlans(plans, startDate, endDate)
+	data.StudentName = student.User.Name
+	data.MonthYear = startDate.Format("January 2006")
+	data.Date = time.Now().Format("02/01/2006")
 
-	// Parse template
 	tmpl, err := template.ParseFiles("./templates/pdf/monthly_report/index.html")
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[GenerateMonthlyReport] failed to parse template")
@@ -151,3 +626,253 @@ func (s *MonthlyReportService) GenerateMonthlyReport(ctx context.Context, studen
 	filename := fmt.Sprintf("Report_%s_%s.pdf", student.User.Name, data.MonthYear)
 	return pdfg.Bytes(), filename, nil
 }
+
+// monthlyReportData summarizes the bookings of a month. The attendance rate
+// counts the completed sessions against every booking of the month the tutor
+// did not decline.
+func monthlyReportData(bookings []model.Booking, now time.Time) MonthlyReportData {
+	var (
+		data                  MonthlyReportData
+		scheduled, attended   int
+		totalTasks, doneTasks int
+		scoreSum              float64
+		scoreCount            int
+		subjects              = map[string][]reportScore{}
+		subjectSessions       = map[string]int{}
+	)
+
+	for _, booking := range bookings {
+		status := booking.GetStatus()
+		if status == model.BookingStatusDeclined {
+			continue
+		}
+
+		scheduled++
+		if status != model.BookingStatusCompleted || booking.BookingDate.After(now) {
+			continue
+		}
+
+		attended++
+
+		subject := booking.Course.Title
+		if booking.Course.CourseCategory.Name != "" {
+			subject = booking.Course.CourseCategory.Name
+		}
+		subjectSessions[subject]++
+
+		var (
+			tasks, done   int
+			sessionSum    float64
+			sessionScored int
+		)
+		for _, task := range booking.SessionTasks {
+			if task.DeletedAt.Valid {
+				continue
+			}
+
+			tasks++
+			for _, submission := range task.TaskSubmissions {
+				if submission.DeletedAt.Valid {
+					continue
+				}
+
+				done++
+				if submission.Score.Valid {
+					score, _ := submission.Score.Decimal.Float64()
+					sessionSum += score
+					sessionScored++
+				}
+				break
+			}
+		}
+
+		totalTasks += tasks
+		doneTasks += done
+
+		avgScore := "-"
+		if sessionScored > 0 {
+			average := sessionSum / float64(sessionScored)
+			avgScore = fmt.Sprintf("%.1f", average)
+			subjects[subject] = append(subjects[subject], reportScore{Date: booking.BookingDate, Score: average})
+			scoreSum += sessionSum
+			scoreCount += sessionScored
+		}
+
+		progressNotes := "-"
+		if booking.LessonNote != nil {
+			progressNotes = booking.LessonNote.Covered
+			if len(booking.LessonNote.Milestones) > 0 {
+				titles := make([]string, 0, len(booking.LessonNote.Milestones))
+				for _, milestone := range booking.LessonNote.Milestones {
+					titles = append(titles, milestone.Title)
+				}
+				progressNotes += " (Milestones: " + strings.Join(titles, ", ") + ")"
+			}
+		} else if booking.ReportBooking.ID != uuid.Nil && !booking.ReportBooking.DeletedAt.Valid {
+			if booking.ReportBooking.ProgressNotes.Valid && booking.ReportBooking.ProgressNotes.String != "" {
+				progressNotes = booking.ReportBooking.ProgressNotes.String
+			} else if booking.ReportBooking.Body != "" {
+				progressNotes = booking.ReportBooking.Body
+			}
+		}
+
+		tutorComment := "-"
+		if booking.LessonNote != nil && booking.LessonNote.NextSteps.Valid && strings.TrimSpace(booking.LessonNote.NextSteps.String) != "" {
+			tutorComment = booking.LessonNote.NextSteps.String
+		} else if booking.NotesStudent.Valid && strings.TrimSpace(booking.NotesStudent.String) != "" {
+			tutorComment = booking.NotesStudent.String
+		}
+
+		data.Sessions = append(data.Sessions, ReportSessionData{
+			Date:          booking.BookingDate.Format("02/01/2006"),
+			Subject:       subject,
+			TutorName:     booking.Tutor.User.Name,
+			Tasks:         fmt.Sprintf("%d/%d", done, tasks),
+			AverageScore:  avgScore,
+			ProgressNotes: progressNotes,
+			TutorComment:  tutorComment,
+		})
+	}
+
+	data.TotalSessions = attended
+	data.AttendanceRate = reportRate(attended, scheduled)
+	data.TaskCompletionRate = reportRate(doneTasks, totalTasks)
+	data.AverageScore = "-"
+	if scoreCount > 0 {
+		data.AverageScore = fmt.Sprintf("%.1f", scoreSum/float64(scoreCount))
+	}
+
+	names := make([]string, 0, len(subjectSessions))
+	for name := range subjectSessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		scores := subjects[name]
+		average := "-"
+		if len(scores) > 0 {
+			sum := 0.0
+			for _, score := range scores {
+				sum += score.Score
+			}
+			average = fmt.Sprintf("%.1f", sum/float64(len(scores)))
+		}
+
+		data.Subjects = append(data.Subjects, ReportSubjectData{
+			Name:         name,
+			Sessions:     subjectSessions[name],
+			AverageScore: average,
+			Chart:        scoreTrendChart(scores),
+		})
+	}
+
+	return data
+}
+
+// reportLearningPlans summarises the milestones of the plans at the end of
+// the month, listing those achieved during it.
+func reportLearningPlans(plans []model.LearningPlan, start, end time.Time) []ReportLearningPlanData {
+	data := make([]ReportLearningPlanData, 0, len(plans))
+	for _, plan := range plans {
+		var (
+			achieved                       int
+			thisMonth, inProgress, overdue []string
+		)
+		for _, milestone := range plan.Milestones {
+			if milestone.Status == model.MilestoneStatusAchieved {
+				achieved++
+				if !milestone.AchievedAt.Time.Before(start) && !milestone.AchievedAt.Time.After(end) {
+					thisMonth = append(thisMonth, milestone.Title)
+				}
+				continue
+			}
+
+			if milestone.IsOverdue(end) {
+				overdue = append(overdue, milestone.Title)
+			} else if milestone.Status == model.MilestoneStatusInProgress {
+				inProgress = append(inProgress, milestone.Title)
+			}
+		}
+
+		data = append(data, ReportLearningPlanData{
+			Subject:    plan.CourseCategory.Name,
+			Title:      plan.Title,
+			TutorName:  plan.Tutor.User.Name,
+			Achieved:   fmt.Sprintf("%d/%d", achieved, len(plan.Milestones)),
+			ThisMonth:  reportList(thisMonth),
+			InProgress: reportList(inProgress),
+			Overdue:    reportList(overdue),
+		})
+	}
+
+	return data
+}
+
+func reportList(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ", ")
+}
+
+func reportRate(count, total int) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return decimal.NewFromInt(int64(count)).
+		Div(decimal.NewFromInt(int64(total))).
+		Mul(decimal.NewFromInt(100)).
+		StringFixed(0) + "%"
+}
+
+// scoreTrendChart draws the session scores of a subject as an inline SVG line
+// chart, which wkhtmltopdf renders without scripts.
+func scoreTrendChart(scores []reportScore) template.HTML {
+	const (
+		width   = 360.0
+		height  = 120.0
+		padding = 24.0
+	)
+
+	if len(scores) == 0 {
+		return template.HTML(`<div class="no-chart">No scored tasks</div>`)
+	}
+
+	var (
+		svg    strings.Builder
+		points = make([]string, 0, len(scores))
+		step   = 0.0
+	)
+	if len(scores) > 1 {
+		step = (width - 2*padding) / float64(len(scores)-1)
+	}
+
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f">`, width, height)
+	fmt.Fprintf(&svg, `<line x1="%.0f" y1="%.0f" x2="%.0f" y2="%.0f" stroke="#ddd"/>`, padding, height-padding, width-padding, height-padding)
+	for i, score := range scores {
+		value := score.Score
+		if value < 0 {
+			value = 0
+		}
+		if value > 100 {
+			value = 100
+		}
+
+		x := padding + step*float64(i)
+		if len(scores) == 1 {
+			x = width / 2
+		}
+		y := height - padding - value/100*(height-2*padding)
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+
+		fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="3" fill="#0056b3"/>`, x, y)
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" font-size="9" text-anchor="middle" fill="#333">%.0f</text>`, x, y-6, score.Score)
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%.0f" font-size="9" text-anchor="middle" fill="#888">%s</text>`, x, height-padding+12, score.Date.Format("02/01"))
+	}
+	fmt.Fprintf(&svg, `<polyline points="%s" fill="none" stroke="#0056b3" stroke-width="2"/>`, strings.Join(points, " "))
+	svg.WriteString(`</svg>`)
+
+	return template.HTML(svg.String())
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func scoredTask(scores ...string) model.SessionTask {
+	task := model.SessionTask{ID: uuid.New()}
+	for _, score := range scores {
+		submission := model.TaskSubmission{}
+		if score != "" {
+			submission.Score = decimal.NewNullDecimal(decimal.RequireFromString(score))
+		}
+		task.TaskSubmissions = append(task.TaskSubmissions, submission)
+	}
+	return task
+}
+
+func TestMonthlyReportData(t *testing.T) {
+	var (
+		now  = time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+		math = model.Course{Title: "Kalkulus", CourseCategory: model.CourseCategory{Name: "Matematika"}}
+		bio  = model.Course{Title: "Biologi Dasar"}
+	)
+
+	bookings := []model.Booking{
+		{
+			ID:           uuid.New(),
+			Status:       model.BookingStatusCompleted,
+			BookingDate:  time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			Course:       math,
+			SessionTasks: []model.SessionTask{scoredTask("80"), scoredTask()},
+			NotesStudent: null.StringFrom("Latih soal integral"),
+			ReportBooking: model.ReportBooking{
+				ID:            uuid.New(),
+				Body:          "Belajar turunan",
+				ProgressNotes: null.StringFrom("Paham aturan rantai"),
+			},
+		},
+		{
+			ID:           uuid.New(),
+			Status:       model.BookingStatusCompleted,
+			BookingDate:  time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+			Course:       math,
+			SessionTasks: []model.SessionTask{scoredTask("100"), scoredTask("")},
+		},
+		{
+			ID:           uuid.New(),
+			Status:       model.BookingStatusCompleted,
+			BookingDate:  time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+			Course:       bio,
+			SessionTasks: []model.SessionTask{{ID: uuid.New(), DeletedAt: null.TimeFrom(now)}},
+		},
+		// Counted as scheduled but not attended
+		{ID: uuid.New(), Status: model.BookingStatusNoShow, BookingDate: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), Course: bio},
+		// Left out of the attendance rate
+		{ID: uuid.New(), Status: model.BookingStatusDeclined, BookingDate: time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC), Course: bio},
+	}
+
+	data := monthlyReportData(bookings, now)
+
+	if data.TotalSessions != 3 {
+		t.Errorf("TotalSessions = %d, want 3", data.TotalSessions)
+	}
+	if data.AttendanceRate != "75%" {
+		t.Errorf("AttendanceRate = %s, want 75%%", data.AttendanceRate)
+	}
+	// 3 of the 4 tasks that were not deleted have a submission
+	if data.TaskCompletionRate != "75%" {
+		t.Errorf("TaskCompletionRate = %s, want 75%%", data.TaskCompletionRate)
+	}
+	if data.AverageScore != "90.0" {
+		t.Errorf("AverageScore = %s, want 90.0", data.AverageScore)
+	}
+
+	if len(data.Subjects) != 2 || data.Subjects[0].Name != "Biologi Dasar" || data.Subjects[1].Name != "Matematika" {
+		t.Fatalf("Subjects = %+v, want Biologi Dasar and Matematika in order", data.Subjects)
+	}
+	if data.Subjects[0].AverageScore != "-" || data.Subjects[1].Sessions != 2 || data.Subjects[1].AverageScore != "90.0" {
+		t.Errorf("Subjects = %+v, want no score for biology and 90.0 over 2 sessions for maths", data.Subjects)
+	}
+
+	if len(data.Sessions) != 3 {
+		t.Fatalf("Sessions = %d, want the 3 attended", len(data.Sessions))
+	}
+	first := data.Sessions[0]
+	if first.Date != "02/03/2026" || first.Tasks != "1/2" || first.AverageScore != "80.0" {
+		t.Errorf("first session = %+v, want 02/03/2026 with 1/2 tasks scored 80.0", first)
+	}
+	if first.ProgressNotes != "Paham aturan rantai" || first.TutorComment != "Latih soal integral" {
+		t.Errorf("first session notes = (%q, %q), want the progress notes and the tutor note", first.ProgressNotes, first.TutorComment)
+	}
+	if data.Sessions[2].ProgressNotes != "-" || data.Sessions[2].Tasks != "0/0" {
+		t.Errorf("third session = %+v, want no notes and no tasks", data.Sessions[2])
+	}
+}
+
+func TestMonthlyReportDataSkipsFutureSessions(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	bookings := []model.Booking{
+		{Status: model.BookingStatusCompleted, BookingDate: now.AddDate(0, 0, 1)},
+	}
+
+	data := monthlyReportData(bookings, now)
+	if data.TotalSessions != 0 || data.AttendanceRate != "0%" || data.TaskCompletionRate != "-" || data.AverageScore != "-" {
+		t.Errorf("monthlyReportData() = %+v, want nothing attended yet", data)
+	}
+}
+
+func TestReportRate(t *testing.T) {
+	tests := []struct {
+		count, total int
+		want         string
+	}{
+		{count: 0, total: 0, want: "-"},
+		{count: 0, total: 4, want: "0%"},
+		{count: 2, total: 3, want: "67%"},
+		{count: 5, total: 5, want: "100%"},
+	}
+
+	for _, tt := range tests {
+		if got := reportRate(tt.count, tt.total); got != tt.want {
+			t.Errorf("reportRate(%d, %d) = %s, want %s", tt.count, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestScoreTrendChart(t *testing.T) {
+	if got := string(scoreTrendChart(nil)); !strings.Contains(got, "No scored tasks") {
+		t.Errorf("scoreTrendChart(nil) = %s, want the empty message", got)
+	}
+
+	scores := []reportScore{
+		{Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Score: 0},
+		{Date: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), Score: 150},
+	}
+	got := string(scoreTrendChart(scores))
+	if !strings.HasPrefix(got, "<svg") || strings.Count(got, "<circle") != 2 {
+		t.Errorf("scoreTrendChart() = %s, want an SVG with a point per score", got)
+	}
+	// Scores are clamped to 0-100, the highest point sitting on the top padding
+	if !strings.Contains(got, `cy="24.0"`) || !strings.Contains(got, `cy="96.0"`) {
+		t.Errorf("scoreTrendChart() = %s, want points on the bottom and top of the chart", got)
+	}
+}
+
+func TestMonthlyReportDataLessonNotes(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	bookings := []model.Booking{{
+		Status:        model.BookingStatusCompleted,
+		BookingDate:   time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		NotesStudent:  null.StringFrom("Catatan lama"),
+		ReportBooking: model.ReportBooking{ID: uuid.New(), Body: "Laporan lama"},
+		LessonNote: &model.LessonNote{
+			Covered:    "Persamaan kuadrat",
+			NextSteps:  null.StringFrom("Kerjakan latihan bab 3"),
+			Milestones: []model.LearningPlanMilestone{{Title: "Aljabar"}, {Title: "Fungsi"}},
+		},
+	}}
+
+	session := monthlyReportData(bookings, now).Sessions[0]
+	if session.ProgressNotes != "Persamaan kuadrat (Milestones: Aljabar, Fungsi)" {
+		t.Errorf("ProgressNotes = %q, want the lesson note with its milestones", session.ProgressNotes)
+	}
+	if session.TutorComment != "Kerjakan latihan bab 3" {
+		t.Errorf("TutorComment = %q, want the next steps of the lesson note", session.TutorComment)
+	}
+}
+
+func TestReportLearningPlans(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 1, 0).Add(-time.Second)
+	plans := []model.LearningPlan{
+		{
+			Title:          "Persiapan UTBK",
+			CourseCategory: model.CourseCategory{Name: "Matematika"},
+			Milestones: []model.LearningPlanMilestone{
+				{Title: "Aljabar", Status: model.MilestoneStatusAchieved, AchievedAt: null.TimeFrom(start.AddDate(0, -1, 0))},
+				{Title: "Fungsi", Status: model.MilestoneStatusAchieved, AchievedAt: null.TimeFrom(start.AddDate(0, 0, 9))},
+				{Title: "Trigonometri", Status: model.MilestoneStatusInProgress, TargetDate: null.TimeFrom(start.AddDate(0, 0, 14))},
+				{Title: "Statistika", Status: model.MilestoneStatusInProgress},
+				{Title: "Peluang", Status: model.MilestoneStatusNotStarted},
+			},
+		},
+		{Title: "Membaca", CourseCategory: model.CourseCategory{Name: "Bahasa Inggris"}},
+	}
+
+	data := reportLearningPlans(plans, start, end)
+	if len(data) != 2 {
+		t.Fatalf("reportLearningPlans() = %+v, want the 2 plans", data)
+	}
+
+	want := ReportLearningPlanData{
+		Subject:    "Matematika",
+		Title:      "Persiapan UTBK",
+		Achieved:   "2/5",
+		ThisMonth:  "Fungsi",
+		InProgress: "Statistika",
+		Overdue:    "Trigonometri",
+	}
+	if data[0] != want {
+		t.Errorf("reportLearningPlans()[0] = %+v, want %+v", data[0], want)
+	}
+
+	empty := data[1]
+	if empty.Achieved != "0/0" || empty.ThisMonth != "-" || empty.InProgress != "-" || empty.Overdue != "-" {
+		t.Errorf("reportLearningPlans()[1] = %+v, want a plan without milestones", empty)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"html/template"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -569,10 +570,68 @@ func (s *NotificationService) SubmitReviewTutor(ctx context.Context, review mode
 	return nil
 }
 
-func (s *NotificationService) PaymentCreated(ctx context.Context, student model.Student, payment model.Payment) error {
+func (s *NotificationService) ReplyReviewStudent(ctx context.Context, review model.TutorReview) error {
 	notification := &model.Notification{
 		ID:           uuid.New(),
-		UserID:       student.UserID,
+		UserID:       review.Student.UserID,
+		Type:         model.NotificationTypeInfo,
+		Title:        "Reply from Tutor",
+		Message:      fmt.Sprintf("%s membalas ulasan kamu. Yuk, cek sekarang!", review.Tutor.User.Name),
+		Link:         s.config.Frontend.BaseURL + s.config.Frontend.Account,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+
+	err := s.notification.Create(ctx, notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyReviewStudent] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+func (s *NotificationService) TutorLevelChanged(ctx context.Context, tutor model.Tutor, history model.TutorLevelHistory, promoted bool) error {
+	notification := &model.Notification{
+		ID:           uuid.New(),
+		UserID:       tutor.UserID,
+		Type:         model.NotificationTypeSuccess,
+		Title:        "Level Naik!",
+		Message:      fmt.Sprintf("Selamat! Level kamu naik menjadi %s", history.ToLevel),
+		Link:         s.config.Frontend.BaseURL + s.config.Frontend.Account,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+
+	if !promoted {
+		notification.Type = model.NotificationTypeWarning
+		notification.Title = "Level Turun"
+		notification.Message = fmt.Sprintf("Level kamu turun menjadi %s. Yuk, cek kriteria level untuk naik kembali", history.ToLevel)
+	}
+
+	err := s.notification.Create(ctx, notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[TutorLevelChanged] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+func (s *NotificationService) PaymentCreated(ctx context.Context, user model.User, payment model.Payment) error {
+	notification := &model.Notification{
+		ID:           uuid.New(),
+		UserID:       user.ID,
 		Type:         model.NotificationTypeInfo,
 		Title:        "Menunggu Pembayaran User Premium",
 		Message:      "Menunggu Pembayaran User Premium",
@@ -591,7 +650,7 @@ func (s *NotificationService) PaymentCreated(ctx context.Context, student model.
 		logger.ErrorCtx(ctx).Err(err).Msg("[PaymentCreated] Error creating notification")
 	}
 
-	err = s.email.SendPaymentCreatedEmail(ctx, student, payment)
+	err = s.email.SendPaymentCreatedEmail(ctx, user, payment)
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitReviewTutor] Error sending email")
 	}
@@ -599,10 +658,10 @@ func (s *NotificationService) PaymentCreated(ctx context.Context, student model.
 	return nil
 }
 
-func (s *NotificationService) PaymentCompleted(ctx context.Context, student model.Student, payment model.Payment) error {
+func (s *NotificationService) PaymentCompleted(ctx context.Context, user model.User, payment model.Payment) error {
 	notification := &model.Notification{
 		ID:           uuid.New(),
-		UserID:       student.UserID,
+		UserID:       user.ID,
 		Type:         model.NotificationTypeInfo,
 		Title:        "Pembayaran Berhasil!",
 		Message:      "Pembayaran Berhasil!",
@@ -621,7 +680,7 @@ func (s *NotificationService) PaymentCompleted(ctx context.Context, student mode
 		logger.ErrorCtx(ctx).Err(err).Msg("[PaymentCompleted] Error creating notification")
 	}
 
-	err = s.email.SendPaymentCompletedEmail(ctx, student, payment)
+	err = s.email.SendPaymentCompletedEmail(ctx, user, payment)
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[PaymentCompleted] Error sending email")
 	}
@@ -629,6 +688,593 @@ func (s *NotificationService) PaymentCompleted(ctx context.Context, student mode
 	return nil
 }
 
+func (s *NotificationService) PaymentRefunded(ctx context.Context, user model.User, refund model.PaymentRefund) error {
+	notification := &model.Notification{
+		ID:           uuid.New(),
+		UserID:       user.ID,
+		Type:         model.NotificationTypeInfo,
+		Title:        "Dana Berhasil Dikembalikan",
+		Message:      fmt.Sprintf("Pengembalian dana sebesar Rp%s telah diproses dengan nomor nota kredit %s", refund.Amount.StringFixed(0), refund.CreditNoteNumber),
+		Link:         s.config.Frontend.BaseURL + s.config.Frontend.Account,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+
+	err := s.notification.Create(ctx, notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[PaymentRefunded] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// GiftCodesIssued sends the guardian the codes of a paid gift payment.
+func (s *NotificationService) GiftCodesIssued(ctx context.Context, user model.User, codes []model.GiftCode) error {
+	list := make([]string, 0, len(codes))
+	for _, code := range codes {
+		list = append(list, code.Code)
+	}
+
+	notification := &model.Notification{
+		ID:           uuid.New(),
+		UserID:       user.ID,
+		Type:         model.NotificationTypeSuccess,
+		Title:        "Kode Hadiah Premium Siap Dibagikan",
+		Message:      fmt.Sprintf("Kode hadiah premium Anda: %s", strings.Join(list, ", ")),
+		Link:         s.config.Frontend.BaseURL + s.config.Frontend.Account,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+
+	err := s.notification.Create(ctx, notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GiftCodesIssued] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// GuardianLinkRequested asks the student to accept a guardian linking to
+// their account.
+func (s *NotificationService) GuardianLinkRequested(ctx context.Context, link model.GuardianStudent) error {
+	notification := &model.Notification{
+		ID:           uuid.New(),
+		UserID:       link.Student.UserID,
+		Type:         model.NotificationTypeInfo,
+		Title:        "Permintaan Wali Murid",
+		Message:      fmt.Sprintf("%s ingin terhubung sebagai wali Anda. Terima permintaan ini di halaman akun.", link.Guardian.User.Name),
+		Link:         s.config.Frontend.BaseURL + s.config.Frontend.Account,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+
+	err := s.notification.Create(ctx, notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GuardianLinkRequested] Error creating notification")
+		return err
+	}
+
+	if !link.ConfirmationToken.Valid {
+		return nil
+	}
+
+	confirmLink := fmt.Sprintf("%s%s?token=%s", s.config.Frontend.BaseURL, s.config.Frontend.GuardianConfirm, link.ConfirmationToken.String)
+	body, err := s.generalEmailBody(notification.Title, link.Student.User.Name, fmt.Sprintf(
+		`%s ingin terhubung sebagai wali Anda dan dapat melihat jadwal, tugas, nilai serta laporan bulanan Anda. Jika Anda setuju, konfirmasi melalui tautan berikut sebelum %s: <a href="%s">%s</a>`,
+		template.HTMLEscapeString(link.Guardian.User.Name),
+		link.TokenExpiresAt.Time.Format("02/01/2006"),
+		confirmLink,
+		confirmLink,
+	))
+	if err != nil {
+		return err
+	}
+
+	err = s.email.SendEmail(ctx, link.Student.User.Email, notification.Title, body)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GuardianLinkRequested] Error sending confirmation email")
+		return err
+	}
+
+	return nil
+}
+
+// GuardianLinkAccepted tells the guardian the student accepted the link.
+func (s *NotificationService) GuardianLinkAccepted(ctx context.Context, link model.GuardianStudent) error {
+	notification := &model.Notification{
+		ID:           uuid.New(),
+		UserID:       link.Guardian.UserID,
+		Type:         model.NotificationTypeSuccess,
+		Title:        "Permintaan Wali Murid Diterima",
+		Message:      fmt.Sprintf("%s telah menerima Anda sebagai wali", link.Student.User.Name),
+		Link:         s.config.Frontend.BaseURL + s.config.Frontend.Account,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+
+	err := s.notification.Create(ctx, notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GuardianLinkAccepted] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// MonthlyReportReady emails the monthly report of the student to the
+// student or one of their guardians.
+func (s *NotificationService) MonthlyReportReady(ctx context.Context, recipient model.User, student model.Student, monthYear, filename string, report []byte) error {
+	subject := fmt.Sprintf("Laporan Bulanan %s - %s", student.User.Name, monthYear)
+
+	learner := "Anda"
+	if recipient.ID != student.UserID {
+		learner = template.HTMLEscapeString(student.User.Name)
+	}
+
+	body, err := s.generalEmailBody(subject, recipient.Name, fmt.Sprintf(
+		"Terlampir laporan perkembangan belajar %s selama %s.",
+		learner,
+		monthYear,
+	))
+	if err != nil {
+		return err
+	}
+
+	err = s.email.SendEmailWithAttachment(ctx, recipient.Email, subject, body, filename, report)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[MonthlyReportReady] Error sending email")
+		return err
+	}
+
+	return nil
+}
+
+// TaskAssigned tells the student about a new task on their booking.
+func (s *NotificationService) TaskAssigned(ctx context.Context, booking model.Booking, task model.SessionTask) error {
+	message := fmt.Sprintf("%s memberikan tugas baru \"%s\"", booking.Tutor.User.Name, task.Title)
+	if task.DueAt.Valid {
+		message += fmt.Sprintf(", kumpulkan sebelum %s", task.DueAt.Time.Format("02/01/2006 15:04"))
+	}
+
+	notification := s.taskNotification(booking.Student.UserID, s.config.Frontend.BaseURL+s.config.Frontend.StudentTasks)
+	notification.Title = "Tugas Baru"
+	notification.Message = message
+
+	err := s.notification.Create(ctx, &notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[TaskAssigned] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// LessonNoteAdded tells the student their tutor wrote the lesson note of a
+// session.
+func (s *NotificationService) LessonNoteAdded(ctx context.Context, booking model.Booking) error {
+	notification := s.taskNotification(booking.Student.UserID, s.config.Frontend.BaseURL+s.config.Frontend.LearningPlans)
+	notification.Title = "Catatan Pelajaran"
+	notification.Message = fmt.Sprintf("%s menulis catatan pelajaran untuk sesi %s", booking.Tutor.User.Name, booking.BookingDate.Format("02/01/2006"))
+
+	err := s.notification.Create(ctx, &notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[LessonNoteAdded] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// MilestoneAchieved tells the student they reached a milestone of their
+// learning plan.
+func (s *NotificationService) MilestoneAchieved(ctx context.Context, plan model.LearningPlan, milestone model.LearningPlanMilestone) error {
+	notification := s.taskNotification(plan.Student.UserID, s.config.Frontend.BaseURL+s.config.Frontend.LearningPlans)
+	notification.Type = model.NotificationTypeSuccess
+	notification.Title = "Target Tercapai"
+	notification.Message = fmt.Sprintf("Selamat! Kamu mencapai target \"%s\" pada rencana belajar %s", milestone.Title, plan.Title)
+
+	err := s.notification.Create(ctx, &notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[MilestoneAchieved] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// TaskSubmissionReceived tells the tutor a student submitted a task, which
+// is now in their grading queue.
+func (s *NotificationService) TaskSubmissionReceived(ctx context.Context, booking model.Booking, task model.SessionTask, submission model.TaskSubmission) error {
+	message := fmt.Sprintf("%s mengumpulkan tugas \"%s\"", booking.Student.User.Name, task.Title)
+	if submission.IsLate {
+		message += " setelah tenggat waktu"
+	}
+
+	notification := s.taskNotification(booking.Tutor.UserID, s.config.Frontend.MentorBaseURL+s.config.Frontend.MentorTasks)
+	notification.Title = "Tugas Dikumpulkan"
+	notification.Message = message + ". Yuk, beri nilai!"
+
+	err := s.notification.Create(ctx, &notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[TaskSubmissionReceived] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// QuizCompleted tells the tutor the student finished the quiz of a task,
+// with the score it was graded with.
+func (s *NotificationService) QuizCompleted(ctx context.Context, booking model.Booking, task model.SessionTask, submission model.TaskSubmission) error {
+	notification := s.taskNotification(booking.Tutor.UserID, s.config.Frontend.MentorBaseURL+s.config.Frontend.MentorTasks)
+	notification.Title = "Kuis Selesai"
+	notification.Message = fmt.Sprintf("%s menyelesaikan kuis tugas \"%s\" dengan nilai %s",
+		booking.Student.User.Name,
+		task.Title,
+		submission.Score.Decimal.StringFixed(2),
+	)
+
+	err := s.notification.Create(ctx, &notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[QuizCompleted] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// TaskGraded tells the student their submission has been scored.
+func (s *NotificationService) TaskGraded(ctx context.Context, booking model.Booking, task model.SessionTask, submission model.TaskSubmission) error {
+	notification := s.taskNotification(booking.Student.UserID, s.config.Frontend.BaseURL+s.config.Frontend.StudentTasks)
+	notification.Type = model.NotificationTypeSuccess
+	notification.Title = "Tugas Dinilai"
+	notification.Message = fmt.Sprintf("%s memberi nilai %s untuk tugas \"%s\". Yuk, cek umpan baliknya!",
+		booking.Tutor.User.Name,
+		submission.Score.Decimal.StringFixed(2),
+		task.Title,
+	)
+
+	err := s.notification.Create(ctx, &notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[TaskGraded] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// GroupAnnouncement delivers the announcement of a tutor to the members of
+// their group.
+func (s *NotificationService) GroupAnnouncement(ctx context.Context, tutor model.Tutor, group model.MentorGroup, announcement model.MentorGroupAnnouncement, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	notifications := make([]model.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notification := s.taskNotification(userID, s.config.Frontend.BaseURL)
+		notification.Title = fmt.Sprintf("[%s] %s", group.Name, announcement.Title)
+		notification.Message = fmt.Sprintf("%s: %s", tutor.User.Name, announcement.Message)
+		notification.CreatedBy = tutor.UserID
+		notification.UpdatedBy = tutor.UserID
+		notifications = append(notifications, notification)
+	}
+
+	err := s.notification.BulkCreate(ctx, notifications)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GroupAnnouncement] Error creating notifications")
+		return err
+	}
+
+	return nil
+}
+
+// bookingNotification addresses the tutor or the student of a booking with a
+// link to the session on their side.
+func (s *NotificationService) bookingNotification(booking model.Booking, party model.AttendanceParty) model.Notification {
+	if party == model.AttendancePartyTutor {
+		return s.taskNotification(booking.Tutor.UserID, s.config.Frontend.MentorBaseURL)
+	}
+	return s.taskNotification(booking.Student.UserID, fmt.Sprintf(s.config.Frontend.BaseURL+s.config.Frontend.BookingDetail, booking.ID.String()))
+}
+
+// NoShowReported tells the party reported absent from a session until when
+// they can dispute it.
+func (s *NotificationService) NoShowReported(ctx context.Context, booking model.Booking, attendance model.BookingAttendance) error {
+	reporter := booking.Tutor.User.Name
+	if attendance.NoShowParty.String == string(model.AttendancePartyTutor) {
+		reporter = booking.Student.User.Name
+	}
+
+	notification := s.bookingNotification(booking, model.AttendanceParty(attendance.NoShowParty.String))
+	notification.Type = model.NotificationTypeWarning
+	notification.Title = "Dilaporkan Tidak Hadir"
+	notification.Message = fmt.Sprintf("%s melaporkan kamu tidak hadir di sesi %s pada %s. Jika kamu hadir, ajukan keberatan sebelum %s",
+		reporter,
+		booking.Course.Title,
+		booking.StartsAt().Format("02/01/2006 15:04"),
+		attendance.DisputeUntil.Time.Format("02/01/2006 15:04"),
+	)
+
+	err := s.notification.Create(ctx, &notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[NoShowReported] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// NoShowResolved tells both parties of a session how the dispute of the
+// no-show was settled.
+func (s *NotificationService) NoShowResolved(ctx context.Context, booking model.Booking, held bool) error {
+	message := fmt.Sprintf("Keberatan atas laporan tidak hadir di sesi %s telah ditinjau. ", booking.Course.Title)
+	if held {
+		message += "Sesi dinyatakan telah berlangsung."
+	} else {
+		message += "Laporan tidak hadir tetap berlaku."
+	}
+
+	for _, party := range []model.AttendanceParty{model.AttendancePartyTutor, model.AttendancePartyStudent} {
+		notification := s.bookingNotification(booking, party)
+		notification.Title = "Keberatan Ditinjau"
+		notification.Message = message
+
+		err := s.notification.Create(ctx, &notification)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[NoShowResolved] Error creating notification")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GroupSessionConfirmed tells the students of a group session and its tutor
+// that enough students booked for the session to take place.
+func (s *NotificationService) GroupSessionConfirmed(ctx context.Context, session model.GroupSession, seats []model.Booking) error {
+	if len(seats) == 0 {
+		return nil
+	}
+
+	slot := groupSessionSlot(session)
+	notifications := make([]model.Notification, 0, len(seats)+1)
+	for _, seat := range seats {
+		notification := s.bookingNotification(seat, model.AttendancePartyStudent)
+		notification.Type = model.NotificationTypeSuccess
+		notification.Title = "Kelas Grup Terkonfirmasi"
+		notification.Message = fmt.Sprintf("Kelas grup %s %s sudah terkonfirmasi dengan %d peserta. Sampai jumpa di kelas!", session.Course.Title, slot, len(seats))
+		notifications = append(notifications, notification)
+	}
+
+	notification := s.bookingNotification(seats[0], model.AttendancePartyTutor)
+	notification.Type = model.NotificationTypeSuccess
+	notification.Title = "Kelas Grup Terkonfirmasi"
+	notification.Message = fmt.Sprintf("Kelas grup %s %s terkonfirmasi dengan %d dari %d kursi terisi.", session.Course.Title, slot, len(seats), session.Capacity)
+	notifications = append(notifications, notification)
+
+	err := s.notification.BulkCreate(ctx, notifications)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GroupSessionConfirmed] Error creating notifications")
+		return err
+	}
+
+	return nil
+}
+
+// GroupSessionCancelled tells the students of a group session and its tutor
+// that the session was cancelled because too few students booked by the
+// cutoff.
+func (s *NotificationService) GroupSessionCancelled(ctx context.Context, session model.GroupSession, seats []model.Booking) error {
+	if len(seats) == 0 {
+		return nil
+	}
+
+	slot := groupSessionSlot(session)
+	notifications := make([]model.Notification, 0, len(seats)+1)
+	for _, seat := range seats {
+		notification := s.bookingNotification(seat, model.AttendancePartyStudent)
+		notification.Type = model.NotificationTypeWarning
+		notification.Title = "Kelas Grup Dibatalkan"
+		notification.Message = fmt.Sprintf("Kelas grup %s %s dibatalkan karena peserta belum mencapai minimal %d orang. Yuk, pilih jadwal lain!", session.Course.Title, slot, session.MinAttendees)
+		notifications = append(notifications, notification)
+	}
+
+	notification := s.bookingNotification(seats[0], model.AttendancePartyTutor)
+	notification.Type = model.NotificationTypeWarning
+	notification.Title = "Kelas Grup Dibatalkan"
+	notification.Message = fmt.Sprintf("Kelas grup %s %s dibatalkan karena hanya %d dari minimal %d peserta yang mendaftar.", session.Course.Title, slot, len(seats), session.MinAttendees)
+	notifications = append(notifications, notification)
+
+	err := s.notification.BulkCreate(ctx, notifications)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GroupSessionCancelled] Error creating notifications")
+		return err
+	}
+
+	return nil
+}
+
+// groupSessionSlot describes when the group session takes place, e.g. "pada
+// 21/10/2026 pukul 16:00 WIB".
+func groupSessionSlot(session model.GroupSession) string {
+	start := session.StartsAt()
+	return fmt.Sprintf("pada %s pukul %s %s", start.Format("02/01/2006"), start.Format("15:04"), session.Timezone)
+}
+
+func (s *NotificationService) taskNotification(userID uuid.UUID, link string) model.Notification {
+	return model.Notification{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Type:         model.NotificationTypeInfo,
+		Link:         link,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+}
+
+// generalEmailBody renders the general email template.
+func (s *NotificationService) generalEmailBody(subject, name, body string) (string, error) {
+	tmpl, err := template.ParseFiles("./templates/email/general.html")
+	if err != nil {
+		return "", fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"subject": subject,
+		"name":    name,
+		"body":    template.HTML(body),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute email template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// PayoutSettled tells the mentor whether the withdrawal reached their bank
+// account or failed and went back to their balance.
+func (s *NotificationService) PayoutSettled(ctx context.Context, withdrawal model.WithdrawalRequest) error {
+	notification := &model.Notification{
+		ID:           uuid.New(),
+		UserID:       withdrawal.Tutor.UserID,
+		Type:         model.NotificationTypeSuccess,
+		Title:        "Penarikan Dana Berhasil",
+		Message:      fmt.Sprintf("Penarikan dana sebesar Rp%s telah dikirim ke rekening %s %s", withdrawal.Amount.StringFixed(0), withdrawal.BankCode, withdrawal.AccountNumber),
+		Link:         s.config.Frontend.MentorBaseURL,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+
+	if withdrawal.Status == model.WithdrawalStatusFailed {
+		notification.Type = model.NotificationTypeError
+		notification.Title = "Penarikan Dana Gagal"
+		notification.Message = fmt.Sprintf("Penarikan dana sebesar Rp%s ke rekening %s %s gagal. Dana telah dikembalikan ke saldo kamu", withdrawal.Amount.StringFixed(0), withdrawal.BankCode, withdrawal.AccountNumber)
+	}
+
+	err := s.notification.Create(ctx, notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[PayoutSettled] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+// SubscriptionRenewalReminder tells students their subscription period ends
+// soon. Subscriptions cancelled at period end are told premium stops.
+func (s *NotificationService) SubscriptionRenewalReminder(ctx context.Context, subscriptions []model.Subscription) error {
+	notifications := []model.Notification{}
+	for _, subscription := range subscriptions {
+		notification := s.subscriptionNotification(subscription)
+		notification.Title = "Langganan Premium Segera Diperpanjang"
+		notification.Message = fmt.Sprintf("%s kamu akan diperpanjang otomatis pada %s", subscription.Name(), subscription.EndDate.Format("02/01/2006"))
+
+		switch {
+		case subscription.CancelAtPeriodEnd:
+			notification.Type = model.NotificationTypeWarning
+			notification.Title = "Langganan Premium Segera Berakhir"
+			notification.Message = fmt.Sprintf("%s kamu berakhir pada %s", subscription.Name(), subscription.EndDate.Format("02/01/2006"))
+		case subscription.Status == model.SubscriptionStatusTrialing:
+			notification.Title = "Masa Percobaan Segera Berakhir"
+			notification.Message = fmt.Sprintf("Masa percobaan %s kamu berakhir pada %s. Pembayaran pertama akan ditagih otomatis", subscription.Name(), subscription.EndDate.Format("02/01/2006"))
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	err := s.notification.BulkCreate(ctx, notifications)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubscriptionRenewalReminder] Error creating notifications")
+		return err
+	}
+
+	return nil
+}
+
+func (s *NotificationService) SubscriptionPaymentFailed(ctx context.Context, subscription model.Subscription) error {
+	notification := s.subscriptionNotification(subscription)
+	notification.Type = model.NotificationTypeError
+	notification.Title = "Pembayaran Langganan Gagal"
+	notification.Message = fmt.Sprintf("Pembayaran %s kamu gagal. Premium tetap aktif sampai %s, segera perbarui metode pembayaran kamu", subscription.Name(), subscription.GraceUntil.Time.Format("02/01/2006"))
+
+	err := s.notification.Create(ctx, &notification)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubscriptionPaymentFailed] Error creating notification")
+		return err
+	}
+
+	return nil
+}
+
+func (s *NotificationService) SubscriptionEnded(ctx context.Context, subscriptions []model.Subscription) error {
+	notifications := []model.Notification{}
+	for _, subscription := range subscriptions {
+		notification := s.subscriptionNotification(subscription)
+		notification.Type = model.NotificationTypeWarning
+		notification.Title = "Langganan Premium Berakhir"
+		notification.Message = fmt.Sprintf("%s kamu telah berakhir. Berlangganan kembali untuk menikmati fitur premium", subscription.Name())
+		notifications = append(notifications, notification)
+	}
+
+	err := s.notification.BulkCreate(ctx, notifications)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubscriptionEnded] Error creating notifications")
+		return err
+	}
+
+	return nil
+}
+
+func (s *NotificationService) subscriptionNotification(subscription model.Subscription) model.Notification {
+	return model.Notification{
+		ID:           uuid.New(),
+		UserID:       subscription.Student.UserID,
+		Type:         model.NotificationTypeInfo,
+		Link:         s.config.Frontend.BaseURL + s.config.Frontend.Account,
+		IsRead:       false,
+		IsDismissed:  false,
+		IsDeleteable: true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		CreatedBy:    uuid.MustParse(model.SystemID),
+		UpdatedBy:    uuid.MustParse(model.SystemID),
+	}
+}
+
 func (s *NotificationService) AdminCreateNotification(ctx context.Context, req dto.CreateAdminNotificationRequest) error {
 	users, _, err := s.user.Get(ctx, model.UserFilter{
 		IDs: req.UserIDs,
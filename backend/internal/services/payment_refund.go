@@ -0,0 +1,356 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/lesprivate/backend/config"
+	xenditext "github.com/lesprivate/backend/external/xendit"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+type PaymentRefundService struct {
+	config        *config.Config
+	payment       *repositories.PaymentRepository
+	refund        *repositories.PaymentRefundRepository
+	mentorBalance *MentorBalanceService
+	notification  *NotificationService
+	refunder      xenditext.Refunder
+}
+
+func NewPaymentRefundService(
+	config *config.Config,
+	payment *repositories.PaymentRepository,
+	refund *repositories.PaymentRefundRepository,
+	mentorBalance *MentorBalanceService,
+	notification *NotificationService,
+	refunder xenditext.Refunder,
+) *PaymentRefundService {
+	return &PaymentRefundService{
+		config:        config,
+		payment:       payment,
+		refund:        refund,
+		mentorBalance: mentorBalance,
+		notification:  notification,
+		refunder:      refunder,
+	}
+}
+
+func (s *PaymentRefundService) getPayment(ctx context.Context, id uuid.UUID) (*model.Payment, error) {
+	payment, err := s.payment.GetByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.MakeError(ErrEntityNotFound, "payment")
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[getPayment] Error getting payment")
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (s *PaymentRefundService) ListByPayment(ctx context.Context, paymentID uuid.UUID) ([]model.PaymentRefund, error) {
+	if _, err := s.getPayment(ctx, paymentID); err != nil {
+		return nil, err
+	}
+
+	return s.refund.GetByPaymentID(ctx, paymentID)
+}
+
+// Create refunds a paid payment in full, or partially when an amount is
+// given. Refunds settled right away by Xendit are completed immediately, the
+// others when the refund webhook arrives.
+func (s *PaymentRefundService) Create(ctx context.Context, req dto.CreatePaymentRefundRequest) (*model.PaymentRefund, error) {
+	payment, err := s.getPayment(ctx, req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status != model.SubscriptionStatusActive || !payment.PaidAt.Valid {
+		return nil, shared.MakeError(ErrBadRequest, "only paid payments can be refunded")
+	}
+
+	if !payment.PaymentRequestID.Valid {
+		return nil, shared.MakeError(ErrBadRequest, "payment has no captured xendit payment to refund")
+	}
+
+	now := time.Now()
+	refund := &model.PaymentRefund{
+		ID:        uuid.New(),
+		PaymentID: payment.ID,
+		Reason:    req.Reason,
+		Note:      req.Note,
+		Status:    model.RefundStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: req.AdminID,
+		UpdatedBy: req.AdminID,
+	}
+	refund.GenerateCreditNoteNumber()
+
+	// Stored before calling Xendit so the webhook always finds the refund.
+	refundable, reserved, err := s.refund.Reserve(ctx, refund, req.Amount, payment.ChargedTotal())
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateRefund] Error creating refund")
+		return nil, err
+	}
+
+	if !reserved {
+		return nil, shared.MakeError(ErrBadRequest, fmt.Sprintf("refund amount must be between 1 and %s", refundable.String()))
+	}
+
+	resp, err := s.refunder.CreateRefund(ctx, xenditext.CreateRefundRequest{
+		PaymentRequestID: payment.PaymentRequestID.String,
+		ReferenceID:      refund.ID.String(),
+		Currency:         payment.Currency,
+		Amount:           int(refund.Amount.IntPart()),
+		Reason:           req.Reason,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateRefund] Error when calling xendit refund")
+
+		// Xendit may have issued the refund all the same, so it stays pending
+		// and holds its amount until the refund webhook settles it
+		var rejected *xenditext.RefundRejectedError
+		if !errors.As(err, &rejected) {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+
+		refund.Status = model.RefundStatusFailed
+		refund.FailureCode = null.StringFrom(rejected.ErrorCode)
+		if rejected.ErrorCode == "" {
+			refund.FailureCode = null.StringFrom("REQUEST_REJECTED")
+		}
+		if e := s.refund.UpdatePending(ctx, refund); e != nil {
+			logger.ErrorCtx(ctx).Err(e).Msg("[CreateRefund] Error updating refund")
+		}
+
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	refund.ReferenceID = resp.ID
+	refund.Payment = *payment
+
+	return refund, s.settle(ctx, refund, resp.Status, resp.FailureCode)
+}
+
+// HandleXenditRefund settles a refund still unsettled from the Xendit refund
+// webhook.
+func (s *PaymentRefundService) HandleXenditRefund(ctx context.Context, data dto.WebhookXenditRefund) error {
+	refund, err := s.refund.GetByReferenceID(ctx, data.ID)
+	if err != nil {
+		return err
+	}
+
+	if refund == nil {
+		if id, e := uuid.Parse(data.ReferenceID); e == nil {
+			refund, err = s.refund.GetByID(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if refund == nil {
+		logger.WarnCtx(ctx).Interface("data", data).Msg("[HandleXenditRefund] refund not found")
+		return shared.MakeError(ErrEntityNotFound, "refund")
+	}
+
+	if !refund.IsUnsettled() {
+		return nil
+	}
+
+	refund.ReferenceID = data.ID
+	return s.settle(ctx, refund, data.Status, data.FailureCode)
+}
+
+// settle records the Xendit status of the refund and, once it succeeded,
+// reverses what the payment granted.
+func (s *PaymentRefundService) settle(ctx context.Context, refund *model.PaymentRefund, status, failureCode string) error {
+	refund.UpdatedAt = time.Now()
+	refund.UpdatedBy = uuid.MustParse(model.SystemID)
+
+	switch refundStatus(status) {
+	case model.RefundStatusSucceeded:
+		return s.complete(ctx, refund)
+	case model.RefundStatusFailed:
+		refund.Status = model.RefundStatusFailed
+		refund.FailureCode = null.NewString(failureCode, failureCode != "")
+	default:
+		// A refund failed here is still being processed by Xendit after all
+		refund.Status = model.RefundStatusPending
+		refund.FailureCode = null.String{}
+	}
+
+	err := s.refund.UpdatePending(ctx, refund)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[settle] Error updating refund")
+		return err
+	}
+
+	return nil
+}
+
+// refundStatus maps the status of a Xendit refund, refunds Xendit is still
+// processing stay pending.
+func refundStatus(status string) model.RefundStatus {
+	switch status {
+	case xenditext.RefundStatusSucceeded:
+		return model.RefundStatusSucceeded
+	case xenditext.RefundStatusFailed:
+		return model.RefundStatusFailed
+	default:
+		return model.RefundStatusPending
+	}
+}
+
+// complete reverses what the payment granted, in proportion to the refunded
+// share, and records it with the refund in one transaction.
+func (s *PaymentRefundService) complete(ctx context.Context, refund *model.PaymentRefund) error {
+	var (
+		now      = time.Now()
+		payment  = refund.Payment
+		ratio    = refund.Ratio()
+		debits   []model.BalanceTransaction
+		students []model.Student
+	)
+
+	if payment.TutorID != uuid.Nil {
+		var err error
+		debits, err = s.mentorBalance.ReverseFromPayment(ctx, payment.ID, ratio, refund.ID)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[complete] Error reversing mentor balance")
+			return err
+		}
+
+		refund.BalanceReversed, refund.CommissionReversed = decimal.Zero, decimal.Zero
+		for _, debit := range debits {
+			refund.BalanceReversed = refund.BalanceReversed.Add(debit.Amount)
+			refund.CommissionReversed = refund.CommissionReversed.Add(debit.Commission)
+		}
+	} else {
+		for _, student := range payment.Recipients() {
+			if !student.PremiumUntil.Valid {
+				continue
+			}
+
+			premiumUntil := payment.PremiumAfterRefund(student.PremiumUntil.Time, ratio, now)
+			if payment.StudentID.Valid {
+				refund.PremiumUntilBefore = student.PremiumUntil
+				refund.PremiumUntilAfter = null.TimeFrom(premiumUntil)
+			}
+
+			student.PremiumUntil = null.TimeFrom(premiumUntil)
+			students = append(students, student)
+		}
+	}
+
+	refund.Status = model.RefundStatusSucceeded
+	refund.RefundedAt = null.TimeFrom(now)
+
+	completed, err := s.refund.Complete(ctx, refund, debits, students)
+	if err != nil {
+		return err
+	}
+
+	if !completed {
+		return nil
+	}
+
+	go func(user model.User, refund model.PaymentRefund) {
+		if err := s.notification.PaymentRefunded(context.Background(), user, refund); err != nil {
+			logger.ErrorCtx(context.Background()).Err(err).Msg("[complete] Error sending payment refunded notification")
+		}
+	}(payment.Payer(), *refund)
+
+	return nil
+}
+
+// CreateCreditNote renders the credit note of a succeeded refund.
+func (s *PaymentRefundService) CreateCreditNote(ctx context.Context, id uuid.UUID) ([]byte, string, error) {
+	refund, err := s.refund.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if refund == nil {
+		return nil, "", shared.MakeError(ErrEntityNotFound, "refund")
+	}
+
+	if refund.Status != model.RefundStatusSucceeded {
+		return nil, "", shared.MakeError(ErrBadRequest, "refund is not completed")
+	}
+
+	ac := model.MustCurrency(refund.Payment.Currency)
+
+	description := "Les Private Booking Payment"
+	if name := refund.Payment.Name(); name != "" && refund.Payment.TutorID == uuid.Nil {
+		description = "Les Private " + name
+	}
+
+	creditNote := dto.CreditNoteData{
+		CreditNoteNumber: refund.CreditNoteNumber,
+		CreditNoteDate:   refund.RefundedAt.Time.Format("02/01/2006"),
+		InvoiceNumber:    refund.Payment.InvoiceNumber,
+		CustomerEmail:    refund.Payment.Payer().Email,
+		Description:      description,
+		Reason:           refund.Reason,
+		SubtotalAmount:   ac.Format(refund.Subtotal()),
+		VATAmount:        ac.Format(refund.VatAmount()),
+		TotalAmount:      ac.Format(refund.Amount),
+	}
+
+	tmpl, err := template.ParseFiles("./templates/pdf/credit_note/index.html")
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateCreditNote] failed to parse template")
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, creditNote)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateCreditNote] failed to execute template")
+		return nil, "", err
+	}
+
+	pdfg, err := wkhtmltopdf.NewPDFGenerator()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateCreditNote] failed to create pdf generator")
+		return nil, "", err
+	}
+
+	page := wkhtmltopdf.NewPageReader(bytes.NewReader(buf.Bytes()))
+	pdfg.AddPage(page)
+
+	pdfg.PageSize.Set(wkhtmltopdf.PageSizeA4)
+	pdfg.MarginTop.Set(10)
+	pdfg.MarginBottom.Set(10)
+	pdfg.MarginLeft.Set(10)
+	pdfg.MarginRight.Set(10)
+	pdfg.Dpi.Set(300)
+	pdfg.Orientation.Set(wkhtmltopdf.OrientationPortrait)
+	pdfg.Grayscale.Set(false)
+
+	err = pdfg.Create()
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateCreditNote] failed to create pdf")
+		return nil, "", err
+	}
+
+	return pdfg.Bytes(), fmt.Sprintf("%s.pdf", refund.CreditNoteNumber), nil
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	xenditext "github.com/lesprivate/backend/external/xendit"
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestRefundStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		want   model.RefundStatus
+	}{
+		{name: "succeeded webhook completes the refund", status: xenditext.RefundStatusSucceeded, want: model.RefundStatusSucceeded},
+		{name: "failed webhook fails the refund", status: xenditext.RefundStatusFailed, want: model.RefundStatusFailed},
+		{name: "pending refund stays pending", status: xenditext.RefundStatusPending, want: model.RefundStatusPending},
+		{name: "unknown status stays pending", status: "CANCELLED", want: model.RefundStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refundStatus(tt.status); got != tt.want {
+				t.Errorf("refundStatus(%q) = %s, want %s", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFakeRefunderCompletesRefund(t *testing.T) {
+	resp, err := xenditext.FakeRefunder{}.CreateRefund(context.Background(), xenditext.CreateRefundRequest{
+		PaymentRequestID: "pr-1",
+		ReferenceID:      "refund-1",
+		Currency:         model.CurrencyIDR,
+		Amount:           50000,
+	})
+	if err != nil {
+		t.Fatalf("CreateRefund() error = %v", err)
+	}
+
+	if resp.ReferenceID != "refund-1" || resp.Amount != 50000 {
+		t.Errorf("CreateRefund() = %+v, want the requested refund", resp)
+	}
+	if got := refundStatus(resp.Status); got != model.RefundStatusSucceeded {
+		t.Errorf("fake refund status = %s, want %s", got, model.RefundStatusSucceeded)
+	}
+}
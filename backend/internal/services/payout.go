@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	xenditext "github.com/lesprivate/backend/external/xendit"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+// PayoutService pays approved withdrawals out through the disbursement
+// provider. Approving a withdrawal holds its amount from the mentor balance;
+// the hold is kept when the payout completes and released when it fails.
+type PayoutService struct {
+	withdrawal   *repositories.WithdrawalRepository
+	batch        *repositories.PayoutBatchRepository
+	notification *NotificationService
+	disburser    xenditext.Disburser
+}
+
+func NewPayoutService(
+	withdrawal *repositories.WithdrawalRepository,
+	batch *repositories.PayoutBatchRepository,
+	notification *NotificationService,
+	disburser xenditext.Disburser,
+) *PayoutService {
+	return &PayoutService{
+		withdrawal:   withdrawal,
+		batch:        batch,
+		notification: notification,
+		disburser:    disburser,
+	}
+}
+
+func (s *PayoutService) GetBatches(ctx context.Context, req dto.AdminListPayoutBatchesRequest) ([]model.PayoutBatch, model.Metadata, error) {
+	filter := req.Pagination
+	filter.SetDefault()
+
+	return s.batch.Get(ctx, req.Status, filter)
+}
+
+func (s *PayoutService) GetBatch(ctx context.Context, id uuid.UUID) (*model.PayoutBatch, error) {
+	batch, err := s.batch.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "payout batch")
+	}
+
+	return batch, nil
+}
+
+// CreateBatch groups the requested approved withdrawals, or every approved
+// withdrawal when none is given, and sends them to the disbursement provider.
+func (s *PayoutService) CreateBatch(ctx context.Context, req dto.CreatePayoutBatchRequest, adminID uuid.UUID) (*model.PayoutBatch, error) {
+	withdrawals, err := s.withdrawal.ListApproved(ctx, req.WithdrawalIDs)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateBatch] Error listing approved withdrawals")
+		return nil, err
+	}
+
+	if len(withdrawals) == 0 {
+		return nil, shared.MakeError(ErrBadRequest, "no approved withdrawal to pay out")
+	}
+
+	if len(req.WithdrawalIDs) > 0 && len(withdrawals) != len(req.WithdrawalIDs) {
+		return nil, shared.MakeError(ErrBadRequest, "withdrawal is not approved or already in a batch")
+	}
+
+	batch, ids, err := newPayoutBatch(withdrawals, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.batch.Create(ctx, batch, ids); err != nil {
+		if errors.Is(err, repositories.ErrWithdrawalNotApproved) {
+			return nil, shared.MakeError(ErrBadRequest, "withdrawal is not approved or already in a batch")
+		}
+
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateBatch] Error creating payout batch")
+		return nil, err
+	}
+
+	return s.SendBatch(ctx, batch.ID)
+}
+
+// newPayoutBatch groups the withdrawals into a new batch. The provider only
+// disburses to Indonesian bank accounts in rupiah.
+func newPayoutBatch(withdrawals []model.WithdrawalRequest, adminID uuid.UUID) (*model.PayoutBatch, []uuid.UUID, error) {
+	batch := &model.PayoutBatch{
+		ID:          uuid.New(),
+		Status:      model.PayoutBatchStatusProcessing,
+		TotalCount:  len(withdrawals),
+		TotalAmount: decimal.Zero,
+		CreatedBy:   adminID,
+	}
+
+	ids := make([]uuid.UUID, 0, len(withdrawals))
+	for _, w := range withdrawals {
+		if w.Currency != model.CurrencyIDR {
+			return nil, nil, shared.MakeError(ErrBadRequest, "withdrawal "+w.ID.String()+" is in "+w.Currency+" and can not be paid out through the provider")
+		}
+
+		ids = append(ids, w.ID)
+		batch.TotalAmount = batch.TotalAmount.Add(w.Amount)
+	}
+
+	return batch, ids, nil
+}
+
+// SendBatch sends the withdrawals of the batch the provider has not accepted
+// yet. Withdrawals the provider could not be reached for stay approved, so
+// sending again retries them with the same idempotency key.
+func (s *PayoutService) SendBatch(ctx context.Context, id uuid.UUID) (*model.PayoutBatch, error) {
+	batch, err := s.GetBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range batch.Withdrawals {
+		w := &batch.Withdrawals[i]
+		if w.Status != model.WithdrawalStatusApproved {
+			continue
+		}
+
+		resp, err := s.disburser.CreateDisbursement(ctx, xenditext.CreateDisbursementRequest{
+			ExternalID:        w.ID.String(),
+			Amount:            int(w.Amount.IntPart()),
+			BankCode:          w.BankCode,
+			AccountHolderName: w.AccountName,
+			AccountNumber:     w.AccountNumber,
+			Description:       "Withdrawal " + w.ID.String(),
+		})
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("withdrawal_id", w.ID.String()).Msg("[SendBatch] Error creating disbursement")
+			continue
+		}
+
+		w.DisbursementID = null.StringFrom(resp.ID)
+		if err := s.settle(ctx, w, resp.Status, resp.FailureCode); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("withdrawal_id", w.ID.String()).Msg("[SendBatch] Error settling withdrawal")
+		}
+	}
+
+	now := time.Now()
+	batch.SentAt = &now
+	if err := s.batch.Update(ctx, batch); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SendBatch] Error updating payout batch")
+		return nil, err
+	}
+
+	if err := s.batch.Complete(ctx, batch.ID); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SendBatch] Error completing payout batch")
+		return nil, err
+	}
+
+	return s.GetBatch(ctx, batch.ID)
+}
+
+// HandleXenditDisbursement settles a withdrawal from the Xendit disbursement
+// callback.
+func (s *PayoutService) HandleXenditDisbursement(ctx context.Context, data dto.WebhookXenditDisbursement) error {
+	w, err := s.withdrawal.GetByDisbursementID(ctx, data.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if id, e := uuid.Parse(data.ExternalID); e == nil {
+			w, err = s.withdrawal.GetByID(ctx, id)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WarnCtx(ctx).Interface("data", data).Msg("[HandleXenditDisbursement] withdrawal not found")
+			return shared.MakeError(ErrEntityNotFound, "withdrawal")
+		}
+
+		return err
+	}
+
+	if !w.Held() {
+		return nil
+	}
+
+	w.DisbursementID = null.StringFrom(data.ID)
+	if err := s.settle(ctx, w, data.Status, data.FailureCode); err != nil {
+		return err
+	}
+
+	if w.PayoutBatchID.Valid {
+		return s.batch.Complete(ctx, w.PayoutBatchID.UUID)
+	}
+
+	return nil
+}
+
+// withdrawalStatus maps the status of a Xendit disbursement. ok is false for
+// statuses the withdrawal does not follow.
+func withdrawalStatus(status string) (model.WithdrawalStatus, bool) {
+	switch status {
+	case xenditext.DisbursementStatusPending:
+		return model.WithdrawalStatusProcessing, true
+	case xenditext.DisbursementStatusCompleted:
+		return model.WithdrawalStatusCompleted, true
+	case xenditext.DisbursementStatusFailed:
+		return model.WithdrawalStatusFailed, true
+	default:
+		return "", false
+	}
+}
+
+// settle moves the withdrawal to the provider status. A failed payout gives
+// the held amount back to the mentor balance.
+func (s *PayoutService) settle(ctx context.Context, w *model.WithdrawalRequest, status, failureCode string) error {
+	from := w.Status
+	now := time.Now()
+
+	to, ok := withdrawalStatus(status)
+	if !ok {
+		logger.WarnCtx(ctx).Str("status", status).Msg("[settle] unknown disbursement status")
+		return nil
+	}
+
+	if from == to {
+		return nil
+	}
+
+	w.Status = to
+	switch to {
+	case model.WithdrawalStatusCompleted:
+		w.ProcessedAt = &now
+	case model.WithdrawalStatusFailed:
+		w.FailureCode = null.NewString(failureCode, failureCode != "")
+		w.ProcessedAt = &now
+	}
+
+	var (
+		updated bool
+		err     error
+	)
+	if to == model.WithdrawalStatusFailed {
+		updated, err = s.withdrawal.Release(ctx, w, from)
+	} else {
+		updated, err = s.withdrawal.UpdateStatus(ctx, w, from)
+	}
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[settle] Error updating withdrawal")
+		return err
+	}
+
+	// Another callback settled the withdrawal first
+	if !updated {
+		return nil
+	}
+
+	if w.Status == model.WithdrawalStatusProcessing {
+		return nil
+	}
+
+	if err := s.notification.PayoutSettled(ctx, *w); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[settle] Error notifying mentor")
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	xenditext "github.com/lesprivate/backend/external/xendit"
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestNewPayoutBatch(t *testing.T) {
+	var (
+		adminID = uuid.New()
+		first   = model.WithdrawalRequest{ID: uuid.New(), Amount: decimal.NewFromInt(150000), Currency: model.CurrencyIDR}
+		second  = model.WithdrawalRequest{ID: uuid.New(), Amount: decimal.RequireFromString("250000.50"), Currency: model.CurrencyIDR}
+		foreign = model.WithdrawalRequest{ID: uuid.New(), Amount: decimal.NewFromInt(100), Currency: "USD"}
+	)
+
+	tests := []struct {
+		name        string
+		withdrawals []model.WithdrawalRequest
+		wantTotal   string
+		wantIDs     []uuid.UUID
+		wantErr     bool
+	}{
+		{
+			name:        "one withdrawal",
+			withdrawals: []model.WithdrawalRequest{first},
+			wantTotal:   "150000",
+			wantIDs:     []uuid.UUID{first.ID},
+		},
+		{
+			name:        "withdrawals are summed",
+			withdrawals: []model.WithdrawalRequest{first, second},
+			wantTotal:   "400000.50",
+			wantIDs:     []uuid.UUID{first.ID, second.ID},
+		},
+		{
+			name:        "withdrawal in another currency is refused",
+			withdrawals: []model.WithdrawalRequest{first, foreign},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, ids, err := newPayoutBatch(tt.withdrawals, adminID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("newPayoutBatch() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newPayoutBatch() error = %v", err)
+			}
+
+			if batch.Status != model.PayoutBatchStatusProcessing || batch.CreatedBy != adminID {
+				t.Errorf("batch is %s by %s, want processing by %s", batch.Status, batch.CreatedBy, adminID)
+			}
+			if batch.TotalCount != len(tt.withdrawals) {
+				t.Errorf("TotalCount = %d, want %d", batch.TotalCount, len(tt.withdrawals))
+			}
+			if !batch.TotalAmount.Equal(decimal.RequireFromString(tt.wantTotal)) {
+				t.Errorf("TotalAmount = %s, want %s", batch.TotalAmount, tt.wantTotal)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("got %d ids, want %d", len(ids), len(tt.wantIDs))
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Errorf("id %d = %s, want %s", i, ids[i], tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWithdrawalStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		want   model.WithdrawalStatus
+		wantOK bool
+	}{
+		{name: "pending disbursement is processing", status: xenditext.DisbursementStatusPending, want: model.WithdrawalStatusProcessing, wantOK: true},
+		{name: "completed disbursement completes", status: xenditext.DisbursementStatusCompleted, want: model.WithdrawalStatusCompleted, wantOK: true},
+		{name: "failed disbursement fails and is released", status: xenditext.DisbursementStatusFailed, want: model.WithdrawalStatusFailed, wantOK: true},
+		{name: "unknown status is ignored", status: "REVERSED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := withdrawalStatus(tt.status)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("withdrawalStatus(%q) = %s, %v, want %s, %v", tt.status, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFakeDisburserCompletesDisbursement(t *testing.T) {
+	resp, err := xenditext.FakeDisburser{}.CreateDisbursement(context.Background(), xenditext.CreateDisbursementRequest{
+		ExternalID:        "withdrawal-1",
+		Amount:            150000,
+		BankCode:          "BCA",
+		AccountHolderName: "Budi",
+		AccountNumber:     "1234567890",
+	})
+	if err != nil {
+		t.Fatalf("CreateDisbursement() error = %v", err)
+	}
+
+	if resp.ExternalID != "withdrawal-1" || resp.Amount != 150000 || resp.ID == "" {
+		t.Errorf("CreateDisbursement() = %+v, want the requested disbursement", resp)
+	}
+	if got, ok := withdrawalStatus(resp.Status); !ok || got != model.WithdrawalStatusCompleted {
+		t.Errorf("fake disbursement status = %s, want %s", got, model.WithdrawalStatusCompleted)
+	}
+}
@@ -23,6 +23,7 @@ type ProfileService struct {
 	user          *repositories.UserRepository
 	student       *repositories.StudentRepository
 	tutor         *repositories.TutorRepository
+	guardian      *repositories.GuardianRepository
 	tutorDocument *repositories.TutorDocumentRepository
 	booking       *repositories.BookingRepository
 	review        *repositories.ReviewRepository
@@ -34,6 +35,7 @@ func NewProfileService(
 	user *repositories.UserRepository,
 	student *repositories.StudentRepository,
 	tutor *repositories.TutorRepository,
+	guardian *repositories.GuardianRepository,
 	tutorDocument *repositories.TutorDocumentRepository,
 	booking *repositories.BookingRepository,
 	review *repositories.ReviewRepository,
@@ -44,6 +46,7 @@ func NewProfileService(
 		user:          user,
 		student:       student,
 		tutor:         tutor,
+		guardian:      guardian,
 		tutorDocument: tutorDocument,
 		booking:       booking,
 		review:        review,
@@ -71,10 +74,11 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, userID uuid.UUID, re
 
 	user.Name = req.Name
 	user.PhoneNumber = req.PhoneNumber
+	user.Currency = null.NewString(req.Currency, req.Currency != "")
 
 	var userRole string
 	for _, role := range user.Roles {
-		if role.Name == model.RoleNameStudent || role.Name == model.RoleNameTutor {
+		if role.Name == model.RoleNameStudent || role.Name == model.RoleNameTutor || role.Name == model.RoleNameGuardian {
 			userRole = role.Name
 			break
 		}
@@ -83,8 +87,8 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, userID uuid.UUID, re
 	if userRole == "" {
 		logger.ErrorCtx(ctx).
 			Str("user_id", userID.String()).
-			Msg("[ProfileService.UpdateProfile] User has no student or tutor role")
-		return fmt.Errorf("user has no student or tutor role")
+			Msg("[ProfileService.UpdateProfile] User has no student, tutor or guardian role")
+		return fmt.Errorf("user has no student, tutor or guardian role")
 	}
 
 	var profile any
@@ -93,6 +97,8 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, userID uuid.UUID, re
 		profile, err = s.updateStudentProfile(ctx, req, user)
 	case model.RoleNameTutor:
 		profile, err = s.updateTutorProfile(ctx, req, user)
+	case model.RoleNameGuardian:
+		profile, err = s.updateGuardianProfile(ctx, req, user)
 	default:
 		return fmt.Errorf("unsupported user role: %s", userRole)
 	}
@@ -307,6 +313,9 @@ func (s *ProfileService) updateStudentProfile(ctx context.Context, req dto.Updat
 		student.DateOfBirth = null.TimeFrom(dateOfBirth)
 	}
 	student.PhoneNumber = null.StringFrom(req.PhoneNumber)
+	student.TaxID = null.NewString(req.TaxID, req.TaxID != "")
+	student.TaxName = null.NewString(req.TaxName, req.TaxName != "")
+	student.TaxAddress = null.NewString(req.TaxAddress, req.TaxAddress != "")
 	if req.SocialMediaLink != nil {
 		links := make([]model.SocialMediaLink, 0)
 		for socialMedia, link := range req.SocialMediaLink {
@@ -322,6 +331,34 @@ func (s *ProfileService) updateStudentProfile(ctx context.Context, req dto.Updat
 	return student, nil
 }
 
+// updateGuardianProfile keeps the tax details printed on the invoices of the
+// payments made by the guardian.
+func (s *ProfileService) updateGuardianProfile(ctx context.Context, req dto.UpdateProfileRequest, user *model.User) (*model.Guardian, error) {
+	guardian, err := s.guardian.GetByUserID(ctx, user.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).
+			Str("user_id", user.ID.String()).
+			Msg("[ProfileService.updateGuardianProfile] Failed to get guardian")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if guardian == nil {
+		guardian = &model.Guardian{
+			UserID:    user.ID,
+			CreatedAt: time.Now(),
+			CreatedBy: uuid.NullUUID{UUID: user.ID, Valid: true},
+		}
+	}
+
+	guardian.TaxID = null.NewString(req.TaxID, req.TaxID != "")
+	guardian.TaxName = null.NewString(req.TaxName, req.TaxName != "")
+	guardian.TaxAddress = null.NewString(req.TaxAddress, req.TaxAddress != "")
+	guardian.UpdatedAt = time.Now()
+	guardian.UpdatedBy = uuid.NullUUID{UUID: user.ID, Valid: true}
+
+	return guardian, nil
+}
+
 func (s *ProfileService) updateTutorProfile(ctx context.Context, req dto.UpdateProfileRequest, user *model.User) (*model.Tutor, error) {
 	tutor, err := s.tutor.GetByUserID(ctx, user.ID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && !strings.Contains(err.Error(), "not found") {
@@ -411,7 +448,7 @@ func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (dto.
 
 	var userRole string
 	for _, role := range user.Roles {
-		if role.Name == model.RoleNameStudent || role.Name == model.RoleNameTutor {
+		if role.Name == model.RoleNameStudent || role.Name == model.RoleNameTutor || role.Name == model.RoleNameGuardian {
 			userRole = role.Name
 			break
 		}
@@ -420,8 +457,8 @@ func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (dto.
 	if userRole == "" {
 		logger.ErrorCtx(ctx).
 			Str("user_id", userID.String()).
-			Msg("[ProfileService.UpdateProfile] User has no student or tutor role")
-		return dto.ProfileResponse{}, fmt.Errorf("user has no student or tutor role")
+			Msg("[ProfileService.UpdateProfile] User has no student, tutor or guardian role")
+		return dto.ProfileResponse{}, fmt.Errorf("user has no student, tutor or guardian role")
 	}
 
 	profile := dto.ProfileResponse{
@@ -429,6 +466,7 @@ func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (dto.
 		Name:            user.Name,
 		Email:           user.Email,
 		Role:            userRole,
+		Currency:        user.Currency,
 		SocialMediaLink: make(map[string]string),
 	}
 
@@ -463,6 +501,9 @@ func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (dto.
 			profile.SocialMediaLink[link.Name] = link.Link
 		}
 		profile.IsPremium = student.IsPremium()
+		profile.TaxID = student.TaxID
+		profile.TaxName = student.TaxName
+		profile.TaxAddress = student.TaxAddress
 	case model.RoleNameTutor:
 		err := s.fillProfileForTutor(ctx, userID, &profile)
 		if err != nil {
@@ -471,48 +512,25 @@ func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (dto.
 				Msg("[ProfileService.UpdateProfile] Failed to get tutor")
 			return dto.ProfileResponse{}, err
 		}
-	}
+	case model.RoleNameGuardian:
+		guardian, err := s.guardian.GetByUserID(ctx, userID)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).
+				Str("user_id", userID.String()).
+				Msg("[ProfileService.UpdateProfile] Failed to get guardian")
+			return dto.ProfileResponse{}, shared.MakeError(ErrInternalServer)
+		}
 
-	return profile, nil
-}
+		if guardian == nil {
+			return profile, nil
+		}
 
-func (s *ProfileService) GetTutorLevel(ctx context.Context, userID uuid.UUID) (dto.TutorLevelInfo, error) {
-	tutor, err := s.tutor.GetByUserID(ctx, userID)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).
-			Str("user_id", userID.String()).
-			Msg("[ProfileService.GetTutorLevel] Failed to get tutor")
-		return dto.TutorLevelInfo{}, shared.MakeError(ErrInternalServer)
+		profile.TaxID = guardian.TaxID
+		profile.TaxName = guardian.TaxName
+		profile.TaxAddress = guardian.TaxAddress
 	}
 
-	if tutor == nil {
-		logger.ErrorCtx(ctx).
-			Str("user_id", userID.String()).
-			Msg("[ProfileService.GetTutorLevel] Tutor not found")
-		return dto.TutorLevelInfo{}, shared.MakeError(ErrEntityNotFound, "tutor")
-	}
-
-	currentLevel := tutor.LevelByPoint()
-	var nextLevel string
-	var pointsNeeded uint
-
-	if tutor.LevelPoint < 10 {
-		nextLevel = string(model.TutorLevelGuruAktif)
-		pointsNeeded = 10 - tutor.LevelPoint
-	} else if tutor.LevelPoint < 25 {
-		nextLevel = string(model.TutorLevelGuruFavorit)
-		pointsNeeded = 25 - tutor.LevelPoint
-	} else {
-		nextLevel = "Max Level"
-		pointsNeeded = 0
-	}
-
-	return dto.TutorLevelInfo{
-		CurrentPoint: tutor.LevelPoint,
-		CurrentLevel: currentLevel,
-		NextLevel:    nextLevel,
-		PointsNeeded: pointsNeeded,
-	}, nil
+	return profile, nil
 }
 
 func (s *ProfileService) fillProfileForTutor(ctx context.Context, userID uuid.UUID, profile *dto.ProfileResponse) error {
@@ -541,15 +559,14 @@ func (s *ProfileService) fillProfileForTutor(ctx context.Context, userID uuid.UU
 		profile.SocialMediaLink[link.Name] = link.Link
 	}
 	profile.Bio = tutor.Description
-	profile.LevelPoint = tutor.LevelPoint
-	profile.Level = null.StringFrom(tutor.LevelByPoint())
+	profile.Level = null.StringFrom(tutor.LevelName())
 	profile.Address = tutor.Address
 	profile.JoinedAt = tutor.CreatedAt
 
 	// Get total sessions
 	totalSessions, err := s.booking.Count(ctx, model.BookingFilter{
-		TutorID: userID,
-		Status:  model.BookingStatusAccepted,
+		TutorID:  tutor.ID,
+		StatusIn: []model.BookingStatus{model.BookingStatusAccepted, model.BookingStatusCompleted},
 	})
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).
@@ -559,15 +576,7 @@ func (s *ProfileService) fillProfileForTutor(ctx context.Context, userID uuid.UU
 	}
 	profile.TotalSessions = totalSessions
 
-	// Get average rating
-	avgRating, err := s.review.GetTotalRatingTutor(ctx, tutor.ID)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).
-			Str("user_id", userID.String()).
-			Msg("[ProfileService.UpdateProfile] Failed to get average rating")
-		// Don't fail
-	}
-	profile.AverageRating = avgRating
+	profile.AverageRating = tutor.Rating.InexactFloat64()
 
 	if profile.Latitude.Valid && profile.Longitude.Valid {
 		location, err := s.courseService.GetLocationByLatLong(ctx, profile.Latitude.Decimal, profile.Longitude.Decimal)
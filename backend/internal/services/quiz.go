@@ -0,0 +1,621 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+// QuizService manages the question bank and quizzes of tutors, and the
+// attempts of students at the quizzes attached to their tasks, graded
+// automatically into the task submission.
+type QuizService struct {
+	quiz              *repositories.QuizRepository
+	sessionTask       *repositories.SessionTaskRepository
+	booking           *repositories.BookingRepository
+	tutor             *repositories.TutorRepository
+	student           *repositories.StudentRepository
+	subCourseCategory *repositories.SubCourseCategoryRepository
+	notification      *NotificationService
+}
+
+func NewQuizService(
+	quiz *repositories.QuizRepository,
+	sessionTask *repositories.SessionTaskRepository,
+	booking *repositories.BookingRepository,
+	tutor *repositories.TutorRepository,
+	student *repositories.StudentRepository,
+	subCourseCategory *repositories.SubCourseCategoryRepository,
+	notification *NotificationService,
+) *QuizService {
+	return &QuizService{
+		quiz:              quiz,
+		sessionTask:       sessionTask,
+		booking:           booking,
+		tutor:             tutor,
+		student:           student,
+		subCourseCategory: subCourseCategory,
+		notification:      notification,
+	}
+}
+
+func (s *QuizService) currentTutor(ctx context.Context) (*model.Tutor, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[QuizService] Error getting tutor")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	return tutor, nil
+}
+
+func (s *QuizService) GetQuestions(ctx context.Context, request dto.GetQuizQuestionsRequest) ([]dto.QuizQuestionResponse, model.Metadata, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	questions, metadata, err := s.quiz.GetQuestions(ctx, model.QuizQuestionFilter{
+		TutorID:             tutor.ID,
+		SubCourseCategoryID: request.SubCourseCategoryID,
+		LevelOfEducation:    request.LevelOfEducation,
+		Type:                request.Type,
+		Query:               request.Query,
+		Pagination:          request.Pagination,
+	})
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	results := make([]dto.QuizQuestionResponse, 0, len(questions))
+	for _, question := range questions {
+		results = append(results, dto.NewQuizQuestionResponse(question))
+	}
+
+	return results, metadata, nil
+}
+
+func (s *QuizService) CreateQuestion(ctx context.Context, request dto.QuizQuestionRequest) (*dto.QuizQuestionResponse, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	question := &model.QuizQuestion{TutorID: tutor.ID}
+	err = s.applyQuestion(ctx, question, request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.quiz.CreateQuestion(ctx, question)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateQuestion] Error creating question")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewQuizQuestionResponse(*question)
+	return &res, nil
+}
+
+// UpdateQuestion changes a question of the bank. Attempts already started
+// keep the question as it was asked.
+func (s *QuizService) UpdateQuestion(ctx context.Context, id uuid.UUID, request dto.QuizQuestionRequest) (*dto.QuizQuestionResponse, error) {
+	question, err := s.ownQuestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.applyQuestion(ctx, question, request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.quiz.UpdateQuestion(ctx, question)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateQuestion] Error updating question")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewQuizQuestionResponse(*question)
+	return &res, nil
+}
+
+func (s *QuizService) DeleteQuestion(ctx context.Context, id uuid.UUID) error {
+	_, err := s.ownQuestion(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.quiz.DeleteQuestion(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteQuestion] Error deleting question")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *QuizService) ownQuestion(ctx context.Context, id uuid.UUID) (*model.QuizQuestion, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := s.quiz.GetQuestionByID(ctx, id)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if question == nil || question.TutorID != tutor.ID {
+		return nil, shared.MakeError(ErrEntityNotFound, "question")
+	}
+
+	return question, nil
+}
+
+func (s *QuizService) applyQuestion(ctx context.Context, question *model.QuizQuestion, request dto.QuizQuestionRequest) error {
+	var subCourseCategory *model.SubCourseCategory
+	if request.SubCourseCategoryID != nil {
+		var err error
+		subCourseCategory, err = s.subCourseCategory.GetByID(ctx, *request.SubCourseCategoryID)
+		if err != nil {
+			return shared.MakeError(ErrInternalServer)
+		}
+		if subCourseCategory == nil {
+			return shared.MakeError(ErrEntityNotFound, "sub course category")
+		}
+	}
+
+	answer, err := json.Marshal(request.Answer)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	question.Options = nil
+	if len(request.Options) > 0 {
+		question.Options, err = json.Marshal(request.Options)
+		if err != nil {
+			return shared.MakeError(ErrInternalServer)
+		}
+	}
+
+	question.SubCourseCategoryID = nullUUID(request.SubCourseCategoryID)
+	question.LevelOfEducation = null.StringFromPtr(request.LevelOfEducation)
+	question.Type = request.Type
+	question.Prompt = request.Prompt
+	question.Answer = answer
+	question.Explanation = null.StringFromPtr(request.Explanation)
+	question.Points = *request.Points
+	question.SubCourseCategory = subCourseCategory
+	return nil
+}
+
+func (s *QuizService) GetQuizzes(ctx context.Context, request dto.GetQuizzesRequest) ([]dto.QuizResponse, model.Metadata, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	quizzes, metadata, err := s.quiz.Get(ctx, model.QuizFilter{
+		TutorID:    tutor.ID,
+		Query:      request.Query,
+		Pagination: request.Pagination,
+	})
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	results := make([]dto.QuizResponse, 0, len(quizzes))
+	for _, quiz := range quizzes {
+		results = append(results, dto.NewQuizResponse(quiz))
+	}
+
+	return results, metadata, nil
+}
+
+func (s *QuizService) GetQuiz(ctx context.Context, id uuid.UUID) (*dto.QuizResponse, error) {
+	_, quiz, err := s.ownQuiz(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := dto.NewQuizResponse(*quiz)
+	return &res, nil
+}
+
+func (s *QuizService) CreateQuiz(ctx context.Context, request dto.QuizRequest) (*dto.QuizResponse, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz := &model.Quiz{TutorID: tutor.ID}
+	err = s.applyQuiz(ctx, tutor, quiz, request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.quiz.Save(ctx, quiz)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewQuizResponse(*quiz)
+	return &res, nil
+}
+
+// UpdateQuiz changes a quiz of the library. Attempts already started keep
+// the questions they were started with.
+func (s *QuizService) UpdateQuiz(ctx context.Context, id uuid.UUID, request dto.QuizRequest) (*dto.QuizResponse, error) {
+	tutor, quiz, err := s.ownQuiz(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.applyQuiz(ctx, tutor, quiz, request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.quiz.Save(ctx, quiz)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewQuizResponse(*quiz)
+	return &res, nil
+}
+
+func (s *QuizService) DeleteQuiz(ctx context.Context, id uuid.UUID) error {
+	_, _, err := s.ownQuiz(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.quiz.Delete(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteQuiz] Error deleting quiz")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *QuizService) ownQuiz(ctx context.Context, id uuid.UUID) (*model.Tutor, *model.Quiz, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quiz, err := s.quiz.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, shared.MakeError(ErrInternalServer)
+	}
+	if quiz == nil || quiz.DeletedAt.Valid || quiz.TutorID != tutor.ID {
+		return nil, nil, shared.MakeError(ErrEntityNotFound, "quiz")
+	}
+
+	return tutor, quiz, nil
+}
+
+// applyQuiz sets the questions of the quiz in the order requested, each of
+// them from the bank of the tutor
+func (s *QuizService) applyQuiz(ctx context.Context, tutor *model.Tutor, quiz *model.Quiz, request dto.QuizRequest) error {
+	questions, err := s.quiz.GetQuestionsByIDs(ctx, tutor.ID, request.QuestionIDs)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	bank := make(map[uuid.UUID]model.QuizQuestion, len(questions))
+	for _, question := range questions {
+		bank[question.ID] = question
+	}
+
+	items := make([]model.QuizItem, 0, len(request.QuestionIDs))
+	for i, id := range request.QuestionIDs {
+		question, ok := bank[id]
+		if !ok {
+			return shared.MakeError(ErrEntityNotFound, "question "+id.String())
+		}
+
+		items = append(items, model.QuizItem{
+			QuizID:     quiz.ID,
+			QuestionID: id,
+			Position:   i,
+			Question:   &question,
+		})
+	}
+
+	quiz.Title = request.Title
+	quiz.Description = null.StringFromPtr(request.Description)
+	quiz.TimeLimitMinutes = null.Int{}
+	if request.TimeLimitMinutes != nil {
+		quiz.TimeLimitMinutes = null.IntFrom(int64(*request.TimeLimitMinutes))
+	}
+	quiz.Items = items
+	return nil
+}
+
+// GetQuizAnalytics shows per question how the students answered the quiz in
+// their submitted attempts, across every task it was given with or the one
+// requested.
+func (s *QuizService) GetQuizAnalytics(ctx context.Context, id uuid.UUID, request dto.GetQuizAnalyticsRequest) (*dto.QuizAnalyticsResponse, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz, err := s.quiz.GetByID(ctx, id)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if quiz == nil || quiz.TutorID != tutor.ID {
+		return nil, shared.MakeError(ErrEntityNotFound, "quiz")
+	}
+
+	answers, err := s.quiz.GetSubmittedAnswers(ctx, model.QuizAttemptFilter{
+		QuizID:        quiz.ID,
+		SessionTaskID: request.SessionTaskID,
+	})
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewQuizAnalyticsResponse(*quiz, answers)
+	return &res, nil
+}
+
+// studentQuiz returns the task of the current student with its booking and
+// quiz
+func (s *QuizService) studentQuiz(ctx context.Context, taskID uuid.UUID) (*model.SessionTask, *model.Booking, *model.Quiz, error) {
+	student, err := s.student.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil || student == nil {
+		return nil, nil, nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	task, err := s.sessionTask.GetByID(ctx, taskID)
+	if err != nil || task == nil {
+		return nil, nil, nil, shared.MakeError(ErrEntityNotFound, "task")
+	}
+
+	booking, err := s.booking.GetByID(ctx, task.BookingID)
+	if err != nil || booking == nil {
+		return nil, nil, nil, shared.MakeError(ErrEntityNotFound, "booking")
+	}
+
+	if booking.StudentID != student.ID {
+		return nil, nil, nil, shared.MakeError(ErrForbidden, "task ownership mismatch")
+	}
+
+	if !task.QuizID.Valid {
+		return nil, nil, nil, shared.MakeError(ErrBadRequest, "task has no quiz")
+	}
+
+	quiz, err := s.quiz.GetByID(ctx, task.QuizID.UUID)
+	if err != nil {
+		return nil, nil, nil, shared.MakeError(ErrInternalServer)
+	}
+	if quiz == nil {
+		return nil, nil, nil, shared.MakeError(ErrEntityNotFound, "quiz")
+	}
+
+	return task, booking, quiz, nil
+}
+
+// StartQuiz starts the attempt of the current student at the quiz of a
+// task, the time limit running from now, or returns the attempt already
+// started. An attempt whose time ran out is graded with the answers saved.
+// Starting after the due date is accepted but flagged late.
+func (s *QuizService) StartQuiz(ctx context.Context, taskID uuid.UUID) (*dto.QuizAttemptResponse, error) {
+	task, booking, quiz, err := s.studentQuiz(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt, err := s.quiz.GetAttemptByTaskID(ctx, task.ID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	now := time.Now()
+	if attempt != nil {
+		if !attempt.IsSubmitted() && attempt.IsExpired(now) {
+			err = s.submitAttempt(ctx, task, booking, attempt, now)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res := dto.NewQuizAttemptResponse(*attempt, *quiz)
+		return &res, nil
+	}
+
+	submission, err := s.sessionTask.GetSubmissionByTaskID(ctx, task.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[StartQuiz] Error getting submission")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if submission != nil && submission.IsGraded() {
+		return nil, shared.MakeError(ErrTaskSubmissionClosed, "task has been graded")
+	}
+
+	if len(quiz.Items) == 0 {
+		return nil, shared.MakeError(ErrBadRequest, "quiz has no questions")
+	}
+
+	attempt = &model.QuizAttempt{
+		SessionTaskID: task.ID,
+		QuizID:        quiz.ID,
+		StudentID:     booking.StudentID,
+		StartedAt:     now,
+		MaxPoints:     quiz.MaxPoints(),
+		Answers:       make([]model.QuizAttemptAnswer, 0, len(quiz.Items)),
+	}
+	if quiz.TimeLimitMinutes.Valid {
+		attempt.DeadlineAt = null.TimeFrom(now.Add(time.Duration(quiz.TimeLimitMinutes.Int64) * time.Minute))
+	}
+
+	for _, item := range quiz.Items {
+		if item.Question != nil {
+			attempt.Answers = append(attempt.Answers, model.NewQuizAttemptAnswer(*item.Question, item.Position))
+		}
+	}
+
+	err = s.quiz.CreateAttempt(ctx, attempt)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewQuizAttemptResponse(*attempt, *quiz)
+	return &res, nil
+}
+
+// SaveQuizAnswers stores the answers of the current student while taking the
+// quiz, until its time runs out.
+func (s *QuizService) SaveQuizAnswers(ctx context.Context, taskID uuid.UUID, request dto.QuizAnswersRequest) (*dto.QuizAttemptResponse, error) {
+	task, _, quiz, err := s.studentQuiz(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt, err := s.openAttempt(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt.IsExpired(time.Now()) {
+		return nil, shared.MakeError(ErrTaskSubmissionClosed, "time limit of the quiz has run out")
+	}
+
+	answers, err := applyAnswers(attempt, request.Answers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(answers) > 0 {
+		err = s.quiz.SaveAnswers(ctx, answers)
+		if err != nil {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+	}
+
+	res := dto.NewQuizAttemptResponse(*attempt, *quiz)
+	return &res, nil
+}
+
+// SubmitQuiz finishes the attempt of the current student and grades it.
+// Answers sent after the time ran out are ignored, the attempt being graded
+// with the answers saved before.
+func (s *QuizService) SubmitQuiz(ctx context.Context, taskID uuid.UUID, request dto.QuizAnswersRequest) (*dto.QuizAttemptResponse, error) {
+	task, booking, quiz, err := s.studentQuiz(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt, err := s.openAttempt(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !attempt.IsExpired(now) {
+		_, err = applyAnswers(attempt, request.Answers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.submitAttempt(ctx, task, booking, attempt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	res := dto.NewQuizAttemptResponse(*attempt, *quiz)
+	return &res, nil
+}
+
+// openAttempt returns the attempt of the task still being taken
+func (s *QuizService) openAttempt(ctx context.Context, taskID uuid.UUID) (*model.QuizAttempt, error) {
+	attempt, err := s.quiz.GetAttemptByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if attempt == nil {
+		return nil, shared.MakeError(ErrBadRequest, "quiz has not been started")
+	}
+	if attempt.IsSubmitted() {
+		return nil, shared.MakeError(ErrTaskSubmissionClosed, "quiz has been submitted")
+	}
+
+	return attempt, nil
+}
+
+// applyAnswers sets the answers of the student on the attempt, returning
+// the ones changed
+func applyAnswers(attempt *model.QuizAttempt, answers []dto.QuizAnswerRequest) ([]model.QuizAttemptAnswer, error) {
+	index := make(map[uuid.UUID]int, len(attempt.Answers))
+	for i, answer := range attempt.Answers {
+		index[answer.QuestionID] = i
+	}
+
+	changed := make([]model.QuizAttemptAnswer, 0, len(answers))
+	for _, answer := range answers {
+		i, ok := index[answer.QuestionID]
+		if !ok {
+			return nil, shared.MakeError(ErrBadRequest, "question "+answer.QuestionID.String()+" is not in the quiz")
+		}
+
+		data, err := json.Marshal(answer.Answer)
+		if err != nil {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+
+		attempt.Answers[i].Answer = data
+		changed = append(changed, attempt.Answers[i])
+	}
+
+	return changed, nil
+}
+
+// submitAttempt grades the attempt and writes the score into the submission
+// of the task, telling the tutor the student finished.
+func (s *QuizService) submitAttempt(ctx context.Context, task *model.SessionTask, booking *model.Booking, attempt *model.QuizAttempt, now time.Time) error {
+	attempt.Grade()
+	attempt.SubmittedAt = null.TimeFrom(now)
+
+	submission := &model.TaskSubmission{
+		SessionTaskID: task.ID,
+		Status:        model.TaskSubmissionStatusGraded,
+		Score:         attempt.Score,
+		SubmittedAt:   null.TimeFrom(now),
+		IsLate:        task.IsPastDue(attempt.StartedAt),
+		GradedAt:      null.TimeFrom(now),
+	}
+
+	submitted, err := s.quiz.SubmitAttempt(ctx, attempt, submission)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+	if !submitted {
+		return shared.MakeError(ErrTaskSubmissionClosed, "quiz has been submitted")
+	}
+
+	err = s.notification.QuizCompleted(ctx, *booking, *task, *submission)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitQuiz] Error sending quiz completed notification")
+	}
+
+	return nil
+}
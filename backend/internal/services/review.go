@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+// defaultBlockedWords is used when REVIEW.BLOCKED_WORDS is not configured.
+var defaultBlockedWords = []string{
+	"anjing", "bangsat", "bajingan", "goblok", "tolol", "kontol", "memek", "fuck", "shit",
+}
+
+// ReviewService handles the public side of tutor reviews (helpful votes and
+// reports) and the admin moderation queue.
+type ReviewService struct {
+	config *config.Config
+	review *repositories.ReviewRepository
+}
+
+func NewReviewService(config *config.Config, review *repositories.ReviewRepository) *ReviewService {
+	return &ReviewService{
+		config: config,
+		review: review,
+	}
+}
+
+// ContainsBlockedWord reports whether text contains any configured blocked
+// word as a whole word, ignoring case.
+func (s *ReviewService) ContainsBlockedWord(text string) bool {
+	words := s.config.Review.BlockedWords
+	if len(words) == 0 {
+		words = defaultBlockedWords
+	}
+
+	blocked := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		blocked[strings.ToLower(strings.TrimSpace(word))] = struct{}{}
+	}
+
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, token := range tokens {
+		if _, ok := blocked[token]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FlagIfProfane holds the review for moderation when its text contains a
+// blocked word and returns the system report to file with it, nil when the
+// text is clean. The report is only filed once the review is saved.
+func (s *ReviewService) FlagIfProfane(review *model.TutorReview) *model.ReviewReport {
+	if !s.ContainsBlockedWord(review.Review.String) {
+		return nil
+	}
+
+	review.ModerationStatus = model.ReviewModerationStatusPending
+
+	return &model.ReviewReport{
+		TutorReviewID: review.ID,
+		Source:        model.ReviewReportSourceSystem,
+		Reason:        "Automatically flagged by profanity filter",
+		Status:        model.ReviewReportStatusPending,
+	}
+}
+
+func (s *ReviewService) getPublicReview(ctx context.Context, id uuid.UUID) (*model.TutorReview, error) {
+	review, err := s.review.GetTutorReviewByID(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[getPublicReview] Error getting tutor review")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if review == nil || !review.IsPublic() {
+		return nil, shared.MakeError(ErrEntityNotFound, "review")
+	}
+
+	return review, nil
+}
+
+func (s *ReviewService) SetHelpful(ctx context.Context, id uuid.UUID, helpful bool) error {
+	userID := middleware.GetUserID(ctx)
+	review, err := s.getPublicReview(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if review.Student.UserID == userID {
+		return shared.MakeError(ErrBadRequest, "cannot vote on your own review")
+	}
+
+	err = s.review.SetHelpfulVote(ctx, review.ID, userID, helpful)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SetHelpful] Error saving helpful vote")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *ReviewService) Report(ctx context.Context, request dto.ReportReviewRequest) error {
+	userID := middleware.GetUserID(ctx)
+	review, err := s.getPublicReview(ctx, request.ID)
+	if err != nil {
+		return err
+	}
+
+	reported, err := s.review.HasPendingReport(ctx, review.ID, userID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportReview] Error checking existing report")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if reported {
+		return shared.MakeError(ErrBadRequest, "review already reported")
+	}
+
+	err = s.review.CreateReport(ctx, &model.ReviewReport{
+		TutorReviewID: review.ID,
+		ReporterID:    uuid.NullUUID{UUID: userID, Valid: true},
+		Source:        model.ReviewReportSourceUser,
+		Reason:        request.Reason,
+		Status:        model.ReviewReportStatusPending,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReportReview] Error creating report")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *ReviewService) ListModerationQueue(ctx context.Context, request dto.ListReviewModerationRequest) ([]model.TutorReview, model.Metadata, error) {
+	reviews, metadata, err := s.review.GetModerationQueue(ctx, model.ReviewModerationFilter{
+		ModerationStatus: model.ReviewModerationStatus(request.Status),
+		TutorID:          request.TutorID,
+		Pagination:       request.Pagination,
+		Sort:             request.Sort,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListModerationQueue] Error getting moderation queue")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return reviews, metadata, nil
+}
+
+func (s *ReviewService) Moderate(ctx context.Context, request dto.ModerateReviewRequest) error {
+	review, err := s.review.GetTutorReviewByID(ctx, request.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ModerateReview] Error getting tutor review")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if review == nil || review.DeletedAt.Valid {
+		return shared.MakeError(ErrEntityNotFound, "review")
+	}
+
+	reportStatus := model.ReviewReportStatusDismissed
+	review.ModerationStatus = model.ReviewModerationStatusPublished
+	if request.Action == dto.ReviewModerationActionHide {
+		reportStatus = model.ReviewReportStatusResolved
+		review.ModerationStatus = model.ReviewModerationStatusHidden
+	}
+
+	userID := middleware.GetUserID(ctx)
+	review.ModerationNote = request.Note
+	review.ModeratedAt = null.TimeFrom(time.Now())
+	review.ModeratedBy = uuid.NullUUID{UUID: userID, Valid: true}
+	review.UpdatedBy = uuid.NullUUID{UUID: userID, Valid: true}
+
+	err = s.review.ModerateTutorReview(ctx, review, reportStatus)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ModerateReview] Error saving moderation")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	err = s.review.RecalculateRating(ctx, review.TutorID, review.CourseID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ModerateReview] Error recalculating rating")
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/internal/model"
+)
+
+func TestReviewServiceContainsBlockedWord(t *testing.T) {
+	tests := []struct {
+		name    string
+		blocked []string
+		text    string
+		want    bool
+	}{
+		{name: "clean review", text: "Tutornya sabar dan jelas menjelaskan", want: false},
+		{name: "default blocked word", text: "Dasar goblok", want: true},
+		{name: "ignores case and punctuation", text: "Materinya... TOLOL!", want: true},
+		{name: "whole words only", text: "Pemetaan soal sangat membantu", want: false},
+		{name: "configured words replace the defaults", blocked: []string{" Jelek "}, text: "goblok, jelek sekali", want: true},
+		{name: "configured words skip the defaults", blocked: []string{"jelek"}, text: "goblok", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Review.BlockedWords = tt.blocked
+
+			s := &ReviewService{config: cfg}
+			if got := s.ContainsBlockedWord(tt.text); got != tt.want {
+				t.Errorf("ContainsBlockedWord(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewServiceFlagIfProfane(t *testing.T) {
+	s := &ReviewService{config: &config.Config{}}
+
+	clean := model.TutorReview{ID: uuid.New(), Review: null.StringFrom("Sangat membantu"), ModerationStatus: model.ReviewModerationStatusPublished}
+	if report := s.FlagIfProfane(&clean); report != nil || clean.ModerationStatus != model.ReviewModerationStatusPublished {
+		t.Errorf("FlagIfProfane() = %+v with status %s, want no report and the review published", report, clean.ModerationStatus)
+	}
+
+	profane := model.TutorReview{ID: uuid.New(), Review: null.StringFrom("Dasar goblok"), ModerationStatus: model.ReviewModerationStatusPublished}
+	report := s.FlagIfProfane(&profane)
+	if report == nil || report.TutorReviewID != profane.ID || report.Source != model.ReviewReportSourceSystem || report.Status != model.ReviewReportStatusPending {
+		t.Fatalf("FlagIfProfane() = %+v, want a pending system report on the review", report)
+	}
+	if profane.ModerationStatus != model.ReviewModerationStatusPending {
+		t.Errorf("ModerationStatus = %s, want %s", profane.ModerationStatus, model.ReviewModerationStatusPending)
+	}
+}
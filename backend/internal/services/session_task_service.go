@@ -2,36 +2,59 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
 	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
 	"github.com/lesprivate/backend/internal/repositories"
 	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
 	"github.com/lesprivate/backend/transport/http/middleware"
 	"github.com/shopspring/decimal"
 )
 
+// maxSubmissionFiles is the number of files a student can submit for a task
+const maxSubmissionFiles = 10
+
 type SessionTaskService struct {
 	sessionTaskRepo *repositories.SessionTaskRepository
 	bookingRepo     *repositories.BookingRepository
 	tutorRepo       *repositories.TutorRepository
+	studentRepo     *repositories.StudentRepository
+	quizRepo        *repositories.QuizRepository
+	file            *FileService
+	notification    *NotificationService
 }
 
 func NewSessionTaskService(
 	sessionTaskRepo *repositories.SessionTaskRepository,
 	bookingRepo *repositories.BookingRepository,
 	tutorRepo *repositories.TutorRepository,
+	studentRepo *repositories.StudentRepository,
+	quizRepo *repositories.QuizRepository,
+	file *FileService,
+	notification *NotificationService,
 ) *SessionTaskService {
 	return &SessionTaskService{
 		sessionTaskRepo: sessionTaskRepo,
 		bookingRepo:     bookingRepo,
 		tutorRepo:       tutorRepo,
+		studentRepo:     studentRepo,
+		quizRepo:        quizRepo,
+		file:            file,
+		notification:    notification,
 	}
 }
 
-// AddTaskToBooking adds a new task (module/assignment) to a specific booking session
-func (s *SessionTaskService) AddTaskToBooking(ctx context.Context, bookingID uuid.UUID, title string, description null.String, attachmentURL null.String) (*model.SessionTask, error) {
+// AddTaskToBooking adds a new task (module/assignment) to a specific booking
+// session. A task with a quiz of the tutor's library is taken by the student
+// and graded automatically, so it has no rubric.
+func (s *SessionTaskService) AddTaskToBooking(ctx context.Context, bookingID uuid.UUID, title string, description null.String, attachmentURL null.String, dueAt null.Time, rubric []model.RubricCriterion, quizID *uuid.UUID) (*model.SessionTask, error) {
 	// 1. Verify Tutor
 	tutor, err := s.tutorRepo.GetByUserID(ctx, middleware.GetUserID(ctx))
 	if err != nil || tutor == nil {
@@ -47,12 +70,47 @@ func (s *SessionTaskService) AddTaskToBooking(ctx context.Context, bookingID uui
 		return nil, shared.MakeError(ErrForbidden, "booking ownership mismatch")
 	}
 
+	if dueAt.Valid && !dueAt.Time.After(time.Now()) {
+		return nil, shared.MakeError(ErrBadRequest, "due date must be in the future")
+	}
+
 	// 3. Create Task
 	task := &model.SessionTask{
 		BookingID:     bookingID,
 		Title:         title,
 		Description:   description,
 		AttachmentURL: attachmentURL,
+		DueAt:         dueAt,
+		QuizID:        nullUUID(quizID),
+	}
+
+	if quizID != nil {
+		if len(rubric) > 0 {
+			return nil, shared.MakeError(ErrBadRequest, "task with a quiz is graded automatically and has no rubric")
+		}
+
+		quiz, err := s.quizRepo.GetByID(ctx, *quizID)
+		if err != nil {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+		if quiz == nil || quiz.DeletedAt.Valid || quiz.TutorID != tutor.ID {
+			return nil, shared.MakeError(ErrEntityNotFound, "quiz")
+		}
+		if len(quiz.Items) == 0 {
+			return nil, shared.MakeError(ErrBadRequest, "quiz has no questions")
+		}
+	}
+
+	if len(rubric) > 0 {
+		err = model.ValidateRubric(rubric)
+		if err != nil {
+			return nil, shared.MakeError(ErrBadRequest, err.Error())
+		}
+
+		task.Rubric, err = json.Marshal(rubric)
+		if err != nil {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
 	}
 
 	err = s.sessionTaskRepo.Create(ctx, task)
@@ -60,6 +118,8 @@ func (s *SessionTaskService) AddTaskToBooking(ctx context.Context, bookingID uui
 		return nil, shared.MakeError(ErrInternalServer)
 	}
 
+	_ = s.notification.TaskAssigned(ctx, *booking, *task)
+
 	return task, nil
 }
 
@@ -91,10 +151,16 @@ func (s *SessionTaskService) GradeTask(ctx context.Context, taskID uuid.UUID, su
 		return nil, shared.MakeError(ErrInternalServer)
 	}
 
+	now := time.Now()
 	if submission != nil {
 		// Update existing
 		submission.SubmissionURL = submissionURL
 		submission.Score = score
+		if score.Valid {
+			submission.Status = model.TaskSubmissionStatusGraded
+			submission.GradedAt = null.TimeFrom(now)
+			submission.GradedBy = uuid.NullUUID{UUID: tutor.UserID, Valid: true}
+		}
 		err = s.sessionTaskRepo.UpdateSubmission(ctx, submission)
 		if err != nil {
 			return nil, shared.MakeError(ErrInternalServer)
@@ -105,8 +171,15 @@ func (s *SessionTaskService) GradeTask(ctx context.Context, taskID uuid.UUID, su
 	// Create new
 	newSubmission := &model.TaskSubmission{
 		SessionTaskID: taskID,
+		Status:        model.TaskSubmissionStatusSubmitted,
 		SubmissionURL: submissionURL,
 		Score:         score,
+		SubmittedAt:   null.TimeFrom(now),
+	}
+	if score.Valid {
+		newSubmission.Status = model.TaskSubmissionStatusGraded
+		newSubmission.GradedAt = null.TimeFrom(now)
+		newSubmission.GradedBy = uuid.NullUUID{UUID: tutor.UserID, Valid: true}
 	}
 
 	err = s.sessionTaskRepo.CreateSubmission(ctx, newSubmission)
@@ -116,3 +189,261 @@ func (s *SessionTaskService) GradeTask(ctx context.Context, taskID uuid.UUID, su
 
 	return newSubmission, nil
 }
+
+// GetStudentTasks lists the tasks across the bookings of the current student
+func (s *SessionTaskService) GetStudentTasks(ctx context.Context, request dto.GetStudentTasksRequest) ([]dto.StudentTaskResponse, model.Metadata, error) {
+	student, err := s.studentRepo.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentTasks] Error getting student")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+	if student == nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	tasks, metadata, err := s.sessionTaskRepo.GetStudentTasks(ctx, model.SessionTaskFilter{
+		StudentID:  student.ID,
+		Status:     request.Status,
+		Pagination: request.Pagination,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetStudentTasks] Error getting tasks")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	results := make([]dto.StudentTaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		results = append(results, dto.NewStudentTaskResponse(task))
+	}
+
+	return results, metadata, nil
+}
+
+// SubmitTask uploads the work of the current student for a task. The student
+// can resubmit, replacing the earlier files, until the task is due or
+// graded. Submitting for the first time after the due date is accepted but
+// flagged late.
+func (s *SessionTaskService) SubmitTask(ctx context.Context, taskID uuid.UUID, files []*multipart.FileHeader) (*model.TaskSubmission, error) {
+	if len(files) == 0 {
+		return nil, shared.MakeError(ErrBadRequest, "at least one file is required")
+	}
+	if len(files) > maxSubmissionFiles {
+		return nil, shared.MakeError(ErrBadRequest, fmt.Sprintf("at most %d files can be submitted", maxSubmissionFiles))
+	}
+
+	for _, header := range files {
+		err := s.file.ValidateFileType(header.Filename)
+		if err != nil {
+			return nil, shared.MakeError(ErrBadRequest, err.Error())
+		}
+	}
+
+	student, err := s.studentRepo.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil || student == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	task, err := s.sessionTaskRepo.GetByID(ctx, taskID)
+	if err != nil || task == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "task")
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, task.BookingID)
+	if err != nil || booking == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "booking")
+	}
+
+	if booking.StudentID != student.ID {
+		return nil, shared.MakeError(ErrForbidden, "task ownership mismatch")
+	}
+
+	if task.QuizID.Valid {
+		return nil, shared.MakeError(ErrBadRequest, "task is submitted by taking its quiz")
+	}
+
+	submission, err := s.sessionTaskRepo.GetSubmissionByTaskID(ctx, taskID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitTask] Error getting submission")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	now := time.Now()
+	if submission != nil {
+		if submission.IsGraded() {
+			return nil, shared.MakeError(ErrTaskSubmissionClosed, "task has been graded")
+		}
+		if task.IsPastDue(now) {
+			return nil, shared.MakeError(ErrTaskSubmissionClosed, "task is past its due date")
+		}
+	} else {
+		submission = &model.TaskSubmission{SessionTaskID: taskID}
+	}
+	submittedAt := submission.SubmittedAt
+
+	uploads := make([]model.TaskSubmissionFile, 0, len(files))
+	for _, header := range files {
+		upload, err := s.uploadSubmissionFile(ctx, header)
+		if err != nil {
+			s.deleteSubmissionFiles(ctx, uploads)
+			return nil, err
+		}
+
+		uploads = append(uploads, model.TaskSubmissionFile{
+			URL:      upload.URL,
+			FileKey:  upload.Key,
+			Filename: upload.Filename,
+			Size:     upload.Size,
+		})
+	}
+
+	submission.Status = model.TaskSubmissionStatusSubmitted
+	submission.SubmissionURL = null.StringFrom(uploads[0].URL)
+	submission.SubmittedAt = null.TimeFrom(now)
+	submission.IsLate = task.IsPastDue(now)
+
+	saved, err := s.sessionTaskRepo.SaveSubmissionFiles(ctx, submission, submittedAt, uploads)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitTask] Error saving submission")
+		s.deleteSubmissionFiles(ctx, uploads)
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	// Graded or submitted again since it was read
+	if !saved {
+		s.deleteSubmissionFiles(ctx, uploads)
+		return nil, shared.MakeError(ErrTaskSubmissionClosed, "task was submitted or graded in the meantime")
+	}
+
+	err = s.notification.TaskSubmissionReceived(ctx, *booking, *task, *submission)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[SubmitTask] Error sending submission notification")
+	}
+
+	return submission, nil
+}
+
+// deleteSubmissionFiles removes uploaded files that did not make it into a
+// submission.
+func (s *SessionTaskService) deleteSubmissionFiles(ctx context.Context, files []model.TaskSubmissionFile) {
+	for _, file := range files {
+		if err := s.file.DeleteFile(ctx, file.FileKey); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("key", file.FileKey).Msg("[SubmitTask] Error deleting unsaved submission file")
+		}
+	}
+}
+
+func (s *SessionTaskService) uploadSubmissionFile(ctx context.Context, header *multipart.FileHeader) (*dto.UploadResult, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, shared.MakeError(ErrBadRequest, err.Error())
+	}
+	defer file.Close()
+
+	result, err := s.file.UploadFile(ctx, file, header)
+	if err != nil {
+		if header.Size > s.file.config.File.MaxUploadSize {
+			return nil, shared.MakeError(ErrBadRequest, err.Error())
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetGradingQueue lists the submissions on the bookings of the current tutor,
+// the ungraded ones waiting longest first
+func (s *SessionTaskService) GetGradingQueue(ctx context.Context, request dto.GetGradingQueueRequest) ([]dto.GradingQueueResponse, model.Metadata, error) {
+	tutor, err := s.tutorRepo.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil || tutor == nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	submissions, metadata, err := s.sessionTaskRepo.GetSubmissions(ctx, model.TaskSubmissionFilter{
+		TutorID:    tutor.ID,
+		Status:     request.Status,
+		Pagination: request.Pagination,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetGradingQueue] Error getting submissions")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	results := make([]dto.GradingQueueResponse, 0, len(submissions))
+	for _, submission := range submissions {
+		results = append(results, dto.NewGradingQueueResponse(submission))
+	}
+
+	return results, metadata, nil
+}
+
+// GradeSubmission scores a submission of the student with written feedback.
+// Tasks with a rubric are scored per criterion, rolled up into the score.
+// A graded submission can be graded again to correct it.
+func (s *SessionTaskService) GradeSubmission(ctx context.Context, submissionID uuid.UUID, request dto.GradeTaskSubmissionRequest) (*model.TaskSubmission, error) {
+	tutor, err := s.tutorRepo.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil || tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	submission, err := s.sessionTaskRepo.GetSubmissionByID(ctx, submissionID)
+	if err != nil || submission == nil || submission.SessionTask == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "submission")
+	}
+
+	task := submission.SessionTask
+	booking, err := s.bookingRepo.GetByID(ctx, task.BookingID)
+	if err != nil || booking == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "booking")
+	}
+
+	if booking.TutorID != tutor.ID {
+		return nil, shared.MakeError(ErrForbidden, "task ownership mismatch")
+	}
+
+	score, err := rubricScore(task.GetRubric(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	submission.RubricScores = nil
+	if len(request.RubricScores) > 0 {
+		submission.RubricScores, err = json.Marshal(request.RubricScores)
+		if err != nil {
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+	}
+
+	submission.Status = model.TaskSubmissionStatusGraded
+	submission.Score = decimal.NewNullDecimal(score)
+	submission.Feedback = null.StringFromPtr(request.Feedback)
+	submission.GradedAt = null.TimeFrom(time.Now())
+	submission.GradedBy = uuid.NullUUID{UUID: tutor.UserID, Valid: true}
+
+	err = s.sessionTaskRepo.UpdateSubmission(ctx, submission)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GradeSubmission] Error updating submission")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	_ = s.notification.TaskGraded(ctx, *booking, *task, *submission)
+
+	return submission, nil
+}
+
+// rubricScore returns the score of the grading, rolled up from the criteria
+// scores for tasks with a rubric.
+func rubricScore(rubric []model.RubricCriterion, request dto.GradeTaskSubmissionRequest) (decimal.Decimal, error) {
+	if len(rubric) == 0 {
+		if request.Score == nil || len(request.RubricScores) > 0 {
+			return decimal.Zero, shared.MakeError(ErrBadRequest, "task without rubric is graded with a score")
+		}
+		return decimal.NewFromFloat(*request.Score).Round(2), nil
+	}
+
+	total, err := model.RubricTotal(rubric, request.RubricScores)
+	if err != nil {
+		return decimal.Zero, shared.MakeError(ErrBadRequest, err.Error())
+	}
+
+	return total, nil
+}
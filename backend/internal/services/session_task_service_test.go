@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+)
+
+func TestRubricScore(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	rubric := []model.RubricCriterion{
+		{Name: "Isi", MaxScore: decimal.NewFromInt(60)},
+		{Name: "Tata bahasa", MaxScore: decimal.NewFromInt(40)},
+	}
+	scores := func(isi, bahasa int64) []model.RubricScore {
+		return []model.RubricScore{
+			{Criterion: "Isi", Score: decimal.NewFromInt(isi)},
+			{Criterion: "Tata bahasa", Score: decimal.NewFromInt(bahasa)},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		rubric  []model.RubricCriterion
+		req     dto.GradeTaskSubmissionRequest
+		want    decimal.Decimal
+		wantErr bool
+	}{
+		{name: "score without rubric", req: dto.GradeTaskSubmissionRequest{Score: score(87.456)}, want: decimal.RequireFromString("87.46")},
+		{name: "rubric scores without rubric", req: dto.GradeTaskSubmissionRequest{RubricScores: scores(50, 30)}, wantErr: true},
+		{name: "rubric scores are summed", rubric: rubric, req: dto.GradeTaskSubmissionRequest{RubricScores: scores(50, 30)}, want: decimal.NewFromInt(80)},
+		{
+			name: "weighted rubric scores",
+			rubric: []model.RubricCriterion{
+				{Name: "Isi", MaxScore: decimal.NewFromInt(4), Weight: decimal.NewFromInt(80)},
+				{Name: "Tata bahasa", MaxScore: decimal.NewFromInt(4), Weight: decimal.NewFromInt(20)},
+			},
+			req:  dto.GradeTaskSubmissionRequest{RubricScores: scores(2, 4)},
+			want: decimal.NewFromInt(60),
+		},
+		{name: "criterion not scored", rubric: rubric, req: dto.GradeTaskSubmissionRequest{RubricScores: scores(50, 30)[:1]}, wantErr: true},
+		{name: "criterion over its max score", rubric: rubric, req: dto.GradeTaskSubmissionRequest{RubricScores: scores(61, 30)}, wantErr: true},
+		{
+			name:    "criterion scored twice",
+			rubric:  rubric,
+			req:     dto.GradeTaskSubmissionRequest{RubricScores: []model.RubricScore{{Criterion: "Isi", Score: decimal.NewFromInt(10)}, {Criterion: "Isi", Score: decimal.NewFromInt(10)}}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown criterion",
+			rubric:  rubric,
+			req:     dto.GradeTaskSubmissionRequest{RubricScores: []model.RubricScore{{Criterion: "Isi", Score: decimal.NewFromInt(10)}, {Criterion: "Kerapian", Score: decimal.NewFromInt(10)}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rubricScore(tt.rubric, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rubricScore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("rubricScore() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
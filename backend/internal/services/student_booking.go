@@ -24,6 +24,9 @@ type StudentBookingService struct {
 	mentorStudent *repositories.MentorStudentRepository
 	notification  *NotificationService
 	courseService *CourseService
+	bookingEvent  *BookingEventService
+	entitlement   *EntitlementService
+	groupSession  *GroupSessionService
 	config        *config.Config
 }
 
@@ -36,6 +39,9 @@ func NewStudentBookingService(
 	mentorStudent *repositories.MentorStudentRepository,
 	notification *NotificationService,
 	courseService *CourseService,
+	bookingEvent *BookingEventService,
+	entitlement *EntitlementService,
+	groupSession *GroupSessionService,
 	config *config.Config,
 ) *StudentBookingService {
 	return &StudentBookingService{
@@ -48,6 +54,9 @@ func NewStudentBookingService(
 		config:        config,
 		notification:  notification,
 		courseService: courseService,
+		bookingEvent:  bookingEvent,
+		entitlement:   entitlement,
+		groupSession:  groupSession,
 	}
 }
 
@@ -159,6 +168,10 @@ func (s *StudentBookingService) Create(ctx context.Context, request dto.CreateSt
 			continue
 		}
 
+		if c.IsGroup() != (request.SessionType == model.GroupSessionType) {
+			continue
+		}
+
 		schedule = &c
 	}
 
@@ -196,8 +209,38 @@ func (s *StudentBookingService) Create(ctx context.Context, request dto.CreateSt
 		}, shared.MakeError(ErrStudentAlreadyHasAnotherSchedule)
 	}
 
+	entitlement, err := s.entitlement.Resolve(ctx, *student)
+	if err != nil {
+		return nil, err
+	}
+
+	quota, err := s.entitlement.BookingQuota(ctx, student.ID, entitlement)
+	if err != nil {
+		return nil, err
+	}
+
+	if quota.Exceeded() {
+		logger.ErrorCtx(ctx).Msg("[CreateStudentBooking] Max booking per day reached")
+		return map[string]any{
+			"quota": quota,
+		}, shared.MakeError(ErrMaxBookingPerDay)
+	}
+
+	quota, err = s.entitlement.CategoryBookingQuota(ctx, student.ID, course.CourseCategoryID, entitlement)
+	if err != nil {
+		return nil, err
+	}
+
+	if quota.Exceeded() {
+		logger.ErrorCtx(ctx).Msg("[CreateStudentBooking] Max booking per category reached")
+		return map[string]any{
+			"quota": quota,
+		}, shared.MakeError(ErrMaxBookingPerCategory)
+	}
+
 	var isFreeFirstCourse bool
-	if course.IsFreeFirstCourse.Bool {
+	freeFirstCourseLimit := entitlement.MaxFreeFirstCoursePerCategory
+	if course.IsFreeFirstCourse.Bool && (!freeFirstCourseLimit.Valid || freeFirstCourseLimit.Int64 > 0) {
 		count, err := s.booking.Count(ctx, model.BookingFilter{
 			StudentID:         student.ID,
 			CourseID:          course.ID,
@@ -224,7 +267,7 @@ func (s *StudentBookingService) Create(ctx context.Context, request dto.CreateSt
 			return nil, shared.MakeError(ErrInternalServer)
 		}
 
-		if bookings != nil && len(bookings) >= s.config.Booking.MaxBookingFreeFirstCourse {
+		if len(bookings) > 0 && freeFirstCourseLimit.Valid && int64(len(bookings)) >= freeFirstCourseLimit.Int64 {
 			logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentBooking] Max booking free first course reached")
 			b := dto.Booking{
 				ID:                bookings[0].ID,
@@ -238,7 +281,8 @@ func (s *StudentBookingService) Create(ctx context.Context, request dto.CreateSt
 			}
 			return map[string]any{
 				"booking":                   b,
-				"maxBookingFreeFirstCourse": s.config.Booking.MaxBookingFreeFirstCourse,
+				"maxBookingFreeFirstCourse": freeFirstCourseLimit.Int64,
+				"quota":                     model.NewEntitlementQuota(model.EntitlementFreeFirstCourse, freeFirstCourseLimit, int64(len(bookings)), quotaResetAt(time.Now())),
 			}, shared.MakeError(ErrMaxBookingFreeFirstCourse)
 		}
 	}
@@ -256,6 +300,7 @@ func (s *StudentBookingService) Create(ctx context.Context, request dto.CreateSt
 		Longitude:         request.Longitude,
 		NotesTutor:        request.Notes,
 		IsFreeFirstCourse: isFreeFirstCourse,
+		IsPriority:        entitlement.PriorityResponse,
 		Status:            model.BookingStatusPending,
 		ExpiredAt:         time.Now().Add(s.config.Booking.ExpiredDuration),
 		CreatedAt:         time.Now(),
@@ -266,10 +311,20 @@ func (s *StudentBookingService) Create(ctx context.Context, request dto.CreateSt
 
 	booking.GenerateCode()
 
-	err = s.booking.Create(ctx, booking)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentBooking] Error creating student booking")
-		return nil, shared.MakeError(ErrInternalServer)
+	if schedule.IsGroup() {
+		err = s.groupSession.ReserveSeat(ctx, *course, *schedule, booking)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentBooking] Error reserving group session seat")
+			return nil, err
+		}
+	} else {
+		err = s.booking.Create(ctx, booking)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentBooking] Error creating student booking")
+			return nil, shared.MakeError(ErrInternalServer)
+		}
+
+		s.bookingEvent.RecordCreated(ctx, *booking, userID)
 	}
 
 	// Auto-join logic: establish tutor-student relationship if it doesn't exist
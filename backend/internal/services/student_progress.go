@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+const (
+	// progressRefreshMonths is how many months up to the current one the
+	// periodic job recomputes, older months rarely change
+	progressRefreshMonths = 2
+	// progressTrendPoints is the number of months with data a trend needs
+	progressTrendPoints = 3
+	// A metric is declining when it loses more than this per month
+	decliningScoreSlope = -3.0
+	decliningRateSlope  = -10.0
+	// Slopes closer to zero than this are flat
+	flatProgressSlope = 0.5
+)
+
+// StudentProgressService computes the learning progress of students per
+// subject over time from the materialised progress summaries.
+type StudentProgressService struct {
+	progress      *repositories.StudentProgressRepository
+	student       *repositories.StudentRepository
+	tutor         *repositories.TutorRepository
+	mentorStudent *repositories.MentorStudentRepository
+}
+
+func NewStudentProgressService(
+	progress *repositories.StudentProgressRepository,
+	student *repositories.StudentRepository,
+	tutor *repositories.TutorRepository,
+	mentorStudent *repositories.MentorStudentRepository,
+) *StudentProgressService {
+	return &StudentProgressService{
+		progress:      progress,
+		student:       student,
+		tutor:         tutor,
+		mentorStudent: mentorStudent,
+	}
+}
+
+// RefreshProgressSummaries recomputes the summaries of the last months, the
+// default number of them when months is zero.
+func (s *StudentProgressService) RefreshProgressSummaries(ctx context.Context, months int) error {
+	if months <= 0 {
+		months = progressRefreshMonths
+	}
+
+	now := time.Now()
+	until := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).AddDate(0, 1, 0)
+	from := until.AddDate(0, -months, 0)
+
+	err := s.progress.Refresh(ctx, from, until, now)
+	if err != nil {
+		return err
+	}
+
+	logger.InfoCtx(ctx).Int("months", months).Msg("[RefreshProgressSummaries] Progress summaries refreshed")
+	return nil
+}
+
+// GetStudentProgress returns the progress of the current student with all
+// their tutors.
+func (s *StudentProgressService) GetStudentProgress(ctx context.Context, request dto.GetStudentProgressRequest) (*dto.StudentProgressResponse, error) {
+	student, err := s.student.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if student == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	return s.studentProgress(ctx, student.ID, uuid.Nil, request)
+}
+
+// GetMentorStudentProgress returns the progress of a student of the current
+// mentor in the sessions with the mentor.
+func (s *StudentProgressService) GetMentorStudentProgress(ctx context.Context, studentID uuid.UUID, request dto.GetStudentProgressRequest) (*dto.StudentProgressResponse, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil || tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	_, err = s.mentorStudent.GetByTutorAndStudent(ctx, tutor.ID, studentID)
+	if err != nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	return s.studentProgress(ctx, studentID, tutor.ID, request)
+}
+
+func (s *StudentProgressService) studentProgress(ctx context.Context, studentID, tutorID uuid.UUID, request dto.GetStudentProgressRequest) (*dto.StudentProgressResponse, error) {
+	periods := model.ProgressPeriods(time.Now(), request.Months)
+	summaries, err := s.progress.Get(ctx, model.StudentProgressFilter{
+		StudentID:        studentID,
+		TutorID:          tutorID,
+		CourseCategoryID: request.CourseCategoryID,
+		PeriodFrom:       periods[0],
+		PeriodUntil:      periods[len(periods)-1],
+	})
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := &dto.StudentProgressResponse{
+		StudentID: studentID,
+		Periods:   periods,
+		Overall:   progressBreakdown(summaries, periods),
+		Subjects:  []dto.SubjectProgress{},
+	}
+
+	subjects := map[uuid.UUID][]model.StudentProgressSummary{}
+	names := map[uuid.UUID]string{}
+	for _, summary := range summaries {
+		subjects[summary.CourseCategoryID] = append(subjects[summary.CourseCategoryID], summary)
+		if summary.CourseCategory != nil {
+			names[summary.CourseCategoryID] = summary.CourseCategory.Name
+		}
+	}
+
+	for id, subjectSummaries := range subjects {
+		res.Subjects = append(res.Subjects, dto.SubjectProgress{
+			CourseCategoryID:  id,
+			Name:              names[id],
+			ProgressBreakdown: progressBreakdown(subjectSummaries, periods),
+		})
+	}
+
+	sort.Slice(res.Subjects, func(i, j int) bool {
+		return res.Subjects[i].Name < res.Subjects[j].Name
+	})
+
+	return res, nil
+}
+
+// GetCohortProgress compares the progress of every student of the current
+// mentor with the cohort of them all, in the sessions with the mentor.
+func (s *StudentProgressService) GetCohortProgress(ctx context.Context, request dto.GetStudentProgressRequest) (*dto.CohortProgressResponse, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil || tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	periods := model.ProgressPeriods(time.Now(), request.Months)
+	summaries, err := s.progress.Get(ctx, model.StudentProgressFilter{
+		TutorID:          tutor.ID,
+		CourseCategoryID: request.CourseCategoryID,
+		PeriodFrom:       periods[0],
+		PeriodUntil:      periods[len(periods)-1],
+		WithStudent:      true,
+	})
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := &dto.CohortProgressResponse{
+		Periods:  periods,
+		Cohort:   progressBreakdown(summaries, periods),
+		Students: []dto.CohortStudentProgress{},
+	}
+
+	students := map[uuid.UUID][]model.StudentProgressSummary{}
+	names := map[uuid.UUID]string{}
+	for _, summary := range summaries {
+		students[summary.StudentID] = append(students[summary.StudentID], summary)
+		if summary.Student != nil {
+			names[summary.StudentID] = summary.Student.User.Name
+		}
+	}
+
+	cohortScore := res.Cohort.Summary.AverageScore
+	for id, studentSummaries := range students {
+		breakdown := progressBreakdown(studentSummaries, periods)
+		student := dto.CohortStudentProgress{
+			StudentID:       id,
+			Name:            names[id],
+			ProgressFigures: breakdown.Summary,
+			Trends:          breakdown.Trends,
+			Declining:       breakdown.Declining,
+		}
+
+		if student.AverageScore.Valid && cohortScore.Valid {
+			student.ScoreDelta = null.FloatFrom(math.Round((student.AverageScore.Float64-cohortScore.Float64)*10) / 10)
+		}
+
+		res.Students = append(res.Students, student)
+	}
+
+	sort.Slice(res.Students, func(i, j int) bool {
+		return res.Students[i].Name < res.Students[j].Name
+	})
+
+	return res, nil
+}
+
+// progressBreakdown totals the summaries over the periods and per period,
+// with the trend of each metric.
+func progressBreakdown(summaries []model.StudentProgressSummary, periods []string) dto.ProgressBreakdown {
+	var (
+		total     model.ProgressTotals
+		perPeriod = make(map[string]*model.ProgressTotals, len(periods))
+	)
+
+	for _, summary := range summaries {
+		total.Add(summary)
+		if perPeriod[summary.Period] == nil {
+			perPeriod[summary.Period] = &model.ProgressTotals{}
+		}
+		perPeriod[summary.Period].Add(summary)
+	}
+
+	series := dto.ProgressSeries{
+		Sessions:           make([]int, len(periods)),
+		AverageScore:       make([]null.Float, len(periods)),
+		TaskCompletionRate: make([]null.Float, len(periods)),
+		AttendanceRate:     make([]null.Float, len(periods)),
+	}
+	for i, period := range periods {
+		totals, ok := perPeriod[period]
+		if !ok {
+			continue
+		}
+		series.Sessions[i] = totals.SessionsAttended
+		series.AverageScore[i] = totals.AverageScore()
+		series.TaskCompletionRate[i] = totals.TaskCompletionRate()
+		series.AttendanceRate[i] = totals.AttendanceRate()
+	}
+
+	breakdown := dto.ProgressBreakdown{
+		Summary: dto.ProgressFigures{
+			Sessions:           total.SessionsAttended,
+			AverageScore:       total.AverageScore(),
+			TaskCompletionRate: total.TaskCompletionRate(),
+			AttendanceRate:     total.AttendanceRate(),
+		},
+		Series: series,
+		Trends: []dto.ProgressTrend{},
+	}
+
+	for _, trend := range []struct {
+		metric    string
+		values    []null.Float
+		declining float64
+	}{
+		{"averageScore", series.AverageScore, decliningScoreSlope},
+		{"taskCompletionRate", series.TaskCompletionRate, decliningRateSlope},
+		{"attendanceRate", series.AttendanceRate, decliningRateSlope},
+	} {
+		slope, ok := model.ProgressSlope(trend.values, progressTrendPoints)
+		if !ok {
+			continue
+		}
+
+		progressTrend := dto.ProgressTrend{
+			Metric:    trend.metric,
+			Slope:     slope,
+			Direction: "flat",
+			Declining: slope <= trend.declining,
+		}
+		if slope >= flatProgressSlope {
+			progressTrend.Direction = "up"
+		} else if slope <= -flatProgressSlope {
+			progressTrend.Direction = "down"
+		}
+
+		breakdown.Trends = append(breakdown.Trends, progressTrend)
+		breakdown.Declining = breakdown.Declining || progressTrend.Declining
+	}
+
+	return breakdown
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+)
+
+func TestProgressBreakdown(t *testing.T) {
+	periods := []string{"202512", "202601", "202602", "202603"}
+	summary := func(period string, score int64) model.StudentProgressSummary {
+		return model.StudentProgressSummary{
+			Period:            period,
+			SessionsScheduled: 2,
+			SessionsAttended:  2,
+			ScoresCount:       1,
+			ScoreSum:          decimal.NewFromInt(score),
+		}
+	}
+
+	// Two subjects in January, nothing in December
+	summaries := []model.StudentProgressSummary{
+		summary("202601", 90),
+		summary("202601", 90),
+		summary("202602", 80),
+		summary("202603", 70),
+	}
+
+	breakdown := progressBreakdown(summaries, periods)
+
+	if breakdown.Summary.Sessions != 8 || breakdown.Summary.AverageScore.Float64 != 82.5 || breakdown.Summary.AttendanceRate.Float64 != 100 {
+		t.Errorf("Summary = %+v, want 8 sessions averaging 82.5 fully attended", breakdown.Summary)
+	}
+	if breakdown.Summary.TaskCompletionRate.Valid {
+		t.Errorf("Summary.TaskCompletionRate = %v, want null without tasks", breakdown.Summary.TaskCompletionRate)
+	}
+
+	wantSessions := []int{0, 4, 2, 2}
+	for i, sessions := range breakdown.Series.Sessions {
+		if sessions != wantSessions[i] {
+			t.Errorf("Series.Sessions = %v, want %v", breakdown.Series.Sessions, wantSessions)
+			break
+		}
+	}
+	if breakdown.Series.AverageScore[0].Valid || breakdown.Series.AverageScore[1].Float64 != 90 {
+		t.Errorf("Series.AverageScore = %v, want null then 90", breakdown.Series.AverageScore)
+	}
+
+	trends := make(map[string]dto.ProgressTrend, len(breakdown.Trends))
+	for _, trend := range breakdown.Trends {
+		trends[trend.Metric] = trend
+	}
+	if len(trends) != 2 {
+		t.Fatalf("Trends = %+v, want score and attendance trends only", breakdown.Trends)
+	}
+	if score := trends["averageScore"]; score.Slope != -10 || score.Direction != "down" || !score.Declining {
+		t.Errorf("averageScore trend = %+v, want declining by 10", score)
+	}
+	if attendance := trends["attendanceRate"]; attendance.Slope != 0 || attendance.Direction != "flat" || attendance.Declining {
+		t.Errorf("attendanceRate trend = %+v, want flat", attendance)
+	}
+	if !breakdown.Declining {
+		t.Errorf("Declining = false, want true")
+	}
+}
+
+func TestProgressBreakdownWithoutData(t *testing.T) {
+	breakdown := progressBreakdown(nil, []string{"202601", "202602", "202603"})
+
+	if breakdown.Trends == nil || len(breakdown.Trends) != 0 || breakdown.Declining {
+		t.Errorf("progressBreakdown() = %+v, want no trends", breakdown)
+	}
+	if len(breakdown.Series.AverageScore) != 3 || breakdown.Series.AverageScore[2].Valid {
+		t.Errorf("Series.AverageScore = %v, want 3 null values", breakdown.Series.AverageScore)
+	}
+}
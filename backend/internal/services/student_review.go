@@ -19,7 +19,8 @@ type StudentReviewService struct {
 	config              *config.Config
 	review              *repositories.ReviewRepository
 	student             *repositories.StudentRepository
-	tutor               *repositories.TutorRepository
+	reviewService       *ReviewService
+	tutorLevelService   *TutorLevelService
 	notificationService *NotificationService
 }
 
@@ -27,14 +28,16 @@ func NewStudentReviewService(
 	config *config.Config,
 	review *repositories.ReviewRepository,
 	student *repositories.StudentRepository,
-	tutor *repositories.TutorRepository,
+	reviewService *ReviewService,
+	tutorLevelService *TutorLevelService,
 	notificationService *NotificationService,
 ) *StudentReviewService {
 	return &StudentReviewService{
 		config:              config,
 		review:              review,
 		student:             student,
-		tutor:               tutor,
+		reviewService:       reviewService,
+		tutorLevelService:   tutorLevelService,
 		notificationService: notificationService,
 	}
 }
@@ -103,30 +106,36 @@ func (s *StudentReviewService) Update(ctx context.Context, request dto.UpdateRev
 	review.Rate = null.IntFrom(int64(request.Rate))
 	review.RecommendByStudent = request.RecommendByStudent
 	review.IsSubmitted = true
+	if request.Criteria != nil {
+		review.PunctualityRate = request.Criteria.Punctuality
+		review.ClarityRate = request.Criteria.Clarity
+		review.PatienceRate = request.Criteria.Patience
+		review.MaterialsRate = request.Criteria.Materials
+	}
 
-	err = s.review.UpdateTutorReview(ctx, review)
+	if report := s.reviewService.FlagIfProfane(review); report != nil {
+		err = s.review.UpdateFlaggedTutorReview(ctx, review, report)
+	} else {
+		err = s.review.UpdateTutorReview(ctx, review)
+	}
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateStudentReview] Error updating student review")
 		return shared.MakeError(ErrInternalServer)
 	}
 
+	err = s.review.RecalculateRating(ctx, review.TutorID, review.CourseID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateStudentReview] Error recalculating rating")
+	}
+
 	if !isSubmitted {
-		// Update tutor level point
+		// Re-evaluate tutor level with the new rating
 		go func() {
 			bgCtx := context.Background()
 
-			tutor, err := s.tutor.GetByID(bgCtx, review.TutorID)
+			err := s.tutorLevelService.Evaluate(bgCtx, review.TutorID)
 			if err != nil {
-				logger.ErrorCtx(bgCtx).Err(err).Msg("[UpdateStudentReview] Error getting tutor")
-				return
-			}
-
-			if tutor != nil {
-				tutor.LevelPoint++
-				err = s.tutor.Update(bgCtx, tutor)
-				if err != nil {
-					logger.ErrorCtx(bgCtx).Err(err).Msg("[UpdateStudentReview] Error updating tutor level point")
-				}
+				logger.ErrorCtx(bgCtx).Err(err).Msg("[UpdateStudentReview] Error evaluating tutor level")
 			}
 
 			err = s.notificationService.SubmitReviewTutor(bgCtx, *review)
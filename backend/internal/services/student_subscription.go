@@ -1,19 +1,16 @@
 package services
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"html/template"
+	"errors"
 	"time"
 
-	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
-	"github.com/leekchan/accounting"
 	"github.com/shopspring/decimal"
 	"github.com/xendit/xendit-go/v7"
 	xenditcustomer "github.com/xendit/xendit-go/v7/customer"
+	"gorm.io/gorm"
 
 	"github.com/lesprivate/backend/config"
 	xenditext "github.com/lesprivate/backend/external/xendit"
@@ -32,6 +29,8 @@ type StudentSubscriptionService struct {
 	subscriptionPrice *repositories.SubscriptionPriceRepository
 	payment           *repositories.PaymentRepository
 	notification      *NotificationService
+	lifecycle         *SubscriptionLifecycleService
+	invoice           *InvoiceService
 	xendit            *xendit.APIClient
 	xenditExt         *xenditext.Client
 }
@@ -43,6 +42,8 @@ func NewStudentSubscriptionService(
 	subscriptionPrice *repositories.SubscriptionPriceRepository,
 	payment *repositories.PaymentRepository,
 	notification *NotificationService,
+	lifecycle *SubscriptionLifecycleService,
+	invoice *InvoiceService,
 	xendit *xendit.APIClient,
 	xenditExt *xenditext.Client,
 ) *StudentSubscriptionService {
@@ -52,6 +53,8 @@ func NewStudentSubscriptionService(
 		subscription:      subscription,
 		subscriptionPrice: subscriptionPrice,
 		notification:      notification,
+		lifecycle:         lifecycle,
+		invoice:           invoice,
 		payment:           payment,
 		xendit:            xendit,
 		xenditExt:         xenditExt,
@@ -168,11 +171,14 @@ func (s *StudentSubscriptionService) RegularPayment(ctx context.Context, request
 
 	payment := &model.Payment{
 		ID:            uuid.New(),
-		StudentID:     student.ID,
+		StudentID:     uuid.NullUUID{UUID: student.ID, Valid: true},
+		Type:          model.PaymentTypePremium,
 		Interval:      interval,
 		IntervalCount: request.IntervalCount,
+		Seats:         1,
 		StartDate:     startDate,
 		EndDate:       endDate,
+		Currency:      model.CurrencyIDR,
 		Amount:        amount,
 		Status:        model.SubscriptionStatusPending,
 		URL:           "http://test.dev/subscription",
@@ -182,13 +188,19 @@ func (s *StudentSubscriptionService) RegularPayment(ctx context.Context, request
 		UpdatedBy:     student.UserID,
 	}
 
+	rate, err := s.invoice.GetTaxRate(ctx, payment.CreatedAt)
+	if err != nil {
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	payment.TaxAmount = decimal.NewNullDecimal(rate.Tax(amount))
 	payment.GenerateInvoiceNumber()
 
 	resp, err := s.xenditExt.CreatePaymentSession(ctx, xenditext.CreatePaymentSessionRequest{
 		ReferenceID:      payment.InvoiceNumber,
 		CustomerID:       student.CustomerID.String,
 		SessionType:      "PAY",
-		Currency:         xenditext.CurrencyIDR,
+		Currency:         payment.Currency,
 		Amount:           int(amount.Add(payment.VatAmount()).IntPart()),
 		Mode:             "PAYMENT_LINK",
 		Country:          "ID",
@@ -211,7 +223,7 @@ func (s *StudentSubscriptionService) RegularPayment(ctx context.Context, request
 	}
 
 	go func() {
-		err = s.notification.PaymentCreated(context.Background(), *student, *payment)
+		err = s.notification.PaymentCreated(context.Background(), student.User, *payment)
 		if err != nil {
 			logger.ErrorCtx(context.Background()).Err(err).Msg("[RegularPayment] Error sending payment created notification")
 		}
@@ -289,58 +301,111 @@ func (s *StudentSubscriptionService) Create(ctx context.Context, request dto.Cre
 		}
 	}
 
+	subscriptions, err := s.subscription.Get(ctx, model.SubscriptionFilter{StudentID: student.ID})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentSubscription] Error getting subscriptions")
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
 	var (
-		scheduleInterval string
-		endDate          time.Time
-		startDate        = time.Now()
-		interval         = price.Interval
-		amount           = decimal.NewFromInt(int64(request.IntervalCount)).Mul(price.Price)
+		startDate    = time.Now()
+		subscription = &model.Subscription{
+			ID:            uuid.New(),
+			StudentID:     student.ID,
+			Interval:      price.Interval,
+			IntervalCount: request.IntervalCount,
+			StartDate:     startDate,
+			Currency:      model.CurrencyIDR,
+			Amount:        decimal.NewFromInt(int64(request.IntervalCount)).Mul(price.Price),
+			Status:        model.SubscriptionStatusPending,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+			CreatedBy:     student.UserID,
+			UpdatedBy:     student.UserID,
+		}
 	)
 
-	switch request.SubscriptionID.String() {
-	case model.SubscriptionMonthlyID:
-		endDate = startDate.AddDate(0, request.IntervalCount, 0)
-		scheduleInterval = xenditext.ScheduleIntervalMonth
-	case model.SubscriptionYearlyID:
-		endDate = startDate.AddDate(request.IntervalCount, 0, 0)
+	subscription.EndDate = subscription.AddPeriod(startDate, 1)
+
+	// Only the first subscription of a student starts with a trial.
+	trial := s.config.Subscription.TrialDuration > 0 && len(subscriptions) == 0
+	if trial {
+		subscription.Status = model.SubscriptionStatusTrialing
+		subscription.EndDate = startDate.Add(s.config.Subscription.TrialDuration)
+		subscription.TrialEndsAt = null.TimeFrom(subscription.EndDate)
+	}
+
+	resp, err := s.createXenditSubscription(ctx, *student, subscription, request.SubscriptionID, subscription.EndDate, !trial)
+	if err != nil {
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	subscription.ReferenceID = resp.ID
+	err = s.subscription.Create(ctx, subscription)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentSubscription] Error creating subscription")
+		return dto.CreateStudentSubscriptionResponse{}, err
+	}
+
+	if trial {
+		student.PremiumUntil = null.TimeFrom(subscription.EndDate)
+		err = s.student.Update(ctx, student)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentSubscription] Error updating student")
+			return dto.CreateStudentSubscriptionResponse{}, err
+		}
+	}
+
+	if len(resp.Actions) == 0 {
+		logger.ErrorCtx(ctx).Msg("[CreateStudentSubscription] No action found in Xendit response")
+		return dto.CreateStudentSubscriptionResponse{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.CreateStudentSubscriptionResponse{
+		URL: resp.Actions[0].URL,
+	}, nil
+}
+
+// createXenditSubscription registers the recurring plan of the subscription
+// on Xendit. The first cycle is charged right away when chargeNow is set,
+// otherwise at anchorDate.
+func (s *StudentSubscriptionService) createXenditSubscription(
+	ctx context.Context,
+	student model.Student,
+	subscription *model.Subscription,
+	priceID uuid.UUID,
+	anchorDate time.Time,
+	chargeNow bool,
+) (xenditext.CreateSubscriptionResponse, error) {
+	scheduleInterval := xenditext.ScheduleIntervalMonth
+	if subscription.Interval == model.SubscriptionIntervalYearly {
 		scheduleInterval = xenditext.ScheduleIntervalYear
 	}
 
-	subscription := &model.Subscription{
-		ID:            uuid.New(),
-		StudentID:     student.ID,
-		Interval:      interval,
-		IntervalCount: request.IntervalCount,
-		StartDate:     startDate,
-		EndDate:       endDate,
-		Currency:      xenditext.CurrencyIDR,
-		Amount:        amount,
-		Status:        model.SubscriptionStatusPending,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
-		CreatedBy:     student.UserID,
-		UpdatedBy:     student.UserID,
+	immediateActionType := ""
+	if chargeNow {
+		immediateActionType = xenditext.ImmediateActionTypeFullAmount
 	}
 
 	resp, err := s.xenditExt.CreateSubscription(ctx, xenditext.CreateSubscriptionRequest{
 		ReferenceID:     subscription.ID.String(),
 		CustomerID:      student.CustomerID.String,
 		RecurringAction: xenditext.RecurringActionPayment,
-		Currency:        xenditext.CurrencyIDR,
+		Currency:        subscription.Currency,
 		Amount:          int(subscription.Amount.IntPart()),
 		Schedule: xenditext.SubscriptionSchedule{
-			ReferenceID:        request.SubscriptionID.String(),
+			ReferenceID:        priceID.String(),
 			Interval:           scheduleInterval,
 			IntervalCount:      subscription.IntervalCount,
-			AnchorDate:         startDate,
+			AnchorDate:         anchorDate,
 			RetryInterval:      xenditext.RetryIntervalDay,
-			RetryIntervalCount: 1,
-			TotalRetry:         1,
+			RetryIntervalCount: max(s.config.Subscription.RetryIntervalInDays, 1),
+			TotalRetry:         max(s.config.Subscription.TotalRetry, 1),
 			FailedAttemptNotifications: []int{
 				1,
 			},
 		},
-		ImmediateActionType:         xenditext.ImmediateActionTypeFullAmount,
+		ImmediateActionType:         immediateActionType,
 		PaymentLinkForFailedAttempt: true,
 		FailedCycleAction:           xenditext.FailedCycleActionResume,
 		Items: []xenditext.SubscriptionItem{
@@ -356,25 +421,11 @@ func (s *StudentSubscriptionService) Create(ctx context.Context, request dto.Cre
 		FailureReturnURL: s.config.Frontend.BaseURL + s.config.Frontend.SubscriptionFailure,
 	})
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentSubscription] Error when calling xenditExt.CreateSubscription")
-		return dto.CreateStudentSubscriptionResponse{}, shared.MakeError(ErrInternalServer)
+		logger.ErrorCtx(ctx).Err(err).Msg("[createXenditSubscription] Error when calling xenditExt.CreateSubscription")
+		return xenditext.CreateSubscriptionResponse{}, shared.MakeError(ErrInternalServer)
 	}
 
-	subscription.ReferenceID = resp.ID
-	err = s.subscription.Create(ctx, subscription)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateStudentSubscription] Error creating subscription")
-		return dto.CreateStudentSubscriptionResponse{}, err
-	}
-
-	if len(resp.Actions) == 0 {
-		logger.ErrorCtx(ctx).Msg("[CreateStudentSubscription] No action found in Xendit response")
-		return dto.CreateStudentSubscriptionResponse{}, shared.MakeError(ErrInternalServer)
-	}
-
-	return dto.CreateStudentSubscriptionResponse{
-		URL: resp.Actions[0].URL,
-	}, nil
+	return resp, nil
 }
 
 func (s *StudentSubscriptionService) Get(ctx context.Context, request dto.GetStudentSubscriptionCreateRequest) ([]model.Subscription, error) {
@@ -429,39 +480,163 @@ func (s *StudentSubscriptionService) GetPayment(ctx context.Context, request dto
 	return payments, nil
 }
 
-func (s *StudentSubscriptionService) Cancel(ctx context.Context, id uuid.UUID) error {
-	subscription, err := s.subscription.GetByID(ctx, id)
+// GetRecurring returns the recurring subscriptions of the student with their
+// lifecycle details.
+func (s *StudentSubscriptionService) GetRecurring(ctx context.Context) ([]model.Subscription, error) {
+	student, err := s.student.GetByUserID(ctx, middleware.GetUserID(ctx))
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CancelStudentSubscription] Error getting subscription")
-		return err
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetRecurring] Error getting student")
+		return nil, err
+	}
+
+	if student == nil {
+		logger.ErrorCtx(ctx).Msg("[GetRecurring] No student found")
+		return nil, shared.MakeError(ErrEntityNotFound, "student")
+	}
+
+	subscriptions, err := s.subscription.Get(ctx, model.SubscriptionFilter{StudentID: student.ID})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetRecurring] Error getting subscriptions")
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// ownSubscription returns the subscription when it belongs to the student
+// making the request.
+func (s *StudentSubscriptionService) ownSubscription(ctx context.Context, id uuid.UUID) (*model.Student, *model.Subscription, error) {
+	student, err := s.student.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ownSubscription] Error getting student")
+		return nil, nil, err
+	}
+
+	if student == nil {
+		logger.ErrorCtx(ctx).Msg("[ownSubscription] No student found")
+		return nil, nil, shared.MakeError(ErrEntityNotFound, "student")
 	}
 
-	if subscription == nil {
-		logger.ErrorCtx(ctx).Msg("[CancelStudentSubscription] No subscription found")
-		return shared.MakeError(ErrEntityNotFound, "subscription")
+	subscription, err := s.subscription.GetByID(ctx, id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ownSubscription] Error getting subscription")
+		return nil, nil, err
 	}
 
-	if subscription.Status != model.SubscriptionStatusActive {
-		logger.ErrorCtx(ctx).Msg("[CancelStudentSubscription] Subscription is not active")
-		return shared.MakeError(ErrEntityNotFound, "subscription")
+	if err != nil || subscription.StudentID != student.ID {
+		logger.ErrorCtx(ctx).Msg("[ownSubscription] No subscription found")
+		return nil, nil, shared.MakeError(ErrEntityNotFound, "subscription")
 	}
 
-	err = s.xenditExt.CancelSubscription(ctx, subscription.ReferenceID)
+	return student, subscription, nil
+}
+
+// Cancel stops the renewal of the subscription. The student keeps premium
+// until the end of the paid period.
+func (s *StudentSubscriptionService) Cancel(ctx context.Context, id uuid.UUID) error {
+	_, subscription, err := s.ownSubscription(ctx, id)
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CancelStudentSubscription] Error canceling subscription")
 		return err
 	}
 
-	subscription.Status = model.SubscriptionStatusCanceled
-	err = s.subscription.Update(ctx, subscription)
+	err = s.lifecycle.Cancel(ctx, subscription, middleware.GetUserID(ctx))
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CancelStudentSubscription] Error updating subscription")
+		logger.ErrorCtx(ctx).Err(err).Msg("[CancelStudentSubscription] Error canceling subscription")
 		return err
 	}
 
 	return nil
 }
 
+// ChangePlan moves an active subscription to another plan. The unused part of
+// the current period is credited to the new plan, which starts billing once
+// the credit is used up.
+func (s *StudentSubscriptionService) ChangePlan(ctx context.Context, request dto.ChangeStudentSubscriptionPlanRequest) (dto.ChangeStudentSubscriptionPlanResponse, error) {
+	student, current, err := s.ownSubscription(ctx, request.ID)
+	if err != nil {
+		return dto.ChangeStudentSubscriptionPlanResponse{}, err
+	}
+
+	if current.Status != model.SubscriptionStatusActive || current.CancelAtPeriodEnd {
+		logger.ErrorCtx(ctx).Msg("[ChangePlan] Subscription is not active")
+		return dto.ChangeStudentSubscriptionPlanResponse{}, shared.MakeError(ErrBadRequest, "only active subscriptions can change plan")
+	}
+
+	price, err := s.subscriptionPrice.GetByID(ctx, request.SubscriptionID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ChangePlan] Error getting subscription prices")
+		return dto.ChangeStudentSubscriptionPlanResponse{}, err
+	}
+
+	if price == nil {
+		logger.ErrorCtx(ctx).Msg("[ChangePlan] Subscription price not found")
+		return dto.ChangeStudentSubscriptionPlanResponse{}, shared.MakeError(ErrEntityNotFound, "subscription price")
+	}
+
+	if price.Interval == current.Interval && request.IntervalCount == current.IntervalCount {
+		return dto.ChangeStudentSubscriptionPlanResponse{}, shared.MakeError(ErrBadRequest, "subscription already on this plan")
+	}
+
+	now := time.Now()
+	next := &model.Subscription{
+		ID:                     uuid.New(),
+		StudentID:              student.ID,
+		Interval:               price.Interval,
+		IntervalCount:          request.IntervalCount,
+		StartDate:              now,
+		Currency:               model.CurrencyIDR,
+		Amount:                 decimal.NewFromInt(int64(request.IntervalCount)).Mul(price.Price),
+		Status:                 model.SubscriptionStatusActive,
+		PreviousSubscriptionID: uuid.NullUUID{UUID: current.ID, Valid: true},
+		ProratedCredit:         current.UnusedCredit(now),
+		CreatedAt:              now,
+		UpdatedAt:              now,
+		CreatedBy:              student.UserID,
+		UpdatedBy:              student.UserID,
+	}
+
+	next.EndDate = next.CreditedPeriodEnd(now)
+	chargeNow := !next.EndDate.After(now)
+	if chargeNow {
+		next.Status = model.SubscriptionStatusPending
+		next.EndDate = next.AddPeriod(now, 1)
+	}
+
+	resp, err := s.createXenditSubscription(ctx, *student, next, request.SubscriptionID, next.EndDate, chargeNow)
+	if err != nil {
+		return dto.ChangeStudentSubscriptionPlanResponse{}, err
+	}
+
+	next.ReferenceID = resp.ID
+	premiumUntil := next.EndDate
+	if chargeNow {
+		premiumUntil = now
+	}
+
+	err = s.lifecycle.Replace(ctx, current, next, premiumUntil, student.UserID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ChangePlan] Error replacing subscription")
+		if e := s.xenditExt.CancelSubscription(ctx, next.ReferenceID); e != nil {
+			logger.ErrorCtx(ctx).Err(e).Msg("[ChangePlan] Error rolling back xendit subscription")
+		}
+
+		return dto.ChangeStudentSubscriptionPlanResponse{}, err
+	}
+
+	result := dto.ChangeStudentSubscriptionPlanResponse{
+		SubscriptionID: next.ID,
+		Amount:         next.Amount,
+		ProratedCredit: next.ProratedCredit,
+		NextBillingAt:  next.EndDate,
+	}
+
+	if len(resp.Actions) > 0 {
+		result.URL = resp.Actions[0].URL
+	}
+
+	return result, nil
+}
+
 func (s *StudentSubscriptionService) CancelPayment(ctx context.Context, id uuid.UUID) error {
 	payment, err := s.payment.GetByID(ctx, id.String())
 	if err != nil {
@@ -503,15 +678,14 @@ func (s *StudentSubscriptionService) CancelPayment(ctx context.Context, id uuid.
 func (s *StudentSubscriptionService) CreateInvoice(ctx context.Context, id uuid.UUID) ([]byte, string, error) {
 	payment, err := s.payment.GetByID(ctx, id.String())
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", shared.MakeError(ErrEntityNotFound, "subscription")
+		}
+
 		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvoice] Error getting subscription")
 		return nil, "", err
 	}
 
-	if payment == nil {
-		logger.ErrorCtx(ctx).Msg("[CreateInvoice] No subscription found")
-		return nil, "", shared.MakeError(ErrEntityNotFound, "subscription")
-	}
-
 	student, err := s.student.GetByUserID(ctx, middleware.GetUserID(ctx))
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvoice] Error getting student")
@@ -523,75 +697,9 @@ func (s *StudentSubscriptionService) CreateInvoice(ctx context.Context, id uuid.
 		return nil, "", shared.MakeError(ErrEntityNotFound, "student")
 	}
 
-	ac := accounting.Accounting{
-		Symbol:    "Rp",
-		Precision: 2,
-		Thousand:  ".",
-		Decimal:   ",",
-	}
-	var period string
-	switch payment.Interval {
-	case model.SubscriptionIntervalYearly:
-		period = "Year"
-	case model.SubscriptionIntervalMonthly:
-		period = "Month"
-	}
-	subscriptionPeriod := fmt.Sprintf("%d %s", payment.IntervalCount, period)
-	invoice := dto.InvoiceData{
-		InvoiceNumber:      payment.InvoiceNumber,
-		InvoiceDate:        time.Now().Format("02/01/2006"),
-		CustomerEmail:      payment.Student.User.Email,
-		Status:             payment.Status.InvoiceLabel(),
-		SubscriptionType:   "Les Private Premium Subscription",
-		SubscriptionPeriod: subscriptionPeriod,
-		StartDate:          payment.StartDate.Format("02/01/2006"),
-		EndDate:            payment.EndDate.Format("02/01/2006"),
-		SubtotalPrice:      ac.FormatMoney(payment.Amount),
-		VATAmount:          ac.FormatMoney(payment.VatAmount()),
-		TotalPrice:         ac.FormatMoney(payment.Amount.Add(payment.VatAmount())),
-	}
-
-	// Parse the template file
-	tmpl, err := template.ParseFiles("./templates/pdf/invoice/index.html")
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvoice] failed to parse template")
-		return nil, "", err
-	}
-
-	// Execute template with data
-	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, invoice)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvoice] failed to execute template")
-		return nil, "", err
-	}
-
-	// Create new PDF generator
-	pdfg, err := wkhtmltopdf.NewPDFGenerator()
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvoice] failed to create pdf generator")
-		return nil, "", err
-	}
-
-	page := wkhtmltopdf.NewPageReader(bytes.NewReader(buf.Bytes()))
-	pdfg.AddPage(page)
-
-	// Set PDF options
-	pdfg.PageSize.Set(wkhtmltopdf.PageSizeA4)
-	pdfg.MarginTop.Set(10)
-	pdfg.MarginBottom.Set(10)
-	pdfg.MarginLeft.Set(10)
-	pdfg.MarginRight.Set(10)
-	pdfg.Dpi.Set(300)
-	pdfg.Orientation.Set(wkhtmltopdf.OrientationPortrait)
-	pdfg.Grayscale.Set(false)
-
-	// Create PDF
-	err = pdfg.Create()
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[CreateInvoice] failed to create pdf")
-		return nil, "", err
+	if payment.StudentID.UUID != student.ID {
+		return nil, "", shared.MakeError(ErrEntityNotFound, "subscription")
 	}
 
-	return pdfg.Bytes(), fmt.Sprintf("%s.pdf", payment.InvoiceNumber), nil
+	return s.invoice.PaymentInvoice(ctx, *payment)
 }
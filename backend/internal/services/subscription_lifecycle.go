@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/config"
+	xenditext "github.com/lesprivate/backend/external/xendit"
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+)
+
+const (
+	defaultSubscriptionReminderBeforeEnd = 72 * time.Hour
+	defaultSubscriptionGracePeriod       = 72 * time.Hour
+)
+
+// SubscriptionLifecycleService moves recurring subscriptions through their
+// statuses and keeps the premium period of the student in sync.
+type SubscriptionLifecycleService struct {
+	config       *config.Config
+	subscription *repositories.SubscriptionRepository
+	student      *repositories.StudentRepository
+	notification *NotificationService
+	xenditExt    *xenditext.Client
+}
+
+func NewSubscriptionLifecycleService(
+	config *config.Config,
+	subscription *repositories.SubscriptionRepository,
+	student *repositories.StudentRepository,
+	notification *NotificationService,
+	xenditExt *xenditext.Client,
+) *SubscriptionLifecycleService {
+	return &SubscriptionLifecycleService{
+		config:       config,
+		subscription: subscription,
+		student:      student,
+		notification: notification,
+		xenditExt:    xenditExt,
+	}
+}
+
+func (s *SubscriptionLifecycleService) gracePeriod() time.Duration {
+	if s.config.Subscription.GracePeriod <= 0 {
+		return defaultSubscriptionGracePeriod
+	}
+
+	return s.config.Subscription.GracePeriod
+}
+
+func (s *SubscriptionLifecycleService) reminderBeforeEnd() time.Duration {
+	if s.config.Subscription.ReminderBeforeEnd <= 0 {
+		return defaultSubscriptionReminderBeforeEnd
+	}
+
+	return s.config.Subscription.ReminderBeforeEnd
+}
+
+// transition validates the move against the state machine and stamps the
+// subscription. It does not persist it.
+func (s *SubscriptionLifecycleService) transition(subscription *model.Subscription, to model.SubscriptionStatus, updatedBy uuid.UUID) error {
+	if !subscription.Status.CanTransitionTo(to) {
+		return shared.MakeError(ErrInvalidSubscriptionStatus, string(subscription.Status), string(to))
+	}
+
+	subscription.Status = to
+	subscription.UpdatedAt = time.Now()
+	subscription.UpdatedBy = updatedBy
+
+	return nil
+}
+
+// save persists the subscription and sets the premium period of the student
+// to premiumUntil.
+func (s *SubscriptionLifecycleService) save(ctx context.Context, subscription *model.Subscription, premiumUntil time.Time) error {
+	err := s.subscription.Update(ctx, subscription)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[save] Error updating subscription")
+		return err
+	}
+
+	student := subscription.Student
+	if student.ID == uuid.Nil {
+		return nil
+	}
+
+	student.PremiumUntil = null.TimeFrom(premiumUntil)
+	err = s.student.Update(ctx, &student)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[save] Error updating student")
+		return err
+	}
+
+	return nil
+}
+
+// CycleSucceeded activates the subscription after the cycle was paid and
+// records its payment with it, taxed at rate. Every cycle after the first one
+// extends the period. It returns no payment when the cycle is ignored or was
+// saved before.
+func (s *SubscriptionLifecycleService) CycleSucceeded(ctx context.Context, subscription *model.Subscription, cycleID string, rate model.TaxRate) (*model.Payment, error) {
+	renewal := subscription.Status != model.SubscriptionStatusPending
+
+	err := s.transition(subscription, model.SubscriptionStatusActive, uuid.MustParse(model.SystemID))
+	if err != nil {
+		logger.WarnCtx(ctx).Err(err).Msgf("[CycleSucceeded] Ignoring cycle of subscription %s", subscription.ID)
+		return nil, nil
+	}
+
+	if renewal {
+		subscription.EndDate = subscription.AddPeriod(subscription.EndDate, 1)
+	}
+
+	subscription.TrialEndsAt = null.Time{}
+	subscription.RetryCount = 0
+	subscription.GraceUntil = null.Time{}
+
+	payment := subscription.CyclePayment(cycleID, time.Now(), rate)
+	renewed, err := s.subscription.Renew(ctx, subscription, &payment, subscription.EndDate)
+	if err != nil || !renewed {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// CycleRetrying marks the subscription past due while the payment is retried.
+// Premium stays on until the grace period counted from the first failure.
+func (s *SubscriptionLifecycleService) CycleRetrying(ctx context.Context, subscription *model.Subscription, attempt int) error {
+	now := time.Now()
+	if subscription.Status != model.SubscriptionStatusPastDue {
+		err := s.transition(subscription, model.SubscriptionStatusPastDue, uuid.MustParse(model.SystemID))
+		if err != nil {
+			logger.WarnCtx(ctx).Err(err).Msgf("[CycleRetrying] Ignoring retry of subscription %s", subscription.ID)
+			return nil
+		}
+	}
+
+	if !subscription.GraceUntil.Valid {
+		subscription.GraceUntil = null.TimeFrom(now.Add(s.gracePeriod()))
+	}
+
+	subscription.RetryCount = max(subscription.RetryCount+1, attempt)
+	subscription.LastFailedAt = null.TimeFrom(now)
+	subscription.UpdatedAt = now
+
+	return s.save(ctx, subscription, subscription.GraceUntil.Time)
+}
+
+// CycleFailed moves the subscription into its grace period once every retry
+// failed. The student keeps premium until GraceUntil.
+func (s *SubscriptionLifecycleService) CycleFailed(ctx context.Context, subscription *model.Subscription, attempt int) error {
+	now := time.Now()
+	if subscription.Status != model.SubscriptionStatusPastDue {
+		if err := s.transition(subscription, model.SubscriptionStatusPastDue, uuid.MustParse(model.SystemID)); err != nil {
+			logger.WarnCtx(ctx).Err(err).Msgf("[CycleFailed] Ignoring failed cycle of subscription %s", subscription.ID)
+			return nil
+		}
+	}
+
+	err := s.transition(subscription, model.SubscriptionStatusGrace, uuid.MustParse(model.SystemID))
+	if err != nil {
+		logger.WarnCtx(ctx).Err(err).Msgf("[CycleFailed] Ignoring failed cycle of subscription %s", subscription.ID)
+		return nil
+	}
+
+	if !subscription.GraceUntil.Valid {
+		subscription.GraceUntil = null.TimeFrom(now.Add(s.gracePeriod()))
+	}
+
+	subscription.RetryCount = max(subscription.RetryCount, attempt)
+	subscription.LastFailedAt = null.TimeFrom(now)
+
+	err = s.save(ctx, subscription, subscription.GraceUntil.Time)
+	if err != nil {
+		return err
+	}
+
+	go func(subscription model.Subscription) {
+		if err := s.notification.SubscriptionPaymentFailed(context.Background(), subscription); err != nil {
+			logger.ErrorCtx(context.Background()).Err(err).Msg("[CycleFailed] Error sending payment failed notification")
+		}
+	}(*subscription)
+
+	return nil
+}
+
+// Cancel stops the renewal of the subscription. Active and trialing
+// subscriptions keep premium until the end of the period, the others end now.
+func (s *SubscriptionLifecycleService) Cancel(ctx context.Context, subscription *model.Subscription, canceledBy uuid.UUID) error {
+	if subscription.CancelAtPeriodEnd {
+		return shared.MakeError(ErrBadRequest, "subscription already canceled")
+	}
+
+	now := time.Now()
+	atPeriodEnd := subscription.Status == model.SubscriptionStatusActive || subscription.Status == model.SubscriptionStatusTrialing
+	if !atPeriodEnd {
+		if err := s.transition(subscription, model.SubscriptionStatusCanceled, canceledBy); err != nil {
+			return err
+		}
+	}
+
+	err := s.xenditExt.CancelSubscription(ctx, subscription.ReferenceID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Cancel] Error canceling xendit subscription")
+		return err
+	}
+
+	subscription.CancelAtPeriodEnd = atPeriodEnd
+	subscription.CanceledAt = null.TimeFrom(now)
+	subscription.UpdatedAt = now
+	subscription.UpdatedBy = canceledBy
+
+	premiumUntil := subscription.EndDate
+	if !atPeriodEnd {
+		premiumUntil = now
+	}
+
+	return s.save(ctx, subscription, premiumUntil)
+}
+
+// Replace ends the subscription now because the student moved to the next
+// plan, which is stored with it in one transaction. Its unused period was
+// credited to the next plan.
+func (s *SubscriptionLifecycleService) Replace(ctx context.Context, subscription, next *model.Subscription, premiumUntil time.Time, replacedBy uuid.UUID) error {
+	err := s.transition(subscription, model.SubscriptionStatusCanceled, replacedBy)
+	if err != nil {
+		return err
+	}
+
+	err = s.xenditExt.CancelSubscription(ctx, subscription.ReferenceID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Replace] Error canceling xendit subscription")
+		return err
+	}
+
+	now := time.Now()
+	subscription.CanceledAt = null.TimeFrom(now)
+	subscription.EndDate = now
+
+	err = s.subscription.Replace(ctx, subscription, next, premiumUntil)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[Replace] Error replacing subscription")
+		return err
+	}
+
+	return nil
+}
+
+// RemindRenewal notifies students whose subscription period ends within the
+// reminder window. Each period is reminded once.
+func (s *SubscriptionLifecycleService) RemindRenewal(ctx context.Context) error {
+	subscriptions, err := s.subscription.Get(ctx, model.SubscriptionFilter{
+		StatusIn:        []model.SubscriptionStatus{model.SubscriptionStatusActive, model.SubscriptionStatusTrialing},
+		EndDateBefore:   time.Now().Add(s.reminderBeforeEnd()),
+		NotRemindedOnly: true,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RemindRenewal] Error getting subscriptions")
+		return err
+	}
+
+	reminded := []model.Subscription{}
+	for i := range subscriptions {
+		if !subscriptions[i].EndDate.After(time.Now()) {
+			continue
+		}
+
+		subscriptions[i].RemindedEndDate = null.TimeFrom(subscriptions[i].EndDate)
+		err = s.subscription.Update(ctx, &subscriptions[i])
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msgf("[RemindRenewal] Error updating subscription %s", subscriptions[i].ID)
+			continue
+		}
+
+		reminded = append(reminded, subscriptions[i])
+	}
+
+	if len(reminded) == 0 {
+		return nil
+	}
+
+	return s.notification.SubscriptionRenewalReminder(ctx, reminded)
+}
+
+// ProcessPeriodEnd cancels subscriptions whose cancellation takes effect at
+// the end of the period and expires the ones whose grace period ran out.
+func (s *SubscriptionLifecycleService) ProcessPeriodEnd(ctx context.Context) error {
+	now := time.Now()
+
+	canceled, err := s.subscription.Get(ctx, model.SubscriptionFilter{
+		StatusIn:          []model.SubscriptionStatus{model.SubscriptionStatusActive, model.SubscriptionStatusTrialing},
+		CancelAtPeriodEnd: null.BoolFrom(true),
+		EndDateBefore:     now,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ProcessPeriodEnd] Error getting canceled subscriptions")
+		return err
+	}
+
+	expired, err := s.subscription.Get(ctx, model.SubscriptionFilter{
+		StatusIn:         []model.SubscriptionStatus{model.SubscriptionStatusPastDue, model.SubscriptionStatusGrace},
+		GraceUntilBefore: now,
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ProcessPeriodEnd] Error getting subscriptions in grace period")
+		return err
+	}
+
+	ended := []model.Subscription{}
+	for i := range canceled {
+		if err = s.transition(&canceled[i], model.SubscriptionStatusCanceled, uuid.MustParse(model.SystemID)); err != nil {
+			logger.WarnCtx(ctx).Err(err).Msgf("[ProcessPeriodEnd] Skipping subscription %s", canceled[i].ID)
+			continue
+		}
+
+		if err = s.save(ctx, &canceled[i], canceled[i].EndDate); err != nil {
+			continue
+		}
+
+		ended = append(ended, canceled[i])
+	}
+
+	for i := range expired {
+		if err = s.transition(&expired[i], model.SubscriptionStatusExpired, uuid.MustParse(model.SystemID)); err != nil {
+			logger.WarnCtx(ctx).Err(err).Msgf("[ProcessPeriodEnd] Skipping subscription %s", expired[i].ID)
+			continue
+		}
+
+		// Xendit keeps billing failed plans, stop it before dropping premium.
+		if err = s.xenditExt.CancelSubscription(ctx, expired[i].ReferenceID); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msgf("[ProcessPeriodEnd] Error canceling xendit subscription %s", expired[i].ID)
+			continue
+		}
+
+		if err = s.save(ctx, &expired[i], expired[i].GraceUntil.Time); err != nil {
+			continue
+		}
+
+		ended = append(ended, expired[i])
+	}
+
+	if len(ended) == 0 {
+		return nil
+	}
+
+	return s.notification.SubscriptionEnded(ctx, ended)
+}
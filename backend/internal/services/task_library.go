@@ -0,0 +1,517 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+// TaskLibraryService manages the task templates and grading rubrics of
+// tutors and assigns templates to their sessions.
+type TaskLibraryService struct {
+	library           *repositories.TaskLibraryRepository
+	sessionTask       *repositories.SessionTaskRepository
+	booking           *repositories.BookingRepository
+	tutor             *repositories.TutorRepository
+	course            *repositories.CourseRepository
+	subCourseCategory *repositories.SubCourseCategoryRepository
+	mentorStudent     *repositories.MentorStudentRepository
+	mentorGroup       *repositories.MentorGroupRepository
+	notification      *NotificationService
+}
+
+func NewTaskLibraryService(
+	library *repositories.TaskLibraryRepository,
+	sessionTask *repositories.SessionTaskRepository,
+	booking *repositories.BookingRepository,
+	tutor *repositories.TutorRepository,
+	course *repositories.CourseRepository,
+	subCourseCategory *repositories.SubCourseCategoryRepository,
+	mentorStudent *repositories.MentorStudentRepository,
+	mentorGroup *repositories.MentorGroupRepository,
+	notification *NotificationService,
+) *TaskLibraryService {
+	return &TaskLibraryService{
+		library:           library,
+		sessionTask:       sessionTask,
+		booking:           booking,
+		tutor:             tutor,
+		course:            course,
+		subCourseCategory: subCourseCategory,
+		mentorStudent:     mentorStudent,
+		mentorGroup:       mentorGroup,
+		notification:      notification,
+	}
+}
+
+func (s *TaskLibraryService) currentTutor(ctx context.Context) (*model.Tutor, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[TaskLibraryService] Error getting tutor")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if tutor == nil {
+		return nil, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	return tutor, nil
+}
+
+// organise looks up the course of the tutor and the sub course category an
+// item of the library is organised by
+func (s *TaskLibraryService) organise(ctx context.Context, tutor *model.Tutor, courseID, subCourseCategoryID *uuid.UUID) (*model.Course, *model.SubCourseCategory, error) {
+	var (
+		course            *model.Course
+		subCourseCategory *model.SubCourseCategory
+		err               error
+	)
+
+	if courseID != nil {
+		course, err = s.course.GetByID(ctx, *courseID)
+		if err != nil {
+			return nil, nil, shared.MakeError(ErrInternalServer)
+		}
+		if course == nil || course.TutorID != tutor.ID {
+			return nil, nil, shared.MakeError(ErrEntityNotFound, "course")
+		}
+	}
+
+	if subCourseCategoryID != nil {
+		subCourseCategory, err = s.subCourseCategory.GetByID(ctx, *subCourseCategoryID)
+		if err != nil {
+			return nil, nil, shared.MakeError(ErrInternalServer)
+		}
+		if subCourseCategory == nil {
+			return nil, nil, shared.MakeError(ErrEntityNotFound, "sub course category")
+		}
+	}
+
+	return course, subCourseCategory, nil
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func (s *TaskLibraryService) GetRubrics(ctx context.Context, request dto.GetTaskLibraryRequest) ([]dto.TaskRubricResponse, model.Metadata, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	rubrics, metadata, err := s.library.GetRubrics(ctx, model.TaskLibraryFilter{
+		TutorID:             tutor.ID,
+		CourseID:            request.CourseID,
+		SubCourseCategoryID: request.SubCourseCategoryID,
+		Query:               request.Query,
+		Pagination:          request.Pagination,
+	})
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	results := make([]dto.TaskRubricResponse, 0, len(rubrics))
+	for _, rubric := range rubrics {
+		results = append(results, dto.NewTaskRubricResponse(rubric))
+	}
+
+	return results, metadata, nil
+}
+
+func (s *TaskLibraryService) CreateRubric(ctx context.Context, request dto.TaskRubricRequest) (*dto.TaskRubricResponse, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rubric := &model.TaskRubric{TutorID: tutor.ID}
+	err = s.applyRubric(ctx, tutor, rubric, request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.library.CreateRubric(ctx, rubric)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateRubric] Error creating rubric")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewTaskRubricResponse(*rubric)
+	return &res, nil
+}
+
+// UpdateRubric changes a rubric of the library. Tasks already assigned keep
+// the criteria they were given with.
+func (s *TaskLibraryService) UpdateRubric(ctx context.Context, id uuid.UUID, request dto.TaskRubricRequest) (*dto.TaskRubricResponse, error) {
+	tutor, rubric, err := s.ownRubric(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.applyRubric(ctx, tutor, rubric, request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.library.UpdateRubric(ctx, rubric)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateRubric] Error updating rubric")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewTaskRubricResponse(*rubric)
+	return &res, nil
+}
+
+func (s *TaskLibraryService) DeleteRubric(ctx context.Context, id uuid.UUID) error {
+	_, _, err := s.ownRubric(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.library.DeleteRubric(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteRubric] Error deleting rubric")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *TaskLibraryService) ownRubric(ctx context.Context, id uuid.UUID) (*model.Tutor, *model.TaskRubric, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rubric, err := s.library.GetRubricByID(ctx, id)
+	if err != nil {
+		return nil, nil, shared.MakeError(ErrInternalServer)
+	}
+	if rubric == nil || rubric.TutorID != tutor.ID {
+		return nil, nil, shared.MakeError(ErrEntityNotFound, "rubric")
+	}
+
+	return tutor, rubric, nil
+}
+
+func (s *TaskLibraryService) applyRubric(ctx context.Context, tutor *model.Tutor, rubric *model.TaskRubric, request dto.TaskRubricRequest) error {
+	course, subCourseCategory, err := s.organise(ctx, tutor, request.CourseID, request.SubCourseCategoryID)
+	if err != nil {
+		return err
+	}
+
+	criteria, err := json.Marshal(request.Criteria)
+	if err != nil {
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	rubric.Name = request.Name
+	rubric.Description = null.StringFromPtr(request.Description)
+	rubric.CourseID = nullUUID(request.CourseID)
+	rubric.SubCourseCategoryID = nullUUID(request.SubCourseCategoryID)
+	rubric.Criteria = criteria
+	rubric.Course = course
+	rubric.SubCourseCategory = subCourseCategory
+	return nil
+}
+
+func (s *TaskLibraryService) GetTemplates(ctx context.Context, request dto.GetTaskLibraryRequest) ([]dto.TaskTemplateResponse, model.Metadata, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, model.Metadata{}, err
+	}
+
+	templates, metadata, err := s.library.GetTemplates(ctx, model.TaskLibraryFilter{
+		TutorID:             tutor.ID,
+		CourseID:            request.CourseID,
+		SubCourseCategoryID: request.SubCourseCategoryID,
+		Query:               request.Query,
+		Pagination:          request.Pagination,
+	})
+	if err != nil {
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	results := make([]dto.TaskTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		results = append(results, dto.NewTaskTemplateResponse(template))
+	}
+
+	return results, metadata, nil
+}
+
+func (s *TaskLibraryService) CreateTemplate(ctx context.Context, request dto.TaskTemplateRequest) (*dto.TaskTemplateResponse, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &model.TaskTemplate{TutorID: tutor.ID}
+	err = s.applyTemplate(ctx, tutor, template, request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.library.CreateTemplate(ctx, template)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[CreateTemplate] Error creating template")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewTaskTemplateResponse(*template)
+	return &res, nil
+}
+
+// UpdateTemplate changes a template of the library. Tasks already assigned
+// from it are left as they are.
+func (s *TaskLibraryService) UpdateTemplate(ctx context.Context, id uuid.UUID, request dto.TaskTemplateRequest) (*dto.TaskTemplateResponse, error) {
+	tutor, template, err := s.ownTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.applyTemplate(ctx, tutor, template, request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.library.UpdateTemplate(ctx, template)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateTemplate] Error updating template")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	res := dto.NewTaskTemplateResponse(*template)
+	return &res, nil
+}
+
+func (s *TaskLibraryService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	_, _, err := s.ownTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.library.DeleteTemplate(ctx, id)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteTemplate] Error deleting template")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *TaskLibraryService) ownTemplate(ctx context.Context, id uuid.UUID) (*model.Tutor, *model.TaskTemplate, error) {
+	tutor, err := s.currentTutor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template, err := s.library.GetTemplateByID(ctx, id)
+	if err != nil {
+		return nil, nil, shared.MakeError(ErrInternalServer)
+	}
+	if template == nil || template.TutorID != tutor.ID {
+		return nil, nil, shared.MakeError(ErrEntityNotFound, "task template")
+	}
+
+	return tutor, template, nil
+}
+
+func (s *TaskLibraryService) applyTemplate(ctx context.Context, tutor *model.Tutor, template *model.TaskTemplate, request dto.TaskTemplateRequest) error {
+	course, subCourseCategory, err := s.organise(ctx, tutor, request.CourseID, request.SubCourseCategoryID)
+	if err != nil {
+		return err
+	}
+
+	var rubric *model.TaskRubric
+	if request.TaskRubricID != nil {
+		rubric, err = s.library.GetRubricByID(ctx, *request.TaskRubricID)
+		if err != nil {
+			return shared.MakeError(ErrInternalServer)
+		}
+		if rubric == nil || rubric.TutorID != tutor.ID {
+			return shared.MakeError(ErrEntityNotFound, "rubric")
+		}
+	}
+
+	template.Title = request.Title
+	template.Description = null.StringFromPtr(request.Description)
+	template.AttachmentURL = null.StringFromPtr(request.AttachmentURL)
+	template.CourseID = nullUUID(request.CourseID)
+	template.SubCourseCategoryID = nullUUID(request.SubCourseCategoryID)
+	template.TaskRubricID = nullUUID(request.TaskRubricID)
+	template.DueDays = null.Int{}
+	if request.DueDays != nil {
+		template.DueDays = null.IntFrom(int64(*request.DueDays))
+	}
+	template.TaskRubric = rubric
+	template.Course = course
+	template.SubCourseCategory = subCourseCategory
+	return nil
+}
+
+// AssignTemplate copies a template into a task of one booking, or of every
+// upcoming session the tutor has with their mentored students or the
+// members of one of their groups. Templates of
+// a course only go to the sessions of that course when bulk assigned.
+// Sessions which already got a task from the template are skipped.
+func (s *TaskLibraryService) AssignTemplate(ctx context.Context, id uuid.UUID, request dto.AssignTaskTemplateRequest) (*dto.AssignTaskTemplateResponse, error) {
+	tutor, template, err := s.ownTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var bookings []model.Booking
+	if request.BookingID != nil {
+		booking, err := s.booking.GetByID(ctx, *request.BookingID)
+		if err != nil || booking == nil {
+			return nil, shared.MakeError(ErrEntityNotFound, "booking")
+		}
+		if booking.TutorID != tutor.ID {
+			return nil, shared.MakeError(ErrForbidden, "booking ownership mismatch")
+		}
+		bookings = append(bookings, *booking)
+	} else if request.GroupID != nil {
+		bookings, err = s.groupSessions(ctx, tutor, template, *request.GroupID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		bookings, err = s.upcomingSessions(ctx, tutor, template, request.StudentIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := &dto.AssignTaskTemplateResponse{Assigned: []model.SessionTask{}}
+	if len(bookings) == 0 {
+		return res, nil
+	}
+
+	bookingIDs := make([]uuid.UUID, 0, len(bookings))
+	for _, booking := range bookings {
+		bookingIDs = append(bookingIDs, booking.ID)
+	}
+
+	assignedIDs, err := s.sessionTask.GetAssignedBookingIDs(ctx, template.ID, bookingIDs)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AssignTemplate] Error getting assigned bookings")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	assigned := make(map[uuid.UUID]bool, len(assignedIDs))
+	for _, bookingID := range assignedIDs {
+		assigned[bookingID] = true
+	}
+
+	var targets []model.Booking
+	for _, booking := range bookings {
+		if assigned[booking.ID] {
+			res.Skipped++
+			continue
+		}
+
+		targets = append(targets, booking)
+		res.Assigned = append(res.Assigned, template.Task(booking))
+	}
+
+	if len(res.Assigned) == 0 {
+		return res, nil
+	}
+
+	err = s.sessionTask.CreateBatch(ctx, res.Assigned)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AssignTemplate] Error creating tasks")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	for i, booking := range targets {
+		_ = s.notification.TaskAssigned(ctx, booking, res.Assigned[i])
+	}
+
+	return res, nil
+}
+
+// groupSessions returns the upcoming sessions of the members of the tutor's
+// group
+func (s *TaskLibraryService) groupSessions(ctx context.Context, tutor *model.Tutor, template *model.TaskTemplate, groupID uuid.UUID) ([]model.Booking, error) {
+	group, err := s.mentorGroup.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+	if group == nil || group.TutorID != tutor.ID {
+		return nil, shared.MakeError(ErrEntityNotFound, "group")
+	}
+
+	studentIDs, err := s.mentorGroup.GetMemberStudentIDs(ctx, group.ID)
+	if err != nil {
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if len(studentIDs) == 0 {
+		return nil, nil
+	}
+
+	return s.upcomingSessions(ctx, tutor, template, studentIDs)
+}
+
+// upcomingSessions returns the pending and accepted sessions from today on
+// the tutor has with their active mentored students, only the given ones
+// when studentIDs is set
+func (s *TaskLibraryService) upcomingSessions(ctx context.Context, tutor *model.Tutor, template *model.TaskTemplate, studentIDs []uuid.UUID) ([]model.Booking, error) {
+	mentored, err := s.mentorStudent.GetActiveStudentIDs(ctx, tutor.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AssignTemplate] Error getting mentored students")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	if len(studentIDs) > 0 {
+		active := make(map[uuid.UUID]bool, len(mentored))
+		for _, studentID := range mentored {
+			active[studentID] = true
+		}
+
+		for _, studentID := range studentIDs {
+			if !active[studentID] {
+				return nil, shared.MakeError(ErrBadRequest, "student "+studentID.String()+" is not mentored by the tutor")
+			}
+		}
+		mentored = studentIDs
+	}
+
+	if len(mentored) == 0 {
+		return nil, nil
+	}
+
+	filter := model.BookingFilter{
+		TutorID:         tutor.ID,
+		StudentIDs:      mentored,
+		StatusIn:        []model.BookingStatus{model.BookingStatusPending, model.BookingStatusAccepted},
+		BookingDateFrom: time.Now(),
+	}
+	if template.CourseID.Valid {
+		filter.CourseID = template.CourseID.UUID
+	}
+
+	bookings, _, err := s.booking.Get(ctx, filter)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[AssignTemplate] Error getting upcoming sessions")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return bookings, nil
+}
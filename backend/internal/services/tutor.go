@@ -139,12 +139,6 @@ func (s *TutorService) GetAdminDetailTutor(ctx context.Context, id uuid.UUID) (d
 		return dto.AdminDetailTutor{}, err
 	}
 
-	ratingTutor, err := s.review.GetTotalRatingTutor(ctx, tutor.ID)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[GetAdminDetailTutor] Failed to get tutor rating tutor")
-		return dto.AdminDetailTutor{}, err
-	}
-
 	studentReviews, _, err := s.review.GetStudentReviews(ctx, model.ReviewFilter{
 		TutorID:         tutor.ID,
 		DeletedAtIsNull: null.BoolFrom(true),
@@ -172,9 +166,8 @@ func (s *TutorService) GetAdminDetailTutor(ctx context.Context, id uuid.UUID) (d
 		Latitude:             tutor.Latitude,
 		Longitude:            tutor.Longitude,
 		PhotoProfile:         tutor.PhotoProfile,
-		Rating:               ratingTutor,
-		LevelPoint:           tutor.LevelPoint,
-		Level:                tutor.LevelByPoint(),
+		Rating:               tutor.Rating.InexactFloat64(),
+		Level:                tutor.LevelName(),
 		Status:               tutor.StatusLabel(),
 		StudentToTutorReview: make([]dto.AdminReview, len(tutorReviews)),
 		TutorToStudentReview: make([]dto.AdminReview, len(studentReviews)),
@@ -367,7 +360,6 @@ func (s *TutorService) UpdateAdminTutor(ctx context.Context, req dto.UpdateAdmin
 	tutor.SocialMediaLink = socialMediaLinks
 	tutor.Latitude = req.Latitude
 	tutor.Longitude = req.Longitude
-	tutor.LevelPoint = req.LevelPoint
 	tutor.UpdatedAt = time.Now()
 	tutor.UpdatedBy = uuid.NullUUID{
 		UUID:  middleware.GetUserID(ctx),
@@ -23,6 +23,8 @@ type TutorBookingService struct {
 	student       *repositories.StudentRepository
 	notification  *NotificationService
 	courseService *CourseService
+	bookingEvent  *BookingEventService
+	meeting       *MeetingService
 	config        *config.Config
 }
 
@@ -33,6 +35,8 @@ func NewTutorBookingService(
 	tutor *repositories.TutorRepository,
 	notification *NotificationService,
 	courseService *CourseService,
+	bookingEvent *BookingEventService,
+	meeting *MeetingService,
 	config *config.Config,
 ) *TutorBookingService {
 	return &TutorBookingService{
@@ -43,6 +47,8 @@ func NewTutorBookingService(
 		config:        config,
 		notification:  notification,
 		courseService: courseService,
+		bookingEvent:  bookingEvent,
+		meeting:       meeting,
 	}
 }
 
@@ -164,6 +170,10 @@ func (s *TutorBookingService) ApproveBooking(ctx context.Context, request dto.Ap
 		return shared.MakeError(ErrEntityNotFound, "booking")
 	}
 
+	if booking.GroupSessionID.Valid {
+		return shared.MakeError(ErrBadRequest, "seats of a group session are confirmed with the session")
+	}
+
 	if booking.Status != model.BookingStatusPending {
 		logger.ErrorCtx(ctx).Err(err).Msg("[ApproveBooking] Booking not found")
 		return shared.MakeError(ErrBadRequest, "booking status is not pending")
@@ -180,6 +190,10 @@ func (s *TutorBookingService) ApproveBooking(ctx context.Context, request dto.Ap
 		return shared.MakeError(ErrInternalServer)
 	}
 
+	s.bookingEvent.RecordStatus(ctx, *booking, middleware.GetUserID(ctx))
+
+	_ = s.meeting.Provision(ctx, booking)
+
 	go func() {
 		_ = s.sendEmailWhenUpdatStatusBooking(context.Background(), *booking)
 	}()
@@ -216,6 +230,10 @@ func (s *TutorBookingService) DeclineBooking(ctx context.Context, request dto.De
 		return shared.MakeError(ErrEntityNotFound, "booking")
 	}
 
+	if booking.GroupSessionID.Valid {
+		return shared.MakeError(ErrBadRequest, "seats of a group session are confirmed with the session")
+	}
+
 	if booking.Status != model.BookingStatusPending {
 		logger.ErrorCtx(ctx).Err(err).Msg("[DeclineBooking] Booking not found")
 		return shared.MakeError(ErrBadRequest, "booking status is not pending")
@@ -232,6 +250,8 @@ func (s *TutorBookingService) DeclineBooking(ctx context.Context, request dto.De
 		return shared.MakeError(ErrInternalServer)
 	}
 
+	s.bookingEvent.RecordStatus(ctx, *booking, middleware.GetUserID(ctx))
+
 	go func() {
 		_ = s.sendEmailWhenUpdatStatusBooking(context.Background(), *booking)
 	}()
@@ -335,6 +355,11 @@ func (s *TutorBookingService) Create(ctx context.Context, request dto.CreateTuto
 		return model.Booking{}, shared.MakeError(ErrInternalServer)
 	}
 
+	s.bookingEvent.RecordCreated(ctx, booking, middleware.GetUserID(ctx))
+
+	booking.Course = *course
+	_ = s.meeting.Provision(ctx, &booking)
+
 	// Send notification?
 	go func() {
 		_ = s.sendEmailWhenUpdatStatusBooking(context.Background(), booking)
@@ -363,19 +388,17 @@ func (s *TutorBookingService) GetStats(ctx context.Context, userID uuid.UUID) (*
 	acceptedCount, _ := s.booking.Count(ctx, model.BookingFilter{TutorID: tutor.ID, Status: model.BookingStatusAccepted})
 	declinedCount, _ := s.booking.Count(ctx, model.BookingFilter{TutorID: tutor.ID, Status: model.BookingStatusDeclined})
 	expiredCount, _ := s.booking.Count(ctx, model.BookingFilter{TutorID: tutor.ID, Status: model.BookingStatusExpired})
-
-	// Completed sessions (Accepted + passed time)
-	// For now let's just use Accepted as proxy or filtered counts.
-	// But let's check repo capabilities.
+	completedCount, _ := s.booking.Count(ctx, model.BookingFilter{TutorID: tutor.ID, Status: model.BookingStatusCompleted})
 
 	total, _ := s.booking.Count(ctx, filter)
 
 	return &dto.TutorBookingStats{
-		Pending:   int(pendingCount),
-		Accepted:  int(acceptedCount),
-		Rejected:  int(declinedCount + expiredCount),
-		Completed: 0, // Need logic for completed
-		Total:     int(total),
+		Pending:      int(pendingCount),
+		Accepted:     int(acceptedCount),
+		Rejected:     int(declinedCount + expiredCount),
+		Completed:    int(completedCount),
+		Total:        int(total),
+		ResponseTime: tutor.ResponseTime,
 	}, nil
 }
 
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/lesprivate/backend/internal/model"
+	"github.com/lesprivate/backend/internal/model/dto"
+	"github.com/lesprivate/backend/internal/repositories"
+	"github.com/lesprivate/backend/shared"
+	"github.com/lesprivate/backend/shared/logger"
+	"github.com/lesprivate/backend/transport/http/middleware"
+)
+
+const tutorLevelHistoryLimit = 10
+
+// TutorLevelService places tutors on the level whose admin-configured
+// criteria they meet and keeps a history of every level change.
+type TutorLevelService struct {
+	tutor               *repositories.TutorRepository
+	tutorLevel          *repositories.TutorLevelRepository
+	notificationService *NotificationService
+}
+
+func NewTutorLevelService(
+	tutor *repositories.TutorRepository,
+	tutorLevel *repositories.TutorLevelRepository,
+	notificationService *NotificationService,
+) *TutorLevelService {
+	return &TutorLevelService{
+		tutor:               tutor,
+		tutorLevel:          tutorLevel,
+		notificationService: notificationService,
+	}
+}
+
+type tutorLevelEvaluation struct {
+	// level is the index of the highest level reached, every level below it
+	// is met as well.
+	level   int
+	metrics []model.TutorLevelMetrics
+	checks  [][]model.TutorLevelCheck
+}
+
+func (e tutorLevelEvaluation) isMet(i int) bool {
+	for _, check := range e.checks[i] {
+		if !check.Met {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *TutorLevelService) evaluate(ctx context.Context, tutorID uuid.UUID, criteria []model.TutorLevelCriteria) (tutorLevelEvaluation, error) {
+	var (
+		evaluation = tutorLevelEvaluation{
+			metrics: make([]model.TutorLevelMetrics, len(criteria)),
+			checks:  make([][]model.TutorLevelCheck, len(criteria)),
+		}
+		metricsByWindow = make(map[int]model.TutorLevelMetrics)
+	)
+
+	for i, c := range criteria {
+		metrics, ok := metricsByWindow[c.RatingWindowDays]
+		if !ok {
+			var err error
+			metrics, err = s.tutorLevel.GetMetrics(ctx, tutorID, c.RatingWindowDays)
+			if err != nil {
+				return evaluation, err
+			}
+			metricsByWindow[c.RatingWindowDays] = metrics
+		}
+
+		evaluation.metrics[i] = metrics
+		evaluation.checks[i] = c.Evaluate(metrics)
+	}
+
+	// The entry level is always granted, higher levels have to be reached
+	// one after another.
+	for i := 1; i < len(criteria); i++ {
+		if !evaluation.isMet(i) {
+			break
+		}
+		evaluation.level = i
+	}
+
+	return evaluation, nil
+}
+
+func levelIndex(criteria []model.TutorLevelCriteria, level string) int {
+	for i, c := range criteria {
+		if string(c.Level) == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// Evaluate recomputes the level of a single tutor, records the change and
+// notifies the tutor when the level went up or down.
+func (s *TutorLevelService) Evaluate(ctx context.Context, tutorID uuid.UUID) error {
+	tutor, err := s.tutor.GetByID(ctx, tutorID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[EvaluateTutorLevel] Error getting tutor")
+		return err
+	}
+
+	criteria, err := s.tutorLevel.GetCriteria(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[EvaluateTutorLevel] Error getting level criteria")
+		return err
+	}
+
+	if len(criteria) == 0 {
+		return nil
+	}
+
+	evaluation, err := s.evaluate(ctx, tutor.ID, criteria)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[EvaluateTutorLevel] Error evaluating tutor")
+		return err
+	}
+
+	current := levelIndex(criteria, tutor.Level.String)
+	if tutor.Level.Valid && current == evaluation.level {
+		return s.tutorLevel.MarkEvaluated(ctx, tutor.ID)
+	}
+
+	var (
+		next     = criteria[evaluation.level]
+		promoted = evaluation.level > current
+		reason   string
+	)
+	if promoted {
+		reason = model.LevelChangeReason(true, next.Level, evaluation.checks[evaluation.level])
+	} else {
+		// The tutor failed the level right above the one they landed on.
+		failed := evaluation.level + 1
+		reason = model.LevelChangeReason(false, criteria[failed].Level, evaluation.checks[failed])
+	}
+
+	metrics := evaluation.metrics[evaluation.level]
+	history := &model.TutorLevelHistory{
+		TutorID:           tutor.ID,
+		FromLevel:         tutor.Level,
+		ToLevel:           string(next.Level),
+		Reason:            reason,
+		CompletedSessions: metrics.CompletedSessions,
+		AverageRating:     metrics.AverageRating,
+		ResponseTime:      metrics.ResponseTime,
+		CancellationRate:  decimal.NewFromFloat(metrics.CancellationRate).Round(2),
+		VerifiedDocuments: metrics.VerifiedDocuments,
+		CreatedAt:         time.Now(),
+		CreatedBy:         uuid.NullUUID{UUID: uuid.MustParse(model.SystemID), Valid: true},
+	}
+
+	err = s.tutorLevel.ChangeLevel(ctx, tutor.ID, history)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[EvaluateTutorLevel] Error changing tutor level")
+		return err
+	}
+
+	// Tutors that never had a level are only initialised, not notified.
+	if tutor.Level.Valid {
+		err = s.notificationService.TutorLevelChanged(ctx, *tutor, *history, promoted)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msg("[EvaluateTutorLevel] Error sending notification")
+		}
+	}
+
+	return nil
+}
+
+// RecomputeAll re-evaluates every tutor. A failure on one tutor is logged and
+// does not stop the run.
+func (s *TutorLevelService) RecomputeAll(ctx context.Context) error {
+	ids, err := s.tutor.GetIDs(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[RecomputeTutorLevel] Error getting tutors")
+		return err
+	}
+
+	for _, id := range ids {
+		err = s.Evaluate(ctx, id)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Str("tutor_id", id.String()).Msg("[RecomputeTutorLevel] Error evaluating tutor")
+		}
+	}
+
+	return nil
+}
+
+func (s *TutorLevelService) GetTutorLevel(ctx context.Context, userID uuid.UUID) (dto.TutorLevelInfo, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, userID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetTutorLevel] Error getting tutor")
+		return dto.TutorLevelInfo{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if tutor == nil {
+		return dto.TutorLevelInfo{}, shared.MakeError(ErrEntityNotFound, "tutor")
+	}
+
+	criteria, err := s.tutorLevel.GetCriteria(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetTutorLevel] Error getting level criteria")
+		return dto.TutorLevelInfo{}, shared.MakeError(ErrInternalServer)
+	}
+
+	histories, err := s.tutorLevel.GetHistories(ctx, tutor.ID, tutorLevelHistoryLimit)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetTutorLevel] Error getting level histories")
+		return dto.TutorLevelInfo{}, shared.MakeError(ErrInternalServer)
+	}
+
+	info := dto.TutorLevelInfo{
+		CurrentLevel: tutor.LevelName(),
+		IsMaxLevel:   true,
+		Progress:     100,
+		Criteria:     []dto.TutorLevelCriterion{},
+		EvaluatedAt:  tutor.LevelEvaluatedAt,
+		Histories:    dto.NewTutorLevelHistoryEntries(histories),
+	}
+
+	if len(criteria) == 0 {
+		return info, nil
+	}
+
+	evaluation, err := s.evaluate(ctx, tutor.ID, criteria)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[GetTutorLevel] Error evaluating tutor")
+		return dto.TutorLevelInfo{}, shared.MakeError(ErrInternalServer)
+	}
+
+	// Progress is shown against the level right above the stored one, the
+	// stored level only moves on the next recompute.
+	current := levelIndex(criteria, info.CurrentLevel)
+	target := current
+	if current+1 < len(criteria) {
+		target = current + 1
+		info.NextLevel = null.StringFrom(string(criteria[target].Level))
+		info.IsMaxLevel = false
+	}
+
+	if target >= 0 {
+		info.Criteria, info.Progress = dto.NewTutorLevelCriteria(evaluation.checks[target])
+	}
+
+	return info, nil
+}
+
+func (s *TutorLevelService) ListCriteria(ctx context.Context) ([]dto.AdminTutorLevelCriteriaResponse, error) {
+	criteria, err := s.tutorLevel.GetCriteria(ctx)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListTutorLevelCriteria] Error getting level criteria")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewAdminTutorLevelCriteriaResponses(criteria), nil
+}
+
+func (s *TutorLevelService) UpdateCriteria(ctx context.Context, request dto.UpdateAdminTutorLevelCriteriaRequest) error {
+	criteria, err := s.tutorLevel.GetCriteriaByID(ctx, request.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateTutorLevelCriteria] Error getting level criteria")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if criteria == nil {
+		return shared.MakeError(ErrEntityNotFound, "tutor level criteria")
+	}
+
+	criteria.MinCompletedSessions = request.MinCompletedSessions
+	criteria.MinRating = request.MinRating
+	criteria.RatingWindowDays = request.RatingWindowDays
+	criteria.MaxResponseTime = request.MaxResponseTime
+	criteria.MaxCancellationRate = request.MaxCancellationRate
+	criteria.MinVerifiedDocuments = request.MinVerifiedDocuments
+	criteria.UpdatedBy = uuid.NullUUID{UUID: middleware.GetUserID(ctx), Valid: true}
+
+	err = s.tutorLevel.UpdateCriteria(ctx, criteria)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateTutorLevelCriteria] Error updating level criteria")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	return nil
+}
+
+func (s *TutorLevelService) ListHistories(ctx context.Context, tutorID uuid.UUID) ([]dto.TutorLevelHistoryEntry, error) {
+	histories, err := s.tutorLevel.GetHistories(ctx, tutorID, tutorLevelHistoryLimit)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListTutorLevelHistories] Error getting level histories")
+		return nil, shared.MakeError(ErrInternalServer)
+	}
+
+	return dto.NewTutorLevelHistoryEntries(histories), nil
+}
@@ -16,16 +16,26 @@ import (
 )
 
 type TutorReviewService struct {
-	config *config.Config
-	review *repositories.ReviewRepository
-	tutor  *repositories.TutorRepository
+	config              *config.Config
+	review              *repositories.ReviewRepository
+	tutor               *repositories.TutorRepository
+	reviewService       *ReviewService
+	notificationService *NotificationService
 }
 
-func NewTutorReviewService(config *config.Config, review *repositories.ReviewRepository, tutor *repositories.TutorRepository) *TutorReviewService {
+func NewTutorReviewService(
+	config *config.Config,
+	review *repositories.ReviewRepository,
+	tutor *repositories.TutorRepository,
+	reviewService *ReviewService,
+	notificationService *NotificationService,
+) *TutorReviewService {
 	return &TutorReviewService{
-		config: config,
-		review: review,
-		tutor:  tutor,
+		config:              config,
+		review:              review,
+		tutor:               tutor,
+		reviewService:       reviewService,
+		notificationService: notificationService,
 	}
 }
 
@@ -100,54 +110,124 @@ func (s *TutorReviewService) Update(ctx context.Context, request dto.UpdateRevie
 	return nil
 }
 
-func (s *TutorReviewService) UpdateByAdmin(ctx context.Context, request dto.UpdateTutorReviewAdminRequest) error {
+// ListReceived lists the reviews students have written about the tutor,
+// including ones held for moderation so the tutor can see their status.
+func (s *TutorReviewService) ListReceived(ctx context.Context, request dto.ListReviewRequest) ([]model.TutorReview, model.Metadata, error) {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListReceivedTutorReview] Error getting tutor by user ID")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	if tutor == nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListReceivedTutorReview] User not found")
+		return nil, model.Metadata{}, shared.MakeError(ErrEntityNotFound, "user")
+	}
+
+	reviews, metadata, err := s.review.GetTutorReviews(ctx, model.ReviewFilter{
+		Pagination:      request.Pagination,
+		Sort:            request.Sort,
+		TutorID:         tutor.ID,
+		IsSubmitted:     null.BoolFrom(true),
+		DeletedAtIsNull: null.BoolFrom(true),
+	})
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ListReceivedTutorReview] Error getting tutor reviews")
+		return nil, model.Metadata{}, shared.MakeError(ErrInternalServer)
+	}
+
+	return reviews, metadata, nil
+}
+
+// Reply stores the tutor's public reply to a review written about them.
+func (s *TutorReviewService) Reply(ctx context.Context, request dto.ReplyReviewRequest) error {
+	tutor, err := s.tutor.GetByUserID(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyTutorReview] Error getting tutor by user ID")
+		return shared.MakeError(ErrInternalServer)
+	}
+
+	if tutor == nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyTutorReview] User not found")
+		return shared.MakeError(ErrEntityNotFound, "user")
+	}
+
 	review, err := s.review.GetTutorReviewByID(ctx, request.ID)
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateByAdmin] Error getting tutor review")
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyTutorReview] Error getting tutor review")
 		return shared.MakeError(ErrInternalServer)
 	}
 
-	if review == nil {
-		logger.ErrorCtx(ctx).Msg("[UpdateByAdmin] Review not found")
+	if review == nil || review.TutorID != tutor.ID || !review.IsSubmitted || review.DeletedAt.Valid {
+		logger.ErrorCtx(ctx).Msg("[ReplyTutorReview] Review not found")
 		return shared.MakeError(ErrEntityNotFound, "review")
 	}
 
-	review.Review = null.StringFrom(request.Review)
-	review.Rate = null.IntFrom(int64(request.Rate))
-	review.RecommendByStudent = request.RecommendByStudent
-	review.IsSubmitted = true
+	if review.ModerationStatus == model.ReviewModerationStatusHidden {
+		return shared.MakeError(ErrBadRequest, "cannot reply to a hidden review")
+	}
+
+	if s.reviewService.ContainsBlockedWord(request.Reply) {
+		return shared.MakeError(ErrBadRequest, "reply contains inappropriate language")
+	}
+
+	isFirstReply := !review.TutorReply.Valid
+	review.TutorReply = null.StringFrom(request.Reply)
+	review.TutorRepliedAt = null.TimeFrom(time.Now())
+	review.UpdatedBy = uuid.NullUUID{UUID: tutor.UserID, Valid: true}
 
 	err = s.review.UpdateTutorReview(ctx, review)
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateByAdmin] Error updating tutor review")
+		logger.ErrorCtx(ctx).Err(err).Msg("[ReplyTutorReview] Error updating tutor review")
 		return shared.MakeError(ErrInternalServer)
 	}
 
+	if isFirstReply {
+		go func() {
+			bgCtx := context.Background()
+			err := s.notificationService.ReplyReviewStudent(bgCtx, *review)
+			if err != nil {
+				logger.ErrorCtx(bgCtx).Err(err).Msg("[ReplyTutorReview] Error sending notification")
+			}
+		}()
+	}
+
 	return nil
 }
 
-func (s *TutorReviewService) DeleteByAdmin(ctx context.Context, id uuid.UUID) error {
-	review, err := s.review.GetTutorReviewByID(ctx, id)
+func (s *TutorReviewService) UpdateByAdmin(ctx context.Context, request dto.UpdateTutorReviewAdminRequest) error {
+	review, err := s.review.GetTutorReviewByID(ctx, request.ID)
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteByAdmin] Error getting tutor review")
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateByAdmin] Error getting tutor review")
 		return shared.MakeError(ErrInternalServer)
 	}
 
 	if review == nil {
-		logger.ErrorCtx(ctx).Msg("[DeleteByAdmin] Review not found")
+		logger.ErrorCtx(ctx).Msg("[UpdateByAdmin] Review not found")
 		return shared.MakeError(ErrEntityNotFound, "review")
 	}
 
-	userID := middleware.GetUserID(ctx)
-	now := null.TimeFrom(time.Now())
-	review.DeletedAt = now
-	review.DeletedBy = uuid.NullUUID{UUID: userID, Valid: true}
+	review.Review = null.StringFrom(request.Review)
+	review.Rate = null.IntFrom(int64(request.Rate))
+	review.RecommendByStudent = request.RecommendByStudent
+	review.IsSubmitted = true
+	if request.Criteria != nil {
+		review.PunctualityRate = request.Criteria.Punctuality
+		review.ClarityRate = request.Criteria.Clarity
+		review.PatienceRate = request.Criteria.Patience
+		review.MaterialsRate = request.Criteria.Materials
+	}
 
 	err = s.review.UpdateTutorReview(ctx, review)
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msg("[DeleteByAdmin] Error deleting tutor review")
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateByAdmin] Error updating tutor review")
 		return shared.MakeError(ErrInternalServer)
 	}
 
+	err = s.review.RecalculateRating(ctx, review.TutorID, review.CourseID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[UpdateByAdmin] Error recalculating rating")
+	}
+
 	return nil
 }
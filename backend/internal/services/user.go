@@ -707,6 +707,15 @@ func (s *UserService) prepareRoleSpecificRecord(user *model.User, roleName strin
 			CreatedBy:     uuid.NullUUID{UUID: user.ID, Valid: true},
 		}
 		return tutor, nil
+
+	case model.RoleNameGuardian:
+		guardian := &model.Guardian{
+			UserID:    user.ID,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			CreatedBy: uuid.NullUUID{UUID: user.ID, Valid: true},
+		}
+		return guardian, nil
 	default:
 		// Unknown role, no additional record created
 		return nil, nil
@@ -19,37 +19,52 @@ import (
 type WebhookXenditFunc func(ctx context.Context, request dto.WebhookXenditRequest) error
 
 type WebhookService struct {
-	subscription  *repositories.SubscriptionRepository
-	payment       *repositories.PaymentRepository
-	student       *repositories.StudentRepository
-	notification  *NotificationService
-	mentorBalance *MentorBalanceService
-	xendit        map[string]WebhookXenditFunc
-	config        *config.Config
+	subscription *repositories.SubscriptionRepository
+	payment      *repositories.PaymentRepository
+	student      *repositories.StudentRepository
+	giftCode     *repositories.GiftCodeRepository
+	notification *NotificationService
+	lifecycle    *SubscriptionLifecycleService
+	refund       *PaymentRefundService
+	invoice      *InvoiceService
+	payout       *PayoutService
+	xendit       map[string]WebhookXenditFunc
+	config       *config.Config
 }
 
 func NewWebhookService(
 	subscription *repositories.SubscriptionRepository,
 	payment *repositories.PaymentRepository,
 	student *repositories.StudentRepository,
+	giftCode *repositories.GiftCodeRepository,
 	notification *NotificationService,
 	config *config.Config,
-	mentorBalance *MentorBalanceService,
+	lifecycle *SubscriptionLifecycleService,
+	refund *PaymentRefundService,
+	invoice *InvoiceService,
+	payout *PayoutService,
 ) *WebhookService {
 	s := &WebhookService{
-		subscription:  subscription,
-		payment:       payment,
-		student:       student,
-		notification:  notification,
-		config:        config,
-		mentorBalance: mentorBalance,
-		xendit:        make(map[string]WebhookXenditFunc),
+		subscription: subscription,
+		payment:      payment,
+		student:      student,
+		giftCode:     giftCode,
+		notification: notification,
+		config:       config,
+		lifecycle:    lifecycle,
+		refund:       refund,
+		invoice:      invoice,
+		payout:       payout,
+		xendit:       make(map[string]WebhookXenditFunc),
 	}
 
 	s.xendit[dto.WebhookXenditEventTypeRecurringCycleSucceeded] = s.handleWebhookXenditRecurringCycleSucceeded
+	s.xendit[dto.WebhookXenditEventTypeRecurringCycleRetrying] = s.handleWebhookXenditRecurringCycleRetrying
 	s.xendit[dto.WebhookXenditEventTypeRecurringCycleFailed] = s.handleWebhookXenditRecurringCycleFailed
 	s.xendit[dto.WebhookXenditEventTypePaymentSessionCompleted] = s.handleWebhookXenditPaymentSessionCompleted
 	s.xendit[dto.WebhookXenditEventTypePaymentSessionExpired] = s.handleWebhookXenditPaymentSessionExpired
+	s.xendit[dto.WebhookXenditEventTypeRefundSucceeded] = s.handleWebhookXenditRefund
+	s.xendit[dto.WebhookXenditEventTypeRefundFailed] = s.handleWebhookXenditRefund
 
 	return s
 }
@@ -68,6 +83,17 @@ func (s *WebhookService) HandleWebhookXendit(ctx context.Context, req dto.Webhoo
 	return s.xendit[req.Event](ctx, req)
 }
 
+// HandleWebhookXenditDisbursement handles the disbursement callback, which
+// Xendit posts to its own URL.
+func (s *WebhookService) HandleWebhookXenditDisbursement(ctx context.Context, req dto.WebhookXenditDisbursement) error {
+	if req.WebhookKey != s.config.Xendit.WebhookKey {
+		logger.WarnCtx(ctx).Msgf("[HandleWebhookXenditDisbursement] invalid webhook key: %s", req.WebhookKey)
+		return shared.MakeError(ErrBadRequest, "invalid webhook key")
+	}
+
+	return s.payout.HandleXenditDisbursement(ctx, req)
+}
+
 func (s *WebhookService) handleWebhookXenditRecurringCycleSucceeded(ctx context.Context, request dto.WebhookXenditRequest) error {
 	payload, err := json.Marshal(request.Data)
 	if err != nil {
@@ -92,31 +118,34 @@ func (s *WebhookService) handleWebhookXenditRecurringCycleSucceeded(ctx context.
 		return shared.MakeError(ErrEntityNotFound, "subscription")
 	}
 
-	if subscription.Status == model.SubscriptionStatusActive {
-		switch subscription.Interval {
-		case model.SubscriptionIntervalMonthly:
-			subscription.EndDate = subscription.EndDate.AddDate(0, subscription.IntervalCount, 0)
-		case model.SubscriptionIntervalYearly:
-			subscription.EndDate = subscription.EndDate.AddDate(subscription.IntervalCount, 0, 0)
-		}
+	// A cycle delivered again is only invoiced, in case issuing failed before.
+	payment, err := s.payment.GetByReferenceID(ctx, data.ID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msgf("[handleWebhookXenditRecurringCycleSucceeded] failed to get payment of cycle: %s", data.ID)
+		return err
 	}
 
-	subscription.Status = model.SubscriptionStatusActive
-	subscription.UpdatedAt = time.Now()
-	subscription.UpdatedBy = uuid.MustParse(model.SystemID)
+	if payment == nil {
+		rate, err := s.invoice.GetTaxRate(ctx, time.Now())
+		if err != nil {
+			return err
+		}
 
-	student := subscription.Student
-	student.PremiumUntil = null.TimeFrom(subscription.EndDate)
+		// The payment is saved with the extension, so a retried delivery does
+		// not extend the subscription again
+		payment, err = s.lifecycle.CycleSucceeded(ctx, subscription, data.ID, *rate)
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Msgf("[handleWebhookXenditRecurringCycleSucceeded] failed to save cycle: %s", data.ID)
+			return err
+		}
 
-	err = s.subscription.Update(ctx, subscription)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msgf("[handleWebhookXenditRecurringCycleSucceeded] failed to update subscription by reference id: %s", data.PlanID)
-		return err
+		if payment == nil {
+			return nil
+		}
 	}
 
-	err = s.student.Update(ctx, &student)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msgf("[handleWebhookXenditRecurringCycleSucceeded] failed to update student by reference id: %s", data.PlanID)
+	if _, err = s.invoice.Issue(ctx, *payment); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msgf("[handleWebhookXenditRecurringCycleSucceeded] failed to issue invoice of cycle: %s", data.ID)
 		return err
 	}
 
@@ -147,52 +176,89 @@ func (s *WebhookService) handleWebhookXenditPaymentSessionCompleted(ctx context.
 		return shared.MakeError(ErrEntityNotFound, "payment")
 	}
 
+	// A payment delivered again is only invoiced, in case issuing failed
+	// before.
 	if payment.Status == model.SubscriptionStatusActive {
 		logger.WarnCtx(ctx).Interface("data", data).Msg("[handleWebhookXenditPaymentSessionCompleted] payment already active")
+		if _, err = s.invoice.Issue(ctx, *payment); err != nil {
+			logger.ErrorCtx(ctx).Err(err).Interface("data", data).Msg("[handleWebhookXenditPaymentSessionCompleted] Error issuing invoice")
+			return err
+		}
+
 		return nil
 	}
 
 	payment.PaidAt = null.TimeFrom(time.Now())
+	payment.PaymentRequestID = null.NewString(data.PaymentRequestID, data.PaymentRequestID != "")
 	payment.Status = model.SubscriptionStatusActive
 	payment.UpdatedAt = time.Now()
 	payment.UpdatedBy = uuid.MustParse(model.SystemID)
 
-	student := payment.Student
-	student.PremiumUntil = null.TimeFrom(payment.EndDate)
-
 	err = s.payment.Update(ctx, payment)
 	if err != nil {
 		logger.ErrorCtx(ctx).Err(err).Interface("data", data).Msg("[handleWebhookXenditPaymentSessionCompleted] failed to update subscription by id")
 		return err
 	}
 
-	err = s.student.Update(ctx, &student)
-	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Interface("data", data).Msgf("[handleWebhookXenditPaymentSessionCompleted] failed to update student by id")
-		return err
+	recipients := payment.Recipients()
+	for i := range recipients {
+		// A student who got a longer premium elsewhere in the meantime keeps it.
+		if recipients[i].PremiumUntil.Valid && recipients[i].PremiumUntil.Time.After(payment.EndDate) {
+			continue
+		}
+
+		recipients[i].PremiumUntil = null.TimeFrom(payment.EndDate)
+
+		err = s.student.Update(ctx, &recipients[i])
+		if err != nil {
+			logger.ErrorCtx(ctx).Err(err).Interface("data", data).Msgf("[handleWebhookXenditPaymentSessionCompleted] failed to update student by id")
+			return err
+		}
 	}
 
-	go func() {
-		err = s.notification.PaymentCompleted(context.Background(), student, *payment)
+	var codes []model.GiftCode
+	if payment.Type == model.PaymentTypeGift {
+		_, err = s.giftCode.UpdateStatusByPayment(ctx, payment.ID, model.GiftCodeStatusPending, model.GiftCodeStatusActive)
 		if err != nil {
-			logger.ErrorCtx(context.Background()).Err(err).Msg("[handleWebhookXenditPaymentSessionCompleted] Error sending payment completed notification")
+			logger.ErrorCtx(ctx).Err(err).Interface("data", data).Msg("[handleWebhookXenditPaymentSessionCompleted] failed to activate gift codes")
+			return err
+		}
+
+		codes, _, err = s.giftCode.Get(ctx, model.GiftCodeFilter{PaymentID: payment.ID})
+		if err != nil {
+			return err
 		}
-	}()
-
-	// Credit mentor balance if payment is for a booking
-	if payment.TutorID != uuid.Nil {
-		go func() {
-			if err := s.mentorBalance.CreditFromBooking(
-				context.Background(),
-				payment.TutorID,
-				payment.Amount,
-				payment.ID,
-			); err != nil {
-				logger.ErrorCtx(context.Background()).Err(err).Msg("[handleWebhookXenditPaymentSessionCompleted] failed to credit mentor balance")
-			}
-		}()
 	}
 
+	// Issued before answering the webhook so a failure is retried by Xendit.
+	if _, err = s.invoice.Issue(ctx, *payment); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Interface("data", data).Msg("[handleWebhookXenditPaymentSessionCompleted] Error issuing invoice")
+		return err
+	}
+
+	go func(payment model.Payment) {
+		// The payer and every student who got premium are told, a student
+		// paying for themselves is told once.
+		users := []model.User{payment.Payer()}
+		if payment.GuardianID.Valid {
+			for _, student := range recipients {
+				users = append(users, student.User)
+			}
+		}
+
+		for _, user := range users {
+			if err := s.notification.PaymentCompleted(context.Background(), user, payment); err != nil {
+				logger.ErrorCtx(context.Background()).Err(err).Msg("[handleWebhookXenditPaymentSessionCompleted] Error sending payment completed notification")
+			}
+		}
+
+		if len(codes) > 0 {
+			if err := s.notification.GiftCodesIssued(context.Background(), payment.Payer(), codes); err != nil {
+				logger.ErrorCtx(context.Background()).Err(err).Msg("[handleWebhookXenditPaymentSessionCompleted] Error sending gift codes notification")
+			}
+		}
+	}(*payment)
+
 	return nil
 }
 
@@ -220,17 +286,34 @@ func (s *WebhookService) handleWebhookXenditRecurringCycleFailed(ctx context.Con
 		return shared.MakeError(ErrEntityNotFound, "subscription")
 	}
 
-	subscription.Status = model.SubscriptionStatusInActive
-	subscription.UpdatedAt = time.Now()
-	subscription.UpdatedBy = uuid.MustParse(model.SystemID)
+	return s.lifecycle.CycleFailed(ctx, subscription, data.AttemptCount)
+}
 
-	err = s.subscription.Update(ctx, subscription)
+func (s *WebhookService) handleWebhookXenditRecurringCycleRetrying(ctx context.Context, request dto.WebhookXenditRequest) error {
+	payload, err := json.Marshal(request.Data)
 	if err != nil {
-		logger.ErrorCtx(ctx).Err(err).Msgf("[handleWebhookXenditRecurringCycleFailed] failed to update subscription by reference id: %s", data.PlanID)
+		logger.ErrorCtx(ctx).Err(err).Msg("[handleWebhookXenditRecurringCycleRetrying] failed to marshal data")
 		return err
 	}
 
-	return nil
+	data := dto.WebhookXenditRecurringCycle{}
+	if err = json.Unmarshal(payload, &data); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[handleWebhookXenditRecurringCycleRetrying] failed to unmarshal data")
+		return err
+	}
+
+	subscription, err := s.subscription.GetByReferenceID(ctx, data.PlanID)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msgf("[handleWebhookXenditRecurringCycleRetrying] failed to get subscription by reference id: %s", data.PlanID)
+		return err
+	}
+
+	if subscription == nil {
+		logger.WarnCtx(ctx).Msgf("[handleWebhookXenditRecurringCycleRetrying] subscription not found by reference id: %s", data.PlanID)
+		return shared.MakeError(ErrEntityNotFound, "subscription")
+	}
+
+	return s.lifecycle.CycleRetrying(ctx, subscription, data.AttemptCount)
 }
 
 func (s *WebhookService) handleWebhookXenditPaymentSessionExpired(ctx context.Context, request dto.WebhookXenditRequest) error {
@@ -274,3 +357,19 @@ func (s *WebhookService) handleWebhookXenditPaymentSessionExpired(ctx context.Co
 
 	return nil
 }
+
+func (s *WebhookService) handleWebhookXenditRefund(ctx context.Context, request dto.WebhookXenditRequest) error {
+	payload, err := json.Marshal(request.Data)
+	if err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[handleWebhookXenditRefund] failed to marshal data")
+		return err
+	}
+
+	data := dto.WebhookXenditRefund{}
+	if err = json.Unmarshal(payload, &data); err != nil {
+		logger.ErrorCtx(ctx).Err(err).Msg("[handleWebhookXenditRefund] failed to unmarshal data")
+		return err
+	}
+
+	return s.refund.HandleXenditRefund(ctx, data)
+}
@@ -0,0 +1,26 @@
+ALTER TABLE tutors
+    DROP COLUMN punctuality_rating,
+    DROP COLUMN clarity_rating,
+    DROP COLUMN patience_rating,
+    DROP COLUMN materials_rating;
+
+ALTER TABLE courses
+    DROP COLUMN rating,
+    DROP COLUMN total_rating;
+
+DROP TABLE IF EXISTS review_reports;
+DROP TABLE IF EXISTS review_helpful_votes;
+
+ALTER TABLE tutor_reviews
+    DROP INDEX idx_tutor_reviews_moderation_status,
+    DROP COLUMN punctuality_rate,
+    DROP COLUMN clarity_rate,
+    DROP COLUMN patience_rate,
+    DROP COLUMN materials_rate,
+    DROP COLUMN tutor_reply,
+    DROP COLUMN tutor_replied_at,
+    DROP COLUMN helpful_count,
+    DROP COLUMN moderation_status,
+    DROP COLUMN moderation_note,
+    DROP COLUMN moderated_at,
+    DROP COLUMN moderated_by;
@@ -0,0 +1,72 @@
+ALTER TABLE tutor_reviews
+    ADD COLUMN punctuality_rate INT NULL AFTER rate,
+    ADD COLUMN clarity_rate INT NULL AFTER punctuality_rate,
+    ADD COLUMN patience_rate INT NULL AFTER clarity_rate,
+    ADD COLUMN materials_rate INT NULL AFTER patience_rate,
+    ADD COLUMN tutor_reply TEXT NULL AFTER recommend_by_student,
+    ADD COLUMN tutor_replied_at TIMESTAMP NULL AFTER tutor_reply,
+    ADD COLUMN helpful_count INT NOT NULL DEFAULT 0 AFTER tutor_replied_at,
+    ADD COLUMN moderation_status VARCHAR(50) NOT NULL DEFAULT 'published' AFTER helpful_count,
+    ADD COLUMN moderation_note VARCHAR(500) NULL AFTER moderation_status,
+    ADD COLUMN moderated_at TIMESTAMP NULL AFTER moderation_note,
+    ADD COLUMN moderated_by CHAR(36) NULL AFTER moderated_at,
+    ADD INDEX idx_tutor_reviews_moderation_status (moderation_status);
+
+CREATE TABLE review_helpful_votes (
+    id              CHAR(36) PRIMARY KEY,
+    tutor_review_id CHAR(36) NOT NULL,
+    user_id         CHAR(36) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uniq_review_helpful_vote (tutor_review_id, user_id),
+    CONSTRAINT fk_review_helpful_votes_review FOREIGN KEY (tutor_review_id) REFERENCES tutor_reviews(id) ON DELETE CASCADE,
+    CONSTRAINT fk_review_helpful_votes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE review_reports (
+    id              CHAR(36) PRIMARY KEY,
+    tutor_review_id CHAR(36) NOT NULL,
+    reporter_id     CHAR(36) NULL,
+    source          ENUM('user', 'system') NOT NULL DEFAULT 'user',
+    reason          VARCHAR(500) NOT NULL,
+    status          ENUM('pending', 'resolved', 'dismissed') NOT NULL DEFAULT 'pending',
+    resolved_at     TIMESTAMP NULL,
+    resolved_by     CHAR(36) NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_review_reports_review (tutor_review_id),
+    INDEX idx_review_reports_status (status),
+    CONSTRAINT fk_review_reports_review FOREIGN KEY (tutor_review_id) REFERENCES tutor_reviews(id) ON DELETE CASCADE,
+    CONSTRAINT fk_review_reports_reporter FOREIGN KEY (reporter_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+ALTER TABLE courses
+    ADD COLUMN rating DECIMAL(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN total_rating INT NOT NULL DEFAULT 0;
+
+ALTER TABLE tutors
+    ADD COLUMN punctuality_rating DECIMAL(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN clarity_rating DECIMAL(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN patience_rating DECIMAL(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN materials_rating DECIMAL(3,2) NOT NULL DEFAULT 0;
+
+UPDATE courses c
+SET c.rating = COALESCE((
+        SELECT AVG(tr.rate) FROM tutor_reviews tr
+        WHERE tr.course_id = c.id AND tr.is_submitted = 1 AND tr.rate IS NOT NULL AND tr.deleted_at IS NULL
+    ), 0),
+    c.total_rating = (
+        SELECT COUNT(*) FROM tutor_reviews tr
+        WHERE tr.course_id = c.id AND tr.is_submitted = 1 AND tr.rate IS NOT NULL AND tr.deleted_at IS NULL
+    );
+
+UPDATE tutors t
+SET t.rating = COALESCE((
+        SELECT AVG(tr.rate) FROM tutor_reviews tr
+        WHERE tr.tutor_id = t.id AND tr.is_submitted = 1 AND tr.rate IS NOT NULL AND tr.deleted_at IS NULL
+    ), 0),
+    t.total_rating = (
+        SELECT COUNT(*) FROM tutor_reviews tr
+        WHERE tr.tutor_id = t.id AND tr.is_submitted = 1 AND tr.rate IS NOT NULL AND tr.deleted_at IS NULL
+    );
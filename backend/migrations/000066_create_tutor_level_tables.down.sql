@@ -0,0 +1,6 @@
+ALTER TABLE tutors
+    ADD COLUMN level_point INT UNSIGNED AFTER level,
+    DROP COLUMN level_evaluated_at;
+
+DROP TABLE IF EXISTS tutor_level_histories;
+DROP TABLE IF EXISTS tutor_level_criteria;
@@ -0,0 +1,45 @@
+CREATE TABLE tutor_level_criteria (
+    id                     CHAR(36) PRIMARY KEY,
+    level                  VARCHAR(100) NOT NULL,
+    sequence               INT NOT NULL,
+    min_completed_sessions INT NOT NULL DEFAULT 0,
+    min_rating             DECIMAL(3,2) NOT NULL DEFAULT 0,
+    rating_window_days     INT NOT NULL DEFAULT 90,
+    max_response_time      INT NULL COMMENT 'in minutes',
+    max_cancellation_rate  DECIMAL(5,2) NULL COMMENT 'in percent',
+    min_verified_documents INT NOT NULL DEFAULT 0,
+    created_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    updated_by             CHAR(36) NULL,
+
+    UNIQUE KEY uniq_tutor_level_criteria_level (level)
+);
+
+INSERT INTO tutor_level_criteria (id, level, sequence, min_completed_sessions, min_rating, rating_window_days, max_response_time, max_cancellation_rate, min_verified_documents) VALUES
+    (UUID(), 'Guru', 1, 0, 0, 90, NULL, NULL, 0),
+    (UUID(), 'Guru Aktif', 2, 10, 4.00, 90, 720, 30.00, 1),
+    (UUID(), 'Guru Favorit', 3, 25, 4.50, 90, 360, 15.00, 2);
+
+CREATE TABLE tutor_level_histories (
+    id                 CHAR(36) PRIMARY KEY,
+    tutor_id           CHAR(36) NOT NULL,
+    from_level         VARCHAR(100) NULL,
+    to_level           VARCHAR(100) NOT NULL,
+    reason             TEXT NOT NULL,
+    completed_sessions INT NOT NULL DEFAULT 0,
+    average_rating     DECIMAL(3,2) NOT NULL DEFAULT 0,
+    response_time      INT NULL,
+    cancellation_rate  DECIMAL(5,2) NOT NULL DEFAULT 0,
+    verified_documents INT NOT NULL DEFAULT 0,
+    created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by         CHAR(36) NULL,
+
+    INDEX idx_tutor_level_histories_tutor (tutor_id, created_at),
+    CONSTRAINT fk_tutor_level_histories_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE
+);
+
+ALTER TABLE tutors
+    ADD COLUMN level_evaluated_at TIMESTAMP NULL AFTER level,
+    DROP COLUMN level_point;
+
+UPDATE tutors SET level = 'Guru' WHERE level IS NULL;
@@ -0,0 +1,28 @@
+CREATE TABLE booking_events (
+    id          CHAR(36) PRIMARY KEY,
+    booking_id  CHAR(36) NOT NULL,
+    tutor_id    CHAR(36) NOT NULL,
+    type        VARCHAR(50) NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    actor_id    CHAR(36) NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_booking_events_booking (booking_id),
+    INDEX idx_booking_events_tutor (tutor_id, type, occurred_at),
+    CONSTRAINT fk_booking_events_booking FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE
+);
+
+-- Backfill from existing bookings. The last update is the best available
+-- approximation of when the tutor answered.
+INSERT INTO booking_events (id, booking_id, tutor_id, type, occurred_at, actor_id)
+SELECT UUID(), id, tutor_id, 'created', created_at, created_by
+FROM bookings
+WHERE deleted_at IS NULL;
+
+INSERT INTO booking_events (id, booking_id, tutor_id, type, occurred_at, actor_id)
+SELECT UUID(), id, tutor_id,
+    CASE status WHEN 'accepted' THEN 'approved' WHEN 'declined' THEN 'declined' ELSE 'expired' END,
+    updated_at, updated_by
+FROM bookings
+WHERE deleted_at IS NULL
+  AND status IN ('accepted', 'declined', 'expired');
@@ -0,0 +1,14 @@
+CREATE TABLE course_versions (
+    id               CHAR(36) PRIMARY KEY,
+    course_id        CHAR(36) NOT NULL,
+    version          INT NOT NULL,
+    snapshot         JSON NOT NULL,
+    source           VARCHAR(50) NOT NULL,
+    draft_id         CHAR(36) NULL,
+    restored_from    INT NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by       CHAR(36) NULL,
+
+    UNIQUE KEY uk_course_versions_course_version (course_id, version),
+    CONSTRAINT fk_course_versions_course FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE
+);
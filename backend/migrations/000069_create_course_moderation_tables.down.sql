@@ -0,0 +1,7 @@
+ALTER TABLE course_drafts
+    DROP INDEX idx_course_drafts_queue,
+    DROP COLUMN rejection_checklist,
+    DROP COLUMN assigned_at,
+    DROP COLUMN assigned_to;
+
+DROP TABLE IF EXISTS course_rejection_reasons;
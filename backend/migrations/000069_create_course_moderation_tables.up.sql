@@ -0,0 +1,29 @@
+CREATE TABLE course_rejection_reasons (
+    id          CHAR(36) PRIMARY KEY,
+    code        VARCHAR(100) NOT NULL,
+    title       VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    sequence    INT NOT NULL DEFAULT 0,
+    is_active   BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_by  CHAR(36) NULL,
+    updated_by  CHAR(36) NULL,
+
+    UNIQUE KEY uk_course_rejection_reasons_code (code)
+);
+
+INSERT INTO course_rejection_reasons (id, code, title, description, sequence) VALUES
+(UUID(), 'incomplete_price', 'Harga belum lengkap', 'Lengkapi harga untuk setiap tipe kelas dan durasi yang ditawarkan.', 1),
+(UUID(), 'missing_schedule', 'Jadwal belum diisi', 'Tambahkan minimal satu jadwal yang tersedia untuk siswa.', 2),
+(UUID(), 'contact_details', 'Terdapat kontak pribadi', 'Hapus nomor telepon, email, tautan atau akun media sosial dari judul dan deskripsi.', 3),
+(UUID(), 'duplicate_course', 'Kursus duplikat', 'Kursus dengan judul yang sama sudah ada. Gabungkan atau bedakan judul kursus.', 4),
+(UUID(), 'unclear_description', 'Deskripsi kurang jelas', 'Jelaskan materi, target siswa dan metode belajar dengan lebih rinci.', 5),
+(UUID(), 'wrong_category', 'Kategori tidak sesuai', 'Pilih kategori dan sub kategori yang sesuai dengan materi kursus.', 6),
+(UUID(), 'inappropriate_content', 'Konten tidak pantas', 'Hapus konten yang melanggar syarat dan ketentuan.', 7);
+
+ALTER TABLE course_drafts
+    ADD COLUMN assigned_to CHAR(36) NULL AFTER review_notes,
+    ADD COLUMN assigned_at TIMESTAMP NULL AFTER assigned_to,
+    ADD COLUMN rejection_checklist JSON NULL AFTER assigned_at,
+    ADD INDEX idx_course_drafts_queue (status, submitted_at);
@@ -0,0 +1,3 @@
+ALTER TABLE bookings DROP COLUMN is_priority;
+
+DROP TABLE IF EXISTS plan_entitlements;
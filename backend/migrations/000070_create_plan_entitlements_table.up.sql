@@ -0,0 +1,26 @@
+-- A row with a NULL subscription_price_id holds the entitlements of the free
+-- plan. NULL limits mean unlimited.
+CREATE TABLE plan_entitlements (
+    id                             CHAR(36) PRIMARY KEY,
+    subscription_price_id          CHAR(36) NULL,
+    name                           VARCHAR(255) NOT NULL,
+    max_booking_per_day            INT NULL,
+    max_booking_per_category       INT NULL,
+    max_free_first_course_per_category  INT NULL,
+    monthly_report                 BOOLEAN NOT NULL DEFAULT FALSE,
+    priority_response              BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at                     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at                     TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    updated_by                     CHAR(36) NULL,
+
+    UNIQUE KEY uk_plan_entitlements_subscription_price (subscription_price_id),
+    CONSTRAINT fk_plan_entitlements_subscription_price FOREIGN KEY (subscription_price_id) REFERENCES subscription_prices(id) ON DELETE CASCADE
+);
+
+INSERT INTO plan_entitlements (id, subscription_price_id, name, max_booking_per_day, max_booking_per_category, max_free_first_course_per_category, monthly_report, priority_response) VALUES
+(UUID(), NULL, 'Free', 5, 1, 1, FALSE, FALSE),
+(UUID(), '30e88d1b-2ed9-4ec3-81a3-f55e8b1df669', 'Les Private Monthly', 20, 5, 1, TRUE, TRUE),
+(UUID(), '95698aaf-2bd6-4f4f-abb2-a360d735718d', 'Les Private Yearly', NULL, NULL, 1, TRUE, TRUE);
+
+ALTER TABLE bookings
+    ADD COLUMN is_priority BOOLEAN NOT NULL DEFAULT FALSE AFTER is_free_first_course;
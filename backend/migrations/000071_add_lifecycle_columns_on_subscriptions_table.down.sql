@@ -0,0 +1,11 @@
+ALTER TABLE subscriptions
+    DROP INDEX idx_status_end_date,
+    DROP COLUMN prorated_credit,
+    DROP COLUMN previous_subscription_id,
+    DROP COLUMN reminded_end_date,
+    DROP COLUMN grace_until,
+    DROP COLUMN last_failed_at,
+    DROP COLUMN retry_count,
+    DROP COLUMN canceled_at,
+    DROP COLUMN cancel_at_period_end,
+    DROP COLUMN trial_ends_at;
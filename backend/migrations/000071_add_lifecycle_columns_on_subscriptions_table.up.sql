@@ -0,0 +1,11 @@
+ALTER TABLE subscriptions
+    ADD COLUMN trial_ends_at TIMESTAMP NULL AFTER end_date,
+    ADD COLUMN cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE AFTER status,
+    ADD COLUMN canceled_at TIMESTAMP NULL AFTER cancel_at_period_end,
+    ADD COLUMN retry_count INT NOT NULL DEFAULT 0 AFTER canceled_at,
+    ADD COLUMN last_failed_at TIMESTAMP NULL AFTER retry_count,
+    ADD COLUMN grace_until TIMESTAMP NULL AFTER last_failed_at,
+    ADD COLUMN reminded_end_date TIMESTAMP NULL AFTER grace_until,
+    ADD COLUMN previous_subscription_id CHAR(36) NULL AFTER reminded_end_date,
+    ADD COLUMN prorated_credit DECIMAL(12, 2) NOT NULL DEFAULT 0 AFTER previous_subscription_id,
+    ADD INDEX idx_status_end_date (status, end_date);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS payment_refunds;
+
+ALTER TABLE payments DROP COLUMN payment_request_id;
@@ -0,0 +1,28 @@
+ALTER TABLE payments
+    ADD COLUMN payment_request_id VARCHAR(255) NULL AFTER reference_id;
+
+CREATE TABLE payment_refunds (
+    id                   CHAR(36) PRIMARY KEY,
+    payment_id           CHAR(36) NOT NULL,
+    reference_id         VARCHAR(255) NOT NULL DEFAULT '',
+    credit_note_number   VARCHAR(255) NOT NULL,
+    amount               DECIMAL(12,2) NOT NULL,
+    reason               VARCHAR(50) NOT NULL,
+    note                 VARCHAR(500) NULL,
+    status               VARCHAR(50) NOT NULL,
+    failure_code         VARCHAR(255) NULL,
+    balance_reversed     DECIMAL(15,2) NOT NULL DEFAULT 0,
+    commission_reversed  DECIMAL(15,2) NOT NULL DEFAULT 0,
+    premium_until_before TIMESTAMP NULL,
+    premium_until_after  TIMESTAMP NULL,
+    refunded_at          TIMESTAMP NULL,
+    created_at           TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at           TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_by           CHAR(36) NOT NULL,
+    updated_by           CHAR(36) NOT NULL,
+
+    UNIQUE KEY uk_payment_refunds_credit_note_number (credit_note_number),
+    INDEX idx_payment_refunds_payment (payment_id),
+    INDEX idx_payment_refunds_reference (reference_id),
+    CONSTRAINT fk_payment_refunds_payment FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE
+);
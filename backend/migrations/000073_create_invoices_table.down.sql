@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS invoice_items;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS invoice_sequences;
+
+ALTER TABLE students
+    DROP COLUMN tax_address,
+    DROP COLUMN tax_name,
+    DROP COLUMN tax_id;
+
+ALTER TABLE payments
+    DROP COLUMN tax_amount;
+
+DROP TABLE IF EXISTS tax_rates;
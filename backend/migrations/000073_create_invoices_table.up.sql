@@ -0,0 +1,87 @@
+-- The tax base is the price multiplied by base_numerator/base_denominator
+-- (DPP nilai lain), the tax is the tax base multiplied by rate.
+CREATE TABLE tax_rates (
+    id               CHAR(36) PRIMARY KEY,
+    name             VARCHAR(50) NOT NULL,
+    rate             DECIMAL(7,4) NOT NULL,
+    base_numerator   INT NOT NULL DEFAULT 1,
+    base_denominator INT NOT NULL DEFAULT 1,
+    effective_from   TIMESTAMP NOT NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_by       CHAR(36) NULL,
+    updated_by       CHAR(36) NULL,
+
+    INDEX idx_tax_rates_effective_from (name, effective_from)
+);
+
+INSERT INTO tax_rates (id, name, rate, base_numerator, base_denominator, effective_from) VALUES
+(UUID(), 'PPN', 0.1100, 1, 1, '2022-04-01 00:00:00'),
+(UUID(), 'PPN', 0.1200, 11, 12, '2025-01-01 00:00:00');
+
+ALTER TABLE payments
+    ADD COLUMN tax_amount DECIMAL(12,2) NULL AFTER amount;
+
+ALTER TABLE students
+    ADD COLUMN tax_id VARCHAR(20) NULL AFTER phone_number,
+    ADD COLUMN tax_name VARCHAR(255) NULL AFTER tax_id,
+    ADD COLUMN tax_address VARCHAR(500) NULL AFTER tax_name;
+
+-- One row per month, locked while an invoice number is taken so numbers stay
+-- gap-free.
+CREATE TABLE invoice_sequences (
+    period      CHAR(6) PRIMARY KEY,
+    last_number INT NOT NULL DEFAULT 0,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE invoices (
+    id               CHAR(36) PRIMARY KEY,
+    number           VARCHAR(50) NOT NULL,
+    period           CHAR(6) NOT NULL,
+    sequence         INT NOT NULL,
+    payment_id       CHAR(36) NOT NULL,
+    student_id       CHAR(36) NOT NULL,
+    buyer_name       VARCHAR(255) NOT NULL,
+    buyer_email      VARCHAR(255) NOT NULL,
+    buyer_tax_id     VARCHAR(20) NULL,
+    buyer_address    VARCHAR(500) NULL,
+    tax_rate_id      CHAR(36) NOT NULL,
+    tax_name         VARCHAR(50) NOT NULL,
+    tax_rate         DECIMAL(7,4) NOT NULL,
+    base_numerator   INT NOT NULL,
+    base_denominator INT NOT NULL,
+    subtotal         DECIMAL(12,2) NOT NULL,
+    tax_base         DECIMAL(12,2) NOT NULL,
+    tax_amount       DECIMAL(12,2) NOT NULL,
+    total            DECIMAL(12,2) NOT NULL,
+    file_key         VARCHAR(255) NULL,
+    issued_at        TIMESTAMP NOT NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_invoices_number (number),
+    UNIQUE KEY uk_invoices_period_sequence (period, sequence),
+    UNIQUE KEY uk_invoices_payment (payment_id),
+    INDEX idx_invoices_student (student_id),
+    INDEX idx_invoices_issued_at (issued_at),
+    CONSTRAINT fk_invoices_payment FOREIGN KEY (payment_id) REFERENCES payments(id),
+    CONSTRAINT fk_invoices_tax_rate FOREIGN KEY (tax_rate_id) REFERENCES tax_rates(id)
+);
+
+CREATE TABLE invoice_items (
+    id           CHAR(36) PRIMARY KEY,
+    invoice_id   CHAR(36) NOT NULL,
+    description  VARCHAR(255) NOT NULL,
+    quantity     INT NOT NULL,
+    unit_price   DECIMAL(12,2) NOT NULL,
+    amount       DECIMAL(12,2) NOT NULL,
+    tax_base     DECIMAL(12,2) NOT NULL,
+    tax_amount   DECIMAL(12,2) NOT NULL,
+    period_start TIMESTAMP NULL,
+    period_end   TIMESTAMP NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_invoice_items_invoice (invoice_id),
+    CONSTRAINT fk_invoice_items_invoice FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
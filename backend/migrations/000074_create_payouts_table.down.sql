@@ -0,0 +1,19 @@
+ALTER TABLE withdrawal_requests
+    DROP FOREIGN KEY fk_withdrawal_requests_payout_batch,
+    DROP FOREIGN KEY fk_withdrawal_requests_bank_account,
+    DROP INDEX idx_withdrawal_requests_disbursement,
+    DROP INDEX idx_withdrawal_requests_status,
+    DROP COLUMN failure_code,
+    DROP COLUMN disbursement_id,
+    DROP COLUMN payout_batch_id,
+    DROP COLUMN bank_code,
+    DROP COLUMN bank_account_id;
+
+UPDATE withdrawal_requests SET status = 'processing' WHERE status = 'approved';
+UPDATE withdrawal_requests SET status = 'rejected' WHERE status = 'failed';
+
+ALTER TABLE withdrawal_requests
+    MODIFY COLUMN status ENUM('pending', 'processing', 'completed', 'rejected') DEFAULT 'pending';
+
+DROP TABLE IF EXISTS payout_batches;
+DROP TABLE IF EXISTS mentor_bank_accounts;
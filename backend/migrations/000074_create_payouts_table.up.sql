@@ -0,0 +1,42 @@
+CREATE TABLE mentor_bank_accounts (
+    id              CHAR(36) PRIMARY KEY,
+    tutor_id        CHAR(36) NOT NULL,
+    bank_code       VARCHAR(50) NOT NULL,
+    account_number  VARCHAR(50) NOT NULL,
+    account_name    VARCHAR(100) NOT NULL,
+    is_default      BOOLEAN NOT NULL DEFAULT FALSE,
+    verified_at     TIMESTAMP NULL,
+    verified_by     CHAR(36) NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_mentor_bank_accounts_account (tutor_id, bank_code, account_number),
+    CONSTRAINT fk_mentor_bank_accounts_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE,
+    CONSTRAINT fk_mentor_bank_accounts_admin FOREIGN KEY (verified_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE payout_batches (
+    id            CHAR(36) PRIMARY KEY,
+    status        VARCHAR(50) NOT NULL,
+    total_count   INT NOT NULL DEFAULT 0,
+    total_amount  DECIMAL(15,2) NOT NULL DEFAULT 0,
+    sent_at       TIMESTAMP NULL,
+    completed_at  TIMESTAMP NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_by    CHAR(36) NOT NULL,
+
+    INDEX idx_payout_batches_status (status)
+);
+
+ALTER TABLE withdrawal_requests
+    MODIFY COLUMN status ENUM('pending', 'approved', 'processing', 'completed', 'rejected', 'failed') DEFAULT 'pending',
+    ADD COLUMN bank_account_id CHAR(36) NULL AFTER amount,
+    ADD COLUMN bank_code VARCHAR(50) NULL AFTER bank_account_id,
+    ADD COLUMN payout_batch_id CHAR(36) NULL AFTER admin_note,
+    ADD COLUMN disbursement_id VARCHAR(255) NULL AFTER payout_batch_id,
+    ADD COLUMN failure_code VARCHAR(255) NULL AFTER disbursement_id,
+    ADD INDEX idx_withdrawal_requests_status (status),
+    ADD INDEX idx_withdrawal_requests_disbursement (disbursement_id),
+    ADD CONSTRAINT fk_withdrawal_requests_bank_account FOREIGN KEY (bank_account_id) REFERENCES mentor_bank_accounts(id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_withdrawal_requests_payout_batch FOREIGN KEY (payout_batch_id) REFERENCES payout_batches(id) ON DELETE SET NULL;
@@ -0,0 +1,28 @@
+ALTER TABLE withdrawal_requests
+    DROP COLUMN currency;
+
+ALTER TABLE balance_transactions
+    DROP COLUMN exchange_rate,
+    DROP COLUMN original_currency,
+    DROP COLUMN original_amount,
+    DROP COLUMN currency;
+
+ALTER TABLE mentor_balances
+    DROP COLUMN currency;
+
+ALTER TABLE invoices
+    DROP COLUMN currency;
+
+ALTER TABLE payments
+    DROP COLUMN currency;
+
+ALTER TABLE course_prices
+    DROP COLUMN currency;
+
+ALTER TABLE courses
+    DROP COLUMN currency;
+
+ALTER TABLE users
+    DROP COLUMN currency;
+
+DROP TABLE IF EXISTS exchange_rates;
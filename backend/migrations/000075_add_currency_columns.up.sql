@@ -0,0 +1,40 @@
+CREATE TABLE exchange_rates (
+    id          CHAR(36) PRIMARY KEY,
+    currency    CHAR(3) NOT NULL,
+    rate        DECIMAL(20,6) NOT NULL,
+    rate_date   DATE NOT NULL,
+    source      VARCHAR(20) NOT NULL,
+    created_by  CHAR(36) NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_exchange_rates_currency_date (currency, rate_date),
+    CONSTRAINT fk_exchange_rates_admin FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+ALTER TABLE users
+    ADD COLUMN currency CHAR(3) NULL AFTER phone_number;
+
+ALTER TABLE courses
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER price;
+
+ALTER TABLE course_prices
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER price;
+
+ALTER TABLE payments
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER amount;
+
+ALTER TABLE invoices
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER tax_rate_id;
+
+ALTER TABLE mentor_balances
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER balance;
+
+ALTER TABLE balance_transactions
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER commission,
+    ADD COLUMN original_amount DECIMAL(15,2) NULL AFTER currency,
+    ADD COLUMN original_currency CHAR(3) NULL AFTER original_amount,
+    ADD COLUMN exchange_rate DECIMAL(20,10) NULL AFTER original_currency;
+
+ALTER TABLE withdrawal_requests
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER amount;
@@ -0,0 +1,30 @@
+ALTER TABLE invoices
+    DROP INDEX idx_invoices_guardian,
+    DROP COLUMN guardian_id;
+
+DELETE FROM invoices WHERE student_id IS NULL;
+
+ALTER TABLE invoices
+    MODIFY COLUMN student_id CHAR(36) NOT NULL;
+
+DROP TABLE IF EXISTS gift_codes;
+DROP TABLE IF EXISTS payment_beneficiaries;
+
+ALTER TABLE payments
+    DROP FOREIGN KEY fk_payments_guardian,
+    DROP INDEX idx_payments_guardian,
+    DROP COLUMN seats,
+    DROP COLUMN type,
+    DROP COLUMN guardian_id;
+
+DELETE FROM payment_refunds WHERE payment_id IN (SELECT id FROM payments WHERE student_id IS NULL);
+DELETE FROM payments WHERE student_id IS NULL;
+
+ALTER TABLE payments
+    MODIFY COLUMN student_id CHAR(36) NOT NULL;
+
+DROP TABLE IF EXISTS guardian_students;
+DROP TABLE IF EXISTS guardians;
+
+DELETE FROM user_roles WHERE role_id = '5c0f3b7e-8d4a-4f6e-9b2c-1a7d3e9f0c64';
+DELETE FROM roles WHERE id = '5c0f3b7e-8d4a-4f6e-9b2c-1a7d3e9f0c64';
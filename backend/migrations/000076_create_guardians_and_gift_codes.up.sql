@@ -0,0 +1,79 @@
+INSERT INTO roles (id, name)
+VALUES ('5c0f3b7e-8d4a-4f6e-9b2c-1a7d3e9f0c64', 'guardian');
+
+CREATE TABLE guardians (
+    id          CHAR(36) PRIMARY KEY,
+    user_id     CHAR(36) NOT NULL,
+    customer_id VARCHAR(255) NULL,
+    tax_id      VARCHAR(20) NULL,
+    tax_name    VARCHAR(255) NULL,
+    tax_address VARCHAR(500) NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP NULL,
+    created_by  CHAR(36) NULL,
+    updated_by  CHAR(36) NULL,
+
+    UNIQUE KEY uk_guardians_user (user_id),
+    CONSTRAINT fk_guardians_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- A guardian may only act for a student once the student accepted the link.
+CREATE TABLE guardian_students (
+    id          CHAR(36) PRIMARY KEY,
+    guardian_id CHAR(36) NOT NULL,
+    student_id  CHAR(36) NOT NULL,
+    status      ENUM('pending', 'active') NOT NULL DEFAULT 'pending',
+    accepted_at TIMESTAMP NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_guardian_students (guardian_id, student_id),
+    INDEX idx_guardian_students_student (student_id),
+    CONSTRAINT fk_guardian_students_guardian FOREIGN KEY (guardian_id) REFERENCES guardians(id) ON DELETE CASCADE,
+    CONSTRAINT fk_guardian_students_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE
+);
+
+-- Payments made by a guardian have no student, the students they cover are
+-- kept in payment_beneficiaries.
+ALTER TABLE payments
+    MODIFY COLUMN student_id CHAR(36) NULL,
+    ADD COLUMN guardian_id CHAR(36) NULL AFTER student_id,
+    ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'premium' AFTER invoice_number,
+    ADD COLUMN seats INT NOT NULL DEFAULT 1 AFTER interval_count,
+    ADD INDEX idx_payments_guardian (guardian_id),
+    ADD CONSTRAINT fk_payments_guardian FOREIGN KEY (guardian_id) REFERENCES guardians(id);
+
+CREATE TABLE payment_beneficiaries (
+    payment_id CHAR(36) NOT NULL,
+    student_id CHAR(36) NOT NULL,
+
+    PRIMARY KEY (payment_id, student_id),
+    INDEX idx_payment_beneficiaries_student (student_id),
+    CONSTRAINT fk_payment_beneficiaries_payment FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_payment_beneficiaries_student FOREIGN KEY (student_id) REFERENCES students(id)
+);
+
+CREATE TABLE gift_codes (
+    id          CHAR(36) PRIMARY KEY,
+    code        VARCHAR(20) NOT NULL,
+    payment_id  CHAR(36) NOT NULL,
+    guardian_id CHAR(36) NOT NULL,
+    status      ENUM('pending', 'active', 'redeemed', 'canceled') NOT NULL DEFAULT 'pending',
+    redeemed_by CHAR(36) NULL,
+    redeemed_at TIMESTAMP NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_gift_codes_code (code),
+    INDEX idx_gift_codes_payment (payment_id),
+    INDEX idx_gift_codes_guardian (guardian_id),
+    CONSTRAINT fk_gift_codes_payment FOREIGN KEY (payment_id) REFERENCES payments(id),
+    CONSTRAINT fk_gift_codes_guardian FOREIGN KEY (guardian_id) REFERENCES guardians(id),
+    CONSTRAINT fk_gift_codes_student FOREIGN KEY (redeemed_by) REFERENCES students(id)
+);
+
+ALTER TABLE invoices
+    MODIFY COLUMN student_id CHAR(36) NULL,
+    ADD COLUMN guardian_id CHAR(36) NULL AFTER student_id,
+    ADD INDEX idx_invoices_guardian (guardian_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS guardian_invites;
+
+ALTER TABLE guardian_students
+    DROP INDEX uk_guardian_students_token,
+    DROP COLUMN last_report_period,
+    DROP COLUMN token_expires_at,
+    DROP COLUMN confirmation_token;
@@ -0,0 +1,26 @@
+-- A link requested by email is confirmed through the token sent to the
+-- student, last_report_period keeps the monthly report from being emailed
+-- twice.
+ALTER TABLE guardian_students
+    ADD COLUMN confirmation_token VARCHAR(64) NULL AFTER status,
+    ADD COLUMN token_expires_at TIMESTAMP NULL AFTER confirmation_token,
+    ADD COLUMN last_report_period CHAR(6) NULL AFTER accepted_at,
+    ADD UNIQUE KEY uk_guardian_students_token (confirmation_token);
+
+-- Invite codes are created by the student, a guardian entering one is linked
+-- right away.
+CREATE TABLE guardian_invites (
+    id          CHAR(36) PRIMARY KEY,
+    student_id  CHAR(36) NOT NULL,
+    code        VARCHAR(20) NOT NULL,
+    expires_at  TIMESTAMP NOT NULL,
+    used_by     CHAR(36) NULL,
+    used_at     TIMESTAMP NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_guardian_invites_code (code),
+    INDEX idx_guardian_invites_student (student_id),
+    CONSTRAINT fk_guardian_invites_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_guardian_invites_guardian FOREIGN KEY (used_by) REFERENCES guardians(id) ON DELETE SET NULL
+);
@@ -0,0 +1,22 @@
+-- Monthly reports are generated by a worker and stored in the bucket, one per
+-- student and period (YYYYMM). Requesting a closed period again returns the
+-- stored report.
+CREATE TABLE monthly_reports (
+    id           CHAR(36) PRIMARY KEY,
+    student_id   CHAR(36) NOT NULL,
+    period       CHAR(6) NOT NULL,
+    status       ENUM('queued', 'processing', 'completed', 'failed') NOT NULL DEFAULT 'queued',
+    file_key     VARCHAR(255) NULL,
+    filename     VARCHAR(255) NULL,
+    error        TEXT NULL,
+    deliver      TINYINT(1) NOT NULL DEFAULT 0,
+    requested_by CHAR(36) NULL,
+    generated_at TIMESTAMP NULL,
+    delivered_at TIMESTAMP NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_monthly_reports_student_period (student_id, period),
+    INDEX idx_monthly_reports_status (status, updated_at),
+    CONSTRAINT fk_monthly_reports_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE
+);
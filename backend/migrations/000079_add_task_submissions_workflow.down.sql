@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS task_submission_files;
+
+ALTER TABLE task_submissions
+    DROP INDEX idx_task_submissions_status,
+    DROP COLUMN graded_by,
+    DROP COLUMN graded_at,
+    DROP COLUMN feedback,
+    DROP COLUMN rubric_scores,
+    DROP COLUMN is_late,
+    DROP COLUMN submitted_at,
+    DROP COLUMN status;
+
+ALTER TABLE session_tasks
+    DROP COLUMN rubric,
+    DROP COLUMN due_at;
@@ -0,0 +1,38 @@
+-- Tasks get a due date and an optional rubric, a JSON list of criteria with
+-- their maximum points. Students submit their work as one or more files and
+-- may resubmit until the task is due or graded.
+ALTER TABLE session_tasks
+    ADD COLUMN due_at TIMESTAMP NULL AFTER attachment_url,
+    ADD COLUMN rubric JSON NULL AFTER due_at;
+
+ALTER TABLE task_submissions
+    ADD COLUMN status ENUM('submitted', 'graded') NOT NULL DEFAULT 'submitted' AFTER session_task_id,
+    ADD COLUMN submitted_at TIMESTAMP NULL AFTER score,
+    ADD COLUMN is_late TINYINT(1) NOT NULL DEFAULT 0 AFTER submitted_at,
+    ADD COLUMN rubric_scores JSON NULL AFTER is_late,
+    ADD COLUMN feedback TEXT NULL AFTER rubric_scores,
+    ADD COLUMN graded_at TIMESTAMP NULL AFTER feedback,
+    ADD COLUMN graded_by CHAR(36) NULL AFTER graded_at,
+    ADD INDEX idx_task_submissions_status (status, submitted_at);
+
+-- Submissions written by tutors so far always carry the score
+UPDATE task_submissions
+SET status = 'graded', submitted_at = created_at, graded_at = updated_at
+WHERE score IS NOT NULL;
+
+UPDATE task_submissions
+SET submitted_at = created_at
+WHERE submitted_at IS NULL;
+
+CREATE TABLE task_submission_files (
+    id                 CHAR(36) PRIMARY KEY,
+    task_submission_id CHAR(36) NOT NULL,
+    url                VARCHAR(255) NOT NULL,
+    file_key           VARCHAR(255) NOT NULL,
+    filename           VARCHAR(255) NOT NULL,
+    size               BIGINT NOT NULL DEFAULT 0,
+    created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_task_submission_files_submission (task_submission_id),
+    CONSTRAINT fk_task_submission_files_submission FOREIGN KEY (task_submission_id) REFERENCES task_submissions(id) ON DELETE CASCADE
+);
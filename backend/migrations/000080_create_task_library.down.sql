@@ -0,0 +1,6 @@
+ALTER TABLE session_tasks
+    DROP INDEX idx_session_tasks_template,
+    DROP COLUMN task_template_id;
+
+DROP TABLE IF EXISTS task_templates;
+DROP TABLE IF EXISTS task_rubrics;
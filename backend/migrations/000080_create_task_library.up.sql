@@ -0,0 +1,42 @@
+-- Tutors keep a library of grading rubrics and task templates, optionally
+-- organised by course and sub course category. Assigning a template copies
+-- it into a session task, so later edits to the library don't change tasks
+-- already given.
+CREATE TABLE task_rubrics (
+    id                     CHAR(36) PRIMARY KEY,
+    tutor_id               CHAR(36) NOT NULL,
+    course_id              CHAR(36) NULL,
+    sub_course_category_id CHAR(36) NULL,
+    name                   VARCHAR(255) NOT NULL,
+    description            TEXT NULL,
+    criteria               JSON NOT NULL,
+    created_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at             TIMESTAMP NULL,
+
+    INDEX idx_task_rubrics_tutor (tutor_id, deleted_at),
+    CONSTRAINT fk_task_rubrics_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE task_templates (
+    id                     CHAR(36) PRIMARY KEY,
+    tutor_id               CHAR(36) NOT NULL,
+    course_id              CHAR(36) NULL,
+    sub_course_category_id CHAR(36) NULL,
+    task_rubric_id         CHAR(36) NULL,
+    title                  VARCHAR(255) NOT NULL,
+    description            TEXT NULL,
+    attachment_url         VARCHAR(255) NULL,
+    due_days               INT NULL,
+    created_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at             TIMESTAMP NULL,
+
+    INDEX idx_task_templates_tutor (tutor_id, deleted_at),
+    CONSTRAINT fk_task_templates_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_templates_rubric FOREIGN KEY (task_rubric_id) REFERENCES task_rubrics(id) ON DELETE SET NULL
+);
+
+ALTER TABLE session_tasks
+    ADD COLUMN task_template_id CHAR(36) NULL AFTER booking_id,
+    ADD INDEX idx_session_tasks_template (task_template_id, booking_id);
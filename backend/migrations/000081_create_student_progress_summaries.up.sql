@@ -0,0 +1,21 @@
+-- Progress of a student with a tutor in a subject (course category) per
+-- month, materialised from bookings and task submissions by a periodic job
+-- so analytics don't aggregate the raw tables on every request.
+CREATE TABLE student_progress_summaries (
+    id                 CHAR(36) PRIMARY KEY,
+    student_id         CHAR(36) NOT NULL,
+    tutor_id           CHAR(36) NOT NULL,
+    course_category_id CHAR(36) NOT NULL,
+    period             CHAR(6) NOT NULL,
+    sessions_scheduled INT NOT NULL DEFAULT 0,
+    sessions_attended  INT NOT NULL DEFAULT 0,
+    tasks_total        INT NOT NULL DEFAULT 0,
+    tasks_submitted    INT NOT NULL DEFAULT 0,
+    scores_count       INT NOT NULL DEFAULT 0,
+    score_sum          DECIMAL(12,2) NOT NULL DEFAULT 0,
+    computed_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_student_progress_summaries (student_id, tutor_id, course_category_id, period),
+    INDEX idx_student_progress_summaries_tutor (tutor_id, period),
+    INDEX idx_student_progress_summaries_period (period, computed_at)
+);
@@ -0,0 +1,8 @@
+UPDATE bookings
+SET status = 'accepted'
+WHERE status IN ('completed', 'no_show');
+
+DROP TABLE IF EXISTS booking_attendances;
+
+ALTER TABLE bookings
+    DROP COLUMN completed_at;
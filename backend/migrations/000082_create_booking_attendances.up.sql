@@ -0,0 +1,40 @@
+-- Accepted bookings now end as completed or no_show. Sessions are completed
+-- by the check-out of the tutor after both parties checked in, a no-show is
+-- reported by the party who turned up and may be disputed until dispute_until.
+ALTER TABLE bookings
+    ADD COLUMN completed_at TIMESTAMP NULL AFTER is_reviewed;
+
+CREATE TABLE booking_attendances (
+    id                    CHAR(36) PRIMARY KEY,
+    booking_id            CHAR(36) NOT NULL,
+    tutor_check_in_at     TIMESTAMP NULL,
+    tutor_check_out_at    TIMESTAMP NULL,
+    tutor_latitude        DECIMAL(8, 6) NULL,
+    tutor_longitude       DECIMAL(9, 6) NULL,
+    student_check_in_at   TIMESTAMP NULL,
+    student_check_out_at  TIMESTAMP NULL,
+    student_latitude      DECIMAL(8, 6) NULL,
+    student_longitude     DECIMAL(9, 6) NULL,
+    duration_minutes      INT NULL,
+    no_show_party         ENUM('tutor', 'student') NULL,
+    no_show_reason        TEXT NULL,
+    no_show_reported_by   CHAR(36) NULL,
+    no_show_reported_at   TIMESTAMP NULL,
+    dispute_until         TIMESTAMP NULL,
+    disputed_at           TIMESTAMP NULL,
+    dispute_reason        TEXT NULL,
+    resolved_at           TIMESTAMP NULL,
+    resolved_by           CHAR(36) NULL,
+    created_at            TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uq_booking_attendances_booking (booking_id),
+    INDEX idx_booking_attendances_dispute (disputed_at, resolved_at),
+    CONSTRAINT fk_booking_attendances_booking FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE
+);
+
+-- Reviews were requested for sessions assumed to have happened, they are
+-- the completed ones so far.
+UPDATE bookings
+SET status = 'completed', completed_at = TIMESTAMP(booking_date, booking_time)
+WHERE status = 'accepted' AND is_reviewed = 1;
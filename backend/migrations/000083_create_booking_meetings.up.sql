@@ -0,0 +1,18 @@
+-- Online sessions get a video classroom when the booking is accepted. The
+-- room stays open until expires_at, expired_at marks it closed at the
+-- provider.
+CREATE TABLE booking_meetings (
+    id          CHAR(36) PRIMARY KEY,
+    booking_id  CHAR(36) NOT NULL,
+    provider    VARCHAR(20) NOT NULL,
+    room_id     VARCHAR(255) NOT NULL,
+    join_url    TEXT NOT NULL,
+    expires_at  TIMESTAMP NOT NULL,
+    expired_at  TIMESTAMP NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uq_booking_meetings_booking (booking_id),
+    INDEX idx_booking_meetings_expiry (expired_at, expires_at),
+    CONSTRAINT fk_booking_meetings_booking FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE
+);
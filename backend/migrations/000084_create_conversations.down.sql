@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS conversation_reports;
+DROP TABLE IF EXISTS conversation_message_attachments;
+DROP TABLE IF EXISTS conversation_messages;
+DROP TABLE IF EXISTS conversations;
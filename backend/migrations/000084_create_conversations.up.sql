@@ -0,0 +1,64 @@
+-- Conversations are threads between a tutor and a student, optionally about
+-- one booking. Contact details in messages are masked until the pair has a
+-- confirmed booking. Admins read a thread through the reports filed on it.
+CREATE TABLE conversations (
+    id                    CHAR(36) PRIMARY KEY,
+    tutor_id              CHAR(36) NOT NULL,
+    student_id            CHAR(36) NOT NULL,
+    booking_id            CHAR(36) NULL,
+    last_message_at       TIMESTAMP NULL,
+    last_message_preview  VARCHAR(255) NULL,
+    created_by            CHAR(36) NOT NULL,
+    created_at            TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_conversations_tutor (tutor_id, last_message_at),
+    INDEX idx_conversations_student (student_id, last_message_at),
+    CONSTRAINT fk_conversations_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE,
+    CONSTRAINT fk_conversations_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_conversations_booking FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE SET NULL
+);
+
+CREATE TABLE conversation_messages (
+    id               CHAR(36) PRIMARY KEY,
+    conversation_id  CHAR(36) NOT NULL,
+    sender_id        CHAR(36) NOT NULL,
+    body             TEXT NULL,
+    is_masked        TINYINT(1) NOT NULL DEFAULT 0,
+    read_at          TIMESTAMP NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_conversation_messages_conversation (conversation_id, created_at),
+    INDEX idx_conversation_messages_unread (conversation_id, sender_id, read_at),
+    CONSTRAINT fk_conversation_messages_conversation FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE
+);
+
+CREATE TABLE conversation_message_attachments (
+    id          CHAR(36) PRIMARY KEY,
+    message_id  CHAR(36) NOT NULL,
+    url         VARCHAR(255) NOT NULL,
+    file_key    VARCHAR(255) NOT NULL,
+    filename    VARCHAR(255) NOT NULL,
+    size        BIGINT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_conversation_message_attachments_message (message_id),
+    CONSTRAINT fk_conversation_message_attachments_message FOREIGN KEY (message_id) REFERENCES conversation_messages(id) ON DELETE CASCADE
+);
+
+CREATE TABLE conversation_reports (
+    id               CHAR(36) PRIMARY KEY,
+    conversation_id  CHAR(36) NOT NULL,
+    reporter_id      CHAR(36) NOT NULL,
+    reason           VARCHAR(500) NOT NULL,
+    status           ENUM('pending', 'resolved', 'dismissed') NOT NULL DEFAULT 'pending',
+    resolution_note  TEXT NULL,
+    resolved_at      TIMESTAMP NULL,
+    resolved_by      CHAR(36) NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_conversation_reports_status (status, created_at),
+    INDEX idx_conversation_reports_conversation (conversation_id),
+    CONSTRAINT fk_conversation_reports_conversation FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+DELETE FROM mentor_invite_codes WHERE group_id IS NOT NULL;
+
+ALTER TABLE mentor_invite_codes ADD UNIQUE KEY uniq_mentor_invite_tutor (tutor_id);
+
+ALTER TABLE mentor_invite_codes
+    DROP FOREIGN KEY fk_mentor_invite_codes_group,
+    DROP INDEX idx_mentor_invite_codes_group,
+    DROP INDEX idx_mentor_invite_codes_tutor,
+    DROP COLUMN revoked_at,
+    DROP COLUMN used_count,
+    DROP COLUMN max_uses,
+    DROP COLUMN expires_at,
+    DROP COLUMN group_id;
+
+DROP TABLE IF EXISTS mentor_group_announcements;
+DROP TABLE IF EXISTS mentor_group_members;
+DROP TABLE IF EXISTS mentor_groups;
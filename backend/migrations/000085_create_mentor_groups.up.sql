@@ -0,0 +1,54 @@
+-- Mentor groups split the students of a tutor into classes, each with its
+-- own invite codes and announcements. Students stay mentored by the tutor
+-- through mentor_students whichever groups they are in.
+CREATE TABLE mentor_groups (
+    id           CHAR(36) PRIMARY KEY,
+    tutor_id     CHAR(36) NOT NULL,
+    name         VARCHAR(100) NOT NULL,
+    description  TEXT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_mentor_groups_tutor (tutor_id),
+    CONSTRAINT fk_mentor_groups_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE mentor_group_members (
+    id          CHAR(36) PRIMARY KEY,
+    group_id    CHAR(36) NOT NULL,
+    student_id  CHAR(36) NOT NULL,
+    joined_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uniq_mentor_group_member (group_id, student_id),
+    INDEX idx_mentor_group_members_student (student_id),
+    CONSTRAINT fk_mentor_group_members_group FOREIGN KEY (group_id) REFERENCES mentor_groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_mentor_group_members_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE
+);
+
+CREATE TABLE mentor_group_announcements (
+    id          CHAR(36) PRIMARY KEY,
+    group_id    CHAR(36) NOT NULL,
+    tutor_id    CHAR(36) NOT NULL,
+    title       VARCHAR(255) NOT NULL,
+    message     TEXT NOT NULL,
+    recipients  INT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_mentor_group_announcements_group (group_id, created_at),
+    CONSTRAINT fk_mentor_group_announcements_group FOREIGN KEY (group_id) REFERENCES mentor_groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_mentor_group_announcements_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE
+);
+
+-- The code without a group stays the single general code of the tutor,
+-- groups can have any number of codes limited by expiry and uses.
+ALTER TABLE mentor_invite_codes
+    ADD COLUMN group_id CHAR(36) NULL AFTER tutor_id,
+    ADD COLUMN expires_at TIMESTAMP NULL AFTER code,
+    ADD COLUMN max_uses INT NULL AFTER expires_at,
+    ADD COLUMN used_count INT NOT NULL DEFAULT 0 AFTER max_uses,
+    ADD COLUMN revoked_at TIMESTAMP NULL AFTER used_count,
+    ADD INDEX idx_mentor_invite_codes_tutor (tutor_id),
+    ADD INDEX idx_mentor_invite_codes_group (group_id),
+    ADD CONSTRAINT fk_mentor_invite_codes_group FOREIGN KEY (group_id) REFERENCES mentor_groups(id) ON DELETE CASCADE;
+
+ALTER TABLE mentor_invite_codes DROP INDEX uniq_mentor_invite_tutor;
@@ -0,0 +1,13 @@
+ALTER TABLE bookings
+    DROP FOREIGN KEY fk_bookings_group_session,
+    DROP INDEX idx_bookings_group_session,
+    DROP COLUMN group_session_id;
+
+DROP TABLE IF EXISTS group_sessions;
+
+ALTER TABLE course_prices DROP COLUMN session_type;
+
+ALTER TABLE course_schedules
+    DROP COLUMN min_attendees,
+    DROP COLUMN capacity,
+    DROP COLUMN session_type;
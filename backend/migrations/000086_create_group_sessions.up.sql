@@ -0,0 +1,43 @@
+-- Group schedules let several students book the same slot at a per-seat
+-- price. The group session of a slot is created with its first seat and is
+-- confirmed once min_attendees seats are taken, or cancelled at cutoff_at.
+-- Sessions are keyed by slot rather than schedule because schedules are
+-- recreated whenever the course is edited.
+ALTER TABLE course_schedules
+    ADD COLUMN session_type VARCHAR(20) NOT NULL DEFAULT 'private' AFTER class_type,
+    ADD COLUMN capacity INT NULL AFTER session_type,
+    ADD COLUMN min_attendees INT NULL AFTER capacity;
+
+ALTER TABLE course_prices
+    ADD COLUMN session_type VARCHAR(20) NOT NULL DEFAULT 'private' AFTER class_type;
+
+CREATE TABLE group_sessions (
+    id             CHAR(36) PRIMARY KEY,
+    course_id      CHAR(36) NOT NULL,
+    tutor_id       CHAR(36) NOT NULL,
+    class_type     VARCHAR(20) NOT NULL,
+    session_date   DATE NOT NULL,
+    session_time   TIME NOT NULL,
+    timezone       VARCHAR(50) NOT NULL,
+    capacity       INT NOT NULL,
+    min_attendees  INT NOT NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'open',
+    cutoff_at      TIMESTAMP NOT NULL,
+    confirmed_at   TIMESTAMP NULL,
+    cancelled_at   TIMESTAMP NULL,
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uq_group_sessions_slot (course_id, class_type, session_date, session_time),
+    INDEX idx_group_sessions_tutor (tutor_id, session_date),
+    INDEX idx_group_sessions_cutoff (status, cutoff_at),
+    CONSTRAINT fk_group_sessions_course FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_group_sessions_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE
+);
+
+-- A seat in a group session is a booking of the student like any other so
+-- attendance, tasks and reports keep working per student.
+ALTER TABLE bookings
+    ADD COLUMN group_session_id CHAR(36) NULL AFTER course_id,
+    ADD INDEX idx_bookings_group_session (group_session_id),
+    ADD CONSTRAINT fk_bookings_group_session FOREIGN KEY (group_session_id) REFERENCES group_sessions(id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS lesson_note_milestones;
+DROP TABLE IF EXISTS lesson_notes;
+DROP TABLE IF EXISTS learning_plan_milestones;
+DROP TABLE IF EXISTS learning_plans;
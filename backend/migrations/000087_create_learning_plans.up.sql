@@ -0,0 +1,70 @@
+-- A learning plan sets the goals of a student in one subject with a tutor,
+-- as milestones with target dates the tutor moves along as the student
+-- progresses.
+CREATE TABLE learning_plans (
+    id                  CHAR(36) PRIMARY KEY,
+    student_id          CHAR(36) NOT NULL,
+    tutor_id            CHAR(36) NOT NULL,
+    course_category_id  CHAR(36) NOT NULL,
+    title               VARCHAR(255) NOT NULL,
+    description         TEXT NULL,
+    status              VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uniq_learning_plan_subject (student_id, tutor_id, course_category_id),
+    INDEX idx_learning_plans_tutor (tutor_id),
+    CONSTRAINT fk_learning_plans_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_learning_plans_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE,
+    CONSTRAINT fk_learning_plans_course_category FOREIGN KEY (course_category_id) REFERENCES course_categories(id)
+);
+
+CREATE TABLE learning_plan_milestones (
+    id           CHAR(36) PRIMARY KEY,
+    plan_id      CHAR(36) NOT NULL,
+    title        VARCHAR(255) NOT NULL,
+    description  TEXT NULL,
+    target_date  DATE NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'not_started',
+    position     INT NOT NULL DEFAULT 0,
+    achieved_at  TIMESTAMP NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_learning_plan_milestones_plan (plan_id, position),
+    CONSTRAINT fk_learning_plan_milestones_plan FOREIGN KEY (plan_id) REFERENCES learning_plans(id) ON DELETE CASCADE
+);
+
+-- The tutor writes one lesson note per session, replacing the free text
+-- notes, and links it to the milestones the session worked on.
+CREATE TABLE lesson_notes (
+    id            CHAR(36) PRIMARY KEY,
+    booking_id    CHAR(36) NOT NULL,
+    plan_id       CHAR(36) NULL,
+    tutor_id      CHAR(36) NOT NULL,
+    student_id    CHAR(36) NOT NULL,
+    covered       TEXT NOT NULL,
+    strengths     TEXT NULL,
+    improvements  TEXT NULL,
+    next_steps    TEXT NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uniq_lesson_note_booking (booking_id),
+    INDEX idx_lesson_notes_plan (plan_id, created_at),
+    INDEX idx_lesson_notes_student (student_id, created_at),
+    CONSTRAINT fk_lesson_notes_booking FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+    CONSTRAINT fk_lesson_notes_plan FOREIGN KEY (plan_id) REFERENCES learning_plans(id) ON DELETE SET NULL,
+    CONSTRAINT fk_lesson_notes_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE,
+    CONSTRAINT fk_lesson_notes_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE
+);
+
+CREATE TABLE lesson_note_milestones (
+    note_id       CHAR(36) NOT NULL,
+    milestone_id  CHAR(36) NOT NULL,
+
+    PRIMARY KEY (note_id, milestone_id),
+    INDEX idx_lesson_note_milestones_milestone (milestone_id),
+    CONSTRAINT fk_lesson_note_milestones_note FOREIGN KEY (note_id) REFERENCES lesson_notes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_lesson_note_milestones_milestone FOREIGN KEY (milestone_id) REFERENCES learning_plan_milestones(id) ON DELETE CASCADE
+);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS quiz_attempt_answers;
+DROP TABLE IF EXISTS quiz_attempts;
+
+ALTER TABLE session_tasks
+    DROP INDEX idx_session_tasks_quiz,
+    DROP COLUMN quiz_id;
+
+DROP TABLE IF EXISTS quiz_items;
+DROP TABLE IF EXISTS quizzes;
+DROP TABLE IF EXISTS quiz_questions;
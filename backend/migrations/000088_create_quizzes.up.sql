@@ -0,0 +1,88 @@
+-- Tutors keep a bank of objective questions, tagged by sub course category
+-- and level of education, and assemble them into quizzes. A quiz attached to
+-- a session task is taken by the student within its time limit and graded
+-- automatically into the task submission.
+CREATE TABLE quiz_questions (
+    id                     CHAR(36) PRIMARY KEY,
+    tutor_id               CHAR(36) NOT NULL,
+    sub_course_category_id CHAR(36) NULL,
+    level_of_education     VARCHAR(50) NULL,
+    type                   VARCHAR(20) NOT NULL,
+    prompt                 TEXT NOT NULL,
+    options                JSON NULL,
+    answer                 JSON NOT NULL,
+    explanation            TEXT NULL,
+    points                 DECIMAL(5,2) NOT NULL DEFAULT 1,
+    created_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at             TIMESTAMP NULL,
+
+    INDEX idx_quiz_questions_tutor (tutor_id, deleted_at),
+    CONSTRAINT fk_quiz_questions_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE quizzes (
+    id                  CHAR(36) PRIMARY KEY,
+    tutor_id            CHAR(36) NOT NULL,
+    title               VARCHAR(255) NOT NULL,
+    description         TEXT NULL,
+    time_limit_minutes  INT NULL,
+    created_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at          TIMESTAMP NULL,
+
+    INDEX idx_quizzes_tutor (tutor_id, deleted_at),
+    CONSTRAINT fk_quizzes_tutor FOREIGN KEY (tutor_id) REFERENCES tutors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE quiz_items (
+    quiz_id      CHAR(36) NOT NULL,
+    question_id  CHAR(36) NOT NULL,
+    position     INT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (quiz_id, question_id),
+    INDEX idx_quiz_items_question (question_id),
+    CONSTRAINT fk_quiz_items_quiz FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_quiz_items_question FOREIGN KEY (question_id) REFERENCES quiz_questions(id) ON DELETE CASCADE
+);
+
+ALTER TABLE session_tasks
+    ADD COLUMN quiz_id CHAR(36) NULL AFTER task_template_id,
+    ADD INDEX idx_session_tasks_quiz (quiz_id);
+
+-- A student takes the quiz of a task once. The questions are fixed when the
+-- attempt starts, one answer row each, filled in as the student answers.
+CREATE TABLE quiz_attempts (
+    id               CHAR(36) PRIMARY KEY,
+    session_task_id  CHAR(36) NOT NULL,
+    quiz_id          CHAR(36) NOT NULL,
+    student_id       CHAR(36) NOT NULL,
+    started_at       TIMESTAMP NOT NULL,
+    deadline_at      TIMESTAMP NULL,
+    submitted_at     TIMESTAMP NULL,
+    points           DECIMAL(7,2) NOT NULL DEFAULT 0,
+    max_points       DECIMAL(7,2) NOT NULL DEFAULT 0,
+    score            DECIMAL(5,2) NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uniq_quiz_attempt_task (session_task_id),
+    INDEX idx_quiz_attempts_quiz (quiz_id, submitted_at),
+    CONSTRAINT fk_quiz_attempts_task FOREIGN KEY (session_task_id) REFERENCES session_tasks(id) ON DELETE CASCADE,
+    CONSTRAINT fk_quiz_attempts_quiz FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_quiz_attempts_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE
+);
+
+CREATE TABLE quiz_attempt_answers (
+    attempt_id   CHAR(36) NOT NULL,
+    question_id  CHAR(36) NOT NULL,
+    position     INT NOT NULL DEFAULT 0,
+    answer       JSON NULL,
+    is_correct   BOOLEAN NOT NULL DEFAULT FALSE,
+    points       DECIMAL(5,2) NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (attempt_id, question_id),
+    INDEX idx_quiz_attempt_answers_question (question_id),
+    CONSTRAINT fk_quiz_attempt_answers_attempt FOREIGN KEY (attempt_id) REFERENCES quiz_attempts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_quiz_attempt_answers_question FOREIGN KEY (question_id) REFERENCES quiz_questions(id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+ALTER TABLE quiz_attempt_answers
+    DROP COLUMN max_points,
+    DROP COLUMN explanation,
+    DROP COLUMN answer_key,
+    DROP COLUMN options,
+    DROP COLUMN prompt,
+    DROP COLUMN question_type;
@@ -0,0 +1,23 @@
+-- Attempts keep the question as it was asked, so a tutor editing or deleting
+-- a question of the bank changes neither what a student taking the quiz sees
+-- nor how the attempt is graded.
+ALTER TABLE quiz_attempt_answers
+    ADD COLUMN question_type VARCHAR(20) NOT NULL DEFAULT '' AFTER position,
+    ADD COLUMN prompt        TEXT NULL AFTER question_type,
+    ADD COLUMN options       JSON NULL AFTER prompt,
+    ADD COLUMN answer_key    JSON NULL AFTER options,
+    ADD COLUMN explanation   TEXT NULL AFTER answer_key,
+    ADD COLUMN max_points    DECIMAL(5,2) NOT NULL DEFAULT 0 AFTER explanation;
+
+UPDATE quiz_attempt_answers
+JOIN quiz_questions ON quiz_questions.id = quiz_attempt_answers.question_id
+SET quiz_attempt_answers.question_type = quiz_questions.type,
+    quiz_attempt_answers.prompt        = quiz_questions.prompt,
+    quiz_attempt_answers.options       = quiz_questions.options,
+    quiz_attempt_answers.answer_key    = quiz_questions.answer,
+    quiz_attempt_answers.explanation   = quiz_questions.explanation,
+    quiz_attempt_answers.max_points    = quiz_questions.points;
+
+ALTER TABLE quiz_attempt_answers
+    MODIFY COLUMN prompt     TEXT NOT NULL,
+    MODIFY COLUMN answer_key JSON NOT NULL;
@@ -0,0 +1,4 @@
+-- The foreign key on session_task_id needs an index once the unique one is gone
+ALTER TABLE task_submissions
+    ADD INDEX idx_task_submissions_task (session_task_id),
+    DROP INDEX uniq_task_submissions_task;
@@ -0,0 +1,10 @@
+-- A task has a single submission, replaced on resubmit. Concurrent first
+-- submissions could each insert one, so only the latest is kept before the
+-- task is made unique.
+DELETE ts FROM task_submissions ts
+JOIN task_submissions latest
+    ON latest.session_task_id = ts.session_task_id
+   AND (latest.updated_at > ts.updated_at OR (latest.updated_at = ts.updated_at AND latest.id > ts.id));
+
+ALTER TABLE task_submissions
+    ADD UNIQUE KEY uniq_task_submissions_task (session_task_id);
@@ -0,0 +1,3 @@
+ALTER TABLE balance_transactions
+    DROP INDEX uniq_balance_transactions_session,
+    DROP COLUMN session_reference_id;
@@ -0,0 +1,7 @@
+-- A held session is credited to the mentor once. Withdrawals and refunds
+-- record several transactions per reference, so only session credits are
+-- made unique.
+ALTER TABLE balance_transactions
+    ADD COLUMN session_reference_id CHAR(36)
+        GENERATED ALWAYS AS (IF(reference_type = 'booking_session', reference_id, NULL)) STORED,
+    ADD UNIQUE KEY uniq_balance_transactions_session (session_reference_id);
@@ -31,8 +31,7 @@ INSERT INTO tutors (
     created_at,
     updated_at,
     created_by,
-    updated_by, 
-    level_point
+    updated_by
 )
 SELECT 
     @tutor_id,
@@ -44,7 +43,7 @@ SELECT
     'all',                  -- Class Type
     -6.200000,              -- Latitude (Jakarta)
     106.816666,             -- Longitude (Jakarta)
-    'Beginner',             -- Level
+    'Guru',                 -- Level
     'Bachelor Degree',      -- Education
     5.0,                    -- Rating
     0,                      -- Total Rating
@@ -52,8 +51,7 @@ SELECT
     NOW(),
     NOW(),
     @user_id,
-    @user_id,
-    0
+    @user_id
 FROM users 
 WHERE email = @user_email
 AND NOT EXISTS (SELECT 1 FROM tutors WHERE user_id = @user_id);
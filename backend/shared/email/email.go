@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"html"
 	"strings"
 	"text/template"
 	"time"
@@ -30,9 +31,10 @@ type EmailService interface {
 	SendReviewBookingTutor(ctx context.Context, booking model.Booking, location model.Location) error
 	SendReviewBookingStudent(ctx context.Context, booking model.Booking, location model.Location) error
 	SendSubmitReviewTutor(ctx context.Context, review model.TutorReview) error
-	SendPaymentCreatedEmail(ctx context.Context, student model.Student, payment model.Payment) error
-	SendPaymentCompletedEmail(ctx context.Context, student model.Student, payment model.Payment) error
+	SendPaymentCreatedEmail(ctx context.Context, user model.User, payment model.Payment) error
+	SendPaymentCompletedEmail(ctx context.Context, user model.User, payment model.Payment) error
 	SendEmail(ctx context.Context, to, subject, body string) error
+	SendEmailWithAttachment(ctx context.Context, to, subject, body, filename string, content []byte) error
 }
 
 // Service implements EmailService interface
@@ -130,7 +132,7 @@ func (s *Service) SendBookingCourseTutorEmail(ctx context.Context, student model
 	return s.SendEmail(ctx, tutor.Email, subject, body)
 }
 
-func (s *Service) SendPaymentCreatedEmail(ctx context.Context, student model.Student, payment model.Payment) error {
+func (s *Service) SendPaymentCreatedEmail(ctx context.Context, user model.User, payment model.Payment) error {
 	tmpl, err := template.ParseFiles("./templates/email/payment/created.html")
 	if err != nil {
 		return err
@@ -153,7 +155,7 @@ func (s *Service) SendPaymentCreatedEmail(ctx context.Context, student model.Stu
 
 	var buf bytes.Buffer
 	err = tmpl.Execute(&buf, map[string]interface{}{
-		"student_name":   student.User.Name,
+		"student_name":   user.Name,
 		"package_name":   payment.Name(),
 		"invoice_number": payment.InvoiceNumber,
 		"created_date":   monday.Format(payment.CreatedAt, "Monday, 02 Jan 2006", monday.LocaleIdID),
@@ -165,10 +167,10 @@ func (s *Service) SendPaymentCreatedEmail(ctx context.Context, student model.Stu
 		return err
 	}
 
-	return s.SendEmail(ctx, student.User.Email, "Menunggu Pembayaran User Premium", buf.String())
+	return s.SendEmail(ctx, user.Email, "Menunggu Pembayaran User Premium", buf.String())
 }
 
-func (s *Service) SendPaymentCompletedEmail(ctx context.Context, student model.Student, payment model.Payment) error {
+func (s *Service) SendPaymentCompletedEmail(ctx context.Context, user model.User, payment model.Payment) error {
 	tmpl, err := template.ParseFiles("./templates/email/payment/completed.html")
 	if err != nil {
 		return err
@@ -191,7 +193,7 @@ func (s *Service) SendPaymentCompletedEmail(ctx context.Context, student model.S
 
 	var buf bytes.Buffer
 	err = tmpl.Execute(&buf, map[string]interface{}{
-		"student_name":   student.User.Name,
+		"student_name":   user.Name,
 		"package_name":   payment.Name(),
 		"invoice_number": payment.InvoiceNumber,
 		"created_date":   monday.Format(payment.CreatedAt, "Monday, 02 Jan 2006", monday.LocaleIdID),
@@ -203,7 +205,7 @@ func (s *Service) SendPaymentCompletedEmail(ctx context.Context, student model.S
 		return err
 	}
 
-	return s.SendEmail(ctx, student.User.Email, "Pembayaran Berhasil! 🎉", buf.String())
+	return s.SendEmail(ctx, user.Email, "Pembayaran Berhasil! 🎉", buf.String())
 }
 
 func (s *Service) SendReminderExpiredBookingTutorEmail(ctx context.Context, booking model.Booking, location model.Location) error {
@@ -275,6 +277,7 @@ func (s *Service) SendReminderCourseBookingStudentEmail(ctx context.Context, boo
 		"location":     location.FullName,
 		"notes":        notes,
 		"booking_link": link,
+		"meeting_link": booking.JoinURL(),
 	})
 	if err != nil {
 		return err
@@ -377,7 +380,8 @@ func (s *Service) SendUpdateStatusBookingTutorEmail(ctx context.Context, student
 
 	var badge string
 	if booking.Status == model.BookingStatusAccepted {
-		badge = `<div class="detail-value" style="text-align: center;"><span class="status-badge status-accepted">DITERIMA</span></div>`
+		badge = `<div class="detail-value" style="text-align: center;"><span class="status-badge status-accepted">DITERIMA</span></div>` +
+			meetingLinkBlock(booking)
 	}
 
 	if booking.Status == model.BookingStatusDeclined {
@@ -403,6 +407,22 @@ func (s *Service) SendUpdateStatusBookingTutorEmail(ctx context.Context, student
 	return s.SendEmail(ctx, tutor.Email, subject, body)
 }
 
+// meetingLinkBlock returns the join link of the video classroom of an online
+// session, empty when it has none.
+func meetingLinkBlock(booking model.Booking) string {
+	link := booking.JoinURL()
+	if link == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+			<div class="info-box">
+				<strong>🎥 Link Kelas Online:</strong><br>
+				<a href="%s" style="word-break: break-all;">%s</a><br>
+				Link dapat digunakan sampai sesi selesai.
+			</div>`, link, html.EscapeString(link))
+}
+
 func (s *Service) SendUpdateStatusBookingStudentEmail(ctx context.Context, student model.User, tutor model.User, booking model.Booking, location model.Location) error {
 	link := fmt.Sprintf(s.config.Frontend.BaseURL+s.config.Frontend.BookingDetail, booking.ID)
 
@@ -441,14 +461,14 @@ func (s *Service) SendUpdateStatusBookingStudentEmail(ctx context.Context, stude
 				2. Siapkan materi atau pertanyaan yang ingin dipelajari<br>
 				3. Pastikan perangkat dan koneksi internet stabil (untuk online)<br>
 				4. Hubungi tutor via Platform atau social media yang tercantum pada dashboard.
-			</div>
+			</div>%s
 			<div style="text-align: center;">
 				<div class="button-group">
 					<a href="%s" class="cta-button">
 						BUKA DETAIL BOOKING
 					</a>
 				</div>
-			</div>`, link)
+			</div>`, meetingLinkBlock(booking), link)
 	}
 
 	if booking.Status == model.BookingStatusDeclined {
@@ -511,16 +531,29 @@ func (s *Service) SendUpdateStatusBookingStudentEmail(ctx context.Context, stude
 
 // SendEmail sends an email with the specified parameters
 func (s *Service) SendEmail(ctx context.Context, to, subject, body string) error {
+	return s.send(ctx, to, subject, body, nil)
+}
+
+// SendEmailWithAttachment sends an email with a single attached file, such as
+// a generated report.
+func (s *Service) SendEmailWithAttachment(ctx context.Context, to, subject, body, filename string, content []byte) error {
+	return s.send(ctx, to, subject, body, []*resend.Attachment{
+		{Content: content, Filename: filename},
+	})
+}
+
+func (s *Service) send(ctx context.Context, to, subject, body string, attachments []*resend.Attachment) error {
 	from := s.config.Resend.From
 	if from == "" {
 		from = "onboarding@resend.dev"
 	}
 
 	params := &resend.SendEmailRequest{
-		From:    from,
-		To:      []string{to},
-		Subject: subject,
-		Html:    body,
+		From:        from,
+		To:          []string{to},
+		Subject:     subject,
+		Html:        body,
+		Attachments: attachments,
 	}
 
 	_, err := s.resend.Emails.SendWithContext(ctx, params)
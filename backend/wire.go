@@ -10,6 +10,8 @@ import (
 	xendit "github.com/xendit/xendit-go/v7"
 
 	"github.com/lesprivate/backend/config"
+	"github.com/lesprivate/backend/external/exchangerate"
+	"github.com/lesprivate/backend/external/meeting"
 	xenditext "github.com/lesprivate/backend/external/xendit"
 	"github.com/lesprivate/backend/infras"
 	v1 "github.com/lesprivate/backend/internal/handlers/v1"
@@ -38,11 +40,17 @@ var handlers = wire.NewSet(
 
 var external = wire.NewSet(
 	xenditext.NewClient,
+	xenditext.NewRefunder,
+	xenditext.NewDisburser,
+	exchangerate.NewProvider,
+	meeting.NewProvider,
 )
 
 var svc = wire.NewSet(
 	services.NewCourseService,
 	services.NewCourseDraftService,
+	services.NewCourseVersionService,
+	services.NewCourseModerationService,
 	services.NewLocationService,
 	services.NewCourseCategoryService,
 	services.NewSubCourseCategoryService,
@@ -57,9 +65,18 @@ var svc = wire.NewSet(
 	services.NewNotificationService,
 	services.NewStudentReviewService,
 	services.NewTutorReviewService,
+	services.NewReviewService,
+	services.NewTutorLevelService,
+	services.NewBookingEventService,
 	services.NewCourseViewService,
 	services.NewStudentSubscriptionService,
+	services.NewGuardianService,
+	services.NewGuardianSubscriptionService,
 	services.NewSubscriptionPriceService,
+	services.NewEntitlementService,
+	services.NewSubscriptionLifecycleService,
+	services.NewPaymentRefundService,
+	services.NewInvoiceService,
 	services.NewWebhookService,
 	services.NewStudentService,
 	services.NewTutorService,
@@ -70,7 +87,20 @@ var svc = wire.NewSet(
 	services.NewMentorStudentService,
 	services.NewMentorBalanceService,
 	services.NewMentorBalanceAdminService,
+	services.NewMentorBankAccountService,
+	services.NewPayoutService,
+	services.NewFinanceReportService,
+	services.NewCurrencyService,
 	services.NewSessionTaskService,
+	services.NewTaskLibraryService,
+	services.NewStudentProgressService,
+	services.NewBookingAttendanceService,
+	services.NewMeetingService,
+	services.NewConversationService,
+	services.NewMentorGroupService,
+	services.NewGroupSessionService,
+	services.NewLearningPlanService,
+	services.NewQuizService,
 	services.NewMonthlyReportService,
 	provideXendit,
 )
@@ -78,10 +108,14 @@ var svc = wire.NewSet(
 var repo = wire.NewSet(
 	repositories.NewCourseRepository,
 	repositories.NewCourseDraftRepository,
+	repositories.NewCourseVersionRepository,
+	repositories.NewCourseRejectionReasonRepository,
 	repositories.NewLocationRepository,
 	repositories.NewCourseCategoryRepository,
 	repositories.NewSubCourseCategoryRepository,
 	repositories.NewTutorRepository,
+	repositories.NewTutorLevelRepository,
+	repositories.NewBookingEventRepository,
 	repositories.NewStudentRepository,
 	repositories.NewLookupRepository,
 	repositories.NewUserRepository,
@@ -93,11 +127,31 @@ var repo = wire.NewSet(
 	repositories.NewReviewRepository,
 	repositories.NewSubscriptionRepository,
 	repositories.NewSubscriptionPriceRepository,
+	repositories.NewPlanEntitlementRepository,
 	repositories.NewPaymentRepository,
+	repositories.NewGuardianRepository,
+	repositories.NewMonthlyReportRepository,
+	repositories.NewTaskLibraryRepository,
+	repositories.NewStudentProgressRepository,
+	repositories.NewBookingAttendanceRepository,
+	repositories.NewBookingMeetingRepository,
+	repositories.NewConversationRepository,
+	repositories.NewMentorGroupRepository,
+	repositories.NewGroupSessionRepository,
+	repositories.NewLearningPlanRepository,
+	repositories.NewQuizRepository,
+	repositories.NewGiftCodeRepository,
+	repositories.NewPaymentRefundRepository,
+	repositories.NewInvoiceRepository,
+	repositories.NewTaxRateRepository,
 	repositories.NewCourseViewRepository,
 	repositories.NewMentorStudentRepository,
 	repositories.NewMentorBalanceRepository,
 	repositories.NewWithdrawalRepository,
+	repositories.NewMentorBankAccountRepository,
+	repositories.NewPayoutBatchRepository,
+	repositories.NewFinanceReportRepository,
+	repositories.NewExchangeRateRepository,
 	repositories.NewMentorInviteCodeRepository,
 	repositories.NewSessionTaskRepository,
 )